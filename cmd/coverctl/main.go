@@ -7,6 +7,5 @@ import (
 )
 
 func main() {
-	code := cli.Run(os.Args, os.Stdout, os.Stderr, cli.BuildService(os.Stdout))
-	os.Exit(code)
+	os.Exit(cli.Main())
 }
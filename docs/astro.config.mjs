@@ -114,6 +114,7 @@ export default defineConfig({
             { label: 'Advanced', slug: 'configuration/advanced' },
             { label: 'CI integration', slug: 'guides/ci-integration' },
             { label: 'Monorepos', slug: 'guides/monorepo' },
+            { label: 'Go library', slug: 'guides/library' },
           ],
         },
         {
@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Aggregate sums profile coverage into the configured domains without
+// evaluating thresholds. Files are normalized, excluded, and attributed to
+// domains exactly as report does, so the stats match its domain rows.
+func (s *Service) Aggregate(ctx context.Context, opts AggregateOptions) (map[string]domain.CoverageStat, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	domains = filterDomainsByNames(domains, opts.Domains)
+	if len(domains) == 0 {
+		return nil, errNoMatchingDomains(opts.Domains)
+	}
+
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	profiles = append(profiles, opts.MergeProfiles...)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		return nil, err
+	}
+	return covCtx.DomainCoverage, nil
+}
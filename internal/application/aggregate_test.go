@@ -1,6 +1,8 @@
 package application
 
 import (
+	"context"
+	"io"
 	"path/filepath"
 	"testing"
 
@@ -140,3 +142,37 @@ func TestBuildDomainExcludes(t *testing.T) {
 		t.Fatalf("unexpected core excludes: %+v", excludes)
 	}
 }
+
+func TestServiceAggregate(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+		Exclude: []string{"internal/core/gen.go"},
+	}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}, "api": {"/repo/internal/api"}}, moduleRoot: "/repo", modulePath: "example.com/mod"},
+		ProfileParser: fakeParser{stats: map[string]domain.CoverageStat{
+			"internal/core/a.go":   {Covered: 2, Total: 10},
+			"internal/core/gen.go": {Covered: 0, Total: 50},
+			"internal/api/h.go":    {Covered: 3, Total: 4},
+		}},
+		Out: io.Discard,
+	}
+
+	got, err := svc.Aggregate(context.Background(), AggregateOptions{ProfilePath: "c.out"})
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if got["core"] != (domain.CoverageStat{Covered: 2, Total: 10}) || got["api"] != (domain.CoverageStat{Covered: 3, Total: 4}) {
+		t.Fatalf("unexpected domain coverage: %+v", got)
+	}
+
+	if _, err := svc.Aggregate(context.Background(), AggregateOptions{Domains: []string{"missing"}}); err == nil {
+		t.Fatal("expected error for unknown domain")
+	}
+}
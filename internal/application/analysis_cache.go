@@ -0,0 +1,138 @@
+package application
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// analysisCacheVersion is bumped whenever coverageContext or the way it is
+// prepared changes, so older entries are never read back.
+const analysisCacheVersion = "coverage-context/2"
+
+// analysisCacheKey digests the inputs prepareCoverage reads up front: the
+// working directory, config and domains, the stale-entry mode, and each
+// profile's path, size, mtime, and content hash. The source tree it also
+// depends on is checked separately, through the entry's sourceStamps. ok
+// is false when a profile cannot be read, leaving the error to the
+// uncached path.
+func analysisCacheKey(cfg Config, domains []domain.Domain, profiles []string, pruneStale bool) (string, bool) {
+	h := sha256.New()
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	inputs, err := json.Marshal(struct {
+		Version    string
+		Dir        string
+		Config     Config
+		Domains    []domain.Domain
+		PruneStale bool
+	}{analysisCacheVersion, cwd, cfg, domains, pruneStale})
+	if err != nil {
+		return "", false
+	}
+	_, _ = h.Write(inputs)
+	for _, profile := range profiles {
+		if !profileDigest(h, profile) {
+			return "", false
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+func profileDigest(w io.Writer, path string) bool {
+	f, err := os.Open(path) // #nosec G304 - profile path comes from the user's own flags or config
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	fmt.Fprintf(w, "\x00%s\x00%d\x00%d\x00", path, info.Size(), info.ModTime().UnixNano())
+	_, err = io.Copy(w, f)
+	return err == nil
+}
+
+// cachedCoverage is a cache entry: the prepared coverage and the sources it
+// was prepared from.
+type cachedCoverage struct {
+	Context coverageContext
+	Sources []sourceStamp
+}
+
+// sourceStamp records a source path's modification time, or -1 when it was
+// missing. coverctl:ignore pragmas, exclude.functions scans, stale-entry
+// detection, and domain resolution all read the source tree, so an entry
+// is only reused while every stamp still holds.
+type sourceStamp struct {
+	Path    string
+	ModTime int64
+}
+
+// coverageSources lists the paths a prepared coverage context depends on:
+// go.mod, every profiled source file under moduleRoot, and each resolved
+// domain directory with its parent, whose mtime changes when a sibling
+// directory that a pattern could match appears.
+func coverageSources(moduleRoot string, coverage map[string]domain.CoverageStat, domainDirs map[string][]string) []string {
+	seen := map[string]bool{}
+	var paths []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	add(filepath.Join(moduleRoot, "go.mod"))
+	for file := range coverage {
+		if filepath.IsAbs(file) || strings.HasPrefix(file, "../") {
+			continue
+		}
+		add(filepath.Join(moduleRoot, filepath.FromSlash(file)))
+	}
+	for _, dirs := range domainDirs {
+		for _, dir := range dirs {
+			add(dir)
+			add(filepath.Dir(dir))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func stampSources(paths []string) []sourceStamp {
+	stamps := make([]sourceStamp, len(paths))
+	for i, path := range paths {
+		stamps[i] = sourceStamp{Path: path, ModTime: modTime(path)}
+	}
+	return stamps
+}
+
+// sourcesUnchanged reports whether every stamped path still has its
+// recorded modification time, or is still missing.
+func sourcesUnchanged(stamps []sourceStamp) bool {
+	for _, stamp := range stamps {
+		if modTime(stamp.Path) != stamp.ModTime {
+			return false
+		}
+	}
+	return true
+}
+
+func modTime(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return -1
+	}
+	return info.ModTime().UnixNano()
+}
@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type memoryAnalysisCache struct {
+	entries map[string][]byte
+}
+
+func (m *memoryAnalysisCache) Get(key string, v any) bool {
+	data, ok := m.entries[key]
+	return ok && json.Unmarshal(data, v) == nil
+}
+
+func (m *memoryAnalysisCache) Put(key string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if m.entries == nil {
+		m.entries = map[string][]byte{}
+	}
+	m.entries[key] = data
+}
+
+type countingParser struct {
+	fakeParser
+	calls *int
+}
+
+func (c countingParser) ParseAll(paths []string) (map[string]domain.CoverageStat, error) {
+	*c.calls++
+	return c.fakeParser.ParseAll(paths)
+}
+
+func TestPrepareCoverageCache(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(profile, []byte("mode: set\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	min := 90.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}}
+	calls := 0
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		ProfileParser:  countingParser{fakeParser: fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}}, calls: &calls},
+		Cache:          &memoryAnalysisCache{},
+	}
+	opts := DebtOptions{ConfigPath: ".coverctl.yaml", ProfilePath: profile}
+
+	first, err := svc.Debt(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("debt: %v", err)
+	}
+	second, err := svc.Debt(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("debt: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second call to hit the cache, parsed %d times", calls)
+	}
+	if len(second.Items) != 1 || !reflect.DeepEqual(second.Items[0], first.Items[0]) {
+		t.Fatalf("cached result differs: %+v vs %+v", second, first)
+	}
+
+	if err := os.WriteFile(profile, []byte("mode: atomic\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Debt(context.Background(), opts); err != nil {
+		t.Fatalf("debt: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected a changed profile to miss the cache, parsed %d times", calls)
+	}
+}
+
+func TestPrepareCoverageCacheSourceChanges(t *testing.T) {
+	root := t.TempDir()
+	profile := filepath.Join(t.TempDir(), "coverage.out")
+	source := filepath.Join(root, "internal", "core", "a.go")
+	for path, data := range map[string]string{
+		profile:                       "mode: set\n",
+		filepath.Join(root, "go.mod"): "module github.com/felixgeelhaar/coverctl\n",
+		source:                        "package core\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	calls := 0
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {filepath.Join(root, "internal", "core")}}, moduleRoot: root, modulePath: "github.com/felixgeelhaar/coverctl"},
+		ProfileParser:  countingParser{fakeParser: fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}}, calls: &calls},
+		Cache:          &memoryAnalysisCache{},
+	}
+	opts := DebtOptions{ConfigPath: ".coverctl.yaml", ProfilePath: profile}
+	debt := func() {
+		t.Helper()
+		if _, err := svc.Debt(context.Background(), opts); err != nil {
+			t.Fatalf("debt: %v", err)
+		}
+	}
+
+	debt()
+	debt()
+	if calls != 1 {
+		t.Fatalf("expected an unchanged tree to hit the cache, parsed %d times", calls)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(source, later, later); err != nil {
+		t.Fatal(err)
+	}
+	debt()
+	if calls != 2 {
+		t.Fatalf("expected a changed source file to miss the cache, parsed %d times", calls)
+	}
+	if err := os.Remove(filepath.Join(root, "go.mod")); err != nil {
+		t.Fatal(err)
+	}
+	debt()
+	if calls != 3 {
+		t.Fatalf("expected a removed go.mod to miss the cache, parsed %d times", calls)
+	}
+}
+
+func TestAnalysisCacheKey(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(profile, []byte("mode: set\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Version: 1}
+	key, ok := analysisCacheKey(cfg, nil, []string{profile}, false)
+	if !ok || key == "" {
+		t.Fatal("expected a key for a readable profile")
+	}
+	if again, _ := analysisCacheKey(cfg, nil, []string{profile}, false); again != key {
+		t.Fatal("expected the same inputs to give the same key")
+	}
+	if pruned, _ := analysisCacheKey(cfg, nil, []string{profile}, true); pruned == key {
+		t.Fatal("expected --prune-stale to change the key")
+	}
+	cfg.Exclude = []string{"gen/**"}
+	if changed, _ := analysisCacheKey(cfg, nil, []string{profile}, false); changed == key {
+		t.Fatal("expected a config change to change the key")
+	}
+	if _, ok := analysisCacheKey(cfg, nil, []string{filepath.Join(t.TempDir(), "missing.out")}, false); ok {
+		t.Fatal("expected no key for a missing profile")
+	}
+}
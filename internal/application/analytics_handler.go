@@ -31,18 +31,9 @@ func (h *AnalyticsHandler) Badge(ctx context.Context, opts BadgeOptions) (BadgeR
 		return BadgeResult{}, err
 	}
 
-	var totalCovered, totalStatements int
-	for _, stat := range covCtx.DomainCoverage {
-		totalCovered += stat.Covered
-		totalStatements += stat.Total
-	}
-
-	percent := 0.0
-	if totalStatements > 0 {
-		percent = domain.Round1((float64(totalCovered) / float64(totalStatements)) * 100)
-	}
+	percent := domain.WeightedOverall(covCtx.DomainCoverage, domains)
 
-	return BadgeResult{Percent: percent}, nil
+	return BadgeResult{Percent: percent, Delta: badgeDelta(percent, opts.HistoryStore)}, nil
 }
 
 // Trend analyzes coverage trends over time.
@@ -77,13 +68,17 @@ func (h *AnalyticsHandler) Trend(ctx context.Context, opts TrendOptions, store H
 		return TrendResult{}, err
 	}
 
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	normalizedCoverage, err = excludeFunctions(ctx, h.ProfileParser, h.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return TrendResult{}, err
+	}
 	annotations, err := loadAnnotations(ctx, h.AnnotationScanner, cfg, moduleRoot, normalizedCoverage)
 	if err != nil {
 		return TrendResult{}, err
 	}
 
-	domainDirs, err := h.DomainResolver.Resolve(ctx, domains)
+	domainDirs, err := resolveDomainDirs(ctx, h.DomainResolver, domains, moduleRoot, cfg.Merge)
 	if err != nil {
 		return TrendResult{}, err
 	}
@@ -91,15 +86,7 @@ func (h *AnalyticsHandler) Trend(ctx context.Context, opts TrendOptions, store H
 	domainExcludes := buildDomainExcludes(domains)
 	domainCoverage := AggregateByDomainWithExcludes(normalizedCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
 
-	var totalCovered, totalStatements int
-	for _, stat := range domainCoverage {
-		totalCovered += stat.Covered
-		totalStatements += stat.Total
-	}
-	currentPercent := 0.0
-	if totalStatements > 0 {
-		currentPercent = domain.Round1((float64(totalCovered) / float64(totalStatements)) * 100)
-	}
+	currentPercent := domain.WeightedOverall(domainCoverage, domains)
 
 	latest := history.LatestEntry()
 	previousPercent := latest.Overall
@@ -140,6 +127,11 @@ func (h *AnalyticsHandler) Suggest(ctx context.Context, opts SuggestOptions) (Su
 		return SuggestResult{}, err
 	}
 
+	hist, err := loadSuggestHistory(opts)
+	if err != nil {
+		return SuggestResult{}, err
+	}
+
 	suggestions := make([]Suggestion, 0, len(domains))
 	for i, d := range domains {
 		stat := covCtx.DomainCoverage[d.Name]
@@ -153,7 +145,7 @@ func (h *AnalyticsHandler) Suggest(ctx context.Context, opts SuggestOptions) (Su
 			currentMin = *d.Min
 		}
 
-		suggestedMin, reason := calculateSuggestion(currentPercent, currentMin, opts.Strategy)
+		suggestedMin, reason := suggestThreshold(opts.Strategy, hist, d.Name, currentPercent, currentMin)
 
 		suggestions = append(suggestions, Suggestion{
 			Domain:         d.Name,
@@ -186,97 +178,7 @@ func (h *AnalyticsHandler) Debt(ctx context.Context, opts DebtOptions) (DebtResu
 		return DebtResult{}, err
 	}
 
-	var items []DebtItem
-	var totalDebt float64
-	var totalLines int
-	var passCount, failCount int
-
-	for _, d := range domains {
-		stat := covCtx.DomainCoverage[d.Name]
-		currentPercent := 0.0
-		if stat.Total > 0 {
-			currentPercent = domain.Round1((float64(stat.Covered) / float64(stat.Total)) * 100)
-		}
-
-		required := cfg.Policy.DefaultMin
-		if d.Min != nil {
-			required = *d.Min
-		}
-
-		if currentPercent < required {
-			shortfall := domain.Round1(required - currentPercent)
-			uncoveredLines := stat.Total - stat.Covered
-			denominator := math.Max(required-currentPercent, 1.0)
-			linesNeededFloat := float64(uncoveredLines) * (shortfall / denominator)
-			linesNeeded := int(math.Min(math.Max(linesNeededFloat, 0), float64(uncoveredLines)))
-
-			items = append(items, DebtItem{
-				Name:      d.Name,
-				Type:      "domain",
-				Current:   currentPercent,
-				Required:  required,
-				Shortfall: shortfall,
-				Lines:     linesNeeded,
-			})
-			totalDebt += shortfall
-			totalLines += linesNeeded
-			failCount++
-		} else {
-			passCount++
-		}
-	}
-
-	for _, rule := range cfg.Files {
-		for file, stat := range covCtx.NormalizedCoverage {
-			if excluded(file, cfg.Exclude) {
-				continue
-			}
-			if ann, ok := covCtx.Annotations[file]; ok && ann.Ignore {
-				continue
-			}
-			if matchAnyPattern(file, rule.Match) {
-				currentPercent := 0.0
-				if stat.Total > 0 {
-					currentPercent = domain.Round1((float64(stat.Covered) / float64(stat.Total)) * 100)
-				}
-
-				if currentPercent < rule.Min {
-					shortfall := domain.Round1(rule.Min - currentPercent)
-					linesNeeded := stat.Total - stat.Covered
-
-					items = append(items, DebtItem{
-						Name:      file,
-						Type:      "file",
-						Current:   currentPercent,
-						Required:  rule.Min,
-						Shortfall: shortfall,
-						Lines:     linesNeeded,
-					})
-					totalDebt += shortfall
-					totalLines += linesNeeded
-					failCount++
-				} else {
-					passCount++
-				}
-			}
-		}
-	}
-
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Shortfall > items[j].Shortfall
-	})
-
-	healthScore := 100.0
-	if passCount+failCount > 0 {
-		healthScore = domain.Round1((float64(passCount) / float64(passCount+failCount)) * 100)
-	}
-
-	return DebtResult{
-		Items:       items,
-		TotalDebt:   domain.Round1(totalDebt),
-		TotalLines:  totalLines,
-		HealthScore: healthScore,
-	}, nil
+	return computeDebt(cfg, domains, covCtx), nil
 }
 
 // Compare compares coverage between two profiles.
@@ -364,7 +266,7 @@ func (h *AnalyticsHandler) Compare(ctx context.Context, opts CompareOptions) (Co
 	if opts.ConfigPath != "" {
 		cfg, domains, err := loadOrDetectConfig(h.ConfigLoader, h.Autodetector, opts.ConfigPath)
 		if err == nil && len(domains) > 0 {
-			domainDirs, err := h.DomainResolver.Resolve(ctx, domains)
+			domainDirs, err := resolveDomainDirs(ctx, h.DomainResolver, domains, moduleRoot, cfg.Merge)
 			if err == nil {
 				domainExcludes := buildDomainExcludes(domains)
 				annotations := make(map[string]Annotation)
@@ -420,13 +322,17 @@ func (h *AnalyticsHandler) prepareCoverageContext(ctx context.Context, cfg Confi
 		return nil, err
 	}
 
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	normalizedCoverage, err = excludeFunctions(ctx, h.ProfileParser, h.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return nil, err
+	}
 	annotations, err := loadAnnotations(ctx, h.AnnotationScanner, cfg, moduleRoot, normalizedCoverage)
 	if err != nil {
 		return nil, err
 	}
 
-	domainDirs, err := h.DomainResolver.Resolve(ctx, domains)
+	domainDirs, err := resolveDomainDirs(ctx, h.DomainResolver, domains, moduleRoot, cfg.Merge)
 	if err != nil {
 		return nil, err
 	}
@@ -445,6 +351,57 @@ func (h *AnalyticsHandler) prepareCoverageContext(ctx context.Context, cfg Confi
 	}, nil
 }
 
+// History-driven suggestions look at the most recent historySuggestWindow
+// entries per domain and fall back to the current strategy when fewer than
+// historySuggestMinEntries have been recorded.
+const (
+	historySuggestWindow     = 30
+	historySuggestMinEntries = 5
+)
+
+// loadSuggestHistory loads history for strategies that need it.
+func loadSuggestHistory(opts SuggestOptions) (*domain.History, error) {
+	if opts.Strategy != SuggestHistory {
+		return nil, nil
+	}
+	if opts.HistoryStore == nil {
+		return nil, fmt.Errorf("history strategy requires a history file; run 'coverctl record' after coverage runs")
+	}
+	hist, err := opts.HistoryStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load history: %w", err)
+	}
+	return &hist, nil
+}
+
+// suggestThreshold picks the suggestion for a single domain.
+func suggestThreshold(strategy SuggestStrategy, hist *domain.History, domainName string, current, currentMin float64) (float64, string) {
+	if strategy != SuggestHistory || hist == nil {
+		return calculateSuggestion(current, currentMin, strategy)
+	}
+	analysis := domain.NewTrendAnalysisService().AnalyzeDomainWindow(hist, domainName, historySuggestWindow)
+	if analysis.EntriesCount < historySuggestMinEntries {
+		suggested, reason := calculateSuggestion(current, currentMin, SuggestCurrent)
+		return suggested, fmt.Sprintf("only %d history entries; %s", analysis.EntriesCount, reason)
+	}
+	return calculateHistorySuggestion(analysis)
+}
+
+// calculateHistorySuggestion returns a threshold that history has met at
+// least 90% of the time, less a buffer that grows with volatility. When the
+// window spans more than 10 points the percentile is not trusted and the
+// lowest recorded value is used instead.
+func calculateHistorySuggestion(analysis domain.HistoryAnalysisResult) (float64, string) {
+	buffer := 1 + 2*analysis.Volatility()
+	base, basis := analysis.P10.Value(), "p10"
+	if analysis.ConsistencyScore() < 90 {
+		base, basis = analysis.Lowest.Value(), "lowest"
+	}
+	suggested := math.Max(base-buffer, 0)
+	return domain.Round1(suggested), fmt.Sprintf("%s of last %d entries (%.1f%%) minus %.1f%% volatility buffer",
+		basis, analysis.EntriesCount, base, buffer)
+}
+
 func calculateSuggestion(current, currentMin float64, strategy SuggestStrategy) (float64, string) {
 	switch strategy {
 	case SuggestAggressive:
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Annotate adds a coverctl:ignore pragma to each listed file and to every
+// profile file matching one of opts.Match, so legacy or generated code can
+// be excluded in bulk. Files already carrying the pragma are left alone.
+func (s *Service) Annotate(ctx context.Context, opts AnnotateOptions, annotator SourceAnnotator) (AnnotateResult, error) {
+	if len(opts.Files) == 0 && len(opts.Match) == 0 {
+		return AnnotateResult{}, errors.New("annotate needs files or --match patterns")
+	}
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return AnnotateResult{}, err
+	}
+	moduleRoot, err := s.DomainResolver.ModuleRoot(ctx)
+	if err != nil {
+		return AnnotateResult{}, err
+	}
+
+	files := make(map[string]struct{}, len(opts.Files))
+	for _, file := range opts.Files {
+		files[filepath.ToSlash(filepath.Clean(file))] = struct{}{}
+	}
+	if len(opts.Match) > 0 {
+		profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+		covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+		if err != nil {
+			return AnnotateResult{}, err
+		}
+		moduleRoot = covCtx.ModuleRoot
+		for file := range covCtx.NormalizedCoverage {
+			if matchAnyPattern(file, opts.Match) {
+				files[file] = struct{}{}
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		if strings.HasPrefix(file, "../") || filepath.IsAbs(file) {
+			return AnnotateResult{}, fmt.Errorf("annotate only edits files inside the module: %s", file)
+		}
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+
+	result := AnnotateResult{DryRun: opts.DryRun, Enabled: cfg.Annotations.Enabled}
+	for _, file := range paths {
+		annotated, err := annotator.Annotate(moduleRoot, file, opts.DryRun)
+		if err != nil {
+			return AnnotateResult{}, err
+		}
+		result.Files = append(result.Files, annotated)
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeAnnotator struct {
+	files *[]string
+}
+
+func (f fakeAnnotator) Annotate(moduleRoot, file string, dryRun bool) (AnnotatedFile, error) {
+	*f.files = append(*f.files, file)
+	return AnnotatedFile{File: file, Status: AnnotateAdded}, nil
+}
+
+func TestServiceAnnotate(t *testing.T) {
+	cfg := Config{
+		Version:     1,
+		Policy:      domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/..."}}}},
+		Annotations: AnnotationsConfig{Enabled: true},
+	}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal"}}, moduleRoot: "/repo", modulePath: "example.com/mod"},
+		ProfileParser: fakeParser{stats: map[string]domain.CoverageStat{
+			"internal/gen/a.go":  {Covered: 0, Total: 4},
+			"internal/gen/b.go":  {Covered: 1, Total: 4},
+			"internal/core/c.go": {Covered: 2, Total: 4},
+		}},
+		Out: io.Discard,
+	}
+
+	var files []string
+	result, err := svc.Annotate(context.Background(), AnnotateOptions{
+		Files: []string{"./internal/legacy/old.go", "internal/gen/a.go"},
+		Match: []string{"internal/gen/*.go"},
+	}, fakeAnnotator{files: &files})
+	if err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+	want := []string{"internal/gen/a.go", "internal/gen/b.go", "internal/legacy/old.go"}
+	if !reflect.DeepEqual(files, want) {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+	if !result.Enabled || len(result.Files) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if _, err := svc.Annotate(context.Background(), AnnotateOptions{}, fakeAnnotator{files: &files}); err == nil {
+		t.Fatal("expected error without files or patterns")
+	}
+	if _, err := svc.Annotate(context.Background(), AnnotateOptions{Files: []string{"../other/x.go"}}, fakeAnnotator{files: &files}); err == nil {
+		t.Fatal("expected error for a file outside the module")
+	}
+}
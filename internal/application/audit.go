@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// AuditConfig appends an entry to the audit log when the thresholds in
+// opts.ConfigPath differ from the ones the log last recorded for it. The
+// config hash is compared first, so an unchanged file is not reparsed. The
+// first entry for a config lists every threshold as added. It returns the
+// appended entry, or nil when nothing changed or the config does not exist.
+func (s *Service) AuditConfig(ctx context.Context, opts AuditOptions, log AuditLog) (*AuditEntry, error) {
+	path := filepath.ToSlash(filepath.Clean(opts.ConfigPath))
+	hash, err := configSHA256(opts.ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries, err := log.Load()
+	if err != nil {
+		return nil, err
+	}
+	var last *AuditEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Config == path {
+			last = &entries[i]
+			break
+		}
+	}
+	if last != nil && last.ConfigHash == hash {
+		return nil, nil
+	}
+
+	cfg, err := s.ConfigLoader.Load(opts.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	thresholds := thresholdSnapshot(cfg)
+	var previous map[string]float64
+	if last != nil {
+		previous = last.Thresholds
+	}
+	changes := diffThresholds(previous, thresholds)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	actor := opts.Actor
+	if actor == "" {
+		if provider, ok := s.DiffProvider.(ActorProvider); ok {
+			actor = provider.Actor(ctx)
+		}
+	}
+	entry := AuditEntry{
+		Timestamp:  timeNow().UTC(),
+		Actor:      actor,
+		Source:     opts.Source,
+		Config:     path,
+		ConfigHash: hash,
+		Changes:    changes,
+		Thresholds: thresholds,
+	}
+	if err := log.Append(entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func configSHA256(path string) (string, error) {
+	h := sha256.New()
+	if err := hashFile(h, path); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// thresholdSnapshot flattens every minimum and warning threshold in cfg,
+// keyed by the subjects diff-config uses. Domain minimums are recorded as
+// enforced, so a default change shows up on the domains inheriting it.
+func thresholdSnapshot(cfg Config) map[string]float64 {
+	out := map[string]float64{"default min": cfg.Policy.DefaultMin}
+	if min := newCodeMin(cfg.NewCode); min != nil {
+		out["new code min"] = *min
+	}
+	if cfg.Functions.Min != nil {
+		out["functions min"] = *cfg.Functions.Min
+	}
+	for _, d := range cfg.Policy.Domains {
+		out["domain "+d.Name+" min"] = d.MinThreshold(cfg.Policy.DefaultMin)
+		if d.Warn != nil {
+			out["domain "+d.Name+" warn"] = *d.Warn
+		}
+	}
+	for name, min := range groupsByName(cfg.Policy.Groups) {
+		if min != nil {
+			out["group "+name+" min"] = *min
+		}
+	}
+	for match, min := range fileRulesByMatch(cfg.Files) {
+		out["file rule "+match+" min"] = *min
+	}
+	return out
+}
+
+// diffThresholds lists the thresholds added, removed, raised, or lowered
+// from before to after, in subject order.
+func diffThresholds(before, after map[string]float64) []ConfigChange {
+	var changes []ConfigChange
+	for _, subject := range unionKeys(before, after) {
+		var b, a *float64
+		if v, ok := before[subject]; ok {
+			b = &v
+		}
+		if v, ok := after[subject]; ok {
+			a = &v
+		}
+		changes = appendThreshold(changes, subject, b, a)
+	}
+	return changes
+}
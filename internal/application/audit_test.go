@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type memoryAuditLog struct {
+	entries []AuditEntry
+}
+
+func (m *memoryAuditLog) Load() ([]AuditEntry, error) { return m.entries, nil }
+
+func (m *memoryAuditLog) Append(entry AuditEntry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+type fakeActorProvider struct {
+	fakeDiffProvider
+	actor string
+}
+
+func (f fakeActorProvider) Actor(context.Context) string { return f.actor }
+
+func TestAuditConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	min := func(v float64) *float64 { return &v }
+	cfg := Config{Policy: domain.Policy{DefaultMin: 70, Domains: []domain.Domain{{Name: "core", Min: min(80)}}}}
+	svc := &Service{ConfigLoader: fakeConfigLoader{exists: true, cfg: cfg}, DiffProvider: fakeActorProvider{actor: "alice"}}
+	log := &memoryAuditLog{}
+	ctx := context.Background()
+
+	writeConfig("v1")
+	entry, err := svc.AuditConfig(ctx, AuditOptions{ConfigPath: path, Source: AuditManual}, log)
+	if err != nil || entry == nil {
+		t.Fatalf("expected a first entry, got %v, %v", entry, err)
+	}
+	if entry.Actor != "alice" || len(entry.Changes) != 2 || entry.Changes[0].Kind != ConfigAdded {
+		t.Fatalf("expected every threshold as added, got %+v", entry)
+	}
+
+	// Same file: nothing to record.
+	if entry, err := svc.AuditConfig(ctx, AuditOptions{ConfigPath: path, Source: AuditManual}, log); entry != nil || err != nil {
+		t.Fatalf("expected no entry for an unchanged config, got %+v, %v", entry, err)
+	}
+
+	// Edited without changing a threshold: nothing to record.
+	writeConfig("v1 # comment")
+	if entry, _ := svc.AuditConfig(ctx, AuditOptions{ConfigPath: path, Source: AuditManual}, log); entry != nil {
+		t.Fatalf("expected no entry for a cosmetic edit, got %+v", entry)
+	}
+
+	writeConfig("v2")
+	cfg.Policy.Domains[0].Min = min(82)
+	svc.ConfigLoader = fakeConfigLoader{exists: true, cfg: cfg}
+	entry, err = svc.AuditConfig(ctx, AuditOptions{ConfigPath: path, Source: AuditRatchetUp, Actor: "ci"}, log)
+	if err != nil || entry == nil {
+		t.Fatalf("expected a ratchet entry, got %v, %v", entry, err)
+	}
+	want := ConfigChange{Kind: ConfigRaised, Subject: "domain core min", Before: "80.0%", After: "82.0%"}
+	if entry.Actor != "ci" || entry.Source != AuditRatchetUp || len(entry.Changes) != 1 || entry.Changes[0] != want {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if len(log.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(log.entries))
+	}
+
+	if entry, err := svc.AuditConfig(ctx, AuditOptions{ConfigPath: filepath.Join(t.TempDir(), "missing.yaml")}, log); entry != nil || err != nil {
+		t.Fatalf("expected a missing config to be skipped, got %+v, %v", entry, err)
+	}
+}
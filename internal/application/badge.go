@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// BadgeResult contains the data needed to generate a coverage badge.
+type BadgeResult struct {
+	Percent float64
+	Delta   *float64 // Change since the latest history entry; nil without history
+}
+
+// Badge calculates overall coverage for badge generation.
+func (s *Service) Badge(ctx context.Context, opts BadgeOptions) (BadgeResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return BadgeResult{}, err
+	}
+
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		return BadgeResult{}, err
+	}
+
+	// Calculate overall coverage across all domains
+	percent := domain.WeightedOverall(covCtx.DomainCoverage, domains)
+
+	return BadgeResult{Percent: percent, Delta: badgeDelta(percent, opts.HistoryStore)}, nil
+}
+
+// badgeDelta is the change of percent from the overall coverage of the
+// latest history entry. Like --show-delta elsewhere, an unreadable or empty
+// history yields no delta rather than an error.
+func badgeDelta(percent float64, store HistoryStore) *float64 {
+	if store == nil {
+		return nil
+	}
+	history, err := store.Load()
+	if err != nil {
+		return nil
+	}
+	latest := history.LatestEntry()
+	if latest == nil {
+		return nil
+	}
+	delta := domain.Round1(percent - latest.Overall)
+	return &delta
+}
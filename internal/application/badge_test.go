@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestBadgeDelta(t *testing.T) {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo"},
+		ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 84, Total: 100}}},
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{
+		{Timestamp: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Overall: 83.4},
+		{Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Overall: 90},
+	}}}
+
+	result, err := svc.Badge(context.Background(), BadgeOptions{ProfilePath: "coverage.out", HistoryStore: store})
+	if err != nil {
+		t.Fatalf("badge: %v", err)
+	}
+	if result.Percent != 84 || result.Delta == nil || *result.Delta != 0.6 {
+		t.Fatalf("expected 84%% with a +0.6 delta from the latest entry, got %+v", result)
+	}
+
+	result, err = svc.Badge(context.Background(), BadgeOptions{ProfilePath: "coverage.out", HistoryStore: &memoryHistoryStore{}})
+	if err != nil {
+		t.Fatalf("badge: %v", err)
+	}
+	if result.Delta != nil {
+		t.Fatalf("expected no delta without history, got %v", *result.Delta)
+	}
+}
+
+func TestBadgeWeighted(t *testing.T) {
+	weight := 3.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{
+		{Name: "core", Match: []string{"./internal/core/..."}, Weight: &weight},
+		{Name: "tools", Match: []string{"./tools/..."}},
+	}}}
+	svc := &Service{
+		ConfigLoader: fakeConfigLoader{exists: true, cfg: cfg},
+		DomainResolver: fakeResolver{dirs: map[string][]string{
+			"core":  {"/repo/internal/core"},
+			"tools": {"/repo/tools"},
+		}, moduleRoot: "/repo"},
+		ProfileParser: fakeParser{stats: map[string]domain.CoverageStat{
+			"internal/core/a.go": {Covered: 90, Total: 100},
+			"tools/gen.go":       {Covered: 10, Total: 100},
+		}},
+	}
+
+	result, err := svc.Badge(context.Background(), BadgeOptions{ProfilePath: "coverage.out"})
+	if err != nil {
+		t.Fatalf("badge: %v", err)
+	}
+	// (3*90 + 10) / (3*100 + 100): core counts three times as much as tools.
+	if result.Percent != 70 {
+		t.Fatalf("expected a weighted 70%%, got %v", result.Percent)
+	}
+}
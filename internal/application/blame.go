@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Blame cross-references uncovered lines with git blame and totals them per
+// author and per commit, so teams can see where untested code came from.
+// Files are selected the same way Uncovered selects them.
+func (s *Service) Blame(ctx context.Context, opts BlameOptions) (BlameResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return BlameResult{}, err
+	}
+	domains = filterDomainsByNames(domains, opts.Domains)
+	if len(domains) == 0 {
+		return BlameResult{}, errNoMatchingDomains(opts.Domains)
+	}
+	blamer, ok := s.DiffProvider.(LineBlameProvider)
+	if !ok {
+		return BlameResult{}, errors.New("blame needs a git diff provider that can report line authors")
+	}
+
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		return BlameResult{}, err
+	}
+	lines, ok, err := loadLineCoverage(s.ProfileParser, profiles, cfg.Exclude, covCtx.ModuleRoot, covCtx.ModulePath, cfg.Merge)
+	if err != nil {
+		return BlameResult{}, err
+	}
+	if !ok {
+		return BlameResult{}, errors.New("blame needs line-level coverage, which the profile parser cannot report")
+	}
+
+	uncovered := make(map[string]domain.LineCoverage)
+	var files []string
+	for file, cov := range lines {
+		if len(cov.UncoveredRanges()) == 0 {
+			continue
+		}
+		owners, ignored := fileDomains(file, covCtx)
+		if ignored || (len(opts.Domains) > 0 && !inAnyDomain(owners, covCtx.DomainDirs)) {
+			continue
+		}
+		uncovered[file] = cov
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+	blame, err := blamer.BlameLines(ctx, files)
+	if err != nil {
+		return BlameResult{}, err
+	}
+	summary := domain.SummarizeBlame(uncovered, blame)
+	result := BlameResult{
+		BlameSummary: summary,
+		TotalAuthors: len(summary.Authors),
+		TotalCommits: len(summary.Commits),
+	}
+	if opts.Limit > 0 {
+		result.Authors = result.Authors[:min(opts.Limit, len(result.Authors))]
+		result.Commits = result.Commits[:min(opts.Limit, len(result.Commits))]
+	}
+	return result, nil
+}
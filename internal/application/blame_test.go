@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeBlameProvider struct {
+	fakeDiffProvider
+	blame map[string]map[int]domain.BlameLine
+	files *[]string
+}
+
+func (f fakeBlameProvider) BlameLines(ctx context.Context, files []string) (map[string]map[int]domain.BlameLine, error) {
+	*f.files = files
+	return f.blame, f.err
+}
+
+func TestServiceBlame(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+		Exclude: []string{"internal/core/gen.go"},
+	}
+	parser := fakeLineParser{
+		fakeParser: fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 1, Total: 3}}},
+		lines: map[string]domain.LineCoverage{
+			"example.com/mod/internal/core/a.go":   {1: 0, 2: 0, 3: 1},
+			"example.com/mod/internal/core/gen.go": {1: 0},
+			"example.com/mod/internal/api/h.go":    {1: 0},
+			"example.com/mod/internal/api/ok.go":   {1: 2},
+		},
+	}
+	alice := domain.BlameLine{Commit: "a1", Author: "Alice", Email: "alice@example.com"}
+	bob := domain.BlameLine{Commit: "b1", Author: "Bob", Email: "bob@example.com"}
+	var blamed []string
+	allDirs := map[string][]string{"core": {"/repo/internal/core"}, "api": {"/repo/internal/api"}}
+	newService := func(diff DiffProvider, dirs map[string][]string) *Service {
+		return &Service{
+			ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+			Autodetector:   fakeAutodetector{},
+			DomainResolver: fakeResolver{dirs: dirs, moduleRoot: "/repo", modulePath: "example.com/mod"},
+			ProfileParser:  parser,
+			DiffProvider:   diff,
+			Out:            io.Discard,
+		}
+	}
+	blamer := fakeBlameProvider{
+		blame: map[string]map[int]domain.BlameLine{
+			"internal/core/a.go": {1: alice, 2: alice, 3: bob},
+			"internal/api/h.go":  {1: bob},
+		},
+		files: &blamed,
+	}
+
+	t.Run("totals uncovered lines per author", func(t *testing.T) {
+		got, err := newService(blamer, allDirs).Blame(context.Background(), BlameOptions{ConfigPath: ".coverctl.yaml", ProfilePath: "c.out"})
+		if err != nil {
+			t.Fatalf("blame: %v", err)
+		}
+		if !slices.Equal(blamed, []string{"internal/api/h.go", "internal/core/a.go"}) {
+			t.Fatalf("expected excluded and covered files skipped, blamed %v", blamed)
+		}
+		if got.Uncovered != 3 || got.TotalAuthors != 2 || got.Authors[0].Author != "Alice" || got.Authors[0].Uncovered != 2 {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("filters domains and limits lists", func(t *testing.T) {
+		got, err := newService(blamer, map[string][]string{"api": allDirs["api"]}).Blame(context.Background(), BlameOptions{ConfigPath: ".coverctl.yaml", ProfilePath: "c.out", Domains: []string{"api"}, Limit: 1})
+		if err != nil {
+			t.Fatalf("blame: %v", err)
+		}
+		if got.Uncovered != 1 || len(got.Authors) != 1 || got.Authors[0].Author != "Bob" {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("requires a blame-capable diff provider", func(t *testing.T) {
+		if _, err := newService(fakeDiffProvider{}, allDirs).Blame(context.Background(), BlameOptions{ConfigPath: ".coverctl.yaml"}); err == nil {
+			t.Fatal("expected error without blame support")
+		}
+	})
+}
@@ -27,14 +27,16 @@ func (h *CheckHandler) CheckResult(ctx context.Context, opts CheckOptions) (doma
 	if err != nil {
 		return domain.Result{}, err
 	}
+	cfg.Diff = overrideDiffBase(cfg.Diff, opts.DiffBase)
 
 	domains = filterDomainsByNames(domains, opts.Domains)
 	if len(domains) == 0 {
-		return domain.Result{}, fmt.Errorf("no matching domains found for: %v", opts.Domains)
+		return domain.Result{}, errNoMatchingDomains(opts.Domains)
 	}
 
 	var profiles []string
 	var fromProfileWarnings []string
+	sharedRun := false // profiles[0] is the shared test run
 	if opts.FromProfile {
 		if opts.Profile == "" {
 			return domain.Result{}, fmt.Errorf("profile path is required when using --from-profile")
@@ -50,7 +52,7 @@ func (h *CheckHandler) CheckResult(ctx context.Context, opts CheckOptions) (doma
 			profiles = append(profiles, cfg.Merge.Profiles...)
 		}
 	} else {
-		runner, err := selectRunner(h.RunnerRegistry, h.CoverageRunner, opts.Language, cfg.Language)
+		runner, err := selectRunner(h.RunnerRegistry, h.CoverageRunner, opts.Runner, cfg.Runner, opts.Language, cfg.Language)
 		if err != nil {
 			return domain.Result{}, err
 		}
@@ -86,16 +88,22 @@ func (h *CheckHandler) CheckResult(ctx context.Context, opts CheckOptions) (doma
 			}
 		}
 
-		profile, err := runner.Run(ctx, RunOptions{
+		sharedProfile, domainProfiles, err := runDomainTests(ctx, runner, commandRunnerOf(h.RunnerRegistry, h.CoverageRunner), RunOptions{
 			Domains:     domains,
 			ProfilePath: opts.Profile,
 			BuildFlags:  opts.BuildFlags,
 			Packages:    packages,
-		})
+			Retry:       cfg.Retry,
+			CoverPkg:    cfg.CoverPkg,
+			Matrix:      cfg.Matrix,
+		}, nil)
 		if err != nil {
 			return domain.Result{}, err
 		}
-		profiles = append(profiles, profile)
+		if sharedProfile != "" {
+			profiles = append(profiles, sharedProfile)
+			sharedRun = true
+		}
 
 		if cfg.Integration.Enabled {
 			integrationProfile, err := runner.RunIntegration(ctx, IntegrationOptions{
@@ -105,12 +113,14 @@ func (h *CheckHandler) CheckResult(ctx context.Context, opts CheckOptions) (doma
 				CoverDir:   cfg.Integration.CoverDir,
 				Profile:    cfg.Integration.Profile,
 				BuildFlags: opts.BuildFlags,
+				CoverPkg:   cfg.CoverPkg,
 			})
 			if err != nil {
-				return domain.Result{}, err
+				return domain.Result{}, WithErrorCode(ErrCodeRunnerFailed, err)
 			}
 			profiles = append(profiles, integrationProfile)
 		}
+		profiles = append(profiles, domainProfiles...)
 		if len(cfg.Merge.Profiles) > 0 {
 			profiles = append(profiles, cfg.Merge.Profiles...)
 		}
@@ -131,7 +141,17 @@ func (h *CheckHandler) CheckResult(ctx context.Context, opts CheckOptions) (doma
 		return domain.Result{}, err
 	}
 
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	normalizedCoverage, staleWarnings := sanitizeStaleEntries(normalizedCoverage, moduleRoot, opts.PruneStale)
+	ageWarnings, err := guardProfileAge(h.ProfileParser, cfg.Profile, opts, profiles, normalizedCoverage, moduleRoot)
+	if err != nil {
+		return domain.Result{}, err
+	}
+	fromProfileWarnings = append(fromProfileWarnings, ageWarnings...)
+	normalizedCoverage, err = excludeFunctions(ctx, h.ProfileParser, h.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return domain.Result{}, err
+	}
 	annotations, err := loadAnnotations(ctx, h.AnnotationScanner, cfg, moduleRoot, normalizedCoverage)
 	if err != nil {
 		return domain.Result{}, err
@@ -149,13 +169,16 @@ func (h *CheckHandler) CheckResult(ctx context.Context, opts CheckOptions) (doma
 		return result, nil
 	}
 
-	domainDirs, err := h.DomainResolver.Resolve(ctx, domains)
+	domainDirs, err := resolveDomainDirs(ctx, h.DomainResolver, domains, moduleRoot, cfg.Merge)
 	if err != nil {
 		return domain.Result{}, err
 	}
 
 	domainExcludes := buildDomainExcludes(domains)
 	domainCoverage := AggregateByDomainWithExcludes(filteredCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
+	if err := applyDomainMetrics(h.ProfileParser, cfg.Policy, domains, profiles, newDomainAggregator(cfg, moduleRoot, modulePath, changedFiles, domainDirs, domainExcludes, annotations), domainCoverage); err != nil {
+		return domain.Result{}, err
+	}
 
 	policy := cfg.Policy
 	policy.Domains = domains
@@ -165,15 +188,39 @@ func (h *CheckHandler) CheckResult(ctx context.Context, opts CheckOptions) (doma
 
 	result := domain.Evaluate(policy, domainCoverage)
 	result.Warnings = domainOverlapWarnings(domainDirs)
+	if len(opts.Domains) == 0 {
+		result.Warnings = append(result.Warnings, unmatchedFilesWarning(filteredCoverage, domainDirs, cfg.Exclude, moduleRoot, modulePath, annotations)...)
+	}
+	result.EmptyDomains = emptyDomains(policy.Domains, domainDirs, domainCoverage)
+	result.Warnings = append(result.Warnings, emptyDomainWarnings(result.EmptyDomains)...)
 	if len(fromProfileWarnings) > 0 {
 		result.Warnings = append(result.Warnings, fromProfileWarnings...)
 	}
+	result.Warnings = append(result.Warnings, staleWarnings...)
+	result.Warnings = append(result.Warnings, profileExpansionWarnings(h.ProfileParser, profiles)...)
+	result.Warnings = append(result.Warnings, profileModeWarnings(h.ProfileParser, profiles)...)
 
-	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations)
+	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations, cfg.Policy.Rounding)
 	result.Files = fileResults
 	if !filesPassed {
 		result.Passed = false
 	}
+	applyExceptions(&result, cfg.Exceptions, cfg.Policy.Domains)
+	if err := attachSourceCoverage(h.ProfileParser, profiles, profileSourceLabels(profiles, sharedRun, cfg.Integration.Enabled), newDomainAggregator(cfg, moduleRoot, modulePath, changedFiles, domainDirs, domainExcludes, annotations), &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyPatchCoverage(ctx, h.DiffProvider, h.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyNewCodeCoverage(ctx, h.DiffProvider, h.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyFunctionCoverage(ctx, h.ProfileParser, h.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, filteredCoverage, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := attachLineCoverage(opts.Output, opts.HTML, h.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
 
 	// Apply deltas from history if available
 	if opts.HistoryStore != nil {
@@ -193,26 +240,53 @@ func (h *CheckHandler) RunOnly(ctx context.Context, opts RunOnlyOptions) error {
 		return err
 	}
 
-	runner, err := selectRunner(h.RunnerRegistry, h.CoverageRunner, opts.Language, cfg.Language)
+	runner, err := selectRunner(h.RunnerRegistry, h.CoverageRunner, opts.Runner, cfg.Runner, opts.Language, cfg.Language)
 	if err != nil {
 		return err
 	}
 
 	domains = filterDomainsByNames(domains, opts.Domains)
 	if len(domains) == 0 {
-		return fmt.Errorf("no matching domains found for: %v", opts.Domains)
+		return errNoMatchingDomains(opts.Domains)
 	}
 
-	_, err = runner.Run(ctx, RunOptions{
+	_, _, err = runDomainTests(ctx, runner, commandRunnerOf(h.RunnerRegistry, h.CoverageRunner), RunOptions{
 		Domains:     domains,
 		ProfilePath: opts.Profile,
 		BuildFlags:  opts.BuildFlags,
-	})
+		Retry:       cfg.Retry,
+		CoverPkg:    cfg.CoverPkg,
+		Matrix:      cfg.Matrix,
+	}, nil)
 	return err
 }
 
+// overrideDiffBase enables git diff mode against base when a CLI override is
+// given, leaving the configured diff settings untouched otherwise.
+func overrideDiffBase(cfg DiffConfig, base string) DiffConfig {
+	if base != "" {
+		cfg.Enabled = true
+		cfg.Base = base
+		cfg.FilesFrom = ""
+	}
+	return cfg
+}
+
+// selectDiffProvider swaps in a file-list provider when diff.files_from is
+// set and the configured provider supports it.
+func selectDiffProvider(provider DiffProvider, cfg DiffConfig) DiffProvider {
+	if cfg.FilesFrom == "" || provider == nil {
+		return provider
+	}
+	if lists, ok := provider.(FileListDiffProvider); ok {
+		return lists.FromFileList(cfg.FilesFrom)
+	}
+	return provider
+}
+
 // diffFiles gets changed files from diff provider.
 func diffFiles(ctx context.Context, provider DiffProvider, cfg DiffConfig) (map[string]struct{}, error) {
+	provider = selectDiffProvider(provider, cfg)
 	if !cfg.Enabled || provider == nil {
 		return nil, nil
 	}
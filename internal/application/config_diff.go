@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// DiffConfig loads the base and head configs from source and lists the
+// policy changes between them: thresholds raised or lowered, domains added
+// or removed, and changed matches and excludes. Domain minimums are compared
+// as enforced, so raising the default shows up on every domain that
+// inherits it.
+func (s *Service) DiffConfig(ctx context.Context, opts ConfigDiffOptions, source ConfigSource) (ConfigDiff, error) {
+	if opts.Base == "" || opts.Head == "" {
+		return ConfigDiff{}, fmt.Errorf("diff-config needs a base and a head config")
+	}
+	base, err := source.LoadConfig(ctx, opts.Base)
+	if err != nil {
+		return ConfigDiff{}, fmt.Errorf("base config %s: %w", opts.Base, err)
+	}
+	head, err := source.LoadConfig(ctx, opts.Head)
+	if err != nil {
+		return ConfigDiff{}, fmt.Errorf("head config %s: %w", opts.Head, err)
+	}
+	return ConfigDiff{Base: opts.Base, Head: opts.Head, Changes: diffConfigs(base, head)}, nil
+}
+
+// diffConfigs compares two configs section by section.
+func diffConfigs(base, head Config) []ConfigChange {
+	var changes []ConfigChange
+	changes = appendThreshold(changes, "default min", &base.Policy.DefaultMin, &head.Policy.DefaultMin)
+	if base.NewCode.Since != "" || head.NewCode.Since != "" {
+		changes = appendThreshold(changes, "new code min", newCodeMin(base.NewCode), newCodeMin(head.NewCode))
+	}
+	changes = appendThreshold(changes, "functions min", base.Functions.Min, head.Functions.Min)
+
+	baseDomains := domainsByName(base.Policy.Domains)
+	headDomains := domainsByName(head.Policy.Domains)
+	for _, name := range unionKeys(baseDomains, headDomains) {
+		subject := "domain " + name
+		b, inBase := baseDomains[name]
+		h, inHead := headDomains[name]
+		switch {
+		case !inBase:
+			changes = append(changes, ConfigChange{Kind: ConfigAdded, Subject: subject, After: formatPercent(h.MinThreshold(head.Policy.DefaultMin))})
+		case !inHead:
+			changes = append(changes, ConfigChange{Kind: ConfigRemoved, Subject: subject, Before: formatPercent(b.MinThreshold(base.Policy.DefaultMin))})
+		default:
+			baseMin, headMin := b.MinThreshold(base.Policy.DefaultMin), h.MinThreshold(head.Policy.DefaultMin)
+			changes = appendThreshold(changes, subject+" min", &baseMin, &headMin)
+			changes = appendThreshold(changes, subject+" warn", b.Warn, h.Warn)
+			changes = appendList(changes, subject+" match", b.Match, h.Match)
+			changes = appendList(changes, subject+" exclude", b.Exclude, h.Exclude)
+			if b.Group != h.Group {
+				changes = append(changes, ConfigChange{Kind: ConfigChanged, Subject: subject + " group", Before: b.Group, After: h.Group})
+			}
+		}
+	}
+
+	baseGroups := groupsByName(base.Policy.Groups)
+	headGroups := groupsByName(head.Policy.Groups)
+	for _, name := range unionKeys(baseGroups, headGroups) {
+		changes = appendThreshold(changes, "group "+name+" min", baseGroups[name], headGroups[name])
+	}
+
+	changes = appendList(changes, "exclude", base.Exclude, head.Exclude)
+	changes = appendList(changes, "exclude function", base.ExcludeFunctions, head.ExcludeFunctions)
+
+	baseFiles := fileRulesByMatch(base.Files)
+	headFiles := fileRulesByMatch(head.Files)
+	for _, match := range unionKeys(baseFiles, headFiles) {
+		changes = appendThreshold(changes, "file rule "+match+" min", baseFiles[match], headFiles[match])
+	}
+	return changes
+}
+
+// appendThreshold records a minimum that was added, removed, raised, or
+// lowered; nil means the threshold is not set.
+func appendThreshold(changes []ConfigChange, subject string, before, after *float64) []ConfigChange {
+	switch {
+	case before == nil && after == nil:
+		return changes
+	case before == nil:
+		return append(changes, ConfigChange{Kind: ConfigAdded, Subject: subject, After: formatPercent(*after)})
+	case after == nil:
+		return append(changes, ConfigChange{Kind: ConfigRemoved, Subject: subject, Before: formatPercent(*before)})
+	case *after > *before:
+		return append(changes, ConfigChange{Kind: ConfigRaised, Subject: subject, Before: formatPercent(*before), After: formatPercent(*after)})
+	case *after < *before:
+		return append(changes, ConfigChange{Kind: ConfigLowered, Subject: subject, Before: formatPercent(*before), After: formatPercent(*after)})
+	}
+	return changes
+}
+
+// appendList records each pattern added to or removed from a list.
+func appendList(changes []ConfigChange, subject string, before, after []string) []ConfigChange {
+	for _, pattern := range sortedDifference(after, before) {
+		changes = append(changes, ConfigChange{Kind: ConfigAdded, Subject: subject, After: pattern})
+	}
+	for _, pattern := range sortedDifference(before, after) {
+		changes = append(changes, ConfigChange{Kind: ConfigRemoved, Subject: subject, Before: pattern})
+	}
+	return changes
+}
+
+// sortedDifference returns the entries of a missing from b, sorted.
+func sortedDifference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, v := range b {
+		seen[v] = true
+	}
+	var out []string
+	for _, v := range a {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func newCodeMin(cfg NewCodeConfig) *float64 {
+	if cfg.Since == "" {
+		return nil
+	}
+	return &cfg.Min
+}
+
+func domainsByName(domains []domain.Domain) map[string]domain.Domain {
+	out := make(map[string]domain.Domain, len(domains))
+	for _, d := range domains {
+		out[d.Name] = d
+	}
+	return out
+}
+
+func groupsByName(groups []domain.GroupPolicy) map[string]*float64 {
+	out := make(map[string]*float64, len(groups))
+	for _, g := range groups {
+		out[g.Name] = g.Min
+	}
+	return out
+}
+
+func fileRulesByMatch(rules []domain.FileRule) map[string]*float64 {
+	out := make(map[string]*float64, len(rules))
+	for _, rule := range rules {
+		min := rule.Min
+		out[strings.Join(rule.Match, ", ")] = &min
+	}
+	return out
+}
+
+// unionKeys returns the keys of both maps, sorted.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v)
+}
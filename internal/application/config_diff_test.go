@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeConfigSource map[string]Config
+
+func (f fakeConfigSource) LoadConfig(_ context.Context, ref string) (Config, error) {
+	cfg, ok := f[ref]
+	if !ok {
+		return Config{}, errors.New("not found")
+	}
+	return cfg, nil
+}
+
+func TestServiceDiffConfig(t *testing.T) {
+	eighty, ninety, warn := 80.0, 90.0, 85.0
+	base := Config{
+		Policy: domain.Policy{
+			DefaultMin: 70,
+			Domains: []domain.Domain{
+				{Name: "api", Match: []string{"./api/..."}, Min: &eighty},
+				{Name: "core", Match: []string{"./core/..."}},
+				{Name: "legacy", Match: []string{"./legacy/..."}},
+			},
+			Groups: []domain.GroupPolicy{{Name: "backend", Min: &eighty}},
+		},
+		Exclude: []string{"internal/gen/*", "internal/mocks/*"},
+		Files:   []domain.FileRule{{Match: []string{"core/*.go"}, Min: 90}},
+	}
+	head := Config{
+		Policy: domain.Policy{
+			DefaultMin: 75,
+			Domains: []domain.Domain{
+				{Name: "api", Match: []string{"./api/...", "./web/..."}, Min: &eighty, Warn: &warn},
+				{Name: "billing", Match: []string{"./billing/..."}, Min: &ninety},
+				{Name: "core", Match: []string{"./core/..."}},
+			},
+			Groups: []domain.GroupPolicy{{Name: "backend", Min: &ninety}},
+		},
+		Exclude: []string{"internal/gen/*", "internal/legacy/*"},
+		Files:   []domain.FileRule{{Match: []string{"core/*.go"}, Min: 85}},
+	}
+	svc := &Service{}
+
+	diff, err := svc.DiffConfig(context.Background(), ConfigDiffOptions{Base: "main:.coverctl.yaml", Head: ".coverctl.yaml"},
+		fakeConfigSource{"main:.coverctl.yaml": base, ".coverctl.yaml": head})
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	want := []ConfigChange{
+		{Kind: ConfigRaised, Subject: "default min", Before: "70.0%", After: "75.0%"},
+		{Kind: ConfigAdded, Subject: "domain api warn", After: "85.0%"},
+		{Kind: ConfigAdded, Subject: "domain api match", After: "./web/..."},
+		{Kind: ConfigAdded, Subject: "domain billing", After: "90.0%"},
+		{Kind: ConfigRaised, Subject: "domain core min", Before: "70.0%", After: "75.0%"},
+		{Kind: ConfigRemoved, Subject: "domain legacy", Before: "70.0%"},
+		{Kind: ConfigRaised, Subject: "group backend min", Before: "80.0%", After: "90.0%"},
+		{Kind: ConfigAdded, Subject: "exclude", After: "internal/legacy/*"},
+		{Kind: ConfigRemoved, Subject: "exclude", Before: "internal/mocks/*"},
+		{Kind: ConfigLowered, Subject: "file rule core/*.go min", Before: "90.0%", After: "85.0%"},
+	}
+	if !reflect.DeepEqual(diff.Changes, want) {
+		t.Fatalf("unexpected changes:\n got %+v\nwant %+v", diff.Changes, want)
+	}
+
+	same, err := svc.DiffConfig(context.Background(), ConfigDiffOptions{Base: ".coverctl.yaml", Head: ".coverctl.yaml"}, fakeConfigSource{".coverctl.yaml": head})
+	if err != nil || len(same.Changes) != 0 {
+		t.Fatalf("expected no changes, got %+v (%v)", same.Changes, err)
+	}
+	if _, err := svc.DiffConfig(context.Background(), ConfigDiffOptions{Base: "missing.yaml", Head: ".coverctl.yaml"}, fakeConfigSource{}); err == nil {
+		t.Fatal("expected error for a missing base")
+	}
+}
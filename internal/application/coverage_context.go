@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// coverageContext holds the precomputed coverage data used across multiple methods.
+// This reduces code duplication by encapsulating the common setup logic.
+type coverageContext struct {
+	ModuleRoot         string
+	ModulePath         string
+	NormalizedCoverage map[string]domain.CoverageStat
+	Annotations        map[string]Annotation
+	DomainDirs         map[string][]string
+	DomainExcludes     map[string][]string
+	DomainCoverage     map[string]domain.CoverageStat
+	StaleWarnings      []string
+}
+
+// prepareCoverageContext loads and prepares all coverage-related data needed for analysis.
+// This is the common setup used by Check, Report, Debt, Suggest, Badge, Compare, and Record.
+func (s *Service) prepareCoverageContext(ctx context.Context, cfg Config, domains []domain.Domain, profiles []string) (*coverageContext, error) {
+	return s.prepareCoverage(ctx, cfg, domains, profiles, false)
+}
+
+// prepareCoverage is prepareCoverageContext with stale profile entries
+// dropped when pruneStale is set. Results are cached by their inputs when
+// the Service has a Cache, so repeated analytics on an unchanged profile
+// and source tree skip parsing, source scans, and domain resolution.
+func (s *Service) prepareCoverage(ctx context.Context, cfg Config, domains []domain.Domain, profiles []string, pruneStale bool) (*coverageContext, error) {
+	var key string
+	if s.Cache != nil {
+		var ok bool
+		if key, ok = analysisCacheKey(cfg, domains, profiles, pruneStale); ok {
+			var cached cachedCoverage
+			if s.Cache.Get(key, &cached) && sourcesUnchanged(cached.Sources) {
+				return &cached.Context, nil
+			}
+		}
+	}
+
+	moduleRoot, err := s.DomainResolver.ModuleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modulePath, err := s.DomainResolver.ModulePath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fileCoverage, err := s.parseAll(ctx, profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	profiled := normalizedCoverage
+	normalizedCoverage, staleWarnings := sanitizeStaleEntries(normalizedCoverage, moduleRoot, pruneStale)
+	normalizedCoverage, err = excludeFunctions(ctx, s.ProfileParser, s.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := s.loadAnnotations(ctx, cfg, moduleRoot, normalizedCoverage)
+	if err != nil {
+		return nil, err
+	}
+
+	domainDirs, err := resolveDomainDirs(ctx, s.DomainResolver, domains, moduleRoot, cfg.Merge)
+	if err != nil {
+		return nil, err
+	}
+
+	domainExcludes := buildDomainExcludes(domains)
+	domainCoverage := AggregateByDomainWithExcludes(normalizedCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
+	if err := applyDomainMetrics(s.ProfileParser, cfg.Policy, domains, profiles, newDomainAggregator(cfg, moduleRoot, modulePath, nil, domainDirs, domainExcludes, annotations), domainCoverage); err != nil {
+		return nil, err
+	}
+
+	covCtx := &coverageContext{
+		ModuleRoot:         moduleRoot,
+		ModulePath:         modulePath,
+		NormalizedCoverage: normalizedCoverage,
+		Annotations:        annotations,
+		DomainDirs:         domainDirs,
+		DomainExcludes:     domainExcludes,
+		DomainCoverage:     domainCoverage,
+		StaleWarnings:      staleWarnings,
+	}
+	if key != "" {
+		s.Cache.Put(key, cachedCoverage{Context: *covCtx, Sources: stampSources(coverageSources(moduleRoot, profiled, domainDirs))})
+	}
+	return covCtx, nil
+}
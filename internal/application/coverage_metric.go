@@ -0,0 +1,59 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// metricCoverage parses profiles into per-file counts of metric.
+// Statements are whatever ParseAll counts.
+func metricCoverage(parser ProfileParser, profiles []string, metric domain.Metric) (map[string]domain.CoverageStat, error) {
+	switch metric {
+	case domain.MetricLines:
+		lineParser, ok := parser.(LineProfileParser)
+		if !ok {
+			return nil, fmt.Errorf("the %s profile parser cannot report line coverage", parser.Format())
+		}
+		lines, err := lineParser.ParseAllLines(profiles)
+		if err != nil {
+			return nil, err
+		}
+		stats := make(map[string]domain.CoverageStat, len(lines))
+		for file, cov := range lines {
+			stats[file] = domain.LineStat(cov)
+		}
+		return stats, nil
+	case domain.MetricBranches:
+		branchParser, ok := parser.(BranchProfileParser)
+		if !ok {
+			return nil, fmt.Errorf("the %s profile parser cannot report branch coverage", parser.Format())
+		}
+		return branchParser.ParseAllBranches(profiles)
+	}
+	return parser.ParseAll(profiles)
+}
+
+// applyDomainMetrics recounts the coverage of domains measured by lines or
+// branches, replacing their statement counts in domainCoverage. Each metric
+// is parsed once, however many domains use it.
+func applyDomainMetrics(parser ProfileParser, policy domain.Policy, domains []domain.Domain, profiles []string, agg domainAggregator, domainCoverage map[string]domain.CoverageStat) error {
+	recounted := make(map[domain.Metric]map[string]domain.CoverageStat)
+	for _, d := range domains {
+		metric := policy.MetricFor(d)
+		if metric == domain.MetricStatements {
+			continue
+		}
+		byDomain, ok := recounted[metric]
+		if !ok {
+			files, err := metricCoverage(parser, profiles, metric)
+			if err != nil {
+				return fmt.Errorf("domain %s %s metric: %w", d.Name, metric, err)
+			}
+			byDomain = agg.aggregate(files)
+			recounted[metric] = byDomain
+		}
+		domainCoverage[d.Name] = byDomain[d.Name]
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeBranchParser struct {
+	fakeParser
+	branches map[string]domain.CoverageStat
+}
+
+func (f fakeBranchParser) ParseAllBranches(paths []string) (map[string]domain.CoverageStat, error) {
+	return f.branches, nil
+}
+
+func metricService(parser ProfileParser) *Service {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 70, Metric: domain.MetricBranches, Domains: []domain.Domain{
+		{Name: "core", Match: []string{"./internal/core/..."}},
+		{Name: "api", Match: []string{"./internal/api/..."}, Metric: domain.MetricStatements},
+	}}}
+	return newTestService(cfg, map[string][]string{
+		"core": {"/repo/internal/core"},
+		"api":  {"/repo/internal/api"},
+	}, parser)
+}
+
+func TestServiceCheckBranchMetric(t *testing.T) {
+	statements := map[string]domain.CoverageStat{
+		"internal/core/a.go": {Covered: 9, Total: 10},
+		"internal/api/b.go":  {Covered: 8, Total: 10},
+	}
+	svc := metricService(fakeBranchParser{
+		fakeParser: fakeParser{stats: statements},
+		branches:   map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 3, Total: 6}},
+	})
+
+	result, err := svc.CheckResult(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", Output: OutputText})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	core, api := result.Domains[0], result.Domains[1]
+	if core.Covered != 3 || core.Total != 6 || core.Metric != domain.MetricBranches || core.Status != domain.StatusFail {
+		t.Fatalf("core should be measured by branches: %+v", core)
+	}
+	if api.Covered != 8 || api.Total != 10 || api.Metric != "" || api.Status != domain.StatusPass {
+		t.Fatalf("api should keep statements: %+v", api)
+	}
+}
+
+func TestServiceCheckBranchMetricUnsupported(t *testing.T) {
+	svc := metricService(fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 9, Total: 10}}})
+
+	_, err := svc.CheckResult(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", Output: OutputText})
+	if err == nil || !strings.Contains(err.Error(), "cannot report branch coverage") {
+		t.Fatalf("expected an unsupported metric error, got %v", err)
+	}
+}
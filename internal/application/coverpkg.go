@@ -0,0 +1,77 @@
+package application
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// testHelperPackages are package names auto-exclusion treats as test
+// helpers: code that exists only to support tests.
+var testHelperPackages = map[string]bool{"testutil": true, "testhelpers": true}
+
+// Excludes reports whether the package in dir, a module-relative slash
+// path such as "internal/testutil", is left out of -coverpkg and domain
+// totals. modulePath lets patterns be written as import paths.
+func (c CoverPkgConfig) Excludes(dir, modulePath string) bool {
+	if c.AutoExcludeEnabled() && testHelperPackages[path.Base(dir)] {
+		return true
+	}
+	for _, pattern := range c.Exclude {
+		if matchPackagePattern(relativePackagePattern(pattern, modulePath), dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// relativePackagePattern turns "./internal/x/..." or
+// "<module>/internal/x/..." into "internal/x/...".
+func relativePackagePattern(pattern, modulePath string) string {
+	if modulePath != "" {
+		if pattern == modulePath {
+			return "."
+		}
+		if rest, ok := strings.CutPrefix(pattern, modulePath+"/"); ok {
+			return rest
+		}
+	}
+	pattern = strings.TrimPrefix(pattern, "./")
+	if pattern == "" {
+		return "."
+	}
+	return pattern
+}
+
+// matchPackagePattern matches dir against a go package pattern where "..."
+// matches any string and a trailing "/..." also matches the directory
+// itself, as `go list` does.
+func matchPackagePattern(pattern, dir string) bool {
+	if pattern == "..." {
+		return true
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\.\.\.`, `.*`)
+	if rest, ok := strings.CutSuffix(expr, `/.*`); ok {
+		expr = rest + `(/.*)?`
+	}
+	re, err := regexp.Compile("^" + expr + "$")
+	return err == nil && re.MatchString(dir)
+}
+
+// dropCoverPkgExcludes removes files of excluded packages from normalized
+// coverage, keyed by module-relative slash paths.
+func dropCoverPkgExcludes(files map[string]domain.CoverageStat, cfg CoverPkgConfig, modulePath string) map[string]domain.CoverageStat {
+	if len(cfg.Exclude) == 0 && !cfg.AutoExcludeEnabled() {
+		return files
+	}
+	kept := make(map[string]domain.CoverageStat, len(files))
+	for file, stat := range files {
+		if !cfg.Excludes(path.Dir(file), modulePath) {
+			kept[file] = stat
+		}
+	}
+	return kept
+}
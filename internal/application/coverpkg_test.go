@@ -0,0 +1,49 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestCoverPkgExcludes(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name string
+		cfg  CoverPkgConfig
+		dir  string
+		want bool
+	}{
+		{"auto testutil", CoverPkgConfig{}, "internal/testutil", true},
+		{"auto nested testhelpers", CoverPkgConfig{}, "internal/api/testhelpers", true},
+		{"auto disabled", CoverPkgConfig{AutoExclude: &disabled}, "internal/testutil", false},
+		{"plain package", CoverPkgConfig{}, "internal/core", false},
+		{"recursive pattern", CoverPkgConfig{Exclude: []string{"./internal/fixtures/..."}}, "internal/fixtures/db", true},
+		{"recursive pattern matches root", CoverPkgConfig{Exclude: []string{"./internal/fixtures/..."}}, "internal/fixtures", true},
+		{"recursive pattern is not a prefix", CoverPkgConfig{Exclude: []string{"./internal/fixtures/..."}}, "internal/fixturesx", false},
+		{"exact pattern", CoverPkgConfig{Exclude: []string{"./internal/mocks"}}, "internal/mocks/sub", false},
+		{"import path pattern", CoverPkgConfig{Exclude: []string{"example.com/app/internal/mocks"}}, "internal/mocks", true},
+		{"wildcard element", CoverPkgConfig{Exclude: []string{"./.../mocks"}}, "internal/api/mocks", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.Excludes(tt.dir, "example.com/app"); got != tt.want {
+				t.Fatalf("Excludes(%q) = %v, want %v", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeProfileCoverageDropsCoverPkgExcludes(t *testing.T) {
+	files := map[string]domain.CoverageStat{
+		"example.com/app/internal/core/a.go":     {Covered: 5, Total: 10},
+		"example.com/app/internal/testutil/t.go": {Covered: 0, Total: 40},
+		"example.com/app/internal/fixtures/f.go": {Covered: 0, Total: 20},
+	}
+	cfg := Config{CoverPkg: CoverPkgConfig{Exclude: []string{"./internal/fixtures/..."}}}
+
+	got := normalizeProfileCoverage(files, "/repo", "example.com/app", cfg)
+	if len(got) != 1 || got["internal/core/a.go"] != (domain.CoverageStat{Covered: 5, Total: 10}) {
+		t.Fatalf("expected only core coverage, got %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"math"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// computeDebt measures the gap between each domain and file rule and its
+// minimum. Percentages are compared under policy.rounding, as check does,
+// so debt never reports as passing a domain or file that check fails.
+func computeDebt(cfg Config, domains []domain.Domain, covCtx *coverageContext) DebtResult {
+	rounding := cfg.Policy.Rounding
+	var items []DebtItem
+	var totalDebt float64
+	var totalLines int
+	var passCount, failCount int
+
+	// Calculate domain debt
+	for _, d := range domains {
+		stat := covCtx.DomainCoverage[d.Name]
+		raw := stat.Percent()
+		currentPercent := rounding.Percent(raw)
+
+		required := cfg.Policy.DefaultMin
+		if d.Min != nil {
+			required = *d.Min
+		}
+
+		if !rounding.Meets(raw, required) {
+			shortfall := domain.Round1(required - currentPercent)
+			// Estimate lines needing tests: (shortfall% * total statements) / 100
+			// Use a minimum denominator of 1.0 to prevent division by near-zero
+			uncoveredLines := stat.Total - stat.Covered
+			denominator := math.Max(required-currentPercent, 1.0)
+			linesNeededFloat := float64(uncoveredLines) * (shortfall / denominator)
+			// Clamp to valid range [0, uncoveredLines] to prevent overflow
+			linesNeeded := int(math.Min(math.Max(linesNeededFloat, 0), float64(uncoveredLines)))
+
+			items = append(items, DebtItem{
+				Name:      d.Name,
+				Type:      "domain",
+				Current:   currentPercent,
+				Required:  required,
+				Shortfall: shortfall,
+				Lines:     linesNeeded,
+				Owners:    d.Owners,
+			})
+			totalDebt += shortfall
+			totalLines += linesNeeded
+			failCount++
+		} else {
+			passCount++
+		}
+	}
+
+	// Calculate file rule debt
+	for _, rule := range cfg.Files {
+		for file, stat := range covCtx.NormalizedCoverage {
+			if excluded(file, cfg.Exclude) {
+				continue
+			}
+			if ann, ok := covCtx.Annotations[file]; ok && ann.Ignore {
+				continue
+			}
+			if !matchAnyPattern(file, rule.Match) {
+				continue
+			}
+			raw := stat.Percent()
+			if rounding.Meets(raw, rule.Min) {
+				passCount++
+				continue
+			}
+			currentPercent := rounding.Percent(raw)
+			shortfall := domain.Round1(rule.Min - currentPercent)
+			linesNeeded := stat.Total - stat.Covered
+
+			items = append(items, DebtItem{
+				Name:      file,
+				Type:      "file",
+				Current:   currentPercent,
+				Required:  rule.Min,
+				Shortfall: shortfall,
+				Lines:     linesNeeded,
+			})
+			totalDebt += shortfall
+			totalLines += linesNeeded
+			failCount++
+		}
+	}
+
+	// Sort by shortfall (highest first)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Shortfall > items[j].Shortfall
+	})
+
+	// Calculate health score (0-100, higher is better)
+	healthScore := 100.0
+	if passCount+failCount > 0 {
+		healthScore = domain.Round1((float64(passCount) / float64(passCount+failCount)) * 100)
+	}
+
+	return DebtResult{
+		Items:       items,
+		TotalDebt:   domain.Round1(totalDebt),
+		TotalLines:  totalLines,
+		HealthScore: healthScore,
+	}
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// DebtPlan creates or evaluates a debt burn-down plan from recorded history.
+//
+// With a target date, the latest history entry becomes the plan's baseline
+// and the plan is saved. Without one, the saved plan is compared against
+// the latest entry so repeated runs report whether the burn-down is on track.
+func (s *Service) DebtPlan(_ context.Context, opts DebtPlanOptions, history HistoryStore, plans DebtPlanStore) (DebtPlanResult, error) {
+	hist, err := history.Load()
+	if err != nil {
+		return DebtPlanResult{}, err
+	}
+	latest := hist.LatestEntry()
+	if latest == nil {
+		return DebtPlanResult{}, fmt.Errorf("no history data available; run 'coverctl record' after coverage runs")
+	}
+
+	var plan domain.DebtPlan
+	created := !opts.TargetDate.IsZero()
+	if created {
+		plan, err = domain.NewDebtPlan(*latest, opts.TargetDate)
+		if err != nil {
+			return DebtPlanResult{}, fmt.Errorf("target date %s: %w", opts.TargetDate.Format("2006-01-02"), err)
+		}
+		if err := plans.Save(plan); err != nil {
+			return DebtPlanResult{}, fmt.Errorf("save debt plan: %w", err)
+		}
+	} else {
+		var ok bool
+		plan, ok, err = plans.Load()
+		if err != nil {
+			return DebtPlanResult{}, fmt.Errorf("load debt plan: %w", err)
+		}
+		if !ok {
+			return DebtPlanResult{}, fmt.Errorf("no debt plan found; run 'coverctl debt plan --target-date YYYY-MM-DD' first")
+		}
+	}
+
+	progress := plan.Progress(hist)
+	return DebtPlanResult{
+		Plan:     plan,
+		Created:  created,
+		AsOf:     latest.Timestamp,
+		Progress: progress,
+		OnTrack:  domain.OnTrack(progress),
+	}, nil
+}
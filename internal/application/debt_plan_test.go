@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type memoryHistoryStore struct {
+	history domain.History
+}
+
+func (m *memoryHistoryStore) Load() (domain.History, error) { return m.history, nil }
+func (m *memoryHistoryStore) Save(h domain.History) error   { m.history = h; return nil }
+func (m *memoryHistoryStore) Append(e domain.HistoryEntry) error {
+	m.history.Entries = append(m.history.Entries, e)
+	return nil
+}
+
+type memoryPlanStore struct {
+	plan  domain.DebtPlan
+	saved bool
+}
+
+func (m *memoryPlanStore) Load() (domain.DebtPlan, bool, error) { return m.plan, m.saved, nil }
+func (m *memoryPlanStore) Save(p domain.DebtPlan) error {
+	m.plan, m.saved = p, true
+	return nil
+}
+
+func TestServiceDebtPlan(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{
+		{Timestamp: start, Domains: map[string]domain.DomainEntry{"core": {Name: "core", Percent: 60, Min: 80}}},
+	}}}
+	plans := &memoryPlanStore{}
+	svc := &Service{}
+
+	t.Run("evaluate without plan fails", func(t *testing.T) {
+		_, err := svc.DebtPlan(context.Background(), DebtPlanOptions{}, history, plans)
+		if err == nil || !strings.Contains(err.Error(), "no debt plan found") {
+			t.Fatalf("expected missing plan error, got %v", err)
+		}
+	})
+
+	t.Run("creates and saves plan", func(t *testing.T) {
+		result, err := svc.DebtPlan(context.Background(), DebtPlanOptions{TargetDate: start.Add(10 * 7 * 24 * time.Hour)}, history, plans)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Created || !plans.saved {
+			t.Fatal("expected plan to be created and saved")
+		}
+		if len(result.Plan.Domains) != 1 || result.Plan.Domains[0].WeeklyGain != 2 {
+			t.Fatalf("unexpected plan: %+v", result.Plan)
+		}
+		if !result.OnTrack {
+			t.Fatal("expected a fresh plan to be on track")
+		}
+	})
+
+	t.Run("reports off track on later runs", func(t *testing.T) {
+		_ = history.Append(domain.HistoryEntry{
+			Timestamp: start.Add(5 * 7 * 24 * time.Hour),
+			Domains:   map[string]domain.DomainEntry{"core": {Name: "core", Percent: 62, Min: 80}},
+		})
+		result, err := svc.DebtPlan(context.Background(), DebtPlanOptions{}, history, plans)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Created || result.OnTrack {
+			t.Fatalf("expected evaluated off-track plan, got %+v", result)
+		}
+		if result.Progress[0].Expected != 70 || result.Progress[0].Actual != 62 {
+			t.Fatalf("unexpected progress: %+v", result.Progress[0])
+		}
+	})
+
+	t.Run("rejects target before latest entry", func(t *testing.T) {
+		_, err := svc.DebtPlan(context.Background(), DebtPlanOptions{TargetDate: start}, history, plans)
+		if err == nil {
+			t.Fatal("expected error for past target date")
+		}
+	})
+
+	t.Run("requires history", func(t *testing.T) {
+		_, err := svc.DebtPlan(context.Background(), DebtPlanOptions{}, &memoryHistoryStore{}, plans)
+		if err == nil || !strings.Contains(err.Error(), "no history data") {
+			t.Fatalf("expected no history error, got %v", err)
+		}
+	})
+}
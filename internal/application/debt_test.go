@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestServiceDebtHonoursRounding(t *testing.T) {
+	stats := map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 7996, Total: 10000}}
+	for _, tt := range []struct {
+		rounding domain.Rounding
+		items    int
+	}{
+		{domain.RoundingRound, 0},
+		{domain.RoundingFloor, 2},
+		{domain.RoundingExact, 2},
+	} {
+		t.Run(string(tt.rounding), func(t *testing.T) {
+			cfg := Config{
+				Version: 1,
+				Policy:  domain.Policy{DefaultMin: 80, Rounding: tt.rounding, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+				Files:   []domain.FileRule{{Match: []string{"internal/core/*.go"}, Min: 80}},
+			}
+			svc := &Service{
+				ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+				DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo"},
+				ProfileParser:  fakeParser{stats: stats},
+			}
+			result, err := svc.Debt(context.Background(), DebtOptions{ConfigPath: ".coverctl.yaml", ProfilePath: "coverage.out"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Items) != tt.items {
+				t.Fatalf("expected %d debt items, got %+v", tt.items, result.Items)
+			}
+			if tt.items == 0 {
+				if result.HealthScore != 100 {
+					t.Fatalf("expected health 100, got %v", result.HealthScore)
+				}
+				return
+			}
+			for _, item := range result.Items {
+				if item.Current != 79.9 || item.Shortfall != 0.1 {
+					t.Fatalf("expected 79.9%% with a 0.1 shortfall, got %+v", item)
+				}
+			}
+			if result.HealthScore != 0 {
+				t.Fatalf("expected health 0, got %v", result.HealthScore)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// DetectMergeResult describes how a fresh detection differs from an
+// existing config.
+type DetectMergeResult struct {
+	Existing Config          // Config loaded from opts.ConfigPath
+	Added    []domain.Domain // Detected domains the existing config lacks
+	Stale    []string        // Existing domains whose match patterns resolve to no directories
+}
+
+// DetectMerge runs detection against an existing config without changing
+// it. A detected domain is added when neither its name nor all of its match
+// patterns are already in use; existing domains are never altered, only
+// reported as stale when their directories are gone. Writing the new
+// domains back is left to the caller so the file's comments survive.
+func (s *Service) DetectMerge(ctx context.Context, opts DetectOptions) (DetectMergeResult, error) {
+	exists, err := s.ConfigLoader.Exists(opts.ConfigPath)
+	if err != nil {
+		return DetectMergeResult{}, err
+	}
+	if !exists {
+		return DetectMergeResult{}, fmt.Errorf("config %s not found; run 'coverctl detect' without --merge to create it", opts.ConfigPath)
+	}
+	existing, err := s.ConfigLoader.Load(opts.ConfigPath)
+	if err != nil {
+		return DetectMergeResult{}, err
+	}
+	detected, err := s.Autodetector.Detect()
+	if err != nil {
+		return DetectMergeResult{}, err
+	}
+
+	names := make(map[string]struct{}, len(existing.Policy.Domains))
+	matches := make(map[string]struct{})
+	for _, d := range existing.Policy.Domains {
+		names[d.Name] = struct{}{}
+		for _, m := range d.Match {
+			matches[m] = struct{}{}
+		}
+	}
+	result := DetectMergeResult{Existing: existing}
+	for _, d := range detected.Policy.Domains {
+		if _, ok := names[d.Name]; ok || allMatched(d.Match, matches) {
+			continue
+		}
+		result.Added = append(result.Added, d)
+	}
+
+	for _, d := range existing.Policy.Domains {
+		dirs, err := s.DomainResolver.Resolve(ctx, []domain.Domain{d})
+		if err != nil || len(dirs[d.Name]) == 0 {
+			result.Stale = append(result.Stale, d.Name)
+		}
+	}
+	sort.Strings(result.Stale)
+	return result, nil
+}
+
+func allMatched(patterns []string, matches map[string]struct{}) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	for _, p := range patterns {
+		if _, ok := matches[p]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestDetectMerge(t *testing.T) {
+	min := 90.0
+	existing := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+		{Name: "core", Match: []string{"./internal/core/..."}, Min: &min},
+		{Name: "legacy", Match: []string{"./internal/legacy/..."}},
+	}}}
+	detected := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+		{Name: "core", Match: []string{"./internal/core/..."}},
+		{Name: "domain", Match: []string{"./internal/core/..."}},
+		{Name: "api", Match: []string{"./internal/api/..."}},
+	}}}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: existing},
+		Autodetector:   fakeAutodetector{cfg: detected},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}},
+	}
+
+	result, err := svc.DetectMerge(context.Background(), DetectOptions{ConfigPath: ".coverctl.yaml"})
+	if err != nil {
+		t.Fatalf("detect merge: %v", err)
+	}
+	if len(result.Added) != 1 || result.Added[0].Name != "api" {
+		t.Fatalf("expected only api added, got %+v", result.Added)
+	}
+	if len(result.Stale) != 1 || result.Stale[0] != "legacy" {
+		t.Fatalf("expected legacy stale, got %v", result.Stale)
+	}
+	if *result.Existing.Policy.Domains[0].Min != 90 {
+		t.Fatal("expected existing config returned unchanged")
+	}
+}
+
+func TestDetectMergeRequiresConfig(t *testing.T) {
+	svc := &Service{ConfigLoader: fakeConfigLoader{exists: false}}
+	_, err := svc.DetectMerge(context.Background(), DetectOptions{ConfigPath: ".coverctl.yaml"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runDomainTests runs the shared test command for domains without
+// overrides, then one run per domain with test_args or test_command. It
+// returns the shared profile ("" when every domain has its own run) and the
+// per-domain profiles, to be merged with it. Without overrides it is a
+// single runner.Run, as before. With opts.CoverPkg.Isolate every domain
+// gets its own run, limited to the domain's packages.
+//
+// Failed runs are retried per opts.Retry (runner.retries). Runs stop early
+// when opts.AfterDomains returns true (check --fail-fast); the profiles
+// returned then cover only the domains that ran.
+//
+// When timings is non-nil it collects per-package times from the shared run
+// and wall-clock times for each domain's own run.
+func runDomainTests(ctx context.Context, runner CoverageRunner, commands CommandRunner, opts RunOptions, timings *domain.TestTimings) (string, []string, error) {
+	start := time.Now()
+	if timings != nil {
+		defer func() { timings.Total = time.Since(start).Seconds() }()
+	}
+	var shared, own []domain.Domain
+	for _, d := range opts.Domains {
+		if opts.CoverPkg.Isolate || d.HasTestOverride() {
+			own = append(own, d)
+		} else {
+			shared = append(shared, d)
+		}
+	}
+
+	var sharedProfile string
+	if len(shared) > 0 || len(own) == 0 {
+		sharedOpts := opts
+		if len(own) > 0 {
+			sharedOpts.Domains = shared
+		}
+		if timings != nil {
+			sharedOpts.PackageTime = func(pkg string, seconds float64) {
+				if timings.Packages == nil {
+					timings.Packages = map[string]float64{}
+				}
+				timings.Packages[pkg] += seconds
+			}
+		}
+		profile, err := runRetried(ctx, runner, sharedOpts)
+		if err != nil {
+			return "", nil, WithErrorCode(ErrCodeRunnerFailed, err)
+		}
+		sharedProfile = profile
+		if len(own) > 0 && opts.AfterDomains != nil && opts.AfterDomains(shared, []string{profile}) {
+			return sharedProfile, nil, nil
+		}
+	}
+
+	var profiles []string
+	for i, d := range own {
+		profilePath := domainProfilePath(opts.ProfilePath, d.Name)
+		var profile string
+		var err error
+		domainStart := time.Now()
+		if len(d.TestCommand) > 0 {
+			if commands == nil {
+				return "", nil, fmt.Errorf("domain %s: test_command is not supported by runner %s", d.Name, runner.Name())
+			}
+			profile, err = commands.RunCommand(ctx, d.TestCommand, profilePath)
+			for attempt := 0; err != nil && attempt < opts.Retry.Retries && ctx.Err() == nil; attempt++ {
+				profile, err = commands.RunCommand(ctx, d.TestCommand, profilePath)
+			}
+		} else {
+			domainOpts := opts
+			domainOpts.Domains = []domain.Domain{d}
+			domainOpts.ProfilePath = profilePath
+			domainOpts.BuildFlags.TestArgs = append(append([]string(nil), opts.BuildFlags.TestArgs...), d.TestArgs...)
+			if opts.CoverPkg.Isolate {
+				domainOpts.Packages = d.Match
+			}
+			profile, err = runRetried(ctx, runner, domainOpts)
+		}
+		if err != nil {
+			return "", nil, WithErrorCode(ErrCodeRunnerFailed, fmt.Errorf("domain %s: %w", d.Name, err))
+		}
+		if timings != nil {
+			if timings.Domains == nil {
+				timings.Domains = map[string]float64{}
+			}
+			timings.Domains[d.Name] = time.Since(domainStart).Seconds()
+		}
+		profiles = append(profiles, profile)
+		if i < len(own)-1 && opts.AfterDomains != nil && opts.AfterDomains([]domain.Domain{d}, producedProfiles(sharedProfile, profiles)) {
+			break
+		}
+	}
+	return sharedProfile, profiles, nil
+}
+
+// runRetried runs the tests, re-running them up to opts.Retry.Retries times
+// while they fail. With OnlyFailed and a runner that names the failed
+// packages, a retry runs only those and adds to the profile.
+func runRetried(ctx context.Context, runner CoverageRunner, opts RunOptions) (string, error) {
+	profile, err := runner.Run(ctx, opts)
+	for attempt := 0; err != nil && attempt < opts.Retry.Retries && ctx.Err() == nil; attempt++ {
+		retry := opts
+		var failed *FailedPackagesError
+		if opts.Retry.OnlyFailed && errors.As(err, &failed) && len(failed.Packages) > 0 {
+			retry.Packages = failed.Packages
+			retry.AppendProfile = true
+		}
+		profile, err = runner.Run(ctx, retry)
+	}
+	return profile, err
+}
+
+// producedProfiles lists the shared profile, when there is one, ahead of
+// the per-domain profiles.
+func producedProfiles(shared string, own []string) []string {
+	if shared == "" {
+		return own
+	}
+	return append([]string{shared}, own...)
+}
+
+// domainProfilePath places a domain's profile next to the shared one:
+// .cover/coverage.out gives .cover/domains/<domain>.out, which the coverage
+// by source breakdown then labels with the domain name.
+func domainProfilePath(profile, name string) string {
+	if profile == "" {
+		profile = filepath.Join(".cover", "coverage.out")
+	}
+	return filepath.Join(filepath.Dir(profile), "domains", pathutil.SafeFileName(name)+filepath.Ext(profile))
+}
+
+// commandRunnerOf returns whichever of the registry or the default runner
+// can run test_command, or nil.
+func commandRunnerOf(registry RunnerRegistry, fallback CoverageRunner) CommandRunner {
+	if c, ok := registry.(CommandRunner); ok {
+		return c
+	}
+	if c, ok := fallback.(CommandRunner); ok {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// recordingRunner records the options of every Run call.
+type recordingRunner struct {
+	fakeRunner
+	calls []RunOptions
+}
+
+func (r *recordingRunner) Run(ctx context.Context, opts RunOptions) (string, error) {
+	r.calls = append(r.calls, opts)
+	if opts.ProfilePath != "" {
+		return opts.ProfilePath, nil
+	}
+	return "shared.out", nil
+}
+
+type fakeCommandRunner struct {
+	command []string
+	profile string
+	err     error
+	calls   int
+}
+
+func (f *fakeCommandRunner) RunCommand(ctx context.Context, command []string, profilePath string) (string, error) {
+	f.calls++
+	f.command = command
+	f.profile = profilePath
+	return profilePath, f.err
+}
+
+func TestRunDomainTestsWithoutOverrides(t *testing.T) {
+	runner := &recordingRunner{}
+	opts := RunOptions{Domains: []domain.Domain{{Name: "core"}, {Name: "api"}}}
+	shared, own, err := runDomainTests(context.Background(), runner, nil, opts, nil)
+	if err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	if shared != "shared.out" || len(own) != 0 {
+		t.Fatalf("got shared %q, own %v", shared, own)
+	}
+	if len(runner.calls) != 1 || len(runner.calls[0].Domains) != 2 {
+		t.Fatalf("expected one run over both domains, got %+v", runner.calls)
+	}
+}
+
+func TestRunDomainTestsTestArgs(t *testing.T) {
+	runner := &recordingRunner{}
+	opts := RunOptions{
+		ProfilePath: filepath.Join(".cover", "coverage.out"),
+		BuildFlags:  BuildFlags{TestArgs: []string{"-race"}},
+		Domains: []domain.Domain{
+			{Name: "core"},
+			{Name: "db", TestArgs: []string{"-tags=integration"}},
+		},
+	}
+	shared, own, err := runDomainTests(context.Background(), runner, nil, opts, nil)
+	if err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	if shared != opts.ProfilePath {
+		t.Fatalf("shared profile = %q", shared)
+	}
+	want := filepath.Join(".cover", "domains", "db.out")
+	if !slices.Equal(own, []string{want}) {
+		t.Fatalf("domain profiles = %v, want [%s]", own, want)
+	}
+	if len(runner.calls) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(runner.calls))
+	}
+	if names := runner.calls[0].Domains; len(names) != 1 || names[0].Name != "core" {
+		t.Fatalf("shared run domains = %+v", names)
+	}
+	if got := runner.calls[1].BuildFlags.TestArgs; !slices.Equal(got, []string{"-race", "-tags=integration"}) {
+		t.Fatalf("domain test args = %v", got)
+	}
+	if got := opts.BuildFlags.TestArgs; !slices.Equal(got, []string{"-race"}) {
+		t.Fatalf("shared test args mutated: %v", got)
+	}
+}
+
+func TestRunDomainTestsIsolate(t *testing.T) {
+	runner := &recordingRunner{}
+	opts := RunOptions{
+		ProfilePath: filepath.Join(".cover", "coverage.out"),
+		CoverPkg:    CoverPkgConfig{Isolate: true},
+		Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/...", "./cmd/api"}},
+		},
+	}
+	shared, own, err := runDomainTests(context.Background(), runner, nil, opts, nil)
+	if err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	if shared != "" || len(own) != 2 {
+		t.Fatalf("expected only per-domain profiles, got shared %q, own %v", shared, own)
+	}
+	if len(runner.calls) != 2 {
+		t.Fatalf("expected one run per domain, got %d", len(runner.calls))
+	}
+	for i, d := range opts.Domains {
+		call := runner.calls[i]
+		if len(call.Domains) != 1 || call.Domains[0].Name != d.Name {
+			t.Fatalf("run %d covers %+v, want only %s", i, call.Domains, d.Name)
+		}
+		if !slices.Equal(call.Packages, d.Match) {
+			t.Fatalf("run %d tests %v, want the domain's packages %v", i, call.Packages, d.Match)
+		}
+	}
+}
+
+func TestRunDomainTestsTestCommand(t *testing.T) {
+	runner := &recordingRunner{}
+	commands := &fakeCommandRunner{}
+	opts := RunOptions{Domains: []domain.Domain{{Name: "web ui", TestCommand: []string{"make", "cover"}}}}
+	shared, own, err := runDomainTests(context.Background(), runner, commands, opts, nil)
+	if err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	if shared != "" || len(runner.calls) != 0 {
+		t.Fatalf("expected no shared run, got %q and %d runs", shared, len(runner.calls))
+	}
+	want := filepath.Join(".cover", "domains", "web-ui.out")
+	if !slices.Equal(own, []string{want}) || !slices.Equal(commands.command, []string{"make", "cover"}) {
+		t.Fatalf("got profiles %v, command %v", own, commands.command)
+	}
+}
+
+func TestRunDomainTestsErrors(t *testing.T) {
+	opts := RunOptions{Domains: []domain.Domain{{Name: "web", TestCommand: []string{"make"}}}}
+	if _, _, err := runDomainTests(context.Background(), &recordingRunner{}, nil, opts, nil); err == nil {
+		t.Fatal("expected an error without a command runner")
+	}
+
+	commands := &fakeCommandRunner{err: errors.New("exit status 2")}
+	_, _, err := runDomainTests(context.Background(), &recordingRunner{}, commands, opts, nil)
+	if err == nil || ErrorCodeOf(err) != ErrCodeRunnerFailed {
+		t.Fatalf("expected %s, got %v", ErrCodeRunnerFailed, err)
+	}
+}
+
+// timedRunner reports a package time through RunOptions.PackageTime.
+type timedRunner struct{ recordingRunner }
+
+func (r *timedRunner) Run(ctx context.Context, opts RunOptions) (string, error) {
+	if opts.PackageTime != nil {
+		opts.PackageTime("example.com/core", 1.5)
+	}
+	return r.recordingRunner.Run(ctx, opts)
+}
+
+func TestRunDomainTestsTimings(t *testing.T) {
+	runner := &timedRunner{}
+	opts := RunOptions{
+		ProfilePath: filepath.Join(".cover", "coverage.out"),
+		Domains: []domain.Domain{
+			{Name: "core"},
+			{Name: "db", TestArgs: []string{"-tags=integration"}},
+		},
+	}
+	var timings domain.TestTimings
+	if _, _, err := runDomainTests(context.Background(), runner, nil, opts, &timings); err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	if timings.Packages["example.com/core"] != 1.5 || len(timings.Packages) != 1 {
+		t.Fatalf("expected shared run package time only, got %v", timings.Packages)
+	}
+	if _, ok := timings.Domains["db"]; !ok || len(timings.Domains) != 1 {
+		t.Fatalf("expected wall-clock time for db, got %v", timings.Domains)
+	}
+	if timings.Total < timings.Domains["db"] {
+		t.Fatalf("total %v should cover the db run %v", timings.Total, timings.Domains["db"])
+	}
+}
+
+func TestRunDomainTestsAfterDomainsStops(t *testing.T) {
+	runner := &recordingRunner{}
+	var seen [][]string
+	opts := RunOptions{
+		ProfilePath: filepath.Join(".cover", "coverage.out"),
+		Domains: []domain.Domain{
+			{Name: "core"},
+			{Name: "db", TestArgs: []string{"-tags=integration"}},
+			{Name: "web", TestArgs: []string{"-p=1"}},
+		},
+		AfterDomains: func(done []domain.Domain, profiles []string) bool {
+			seen = append(seen, profiles)
+			return done[0].Name == "db"
+		},
+	}
+	shared, own, err := runDomainTests(context.Background(), runner, nil, opts, nil)
+	if err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	db := filepath.Join(".cover", "domains", "db.out")
+	if shared != opts.ProfilePath || !slices.Equal(own, []string{db}) {
+		t.Fatalf("got shared %q, own %v", shared, own)
+	}
+	if len(runner.calls) != 2 {
+		t.Fatalf("expected web to be skipped, got %d runs", len(runner.calls))
+	}
+	if len(seen) != 2 || !slices.Equal(seen[1], []string{opts.ProfilePath, db}) {
+		t.Fatalf("AfterDomains profiles = %v", seen)
+	}
+}
+
+// flakyRunner fails its first failures runs, naming failed as the failed
+// packages.
+type flakyRunner struct {
+	recordingRunner
+	failures int
+	failed   []string
+}
+
+func (r *flakyRunner) Run(ctx context.Context, opts RunOptions) (string, error) {
+	profile, _ := r.recordingRunner.Run(ctx, opts)
+	if len(r.calls) <= r.failures {
+		return "", &FailedPackagesError{Packages: r.failed, Err: errors.New("exit status 1")}
+	}
+	return profile, nil
+}
+
+func TestRunDomainTestsRetries(t *testing.T) {
+	runner := &flakyRunner{failures: 2, failed: []string{"example.com/a"}}
+	opts := RunOptions{Domains: []domain.Domain{{Name: "core"}}, Retry: RetryConfig{Retries: 2}}
+	shared, _, err := runDomainTests(context.Background(), runner, nil, opts, nil)
+	if err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	if shared != "shared.out" || len(runner.calls) != 3 {
+		t.Fatalf("got %q after %d runs", shared, len(runner.calls))
+	}
+	if runner.calls[1].AppendProfile || len(runner.calls[1].Packages) != 0 {
+		t.Fatalf("expected a full re-run without only_failed, got %+v", runner.calls[1])
+	}
+
+	runner = &flakyRunner{failures: 3}
+	_, _, err = runDomainTests(context.Background(), runner, nil, opts, nil)
+	if err == nil || ErrorCodeOf(err) != ErrCodeRunnerFailed || len(runner.calls) != 3 {
+		t.Fatalf("expected %s after 3 runs, got %v after %d", ErrCodeRunnerFailed, err, len(runner.calls))
+	}
+}
+
+func TestRunDomainTestsRetriesOnlyFailedPackages(t *testing.T) {
+	runner := &flakyRunner{failures: 1, failed: []string{"example.com/a"}}
+	opts := RunOptions{Domains: []domain.Domain{{Name: "core"}}, Retry: RetryConfig{Retries: 1, OnlyFailed: true}}
+	if _, _, err := runDomainTests(context.Background(), runner, nil, opts, nil); err != nil {
+		t.Fatalf("runDomainTests: %v", err)
+	}
+	retry := runner.calls[1]
+	if !retry.AppendProfile || !slices.Equal(retry.Packages, []string{"example.com/a"}) {
+		t.Fatalf("expected the retry to append a run of the failed package, got %+v", retry)
+	}
+}
+
+func TestRunDomainTestsRetriesTestCommand(t *testing.T) {
+	commands := &fakeCommandRunner{err: errors.New("exit status 2")}
+	opts := RunOptions{
+		Domains: []domain.Domain{{Name: "web", TestCommand: []string{"make"}}},
+		Retry:   RetryConfig{Retries: 2},
+	}
+	if _, _, err := runDomainTests(context.Background(), &recordingRunner{}, commands, opts, nil); err == nil {
+		t.Fatal("expected the command to keep failing")
+	}
+	if commands.calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", commands.calls)
+	}
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// maxUnmatchedFilesListed caps the file list in the unmatched-files warning.
+const maxUnmatchedFilesListed = 10
+
+// unmatchedFilesWarning reports covered files that fall into no domain,
+// which usually means profile paths do not line up with the local checkout
+// (see merge.path_mappings). It returns nil when every file is accounted for.
+func unmatchedFilesWarning(files map[string]domain.CoverageStat, domainDirs map[string][]string, exclude []string, moduleRoot, modulePath string, annotations map[string]Annotation) []string {
+	if len(domainDirs) == 0 {
+		return nil
+	}
+	var unmatched []string
+	for file := range files {
+		normalized := normalizeCoverageFile(file, modulePath, moduleRoot)
+		relPath := filepath.ToSlash(moduleRelativePath(normalized, moduleRoot))
+		if excluded(relPath, exclude) {
+			continue
+		}
+		if ann, ok := annotations[relPath]; ok && (ann.Ignore || ann.Domain != "") {
+			continue
+		}
+		matched := false
+		for _, dirs := range domainDirs {
+			if matchesAnyDir(normalized, dirs, moduleRoot) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, relPath)
+		}
+	}
+	if len(unmatched) == 0 {
+		return nil
+	}
+	sort.Strings(unmatched)
+	listed := unmatched
+	more := ""
+	if len(listed) > maxUnmatchedFilesListed {
+		listed = listed[:maxUnmatchedFilesListed]
+		more = fmt.Sprintf(" (and %d more)", len(unmatched)-maxUnmatchedFilesListed)
+	}
+	return []string{fmt.Sprintf("%d covered files matched no domain; check domain match patterns or merge.path_mappings: %s%s",
+		len(unmatched), strings.Join(listed, ", "), more)}
+}
+
+// emptyDomains returns, in policy order, the domains whose match patterns
+// resolved to at least one directory yet received no coverage data.
+func emptyDomains(domains []domain.Domain, domainDirs map[string][]string, coverage map[string]domain.CoverageStat) []string {
+	var empty []string
+	for _, d := range domains {
+		if len(domainDirs[d.Name]) > 0 && coverage[d.Name].Total == 0 {
+			empty = append(empty, d.Name)
+		}
+	}
+	return empty
+}
+
+// emptyDomainWarnings explains each empty domain; this is the most common
+// misconfiguration, so it gets a warning of its own.
+func emptyDomainWarnings(names []string) []string {
+	warnings := make([]string, 0, len(names))
+	for _, name := range names {
+		warnings = append(warnings, fmt.Sprintf("domain %s matched no coverage data — check match patterns or path normalization", name))
+	}
+	return warnings
+}
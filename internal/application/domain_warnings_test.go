@@ -0,0 +1,64 @@
+package application
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestUnmatchedFilesWarning(t *testing.T) {
+	files := map[string]domain.CoverageStat{
+		"internal/core/a.go":     {Covered: 1, Total: 1},
+		"internal/gen/b.go":      {Covered: 1, Total: 1},
+		"/ci/checkout/cmd/c.go":  {Covered: 1, Total: 1},
+		"scripts/tool/main.go":   {Covered: 0, Total: 1},
+		"internal/legacy/old.go": {Covered: 0, Total: 1},
+	}
+	domainDirs := map[string][]string{"core": {"/repo/internal/core"}}
+	annotations := map[string]Annotation{"internal/legacy/old.go": {Ignore: true}}
+
+	warnings := unmatchedFilesWarning(files, domainDirs, []string{"internal/gen/*"}, "/repo", "", annotations)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	want := "2 covered files matched no domain; check domain match patterns or merge.path_mappings: " +
+		filepath.ToSlash(moduleRelativePath("/ci/checkout/cmd/c.go", "/repo")) + ", scripts/tool/main.go"
+	if warnings[0] != want {
+		t.Errorf("warning = %q, want %q", warnings[0], want)
+	}
+
+	if got := unmatchedFilesWarning(files, nil, nil, "/repo", "", nil); got != nil {
+		t.Errorf("expected no warning without domains, got %v", got)
+	}
+}
+
+func TestUnmatchedFilesWarningTruncates(t *testing.T) {
+	files := make(map[string]domain.CoverageStat)
+	for i := 0; i < maxUnmatchedFilesListed+3; i++ {
+		files["other/"+string(rune('a'+i))+".go"] = domain.CoverageStat{Total: 1}
+	}
+	warnings := unmatchedFilesWarning(files, map[string][]string{"core": {"/repo/internal/core"}}, nil, "/repo", "", nil)
+	if len(warnings) != 1 || !strings.HasSuffix(warnings[0], "(and 3 more)") {
+		t.Fatalf("expected truncated warning, got %v", warnings)
+	}
+}
+
+func TestEmptyDomains(t *testing.T) {
+	domains := []domain.Domain{{Name: "core"}, {Name: "api"}, {Name: "ghost"}}
+	domainDirs := map[string][]string{
+		"core": {"/repo/internal/core"},
+		"api":  {"/repo/internal/api"},
+	}
+	coverage := map[string]domain.CoverageStat{"core": {Covered: 3, Total: 4}}
+
+	empty := emptyDomains(domains, domainDirs, coverage)
+	if len(empty) != 1 || empty[0] != "api" {
+		t.Fatalf("expected only api to be empty, got %v", empty)
+	}
+	warnings := emptyDomainWarnings(empty)
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "domain api matched no coverage data") {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode classifies a failure so tooling can branch on its category
+// instead of matching message text. Codes are stable; messages are not.
+type ErrorCode string
+
+const (
+	// ErrCodeParseFormat: a coverage profile could not be parsed.
+	ErrCodeParseFormat ErrorCode = "ERR_PARSE_FORMAT"
+	// ErrCodeNoDomains: no domains are configured, or none match --domain.
+	ErrCodeNoDomains ErrorCode = "ERR_NO_DOMAINS"
+	// ErrCodeRunnerFailed: the test runner failed to produce a profile.
+	ErrCodeRunnerFailed ErrorCode = "ERR_RUNNER_FAILED"
+	// ErrCodeConfigInvalid: the config file is malformed or fails validation.
+	ErrCodeConfigInvalid ErrorCode = "ERR_CONFIG_INVALID"
+	// ErrCodeStaleProfile: a --from-profile profile is older than allowed.
+	ErrCodeStaleProfile ErrorCode = "ERR_STALE_PROFILE"
+)
+
+var errorRemediation = map[ErrorCode]string{
+	ErrCodeParseFormat:   "Check that the profile is a complete Go cover profile, LCOV, Cobertura, or JaCoCo file. A Go profile must start with a \"mode:\" line; regenerate it if the test run was interrupted. 'coverctl validate-profile <path>' lists every problem in it.",
+	ErrCodeNoDomains:     "Define domains under policy.domains (run 'coverctl detect' to generate them), or check that every --domain name matches a configured domain.",
+	ErrCodeRunnerFailed:  "Run the test command directly to see the failure, fix failing or hanging tests, or pass --runner/--language if the wrong toolchain was detected.",
+	ErrCodeConfigInvalid: "Fix the config file at the reported key ('coverctl check --validate' checks it without running tests); see schemas/coverctl.schema.json for valid keys.",
+	ErrCodeStaleProfile:  "Re-run the tests to regenerate the profile, or raise --max-profile-age (profile.max_age); set profile.stale: warn to report stale profiles without failing.",
+}
+
+// CodedError attaches an ErrorCode to an error without changing its message.
+type CodedError struct {
+	Code ErrorCode
+	Err  error
+}
+
+func (e *CodedError) Error() string { return e.Err.Error() }
+
+func (e *CodedError) Unwrap() error { return e.Err }
+
+// WithErrorCode wraps err with code. A nil err stays nil, and an error that
+// already carries a code keeps it, so the innermost classification wins.
+func WithErrorCode(code ErrorCode, err error) error {
+	if err == nil || ErrorCodeOf(err) != "" {
+		return err
+	}
+	return &CodedError{Code: code, Err: err}
+}
+
+// ErrorCodeOf returns the code attached anywhere in err's chain, or "".
+func ErrorCodeOf(err error) ErrorCode {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ""
+}
+
+// Remediation returns the next-step hint for code, or "" for unknown codes.
+func Remediation(code ErrorCode) string {
+	return errorRemediation[code]
+}
+
+func errNoMatchingDomains(names []string) error {
+	return WithErrorCode(ErrCodeNoDomains, fmt.Errorf("no matching domains found for: %v", names))
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWithErrorCode(t *testing.T) {
+	if WithErrorCode(ErrCodeParseFormat, nil) != nil {
+		t.Fatal("expected nil error to stay nil")
+	}
+
+	base := errors.New("invalid coverage mode line")
+	err := fmt.Errorf("report: %w", WithErrorCode(ErrCodeParseFormat, base))
+	if got := ErrorCodeOf(err); got != ErrCodeParseFormat {
+		t.Fatalf("expected %s through wrapping, got %q", ErrCodeParseFormat, got)
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("expected the original error to stay in the chain")
+	}
+	if err.Error() != "report: invalid coverage mode line" {
+		t.Fatalf("expected message unchanged, got %q", err.Error())
+	}
+	if got := ErrorCodeOf(WithErrorCode(ErrCodeRunnerFailed, err)); got != ErrCodeParseFormat {
+		t.Fatalf("expected the innermost code to win, got %q", got)
+	}
+	if ErrorCodeOf(base) != "" {
+		t.Fatal("expected no code on a plain error")
+	}
+	for _, code := range []ErrorCode{ErrCodeParseFormat, ErrCodeNoDomains, ErrCodeRunnerFailed, ErrCodeConfigInvalid} {
+		if Remediation(code) == "" {
+			t.Fatalf("expected remediation for %s", code)
+		}
+	}
+}
+
+func TestErrorCodesFromService(t *testing.T) {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "module", Match: []string{"./..."}}}}}
+	newService := func(cfg Config, runner CoverageRunner) *Service {
+		return &Service{
+			ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+			Autodetector:   fakeAutodetector{},
+			DomainResolver: fakeResolver{dirs: map[string][]string{"module": {"/repo"}}, moduleRoot: "/repo", modulePath: "example.com/repo"},
+			CoverageRunner: runner,
+			ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{}},
+			Reporter:       &fakeReporter{},
+			Out:            io.Discard,
+		}
+	}
+	tests := []struct {
+		name string
+		run  func() error
+		want ErrorCode
+	}{
+		{"no domains configured", func() error {
+			_, err := newService(Config{Version: 1}, fakeRunner{}).ReportResult(context.Background(), ReportOptions{ConfigPath: ".coverctl.yaml"})
+			return err
+		}, ErrCodeNoDomains},
+		{"no matching domains", func() error {
+			_, err := newService(cfg, fakeRunner{}).ReportResult(context.Background(), ReportOptions{ConfigPath: ".coverctl.yaml", Domains: []string{"missing"}})
+			return err
+		}, ErrCodeNoDomains},
+		{"runner failure", func() error {
+			return newService(cfg, fakeRunner{err: errSentinel}).Check(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml"})
+		}, ErrCodeRunnerFailed},
+		{"run only runner failure", func() error {
+			return newService(cfg, fakeRunner{err: errSentinel}).RunOnly(context.Background(), RunOnlyOptions{ConfigPath: ".coverctl.yaml"})
+		}, ErrCodeRunnerFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if got := ErrorCodeOf(err); got != tt.want {
+				t.Fatalf("expected %s, got %q (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// publishEvents sends events to the sinks in cfg. Like notifications,
+// delivery failures come back as warnings so an unreachable sink never
+// fails a coverage run.
+func (s *Service) publishEvents(ctx context.Context, cfg EventsConfig, source string, events []domain.DomainEvent) []string {
+	if !cfg.Enabled() || s.Events == nil || len(events) == 0 {
+		return nil
+	}
+	if err := s.Events.Publish(ctx, cfg, source, events); err != nil {
+		return []string{fmt.Sprintf("events not published: %v", err)}
+	}
+	return nil
+}
+
+// publishCheckEvents publishes the policy evaluation of a check result and,
+// when the check has a history store, its trend against the latest recorded
+// entry.
+func (s *Service) publishCheckEvents(ctx context.Context, opts CheckOptions, result domain.Result) []string {
+	if s.Events == nil {
+		return nil
+	}
+	exists, err := s.ConfigLoader.Exists(opts.ConfigPath)
+	if err != nil || !exists {
+		return nil
+	}
+	cfg, err := s.ConfigLoader.Load(opts.ConfigPath)
+	if err != nil || !cfg.Events.Enabled() {
+		return nil
+	}
+	current := domain.EntryFromResult(result, timeNow())
+	events := append(evaluationEvents(result), trendEvents(latestHistoryEntry(opts.HistoryStore), &current)...)
+	return s.publishEvents(ctx, cfg.Events, "check", events)
+}
+
+// evaluationEvents replays a result's domains through PolicyAggregate, which
+// raises a ThresholdViolated event per failing domain and a closing
+// CoverageEvaluated event.
+func evaluationEvents(result domain.Result) []domain.DomainEvent {
+	policy := domain.Policy{Domains: make([]domain.Domain, 0, len(result.Domains))}
+	coverage := make(map[string]domain.CoverageStat, len(result.Domains))
+	for _, d := range result.Domains {
+		required := d.Required
+		policy.Domains = append(policy.Domains, domain.Domain{Name: d.Domain, Min: &required})
+		coverage[d.Domain] = domain.CoverageStat{Covered: d.Covered, Total: d.Total}
+	}
+	_, events, err := domain.EvaluateWithAggregate(policy, coverage)
+	if err != nil {
+		return nil
+	}
+	return events
+}
+
+// trendEvents returns the CoverageImproved and CoverageRegressed events
+// TrendAnalysisService raises between two entries.
+func trendEvents(previous, current *domain.HistoryEntry) []domain.DomainEvent {
+	analysis := domain.NewTrendAnalysisService()
+	analysis.AnalyzeTrend(previous, current)
+	return analysis.Events()
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeEventPublisher struct {
+	cfg     EventsConfig
+	sources []string
+	events  []domain.DomainEvent
+	err     error
+}
+
+func (f *fakeEventPublisher) Publish(_ context.Context, cfg EventsConfig, source string, events []domain.DomainEvent) error {
+	f.cfg = cfg
+	f.sources = append(f.sources, source)
+	f.events = append(f.events, events...)
+	return f.err
+}
+
+func eventTypes(events []domain.DomainEvent) []string {
+	types := make([]string, 0, len(events))
+	for _, e := range events {
+		types = append(types, e.EventType())
+	}
+	return types
+}
+
+func TestCheckPublishesEvents(t *testing.T) {
+	min := 90.0
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}},
+		Events:  EventsConfig{File: ".cover/events.jsonl"},
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{{
+		Overall: 80,
+		Domains: map[string]domain.DomainEntry{"core": {Name: "core", Percent: 80}},
+	}}}}
+	publisher := &fakeEventPublisher{}
+	svc := notifyTestService(cfg, nil)
+	svc.Events = publisher
+
+	opts := CheckOptions{ConfigPath: ".coverctl.yaml", HistoryStore: store}
+	result, err := svc.CheckResult(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("check result: %v", err)
+	}
+	if warnings := svc.publishCheckEvents(context.Background(), opts, result); len(warnings) != 0 {
+		t.Fatalf("unexpected warnings %v", warnings)
+	}
+	got := strings.Join(eventTypes(publisher.events), ",")
+	if got != "ThresholdViolated,CoverageEvaluated,CoverageRegressed,CoverageRegressed" {
+		t.Fatalf("unexpected events %s", got)
+	}
+	if publisher.sources[0] != "check" || publisher.cfg.File != ".cover/events.jsonl" {
+		t.Fatalf("unexpected publish call %+v", publisher)
+	}
+	violated := publisher.events[0].(domain.ThresholdViolatedEvent)
+	if violated.DomainName != "core" || violated.Actual != 70 || violated.Required != 90 {
+		t.Fatalf("unexpected violation %+v", violated)
+	}
+}
+
+func TestRecordPublishesTrendEvents(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+		Events:  EventsConfig{Webhook: "https://hooks.example.com/events"},
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{{
+		Overall: 60,
+		Domains: map[string]domain.DomainEntry{"core": {Name: "core", Percent: 60}},
+	}}}}
+	publisher := &fakeEventPublisher{err: errors.New("connection refused")}
+	svc := notifyTestService(cfg, nil)
+	svc.Events = publisher
+
+	result, err := svc.RecordWithWarnings(context.Background(), RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out"}, store)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if got := strings.Join(eventTypes(publisher.events), ","); got != "CoverageImproved,CoverageImproved" || publisher.sources[0] != "record" {
+		t.Fatalf("unexpected events %s from %v", got, publisher.sources)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "events not published: connection refused") {
+		t.Fatalf("expected delivery failure as warning, got %v", result.Warnings)
+	}
+}
+
+func TestEventsSkippedWithoutSinks(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+	}
+	publisher := &fakeEventPublisher{}
+	svc := notifyTestService(cfg, nil)
+	svc.Events = publisher
+
+	if _, err := svc.RecordWithWarnings(context.Background(), RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out"}, &memoryHistoryStore{}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if len(publisher.sources) != 0 {
+		t.Fatalf("expected nothing published, got %+v", publisher)
+	}
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// defaultExplainFiles is how many files explain-failure lists per domain.
+const defaultExplainFiles = 3
+
+// ExplainFailure turns failing domains into remediation steps: current
+// against required coverage, how many more statements to cover, the
+// lowest-covered files, and commands that reproduce the failure locally.
+// Failing domains come from opts.Report when set (a --report-file from an
+// earlier run) and from evaluating opts.Profile otherwise; files always
+// come from the profile.
+func (s *Service) ExplainFailure(ctx context.Context, opts ExplainOptions) (ExplainResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return ExplainResult{}, err
+	}
+	var result domain.Result
+	if opts.Report != nil {
+		if opts.Report.Result == nil {
+			return ExplainResult{}, fmt.Errorf("report holds no coverage result: %s", orUnknown(opts.Report.Error))
+		}
+		result = *opts.Report.Result
+	} else if result, err = s.ReportResult(ctx, ReportOptions{ConfigPath: opts.ConfigPath, Profile: opts.Profile}); err != nil {
+		return ExplainResult{}, err
+	}
+
+	explain := ExplainResult{Passed: true, Domains: []DomainExplanation{}}
+	byName := domainsByName(domains)
+	for _, d := range result.Domains {
+		if d.Status != domain.StatusFail {
+			continue
+		}
+		explain.Passed = false
+		metric := d.Metric
+		if metric == "" {
+			metric = domain.MetricStatements
+		}
+		explain.Domains = append(explain.Domains, DomainExplanation{
+			Domain:   d.Domain,
+			Percent:  d.Percent,
+			Required: d.Required,
+			Covered:  d.Covered,
+			Total:    d.Total,
+			Metric:   metric,
+			ToCover:  d.ToCover(cfg.Policy.Rounding),
+			Owners:   d.Owners,
+			Files:    []domain.UncoveredFile{},
+			Commands: explainCommands(cfg, d.Domain, byName[d.Domain].Match, opts),
+		})
+	}
+	if explain.Passed {
+		return explain, nil
+	}
+
+	profiles := append([]string{opts.Profile}, cfg.Merge.Profiles...)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		if opts.Report == nil {
+			return ExplainResult{}, err
+		}
+		explain.Warnings = append(explain.Warnings, fmt.Sprintf("cannot list files from %s: %v", opts.Profile, err))
+		return explain, nil
+	}
+	files := lowestCoveredFiles(covCtx, cfg.Exclude)
+	limit := opts.Files
+	if limit <= 0 {
+		limit = defaultExplainFiles
+	}
+	for i := range explain.Domains {
+		domainFiles := files[explain.Domains[i].Domain]
+		domain.SortLowestCovered(domainFiles)
+		if len(domainFiles) > limit {
+			domainFiles = domainFiles[:limit]
+		}
+		explain.Domains[i].Files = append(explain.Domains[i].Files, domainFiles...)
+	}
+	return explain, nil
+}
+
+// lowestCoveredFiles groups the files with uncovered statements by the
+// domains they count toward.
+func lowestCoveredFiles(covCtx *coverageContext, exclude []string) map[string][]domain.UncoveredFile {
+	out := make(map[string][]domain.UncoveredFile)
+	for file, stat := range covCtx.NormalizedCoverage {
+		if stat.Uncovered() == 0 || excluded(file, exclude) {
+			continue
+		}
+		owners, ignored := fileDomains(file, covCtx)
+		if ignored {
+			continue
+		}
+		for _, name := range owners {
+			out[name] = append(out[name], domain.NewUncoveredFile(file, stat))
+		}
+	}
+	return out
+}
+
+// explainCommands returns the commands that reproduce a domain's failure:
+// the coverctl check limited to it and, for Go, the underlying go test run
+// and an HTML view of its profile.
+func explainCommands(cfg Config, name string, match []string, opts ExplainOptions) []string {
+	var flags string
+	if opts.ConfigPath != "" && opts.ConfigPath != ".coverctl.yaml" {
+		flags = " -c " + shellQuote(opts.ConfigPath)
+	}
+	commands := []string{"coverctl check" + flags + " --domain " + shellQuote(name)}
+	if !isGoConfig(cfg, match) {
+		return commands
+	}
+	profile := ".cover/" + name + ".out"
+	packages := make([]string, len(match))
+	for i, pattern := range match {
+		packages[i] = shellQuote(pattern)
+	}
+	return append(commands,
+		"go test -coverprofile="+shellQuote(profile)+" "+strings.Join(packages, " "),
+		"go tool cover -html="+shellQuote(profile),
+	)
+}
+
+// isGoConfig reports whether a domain's tests run with go test: the
+// project is Go, or undetected and every pattern is a Go package path.
+func isGoConfig(cfg Config, match []string) bool {
+	switch cfg.Language {
+	case LanguageGo:
+		return len(match) > 0
+	case "", LanguageAuto:
+		for _, pattern := range match {
+			if !strings.HasPrefix(pattern, "./") {
+				return false
+			}
+		}
+		return len(match) > 0
+	}
+	return false
+}
+
+// shellQuote single-quotes s when it holds characters a POSIX shell would
+// interpret.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,+@") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown error"
+	}
+	return s
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestExplainFailure(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+	}
+	svc := notifyTestService(cfg, nil)
+	svc.ProfileParser = fakeParser{stats: map[string]domain.CoverageStat{
+		"internal/core/a.go": {Covered: 7, Total: 10},
+		"internal/core/b.go": {Covered: 1, Total: 10},
+		"internal/core/c.go": {Covered: 5, Total: 10},
+		"internal/core/d.go": {Covered: 10, Total: 10},
+		"internal/core/e.go": {Covered: 0, Total: 4},
+	}}
+	opts := ExplainOptions{ConfigPath: ".coverctl.yaml", Profile: ".cover/coverage.out"}
+
+	result, err := svc.ExplainFailure(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	if result.Passed || len(result.Domains) != 1 {
+		t.Fatalf("expected one failing domain, got %+v", result)
+	}
+	core := result.Domains[0]
+	if core.Covered != 23 || core.Total != 44 || core.Required != 80 || core.ToCover != 13 || core.Metric != domain.MetricStatements {
+		t.Fatalf("unexpected explanation %+v", core)
+	}
+	var files []string
+	for _, f := range core.Files {
+		files = append(files, f.File)
+	}
+	if want := []string{"internal/core/e.go", "internal/core/b.go", "internal/core/c.go"}; !reflect.DeepEqual(files, want) {
+		t.Fatalf("expected lowest-covered files %v, got %v", want, files)
+	}
+	wantCommands := []string{
+		"coverctl check --domain core",
+		"go test -coverprofile=.cover/core.out ./internal/core/...",
+		"go tool cover -html=.cover/core.out",
+	}
+	if !reflect.DeepEqual(core.Commands, wantCommands) {
+		t.Fatalf("expected commands %v, got %v", wantCommands, core.Commands)
+	}
+
+	// A passing report has nothing to explain, whatever the profile says.
+	opts.Report = &RunReport{Passed: true, Result: &domain.Result{Passed: true, Domains: []domain.DomainResult{{Domain: "core", Status: domain.StatusPass}}}}
+	if result, err := svc.ExplainFailure(context.Background(), opts); err != nil || !result.Passed || len(result.Domains) != 0 {
+		t.Fatalf("expected a passing report to explain nothing, got %+v, %v", result, err)
+	}
+	opts.Report = &RunReport{Error: "runner failed"}
+	if _, err := svc.ExplainFailure(context.Background(), opts); err == nil {
+		t.Fatal("expected an error for a report without a result")
+	}
+}
+
+func TestExplainCommands(t *testing.T) {
+	opts := ExplainOptions{ConfigPath: "policy/coverage config.yaml"}
+	got := explainCommands(Config{Language: LanguagePython}, "api", []string{"src/api"}, opts)
+	if want := []string{"coverctl check -c 'policy/coverage config.yaml' --domain api"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
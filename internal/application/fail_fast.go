@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// failFast stops a check's per-domain test runs once a finished domain
+// falls below its minimum, so an obviously failing branch reports without
+// running the remaining domains' tests.
+type failFast struct {
+	ctx     context.Context
+	service *Service
+	cfg     Config
+	ran     map[string]bool
+	failed  []domain.DomainResult // Domains that stopped the run
+}
+
+func newFailFast(ctx context.Context, s *Service, cfg Config) *failFast {
+	return &failFast{ctx: ctx, service: s, cfg: cfg, ran: map[string]bool{}}
+}
+
+// after is RunOptions.AfterDomains. It evaluates the domains just run on the
+// profiles produced so far (plus merge.profiles) and stops when any fails.
+// Coverage a later domain's tests would add is not counted, which is the
+// price of stopping early.
+func (f *failFast) after(done []domain.Domain, profiles []string) bool {
+	for _, d := range done {
+		f.ran[d.Name] = true
+	}
+	profiles = append(append([]string(nil), profiles...), f.cfg.Merge.Profiles...)
+	covCtx, err := f.service.prepareCoverageContext(f.ctx, f.cfg, done, profiles)
+	if err != nil {
+		// Leave the error to the full evaluation after the runs.
+		return false
+	}
+	policy := f.cfg.Policy
+	policy.Domains = done
+	for _, d := range domain.Evaluate(policy, covCtx.DomainCoverage).Domains {
+		if d.IsFailing() {
+			f.failed = append(f.failed, d)
+		}
+	}
+	return len(f.failed) > 0
+}
+
+// trim drops the domains whose tests were skipped from domains, returning a
+// warning naming them. It returns domains unchanged when the run was not
+// stopped.
+func (f *failFast) trim(domains []domain.Domain) ([]domain.Domain, []string) {
+	if len(f.failed) == 0 {
+		return domains, nil
+	}
+	var kept []domain.Domain
+	var skipped []string
+	for _, d := range domains {
+		if f.ran[d.Name] {
+			kept = append(kept, d)
+		} else {
+			skipped = append(skipped, d.Name)
+		}
+	}
+	failed := make([]string, len(f.failed))
+	for i, d := range f.failed {
+		failed[i] = fmt.Sprintf("%s (%.1f%% < %.1f%%)", d.Domain, d.Percent, d.Required)
+	}
+	return kept, []string{fmt.Sprintf("fail-fast: %s below minimum; skipped tests for %s", strings.Join(failed, ", "), strings.Join(skipped, ", "))}
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func failFastTestService(runner CoverageRunner) *Service {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "db", Match: []string{"./internal/db/..."}, TestArgs: []string{"-tags=integration"}},
+			{Name: "web", Match: []string{"./internal/web/..."}, TestArgs: []string{"-p=1"}},
+		}},
+	}
+	svc := newTestService(cfg, map[string][]string{
+		"core": {"/repo/internal/core"},
+		"db":   {"/repo/internal/db"},
+		"web":  {"/repo/internal/web"},
+	}, fakeParser{stats: map[string]domain.CoverageStat{
+		"internal/core/a.go": {Covered: 9, Total: 10},
+		"internal/db/a.go":   {Covered: 2, Total: 10},
+		"internal/web/a.go":  {Covered: 9, Total: 10},
+	}})
+	svc.CoverageRunner = runner
+	return svc
+}
+
+func TestCheckFailFastSkipsRemainingDomains(t *testing.T) {
+	runner := &recordingRunner{}
+	svc := failFastTestService(runner)
+
+	result, err := svc.CheckResult(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", FailFast: true})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(runner.calls) != 2 {
+		t.Fatalf("expected the shared and db runs only, got %d runs", len(runner.calls))
+	}
+	if result.Passed {
+		t.Fatal("expected the check to fail on db")
+	}
+	var names []string
+	for _, d := range result.Domains {
+		names = append(names, d.Domain)
+	}
+	if strings.Join(names, ",") != "core,db" {
+		t.Fatalf("expected skipped web to be left out, got %v", names)
+	}
+	want := "fail-fast: db (20.0% < 50.0%) below minimum; skipped tests for web"
+	found := false
+	for _, w := range result.Warnings {
+		found = found || w == want
+	}
+	if !found {
+		t.Fatalf("expected warning %q, got %v", want, result.Warnings)
+	}
+}
+
+func TestCheckWithoutFailFastRunsEveryDomain(t *testing.T) {
+	runner := &recordingRunner{}
+	svc := failFastTestService(runner)
+
+	result, err := svc.CheckResult(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml"})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(runner.calls) != 3 || len(result.Domains) != 3 {
+		t.Fatalf("expected 3 runs and 3 domains, got %d runs and %d domains", len(runner.calls), len(result.Domains))
+	}
+}
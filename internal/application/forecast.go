@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Forecast projects each configured domain's coverage from its recorded
+// history and estimates when domains below their threshold will reach it.
+func (s *Service) Forecast(ctx context.Context, opts ForecastOptions, store HistoryStore) (ForecastResult, error) {
+	if opts.Horizon < 1 {
+		return ForecastResult{}, errors.New("forecast horizon must be at least 1")
+	}
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return ForecastResult{}, err
+	}
+	history, err := store.Load()
+	if err != nil {
+		return ForecastResult{}, err
+	}
+	if len(history.Entries) < 2 {
+		return ForecastResult{}, fmt.Errorf("forecast needs at least two history entries; run 'coverctl record' after coverage runs")
+	}
+
+	result := ForecastResult{Horizon: opts.Horizon, Lookback: opts.Lookback}
+	analysis := domain.NewTrendAnalysisService()
+	for _, d := range domains {
+		min := cfg.Policy.DefaultMin
+		if d.Min != nil {
+			min = *d.Min
+		}
+		forecast, ok := analysis.ForecastDomain(&history, d.Name, opts.Lookback, opts.Horizon, min)
+		if !ok {
+			result.Skipped = append(result.Skipped, d.Name)
+			continue
+		}
+		result.Domains = append(result.Domains, forecast)
+	}
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestForecast(t *testing.T) {
+	min := 80.0
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}, Min: &min},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+	}
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryHistoryStore{}
+	for i, p := range []float64{60, 65, 70} {
+		store.history.Entries = append(store.history.Entries, domain.HistoryEntry{
+			Timestamp: start.Add(time.Duration(i) * 24 * time.Hour),
+			Domains:   map[string]domain.DomainEntry{"core": {Name: "core", Percent: p}},
+		})
+	}
+	svc := notifyTestService(cfg, nil)
+
+	result, err := svc.Forecast(context.Background(), ForecastOptions{ConfigPath: ".coverctl.yaml", Horizon: 2}, store)
+	if err != nil {
+		t.Fatalf("forecast: %v", err)
+	}
+	if len(result.Domains) != 1 || len(result.Skipped) != 1 || result.Skipped[0] != "api" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	core := result.Domains[0]
+	if core.Min != 80 || core.Predicted != 80 || core.ReachesMin == nil || !core.ReachesMin.Equal(start.Add(4*24*time.Hour)) {
+		t.Fatalf("unexpected core forecast %+v", core)
+	}
+
+	if _, err := svc.Forecast(context.Background(), ForecastOptions{ConfigPath: ".coverctl.yaml"}, store); err == nil {
+		t.Fatal("expected an error for a zero horizon")
+	}
+	_, err = svc.Forecast(context.Background(), ForecastOptions{ConfigPath: ".coverctl.yaml", Horizon: 1}, &memoryHistoryStore{})
+	if err == nil || !strings.Contains(err.Error(), "at least two history entries") {
+		t.Fatalf("expected a history error, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// applyFunctionCoverage enforces functions.min on the functions of the
+// files in coverage, which is keyed by module-relative path. Excluded files
+// and functions matching exclude.functions do not count. Function coverage
+// needs statement blocks (Go profiles) and a scanner that locates
+// functions; without them it is skipped with a warning.
+func applyFunctionCoverage(ctx context.Context, parser ProfileParser, scanner AnnotationScanner, cfg Config, profiles []string, moduleRoot, modulePath string, coverage map[string]domain.CoverageStat, result *domain.Result) error {
+	if cfg.Functions.Min == nil {
+		return nil
+	}
+	blockParser, ok := parser.(BlockProfileParser)
+	if !ok {
+		result.Warnings = append(result.Warnings, "functions.min is set but the profile parser cannot report statement blocks; function coverage skipped")
+		return nil
+	}
+	funcScanner, ok := scanner.(FunctionScanner)
+	if !ok {
+		result.Warnings = append(result.Warnings, "functions.min is set but the annotation scanner cannot locate functions; function coverage skipped")
+		return nil
+	}
+	patterns, err := compileFunctionPatterns(cfg.ExcludeFunctions)
+	if err != nil {
+		return WithErrorCode(ErrCodeConfigInvalid, err)
+	}
+
+	blocks, err := moduleBlocks(blockParser, cfg, profiles, moduleRoot, modulePath)
+	if err != nil {
+		return fmt.Errorf("function coverage: %w", err)
+	}
+	files := make([]string, 0, len(blocks))
+	for file := range blocks {
+		if _, ok := coverage[file]; ok && !excluded(file, cfg.Exclude) {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	spans, err := funcScanner.ScanFunctions(ctx, moduleRoot, files)
+	if err != nil {
+		return fmt.Errorf("function coverage: %w", err)
+	}
+	for file, fns := range spans {
+		var kept []domain.FunctionSpan
+		for _, fn := range fns {
+			if !matchesFunction(fn.Name, patterns) {
+				kept = append(kept, fn)
+			}
+		}
+		spans[file] = kept
+	}
+
+	functions := domain.EvaluateFunctions(spans, blocks, *cfg.Functions.Min, cfg.Policy.Rounding)
+	result.Functions = &functions
+	if functions.Status == domain.StatusFail {
+		result.Passed = false
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// excludeFunctions drops the statements of functions matching
+// cfg.ExcludeFunctions from coverage, which is keyed by module-relative
+// path. Without a parser that reports blocks or a scanner that locates
+// functions the coverage is returned unchanged.
+func excludeFunctions(ctx context.Context, parser ProfileParser, scanner AnnotationScanner, cfg Config, profiles []string, moduleRoot, modulePath string, coverage map[string]domain.CoverageStat) (map[string]domain.CoverageStat, error) {
+	if len(cfg.ExcludeFunctions) == 0 {
+		return coverage, nil
+	}
+	blockParser, ok := parser.(BlockProfileParser)
+	if !ok {
+		return coverage, nil
+	}
+	funcScanner, ok := scanner.(FunctionScanner)
+	if !ok {
+		return coverage, nil
+	}
+	patterns, err := compileFunctionPatterns(cfg.ExcludeFunctions)
+	if err != nil {
+		return nil, WithErrorCode(ErrCodeConfigInvalid, err)
+	}
+
+	blocks, err := moduleBlocks(blockParser, cfg, profiles, moduleRoot, modulePath)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(blocks))
+	for file := range blocks {
+		if _, ok := coverage[file]; ok {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	spans, err := funcScanner.ScanFunctions(ctx, moduleRoot, files)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]domain.CoverageStat, len(coverage))
+	for file, stat := range coverage {
+		var ranges []domain.LineRange
+		for _, fn := range spans[file] {
+			if matchesFunction(fn.Name, patterns) {
+				ranges = append(ranges, fn.Lines)
+			}
+		}
+		if len(ranges) > 0 {
+			stat = domain.ExcludeBlocks(stat, blocks[file], ranges)
+		}
+		result[file] = stat
+	}
+	return result, nil
+}
+
+// moduleBlocks parses the statement blocks of profiles keyed by
+// module-relative path, the same keys as normalized coverage.
+func moduleBlocks(parser BlockProfileParser, cfg Config, profiles []string, moduleRoot, modulePath string) (map[string][]domain.CoverageBlock, error) {
+	raw, err := parser.ParseAllBlocks(profiles)
+	if err != nil {
+		return nil, err
+	}
+	links := mergeSymlinks(cfg.Merge, moduleRoot)
+	blocks := make(map[string][]domain.CoverageBlock, len(raw))
+	for file, b := range raw {
+		rel := coverageKey(file, moduleRoot, modulePath, cfg.Merge.PathMappings, links)
+		blocks[rel] = append(blocks[rel], b...)
+	}
+	return blocks, nil
+}
+
+// compileFunctionPatterns compiles exclude.functions regexps.
+func compileFunctionPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("exclude.functions %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func matchesFunction(name string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
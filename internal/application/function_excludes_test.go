@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeBlockParser struct {
+	fakeParser
+	blocks map[string][]domain.CoverageBlock
+}
+
+func (f fakeBlockParser) ParseAllBlocks([]string) (map[string][]domain.CoverageBlock, error) {
+	return f.blocks, nil
+}
+
+type fakeFunctionScanner struct {
+	spans map[string][]domain.FunctionSpan
+}
+
+func (fakeFunctionScanner) Scan(context.Context, string, []string) (map[string]Annotation, error) {
+	return nil, nil
+}
+
+func (f fakeFunctionScanner) ScanFunctions(context.Context, string, []string) (map[string][]domain.FunctionSpan, error) {
+	return f.spans, nil
+}
+
+func TestExcludeFunctionsDropsMatchingFunctions(t *testing.T) {
+	coverage := map[string]domain.CoverageStat{"pkg/a.go": {Covered: 2, Total: 5}}
+	parser := fakeBlockParser{blocks: map[string][]domain.CoverageBlock{
+		"example.com/mod/pkg/a.go": {
+			{Lines: domain.LineRange{Start: 4, End: 4}, Stat: domain.CoverageStat{Covered: 0, Total: 3}},
+			{Lines: domain.LineRange{Start: 8, End: 9}, Stat: domain.CoverageStat{Covered: 2, Total: 2}},
+		},
+	}}
+	scanner := fakeFunctionScanner{spans: map[string][]domain.FunctionSpan{
+		"pkg/a.go": {
+			{Name: "Thing.String()", Lines: domain.LineRange{Start: 3, End: 5}},
+			{Name: "Run()", Lines: domain.LineRange{Start: 7, End: 10}},
+		},
+	}}
+	cfg := Config{ExcludeFunctions: []string{`String\(\)$`}}
+
+	got, err := excludeFunctions(context.Background(), parser, scanner, cfg, []string{"cover.out"}, "/repo", "example.com/mod", coverage)
+	if err != nil {
+		t.Fatalf("exclude: %v", err)
+	}
+	if got["pkg/a.go"] != (domain.CoverageStat{Covered: 2, Total: 2}) {
+		t.Fatalf("expected String() dropped, got %+v", got["pkg/a.go"])
+	}
+
+	// A parser without block support leaves coverage untouched.
+	got, err = excludeFunctions(context.Background(), fakeParser{}, scanner, cfg, nil, "/repo", "example.com/mod", coverage)
+	if err != nil || got["pkg/a.go"] != coverage["pkg/a.go"] {
+		t.Fatalf("expected unchanged coverage, got %+v (%v)", got, err)
+	}
+}
+
+func TestExcludeFunctionsInvalidPattern(t *testing.T) {
+	cfg := Config{ExcludeFunctions: []string{"("}}
+	_, err := excludeFunctions(context.Background(), fakeBlockParser{}, fakeFunctionScanner{}, cfg, nil, "", "", nil)
+	if ErrorCodeOf(err) != ErrCodeConfigInvalid {
+		t.Fatalf("expected config error, got %v", err)
+	}
+}
+
+func TestApplyFunctionCoverage(t *testing.T) {
+	coverage := map[string]domain.CoverageStat{"pkg/a.go": {Covered: 2, Total: 7}}
+	parser := fakeBlockParser{blocks: map[string][]domain.CoverageBlock{
+		"example.com/mod/pkg/a.go": {
+			{Lines: domain.LineRange{Start: 4, End: 4}, Stat: domain.CoverageStat{Covered: 0, Total: 3}},
+			{Lines: domain.LineRange{Start: 8, End: 9}, Stat: domain.CoverageStat{Covered: 2, Total: 2}},
+			{Lines: domain.LineRange{Start: 13, End: 13}, Stat: domain.CoverageStat{Covered: 0, Total: 2}},
+		},
+	}}
+	scanner := fakeFunctionScanner{spans: map[string][]domain.FunctionSpan{
+		"pkg/a.go": {
+			{Name: "Thing.String()", Lines: domain.LineRange{Start: 3, End: 5}},
+			{Name: "Run()", Lines: domain.LineRange{Start: 7, End: 10}},
+			{Name: "stop()", Lines: domain.LineRange{Start: 12, End: 14}},
+		},
+	}}
+	min := 50.0
+	cfg := Config{Functions: FunctionsConfig{Min: &min}, ExcludeFunctions: []string{`String\(\)$`}}
+
+	result := domain.Result{Passed: true}
+	if err := applyFunctionCoverage(context.Background(), parser, scanner, cfg, []string{"cover.out"}, "/repo", "example.com/mod", coverage, &result); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	fc := result.Functions
+	if fc == nil || fc.Covered != 1 || fc.Total != 2 || fc.Status != domain.StatusPass || !result.Passed {
+		t.Fatalf("expected 1 of 2 functions with String() excluded, got %+v", fc)
+	}
+
+	// A parser without block support skips the check with a warning.
+	result = domain.Result{Passed: true}
+	if err := applyFunctionCoverage(context.Background(), fakeParser{}, scanner, cfg, nil, "/repo", "example.com/mod", coverage, &result); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if result.Functions != nil || len(result.Warnings) != 1 {
+		t.Fatalf("expected a skipped check, got %+v", result)
+	}
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Gate runs the full check pipeline once and folds policy, file rules,
+// patch coverage, ratchet, fail-under, group minimums, functions.min, and
+// expired exceptions into a single verdict. The ratchet
+// compares against the latest entry in opts.HistoryStore, when one is set
+// and opts.Ratchet is enabled.
+func (s *Service) Gate(ctx context.Context, opts CheckOptions) (gate domain.Gate, err error) {
+	report := s.startRunReport(opts.ReportFile, "gate", opts.ConfigPath)
+	defer func() { err = report.finish(err) }()
+	result, err := s.CheckResult(ctx, opts)
+	if err != nil {
+		return domain.Gate{}, err
+	}
+
+	var previous *domain.HistoryEntry
+	if opts.Ratchet && opts.HistoryStore != nil {
+		hist, err := opts.HistoryStore.Load()
+		if err != nil {
+			return domain.Gate{}, err
+		}
+		previous = hist.LatestEntry()
+	}
+	gate = domain.EvaluateGate(result, previous, opts.FailUnder)
+	report.setGate(gate)
+	return gate, nil
+}
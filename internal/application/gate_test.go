@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestServiceGate(t *testing.T) {
+	min := 70.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 70, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		CoverageRunner: fakeRunner{profile: ".cover/coverage.out"},
+		ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}},
+		Reporter:       &fakeReporter{},
+		Out:            io.Discard,
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{{Overall: 85}}}}
+
+	gate, err := svc.Gate(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", HistoryStore: store, Ratchet: true})
+	if err != nil {
+		t.Fatalf("gate: %v", err)
+	}
+	if gate.Passed {
+		t.Fatal("expected ratchet regression from 85% to 80% to fail the gate")
+	}
+	if !gate.Result.Passed {
+		t.Fatal("expected policy itself to pass")
+	}
+
+	gate, err = svc.Gate(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", HistoryStore: store})
+	if err != nil {
+		t.Fatalf("gate: %v", err)
+	}
+	if !gate.Passed {
+		t.Fatalf("expected gate to pass without ratchet, got %+v", gate.Checks)
+	}
+}
+
+func TestServiceGateNewDomainWarn(t *testing.T) {
+	min := 90.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, NewDomain: domain.NewDomainWarn, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		CoverageRunner: fakeRunner{profile: ".cover/coverage.out"},
+		ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}},
+		Reporter:       &fakeReporter{},
+		Out:            io.Discard,
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{
+		{Overall: 70, Domains: map[string]domain.DomainEntry{"api": {Name: "api", Percent: 70}}},
+	}}}
+
+	gate, err := svc.Gate(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", HistoryStore: store, BaselineStore: store, Ratchet: true})
+	if err != nil {
+		t.Fatalf("gate: %v", err)
+	}
+	if !gate.Passed {
+		t.Fatalf("expected new domain below its minimum to warn, got %+v", gate.Checks)
+	}
+	if len(gate.Result.NewDomains) != 1 || gate.Result.Domains[0].Status != domain.StatusWarn {
+		t.Fatalf("expected core reported as a new warning domain, got %+v", gate.Result)
+	}
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// checkGoalLookback is how many recent entries check projects goals from,
+// the goals command's default.
+const checkGoalLookback = 10
+
+// Goals measures each configured goal against recorded history.
+func (s *Service) Goals(ctx context.Context, opts GoalsOptions, store HistoryStore) (GoalsResult, error) {
+	cfg, _, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return GoalsResult{}, err
+	}
+	history, err := store.Load()
+	if err != nil {
+		return GoalsResult{}, err
+	}
+	progress, err := trackGoals(cfg.Goals, &history, opts.Lookback, timeNow())
+	if err != nil {
+		return GoalsResult{}, err
+	}
+	return GoalsResult{Goals: progress}, nil
+}
+
+func trackGoals(goals []domain.Goal, history *domain.History, lookback int, now time.Time) ([]domain.GoalProgress, error) {
+	analysis := domain.NewTrendAnalysisService()
+	progress := make([]domain.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		p, err := analysis.TrackGoal(history, goal, lookback, now)
+		if err != nil {
+			return nil, fmt.Errorf("goal %s: %w", goal.Domain, err)
+		}
+		progress = append(progress, p)
+	}
+	return progress, nil
+}
+
+// goalWarnings warns about goals with warn set that are off track or
+// missed, counting the checked result as the newest history entry.
+func (s *Service) goalWarnings(opts CheckOptions, result domain.Result) []string {
+	if opts.BaselineStore == nil {
+		return nil
+	}
+	exists, err := s.ConfigLoader.Exists(opts.ConfigPath)
+	if err != nil || !exists {
+		return nil
+	}
+	cfg, err := s.ConfigLoader.Load(opts.ConfigPath)
+	if err != nil {
+		return nil
+	}
+	var goals []domain.Goal
+	for _, goal := range cfg.Goals {
+		if goal.Warn {
+			goals = append(goals, goal)
+		}
+	}
+	if len(goals) == 0 {
+		return nil
+	}
+	history, err := opts.BaselineStore.Load()
+	if err != nil {
+		return nil
+	}
+	now := timeNow()
+	history.Entries = append(append([]domain.HistoryEntry(nil), history.Entries...), domain.EntryFromResult(result, now))
+	progress, err := trackGoals(goals, &history, checkGoalLookback, now)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var warnings []string
+	for _, p := range progress {
+		switch p.Status {
+		case domain.GoalMissed:
+			warnings = append(warnings, fmt.Sprintf("goal %s %.1f%% by %s was missed: %.1f%%", p.Domain, p.Target, p.By, p.Current))
+		case domain.GoalOffTrack:
+			reaches := "not reached at the current trend"
+			if p.ReachesTarget != nil {
+				reaches = "reached around " + p.ReachesTarget.Format(domain.ExceptionDateLayout) + " at the current trend"
+			}
+			warnings = append(warnings, fmt.Sprintf("goal %s %.1f%% by %s is off track: %.1f%%, %s", p.Domain, p.Target, p.By, p.Current, reaches))
+		}
+	}
+	return warnings
+}
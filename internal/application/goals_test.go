@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func goalHistory(start time.Time, percents ...float64) *memoryHistoryStore {
+	store := &memoryHistoryStore{}
+	for i, p := range percents {
+		store.history.Entries = append(store.history.Entries, domain.HistoryEntry{
+			Timestamp: start.Add(time.Duration(i) * 24 * time.Hour),
+			Domains:   map[string]domain.DomainEntry{"core": {Name: "core", Percent: p}},
+		})
+	}
+	return store
+}
+
+func TestGoals(t *testing.T) {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	orig := timeNow
+	timeNow = func() time.Time { return start.Add(3 * 24 * time.Hour) }
+	t.Cleanup(func() { timeNow = orig })
+
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+		Goals: []domain.Goal{
+			{Domain: "core", Target: 80, By: "2026-Q2"},
+			{Domain: "api", Target: 80, By: "2026-Q2"},
+		},
+	}
+	svc := notifyTestService(cfg, nil)
+
+	result, err := svc.Goals(context.Background(), GoalsOptions{ConfigPath: ".coverctl.yaml"}, goalHistory(start, 60, 65, 70))
+	if err != nil {
+		t.Fatalf("goals: %v", err)
+	}
+	if len(result.Goals) != 2 {
+		t.Fatalf("expected two goals, got %+v", result.Goals)
+	}
+	core, api := result.Goals[0], result.Goals[1]
+	if core.Status != domain.GoalOnTrack || core.Progress != 50 || core.ReachesTarget == nil || !core.ReachesTarget.Equal(start.Add(4*24*time.Hour)) {
+		t.Fatalf("unexpected core progress %+v", core)
+	}
+	if api.Status != domain.GoalUnknown {
+		t.Fatalf("expected api unknown, got %+v", api)
+	}
+}
+
+func TestCheckWarnsOffTrackGoals(t *testing.T) {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	orig := timeNow
+	timeNow = func() time.Time { return start.Add(2 * 24 * time.Hour) }
+	t.Cleanup(func() { timeNow = orig })
+
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+		Goals: []domain.Goal{
+			{Domain: "core", Target: 90, By: "2026-05-04", Warn: true},
+			{Domain: "core", Target: 95, By: "2026-04-30", Warn: true},
+			{Domain: "core", Target: 99, By: "2026-05-04"},
+			{Domain: "core", Target: 75, By: "2026-Q4", Warn: true},
+		},
+	}
+	svc := notifyTestService(cfg, nil)
+	reporter := &fakeReporter{}
+	svc.Reporter = reporter
+
+	// 60% and 65% recorded, 70% checked now: 90% is reached on May 7th.
+	err := svc.Check(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", BaselineStore: goalHistory(start, 60, 65)})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	var warnings []string
+	for _, w := range reporter.last.Warnings {
+		if strings.HasPrefix(w, "goal ") {
+			warnings = append(warnings, w)
+		}
+	}
+	want := []string{
+		"goal core 90.0% by 2026-05-04 is off track: 70.0%, reached around 2026-05-07 at the current trend",
+		"goal core 95.0% by 2026-04-30 was missed: 70.0%",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected goal warnings:\n%s", strings.Join(warnings, "\n"))
+	}
+}
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Heatmap nests per-file coverage into a directory tree sized by
+// statements, so large untested areas stand out in a treemap. Files honour
+// global excludes, per-domain excludes, and ignore annotations the same way
+// policy evaluation does.
+func (s *Service) Heatmap(ctx context.Context, opts HeatmapOptions) (HeatmapResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return HeatmapResult{}, err
+	}
+	domains = filterDomainsByNames(domains, opts.Domains)
+	if len(domains) == 0 {
+		return HeatmapResult{}, errNoMatchingDomains(opts.Domains)
+	}
+
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		return HeatmapResult{}, err
+	}
+
+	files := make(map[string]domain.CoverageStat)
+	for file, stat := range covCtx.NormalizedCoverage {
+		if stat.Total == 0 || excluded(file, cfg.Exclude) {
+			continue
+		}
+		owners, ignored := fileDomains(file, covCtx)
+		if ignored || (len(opts.Domains) > 0 && !inAnyDomain(owners, covCtx.DomainDirs)) {
+			continue
+		}
+		files[file] = stat
+	}
+	return HeatmapResult{Root: domain.BuildHeatmap(files), Files: len(files)}, nil
+}
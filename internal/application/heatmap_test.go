@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestServiceHeatmap(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+		Exclude: []string{"internal/core/gen.go"},
+	}
+	stats := map[string]domain.CoverageStat{
+		"internal/core/a.go":   {Covered: 2, Total: 10},
+		"internal/core/gen.go": {Covered: 0, Total: 50},
+		"internal/api/h.go":    {Covered: 3, Total: 4},
+	}
+	newService := func(dirs map[string][]string) *Service {
+		return &Service{
+			ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+			Autodetector:   fakeAutodetector{},
+			DomainResolver: fakeResolver{dirs: dirs, moduleRoot: "/repo", modulePath: "example.com/mod"},
+			ProfileParser:  fakeParser{stats: stats},
+			Out:            io.Discard,
+		}
+	}
+
+	t.Run("builds tree without excluded files", func(t *testing.T) {
+		svc := newService(map[string][]string{"core": {"/repo/internal/core"}, "api": {"/repo/internal/api"}})
+		got, err := svc.Heatmap(context.Background(), HeatmapOptions{ProfilePath: "c.out"})
+		if err != nil {
+			t.Fatalf("heatmap: %v", err)
+		}
+		if got.Files != 2 || got.Root.Path != "internal" || got.Root.Total != 14 || len(got.Root.Children) != 2 {
+			t.Fatalf("unexpected heatmap: %+v", got)
+		}
+	})
+
+	t.Run("filters by domain", func(t *testing.T) {
+		svc := newService(map[string][]string{"core": {"/repo/internal/core"}})
+		got, err := svc.Heatmap(context.Background(), HeatmapOptions{Domains: []string{"core"}})
+		if err != nil {
+			t.Fatalf("heatmap: %v", err)
+		}
+		if got.Files != 1 || got.Root.Path != "internal/core" || got.Root.Total != 10 {
+			t.Fatalf("unexpected heatmap: %+v", got)
+		}
+	})
+}
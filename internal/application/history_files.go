@@ -0,0 +1,56 @@
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// trackedFiles returns the per-file counts record keeps when
+// history.track_files is set: every measured file outside excludes and
+// ignore annotations.
+func trackedFiles(cfg Config, covCtx *coverageContext) domain.FileHistory {
+	if !cfg.History.TrackFiles {
+		return nil
+	}
+	files := make(domain.FileHistory, len(covCtx.NormalizedCoverage))
+	for file, stat := range covCtx.NormalizedCoverage {
+		if stat.Total == 0 || excluded(file, cfg.Exclude) {
+			continue
+		}
+		if _, ignored := fileDomains(file, covCtx); ignored {
+			continue
+		}
+		files[file] = stat
+	}
+	return files
+}
+
+// fileTrend answers trend --file from recorded history alone: Current and
+// Previous are the file's last two recorded values.
+func fileTrend(history domain.History, file string) (TrendResult, error) {
+	file = filepath.ToSlash(filepath.Clean(file))
+	points := history.FileTrend(file)
+	if len(points) == 0 {
+		for _, e := range history.Entries {
+			if len(e.Files) > 0 {
+				return TrendResult{}, fmt.Errorf("no recorded coverage for %s", file)
+			}
+		}
+		return TrendResult{}, fmt.Errorf("history has no per-file coverage; set history.track_files: true and run 'coverctl record'")
+	}
+	last := points[len(points)-1]
+	result := TrendResult{
+		Current:   last.Percent,
+		Previous:  last.Percent,
+		Trend:     last.Trend,
+		Entries:   history.Entries,
+		File:      file,
+		FileTrend: points,
+	}
+	if len(points) > 1 {
+		result.Previous = points[len(points)-2].Percent
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestRecordTracksFiles(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+	}
+	opts := RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out", NoDetect: true}
+
+	store := &memoryHistoryStore{}
+	if _, err := notifyTestService(cfg, nil).RecordWithWarnings(context.Background(), opts, store); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if store.history.Entries[0].Files != nil {
+		t.Fatalf("expected no file history by default, got %+v", store.history.Entries[0].Files)
+	}
+
+	cfg.History.TrackFiles = true
+	store = &memoryHistoryStore{}
+	if _, err := notifyTestService(cfg, nil).RecordWithWarnings(context.Background(), opts, store); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	files := store.history.Entries[0].Files
+	if len(files) != 1 || files["internal/core/a.go"] != (domain.CoverageStat{Covered: 7, Total: 10}) {
+		t.Fatalf("unexpected file history: %+v", files)
+	}
+}
+
+func TestTrendFile(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC) }
+	svc := &Service{}
+
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{
+		{Timestamp: day(1), Files: domain.FileHistory{"internal/core/a.go": {Covered: 9, Total: 10}}},
+		{Timestamp: day(2), Files: domain.FileHistory{"internal/core/a.go": {Covered: 6, Total: 10}}},
+	}}}
+	got, err := svc.Trend(context.Background(), TrendOptions{File: "./internal/core/a.go"}, store)
+	if err != nil {
+		t.Fatalf("trend: %v", err)
+	}
+	if got.File != "internal/core/a.go" || len(got.FileTrend) != 2 || got.Previous != 90 || got.Current != 60 || got.Trend.Direction != domain.TrendDown {
+		t.Fatalf("unexpected file trend: %+v", got)
+	}
+
+	if _, err := svc.Trend(context.Background(), TrendOptions{File: "other.go"}, store); err == nil {
+		t.Fatal("expected error for an unrecorded file")
+	}
+	untracked := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{{Timestamp: day(1), Overall: 80}}}}
+	if _, err := svc.Trend(context.Background(), TrendOptions{File: "internal/core/a.go"}, untracked); err == nil {
+		t.Fatal("expected error when history does not track files")
+	}
+}
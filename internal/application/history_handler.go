@@ -28,16 +28,12 @@ func (h *HistoryHandler) Record(ctx context.Context, opts RecordOptions, store H
 		return err
 	}
 
-	var totalCovered, totalStatements int
+	var totalStatements int
 	domainEntries := make(map[string]domain.DomainEntry)
 	for domainName, stat := range covCtx.DomainCoverage {
-		totalCovered += stat.Covered
 		totalStatements += stat.Total
 
-		percent := 0.0
-		if stat.Total > 0 {
-			percent = domain.Round1((float64(stat.Covered) / float64(stat.Total)) * 100)
-		}
+		percent := cfg.Policy.Rounding.Percent(stat.Percent())
 
 		var min float64
 		for _, d := range domains {
@@ -51,7 +47,7 @@ func (h *HistoryHandler) Record(ctx context.Context, opts RecordOptions, store H
 		}
 
 		status := domain.StatusPass
-		if percent < min {
+		if !cfg.Policy.Rounding.Meets(stat.Percent(), min) {
 			status = domain.StatusFail
 		}
 
@@ -63,17 +59,21 @@ func (h *HistoryHandler) Record(ctx context.Context, opts RecordOptions, store H
 		}
 	}
 
-	overallPercent := 0.0
-	if totalStatements > 0 {
-		overallPercent = domain.Round1((float64(totalCovered) / float64(totalStatements)) * 100)
-	}
+	overallPercent := domain.WeightedOverall(covCtx.DomainCoverage, domains)
 
 	entry := domain.HistoryEntry{
-		Timestamp: timeNow(),
-		Commit:    opts.Commit,
-		Branch:    opts.Branch,
-		Overall:   overallPercent,
-		Domains:   domainEntries,
+		Timestamp:  timeNow(),
+		Commit:     opts.Commit,
+		Branch:     opts.Branch,
+		Overall:    overallPercent,
+		Statements: totalStatements,
+		Domains:    domainEntries,
+		Files:      trackedFiles(cfg, covCtx),
+	}
+	if opts.Signer != nil {
+		if err := signEntry(&entry, h.ProfileParser, profiles, opts.Signer); err != nil {
+			return err
+		}
 	}
 
 	return store.Append(entry)
@@ -98,13 +98,17 @@ func (h *HistoryHandler) prepareCoverageContext(ctx context.Context, cfg Config,
 		return nil, err
 	}
 
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	normalizedCoverage, err = excludeFunctions(ctx, h.ProfileParser, h.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return nil, err
+	}
 	annotations, err := loadAnnotations(ctx, h.AnnotationScanner, cfg, moduleRoot, normalizedCoverage)
 	if err != nil {
 		return nil, err
 	}
 
-	domainDirs, err := h.DomainResolver.Resolve(ctx, domains)
+	domainDirs, err := resolveDomainDirs(ctx, h.DomainResolver, domains, moduleRoot, cfg.Merge)
 	if err != nil {
 		return nil, err
 	}
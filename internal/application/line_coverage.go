@@ -0,0 +1,68 @@
+package application
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// needsLineCoverage reports whether an output format embeds per-line hits.
+func needsLineCoverage(format OutputFormat) bool {
+	return format == OutputGitLab || format == OutputAzure
+}
+
+// loadLineCoverage parses per-line hits from profiles, keyed by
+// module-relative slash path with excluded files dropped. ok is false when
+// the parser cannot report line-level data.
+func loadLineCoverage(parser ProfileParser, profiles, exclude []string, moduleRoot, modulePath string, merge MergeConfig) (lines map[string]domain.LineCoverage, ok bool, err error) {
+	lineParser, ok := parser.(LineProfileParser)
+	if !ok {
+		return nil, false, nil
+	}
+	raw, err := lineParser.ParseAllLines(profiles)
+	if err != nil {
+		return nil, true, err
+	}
+
+	links := mergeSymlinks(merge, moduleRoot)
+	lines = make(map[string]domain.LineCoverage, len(raw))
+	for file, cov := range raw {
+		rel := coverageKey(file, moduleRoot, modulePath, merge.PathMappings, links)
+		if excluded(rel, exclude) {
+			continue
+		}
+		domain.MergeLineCoverage(lines, map[string]domain.LineCoverage{rel: cov})
+	}
+	return lines, true, nil
+}
+
+// attachLineCoverage fills result.Lines for formats that need it, including
+// self-contained HTML, which embeds uncovered source. A parser without line
+// support leaves the result as is with a warning, and the writer reports the
+// missing data.
+func attachLineCoverage(format OutputFormat, html HTMLOptions, parser ProfileParser, cfg Config, profiles []string, moduleRoot, modulePath string, result *domain.Result) error {
+	if !needsLineCoverage(format) && !(format == OutputHTML && html.SelfContained) {
+		return nil
+	}
+	lines, ok, err := loadLineCoverage(parser, profiles, cfg.Exclude, moduleRoot, modulePath, cfg.Merge)
+	if err != nil {
+		return fmt.Errorf("%s output: %w", format, err)
+	}
+	if !ok {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s output needs line-level coverage, which the profile parser cannot report", format))
+		return nil
+	}
+	result.Lines = lines
+	result.SourceRoot = moduleRoot
+	return nil
+}
+
+// writeResult renders result in format, passing HTML options to reporters
+// that accept them.
+func (s *Service) writeResult(w io.Writer, result domain.Result, format OutputFormat, html HTMLOptions) error {
+	if r, ok := s.Reporter.(HTMLReporter); ok && format == OutputHTML {
+		return r.WriteHTML(w, result, html)
+	}
+	return s.Reporter.Write(w, result, format)
+}
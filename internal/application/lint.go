@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Lint looks for config that no longer matches the code: domains and
+// match patterns that resolve to no packages, exclude patterns no profile
+// file matches, file rules that give one file different minimums, and
+// coverctl:domain annotations naming a domain the config lacks. The checks
+// against files need the profile; without one they are listed as skipped.
+func (s *Service) Lint(ctx context.Context, opts LintOptions) (LintResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return LintResult{}, err
+	}
+	moduleRoot, err := s.DomainResolver.ModuleRoot(ctx)
+	if err != nil {
+		return LintResult{}, err
+	}
+	modulePath, err := s.DomainResolver.ModulePath(ctx)
+	if err != nil {
+		return LintResult{}, err
+	}
+
+	var result LintResult
+	result.Findings = append(result.Findings, s.lintDomainMatches(ctx, domains)...)
+
+	stats, err := s.parseAll(ctx, buildProfileList(opts.ProfilePath, cfg.Merge.Profiles))
+	if errors.Is(err, fs.ErrNotExist) {
+		result.Skipped = []string{LintUnusedExclude, LintConflictingRules, LintUnknownAnnotation}
+		result.Findings = append(result.Findings, lintDuplicateFileRules(cfg.Files)...)
+		return result, nil
+	} else if err != nil {
+		return LintResult{}, err
+	}
+	files := make([]string, 0, len(stats))
+	for file := range normalizeProfileCoverage(stats, moduleRoot, modulePath, cfg) {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	result.Findings = append(result.Findings, lintExcludes(cfg, domains, files)...)
+	result.Findings = append(result.Findings, lintFileRules(cfg.Files, files)...)
+	if s.AnnotationScanner != nil {
+		annotations, err := s.AnnotationScanner.Scan(ctx, moduleRoot, files)
+		if err != nil {
+			return LintResult{}, err
+		}
+		result.Findings = append(result.Findings, lintAnnotations(annotations, domains)...)
+	}
+	return result, nil
+}
+
+// lintDomainMatches resolves every match pattern on its own, so a dead
+// pattern is found even when the domain's other patterns resolve. A
+// pattern the resolver rejects counts as dead.
+func (s *Service) lintDomainMatches(ctx context.Context, domains []domain.Domain) []LintFinding {
+	type key struct{ domain, pattern string }
+	var probes []domain.Domain
+	keys := make(map[string]key)
+	for _, d := range domains {
+		for _, pattern := range d.Match {
+			name := fmt.Sprintf("%s\x00%s", d.Name, pattern)
+			keys[name] = key{d.Name, pattern}
+			probes = append(probes, domain.Domain{Name: name, Match: []string{pattern}})
+		}
+	}
+	resolved, err := s.DomainResolver.Resolve(ctx, probes)
+	if err != nil {
+		// One bad pattern can fail a batched lookup; retry them one by one.
+		resolved = make(map[string][]string, len(probes))
+		for _, probe := range probes {
+			if dirs, err := s.DomainResolver.Resolve(ctx, []domain.Domain{probe}); err == nil {
+				resolved[probe.Name] = dirs[probe.Name]
+			}
+		}
+	}
+
+	var findings []LintFinding
+	for _, d := range domains {
+		var dead []string
+		for _, pattern := range d.Match {
+			if len(resolved[fmt.Sprintf("%s\x00%s", d.Name, pattern)]) == 0 {
+				dead = append(dead, pattern)
+			}
+		}
+		switch {
+		case len(dead) == 0:
+		case len(dead) == len(d.Match):
+			findings = append(findings, LintFinding{Rule: LintDeadDomain, Subject: d.Name,
+				Message: fmt.Sprintf("domain %s matches no packages (%s)", d.Name, strings.Join(dead, ", "))})
+		default:
+			for _, pattern := range dead {
+				findings = append(findings, LintFinding{Rule: LintDeadMatch, Subject: d.Name,
+					Message: fmt.Sprintf("domain %s pattern %s matches no packages", d.Name, pattern)})
+			}
+		}
+	}
+	return findings
+}
+
+// lintExcludes flags global and domain exclude patterns that match no file.
+func lintExcludes(cfg Config, domains []domain.Domain, files []string) []LintFinding {
+	var findings []LintFinding
+	for _, pattern := range cfg.Exclude {
+		if !anyFileMatches(files, pattern) {
+			findings = append(findings, LintFinding{Rule: LintUnusedExclude, Subject: pattern,
+				Message: fmt.Sprintf("exclude %s matches no file in the profile", pattern)})
+		}
+	}
+	for _, d := range domains {
+		for _, pattern := range d.Exclude {
+			if !anyFileMatches(files, pattern) {
+				findings = append(findings, LintFinding{Rule: LintUnusedExclude, Subject: pattern,
+					Message: fmt.Sprintf("domain %s exclude %s matches no file in the profile", d.Name, pattern)})
+			}
+		}
+	}
+	return findings
+}
+
+// lintFileRules flags pairs of file rules with different minimums that
+// both match some file, naming the first such file.
+func lintFileRules(rules []domain.FileRule, files []string) []LintFinding {
+	findings := lintDuplicateFileRules(rules)
+	for i := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			if rules[i].Min == rules[j].Min || sharedPattern(rules[i], rules[j]) {
+				continue
+			}
+			for _, file := range files {
+				if matchAnyPattern(file, rules[i].Match) && matchAnyPattern(file, rules[j].Match) {
+					findings = append(findings, conflictingRules(rules[i], rules[j],
+						fmt.Sprintf("both match %s", file)))
+					break
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// lintDuplicateFileRules flags file rules that repeat a pattern with a
+// different minimum, which needs no profile to detect.
+func lintDuplicateFileRules(rules []domain.FileRule) []LintFinding {
+	var findings []LintFinding
+	for i := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			if rules[i].Min != rules[j].Min && sharedPattern(rules[i], rules[j]) {
+				findings = append(findings, conflictingRules(rules[i], rules[j], "they share a pattern"))
+			}
+		}
+	}
+	return findings
+}
+
+func sharedPattern(a, b domain.FileRule) bool {
+	for _, pattern := range a.Match {
+		for _, other := range b.Match {
+			if pattern == other {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func conflictingRules(a, b domain.FileRule, why string) LintFinding {
+	subject := strings.Join(a.Match, ", ")
+	return LintFinding{Rule: LintConflictingRules, Subject: subject,
+		Message: fmt.Sprintf("file rules %s (min %.1f%%) and %s (min %.1f%%) conflict: %s",
+			subject, a.Min, strings.Join(b.Match, ", "), b.Min, why)}
+}
+
+// lintAnnotations flags coverctl:domain annotations naming unknown domains.
+func lintAnnotations(annotations map[string]Annotation, domains []domain.Domain) []LintFinding {
+	known := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		known[d.Name] = true
+	}
+	files := make([]string, 0, len(annotations))
+	for file := range annotations {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	var findings []LintFinding
+	for _, file := range files {
+		if name := annotations[file].Domain; name != "" && !known[name] {
+			findings = append(findings, LintFinding{Rule: LintUnknownAnnotation, Subject: file,
+				Message: fmt.Sprintf("%s is annotated coverctl:domain=%s, which is not a configured domain", file, name)})
+		}
+	}
+	return findings
+}
+
+func anyFileMatches(files []string, pattern string) bool {
+	for _, file := range files {
+		if excluded(file, []string{pattern}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func lintConfig() Config {
+	return Config{
+		Policy: domain.Policy{
+			DefaultMin: 80,
+			Domains: []domain.Domain{
+				{Name: "api", Match: []string{"./api/...", "./gateway/..."}, Exclude: []string{"api/mock_*.go"}},
+				{Name: "legacy", Match: []string{"./legacy/..."}},
+			},
+		},
+		Exclude: []string{"api/gen/*", "proto/*"},
+		Files: []domain.FileRule{
+			{Match: []string{"api/*.go"}, Min: 90},
+			{Match: []string{"api/handler.go"}, Min: 70},
+			{Match: []string{"api/*.go"}, Min: 95},
+		},
+	}
+}
+
+func lintService(cfg Config, parser fakeParser) *Service {
+	svc := newTestService(cfg, nil, parser)
+	svc.DomainResolver = fakeResolver{
+		dirs:       map[string][]string{fmt.Sprintf("api\x00%s", "./api/..."): {"/repo/api"}},
+		moduleRoot: "/repo",
+		modulePath: "example.com/repo",
+	}
+	svc.AnnotationScanner = fakeAnnotationScanner{annotations: map[string]Annotation{
+		"api/handler.go": {Domain: "api"},
+		"api/gen/pb.go":  {Domain: "protos"},
+	}}
+	return svc
+}
+
+func lintRules(findings []LintFinding) []string {
+	rules := make([]string, 0, len(findings))
+	for _, f := range findings {
+		rules = append(rules, f.Rule+" "+f.Subject)
+	}
+	return rules
+}
+
+func TestServiceLint(t *testing.T) {
+	svc := lintService(lintConfig(), fakeParser{stats: map[string]domain.CoverageStat{
+		"example.com/repo/api/handler.go": {Covered: 1, Total: 2},
+		"example.com/repo/api/gen/pb.go":  {Covered: 0, Total: 4},
+	}})
+
+	result, err := svc.Lint(context.Background(), LintOptions{ConfigPath: ".coverctl.yaml", ProfilePath: "coverage.out"})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	want := []string{
+		"dead-match api",
+		"dead-domain legacy",
+		"unused-exclude proto/*",
+		"unused-exclude api/mock_*.go",
+		"conflicting-file-rules api/*.go",
+		"conflicting-file-rules api/*.go",
+		"conflicting-file-rules api/handler.go",
+		"unknown-annotation-domain api/gen/pb.go",
+	}
+	if got := lintRules(result.Findings); !reflect.DeepEqual(got, want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	if len(result.Skipped) != 0 {
+		t.Fatalf("expected nothing skipped, got %v", result.Skipped)
+	}
+}
+
+func TestServiceLintWithoutProfile(t *testing.T) {
+	svc := lintService(lintConfig(), fakeParser{err: fmt.Errorf("open coverage.out: %w", os.ErrNotExist)})
+
+	result, err := svc.Lint(context.Background(), LintOptions{ConfigPath: ".coverctl.yaml", ProfilePath: "coverage.out"})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	want := []string{"dead-match api", "dead-domain legacy", "conflicting-file-rules api/*.go"}
+	if got := lintRules(result.Findings); !reflect.DeepEqual(got, want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(result.Skipped, []string{LintUnusedExclude, LintConflictingRules, LintUnknownAnnotation}) {
+		t.Fatalf("unexpected skipped rules: %v", result.Skipped)
+	}
+}
@@ -0,0 +1,12 @@
+package application
+
+import "fmt"
+
+// matrixWarnings reports a build matrix the runner cannot honour: only the
+// Go runner tests once per variant, so other runners ignore it.
+func matrixWarnings(runner CoverageRunner, matrix []BuildVariant) []string {
+	if len(matrix) == 0 || runner.Language() == LanguageGo {
+		return nil
+	}
+	return []string{fmt.Sprintf("matrix is only supported by the go runner; runner %s tested once", runner.Name())}
+}
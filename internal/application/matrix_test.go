@@ -0,0 +1,26 @@
+package application
+
+import (
+	"strings"
+	"testing"
+)
+
+type pythonRunner struct{ fakeRunner }
+
+func (pythonRunner) Name() string { return "python" }
+
+func (pythonRunner) Language() Language { return LanguagePython }
+
+func TestMatrixWarnings(t *testing.T) {
+	matrix := []BuildVariant{{Name: "linux", GOOS: "linux"}, {Name: "windows", GOOS: "windows"}}
+	if got := matrixWarnings(fakeRunner{}, matrix); got != nil {
+		t.Fatalf("expected no warning for the go runner, got %v", got)
+	}
+	if got := matrixWarnings(pythonRunner{}, nil); got != nil {
+		t.Fatalf("expected no warning without a matrix, got %v", got)
+	}
+	got := matrixWarnings(pythonRunner{}, matrix)
+	if len(got) != 1 || !strings.Contains(got[0], "runner python tested once") {
+		t.Fatalf("unexpected warnings %v", got)
+	}
+}
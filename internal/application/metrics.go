@@ -0,0 +1,23 @@
+package application
+
+import "context"
+
+// Metrics gathers the policy result and coverage debt for one profile so
+// they can be exported as time series.
+func (s *Service) Metrics(ctx context.Context, opts MetricsOptions) (MetricsResult, error) {
+	result, err := s.ReportResult(ctx, ReportOptions{
+		ConfigPath: opts.ConfigPath,
+		Profile:    opts.ProfilePath,
+	})
+	if err != nil {
+		return MetricsResult{}, err
+	}
+	debt, err := s.Debt(ctx, DebtOptions{
+		ConfigPath:  opts.ConfigPath,
+		ProfilePath: opts.ProfilePath,
+	})
+	if err != nil {
+		return MetricsResult{}, err
+	}
+	return MetricsResult{Result: result, Debt: debt}, nil
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestServiceMetrics(t *testing.T) {
+	min := 90.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}},
+		Out:            io.Discard,
+	}
+
+	got, err := svc.Metrics(context.Background(), MetricsOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out"})
+	if err != nil {
+		t.Fatalf("metrics: %v", err)
+	}
+	if got.Result.Passed || len(got.Result.Domains) != 1 || got.Result.Domains[0].Percent != 80 {
+		t.Fatalf("unexpected result: %+v", got.Result)
+	}
+	if got.Debt.TotalDebt != 10 || len(got.Debt.Items) != 1 {
+		t.Fatalf("unexpected debt: %+v", got.Debt)
+	}
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// applyNewCodeCoverage enforces policy.new_code_min on lines git blame dates
+// within policy.new_code_since. It is a no-op without an age window.
+// Providers or parsers that cannot report line-level data downgrade to a
+// warning, as with patch coverage.
+func applyNewCodeCoverage(ctx context.Context, diff DiffProvider, parser ProfileParser, cfg Config, profiles []string, moduleRoot, modulePath string, result *domain.Result) error {
+	if cfg.NewCode.Since == "" {
+		return nil
+	}
+	age, err := domain.ParseAge(cfg.NewCode.Since)
+	if err != nil {
+		return fmt.Errorf("policy.new_code_since: %w", err)
+	}
+	ages, ok := diff.(LineAgeProvider)
+	if !ok {
+		result.Warnings = append(result.Warnings, "policy.new_code_since is set but the diff provider cannot report line ages; new code coverage skipped")
+		return nil
+	}
+	lines, ok, err := loadLineCoverage(parser, profiles, cfg.Exclude, moduleRoot, modulePath, cfg.Merge)
+	if err != nil {
+		return fmt.Errorf("new code coverage: %w", err)
+	}
+	if !ok {
+		result.Warnings = append(result.Warnings, "policy.new_code_since is set but the profile parser cannot report line coverage; new code coverage skipped")
+		return nil
+	}
+
+	files := make([]string, 0, len(lines))
+	for file := range lines {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	recent, err := ages.LinesChangedSince(ctx, files, timeNow().Add(-age))
+	if err != nil {
+		return fmt.Errorf("new code coverage: %w", err)
+	}
+
+	newCode := domain.NewCodeResult{
+		PatchResult: domain.EvaluatePatch(recent, lines, cfg.NewCode.Min, cfg.Policy.Rounding),
+		Since:       cfg.NewCode.Since,
+	}
+	result.NewCode = &newCode
+	if newCode.Status == domain.StatusFail {
+		result.Passed = false
+	}
+	return nil
+}
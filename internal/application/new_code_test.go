@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeLineAgeProvider struct {
+	fakeDiffProvider
+	recent map[string][]domain.LineRange
+	files  *[]string
+	since  *time.Time
+}
+
+func (f fakeLineAgeProvider) LinesChangedSince(ctx context.Context, files []string, since time.Time) (map[string][]domain.LineRange, error) {
+	*f.files = files
+	*f.since = since
+	return f.recent, f.err
+}
+
+func TestApplyNewCodeCoverage(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	orig := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = orig })
+
+	cfg := Config{NewCode: NewCodeConfig{Since: "90d", Min: 90}}
+	parser := fakeLineParser{lines: map[string]domain.LineCoverage{
+		"example.com/mod/internal/core/a.go":   {1: 1, 2: 0, 3: 1, 4: 0},
+		"example.com/mod/internal/legacy/b.go": {1: 0, 2: 0},
+	}}
+	var files []string
+	var since time.Time
+	ages := fakeLineAgeProvider{
+		recent: map[string][]domain.LineRange{"internal/core/a.go": {{Start: 1, End: 3}}},
+		files:  &files,
+		since:  &since,
+	}
+
+	t.Run("no-op without an age window", func(t *testing.T) {
+		result := domain.Result{Passed: true}
+		if err := applyNewCodeCoverage(context.Background(), ages, parser, Config{}, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NewCode != nil || len(result.Warnings) != 0 {
+			t.Fatalf("expected no new code evaluation, got %+v", result)
+		}
+	})
+
+	t.Run("warns when diff provider cannot date lines", func(t *testing.T) {
+		result := domain.Result{Passed: true}
+		if err := applyNewCodeCoverage(context.Background(), fakeDiffProvider{}, parser, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NewCode != nil || len(result.Warnings) != 1 || !result.Passed {
+			t.Fatalf("expected warning only, got %+v", result)
+		}
+	})
+
+	t.Run("holds only recent lines to the threshold", func(t *testing.T) {
+		result := domain.Result{Passed: true}
+		if err := applyNewCodeCoverage(context.Background(), ages, parser, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(files, []string{"internal/core/a.go", "internal/legacy/b.go"}) {
+			t.Fatalf("unexpected blamed files: %v", files)
+		}
+		if want := now.Add(-90 * 24 * time.Hour); !since.Equal(want) {
+			t.Fatalf("expected window start %v, got %v", want, since)
+		}
+		nc := result.NewCode
+		if nc == nil || result.Passed {
+			t.Fatalf("expected failing new code result, got %+v", result)
+		}
+		if nc.Total != 3 || nc.Covered != 2 || nc.Since != "90d" || nc.Required != 90 {
+			t.Fatalf("unexpected new code result: %+v", nc)
+		}
+	})
+
+	t.Run("rejects an invalid window", func(t *testing.T) {
+		result := domain.Result{Passed: true}
+		bad := Config{NewCode: NewCodeConfig{Since: "soon", Min: 90}}
+		if err := applyNewCodeCoverage(context.Background(), ages, parser, bad, nil, "/repo", "example.com/mod", &result); err == nil {
+			t.Fatal("expected error for invalid age")
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/perf"
+)
+
+func BenchmarkNormalizeCoverageMap(b *testing.B) {
+	stats, err := perf.Stats(perf.Options{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		normalizeCoverageMap(stats, "/src/fixture", perf.DefaultModulePath)
+	}
+}
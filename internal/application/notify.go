@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// notify sends a notification when the configured conditions fire for
+// current compared to previous. notify.webhook receives every reason; each
+// notify.owners webhook receives the reasons about domains that list the
+// owner. Delivery failures come back as warnings so an unreachable webhook
+// never fails a coverage run.
+func (s *Service) notify(ctx context.Context, cfg Config, source string, current domain.HistoryEntry, previous *domain.HistoryEntry) []string {
+	if !cfg.Notify.Enabled() || s.Notifier == nil {
+		return nil
+	}
+	rule := domain.NotifyRule{Regression: cfg.Notify.Regression, OnFailure: cfg.Notify.OnFailure}
+	reasons := rule.DomainReasons(current, previous)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	format := cfg.Notify.Format
+	if format == "" {
+		format = NotifySlack
+	}
+	var warnings []string
+	for _, route := range notifyRoutes(cfg.Notify, cfg.Policy.Domains, reasons) {
+		err := s.Notifier.Notify(ctx, Notification{
+			Webhook:  route.webhook,
+			Format:   format,
+			Source:   source,
+			Reasons:  route.reasons,
+			Owners:   route.owners,
+			Current:  current,
+			Previous: previous,
+		})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("notification not sent: %v", err))
+		}
+	}
+	return warnings
+}
+
+// notifyRoute is one webhook delivery and the reasons it carries.
+type notifyRoute struct {
+	webhook string
+	reasons []string
+	owners  []string
+}
+
+// notifyRoutes groups reasons by the webhook that should receive them,
+// the default webhook first. Owners sharing a webhook get one message.
+func notifyRoutes(cfg NotifyConfig, domains []domain.Domain, reasons []domain.NotifyReason) []notifyRoute {
+	owners := make(map[string][]string, len(domains))
+	for _, d := range domains {
+		owners[d.Name] = d.Owners
+	}
+
+	var routes []notifyRoute
+	index := make(map[string]int)
+	add := func(webhook string, routeOwners []string, reason domain.NotifyReason) {
+		i, ok := index[webhook]
+		if !ok {
+			i = len(routes)
+			index[webhook] = i
+			routes = append(routes, notifyRoute{webhook: webhook})
+		}
+		r := &routes[i]
+		// Owners sharing a webhook see each reason once.
+		if len(r.reasons) == 0 || r.reasons[len(r.reasons)-1] != reason.Text {
+			r.reasons = append(r.reasons, reason.Text)
+		}
+		for _, owner := range routeOwners {
+			if !slices.Contains(r.owners, owner) {
+				r.owners = append(r.owners, owner)
+			}
+		}
+	}
+
+	for _, reason := range reasons {
+		if cfg.Webhook != "" {
+			add(cfg.Webhook, owners[reason.Domain], reason)
+		}
+		for _, owner := range owners[reason.Domain] {
+			if webhook, ok := cfg.Owners[owner]; ok {
+				add(webhook, []string{owner}, reason)
+			}
+		}
+	}
+	return routes
+}
+
+// notifyCheck evaluates notification conditions for a check result. The
+// regression condition compares against the latest recorded entry and only
+// applies when the check has a history store.
+func (s *Service) notifyCheck(ctx context.Context, opts CheckOptions, result domain.Result) []string {
+	if s.Notifier == nil {
+		return nil
+	}
+	exists, err := s.ConfigLoader.Exists(opts.ConfigPath)
+	if err != nil || !exists {
+		return nil
+	}
+	cfg, err := s.ConfigLoader.Load(opts.ConfigPath)
+	if err != nil {
+		return nil
+	}
+	return s.notify(ctx, cfg, "check", domain.EntryFromResult(result, timeNow()), latestHistoryEntry(opts.HistoryStore))
+}
+
+// latestHistoryEntry returns the newest recorded entry, or nil when there is
+// no store, no history, or the history cannot be read.
+func latestHistoryEntry(store HistoryStore) *domain.HistoryEntry {
+	if store == nil {
+		return nil
+	}
+	hist, err := store.Load()
+	if err != nil {
+		return nil
+	}
+	return hist.LatestEntry()
+}
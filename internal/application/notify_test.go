@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeNotifier struct {
+	sent []Notification
+	err  error
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, n Notification) error {
+	f.sent = append(f.sent, n)
+	return f.err
+}
+
+func notifyTestService(cfg Config, notifier Notifier) *Service {
+	svc := newTestService(cfg, map[string][]string{"core": {"/repo/internal/core"}}, fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 7, Total: 10}}})
+	svc.Notifier = notifier
+	return svc
+}
+
+func TestRecordNotifiesOnRegression(t *testing.T) {
+	regression := 1.0
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+		Notify:  NotifyConfig{Webhook: "https://hooks.example.com/x", Regression: &regression},
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{{
+		Overall: 80,
+		Domains: map[string]domain.DomainEntry{"core": {Name: "core", Percent: 80, Min: 50, Status: domain.StatusPass}},
+	}}}}
+	notifier := &fakeNotifier{}
+	svc := notifyTestService(cfg, notifier)
+
+	if _, err := svc.RecordWithWarnings(context.Background(), RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out"}, store); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected one notification, got %d", len(notifier.sent))
+	}
+	n := notifier.sent[0]
+	if n.Source != "record" || n.Format != NotifySlack || n.Previous == nil || n.Previous.Overall != 80 {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+	if len(n.Reasons) != 2 {
+		t.Fatalf("expected overall and domain regression reasons, got %v", n.Reasons)
+	}
+}
+
+func TestCheckNotifiesOnFailure(t *testing.T) {
+	min := 90.0
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}},
+		Notify:  NotifyConfig{Webhook: "https://hooks.example.com/x", Format: NotifyJSON, OnFailure: true},
+	}
+	notifier := &fakeNotifier{err: errors.New("connection refused")}
+	svc := notifyTestService(cfg, notifier)
+
+	result, err := svc.CheckResult(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml"})
+	if err != nil {
+		t.Fatalf("check result: %v", err)
+	}
+	warnings := svc.notifyCheck(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml"}, result)
+	if len(notifier.sent) != 1 || notifier.sent[0].Source != "check" || notifier.sent[0].Format != NotifyJSON {
+		t.Fatalf("unexpected notifications: %+v", notifier.sent)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "connection refused") {
+		t.Fatalf("expected delivery failure as warning, got %v", warnings)
+	}
+}
+
+func TestNotifySkippedWhenNothingFires(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+		Notify:  NotifyConfig{Webhook: "https://hooks.example.com/x", OnFailure: true},
+	}
+	notifier := &fakeNotifier{}
+	svc := notifyTestService(cfg, notifier)
+
+	if _, err := svc.RecordWithWarnings(context.Background(), RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out"}, &memoryHistoryStore{}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if len(notifier.sent) != 0 {
+		t.Fatalf("expected no notification, got %+v", notifier.sent)
+	}
+}
+
+func TestNotifyRoutesToOwners(t *testing.T) {
+	cfg := Config{
+		Policy: domain.Policy{Domains: []domain.Domain{
+			{Name: "api", Owners: []string{"@platform"}},
+			{Name: "billing", Owners: []string{"@payments", "@platform"}},
+			{Name: "core"},
+		}},
+		Notify: NotifyConfig{
+			Webhook:   "https://hooks.example.com/all",
+			OnFailure: true,
+			Owners: map[string]string{
+				"@payments": "https://hooks.example.com/payments",
+				"@platform": "https://hooks.example.com/platform",
+			},
+		},
+	}
+	current := domain.HistoryEntry{Overall: 40, Domains: map[string]domain.DomainEntry{
+		"api":     {Percent: 40, Min: 80, Status: domain.StatusFail},
+		"billing": {Percent: 30, Min: 80, Status: domain.StatusFail},
+		"core":    {Percent: 50, Min: 80, Status: domain.StatusFail},
+	}}
+	notifier := &fakeNotifier{}
+	svc := &Service{Notifier: notifier}
+
+	if warnings := svc.notify(context.Background(), cfg, "check", current, nil); len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	if len(notifier.sent) != 3 {
+		t.Fatalf("expected default, platform, and payments messages, got %+v", notifier.sent)
+	}
+	all, platform, payments := notifier.sent[0], notifier.sent[1], notifier.sent[2]
+	if all.Webhook != cfg.Notify.Webhook || len(all.Reasons) != 3 || !reflect.DeepEqual(all.Owners, []string{"@platform", "@payments"}) {
+		t.Fatalf("unexpected default message: %+v", all)
+	}
+	if platform.Webhook != cfg.Notify.Owners["@platform"] || len(platform.Reasons) != 2 || !reflect.DeepEqual(platform.Owners, []string{"@platform"}) {
+		t.Fatalf("unexpected platform message: %+v", platform)
+	}
+	if payments.Webhook != cfg.Notify.Owners["@payments"] || len(payments.Reasons) != 1 || !strings.Contains(payments.Reasons[0], "billing") {
+		t.Fatalf("unexpected payments message: %+v", payments)
+	}
+}
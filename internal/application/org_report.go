@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// genericReportNames are file names that say nothing about the service,
+// so the enclosing directory names it instead (payments/history.json).
+var genericReportNames = map[string]bool{
+	"coverage": true,
+	"report":   true,
+	"history":  true,
+	"gate":     true,
+	"result":   true,
+}
+
+// OrgReport combines the JSON reports and history files of several
+// repositories into one org-wide report. Each input is one service, named
+// after its file (payments.json) or, for generic names such as
+// history.json, its directory.
+func (s *Service) OrgReport(ctx context.Context, opts OrgReportOptions, source SnapshotSource) (domain.OrgReport, error) {
+	if len(opts.Inputs) == 0 {
+		return domain.OrgReport{}, fmt.Errorf("no inputs given")
+	}
+	snapshots, err := source.Snapshots(opts.Inputs)
+	if err != nil {
+		return domain.OrgReport{}, err
+	}
+	if len(snapshots) == 0 {
+		return domain.OrgReport{}, fmt.Errorf("no input files match %s", strings.Join(opts.Inputs, ", "))
+	}
+
+	services := make([]domain.ServiceCoverage, 0, len(snapshots))
+	histories := make(map[string]domain.History)
+	seen := make(map[string]string, len(snapshots))
+	for _, snap := range snapshots {
+		name := serviceName(snap.Path)
+		if prev, ok := seen[name]; ok {
+			return domain.OrgReport{}, fmt.Errorf("inputs %s and %s both name service %s; rename one", prev, snap.Path, name)
+		}
+		seen[name] = snap.Path
+		switch {
+		case snap.Result != nil:
+			services = append(services, domain.ServiceFromResult(name, snap.Path, *snap.Result))
+		case snap.History != nil:
+			sc, ok := domain.ServiceFromHistory(name, snap.Path, *snap.History)
+			if !ok {
+				return domain.OrgReport{}, fmt.Errorf("%s: history has no entries", snap.Path)
+			}
+			services = append(services, sc)
+			histories[name] = *snap.History
+		default:
+			return domain.OrgReport{}, fmt.Errorf("%s: neither a JSON report nor a history file", snap.Path)
+		}
+	}
+	return domain.BuildOrgReport(services, histories), nil
+}
+
+// serviceName derives a service name from an input path.
+func serviceName(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if genericReportNames[strings.ToLower(base)] {
+		if dir := filepath.Base(filepath.Dir(path)); dir != "." && dir != string(filepath.Separator) {
+			return dir
+		}
+	}
+	return base
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeSnapshotSource struct {
+	snapshots []ServiceSnapshot
+	err       error
+}
+
+func (f fakeSnapshotSource) Snapshots([]string) ([]ServiceSnapshot, error) {
+	return f.snapshots, f.err
+}
+
+func TestServiceOrgReport(t *testing.T) {
+	svc := &Service{}
+	source := fakeSnapshotSource{snapshots: []ServiceSnapshot{
+		{Path: "reports/payments.json", Result: &domain.Result{Passed: true, Domains: []domain.DomainResult{{Domain: "core", Covered: 8, Total: 10, Status: domain.StatusPass}}}},
+		{Path: "histories/ledger/history.json", History: &domain.History{Entries: []domain.HistoryEntry{{Overall: 60}}}},
+	}}
+
+	report, err := svc.OrgReport(context.Background(), OrgReportOptions{Inputs: []string{"reports/*.json"}}, source)
+	if err != nil {
+		t.Fatalf("org report: %v", err)
+	}
+	if len(report.Services) != 2 || report.Services[0].Service != "ledger" || report.Services[1].Service != "payments" {
+		t.Fatalf("expected services named after file and directory, got %+v", report.Services)
+	}
+	if report.Percent != 70 || len(report.Trend) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestServiceOrgReportErrors(t *testing.T) {
+	svc := &Service{}
+	result := &domain.Result{Passed: true}
+	tests := map[string]struct {
+		source fakeSnapshotSource
+		want   string
+	}{
+		"no matches": {source: fakeSnapshotSource{}, want: "no input files match"},
+		"duplicate service": {source: fakeSnapshotSource{snapshots: []ServiceSnapshot{
+			{Path: "a/api.json", Result: result},
+			{Path: "b/api.json", Result: result},
+		}}, want: "both name service api"},
+		"empty history": {source: fakeSnapshotSource{snapshots: []ServiceSnapshot{
+			{Path: "api.json", History: &domain.History{}},
+		}}, want: "history has no entries"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.OrgReport(context.Background(), OrgReportOptions{Inputs: []string{"*.json"}}, tt.source)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q error, got %v", tt.want, err)
+			}
+		})
+	}
+}
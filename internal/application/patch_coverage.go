@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// applyPatchCoverage enforces diff.min on changed lines only. It is a no-op
+// unless diff mode is enabled with a minimum. Providers or parsers that
+// cannot report line-level data downgrade to a warning rather than failing
+// the check, since file-level diff filtering still applies.
+func applyPatchCoverage(ctx context.Context, diff DiffProvider, parser ProfileParser, cfg Config, profiles []string, moduleRoot, modulePath string, result *domain.Result) error {
+	if !cfg.Diff.Enabled || (cfg.Diff.Min == nil && cfg.Diff.MaxUncoveredLines == nil) {
+		return nil
+	}
+	lineDiff, ok := selectDiffProvider(diff, cfg.Diff).(LineDiffProvider)
+	if !ok {
+		result.Warnings = append(result.Warnings, "patch coverage is configured but the diff provider cannot report changed lines; patch coverage skipped")
+		return nil
+	}
+	lines, ok, err := loadLineCoverage(parser, profiles, cfg.Exclude, moduleRoot, modulePath, cfg.Merge)
+	if err != nil {
+		return fmt.Errorf("patch coverage: %w", err)
+	}
+	if !ok {
+		result.Warnings = append(result.Warnings, "patch coverage is configured but the profile parser cannot report line coverage; patch coverage skipped")
+		return nil
+	}
+
+	changed, err := lineDiff.ChangedLines(ctx, cfg.Diff.Base)
+	if err != nil {
+		return fmt.Errorf("patch coverage: %w", err)
+	}
+
+	var required float64
+	if cfg.Diff.Min != nil {
+		required = *cfg.Diff.Min
+	}
+	patch := domain.EvaluatePatch(changed, lines, required, cfg.Policy.Rounding)
+	if cfg.Diff.MaxUncoveredLines != nil {
+		patch.ApplyBudget(*cfg.Diff.MaxUncoveredLines)
+	}
+	result.Patch = &patch
+	if patch.Status == domain.StatusFail {
+		result.Passed = false
+	}
+	return nil
+}
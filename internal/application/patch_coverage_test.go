@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeLineDiffProvider struct {
+	fakeDiffProvider
+	lines map[string][]domain.LineRange
+}
+
+func (f fakeLineDiffProvider) ChangedLines(ctx context.Context, base string) (map[string][]domain.LineRange, error) {
+	return f.lines, f.err
+}
+
+type fakeLineParser struct {
+	fakeParser
+	lines map[string]domain.LineCoverage
+}
+
+func (f fakeLineParser) ParseAllLines(paths []string) (map[string]domain.LineCoverage, error) {
+	return f.lines, f.err
+}
+
+func TestApplyPatchCoverage(t *testing.T) {
+	minimum := 80.0
+	cfg := Config{Diff: DiffConfig{Enabled: true, Base: "main", Min: &minimum}}
+	diff := fakeLineDiffProvider{lines: map[string][]domain.LineRange{"internal/core/a.go": {{Start: 10, End: 12}}}}
+	parser := fakeLineParser{lines: map[string]domain.LineCoverage{
+		"example.com/mod/internal/core/a.go": {10: 1, 11: 0, 12: 0, 20: 0},
+	}}
+
+	t.Run("no-op without diff.min", func(t *testing.T) {
+		result := domain.Result{Passed: true}
+		if err := applyPatchCoverage(context.Background(), diff, parser, Config{Diff: DiffConfig{Enabled: true}}, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Patch != nil || len(result.Warnings) != 0 {
+			t.Fatalf("expected no patch evaluation, got %+v", result)
+		}
+	})
+
+	t.Run("warns when diff provider lacks line support", func(t *testing.T) {
+		result := domain.Result{Passed: true}
+		if err := applyPatchCoverage(context.Background(), fakeDiffProvider{}, parser, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Patch != nil || len(result.Warnings) != 1 || !result.Passed {
+			t.Fatalf("expected warning only, got %+v", result)
+		}
+	})
+
+	t.Run("fails when changed lines are uncovered", func(t *testing.T) {
+		result := domain.Result{Passed: true}
+		if err := applyPatchCoverage(context.Background(), diff, parser, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Passed || result.Patch == nil {
+			t.Fatalf("expected failing patch result, got %+v", result)
+		}
+		if result.Patch.Total != 3 || result.Patch.Covered != 1 || len(result.Patch.Uncovered) != 2 {
+			t.Fatalf("unexpected patch: %+v", result.Patch)
+		}
+		if got := result.Patch.Uncovered[0].String(); got != "internal/core/a.go:11" {
+			t.Fatalf("unexpected uncovered line %q", got)
+		}
+	})
+
+	t.Run("enforces the uncovered line budget without diff.min", func(t *testing.T) {
+		for budget, wantPass := range map[int]bool{2: true, 1: false} {
+			result := domain.Result{Passed: true}
+			cfg := Config{Diff: DiffConfig{Enabled: true, Base: "main", MaxUncoveredLines: &budget}}
+			if err := applyPatchCoverage(context.Background(), diff, parser, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Patch == nil || result.Passed != wantPass || *result.Patch.MaxUncovered != budget {
+				t.Fatalf("budget %d: expected pass=%v, got %+v", budget, wantPass, result.Patch)
+			}
+		}
+	})
+}
+
+func TestAttachLineCoverage(t *testing.T) {
+	parser := fakeLineParser{lines: map[string]domain.LineCoverage{
+		"example.com/mod/internal/core/a.go": {3: 1},
+		"example.com/mod/gen/z.go":           {1: 0},
+	}}
+	cfg := Config{Exclude: []string{"gen/**"}}
+
+	t.Run("skips formats without line data", func(t *testing.T) {
+		var result domain.Result
+		if err := attachLineCoverage(OutputJSON, HTMLOptions{}, parser, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Lines != nil {
+			t.Fatal("expected no line data for json output")
+		}
+	})
+
+	t.Run("attaches normalized lines", func(t *testing.T) {
+		var result domain.Result
+		if err := attachLineCoverage(OutputGitLab, HTMLOptions{}, parser, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Lines) != 1 || result.Lines["internal/core/a.go"][3] != 1 || result.SourceRoot != "/repo" {
+			t.Fatalf("unexpected lines: %+v root=%q", result.Lines, result.SourceRoot)
+		}
+	})
+
+	t.Run("attaches lines for self-contained html only", func(t *testing.T) {
+		var plain, embedded domain.Result
+		if err := attachLineCoverage(OutputHTML, HTMLOptions{}, parser, cfg, nil, "/repo", "example.com/mod", &plain); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := attachLineCoverage(OutputHTML, HTMLOptions{SelfContained: true}, parser, cfg, nil, "/repo", "example.com/mod", &embedded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if plain.Lines != nil || len(embedded.Lines) != 1 {
+			t.Fatalf("expected lines only when self-contained, got %v and %v", plain.Lines, embedded.Lines)
+		}
+	})
+
+	t.Run("warns when parser lacks line support", func(t *testing.T) {
+		var result domain.Result
+		if err := attachLineCoverage(OutputAzure, HTMLOptions{}, fakeParser{}, cfg, nil, "/repo", "example.com/mod", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Warnings) != 1 {
+			t.Fatalf("expected a warning, got %v", result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// PatchReport measures coverage of the lines changed since a base ref, for
+// a reviewer-facing view of a pull request. Patch status uses diff.min and
+// diff.max_uncovered_lines when configured.
+func (s *Service) PatchReport(ctx context.Context, opts PatchReportOptions) (PatchReportResult, error) {
+	cfg, _, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return PatchReportResult{}, err
+	}
+	diffCfg := overrideDiffBase(cfg.Diff, opts.Base)
+	if diffCfg.Base == "" {
+		diffCfg.Base = "HEAD~1"
+	}
+	lineDiff, ok := selectDiffProvider(s.DiffProvider, diffCfg).(LineDiffProvider)
+	if !ok {
+		return PatchReportResult{}, errors.New("patch-report needs a diff provider that reports changed lines")
+	}
+
+	moduleRoot, err := s.DomainResolver.ModuleRoot(ctx)
+	if err != nil {
+		return PatchReportResult{}, err
+	}
+	modulePath, err := s.DomainResolver.ModulePath(ctx)
+	if err != nil {
+		return PatchReportResult{}, err
+	}
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	lines, ok, err := loadLineCoverage(s.ProfileParser, profiles, cfg.Exclude, moduleRoot, modulePath, cfg.Merge)
+	if err != nil {
+		return PatchReportResult{}, err
+	}
+	if !ok {
+		return PatchReportResult{}, errors.New("patch-report needs line-level coverage, which the profile parser cannot report")
+	}
+	changed, err := lineDiff.ChangedLines(ctx, diffCfg.Base)
+	if err != nil {
+		return PatchReportResult{}, fmt.Errorf("changed lines: %w", err)
+	}
+
+	var required float64
+	if cfg.Diff.Min != nil {
+		required = *cfg.Diff.Min
+	}
+	patch := domain.EvaluatePatch(changed, lines, required, cfg.Policy.Rounding)
+	if cfg.Diff.MaxUncoveredLines != nil {
+		patch.ApplyBudget(*cfg.Diff.MaxUncoveredLines)
+	}
+	return PatchReportResult{
+		Base:       diffCfg.Base,
+		Patch:      patch,
+		Files:      domain.PatchFiles(changed, lines),
+		SourceRoot: moduleRoot,
+	}, nil
+}
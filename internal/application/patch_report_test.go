@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestPatchReport(t *testing.T) {
+	minimum := 80.0
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+		Diff:    DiffConfig{Base: "origin/main", Min: &minimum},
+	}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{moduleRoot: "/repo", modulePath: "example.com/mod"},
+		DiffProvider: fakeLineDiffProvider{lines: map[string][]domain.LineRange{
+			"internal/core/a.go": {{Start: 10, End: 12}},
+			"README.md":          {{Start: 1, End: 2}},
+		}},
+		ProfileParser: fakeLineParser{lines: map[string]domain.LineCoverage{
+			"example.com/mod/internal/core/a.go": {10: 1, 11: 0, 12: 0, 20: 0},
+			"example.com/mod/internal/core/b.go": {1: 0},
+		}},
+		Out: io.Discard,
+	}
+
+	result, err := svc.PatchReport(context.Background(), PatchReportOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out"})
+	if err != nil {
+		t.Fatalf("patch report: %v", err)
+	}
+	if result.Base != "origin/main" || result.SourceRoot != "/repo" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.Patch.Total != 3 || result.Patch.Covered != 1 || result.Patch.Status != domain.StatusFail {
+		t.Fatalf("unexpected patch %+v", result.Patch)
+	}
+	if len(result.Files) != 1 || result.Files[0].File != "internal/core/a.go" || result.Files[0].Percent != 33.3 {
+		t.Fatalf("unexpected files %+v", result.Files)
+	}
+
+	result, err = svc.PatchReport(context.Background(), PatchReportOptions{ConfigPath: ".coverctl.yaml", Base: "HEAD~3"})
+	if err != nil || result.Base != "HEAD~3" {
+		t.Fatalf("expected --base to win, got %q (%v)", result.Base, err)
+	}
+
+	svc.DiffProvider = fakeDiffProvider{}
+	if _, err := svc.PatchReport(context.Background(), PatchReportOptions{ConfigPath: ".coverctl.yaml"}); err == nil {
+		t.Fatal("expected an error without changed-line support")
+	}
+}
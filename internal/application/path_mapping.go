@@ -0,0 +1,69 @@
+package application
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// mapCoveragePath rewrites file with the first mapping whose From prefix it
+// starts with, on a path-segment boundary. Unmatched paths are returned as is.
+// Both sides are compared in canonical form, so a mapping written with
+// forward slashes also matches a profile written on Windows.
+func mapCoveragePath(file string, mappings []PathMapping) string {
+	canonical := domain.CanonicalPath(file)
+	for _, m := range mappings {
+		from := strings.TrimSuffix(domain.CanonicalPath(m.From), "/")
+		if from == "" || from == "." {
+			continue
+		}
+		rest, ok := cutPathPrefix(canonical, from)
+		if !ok {
+			continue
+		}
+		to := strings.TrimSuffix(domain.CanonicalPath(m.To), "/")
+		switch {
+		case rest == "":
+			return filepath.FromSlash(to)
+		case to == "":
+			return filepath.FromSlash(rest)
+		default:
+			return filepath.FromSlash(to + "/" + rest)
+		}
+	}
+	return file
+}
+
+// cutPathPrefix returns p without the directory prefix, on a segment
+// boundary. Windows paths compare case-insensitively, as the OS does.
+func cutPathPrefix(p, prefix string) (string, bool) {
+	if len(p) < len(prefix) {
+		return "", false
+	}
+	head := p[:len(prefix)]
+	if head != prefix && !(domain.IsWindowsAbs(prefix) && strings.EqualFold(head, prefix)) {
+		return "", false
+	}
+	rest := p[len(prefix):]
+	if rest == "" {
+		return "", true
+	}
+	if rest[0] != '/' && !strings.HasSuffix(prefix, "/") {
+		return "", false
+	}
+	return strings.TrimPrefix(rest, "/"), true
+}
+
+// windowsCoverageFile resolves an absolute Windows path from a profile. Under
+// the module root it is rejoined onto moduleRoot; otherwise it is kept as is,
+// and only a merge.path_mappings entry can bring it into the module.
+func windowsCoverageFile(file, moduleRoot string) string {
+	root := domain.CanonicalPath(moduleRoot)
+	if domain.IsWindowsAbs(root) {
+		if rest, ok := cutPathPrefix(file, root); ok {
+			return filepath.Join(moduleRoot, filepath.FromSlash(rest))
+		}
+	}
+	return filepath.FromSlash(file)
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestMapCoveragePath(t *testing.T) {
+	mappings := []PathMapping{
+		{From: "/app/services/api", To: "services/api"},
+		{From: "/app/", To: ""},
+	}
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"/app/services/api/handler.go", filepath.FromSlash("services/api/handler.go")},
+		{"/app/lib/util.go", filepath.FromSlash("lib/util.go")},
+		{"/application/main.go", "/application/main.go"},
+		{"internal/core/a.go", "internal/core/a.go"},
+	}
+	for _, tt := range tests {
+		if got := mapCoveragePath(tt.in, mappings); got != tt.want {
+			t.Errorf("mapCoveragePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeCoverageMapWithPathMappings(t *testing.T) {
+	files := map[string]domain.CoverageStat{
+		"/build/src/internal/core/a.go": {Covered: 1, Total: 2},
+		"internal/core/a.go":            {Covered: 1, Total: 1},
+	}
+	result := normalizeCoverageMap(files, "/repo", "", PathMapping{From: "/build/src", To: "/repo"})
+	if len(result) != 1 {
+		t.Fatalf("expected mapped paths to merge into one entry, got %v", result)
+	}
+	if got := result["internal/core/a.go"]; got.Covered != 2 || got.Total != 3 {
+		t.Fatalf("unexpected merged stat: %+v", got)
+	}
+}
+
+func TestNormalizeCoverageMapWindowsPaths(t *testing.T) {
+	files := map[string]domain.CoverageStat{
+		`C:\ci\build\src\core\a.js`: {Covered: 1, Total: 2},
+		`src\core\b.js`:             {Covered: 3, Total: 4},
+		"src/api/c.js\r":            {Covered: 1, Total: 1},
+	}
+	// Mappings may be written with either separator and drive-letter case.
+	result := normalizeCoverageMap(files, "/repo", "", PathMapping{From: "c:/ci/build", To: "/repo"})
+	for _, want := range []string{"src/core/a.js", "src/core/b.js", "src/api/c.js"} {
+		if _, ok := result[want]; !ok {
+			t.Fatalf("expected %s in %v", want, result)
+		}
+	}
+}
+
+func TestCutPathPrefix(t *testing.T) {
+	tests := []struct {
+		p, prefix string
+		rest      string
+		ok        bool
+	}{
+		{"/app/lib/a.go", "/app", "lib/a.go", true},
+		{"/application/a.go", "/app", "", false},
+		{"C:/Work/app/a.go", "C:/work/app", "a.go", true},
+		{"/Repo/a.go", "/repo", "", false},
+	}
+	for _, tt := range tests {
+		rest, ok := cutPathPrefix(tt.p, tt.prefix)
+		if rest != tt.rest || ok != tt.ok {
+			t.Errorf("cutPathPrefix(%q, %q) = %q, %v", tt.p, tt.prefix, rest, ok)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Preset is a curated set of init defaults applied on top of autodetection,
+// so many repositories can adopt the same policy with one command.
+type Preset struct {
+	Name       string
+	DefaultMin *float64    // Default minimum coverage
+	WarnMargin *float64    // Each domain warns this many points above its minimum
+	Exclude    []string    // Patterns added to the global excludes, e.g. generated code
+	Diff       *PresetDiff // Diff mode settings; nil leaves diff mode as detected
+}
+
+// PresetDiff is the diff mode a preset enables.
+type PresetDiff struct {
+	Enabled bool
+	Base    string
+	Min     *float64 // Minimum patch coverage (diff.min)
+}
+
+// generatedExcludes keeps common generated code out of every built-in preset.
+var generatedExcludes = []string{"**/*.pb.go", "**/*_gen.go", "**/zz_generated*.go", "**/generated/**", "**/mocks/**"}
+
+// builtinPresets are the presets init knows by name.
+var builtinPresets = map[string]Preset{
+	"strict":   newBuiltinPreset("strict", 85, 5, 90),
+	"balanced": newBuiltinPreset("balanced", 75, 5, 80),
+	// legacy sets a low floor for existing code and holds changes higher.
+	"legacy": newBuiltinPreset("legacy", 50, 10, 70),
+}
+
+func newBuiltinPreset(name string, defaultMin, warnMargin, diffMin float64) Preset {
+	return Preset{
+		Name:       name,
+		DefaultMin: &defaultMin,
+		WarnMargin: &warnMargin,
+		Exclude:    generatedExcludes,
+		Diff:       &PresetDiff{Enabled: true, Base: "origin/main", Min: &diffMin},
+	}
+}
+
+// BuiltinPreset returns the built-in preset called name.
+func BuiltinPreset(name string) (Preset, bool) {
+	p, ok := builtinPresets[name]
+	return p, ok
+}
+
+// BuiltinPresetNames lists the built-in presets in name order.
+func BuiltinPresetNames() []string {
+	names := make([]string, 0, len(builtinPresets))
+	for name := range builtinPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Seed applies the settings the init wizard asks about, the default
+// minimum and excludes, so the wizard starts from the preset.
+func (p Preset) Seed(cfg Config) Config {
+	if p.DefaultMin != nil {
+		cfg.Policy.DefaultMin = *p.DefaultMin
+	}
+	for _, pattern := range p.Exclude {
+		if !slices.Contains(cfg.Exclude, pattern) {
+			cfg.Exclude = append(cfg.Exclude, pattern)
+		}
+	}
+	return cfg
+}
+
+// Finish applies the settings the wizard does not ask about: warn levels
+// derived from each domain's final minimum, and diff mode.
+func (p Preset) Finish(cfg Config) Config {
+	if p.WarnMargin != nil {
+		domains := slices.Clone(cfg.Policy.Domains)
+		for i, d := range domains {
+			warn := math.Min(100, d.MinThreshold(cfg.Policy.DefaultMin)+*p.WarnMargin)
+			domains[i].Warn = &warn
+		}
+		cfg.Policy.Domains = domains
+	}
+	if p.Diff != nil {
+		cfg.Diff.Enabled = p.Diff.Enabled
+		if p.Diff.Base != "" {
+			cfg.Diff.Base = p.Diff.Base
+		}
+		cfg.Diff.Min = p.Diff.Min
+	}
+	return cfg
+}
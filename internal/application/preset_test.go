@@ -0,0 +1,69 @@
+package application
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestPresetSeedAndFinish(t *testing.T) {
+	strict, ok := BuiltinPreset("strict")
+	if !ok {
+		t.Fatal("strict preset missing")
+	}
+	min := 98.0
+	cfg := Config{
+		Policy:  domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core"}, {Name: "api", Min: &min}}},
+		Exclude: []string{"**/*.pb.go"},
+	}
+
+	seeded := strict.Seed(cfg)
+	if seeded.Policy.DefaultMin != 85 || len(seeded.Exclude) != len(generatedExcludes) {
+		t.Fatalf("unexpected seeded config %+v", seeded)
+	}
+
+	finished := strict.Finish(seeded)
+	core, api := finished.Policy.Domains[0], finished.Policy.Domains[1]
+	if core.Warn == nil || *core.Warn != 90 || api.Warn == nil || *api.Warn != 100 {
+		t.Fatalf("unexpected warn levels core=%v api=%v", core.Warn, api.Warn)
+	}
+	if cfg.Policy.Domains[0].Warn != nil {
+		t.Fatal("Finish must not modify the input domains")
+	}
+	if !finished.Diff.Enabled || finished.Diff.Base != "origin/main" || finished.Diff.Min == nil || *finished.Diff.Min != 90 {
+		t.Fatalf("unexpected diff %+v", finished.Diff)
+	}
+
+	if got := (Preset{}).Finish(cfg); !reflect.DeepEqual(got, cfg) {
+		t.Fatalf("an empty preset must leave the config unchanged, got %+v", got)
+	}
+}
+
+func TestBuiltinPresetNames(t *testing.T) {
+	if got := BuiltinPresetNames(); !reflect.DeepEqual(got, []string{"balanced", "legacy", "strict"}) {
+		t.Fatalf("unexpected names %v", got)
+	}
+}
+
+func TestBuiltinPresetExcludesMatchGeneratedCode(t *testing.T) {
+	preset, _ := BuiltinPreset("balanced")
+	for _, file := range []string{
+		"foo.pb.go",
+		"internal/api/v1/foo.pb.go",
+		"internal/store/queries_gen.go",
+		"pkg/apis/zz_generated.deepcopy.go",
+		"internal/generated/client.go",
+		"a/b/mocks/m.go",
+		"mocks/store.go",
+	} {
+		if !excluded(file, preset.Exclude) {
+			t.Errorf("expected %s to be excluded", file)
+		}
+	}
+	for _, file := range []string{"internal/core/service.go", "internal/mockserver/server.go", "cmd/gen.go"} {
+		if excluded(file, preset.Exclude) {
+			t.Errorf("expected %s to be kept", file)
+		}
+	}
+}
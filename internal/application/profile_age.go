@@ -0,0 +1,94 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// guardProfileAge applies the freshness guard to the profiles of a
+// --from-profile run. It does nothing unless a maximum age is set. With
+// the warn action the problems come back as warnings; otherwise they fail
+// the check with ErrCodeStaleProfile.
+func guardProfileAge(parser ProfileParser, cfg ProfileConfig, opts CheckOptions, profiles []string, coverage map[string]domain.CoverageStat, moduleRoot string) ([]string, error) {
+	maxAge := opts.MaxProfileAge
+	if maxAge == "" {
+		maxAge = cfg.MaxAge
+	}
+	if !opts.FromProfile || maxAge == "" {
+		return nil, nil
+	}
+	limit, err := domain.ParseAge(maxAge)
+	if err != nil {
+		return nil, WithErrorCode(ErrCodeConfigInvalid, fmt.Errorf("profile max age: %w", err))
+	}
+	if expander, ok := parser.(ProfileExpander); ok {
+		profiles = expander.ExpandProfiles(profiles)
+	}
+	problems := profileFreshness(profiles, coverage, moduleRoot, maxAge, limit, time.Now())
+	if len(problems) == 0 {
+		return nil, nil
+	}
+	action := opts.StaleProfile
+	if action == "" {
+		action = cfg.Stale
+	}
+	if action == StaleProfileWarn {
+		return problems, nil
+	}
+	return nil, WithErrorCode(ErrCodeStaleProfile, errors.New(strings.Join(problems, "; ")))
+}
+
+// profileFreshness reports each profile written more than limit (given by
+// the user as maxAge) before now, or before the newest source file it
+// covers was last changed. Profiles that cannot be read are left to the
+// parser to report.
+func profileFreshness(profiles []string, coverage map[string]domain.CoverageStat, moduleRoot, maxAge string, limit time.Duration, now time.Time) []string {
+	source, changed := newestSource(coverage, moduleRoot)
+	var problems []string
+	for _, path := range profiles {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		written := info.ModTime()
+		if age := now.Sub(written); age > limit {
+			problems = append(problems, fmt.Sprintf("profile %s is %s old, older than the %s maximum", path, age.Round(time.Second), maxAge))
+			continue
+		}
+		if source != "" && changed.After(written) {
+			problems = append(problems, fmt.Sprintf("profile %s predates %s, changed %s after it was written", path, source, changed.Sub(written).Round(time.Second)))
+		}
+	}
+	return problems
+}
+
+// newestSource returns the module-relative coverage key whose file under
+// moduleRoot changed last, and when. Keys outside the module are skipped.
+func newestSource(coverage map[string]domain.CoverageStat, moduleRoot string) (string, time.Time) {
+	files := make([]string, 0, len(coverage))
+	for file := range coverage {
+		if !filepath.IsAbs(file) && !strings.HasPrefix(file, "../") {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	var newest string
+	var changed time.Time
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(moduleRoot, filepath.FromSlash(file)))
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(changed) {
+			newest, changed = file, info.ModTime()
+		}
+	}
+	return newest, changed
+}
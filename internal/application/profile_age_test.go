@@ -0,0 +1,69 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestGuardProfileAge(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "internal", "core", "a.go")
+	if err := os.MkdirAll(filepath.Dir(source), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(source, []byte("package core\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	profile := filepath.Join(root, "coverage.out")
+	if err := os.WriteFile(profile, []byte("mode: set\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	coverage := map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 1, Total: 2}}
+	touch := func(path string, ago time.Duration) {
+		t.Helper()
+		at := time.Now().Add(-ago)
+		if err := os.Chtimes(path, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	guard := func(cfg ProfileConfig, opts CheckOptions) ([]string, error) {
+		opts.FromProfile = true
+		return guardProfileAge(nil, cfg, opts, []string{profile}, coverage, root)
+	}
+
+	touch(source, 2*time.Hour)
+	touch(profile, time.Hour)
+	if warnings, err := guard(ProfileConfig{}, CheckOptions{}); err != nil || warnings != nil {
+		t.Fatalf("expected no guard without a maximum age, got %v %v", warnings, err)
+	}
+	if warnings, err := guard(ProfileConfig{MaxAge: "2h"}, CheckOptions{}); err != nil || warnings != nil {
+		t.Fatalf("expected a fresh profile to pass, got %v %v", warnings, err)
+	}
+
+	_, err := guard(ProfileConfig{MaxAge: "2h"}, CheckOptions{MaxProfileAge: "30m"})
+	if ErrorCodeOf(err) != ErrCodeStaleProfile || !strings.Contains(err.Error(), "older than the 30m maximum") {
+		t.Fatalf("expected the flag to override the config, got %v", err)
+	}
+	warnings, err := guard(ProfileConfig{MaxAge: "30m", Stale: StaleProfileWarn}, CheckOptions{})
+	if err != nil || len(warnings) != 1 {
+		t.Fatalf("expected a warning, got %v %v", warnings, err)
+	}
+
+	touch(source, time.Minute)
+	_, err = guard(ProfileConfig{MaxAge: "1d"}, CheckOptions{})
+	if ErrorCodeOf(err) != ErrCodeStaleProfile || !strings.Contains(err.Error(), "predates internal/core/a.go") {
+		t.Fatalf("expected a profile older than its source to fail, got %v", err)
+	}
+
+	if _, err := guard(ProfileConfig{}, CheckOptions{MaxProfileAge: "soon"}); ErrorCodeOf(err) != ErrCodeConfigInvalid {
+		t.Fatalf("expected an invalid age error, got %v", err)
+	}
+	if warnings, err := guardProfileAge(nil, ProfileConfig{MaxAge: "1d"}, CheckOptions{}, []string{profile}, coverage, root); err != nil || warnings != nil {
+		t.Fatalf("expected runs that execute tests to skip the guard, got %v %v", warnings, err)
+	}
+}
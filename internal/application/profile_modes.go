@@ -0,0 +1,27 @@
+package application
+
+// profileModeWarnings returns the parser's warnings about merged profiles
+// written in different coverage modes. Only merges can mix modes, and a
+// profile that cannot be read has already failed parsing, so a single
+// profile or a read error yields none.
+func profileModeWarnings(parser ProfileParser, profiles []string) []string {
+	reconciler, ok := parser.(ModeReconciler)
+	if !ok || len(profiles) < 2 {
+		return nil
+	}
+	warnings, err := reconciler.ModeWarnings(profiles)
+	if err != nil {
+		return nil
+	}
+	return warnings
+}
+
+// profileExpansionWarnings returns the parser's warnings about profile
+// patterns and directories that name no profile.
+func profileExpansionWarnings(parser ProfileParser, profiles []string) []string {
+	expander, ok := parser.(ProfileExpander)
+	if !ok {
+		return nil
+	}
+	return expander.ExpansionWarnings(profiles)
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// reconcilingParser is a fakeParser that reports mixed coverage modes.
+type reconcilingParser struct {
+	fakeParser
+	warnings []string
+	err      error
+}
+
+func (p reconcilingParser) ModeWarnings(paths []string) ([]string, error) {
+	return p.warnings, p.err
+}
+
+func TestProfileModeWarnings(t *testing.T) {
+	mixed := reconcilingParser{warnings: []string{"merged profiles mix coverage modes"}}
+	if got := profileModeWarnings(mixed, []string{"unit.out", "e2e.out"}); len(got) != 1 {
+		t.Fatalf("expected the parser's warning, got %v", got)
+	}
+	if got := profileModeWarnings(mixed, []string{"unit.out"}); got != nil {
+		t.Fatalf("a single profile cannot mix modes, got %v", got)
+	}
+	if got := profileModeWarnings(reconcilingParser{err: errors.New("boom")}, []string{"a", "b"}); got != nil {
+		t.Fatalf("read errors yield no warnings, got %v", got)
+	}
+	if got := profileModeWarnings(fakeParser{}, []string{"a", "b"}); got != nil {
+		t.Fatalf("parsers without modes yield no warnings, got %v", got)
+	}
+}
+
+func TestReportResultWarnsAboutMixedModes(t *testing.T) {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}
+	warning := "merged profiles mix coverage modes (count: e2e.out; set: unit.out)"
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo"},
+		ProfileParser: reconcilingParser{
+			fakeParser: fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 6, Total: 10}}},
+			warnings:   []string{warning},
+		},
+	}
+	result, err := svc.ReportResult(context.Background(), ReportOptions{
+		ConfigPath:    ".coverctl.yaml",
+		Profile:       "unit.out",
+		MergeProfiles: []string{"e2e.out"},
+	})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if !slices.Contains(result.Warnings, warning) {
+		t.Fatalf("expected mode warning in %v", result.Warnings)
+	}
+}
+
+// expandingParser is a fakeParser that reports patterns matching nothing.
+type expandingParser struct {
+	fakeParser
+	warnings []string
+}
+
+func (p expandingParser) ExpandProfiles(paths []string) []string {
+	return paths
+}
+
+func (p expandingParser) ExpansionWarnings(paths []string) []string {
+	return p.warnings
+}
+
+func TestProfileExpansionWarnings(t *testing.T) {
+	want := []string{"profile pattern coverage-*.out matches no files"}
+	if got := profileExpansionWarnings(expandingParser{warnings: want}, []string{"coverage-*.out"}); !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := profileExpansionWarnings(fakeParser{}, []string{"coverage-*.out"}); got != nil {
+		t.Fatalf("expected no warnings without expansion support, got %v", got)
+	}
+}
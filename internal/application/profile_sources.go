@@ -0,0 +1,101 @@
+package application
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Source labels for the profiles coverctl produces itself.
+const (
+	sourceUnit        = "unit"
+	sourceIntegration = "integration"
+)
+
+// profileSourceLabels names each profile for the per-source breakdown. When
+// coverctl ran the tests, the first profile is "unit" and, with integration
+// coverage enabled, the second is "integration"; any other profile is named
+// after its file ("e2e.out" becomes "e2e"), or its path when two share a name.
+func profileSourceLabels(profiles []string, ran, integration bool) []string {
+	labels := make([]string, 0, len(profiles))
+	for i, p := range profiles {
+		var label string
+		switch {
+		case ran && i == 0:
+			label = sourceUnit
+		case ran && integration && i == 1:
+			label = sourceIntegration
+		default:
+			base := filepath.Base(p)
+			label = strings.TrimSuffix(base, filepath.Ext(base))
+		}
+		if slices.Contains(labels, label) {
+			label = p
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// domainAggregator repeats the main pass's path normalization, diff filter,
+// and domain aggregation on another set of profile stats.
+type domainAggregator struct {
+	moduleRoot     string
+	modulePath     string
+	cfg            Config
+	exclude        []string
+	changed        map[string]struct{}
+	dirs           map[string][]string
+	domainExcludes map[string][]string
+	annotations    map[string]Annotation
+}
+
+func newDomainAggregator(cfg Config, moduleRoot, modulePath string, changed map[string]struct{}, dirs, domainExcludes map[string][]string, annotations map[string]Annotation) domainAggregator {
+	return domainAggregator{
+		moduleRoot:     moduleRoot,
+		modulePath:     modulePath,
+		cfg:            cfg,
+		exclude:        cfg.Exclude,
+		changed:        changed,
+		dirs:           dirs,
+		domainExcludes: domainExcludes,
+		annotations:    annotations,
+	}
+}
+
+func (a domainAggregator) aggregate(files map[string]domain.CoverageStat) map[string]domain.CoverageStat {
+	normalized := normalizeProfileCoverage(files, a.moduleRoot, a.modulePath, a.cfg)
+	filtered := filterCoverageByFiles(normalized, a.changed)
+	return AggregateByDomainWithExcludes(filtered, a.dirs, a.exclude, a.domainExcludes, a.moduleRoot, a.modulePath, a.annotations)
+}
+
+// attachSourceCoverage splits each domain's coverage by profile source when
+// more than one profile was merged. The split is informational: thresholds
+// were already evaluated on the combined coverage.
+func attachSourceCoverage(parser ProfileParser, profiles, labels []string, agg domainAggregator, result *domain.Result) error {
+	if len(profiles) < 2 || len(result.Domains) == 0 {
+		return nil
+	}
+	alone := make([]map[string]domain.CoverageStat, len(profiles))
+	cumulative := make([]map[string]domain.CoverageStat, len(profiles))
+	for i, p := range profiles {
+		files, err := parser.ParseAll([]string{p})
+		if err != nil {
+			return err
+		}
+		alone[i] = agg.aggregate(files)
+		if i == 0 {
+			cumulative[i] = alone[i]
+			continue
+		}
+		files, err = parser.ParseAll(profiles[:i+1])
+		if err != nil {
+			return err
+		}
+		cumulative[i] = agg.aggregate(files)
+	}
+	result.ApplySources(labels, alone, cumulative)
+	return nil
+}
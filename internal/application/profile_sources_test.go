@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// sourceParser returns different stats per profile and merges them the way
+// the real parsers do: a statement covered in any profile is covered.
+type sourceParser map[string]map[string]domain.CoverageStat
+
+func (p sourceParser) Parse(path string) (map[string]domain.CoverageStat, error) {
+	return p[path], nil
+}
+
+func (p sourceParser) ParseAll(paths []string) (map[string]domain.CoverageStat, error) {
+	out := make(map[string]domain.CoverageStat)
+	for _, path := range paths {
+		for file, stat := range p[path] {
+			cur := out[file]
+			cur.Total = max(cur.Total, stat.Total)
+			cur.Covered = max(cur.Covered, stat.Covered)
+			out[file] = cur
+		}
+	}
+	return out, nil
+}
+
+func (sourceParser) Format() Format { return FormatGo }
+
+func TestProfileSourceLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		profiles    []string
+		ran         bool
+		integration bool
+		want        []string
+	}{
+		{"ran with integration", []string{".cover/coverage.out", ".cover/integration.out", "e2e.out"}, true, true, []string{"unit", "integration", "e2e"}},
+		{"ran without integration", []string{".cover/coverage.out", "e2e.out"}, true, false, []string{"unit", "e2e"}},
+		{"from files", []string{"unit.out", "ci/e2e.out"}, false, false, []string{"unit", "e2e"}},
+		{"duplicate names", []string{"a/cover.out", "b/cover.out"}, false, false, []string{"cover", "b/cover.out"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := profileSourceLabels(tt.profiles, tt.ran, tt.integration)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportResultSplitsCoverageBySource(t *testing.T) {
+	min := 80.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}}
+	parser := sourceParser{
+		"unit.out": {"internal/core/a.go": {Covered: 6, Total: 10}},
+		"integration.out": {
+			"internal/core/a.go": {Covered: 7, Total: 10},
+			"internal/core/b.go": {Covered: 2, Total: 10},
+		},
+	}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo"},
+		ProfileParser:  parser,
+	}
+
+	result, err := svc.ReportResult(context.Background(), ReportOptions{
+		ConfigPath:    ".coverctl.yaml",
+		Profile:       "unit.out",
+		MergeProfiles: []string{"integration.out"},
+	})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	core := result.Domains[0]
+	if core.Percent != 45 || core.Status != domain.StatusFail {
+		t.Fatalf("threshold must apply to combined coverage, got %.1f%% %s", core.Percent, core.Status)
+	}
+	want := []domain.SourceCoverage{
+		{Source: "unit", Percent: 30, Added: 30},
+		{Source: "integration", Percent: 45, Added: 15},
+	}
+	if !reflect.DeepEqual(core.Sources, want) {
+		t.Fatalf("sources = %+v, want %+v", core.Sources, want)
+	}
+}
+
+func TestReportResultSingleProfileHasNoSources(t *testing.T) {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo"},
+		ProfileParser:  sourceParser{"unit.out": {"internal/core/a.go": {Covered: 6, Total: 10}}},
+	}
+	result, err := svc.ReportResult(context.Background(), ReportOptions{ConfigPath: ".coverctl.yaml", Profile: "unit.out"})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if result.Domains[0].Sources != nil {
+		t.Fatalf("expected no source split for one profile, got %+v", result.Domains[0].Sources)
+	}
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Defaults for ratchet-up when options are left zero.
+const (
+	defaultRatchetDays    = 14
+	defaultRatchetMaxStep = 2.0
+)
+
+// RatchetUp raises domain minimums to the lowest coverage each domain has
+// held for the last opts.Days days, never by more than opts.MaxStep per
+// run. Thresholds are never lowered. The returned config carries the new
+// minimums; writing it back is left to the caller.
+func (s *Service) RatchetUp(_ context.Context, opts RatchetUpOptions, store HistoryStore) (RatchetUpResult, error) {
+	days := opts.Days
+	if days <= 0 {
+		days = defaultRatchetDays
+	}
+	maxStep := opts.MaxStep
+	if maxStep <= 0 {
+		maxStep = defaultRatchetMaxStep
+	}
+
+	exists, err := s.ConfigLoader.Exists(opts.ConfigPath)
+	if err != nil {
+		return RatchetUpResult{}, err
+	}
+	if !exists {
+		return RatchetUpResult{}, fmt.Errorf("config %s not found; ratchet-up only updates an existing config", opts.ConfigPath)
+	}
+	cfg, err := s.ConfigLoader.Load(opts.ConfigPath)
+	if err != nil {
+		return RatchetUpResult{}, err
+	}
+
+	hist, err := store.Load()
+	if err != nil {
+		return RatchetUpResult{}, fmt.Errorf("load history: %w", err)
+	}
+	if len(hist.Entries) == 0 {
+		return RatchetUpResult{}, fmt.Errorf("no history data available; run 'coverctl record' after coverage runs")
+	}
+
+	since := timeNow().AddDate(0, 0, -days)
+	result := RatchetUpResult{Days: days, Changes: []ThresholdChange{}, Skipped: []string{}}
+	for i, d := range cfg.Policy.Domains {
+		sustained, ok := hist.SustainedMinimum(d.Name, since)
+		if !ok {
+			result.Skipped = append(result.Skipped, d.Name)
+			continue
+		}
+
+		current := cfg.Policy.DefaultMin
+		if d.Min != nil {
+			current = *d.Min
+		}
+		// Floor to one decimal so the new minimum never exceeds what was met.
+		target := math.Min(math.Floor(sustained*10)/10, current+maxStep)
+		target = domain.Round1(math.Min(target, 100))
+		if target <= current {
+			continue
+		}
+
+		newMin := target
+		cfg.Policy.Domains[i].Min = &newMin
+		result.Changes = append(result.Changes, ThresholdChange{
+			Domain:    d.Name,
+			From:      current,
+			To:        target,
+			Sustained: sustained,
+		})
+	}
+	result.Config = cfg
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestServiceRatchetUp(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	origNow := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = origNow }()
+
+	day := 24 * time.Hour
+	entry := func(ago time.Duration, core, api float64) domain.HistoryEntry {
+		return domain.HistoryEntry{Timestamp: now.Add(-ago), Domains: map[string]domain.DomainEntry{
+			"core": {Name: "core", Percent: core},
+			"api":  {Name: "api", Percent: api},
+		}}
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{
+		entry(20*day, 86.47, 71),
+		entry(7*day, 88, 73),
+		entry(day, 90, 72),
+	}}}
+
+	coreMin, apiMin := 80.0, 72.0
+	svc := &Service{ConfigLoader: fakeConfigLoader{exists: true, cfg: Config{
+		Policy: domain.Policy{DefaultMin: 75, Domains: []domain.Domain{
+			{Name: "core", Min: &coreMin},
+			{Name: "api", Min: &apiMin},
+			{Name: "new"},
+		}},
+	}}}
+
+	result, err := svc.RatchetUp(context.Background(), RatchetUpOptions{ConfigPath: ".coverctl.yaml", Days: 14, MaxStep: 5}, store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Changes) != 1 {
+		t.Fatalf("expected 1 change, got %+v", result.Changes)
+	}
+	change := result.Changes[0]
+	if change.Domain != "core" || change.From != 80 || change.To != 85 {
+		t.Fatalf("expected core capped at +5, got %+v", change)
+	}
+	if got := *result.Config.Policy.Domains[0].Min; got != 85 {
+		t.Fatalf("expected config core min 85, got %.1f", got)
+	}
+	if got := *result.Config.Policy.Domains[1].Min; got != 72 {
+		t.Fatalf("expected api min unchanged, got %.1f", got)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "new" {
+		t.Fatalf("expected new domain skipped, got %v", result.Skipped)
+	}
+
+	uncapped, err := svc.RatchetUp(context.Background(), RatchetUpOptions{ConfigPath: ".coverctl.yaml", Days: 14, MaxStep: 10}, store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uncapped.Changes[0].To != 86.4 {
+		t.Fatalf("expected sustained minimum floored to 86.4, got %.1f", uncapped.Changes[0].To)
+	}
+}
+
+func TestServiceRatchetUpRequiresConfigAndHistory(t *testing.T) {
+	svc := &Service{ConfigLoader: fakeConfigLoader{}}
+	if _, err := svc.RatchetUp(context.Background(), RatchetUpOptions{ConfigPath: "missing.yaml"}, &memoryHistoryStore{}); err == nil {
+		t.Fatal("expected error for missing config")
+	}
+
+	svc.ConfigLoader = fakeConfigLoader{exists: true, cfg: Config{Policy: domain.Policy{Domains: []domain.Domain{{Name: "core"}}}}}
+	if _, err := svc.RatchetUp(context.Background(), RatchetUpOptions{ConfigPath: ".coverctl.yaml"}, &memoryHistoryStore{}); err == nil {
+		t.Fatal("expected error for empty history")
+	}
+}
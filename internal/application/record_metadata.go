@@ -0,0 +1,40 @@
+package application
+
+import "context"
+
+// recordMetadata returns the commit, branch, and CI run for a history entry.
+// Explicit options win; unset fields are filled from the diff provider when
+// it can read them, unless detection is disabled.
+func (s *Service) recordMetadata(ctx context.Context, opts RecordOptions) RunMetadata {
+	meta := RunMetadata{
+		Commit: opts.Commit,
+		Branch: opts.Branch,
+		Tag:    opts.Tag,
+		PR:     opts.PR,
+		RunID:  opts.RunID,
+	}
+	if opts.NoDetect {
+		return meta
+	}
+	provider, ok := s.DiffProvider.(RunMetadataProvider)
+	if !ok {
+		return meta
+	}
+	detected := provider.RunMetadata(ctx)
+	if meta.Commit == "" {
+		meta.Commit = detected.Commit
+	}
+	if meta.Branch == "" {
+		meta.Branch = detected.Branch
+	}
+	if meta.Tag == "" {
+		meta.Tag = detected.Tag
+	}
+	if meta.PR == 0 {
+		meta.PR = detected.PR
+	}
+	if meta.RunID == "" {
+		meta.RunID = detected.RunID
+	}
+	return meta
+}
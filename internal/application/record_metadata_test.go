@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeMetadataDiff struct {
+	fakeDiffProvider
+	meta RunMetadata
+}
+
+func (f fakeMetadataDiff) RunMetadata(context.Context) RunMetadata { return f.meta }
+
+func TestRecordFillsRunMetadata(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+	}
+	svc := notifyTestService(cfg, nil)
+	svc.DiffProvider = fakeMetadataDiff{meta: RunMetadata{Commit: "abc123", Branch: "detected", Tag: "v1.0.0", PR: 7, RunID: "99"}}
+
+	store := &memoryHistoryStore{}
+	opts := RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out", Branch: "explicit"}
+	if _, err := svc.RecordWithWarnings(context.Background(), opts, store); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	entry := store.history.Entries[0]
+	if entry.Commit != "abc123" || entry.Branch != "explicit" || entry.Tag != "v1.0.0" || entry.PR != 7 || entry.RunID != "99" {
+		t.Fatalf("unexpected metadata: %+v", entry)
+	}
+
+	store = &memoryHistoryStore{}
+	opts.NoDetect = true
+	if _, err := svc.RecordWithWarnings(context.Background(), opts, store); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if entry := store.history.Entries[0]; entry.Commit != "" || entry.Branch != "explicit" {
+		t.Fatalf("expected detection disabled, got %+v", entry)
+	}
+}
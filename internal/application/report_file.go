@@ -0,0 +1,99 @@
+package application
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// RunReport is the machine-readable outcome of check, report, or gate
+// written to --report-file. It is written whether the run passed, failed,
+// or errored, so CI steps can read the outcome without scraping logs.
+type RunReport struct {
+	Schema       string             `json:"schema"`
+	Command      string             `json:"command"`
+	Passed       bool               `json:"passed"`
+	Error        string             `json:"error,omitempty"`
+	ErrorCode    ErrorCode          `json:"errorCode,omitempty"`
+	Remediation  string             `json:"remediation,omitempty"`
+	TestFailures []TestFailure      `json:"testFailures,omitempty"`
+	StartedAt    time.Time          `json:"startedAt"`
+	DurationMs   int64              `json:"durationMs"`
+	ConfigHash   string             `json:"configHash,omitempty"`
+	Overall      float64            `json:"overall"`
+	Result       *domain.Result     `json:"result,omitempty"`
+	Checks       []domain.GateCheck `json:"checks,omitempty"`
+}
+
+// ConfigHash fingerprints a resolved config, so two runs can be checked for
+// having evaluated the same policy.
+func ConfigHash(cfg Config) string {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// runReport accumulates a RunReport over one command.
+type runReport struct {
+	w          io.Writer
+	svc        *Service
+	configPath string
+	doc        RunReport
+}
+
+// startRunReport begins timing a command; finish writes the report to w.
+// A nil w disables the report.
+func (s *Service) startRunReport(w io.Writer, command, configPath string) *runReport {
+	return &runReport{
+		w:          w,
+		svc:        s,
+		configPath: configPath,
+		doc:        RunReport{Schema: JSONSchema, Command: command, StartedAt: time.Now().UTC()},
+	}
+}
+
+func (r *runReport) setResult(result domain.Result) {
+	r.doc.Result = &result
+	r.doc.Overall = result.OverallPercent()
+	r.doc.Passed = result.Passed
+}
+
+func (r *runReport) setGate(gate domain.Gate) {
+	r.setResult(gate.Result)
+	r.doc.Checks = gate.Checks
+	r.doc.Overall = gate.Overall
+	r.doc.Passed = gate.Passed
+}
+
+// finish writes the report with runErr as the command's outcome and
+// returns runErr, or the write error when the run itself succeeded.
+func (r *runReport) finish(runErr error) error {
+	if r.w == nil {
+		return runErr
+	}
+	r.doc.DurationMs = time.Since(r.doc.StartedAt).Milliseconds()
+	if runErr != nil {
+		r.doc.Passed = false
+		r.doc.Error = runErr.Error()
+		r.doc.ErrorCode = ErrorCodeOf(runErr)
+		r.doc.Remediation = Remediation(r.doc.ErrorCode)
+		r.doc.TestFailures = TestFailuresOf(runErr)
+	}
+	if cfg, _, err := r.svc.loadOrDetect(r.configPath); err == nil {
+		r.doc.ConfigHash = ConfigHash(cfg)
+	}
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r.doc); err != nil && runErr == nil {
+		return fmt.Errorf("write report file: %w", err)
+	}
+	return runErr
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func reportFileService(min float64, runner fakeRunner) *Service {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}}
+	svc := newTestService(cfg, map[string][]string{"core": {"/repo/internal/core"}}, fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}})
+	svc.CoverageRunner = runner
+	return svc
+}
+
+func decodeRunReport(t *testing.T, buf *bytes.Buffer) RunReport {
+	t.Helper()
+	var doc RunReport
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid report file %q: %v", buf.String(), err)
+	}
+	return doc
+}
+
+func TestCheckWritesReportFileOnFailure(t *testing.T) {
+	svc := reportFileService(90, fakeRunner{profile: ".cover/coverage.out"})
+	var buf bytes.Buffer
+	err := svc.Check(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", Output: OutputText, ReportFile: &buf})
+	if err == nil {
+		t.Fatal("expected policy violation")
+	}
+	doc := decodeRunReport(t, &buf)
+	if doc.Command != "check" || doc.Passed || doc.Error != "policy violation" {
+		t.Fatalf("unexpected outcome: %+v", doc)
+	}
+	if doc.Result == nil || len(doc.Result.Domains) != 1 || doc.Overall != 80 {
+		t.Fatalf("expected full result, got %+v", doc.Result)
+	}
+	if !strings.HasPrefix(doc.ConfigHash, "sha256:") || doc.StartedAt.IsZero() {
+		t.Fatalf("expected config hash and timing, got %+v", doc)
+	}
+}
+
+func TestCheckWritesReportFileOnError(t *testing.T) {
+	svc := reportFileService(80, fakeRunner{err: errSentinel})
+	var buf bytes.Buffer
+	if err := svc.Check(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", ReportFile: &buf}); err == nil {
+		t.Fatal("expected runner error")
+	}
+	doc := decodeRunReport(t, &buf)
+	if doc.Passed || doc.Error == "" || doc.Result != nil {
+		t.Fatalf("expected an error report without result, got %+v", doc)
+	}
+	if doc.ErrorCode != ErrCodeRunnerFailed || doc.Remediation == "" {
+		t.Fatalf("expected runner error code and remediation, got %+v", doc)
+	}
+}
+
+func TestGateWritesReportFile(t *testing.T) {
+	svc := reportFileService(70, fakeRunner{profile: ".cover/coverage.out"})
+	var buf bytes.Buffer
+	gate, err := svc.Gate(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", ReportFile: &buf})
+	if err != nil {
+		t.Fatalf("gate: %v", err)
+	}
+	doc := decodeRunReport(t, &buf)
+	if doc.Command != "gate" || doc.Passed != gate.Passed || len(doc.Checks) != len(gate.Checks) {
+		t.Fatalf("report does not match gate: %+v", doc)
+	}
+}
+
+func TestConfigHashStable(t *testing.T) {
+	a := Config{Version: 1, Exclude: []string{"gen/*"}}
+	b := Config{Version: 1, Exclude: []string{"gen/*"}}
+	if ConfigHash(a) != ConfigHash(b) {
+		t.Fatal("equal configs must hash equally")
+	}
+	b.Exclude = nil
+	if ConfigHash(a) == ConfigHash(b) {
+		t.Fatal("different configs must hash differently")
+	}
+}
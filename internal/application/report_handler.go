@@ -3,7 +3,6 @@ package application
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"sort"
 
 	"github.com/felixgeelhaar/coverctl/internal/domain"
@@ -28,7 +27,7 @@ func (h *ReportHandler) ReportResult(ctx context.Context, opts ReportOptions) (d
 
 	domains = filterDomainsByNames(domains, opts.Domains)
 	if len(domains) == 0 {
-		return domain.Result{}, fmt.Errorf("no matching domains found for: %v", opts.Domains)
+		return domain.Result{}, errNoMatchingDomains(opts.Domains)
 	}
 
 	moduleRoot, err := h.DomainResolver.ModuleRoot(ctx)
@@ -54,7 +53,12 @@ func (h *ReportHandler) ReportResult(ctx context.Context, opts ReportOptions) (d
 		return domain.Result{}, err
 	}
 
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	normalizedCoverage, staleWarnings := sanitizeStaleEntries(normalizedCoverage, moduleRoot, opts.PruneStale)
+	normalizedCoverage, err = excludeFunctions(ctx, h.ProfileParser, h.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return domain.Result{}, err
+	}
 	annotations, err := loadAnnotations(ctx, h.AnnotationScanner, cfg, moduleRoot, normalizedCoverage)
 	if err != nil {
 		return domain.Result{}, err
@@ -66,11 +70,7 @@ func (h *ReportHandler) ReportResult(ctx context.Context, opts ReportOptions) (d
 	}
 
 	// Handle --diff flag
-	diffCfg := cfg.Diff
-	if opts.DiffRef != "" {
-		diffCfg.Enabled = true
-		diffCfg.Base = opts.DiffRef
-	}
+	diffCfg := overrideDiffBase(cfg.Diff, opts.DiffRef)
 
 	changedFiles, err := h.diffFilesWithConfig(ctx, diffCfg)
 	if err != nil {
@@ -84,7 +84,7 @@ func (h *ReportHandler) ReportResult(ctx context.Context, opts ReportOptions) (d
 		return result, nil
 	}
 
-	domainDirs, err := h.DomainResolver.Resolve(ctx, domains)
+	domainDirs, err := resolveDomainDirs(ctx, h.DomainResolver, domains, moduleRoot, cfg.Merge)
 	if err != nil {
 		return domain.Result{}, err
 	}
@@ -100,12 +100,24 @@ func (h *ReportHandler) ReportResult(ctx context.Context, opts ReportOptions) (d
 
 	result := domain.Evaluate(policy, domainCoverage)
 	result.Warnings = domainOverlapWarnings(domainDirs)
+	if len(opts.Domains) == 0 {
+		result.Warnings = append(result.Warnings, unmatchedFilesWarning(filteredCoverage, domainDirs, cfg.Exclude, moduleRoot, modulePath, annotations)...)
+	}
+	result.EmptyDomains = emptyDomains(policy.Domains, domainDirs, domainCoverage)
+	result.Warnings = append(result.Warnings, emptyDomainWarnings(result.EmptyDomains)...)
+	result.Warnings = append(result.Warnings, staleWarnings...)
+	result.Warnings = append(result.Warnings, profileExpansionWarnings(h.ProfileParser, profiles)...)
+	result.Warnings = append(result.Warnings, profileModeWarnings(h.ProfileParser, profiles)...)
 
-	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations)
+	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations, cfg.Policy.Rounding)
 	result.Files = fileResults
 	if !filesPassed {
 		result.Passed = false
 	}
+	applyExceptions(&result, cfg.Exceptions, cfg.Policy.Domains)
+	if err := attachLineCoverage(opts.Output, opts.HTML, h.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
 
 	// Apply deltas from history if available
 	if opts.HistoryStore != nil {
@@ -159,16 +171,5 @@ func (h *ReportHandler) reportUncoveredResult(files map[string]domain.CoverageSt
 
 // diffFilesWithConfig gets changed files using the given diff configuration.
 func (h *ReportHandler) diffFilesWithConfig(ctx context.Context, cfg DiffConfig) (map[string]struct{}, error) {
-	if !cfg.Enabled || h.DiffProvider == nil {
-		return nil, nil
-	}
-	files, err := h.DiffProvider.ChangedFiles(ctx, cfg.Base)
-	if err != nil {
-		return nil, err
-	}
-	allow := make(map[string]struct{}, len(files))
-	for _, file := range files {
-		allow[filepath.ToSlash(filepath.Clean(file))] = struct{}{}
-	}
-	return allow, nil
+	return diffFiles(ctx, h.DiffProvider, cfg)
 }
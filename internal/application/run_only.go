@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type RunOnlyOptions struct {
+	ConfigPath     string
+	Profile        string
+	Domains        []string    // Filter to specific domains (empty = all domains)
+	BuildFlags     BuildFlags  // Build and test flags
+	Language       Language    // Override language auto-detection (empty = auto)
+	Runner         string      // Run with this runner, bypassing detection (empty = config or auto)
+	Progress       io.Writer   // Optional: live test progress line (TTY only, Go runner)
+	TimingStore    TimingStore // Optional: record how long the test run took
+	IsolateDomains bool        // Test each domain alone with -coverpkg limited to it (coverpkg.isolate)
+}
+
+// RunOnly runs the tests with coverage and leaves the profile in place,
+// without evaluating policy.
+func (s *Service) RunOnly(ctx context.Context, opts RunOnlyOptions) error {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	// Select the appropriate runner based on language
+	runner, err := s.selectRunnerMethod(opts.Runner, cfg.Runner, opts.Language, cfg.Language)
+	if err != nil {
+		return err
+	}
+
+	// Filter domains if specific ones are requested
+	domains = filterDomainsByNames(domains, opts.Domains)
+	if len(domains) == 0 {
+		return errNoMatchingDomains(opts.Domains)
+	}
+
+	var timings *domain.TestTimings
+	if opts.TimingStore != nil {
+		timings = &domain.TestTimings{RecordedAt: time.Now()}
+	}
+	cfg.CoverPkg.Isolate = cfg.CoverPkg.Isolate || opts.IsolateDomains
+	_, _, err = runDomainTests(ctx, runner, commandRunnerOf(s.RunnerRegistry, s.CoverageRunner), RunOptions{Domains: domains, ProfilePath: opts.Profile, BuildFlags: opts.BuildFlags, Progress: opts.Progress, Retry: cfg.Retry, CoverPkg: cfg.CoverPkg, Matrix: cfg.Matrix}, timings)
+	if err != nil || timings == nil {
+		return err
+	}
+	return opts.TimingStore.Save(*timings)
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Scaffold finds exported functions that no test executes and has
+// scaffolder generate a table-driven test skeleton for each source file
+// holding any. It needs statement blocks from the profile and function
+// spans from the source, which only Go projects provide today.
+func (s *Service) Scaffold(ctx context.Context, opts ScaffoldOptions, scaffolder TestScaffolder) (ScaffoldResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return ScaffoldResult{}, err
+	}
+	domains = filterDomainsByNames(domains, opts.Domains)
+	if len(domains) == 0 {
+		return ScaffoldResult{}, errNoMatchingDomains(opts.Domains)
+	}
+	blockParser, ok := s.ProfileParser.(BlockProfileParser)
+	if !ok {
+		return ScaffoldResult{}, errors.New("scaffold needs a profile parser that reports statement blocks")
+	}
+	funcScanner, ok := s.AnnotationScanner.(FunctionScanner)
+	if !ok {
+		return ScaffoldResult{}, errors.New("scaffold needs a source scanner that locates functions")
+	}
+
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		return ScaffoldResult{}, err
+	}
+	blocks, err := moduleBlocks(blockParser, cfg, profiles, covCtx.ModuleRoot, covCtx.ModulePath)
+	if err != nil {
+		return ScaffoldResult{}, err
+	}
+
+	var files []string
+	for file, stat := range covCtx.NormalizedCoverage {
+		if stat.Covered == stat.Total || !scaffoldable(file) || excluded(file, cfg.Exclude) {
+			continue
+		}
+		owners, ignored := fileDomains(file, covCtx)
+		if ignored || (len(opts.Domains) > 0 && !inAnyDomain(owners, covCtx.DomainDirs)) {
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	spans, err := funcScanner.ScanFunctions(ctx, covCtx.ModuleRoot, files)
+	if err != nil {
+		return ScaffoldResult{}, err
+	}
+
+	result := ScaffoldResult{ModuleRoot: covCtx.ModuleRoot}
+	for _, file := range files {
+		target := ScaffoldTarget{File: file}
+		for _, fn := range spans[file] {
+			stat := domain.FunctionCoverage(fn, blocks[file])
+			if fn.Exported() && stat.Total > 0 && stat.Covered == 0 {
+				target.Functions = append(target.Functions, fn.Name)
+			}
+		}
+		if len(target.Functions) == 0 {
+			continue
+		}
+		generated, err := scaffolder.Scaffold(covCtx.ModuleRoot, target)
+		if err != nil {
+			return ScaffoldResult{}, err
+		}
+		result.Files = append(result.Files, generated)
+	}
+	return result, nil
+}
+
+// scaffoldable reports whether file is Go source that is not itself a test.
+func scaffoldable(file string) bool {
+	return filepath.Ext(file) == ".go" && !strings.HasSuffix(file, "_test.go")
+}
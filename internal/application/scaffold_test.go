@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeScaffolder struct {
+	targets *[]ScaffoldTarget
+}
+
+func (f fakeScaffolder) Scaffold(moduleRoot string, target ScaffoldTarget) (ScaffoldFile, error) {
+	*f.targets = append(*f.targets, target)
+	return ScaffoldFile{Source: target.File, Functions: target.Functions}, nil
+}
+
+func TestServiceScaffold(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+	}
+	stats := map[string]domain.CoverageStat{
+		"internal/core/a.go":      {Covered: 2, Total: 7},
+		"internal/core/b.go":      {Covered: 3, Total: 3},
+		"internal/core/a_test.go": {Covered: 0, Total: 2},
+		"internal/api/h.go":       {Covered: 0, Total: 4},
+	}
+	parser := fakeBlockParser{
+		fakeParser: fakeParser{stats: stats},
+		blocks: map[string][]domain.CoverageBlock{
+			"example.com/mod/internal/core/a.go": {
+				{Lines: domain.LineRange{Start: 4, End: 5}, Stat: domain.CoverageStat{Covered: 0, Total: 3}},
+				{Lines: domain.LineRange{Start: 9, End: 9}, Stat: domain.CoverageStat{Covered: 2, Total: 2}},
+				{Lines: domain.LineRange{Start: 13, End: 13}, Stat: domain.CoverageStat{Covered: 0, Total: 1}},
+				{Lines: domain.LineRange{Start: 17, End: 17}, Stat: domain.CoverageStat{Covered: 0, Total: 1}},
+			},
+			"example.com/mod/internal/api/h.go": {
+				{Lines: domain.LineRange{Start: 3, End: 6}, Stat: domain.CoverageStat{Covered: 0, Total: 4}},
+			},
+		},
+	}
+	scanner := fakeFunctionScanner{spans: map[string][]domain.FunctionSpan{
+		"internal/core/a.go": {
+			{Name: "Parse()", Lines: domain.LineRange{Start: 3, End: 6}},
+			{Name: "Covered()", Lines: domain.LineRange{Start: 8, End: 10}},
+			{Name: "helper()", Lines: domain.LineRange{Start: 12, End: 14}},
+			{Name: "Thing.Run()", Lines: domain.LineRange{Start: 16, End: 18}},
+		},
+		"internal/api/h.go": {
+			{Name: "Handle()", Lines: domain.LineRange{Start: 2, End: 7}},
+		},
+	}}
+	newService := func(dirs map[string][]string, p ProfileParser) *Service {
+		return &Service{
+			ConfigLoader:      fakeConfigLoader{exists: true, cfg: cfg},
+			Autodetector:      fakeAutodetector{},
+			DomainResolver:    fakeResolver{dirs: dirs, moduleRoot: "/repo", modulePath: "example.com/mod"},
+			ProfileParser:     p,
+			AnnotationScanner: scanner,
+			Out:               io.Discard,
+		}
+	}
+
+	t.Run("targets uncovered exported functions in the domain", func(t *testing.T) {
+		var targets []ScaffoldTarget
+		svc := newService(map[string][]string{"core": {"/repo/internal/core"}}, parser)
+		got, err := svc.Scaffold(context.Background(), ScaffoldOptions{Domains: []string{"core"}}, fakeScaffolder{targets: &targets})
+		if err != nil {
+			t.Fatalf("scaffold: %v", err)
+		}
+		want := []ScaffoldTarget{{File: "internal/core/a.go", Functions: []string{"Parse()", "Thing.Run()"}}}
+		if !reflect.DeepEqual(targets, want) {
+			t.Fatalf("unexpected targets: %+v", targets)
+		}
+		if got.ModuleRoot != "/repo" || len(got.Files) != 1 {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("covers every domain by default", func(t *testing.T) {
+		var targets []ScaffoldTarget
+		svc := newService(map[string][]string{"core": {"/repo/internal/core"}, "api": {"/repo/internal/api"}}, parser)
+		if _, err := svc.Scaffold(context.Background(), ScaffoldOptions{}, fakeScaffolder{targets: &targets}); err != nil {
+			t.Fatalf("scaffold: %v", err)
+		}
+		if len(targets) != 2 || targets[0].File != "internal/api/h.go" {
+			t.Fatalf("unexpected targets: %+v", targets)
+		}
+	})
+
+	t.Run("requires statement blocks", func(t *testing.T) {
+		svc := newService(map[string][]string{"core": {"/repo/internal/core"}}, fakeParser{stats: stats})
+		if _, err := svc.Scaffold(context.Background(), ScaffoldOptions{}, fakeScaffolder{targets: new([]ScaffoldTarget)}); err == nil {
+			t.Fatal("expected error without block support")
+		}
+	})
+}
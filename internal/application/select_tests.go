@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// SelectTests maps the files changed since a base ref to the tests that
+// cover them. With opts.ProfilesDir it uses per-test profiles, one per
+// top-level test, to name individual tests; otherwise, and for changed
+// files no profile covers, it selects whole packages.
+func (s *Service) SelectTests(ctx context.Context, opts SelectOptions, profiles TestProfileSource) (domain.TestSelection, error) {
+	cfg, _, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return domain.TestSelection{}, err
+	}
+	diffCfg := overrideDiffBase(cfg.Diff, opts.Base)
+	if opts.FilesFrom != "" {
+		diffCfg.FilesFrom = opts.FilesFrom
+	}
+	if diffCfg.Base == "" {
+		diffCfg.Base = "HEAD~1"
+	}
+	provider := selectDiffProvider(s.DiffProvider, diffCfg)
+	if provider == nil {
+		return domain.TestSelection{}, errors.New("select needs a diff provider")
+	}
+	changed, err := provider.ChangedFiles(ctx, diffCfg.Base)
+	if err != nil {
+		return domain.TestSelection{}, fmt.Errorf("changed files: %w", err)
+	}
+
+	var coveredBy map[string][]domain.TestRef
+	if opts.ProfilesDir != "" {
+		coveredBy, err = s.testAttribution(ctx, cfg, profiles, opts.ProfilesDir)
+		if err != nil {
+			return domain.TestSelection{}, err
+		}
+	}
+	return domain.SelectTests(changed, coveredBy), nil
+}
+
+// testAttribution maps each module-relative file to the tests whose
+// profile executes at least one of its statements.
+func (s *Service) testAttribution(ctx context.Context, cfg Config, source TestProfileSource, dir string) (map[string][]domain.TestRef, error) {
+	list, err := source.TestProfiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("no per-test profiles found in %s", dir)
+	}
+	moduleRoot, err := s.DomainResolver.ModuleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	modulePath, err := s.DomainResolver.ModulePath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	links := mergeSymlinks(cfg.Merge, moduleRoot)
+
+	coveredBy := make(map[string][]domain.TestRef)
+	for _, p := range list {
+		stats, err := s.ProfileParser.Parse(p.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Path, err)
+		}
+		ref := domain.TestRef{Package: domain.GoPackage(p.Dir), Name: p.Test}
+		for file, stat := range stats {
+			if stat.Covered == 0 {
+				continue
+			}
+			key := coverageKey(file, moduleRoot, modulePath, cfg.Merge.PathMappings, links)
+			coveredBy[key] = append(coveredBy[key], ref)
+		}
+	}
+	return coveredBy, nil
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// fakeProfileFiles parses each profile path to its own stats.
+type fakeProfileFiles struct {
+	fakeParser
+	byPath map[string]map[string]domain.CoverageStat
+}
+
+func (f fakeProfileFiles) Parse(path string) (map[string]domain.CoverageStat, error) {
+	return f.byPath[path], nil
+}
+
+type fakeTestProfiles []TestProfile
+
+func (f fakeTestProfiles) TestProfiles(string) ([]TestProfile, error) { return f, nil }
+
+func TestServiceSelectTests(t *testing.T) {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "all", Match: []string{"./..."}}}}}
+	newService := func(changed []string) *Service {
+		return &Service{
+			ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+			Autodetector:   fakeAutodetector{},
+			DomainResolver: fakeResolver{moduleRoot: "/repo", modulePath: "example.com/mod"},
+			DiffProvider:   fakeDiffProvider{files: changed},
+			ProfileParser: fakeProfileFiles{byPath: map[string]map[string]domain.CoverageStat{
+				"p/TestParse.out":  {"example.com/mod/internal/core/parse.go": {Covered: 3, Total: 5}, "example.com/mod/internal/core/other.go": {Covered: 0, Total: 2}},
+				"p/TestHandle.out": {"example.com/mod/internal/core/parse.go": {Covered: 1, Total: 5}, "example.com/mod/internal/api/h.go": {Covered: 4, Total: 4}},
+			}},
+			Out: io.Discard,
+		}
+	}
+	profiles := fakeTestProfiles{
+		{Dir: "internal/core", Test: "TestParse", Path: "p/TestParse.out"},
+		{Dir: "internal/api", Test: "TestHandle", Path: "p/TestHandle.out"},
+	}
+
+	t.Run("names covering tests from per-test profiles", func(t *testing.T) {
+		svc := newService([]string{"internal/core/parse.go", "internal/core/other.go", "go.mod"})
+		got, err := svc.SelectTests(context.Background(), SelectOptions{ProfilesDir: "p"}, profiles)
+		if err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		if got.Run != "^(TestHandle|TestParse)$" || !reflect.DeepEqual(got.TestPackages, []string{"./internal/api"}) {
+			t.Fatalf("unexpected selection: %+v", got)
+		}
+		if !reflect.DeepEqual(got.Packages, []string{"./internal/core"}) || !reflect.DeepEqual(got.Unmapped, []string{"internal/core/other.go"}) {
+			t.Fatalf("unexpected fallback: %+v", got)
+		}
+	})
+
+	t.Run("selects packages without profiles", func(t *testing.T) {
+		svc := newService([]string{"internal/api/h.go"})
+		got, err := svc.SelectTests(context.Background(), SelectOptions{}, profiles)
+		if err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		if !reflect.DeepEqual(got.Packages, []string{"./internal/api"}) || len(got.Tests) != 0 {
+			t.Fatalf("unexpected selection: %+v", got)
+		}
+	})
+
+	t.Run("requires profiles in the directory", func(t *testing.T) {
+		svc := newService([]string{"internal/api/h.go"})
+		if _, err := svc.SelectTests(context.Background(), SelectOptions{ProfilesDir: "p"}, fakeTestProfiles{}); err == nil {
+			t.Fatal("expected error for an empty profile directory")
+		}
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -26,6 +25,10 @@ type Service struct {
 	Reporter          Reporter
 	PRClients         map[PRProvider]PRClient // Supports GitHub, GitLab, Bitbucket
 	CommentFormatter  CommentFormatter
+	Notifier          Notifier       // Optional: webhook notifications from check/record
+	Events            EventPublisher // Optional: domain events from check/record
+	Telemetry         Telemetry      // Optional: spans and metrics for coverage runs
+	Cache             AnalysisCache  // Optional: reuse prepared coverage for unchanged profiles and config
 	Out               io.Writer
 }
 
@@ -35,23 +38,29 @@ type CheckOptions struct {
 	ConfigPath     string
 	Output         OutputFormat
 	Profile        string
-	Domains        []string     // Filter to specific domains (empty = all domains)
-	HistoryStore   HistoryStore // Optional: for delta calculation
-	FailUnder      *float64     // Optional: fail if overall coverage is below this threshold
-	Ratchet        bool         // Fail if coverage decreases from previous recorded value
-	BuildFlags     BuildFlags   // Build and test flags
-	Incremental    bool         // Only test packages with changed files
-	IncrementalRef string       // Git ref to compare against (default: HEAD~1)
-	Language       Language     // Override language auto-detection (empty = auto)
-	FromProfile    bool         // Use existing coverage profile instead of running tests (policy still evaluates every domain)
-}
-
-type RunOnlyOptions struct {
-	ConfigPath string
-	Profile    string
-	Domains    []string   // Filter to specific domains (empty = all domains)
-	BuildFlags BuildFlags // Build and test flags
-	Language   Language   // Override language auto-detection (empty = auto)
+	Domains        []string           // Filter to specific domains (empty = all domains)
+	HistoryStore   HistoryStore       // Optional: for delta calculation
+	BaselineStore  HistoryStore       // Optional: history that decides which domains are new (policy.new_domain) and tracks goals
+	FailUnder      *float64           // Optional: fail if overall coverage is below this threshold
+	Ratchet        bool               // Fail if coverage decreases from previous recorded value
+	BuildFlags     BuildFlags         // Build and test flags
+	Incremental    bool               // Only test packages with changed files
+	IncrementalRef string             // Git ref to compare against (default: HEAD~1)
+	Language       Language           // Override language auto-detection (empty = auto)
+	Runner         string             // Run with this runner, bypassing detection (empty = config or auto)
+	FromProfile    bool               // Use existing coverage profile instead of running tests (policy still evaluates every domain)
+	MaxProfileAge  string             // Overrides profile.max_age for FromProfile (30m, 12h, 1d)
+	StaleProfile   StaleProfileAction // Overrides profile.stale
+	DiffBase       string             // Git ref (or "auto") for diff mode; enables diff and overrides config
+	Summary        io.Writer          // Optional: also write a markdown summary here (e.g. GitHub job summary)
+	ReportFile     io.Writer          // Optional: always write the RunReport JSON here
+	HTML           HTMLOptions        // Title and source embedding for HTML output
+	TopFiles       int                // List this many files with the most uncovered statements in failing domains
+	PruneStale     bool               // Drop profile entries for files missing under the module root
+	Progress       io.Writer          // Optional: live test progress line (TTY only, Go runner)
+	TimingStore    TimingStore        // Optional: record how long the test run took
+	FailFast       bool               // Stop per-domain test runs once a finished domain is below its minimum
+	IsolateDomains bool               // Test each domain alone with -coverpkg limited to it (coverpkg.isolate)
 }
 
 type ReportOptions struct {
@@ -63,64 +72,16 @@ type ReportOptions struct {
 	ShowUncovered bool         // Show only files with 0% coverage
 	DiffRef       string       // Git ref for diff-based filtering (overrides config)
 	MergeProfiles []string     // Additional profile files to merge
+	Summary       io.Writer    // Optional: also write a markdown summary here (e.g. GitHub job summary)
+	ReportFile    io.Writer    // Optional: always write the RunReport JSON here
+	HTML          HTMLOptions  // Title and source embedding for HTML output
+	TopFiles      int          // List this many files with the most uncovered statements in failing domains
+	PruneStale    bool         // Drop profile entries for files missing under the module root
+	TimingStore   TimingStore  // Optional: split the last recorded test time across domains
 }
 
 type DetectOptions struct {
-}
-
-// coverageContext holds the precomputed coverage data used across multiple methods.
-// This reduces code duplication by encapsulating the common setup logic.
-type coverageContext struct {
-	ModuleRoot         string
-	ModulePath         string
-	NormalizedCoverage map[string]domain.CoverageStat
-	Annotations        map[string]Annotation
-	DomainDirs         map[string][]string
-	DomainExcludes     map[string][]string
-	DomainCoverage     map[string]domain.CoverageStat
-}
-
-// prepareCoverageContext loads and prepares all coverage-related data needed for analysis.
-// This is the common setup used by Check, Report, Debt, Suggest, Badge, Compare, and Record.
-func (s *Service) prepareCoverageContext(ctx context.Context, cfg Config, domains []domain.Domain, profiles []string) (*coverageContext, error) {
-	moduleRoot, err := s.DomainResolver.ModuleRoot(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	modulePath, err := s.DomainResolver.ModulePath(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	fileCoverage, err := s.ProfileParser.ParseAll(profiles)
-	if err != nil {
-		return nil, err
-	}
-
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
-	annotations, err := s.loadAnnotations(ctx, cfg, moduleRoot, normalizedCoverage)
-	if err != nil {
-		return nil, err
-	}
-
-	domainDirs, err := s.DomainResolver.Resolve(ctx, domains)
-	if err != nil {
-		return nil, err
-	}
-
-	domainExcludes := buildDomainExcludes(domains)
-	domainCoverage := AggregateByDomainWithExcludes(normalizedCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
-
-	return &coverageContext{
-		ModuleRoot:         moduleRoot,
-		ModulePath:         modulePath,
-		NormalizedCoverage: normalizedCoverage,
-		Annotations:        annotations,
-		DomainDirs:         domainDirs,
-		DomainExcludes:     domainExcludes,
-		DomainCoverage:     domainCoverage,
-	}, nil
+	ConfigPath string // Existing config that DetectMerge adds domains to
 }
 
 // buildProfileList constructs the list of profiles from a primary profile path and config merge profiles.
@@ -133,8 +94,12 @@ func buildProfileList(primaryProfile string, mergeProfiles []string) []string {
 }
 
 // selectRunnerMethod is a convenience method that delegates to the shared selectRunner function.
-func (s *Service) selectRunnerMethod(lang Language, cfgLang Language) (CoverageRunner, error) {
-	return selectRunner(s.RunnerRegistry, s.CoverageRunner, lang, cfgLang)
+func (s *Service) selectRunnerMethod(runnerName, cfgRunner string, lang, cfgLang Language) (CoverageRunner, error) {
+	runner, err := selectRunner(s.RunnerRegistry, s.CoverageRunner, runnerName, cfgRunner, lang, cfgLang)
+	if err != nil || s.Telemetry == nil {
+		return runner, err
+	}
+	return tracedRunner{CoverageRunner: runner, telemetry: s.Telemetry}, nil
 }
 
 // CheckResult runs coverage tests and evaluates policy, returning the result.
@@ -144,15 +109,17 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 	if err != nil {
 		return domain.Result{}, err
 	}
+	cfg.Diff = overrideDiffBase(cfg.Diff, opts.DiffBase)
 
 	// Filter domains if specific ones are requested
 	domains = filterDomainsByNames(domains, opts.Domains)
 	if len(domains) == 0 {
-		return domain.Result{}, fmt.Errorf("no matching domains found for: %v", opts.Domains)
+		return domain.Result{}, errNoMatchingDomains(opts.Domains)
 	}
 
 	var profiles []string
-	var fromProfileWarnings []string
+	var runWarnings []string
+	sharedRun := false // profiles[0] is the shared test run
 	if opts.FromProfile {
 		if opts.Profile == "" {
 			return domain.Result{}, fmt.Errorf("profile path is required when using --from-profile")
@@ -162,14 +129,14 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 		}
 		profiles = append(profiles, opts.Profile)
 		if cfg.Integration.Enabled {
-			fromProfileWarnings = append(fromProfileWarnings, "integration coverage is enabled but --from-profile skips running integration tests")
+			runWarnings = append(runWarnings, "integration coverage is enabled but --from-profile skips running integration tests")
 		}
 		if len(cfg.Merge.Profiles) > 0 {
 			profiles = append(profiles, cfg.Merge.Profiles...)
 		}
 	} else {
 		// Select the appropriate runner based on language
-		runner, err := s.selectRunnerMethod(opts.Language, cfg.Language)
+		runner, err := s.selectRunnerMethod(opts.Runner, cfg.Runner, opts.Language, cfg.Language)
 		if err != nil {
 			return domain.Result{}, err
 		}
@@ -195,18 +162,47 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 			}
 		}
 
-		profile, err := runner.Run(ctx, RunOptions{
+		// Incremental runs only test some packages, so they would
+		// overwrite the full run's timings with a partial picture.
+		var timings *domain.TestTimings
+		if opts.TimingStore != nil && !opts.Incremental {
+			timings = &domain.TestTimings{RecordedAt: time.Now()}
+		}
+		runOpts := RunOptions{
 			Domains:     domains,
 			ProfilePath: opts.Profile,
 			BuildFlags:  opts.BuildFlags,
 			Packages:    packages,
-		})
+			Progress:    opts.Progress,
+			Retry:       cfg.Retry,
+			CoverPkg:    cfg.CoverPkg,
+			Matrix:      cfg.Matrix,
+		}
+		runOpts.CoverPkg.Isolate = cfg.CoverPkg.Isolate || opts.IsolateDomains
+		runWarnings = append(runWarnings, matrixWarnings(runner, cfg.Matrix)...)
+		stop := newFailFast(ctx, s, cfg)
+		if opts.FailFast {
+			runOpts.AfterDomains = stop.after
+		}
+		sharedProfile, domainProfiles, err := runDomainTests(ctx, runner, commandRunnerOf(s.RunnerRegistry, s.CoverageRunner), runOpts, timings)
 		if err != nil {
 			return domain.Result{}, err
 		}
+		var stopWarnings []string
+		domains, stopWarnings = stop.trim(domains)
+		runWarnings = append(runWarnings, stopWarnings...)
+		// A run stopped by fail-fast has no times for the skipped domains.
+		if timings != nil && len(stopWarnings) == 0 {
+			if err := opts.TimingStore.Save(*timings); err != nil {
+				return domain.Result{}, err
+			}
+		}
 
-		profiles = append(profiles, profile)
-		if cfg.Integration.Enabled {
+		if sharedProfile != "" {
+			profiles = append(profiles, sharedProfile)
+			sharedRun = true
+		}
+		if cfg.Integration.Enabled && len(stopWarnings) == 0 {
 			integrationProfile, err := runner.RunIntegration(ctx, IntegrationOptions{
 				Domains:    domains,
 				Packages:   cfg.Integration.Packages,
@@ -214,12 +210,14 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 				CoverDir:   cfg.Integration.CoverDir,
 				Profile:    cfg.Integration.Profile,
 				BuildFlags: opts.BuildFlags,
+				CoverPkg:   cfg.CoverPkg,
 			})
 			if err != nil {
-				return domain.Result{}, err
+				return domain.Result{}, WithErrorCode(ErrCodeRunnerFailed, err)
 			}
 			profiles = append(profiles, integrationProfile)
 		}
+		profiles = append(profiles, domainProfiles...)
 		if len(cfg.Merge.Profiles) > 0 {
 			profiles = append(profiles, cfg.Merge.Profiles...)
 		}
@@ -235,12 +233,22 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 		return domain.Result{}, err
 	}
 
-	fileCoverage, err := s.ProfileParser.ParseAll(profiles)
+	fileCoverage, err := s.parseAll(ctx, profiles)
 	if err != nil {
 		return domain.Result{}, err
 	}
 
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	normalizedCoverage, staleWarnings := sanitizeStaleEntries(normalizedCoverage, moduleRoot, opts.PruneStale)
+	ageWarnings, err := guardProfileAge(s.ProfileParser, cfg.Profile, opts, profiles, normalizedCoverage, moduleRoot)
+	if err != nil {
+		return domain.Result{}, err
+	}
+	runWarnings = append(runWarnings, ageWarnings...)
+	normalizedCoverage, err = excludeFunctions(ctx, s.ProfileParser, s.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return domain.Result{}, err
+	}
 	annotations, err := s.loadAnnotations(ctx, cfg, moduleRoot, normalizedCoverage)
 	if err != nil {
 		return domain.Result{}, err
@@ -256,13 +264,19 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 		return result, nil
 	}
 
-	domainDirs, err := s.DomainResolver.Resolve(ctx, domains)
+	domainDirs, err := resolveDomainDirs(ctx, s.DomainResolver, domains, moduleRoot, cfg.Merge)
 	if err != nil {
 		return domain.Result{}, err
 	}
 
+	_, endAggregate := s.startPhase(ctx, PhaseAggregate, nil)
 	domainExcludes := buildDomainExcludes(domains)
 	domainCoverage := AggregateByDomainWithExcludes(filteredCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
+	err = applyDomainMetrics(s.ProfileParser, cfg.Policy, domains, profiles, newDomainAggregator(cfg, moduleRoot, modulePath, changedFiles, domainDirs, domainExcludes, annotations), domainCoverage)
+	endAggregate(err)
+	if err != nil {
+		return domain.Result{}, err
+	}
 	policy := cfg.Policy
 	// Use filtered domains for policy evaluation
 	policy.Domains = domains
@@ -271,14 +285,46 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 	}
 	result := domain.Evaluate(policy, domainCoverage)
 	result.Warnings = domainOverlapWarnings(domainDirs)
-	if len(fromProfileWarnings) > 0 {
-		result.Warnings = append(result.Warnings, fromProfileWarnings...)
-	}
-	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations)
+	if len(opts.Domains) == 0 {
+		result.Warnings = append(result.Warnings, unmatchedFilesWarning(filteredCoverage, domainDirs, cfg.Exclude, moduleRoot, modulePath, annotations)...)
+	}
+	result.EmptyDomains = emptyDomains(policy.Domains, domainDirs, domainCoverage)
+	result.Warnings = append(result.Warnings, emptyDomainWarnings(result.EmptyDomains)...)
+	if len(runWarnings) > 0 {
+		result.Warnings = append(result.Warnings, runWarnings...)
+	}
+	result.Warnings = append(result.Warnings, staleWarnings...)
+	result.Warnings = append(result.Warnings, profileExpansionWarnings(s.ProfileParser, profiles)...)
+	result.Warnings = append(result.Warnings, profileModeWarnings(s.ProfileParser, profiles)...)
+	applyNewDomainPolicy(&result, cfg.Policy.NewDomain, opts.BaselineStore)
+	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations, cfg.Policy.Rounding)
 	result.Files = fileResults
 	if !filesPassed {
 		result.Passed = false
 	}
+	applyExceptions(&result, cfg.Exceptions, cfg.Policy.Domains)
+	result.TopFiles = topUncoveredFiles(opts.TopFiles, filteredCoverage, result, cfg.Exclude, &coverageContext{
+		ModuleRoot:     moduleRoot,
+		ModulePath:     modulePath,
+		Annotations:    annotations,
+		DomainDirs:     domainDirs,
+		DomainExcludes: domainExcludes,
+	})
+	if err := attachSourceCoverage(s.ProfileParser, profiles, profileSourceLabels(profiles, sharedRun, cfg.Integration.Enabled), newDomainAggregator(cfg, moduleRoot, modulePath, changedFiles, domainDirs, domainExcludes, annotations), &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyPatchCoverage(ctx, s.DiffProvider, s.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyNewCodeCoverage(ctx, s.DiffProvider, s.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyFunctionCoverage(ctx, s.ProfileParser, s.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, filteredCoverage, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := attachLineCoverage(opts.Output, opts.HTML, s.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
 
 	// Apply deltas from history if available
 	if opts.HistoryStore != nil {
@@ -291,62 +337,59 @@ func (s *Service) CheckResult(ctx context.Context, opts CheckOptions) (domain.Re
 	return result, nil
 }
 
-func (s *Service) Check(ctx context.Context, opts CheckOptions) error {
+func (s *Service) Check(ctx context.Context, opts CheckOptions) (err error) {
+	ctx, end := s.startPhase(ctx, PhaseCheck, nil)
+	defer func() { end(err) }()
+	report := s.startRunReport(opts.ReportFile, "check", opts.ConfigPath)
+	defer func() { err = report.finish(err) }()
 	result, err := s.CheckResult(ctx, opts)
 	if err != nil {
 		return err
 	}
+	// Fail-under and the ratchet fail the result before it is rendered,
+	// summarised, and notified, so every consumer sees the same verdict.
+	overallErrs := overallThresholdErrors(opts, result)
+	for _, err := range overallErrs {
+		result.Passed = false
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+	s.recordResult(ctx, PhaseCheck, result)
+	health := result.ComputeHealth(len(evaluationEvents(result)))
+	result.Health = &health
+	result.Warnings = append(result.Warnings, s.notifyCheck(ctx, opts, result)...)
+	result.Warnings = append(result.Warnings, s.publishCheckEvents(ctx, opts, result)...)
+	result.Warnings = append(result.Warnings, s.goalWarnings(opts, result)...)
+	report.setResult(result)
 
-	if err := s.Reporter.Write(s.Out, result, opts.Output); err != nil {
+	if err := s.writeResult(s.Out, result, opts.Output, opts.HTML); err != nil {
 		return err
 	}
-
-	// Check fail-under threshold if specified
-	if opts.FailUnder != nil {
-		overallPercent := result.OverallPercent()
-		if overallPercent < *opts.FailUnder {
-			return fmt.Errorf("coverage %.1f%% is below --fail-under threshold of %.1f%%", overallPercent, *opts.FailUnder)
-		}
+	if err := s.writeSummary(opts.Summary, result); err != nil {
+		return err
 	}
-
-	// Check ratchet: coverage must not decrease from previous value
-	if opts.Ratchet && opts.HistoryStore != nil {
-		hist, err := opts.HistoryStore.Load()
-		if err == nil && len(hist.Entries) > 0 {
-			previousPercent := hist.Entries[len(hist.Entries)-1].Overall
-			currentPercent := result.OverallPercent()
-			if currentPercent < previousPercent {
-				return fmt.Errorf("coverage decreased from %.1f%% to %.1f%% (--ratchet prevents regression)", previousPercent, currentPercent)
-			}
-		}
+	if len(overallErrs) > 0 {
+		return overallErrs[0]
 	}
-
 	if !result.Passed {
 		return fmt.Errorf("policy violation")
 	}
 	return nil
 }
 
-func (s *Service) RunOnly(ctx context.Context, opts RunOnlyOptions) error {
-	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
-	if err != nil {
-		return err
+// overallThresholdErrors checks the overall percentage against
+// --fail-under and, with --ratchet, against the latest history entry.
+func overallThresholdErrors(opts CheckOptions, result domain.Result) []error {
+	var errs []error
+	overall := result.OverallPercent()
+	if opts.FailUnder != nil && !result.MeetsOverall(*opts.FailUnder) {
+		errs = append(errs, fmt.Errorf("coverage %.1f%% is below --fail-under threshold of %.1f%%", overall, *opts.FailUnder))
 	}
-
-	// Select the appropriate runner based on language
-	runner, err := s.selectRunnerMethod(opts.Language, cfg.Language)
-	if err != nil {
-		return err
-	}
-
-	// Filter domains if specific ones are requested
-	domains = filterDomainsByNames(domains, opts.Domains)
-	if len(domains) == 0 {
-		return fmt.Errorf("no matching domains found for: %v", opts.Domains)
+	if opts.Ratchet {
+		if previous := latestHistoryEntry(opts.HistoryStore); previous != nil && !result.MeetsOverall(previous.Overall) {
+			errs = append(errs, fmt.Errorf("coverage decreased from %.1f%% to %.1f%% (--ratchet prevents regression)", previous.Overall, overall))
+		}
 	}
-
-	_, err = runner.Run(ctx, RunOptions{Domains: domains, ProfilePath: opts.Profile, BuildFlags: opts.BuildFlags})
-	return err
+	return errs
 }
 
 // ReportResult analyzes an existing coverage profile and returns the result.
@@ -360,17 +403,7 @@ func (s *Service) ReportResult(ctx context.Context, opts ReportOptions) (domain.
 	// Filter domains if specific ones are requested
 	domains = filterDomainsByNames(domains, opts.Domains)
 	if len(domains) == 0 {
-		return domain.Result{}, fmt.Errorf("no matching domains found for: %v", opts.Domains)
-	}
-
-	moduleRoot, err := s.DomainResolver.ModuleRoot(ctx)
-	if err != nil {
-		return domain.Result{}, err
-	}
-
-	modulePath, err := s.DomainResolver.ModulePath(ctx)
-	if err != nil {
-		return domain.Result{}, err
+		return domain.Result{}, errNoMatchingDomains(opts.Domains)
 	}
 
 	profiles := []string{opts.Profile}
@@ -381,16 +414,13 @@ func (s *Service) ReportResult(ctx context.Context, opts ReportOptions) (domain.
 	if len(opts.MergeProfiles) > 0 {
 		profiles = append(profiles, opts.MergeProfiles...)
 	}
-	fileCoverage, err := s.ProfileParser.ParseAll(profiles)
-	if err != nil {
-		return domain.Result{}, err
-	}
-
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
-	annotations, err := s.loadAnnotations(ctx, cfg, moduleRoot, normalizedCoverage)
+	covCtx, err := s.prepareCoverage(ctx, cfg, domains, profiles, opts.PruneStale)
 	if err != nil {
 		return domain.Result{}, err
 	}
+	moduleRoot, modulePath := covCtx.ModuleRoot, covCtx.ModulePath
+	normalizedCoverage, annotations := covCtx.NormalizedCoverage, covCtx.Annotations
+	staleWarnings := covCtx.StaleWarnings
 
 	// Handle --uncovered flag: show only files with 0% coverage
 	if opts.ShowUncovered {
@@ -398,11 +428,7 @@ func (s *Service) ReportResult(ctx context.Context, opts ReportOptions) (domain.
 	}
 
 	// Handle --diff flag: override config diff setting
-	diffCfg := cfg.Diff
-	if opts.DiffRef != "" {
-		diffCfg.Enabled = true
-		diffCfg.Base = opts.DiffRef
-	}
+	diffCfg := overrideDiffBase(cfg.Diff, opts.DiffRef)
 	changedFiles, err := s.diffFilesWithConfig(ctx, diffCfg)
 	if err != nil {
 		return domain.Result{}, err
@@ -414,13 +440,16 @@ func (s *Service) ReportResult(ctx context.Context, opts ReportOptions) (domain.
 		return result, nil
 	}
 
-	domainDirs, err := s.DomainResolver.Resolve(ctx, domains)
+	domainDirs := covCtx.DomainDirs
+
+	_, endAggregate := s.startPhase(ctx, PhaseAggregate, nil)
+	domainExcludes := covCtx.DomainExcludes
+	domainCoverage := AggregateByDomainWithExcludes(filteredCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
+	err = applyDomainMetrics(s.ProfileParser, cfg.Policy, domains, profiles, newDomainAggregator(cfg, moduleRoot, modulePath, changedFiles, domainDirs, domainExcludes, annotations), domainCoverage)
+	endAggregate(err)
 	if err != nil {
 		return domain.Result{}, err
 	}
-
-	domainExcludes := buildDomainExcludes(domains)
-	domainCoverage := AggregateByDomainWithExcludes(filteredCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
 	policy := cfg.Policy
 	// Use filtered domains for policy evaluation
 	policy.Domains = domains
@@ -429,11 +458,42 @@ func (s *Service) ReportResult(ctx context.Context, opts ReportOptions) (domain.
 	}
 	result := domain.Evaluate(policy, domainCoverage)
 	result.Warnings = domainOverlapWarnings(domainDirs)
-	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations)
+	if len(opts.Domains) == 0 {
+		result.Warnings = append(result.Warnings, unmatchedFilesWarning(filteredCoverage, domainDirs, cfg.Exclude, moduleRoot, modulePath, annotations)...)
+	}
+	result.EmptyDomains = emptyDomains(policy.Domains, domainDirs, domainCoverage)
+	result.Warnings = append(result.Warnings, emptyDomainWarnings(result.EmptyDomains)...)
+	result.Warnings = append(result.Warnings, staleWarnings...)
+	result.Warnings = append(result.Warnings, profileExpansionWarnings(s.ProfileParser, profiles)...)
+	result.Warnings = append(result.Warnings, profileModeWarnings(s.ProfileParser, profiles)...)
+	fileResults, filesPassed := evaluateFileRules(filteredCoverage, cfg.Files, cfg.Exclude, annotations, cfg.Policy.Rounding)
 	result.Files = fileResults
 	if !filesPassed {
 		result.Passed = false
 	}
+	applyExceptions(&result, cfg.Exceptions, cfg.Policy.Domains)
+	result.TopFiles = topUncoveredFiles(opts.TopFiles, filteredCoverage, result, cfg.Exclude, &coverageContext{
+		ModuleRoot:     moduleRoot,
+		ModulePath:     modulePath,
+		Annotations:    annotations,
+		DomainDirs:     domainDirs,
+		DomainExcludes: domainExcludes,
+	})
+	if err := attachTiming(opts.TimingStore, domainDirs, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyNewCodeCoverage(ctx, s.DiffProvider, s.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := applyFunctionCoverage(ctx, s.ProfileParser, s.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, filteredCoverage, &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := attachSourceCoverage(s.ProfileParser, profiles, profileSourceLabels(profiles, false, false), newDomainAggregator(cfg, moduleRoot, modulePath, changedFiles, domainDirs, domainExcludes, annotations), &result); err != nil {
+		return domain.Result{}, err
+	}
+	if err := attachLineCoverage(opts.Output, opts.HTML, s.ProfileParser, cfg, profiles, moduleRoot, modulePath, &result); err != nil {
+		return domain.Result{}, err
+	}
 
 	// Apply deltas from history if available
 	if opts.HistoryStore != nil {
@@ -446,12 +506,33 @@ func (s *Service) ReportResult(ctx context.Context, opts ReportOptions) (domain.
 	return result, nil
 }
 
-func (s *Service) Report(ctx context.Context, opts ReportOptions) error {
+func (s *Service) Report(ctx context.Context, opts ReportOptions) (err error) {
+	ctx, end := s.startPhase(ctx, PhaseReport, nil)
+	defer func() { end(err) }()
+	report := s.startRunReport(opts.ReportFile, "report", opts.ConfigPath)
+	defer func() { err = report.finish(err) }()
 	result, err := s.ReportResult(ctx, opts)
 	if err != nil {
 		return err
 	}
-	return s.Reporter.Write(s.Out, result, opts.Output)
+	report.setResult(result)
+	s.recordResult(ctx, PhaseReport, result)
+	if err := s.writeResult(s.Out, result, opts.Output, opts.HTML); err != nil {
+		return err
+	}
+	return s.writeSummary(opts.Summary, result)
+}
+
+// writeSummary renders result as markdown to w when a summary destination
+// was requested.
+func (s *Service) writeSummary(w io.Writer, result domain.Result) error {
+	if w == nil {
+		return nil
+	}
+	if err := s.Reporter.Write(w, result, OutputMarkdown); err != nil {
+		return fmt.Errorf("write summary: %w", err)
+	}
+	return nil
 }
 
 // reportUncoveredResult returns a result of files with 0% coverage.
@@ -495,18 +576,7 @@ func (s *Service) reportUncoveredResult(files map[string]domain.CoverageStat, ex
 
 // diffFilesWithConfig gets changed files using the given diff configuration.
 func (s *Service) diffFilesWithConfig(ctx context.Context, cfg DiffConfig) (map[string]struct{}, error) {
-	if !cfg.Enabled || s.DiffProvider == nil {
-		return nil, nil
-	}
-	files, err := s.DiffProvider.ChangedFiles(ctx, cfg.Base)
-	if err != nil {
-		return nil, err
-	}
-	allow := make(map[string]struct{}, len(files))
-	for _, file := range files {
-		allow[filepath.ToSlash(filepath.Clean(file))] = struct{}{}
-	}
-	return allow, nil
+	return diffFiles(ctx, s.DiffProvider, cfg)
 }
 
 func (s *Service) Detect(ctx context.Context, opts DetectOptions) (Config, error) {
@@ -581,7 +651,7 @@ func excluded(file string, patterns []string) bool {
 		return false
 	}
 	for _, pattern := range patterns {
-		if ok, _ := filepath.Match(pattern, file); ok {
+		if domain.MatchGlob(pattern, file) {
 			return true
 		}
 	}
@@ -612,6 +682,10 @@ func matchesAnyDir(file string, dirs []string, moduleRoot string) bool {
 }
 
 func normalizeCoverageFile(file, modulePath, moduleRoot string) string {
+	file = domain.CanonicalPath(file)
+	if domain.IsWindowsAbs(file) {
+		return windowsCoverageFile(file, moduleRoot)
+	}
 	clean := filepath.Clean(file)
 	if filepath.IsAbs(clean) {
 		return clean
@@ -643,11 +717,16 @@ func moduleRelativePath(path, moduleRoot string) string {
 	return filepath.Clean(rel)
 }
 
-func normalizeCoverageMap(files map[string]domain.CoverageStat, moduleRoot, modulePath string) map[string]domain.CoverageStat {
+// normalizeCoverageMap keys files by module-relative slash path, applying
+// mappings to the raw profile paths first.
+func normalizeCoverageMap(files map[string]domain.CoverageStat, moduleRoot, modulePath string, mappings ...PathMapping) map[string]domain.CoverageStat {
+	return normalizeCoverageKeys(files, moduleRoot, modulePath, mappings, nil)
+}
+
+func normalizeCoverageKeys(files map[string]domain.CoverageStat, moduleRoot, modulePath string, mappings []PathMapping, links *symlinkResolver) map[string]domain.CoverageStat {
 	result := make(map[string]domain.CoverageStat, len(files))
 	for file, stat := range files {
-		normalized := normalizeCoverageFile(file, modulePath, moduleRoot)
-		rel := filepath.ToSlash(moduleRelativePath(normalized, moduleRoot))
+		rel := coverageKey(file, moduleRoot, modulePath, mappings, links)
 		agg := result[rel]
 		agg.Covered += stat.Covered
 		agg.Total += stat.Total
@@ -669,7 +748,7 @@ func filterCoverageByFiles(files map[string]domain.CoverageStat, allow map[strin
 	return filtered
 }
 
-func evaluateFileRules(files map[string]domain.CoverageStat, rules []domain.FileRule, exclude []string, annotations map[string]Annotation) ([]domain.FileResult, bool) {
+func evaluateFileRules(files map[string]domain.CoverageStat, rules []domain.FileRule, exclude []string, annotations map[string]Annotation, rounding domain.Rounding) ([]domain.FileResult, bool) {
 	if len(rules) == 0 {
 		return nil, true
 	}
@@ -693,9 +772,9 @@ func evaluateFileRules(files map[string]domain.CoverageStat, rules []domain.File
 	passed := true
 	for file, min := range minByFile {
 		stat := files[file]
-		percent := domain.Round1(stat.Percent())
+		percent := rounding.Percent(stat.Percent())
 		status := domain.StatusPass
-		if percent < min {
+		if !rounding.Meets(stat.Percent(), min) {
 			status = domain.StatusFail
 			passed = false
 		}
@@ -753,18 +832,7 @@ func filterDomainsByNames(domains []domain.Domain, names []string) []domain.Doma
 }
 
 func (s *Service) diffFiles(ctx context.Context, cfg Config) (map[string]struct{}, error) {
-	if !cfg.Diff.Enabled || s.DiffProvider == nil {
-		return nil, nil
-	}
-	files, err := s.DiffProvider.ChangedFiles(ctx, cfg.Diff.Base)
-	if err != nil {
-		return nil, err
-	}
-	allow := make(map[string]struct{}, len(files))
-	for _, file := range files {
-		allow[filepath.ToSlash(filepath.Clean(file))] = struct{}{}
-	}
-	return allow, nil
+	return diffFiles(ctx, s.DiffProvider, cfg.Diff)
 }
 
 func (s *Service) loadAnnotations(ctx context.Context, cfg Config, moduleRoot string, files map[string]domain.CoverageStat) (map[string]Annotation, error) {
@@ -809,39 +877,6 @@ func buildDomainExcludes(domains []domain.Domain) map[string][]string {
 	return result
 }
 
-// BadgeResult contains the data needed to generate a coverage badge.
-type BadgeResult struct {
-	Percent float64
-}
-
-// Badge calculates overall coverage for badge generation.
-func (s *Service) Badge(ctx context.Context, opts BadgeOptions) (BadgeResult, error) {
-	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
-	if err != nil {
-		return BadgeResult{}, err
-	}
-
-	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
-	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
-	if err != nil {
-		return BadgeResult{}, err
-	}
-
-	// Calculate overall coverage across all domains
-	var totalCovered, totalStatements int
-	for _, stat := range covCtx.DomainCoverage {
-		totalCovered += stat.Covered
-		totalStatements += stat.Total
-	}
-
-	percent := 0.0
-	if totalStatements > 0 {
-		percent = domain.Round1((float64(totalCovered) / float64(totalStatements)) * 100)
-	}
-
-	return BadgeResult{Percent: percent}, nil
-}
-
 // TrendResult contains trend analysis data.
 type TrendResult struct {
 	Current  float64
@@ -849,6 +884,14 @@ type TrendResult struct {
 	Trend    domain.Trend
 	Entries  []domain.HistoryEntry
 	ByDomain map[string]domain.Trend
+	// FileRegressions lists files below their coverage in the latest entry
+	// when it tracked files (history.track_files).
+	FileRegressions []domain.FileRegression
+	File            string                  // Set by trend --file
+	FileTrend       []domain.FileTrendPoint // The file's recorded coverage, oldest first
+	// Anomalies are changes since the latest entry large enough to suggest
+	// a broken profile or skipped tests.
+	Anomalies []domain.Anomaly
 }
 
 // Trend analyzes coverage trends over time.
@@ -861,6 +904,9 @@ func (s *Service) Trend(ctx context.Context, opts TrendOptions, store HistorySto
 	if len(history.Entries) == 0 {
 		return TrendResult{}, fmt.Errorf("no history data available; run 'coverctl record' after coverage runs")
 	}
+	if opts.File != "" {
+		return fileTrend(history, opts.File)
+	}
 
 	// Get current coverage
 	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
@@ -887,13 +933,17 @@ func (s *Service) Trend(ctx context.Context, opts TrendOptions, store HistorySto
 		return TrendResult{}, err
 	}
 
-	normalizedCoverage := normalizeCoverageMap(fileCoverage, moduleRoot, modulePath)
+	normalizedCoverage := normalizeProfileCoverage(fileCoverage, moduleRoot, modulePath, cfg)
+	normalizedCoverage, err = excludeFunctions(ctx, s.ProfileParser, s.AnnotationScanner, cfg, profiles, moduleRoot, modulePath, normalizedCoverage)
+	if err != nil {
+		return TrendResult{}, err
+	}
 	annotations, err := s.loadAnnotations(ctx, cfg, moduleRoot, normalizedCoverage)
 	if err != nil {
 		return TrendResult{}, err
 	}
 
-	domainDirs, err := s.DomainResolver.Resolve(ctx, domains)
+	domainDirs, err := resolveDomainDirs(ctx, s.DomainResolver, domains, moduleRoot, cfg.Merge)
 	if err != nil {
 		return TrendResult{}, err
 	}
@@ -902,15 +952,11 @@ func (s *Service) Trend(ctx context.Context, opts TrendOptions, store HistorySto
 	domainCoverage := AggregateByDomainWithExcludes(normalizedCoverage, domainDirs, cfg.Exclude, domainExcludes, moduleRoot, modulePath, annotations)
 
 	// Calculate current overall coverage
-	var totalCovered, totalStatements int
+	var totalStatements int
 	for _, stat := range domainCoverage {
-		totalCovered += stat.Covered
 		totalStatements += stat.Total
 	}
-	currentPercent := 0.0
-	if totalStatements > 0 {
-		currentPercent = domain.Round1((float64(totalCovered) / float64(totalStatements)) * 100)
-	}
+	currentPercent := domain.WeightedOverall(domainCoverage, domains)
 
 	// Get previous entry for trend calculation
 	latest := history.LatestEntry()
@@ -919,11 +965,13 @@ func (s *Service) Trend(ctx context.Context, opts TrendOptions, store HistorySto
 
 	// Calculate per-domain trends
 	byDomain := make(map[string]domain.Trend)
+	current := domain.HistoryEntry{Overall: currentPercent, Statements: totalStatements, Domains: make(map[string]domain.DomainEntry)}
 	for domainName, stat := range domainCoverage {
 		currentDomainPercent := 0.0
 		if stat.Total > 0 {
 			currentDomainPercent = domain.Round1((float64(stat.Covered) / float64(stat.Total)) * 100)
 		}
+		current.Domains[domainName] = domain.DomainEntry{Name: domainName, Percent: currentDomainPercent}
 		if prevEntry, ok := latest.Domains[domainName]; ok {
 			byDomain[domainName] = domain.CalculateTrend(prevEntry.Percent, currentDomainPercent)
 		} else {
@@ -932,11 +980,13 @@ func (s *Service) Trend(ctx context.Context, opts TrendOptions, store HistorySto
 	}
 
 	return TrendResult{
-		Current:  currentPercent,
-		Previous: previousPercent,
-		Trend:    trend,
-		Entries:  history.Entries,
-		ByDomain: byDomain,
+		Current:         currentPercent,
+		Previous:        previousPercent,
+		Trend:           trend,
+		Entries:         history.Entries,
+		ByDomain:        byDomain,
+		FileRegressions: domain.FileRegressions(latest.Files, normalizedCoverage),
+		Anomalies:       domain.NewTrendAnalysisService().DetectAnomalies(latest, &current),
 	}, nil
 }
 
@@ -949,42 +999,44 @@ func (s *Service) RecordWithWarnings(ctx context.Context, opts RecordOptions, st
 
 	domains = filterDomainsByNames(domains, opts.Domains)
 	if len(domains) == 0 {
-		return RecordResult{}, fmt.Errorf("no matching domains found for: %v", opts.Domains)
+		return RecordResult{}, errNoMatchingDomains(opts.Domains)
 	}
 
-	profilePath := opts.ProfilePath
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
 	if opts.Run {
-		runner, err := s.selectRunnerMethod(opts.Language, cfg.Language)
+		runner, err := s.selectRunnerMethod(opts.Runner, cfg.Runner, opts.Language, cfg.Language)
 		if err != nil {
 			return RecordResult{}, err
 		}
-		profilePath, err = runner.Run(ctx, RunOptions{
+		sharedProfile, domainProfiles, err := runDomainTests(ctx, runner, commandRunnerOf(s.RunnerRegistry, s.CoverageRunner), RunOptions{
 			Domains:     domains,
 			ProfilePath: opts.ProfilePath,
 			BuildFlags:  opts.BuildFlags,
-		})
+			Retry:       cfg.Retry,
+			CoverPkg:    cfg.CoverPkg,
+			Matrix:      cfg.Matrix,
+		}, nil)
 		if err != nil {
 			return RecordResult{}, err
 		}
+		profiles = append(domainProfiles, cfg.Merge.Profiles...)
+		if sharedProfile != "" {
+			profiles = append([]string{sharedProfile}, profiles...)
+		}
 	}
 
-	profiles := buildProfileList(profilePath, cfg.Merge.Profiles)
 	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
 	if err != nil {
 		return RecordResult{}, err
 	}
 
 	// Calculate overall coverage
-	var totalCovered, totalStatements int
+	var totalStatements int
 	domainEntries := make(map[string]domain.DomainEntry)
 	for domainName, stat := range covCtx.DomainCoverage {
-		totalCovered += stat.Covered
 		totalStatements += stat.Total
 
-		percent := 0.0
-		if stat.Total > 0 {
-			percent = domain.Round1((float64(stat.Covered) / float64(stat.Total)) * 100)
-		}
+		percent := cfg.Policy.Rounding.Percent(stat.Percent())
 
 		// Find the min threshold for this domain
 		var min float64
@@ -999,7 +1051,7 @@ func (s *Service) RecordWithWarnings(ctx context.Context, opts RecordOptions, st
 		}
 
 		status := domain.StatusPass
-		if percent < min {
+		if !cfg.Policy.Rounding.Meets(stat.Percent(), min) {
 			status = domain.StatusFail
 		}
 
@@ -1011,24 +1063,37 @@ func (s *Service) RecordWithWarnings(ctx context.Context, opts RecordOptions, st
 		}
 	}
 
-	overallPercent := 0.0
-	if totalStatements > 0 {
-		overallPercent = domain.Round1((float64(totalCovered) / float64(totalStatements)) * 100)
-	}
+	overallPercent := domain.WeightedOverall(covCtx.DomainCoverage, domains)
 
+	meta := s.recordMetadata(ctx, opts)
 	entry := domain.HistoryEntry{
-		Timestamp: timeNow(),
-		Commit:    opts.Commit,
-		Branch:    opts.Branch,
-		Overall:   overallPercent,
-		Domains:   domainEntries,
+		Timestamp:  timeNow(),
+		Commit:     meta.Commit,
+		Branch:     meta.Branch,
+		Tag:        meta.Tag,
+		PR:         meta.PR,
+		RunID:      meta.RunID,
+		Overall:    overallPercent,
+		Statements: totalStatements,
+		Domains:    domainEntries,
+		Files:      trackedFiles(cfg, covCtx),
+	}
+	if opts.Signer != nil {
+		if err := signEntry(&entry, s.ProfileParser, profiles, opts.Signer); err != nil {
+			return RecordResult{}, err
+		}
 	}
 
+	previous := latestHistoryEntry(store)
 	if err := store.Append(entry); err != nil {
 		return RecordResult{}, err
 	}
 
-	return RecordResult{Warnings: recordInstrumentationWarnings(domains, covCtx.DomainCoverage)}, nil
+	warnings := recordInstrumentationWarnings(domains, covCtx.DomainCoverage)
+	warnings = append(warnings, recordAnomalyWarnings(previous, &entry)...)
+	warnings = append(warnings, s.notify(ctx, cfg, "record", entry, previous)...)
+	warnings = append(warnings, s.publishEvents(ctx, cfg.Events, "record", trendEvents(previous, &entry))...)
+	return RecordResult{Warnings: warnings}, nil
 }
 
 func (s *Service) Record(ctx context.Context, opts RecordOptions, store HistoryStore) error {
@@ -1063,6 +1128,11 @@ func (s *Service) Suggest(ctx context.Context, opts SuggestOptions) (SuggestResu
 	}
 
 	// Generate suggestions for each domain
+	hist, err := loadSuggestHistory(opts)
+	if err != nil {
+		return SuggestResult{}, err
+	}
+
 	suggestions := make([]Suggestion, 0, len(domains))
 	for i, d := range domains {
 		stat := covCtx.DomainCoverage[d.Name]
@@ -1076,7 +1146,7 @@ func (s *Service) Suggest(ctx context.Context, opts SuggestOptions) (SuggestResu
 			currentMin = *d.Min
 		}
 
-		suggestedMin, reason := calculateSuggestion(currentPercent, currentMin, opts.Strategy)
+		suggestedMin, reason := suggestThreshold(opts.Strategy, hist, d.Name, currentPercent, currentMin)
 
 		suggestions = append(suggestions, Suggestion{
 			Domain:         d.Name,
@@ -1112,104 +1182,7 @@ func (s *Service) Debt(ctx context.Context, opts DebtOptions) (DebtResult, error
 		return DebtResult{}, err
 	}
 
-	var items []DebtItem
-	var totalDebt float64
-	var totalLines int
-	var passCount, failCount int
-
-	// Calculate domain debt
-	for _, d := range domains {
-		stat := covCtx.DomainCoverage[d.Name]
-		currentPercent := 0.0
-		if stat.Total > 0 {
-			currentPercent = domain.Round1((float64(stat.Covered) / float64(stat.Total)) * 100)
-		}
-
-		required := cfg.Policy.DefaultMin
-		if d.Min != nil {
-			required = *d.Min
-		}
-
-		if currentPercent < required {
-			shortfall := domain.Round1(required - currentPercent)
-			// Estimate lines needing tests: (shortfall% * total statements) / 100
-			// Use a minimum denominator of 1.0 to prevent division by near-zero
-			uncoveredLines := stat.Total - stat.Covered
-			denominator := math.Max(required-currentPercent, 1.0)
-			linesNeededFloat := float64(uncoveredLines) * (shortfall / denominator)
-			// Clamp to valid range [0, uncoveredLines] to prevent overflow
-			linesNeeded := int(math.Min(math.Max(linesNeededFloat, 0), float64(uncoveredLines)))
-
-			items = append(items, DebtItem{
-				Name:      d.Name,
-				Type:      "domain",
-				Current:   currentPercent,
-				Required:  required,
-				Shortfall: shortfall,
-				Lines:     linesNeeded,
-			})
-			totalDebt += shortfall
-			totalLines += linesNeeded
-			failCount++
-		} else {
-			passCount++
-		}
-	}
-
-	// Calculate file rule debt
-	for _, rule := range cfg.Files {
-		for file, stat := range covCtx.NormalizedCoverage {
-			if excluded(file, cfg.Exclude) {
-				continue
-			}
-			if ann, ok := covCtx.Annotations[file]; ok && ann.Ignore {
-				continue
-			}
-			if matchAnyPattern(file, rule.Match) {
-				currentPercent := 0.0
-				if stat.Total > 0 {
-					currentPercent = domain.Round1((float64(stat.Covered) / float64(stat.Total)) * 100)
-				}
-
-				if currentPercent < rule.Min {
-					shortfall := domain.Round1(rule.Min - currentPercent)
-					linesNeeded := stat.Total - stat.Covered
-
-					items = append(items, DebtItem{
-						Name:      file,
-						Type:      "file",
-						Current:   currentPercent,
-						Required:  rule.Min,
-						Shortfall: shortfall,
-						Lines:     linesNeeded,
-					})
-					totalDebt += shortfall
-					totalLines += linesNeeded
-					failCount++
-				} else {
-					passCount++
-				}
-			}
-		}
-	}
-
-	// Sort by shortfall (highest first)
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Shortfall > items[j].Shortfall
-	})
-
-	// Calculate health score (0-100, higher is better)
-	healthScore := 100.0
-	if passCount+failCount > 0 {
-		healthScore = domain.Round1((float64(passCount) / float64(passCount+failCount)) * 100)
-	}
-
-	return DebtResult{
-		Items:       items,
-		TotalDebt:   domain.Round1(totalDebt),
-		TotalLines:  totalLines,
-		HealthScore: healthScore,
-	}, nil
+	return computeDebt(cfg, domains, covCtx), nil
 }
 
 // Compare compares coverage between two profiles.
@@ -1306,7 +1279,7 @@ func (s *Service) Compare(ctx context.Context, opts CompareOptions) (CompareResu
 	if opts.ConfigPath != "" {
 		cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
 		if err == nil && len(domains) > 0 {
-			domainDirs, err := s.DomainResolver.Resolve(ctx, domains)
+			domainDirs, err := resolveDomainDirs(ctx, s.DomainResolver, domains, moduleRoot, cfg.Merge)
 			if err == nil {
 				domainExcludes := buildDomainExcludes(domains)
 				annotations := make(map[string]Annotation)
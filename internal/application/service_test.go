@@ -7,6 +7,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +18,22 @@ import (
 
 var errSentinel = errors.New("sentinel")
 
+// newTestService returns a Service over the fakes: cfg as the loaded
+// config, dirs as the resolved domain directories of a module at /repo, and
+// parser as the profile parser. Tests set any other port they exercise on
+// the result.
+func newTestService(cfg Config, dirs map[string][]string, parser ProfileParser) *Service {
+	return &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: dirs, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		CoverageRunner: fakeRunner{profile: ".cover/coverage.out"},
+		ProfileParser:  parser,
+		Reporter:       &fakeReporter{},
+		Out:            io.Discard,
+	}
+}
+
 type fakeConfigLoader struct {
 	exists    bool
 	cfg       Config
@@ -132,6 +150,83 @@ func TestServiceCheckPass(t *testing.T) {
 	if !reporter.last.Passed {
 		t.Fatalf("expected pass")
 	}
+	// One passing domain raises only the closing CoverageEvaluated event.
+	if h := reporter.last.Health; h == nil || *h != (domain.Health{Score: 100, PassingDomains: 1, Events: 1}) {
+		t.Fatalf("unexpected health %+v", h)
+	}
+}
+
+func TestServiceCheckNewDomainWarn(t *testing.T) {
+	min := 90.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, NewDomain: domain.NewDomainWarn, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}}
+	baseline := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{
+		{Domains: map[string]domain.DomainEntry{"api": {Name: "api", Percent: 70}}},
+	}}}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		CoverageRunner: fakeRunner{profile: ".cover/coverage.out"},
+		ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}},
+		Reporter:       &fakeReporter{},
+		Out:            io.Discard,
+	}
+
+	result, err := svc.CheckResult(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", Output: OutputText, BaselineStore: baseline})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if !result.Passed || result.Domains[0].Status != domain.StatusWarn {
+		t.Fatalf("expected new domain to warn instead of fail, got %+v", result)
+	}
+	if len(result.NewDomains) != 1 || result.NewDomains[0] != "core" {
+		t.Fatalf("expected core listed as new, got %v", result.NewDomains)
+	}
+}
+
+func TestServiceCheckExceptions(t *testing.T) {
+	restore := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = restore }()
+
+	min := 90.0
+	newSvc := func(exceptions ...domain.PolicyException) *Service {
+		cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}, Min: &min}}}, Exceptions: exceptions}
+		return &Service{
+			ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+			Autodetector:   fakeAutodetector{},
+			DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+			CoverageRunner: fakeRunner{profile: ".cover/coverage.out"},
+			ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}},
+			Reporter:       &fakeReporter{},
+			Out:            io.Discard,
+		}
+	}
+	opts := CheckOptions{ConfigPath: ".coverctl.yaml", Output: OutputText}
+
+	result, err := newSvc(domain.PolicyException{Domain: "core", Reason: "rewrite", Approver: "alice", Expires: "2026-04-01"}).CheckResult(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if !result.Passed || result.Domains[0].Status != domain.StatusWarn || !result.Exceptions[0].Applied {
+		t.Fatalf("expected active exception to exempt core, got %+v", result)
+	}
+
+	result, err = newSvc(domain.PolicyException{Domain: "core", Reason: "rewrite", Approver: "alice", Expires: "2026-03-31"}).CheckResult(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if result.Passed || !result.Exceptions[0].Expired {
+		t.Fatalf("expected expired exception to fail the run, got %+v", result)
+	}
+
+	result, err = newSvc(domain.PolicyException{Domain: "cor", Reason: "typo", Approver: "alice", Expires: "2026-04-01"}).CheckResult(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if result.Passed || !slices.Contains(result.Warnings, "exception for domain cor: no such domain is configured") {
+		t.Fatalf("expected an unknown exception domain to warn, got %+v", result)
+	}
 }
 
 func TestServiceCheckFail(t *testing.T) {
@@ -871,6 +966,10 @@ func TestApplyDeltas(t *testing.T) {
 	} else if *result.Domains[0].Delta != 5.0 {
 		t.Errorf("expected delta of 5.0, got %v", *result.Domains[0].Delta)
 	}
+	want := []domain.DomainDelta{{Domain: "core", Previous: 75, Current: 80, Delta: 5, Trend: domain.TrendUp}}
+	if !reflect.DeepEqual(result.Deltas, want) {
+		t.Errorf("expected deltas %+v, got %+v", want, result.Deltas)
+	}
 }
 
 // fakeRegistry implements RunnerRegistry for testing.
@@ -887,15 +986,72 @@ func (r fakeRegistry) DetectRunner(projectDir string) (CoverageRunner, error) {
 	return r.runner, r.err
 }
 
+func (r fakeRegistry) GetRunnerByName(name string) (CoverageRunner, error) {
+	return r.runner, r.err
+}
+
 func (r fakeRegistry) SupportedLanguages() []Language {
 	return []Language{LanguageGo}
 }
 
+// recordingRegistry records how a runner was looked up.
+type recordingRegistry struct {
+	fakeRegistry
+	byName     string
+	byLanguage Language
+}
+
+func (r *recordingRegistry) GetRunner(lang Language) (CoverageRunner, error) {
+	r.byLanguage = lang
+	return r.runner, r.err
+}
+
+func (r *recordingRegistry) GetRunnerByName(name string) (CoverageRunner, error) {
+	r.byName = name
+	return r.runner, r.err
+}
+
 func TestSelectRunner(t *testing.T) {
 	goRunner := &fakeRunner{}
 
+	t.Run("runner flag bypasses language and detection", func(t *testing.T) {
+		registry := &recordingRegistry{fakeRegistry: fakeRegistry{runner: goRunner}}
+		if _, err := selectRunner(registry, nil, "node", "python", LanguageGo, LanguageGo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if registry.byName != "node" || registry.byLanguage != "" {
+			t.Errorf("expected runner lookup by name, got name=%q language=%q", registry.byName, registry.byLanguage)
+		}
+	})
+
+	t.Run("language flag beats config runner", func(t *testing.T) {
+		registry := &recordingRegistry{fakeRegistry: fakeRegistry{runner: goRunner}}
+		if _, err := selectRunner(registry, nil, "", "python", LanguageGo, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if registry.byName != "" || registry.byLanguage != LanguageGo {
+			t.Errorf("expected language lookup, got name=%q language=%q", registry.byName, registry.byLanguage)
+		}
+	})
+
+	t.Run("config runner beats config language", func(t *testing.T) {
+		registry := &recordingRegistry{fakeRegistry: fakeRegistry{runner: goRunner}}
+		if _, err := selectRunner(registry, nil, "", "python", "", LanguageGo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if registry.byName != "python" {
+			t.Errorf("expected config runner lookup, got name=%q", registry.byName)
+		}
+	})
+
+	t.Run("runner without registry fails", func(t *testing.T) {
+		if _, err := selectRunner(nil, goRunner, "go", "", "", ""); err == nil {
+			t.Error("expected error when a runner is named without a registry")
+		}
+	})
+
 	t.Run("returns default runner when no registry", func(t *testing.T) {
-		runner, err := selectRunner(nil, goRunner, "", "")
+		runner, err := selectRunner(nil, goRunner, "", "", "", "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -905,7 +1061,7 @@ func TestSelectRunner(t *testing.T) {
 	})
 
 	t.Run("returns error when no runner available", func(t *testing.T) {
-		_, err := selectRunner(nil, nil, "", "")
+		_, err := selectRunner(nil, nil, "", "", "", "")
 		if err == nil {
 			t.Error("expected error when no runner configured")
 		}
@@ -913,7 +1069,7 @@ func TestSelectRunner(t *testing.T) {
 
 	t.Run("uses registry to get runner for specified language", func(t *testing.T) {
 		registry := fakeRegistry{runner: goRunner}
-		runner, err := selectRunner(registry, nil, LanguageGo, "")
+		runner, err := selectRunner(registry, nil, "", "", LanguageGo, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -924,7 +1080,7 @@ func TestSelectRunner(t *testing.T) {
 
 	t.Run("uses config language when lang is auto", func(t *testing.T) {
 		registry := fakeRegistry{runner: goRunner}
-		runner, err := selectRunner(registry, nil, LanguageAuto, LanguageGo)
+		runner, err := selectRunner(registry, nil, "", "", LanguageAuto, LanguageGo)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -935,7 +1091,7 @@ func TestSelectRunner(t *testing.T) {
 
 	t.Run("detects runner when no language specified", func(t *testing.T) {
 		registry := fakeRegistry{runner: goRunner}
-		runner, err := selectRunner(registry, nil, "", LanguageAuto)
+		runner, err := selectRunner(registry, nil, "", "", "", LanguageAuto)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1260,7 +1416,7 @@ func TestServiceSelectRunnerMethod(t *testing.T) {
 	svc := &Service{
 		CoverageRunner: goRunner,
 	}
-	runner, err := svc.selectRunnerMethod("", "")
+	runner, err := svc.selectRunnerMethod("", "", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1301,6 +1457,25 @@ func TestModuleRelativePathError(t *testing.T) {
 	}
 }
 
+func TestExcludedDoublestar(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"**/*_test.go", "a_test.go", true},
+		{"**/*_test.go", "internal/core/a_test.go", true},
+		{"internal/**/testdata/**", "internal/core/testdata/x/y.go", true},
+		{"internal/**/testdata/**", "pkg/core/testdata/y.go", false},
+		{"**/generated/**", "internal/generated.go", false},
+		{"*_test.go", "internal/core/a_test.go", false},
+	}
+	for _, tt := range tests {
+		if got := excluded(tt.file, []string{tt.pattern}); got != tt.want {
+			t.Errorf("excluded(%q, %q) = %v, want %v", tt.file, tt.pattern, got, tt.want)
+		}
+	}
+}
+
 func TestExcludedMultiplePatterns(t *testing.T) {
 	result := excluded("generated.go", []string{"*_test.go", "generated.go", "mock_*.go"})
 	if !result {
@@ -1313,7 +1488,7 @@ func TestSelectRunnerWithRegistry(t *testing.T) {
 	registry := fakeRegistry{runner: goRunner}
 
 	// Test fallback to detect when lang is empty
-	runner, err := selectRunner(registry, nil, "", "")
+	runner, err := selectRunner(registry, nil, "", "", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1334,7 +1509,7 @@ func TestEvaluateFileRulesWithAnnotations(t *testing.T) {
 		"ignored.go": {Ignore: true},
 	}
 
-	results, passed := evaluateFileRules(files, rules, nil, annotations)
+	results, passed := evaluateFileRules(files, rules, nil, annotations, domain.RoundingRound)
 	if !passed {
 		t.Error("expected to pass when ignored file is excluded")
 	}
@@ -1353,7 +1528,7 @@ func TestEvaluateFileRulesWithExcludes(t *testing.T) {
 	}
 	excludes := []string{"*_test.go"}
 
-	results, passed := evaluateFileRules(files, rules, excludes, nil)
+	results, passed := evaluateFileRules(files, rules, excludes, nil, domain.RoundingRound)
 	if !passed {
 		t.Error("expected to pass when test file is excluded")
 	}
@@ -1370,7 +1545,7 @@ func TestEvaluateFileRulesFailing(t *testing.T) {
 		{Match: []string{"*.go"}, Min: 80}, // Requires 80%
 	}
 
-	results, passed := evaluateFileRules(files, rules, nil, nil)
+	results, passed := evaluateFileRules(files, rules, nil, nil, domain.RoundingRound)
 	if passed {
 		t.Error("expected to fail when coverage below minimum")
 	}
@@ -1386,7 +1561,7 @@ func TestEvaluateFileRulesNoRules(t *testing.T) {
 	files := map[string]domain.CoverageStat{
 		"service.go": {Covered: 5, Total: 10},
 	}
-	results, passed := evaluateFileRules(files, nil, nil, nil)
+	results, passed := evaluateFileRules(files, nil, nil, nil, domain.RoundingRound)
 	if !passed {
 		t.Error("expected to pass with no rules")
 	}
@@ -1492,7 +1667,7 @@ func TestEvaluateFileRulesHigherMin(t *testing.T) {
 		{Match: []string{"service.go"}, Min: 80},
 	}
 
-	results, passed := evaluateFileRules(files, rules, nil, nil)
+	results, passed := evaluateFileRules(files, rules, nil, nil, domain.RoundingRound)
 	if !passed {
 		t.Error("expected to pass when coverage meets higher min")
 	}
@@ -1508,7 +1683,7 @@ func TestEvaluateFileRulesHigherMin(t *testing.T) {
 func TestSelectRunnerDefaultRunner(t *testing.T) {
 	defaultRunner := &fakeRunner{}
 
-	runner, err := selectRunner(nil, defaultRunner, "", "")
+	runner, err := selectRunner(nil, defaultRunner, "", "", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1518,7 +1693,7 @@ func TestSelectRunnerDefaultRunner(t *testing.T) {
 }
 
 func TestSelectRunnerNoRunner(t *testing.T) {
-	_, err := selectRunner(nil, nil, "", "")
+	_, err := selectRunner(nil, nil, "", "", "", "")
 	if err == nil {
 		t.Error("expected error when no runner available")
 	}
@@ -1803,7 +1978,7 @@ func TestSelectRunnerWithLanguage(t *testing.T) {
 	goRunner := &fakeRunner{}
 	registry := fakeRegistry{runner: goRunner}
 
-	runner, err := selectRunner(registry, nil, LanguageGo, "")
+	runner, err := selectRunner(registry, nil, "", "", LanguageGo, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1817,7 +1992,7 @@ func TestSelectRunnerUsesConfigLanguage(t *testing.T) {
 	registry := fakeRegistry{runner: goRunner}
 
 	// Empty lang but cfgLang is set
-	runner, err := selectRunner(registry, nil, "", LanguageGo)
+	runner, err := selectRunner(registry, nil, "", "", "", LanguageGo)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1867,7 +2042,7 @@ func TestSelectRunnerAutoDetect(t *testing.T) {
 	registry := fakeRegistry{runner: goRunner}
 
 	// LanguageAuto should trigger detection
-	runner, err := selectRunner(registry, nil, LanguageAuto, "")
+	runner, err := selectRunner(registry, nil, "", "", LanguageAuto, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1907,7 +2082,7 @@ func TestServiceSelectRunnerMethodSuccess(t *testing.T) {
 		CoverageRunner: goRunner,
 	}
 
-	runner, err := svc.selectRunnerMethod("", "")
+	runner, err := svc.selectRunnerMethod("", "", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1922,7 +2097,7 @@ func TestServiceSelectRunnerMethodWithRegistry(t *testing.T) {
 		RunnerRegistry: fakeRegistry{runner: goRunner},
 	}
 
-	runner, err := svc.selectRunnerMethod(LanguageGo, "")
+	runner, err := svc.selectRunnerMethod("", "", LanguageGo, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -2174,7 +2349,7 @@ func TestEvaluateFileRulesMultipleMatches(t *testing.T) {
 		{Match: []string{"service*.go"}, Min: 70},
 	}
 
-	results, passed := evaluateFileRules(files, rules, nil, nil)
+	results, passed := evaluateFileRules(files, rules, nil, nil, domain.RoundingRound)
 	if passed {
 		t.Error("expected to fail when service_a.go is below threshold")
 	}
@@ -3072,6 +3247,45 @@ func TestServiceCheckWithFailUnderFailing(t *testing.T) {
 	}
 }
 
+func TestServiceCheckFailUnderRounding(t *testing.T) {
+	failUnder := 80.0
+	check := func(rounding domain.Rounding) error {
+		cfg := Config{Version: 1, Policy: domain.Policy{Rounding: rounding, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}
+		svc := newTestService(cfg, map[string][]string{"core": {"/repo/internal/core"}}, fakeParser{stats: map[string]domain.CoverageStat{
+			"internal/core/a.go": {Covered: 7996, Total: 10000}, // 79.96%
+		}})
+		return svc.Check(context.Background(), CheckOptions{FailUnder: &failUnder})
+	}
+	if err := check(domain.RoundingRound); err != nil {
+		t.Fatalf("expected 79.96%% to meet 80%% under round, got %v", err)
+	}
+	if err := check(domain.RoundingFloor); err == nil || !strings.Contains(err.Error(), "79.9%") {
+		t.Fatalf("expected 79.9%% to miss 80%% under floor, got %v", err)
+	}
+}
+
+func TestServiceCheckFailUnderBeforeSummary(t *testing.T) {
+	failUnder := 90.0
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}
+	svc := newTestService(cfg, map[string][]string{"core": {"/repo/internal/core"}}, fakeParser{stats: map[string]domain.CoverageStat{
+		"internal/core/a.go": {Covered: 80, Total: 100},
+	}})
+	reporter := &fakeReporter{}
+	svc.Reporter = reporter
+	var summary bytes.Buffer
+
+	err := svc.Check(context.Background(), CheckOptions{FailUnder: &failUnder, Summary: &summary})
+	if err == nil || !strings.Contains(err.Error(), "below --fail-under") {
+		t.Fatalf("expected fail-under error, got %v", err)
+	}
+	if reporter.last.Passed {
+		t.Fatal("expected the summary to render the fail-under failure")
+	}
+	if !slices.ContainsFunc(reporter.last.Warnings, func(w string) bool { return strings.Contains(w, "below --fail-under") }) {
+		t.Fatalf("expected fail-under warning in the rendered result, got %v", reporter.last.Warnings)
+	}
+}
+
 func TestServiceCheckReporterError(t *testing.T) {
 	out := &bytes.Buffer{}
 	svc := &Service{
@@ -3100,3 +3314,79 @@ func TestServiceCheckReporterError(t *testing.T) {
 		t.Errorf("expected sentinel error, got: %v", err)
 	}
 }
+
+func TestSuggestThresholdHistory(t *testing.T) {
+	series := func(values ...float64) *domain.History {
+		h := &domain.History{}
+		for i, v := range values {
+			h.Entries = append(h.Entries, domain.HistoryEntry{
+				Timestamp: time.Date(2025, 1, 1+i, 0, 0, 0, 0, time.UTC),
+				Domains:   map[string]domain.DomainEntry{"core": {Name: "core", Percent: v}},
+			})
+		}
+		return h
+	}
+
+	tests := []struct {
+		name       string
+		hist       *domain.History
+		want       float64
+		wantReason string
+	}{
+		{"stable history uses p10 minus base buffer", series(80, 80, 80, 80, 80, 80), 79, "p10 of last 6 entries"},
+		{"volatile history widens buffer", series(80, 82, 80, 82, 80, 82), 77, "p10"},
+		{"inconsistent history uses lowest", series(90, 70, 90, 90, 90), 68, "lowest"},
+		{"short history falls back to current", series(80, 80), 83, "only 2 history entries"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := suggestThreshold(SuggestHistory, tt.hist, "core", 85, 70)
+			if got != tt.want {
+				t.Fatalf("expected %.1f, got %.1f (%s)", tt.want, got, reason)
+			}
+			if !strings.Contains(reason, tt.wantReason) {
+				t.Fatalf("expected reason to contain %q, got %q", tt.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestSuggestHistoryRequiresStore(t *testing.T) {
+	if _, err := loadSuggestHistory(SuggestOptions{Strategy: SuggestHistory}); err == nil {
+		t.Fatal("expected error when history store is missing")
+	}
+	if hist, err := loadSuggestHistory(SuggestOptions{Strategy: SuggestCurrent}); err != nil || hist != nil {
+		t.Fatalf("expected no history for current strategy, got %v, %v", hist, err)
+	}
+}
+
+type formatRecordingReporter struct {
+	formats []OutputFormat
+}
+
+func (f *formatRecordingReporter) Write(w io.Writer, result domain.Result, format OutputFormat) error {
+	f.formats = append(f.formats, format)
+	return nil
+}
+
+func TestServiceCheckWritesSummary(t *testing.T) {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}
+	reporter := &formatRecordingReporter{}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		CoverageRunner: fakeRunner{profile: ".cover/coverage.out"},
+		ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}},
+		Reporter:       reporter,
+		Out:            io.Discard,
+	}
+
+	var summary bytes.Buffer
+	if err := svc.Check(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml", Output: OutputJSON, Summary: &summary}); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(reporter.formats) != 2 || reporter.formats[0] != OutputJSON || reporter.formats[1] != OutputMarkdown {
+		t.Fatalf("expected json then markdown writes, got %v", reporter.formats)
+	}
+}
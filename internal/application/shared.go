@@ -1,6 +1,7 @@
 package application
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sort"
@@ -30,14 +31,26 @@ func loadOrDetectConfig(loader ConfigLoader, detector Autodetector, configPath s
 	}
 
 	if len(cfg.Policy.Domains) == 0 {
-		return Config{}, nil, fmt.Errorf("no domains configured")
+		return Config{}, nil, WithErrorCode(ErrCodeNoDomains, errors.New("no domains configured"))
 	}
 
 	return cfg, cfg.Policy.Domains, nil
 }
 
-// selectRunner returns the appropriate coverage runner based on language preference.
-func selectRunner(registry RunnerRegistry, defaultRunner CoverageRunner, lang, cfgLang Language) (CoverageRunner, error) {
+// selectRunner returns the appropriate coverage runner. A runner named on
+// the command line wins, then --language, then the config's runner and
+// language, then auto-detection.
+func selectRunner(registry RunnerRegistry, defaultRunner CoverageRunner, runnerName, cfgRunner string, lang, cfgLang Language) (CoverageRunner, error) {
+	if runnerName == "" && (lang == "" || lang == LanguageAuto) {
+		runnerName = cfgRunner
+	}
+	if runnerName != "" {
+		if registry == nil {
+			return nil, fmt.Errorf("runner %q requested but no runner registry is configured", runnerName)
+		}
+		return registry.GetRunnerByName(runnerName)
+	}
+
 	effectiveLang := lang
 	if effectiveLang == "" || effectiveLang == LanguageAuto {
 		effectiveLang = cfgLang
@@ -68,6 +81,39 @@ func applyDeltas(result *domain.Result, history domain.History) {
 	result.ApplyDeltas(history)
 }
 
+// applyNewDomainPolicy relaxes thresholds of domains the baseline history
+// has never recorded. A missing or unreadable history leaves result as is.
+func applyNewDomainPolicy(result *domain.Result, policy domain.NewDomainPolicy, store HistoryStore) {
+	if store == nil || policy == "" || policy == domain.NewDomainDefault {
+		return
+	}
+	history, err := store.Load()
+	if err != nil {
+		return
+	}
+	result.ApplyNewDomainPolicy(policy, history)
+}
+
+// applyExceptions downgrades failures covered by an active policy
+// exception to warnings and fails the run for expired ones. An exception
+// naming a domain the config does not define can never apply, so it is
+// reported as a warning.
+func applyExceptions(result *domain.Result, exceptions []domain.PolicyException, domains []domain.Domain) {
+	if len(exceptions) == 0 {
+		return
+	}
+	configured := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		configured[d.Name] = true
+	}
+	for _, e := range exceptions {
+		if e.Domain != "" && !configured[e.Domain] {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("exception for domain %s: no such domain is configured", e.Domain))
+		}
+	}
+	result.ApplyExceptions(exceptions, timeNow())
+}
+
 func missingCoverageDomains(domains []domain.Domain, coverage map[string]domain.CoverageStat) []string {
 	if len(domains) == 0 {
 		return nil
@@ -95,3 +141,13 @@ func recordInstrumentationWarnings(domains []domain.Domain, coverage map[string]
 		strings.Join(missing, ", "),
 	)}
 }
+
+// recordAnomalyWarnings warns about jumps since the previous entry that
+// usually mean a broken profile rather than a real regression.
+func recordAnomalyWarnings(previous, current *domain.HistoryEntry) []string {
+	var warnings []string
+	for _, a := range domain.NewTrendAnalysisService().DetectAnomalies(previous, current) {
+		warnings = append(warnings, "record: "+a.String())
+	}
+	return warnings
+}
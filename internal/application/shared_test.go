@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -41,3 +42,61 @@ func TestRecordInstrumentationWarnings(t *testing.T) {
 		t.Fatalf("expected warning to mention missing domain, got %q", warnings[0])
 	}
 }
+
+func TestRecordWarnsOnAnomalies(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{{
+		Overall:    95,
+		Statements: 100,
+		Domains:    map[string]domain.DomainEntry{"core": {Name: "core", Percent: 95}},
+	}}}}
+
+	result, err := notifyTestService(cfg, nil).RecordWithWarnings(context.Background(), RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: ".cover/coverage.out"}, store)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if got := store.history.Entries[1].Statements; got != 10 {
+		t.Fatalf("expected 10 statements recorded, got %d", got)
+	}
+	if len(result.Warnings) != 2 ||
+		!strings.Contains(result.Warnings[0], "total statements fell from 100 to 10") ||
+		!strings.Contains(result.Warnings[1], "domain core dropped from 95.0% to 70.0%") {
+		t.Fatalf("unexpected warnings %q", result.Warnings)
+	}
+}
+
+type fakeFileListDiffProvider struct {
+	fakeDiffProvider
+	lists map[string][]string
+}
+
+func (f fakeFileListDiffProvider) FromFileList(path string) DiffProvider {
+	return fakeDiffProvider{files: f.lists[path]}
+}
+
+func TestDiffFilesFromList(t *testing.T) {
+	provider := fakeFileListDiffProvider{
+		fakeDiffProvider: fakeDiffProvider{files: []string{"from/git.go"}},
+		lists:            map[string][]string{"changed.txt": {"from/list.go"}},
+	}
+
+	allow, err := diffFiles(context.Background(), provider, DiffConfig{Enabled: true, FilesFrom: "changed.txt"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := allow["from/list.go"]; !ok || len(allow) != 1 {
+		t.Fatalf("expected files from list, got %v", allow)
+	}
+
+	cfg := overrideDiffBase(DiffConfig{Enabled: true, FilesFrom: "changed.txt"}, "main")
+	allow, err = diffFiles(context.Background(), provider, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := allow["from/git.go"]; !ok {
+		t.Fatalf("expected --diff-base to fall back to git, got %v", allow)
+	}
+}
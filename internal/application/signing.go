@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// signEntry records the digests of the profiles entry was computed from
+// and signs it.
+func signEntry(entry *domain.HistoryEntry, parser ProfileParser, profiles []string, signer EntrySigner) error {
+	if expander, ok := parser.(ProfileExpander); ok {
+		profiles = expander.ExpandProfiles(profiles)
+	}
+	entry.Profiles = make([]domain.ProfileDigest, 0, len(profiles))
+	for _, path := range profiles {
+		sum, err := profileSHA256(path)
+		if err != nil {
+			return fmt.Errorf("sign history entry: %w", err)
+		}
+		entry.Profiles = append(entry.Profiles, domain.ProfileDigest{Path: filepath.ToSlash(path), SHA256: sum})
+	}
+	payload, err := entry.SigningPayload()
+	if err != nil {
+		return fmt.Errorf("sign history entry: %w", err)
+	}
+	sig, err := signer.Sign(payload)
+	if err != nil {
+		return fmt.Errorf("sign history entry: %w", err)
+	}
+	entry.Signature = &sig
+	return nil
+}
+
+// profileSHA256 hashes a profile file. A directory (Go covdata) hashes the
+// names and contents of the files directly inside it, in name order.
+func profileSHA256(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if !info.IsDir() {
+		if err := hashFile(h, path); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00", name)
+		if err := hashFile(h, filepath.Join(path, name)); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path) // #nosec G304 - profile path comes from the user's own flags or config
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// Verify checks the signature of every history entry, then compares the
+// given profiles with the digests recorded by the newest valid signed
+// entry. It passes when no signature is invalid, every profile matches,
+// and, with opts.RequireSigned, every entry is signed.
+func (s *Service) Verify(ctx context.Context, opts VerifyOptions, store HistoryStore) (VerifyResult, error) {
+	history, err := store.Load()
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	result := VerifyResult{Entries: make([]EntryVerification, 0, len(history.Entries))}
+	var newest *domain.HistoryEntry
+	for i := range history.Entries {
+		entry := &history.Entries[i]
+		check := EntryVerification{Index: i, Timestamp: entry.Timestamp, Commit: entry.Commit, Status: EntryUnsigned}
+		if entry.Signature != nil {
+			check.Status = EntryValid
+			if err := verifyEntry(*entry, opts.Verifier); err != nil {
+				check.Status, check.Reason = EntryInvalid, err.Error()
+			} else {
+				newest = entry
+			}
+		}
+		switch check.Status {
+		case EntryValid:
+			result.Valid++
+		case EntryInvalid:
+			result.Invalid++
+		default:
+			result.Unsigned++
+		}
+		result.Entries = append(result.Entries, check)
+	}
+
+	profilesMatch := true
+	if len(opts.ProfilePaths) > 0 {
+		if newest == nil {
+			return result, fmt.Errorf("no valid signed history entry to compare profiles with")
+		}
+		recorded := make(map[string]bool, len(newest.Profiles))
+		for _, p := range newest.Profiles {
+			recorded[p.SHA256] = true
+		}
+		for _, path := range opts.ProfilePaths {
+			sum, err := profileSHA256(path)
+			if err != nil {
+				return result, err
+			}
+			check := ProfileVerification{Path: path, SHA256: sum, Matches: recorded[sum]}
+			profilesMatch = profilesMatch && check.Matches
+			result.Profiles = append(result.Profiles, check)
+		}
+	}
+	result.Passed = result.Invalid == 0 && profilesMatch && (!opts.RequireSigned || result.Unsigned == 0)
+	return result, nil
+}
+
+func verifyEntry(entry domain.HistoryEntry, verifier EntryVerifier) error {
+	payload, err := entry.SigningPayload()
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(payload, *entry.Signature)
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// hashSigner "signs" with a plain digest of the payload, which hashVerifier
+// recomputes.
+type hashSigner struct{}
+
+func (hashSigner) Sign(payload []byte) (domain.Signature, error) {
+	sum := sha256.Sum256(payload)
+	return domain.Signature{Algorithm: "test", KeyID: "k1", Value: hex.EncodeToString(sum[:])}, nil
+}
+
+type hashVerifier struct{}
+
+func (hashVerifier) Verify(payload []byte, sig domain.Signature) error {
+	if want, _ := (hashSigner{}).Sign(payload); sig != want {
+		return errors.New("signature does not match the entry")
+	}
+	return nil
+}
+
+func TestRecordSignsAndVerify(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy:  domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}},
+	}
+	profile := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(profile, []byte("mode: set\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store := &memoryHistoryStore{history: domain.History{Entries: []domain.HistoryEntry{{Overall: 70}}}}
+	svc := notifyTestService(cfg, nil)
+	opts := RecordOptions{ConfigPath: ".coverctl.yaml", ProfilePath: profile, NoDetect: true, Signer: hashSigner{}}
+	if _, err := svc.RecordWithWarnings(context.Background(), opts, store); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	signed := store.history.Entries[1]
+	if signed.Signature == nil || len(signed.Profiles) != 1 || signed.Profiles[0].SHA256 == "" {
+		t.Fatalf("expected a signed entry with a profile digest, got %+v", signed)
+	}
+
+	result, err := svc.Verify(context.Background(), VerifyOptions{ProfilePaths: []string{profile}, Verifier: hashVerifier{}}, store)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !result.Passed || result.Valid != 1 || result.Unsigned != 1 || !result.Profiles[0].Matches {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result, _ := svc.Verify(context.Background(), VerifyOptions{RequireSigned: true, Verifier: hashVerifier{}}, store); result.Passed {
+		t.Fatal("expected --require-signed to fail on the unsigned entry")
+	}
+
+	if err := os.WriteFile(profile, []byte("mode: count\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := svc.Verify(context.Background(), VerifyOptions{ProfilePaths: []string{profile}, Verifier: hashVerifier{}}, store); result.Passed || result.Profiles[0].Matches {
+		t.Fatalf("expected a changed profile to fail, got %+v", result)
+	}
+
+	store.history.Entries[1].Overall = 99
+	result, _ = svc.Verify(context.Background(), VerifyOptions{Verifier: hashVerifier{}}, store)
+	if result.Passed || result.Invalid != 1 || result.Entries[1].Status != EntryInvalid {
+		t.Fatalf("expected a tampered entry to be invalid, got %+v", result)
+	}
+	if _, err := svc.Verify(context.Background(), VerifyOptions{ProfilePaths: []string{profile}, Verifier: hashVerifier{}}, store); err == nil {
+		t.Fatal("expected an error comparing profiles without a valid signed entry")
+	}
+}
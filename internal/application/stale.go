@@ -0,0 +1,63 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// maxStaleListed caps the stale files named in a warning.
+const maxStaleListed = 5
+
+// staleEntries returns, sorted, the module-relative coverage keys whose
+// files no longer exist under moduleRoot, e.g. files deleted or renamed
+// since a cached profile was written. Nothing is reported when moduleRoot
+// itself is missing, since every entry would then look stale.
+func staleEntries(coverage map[string]domain.CoverageStat, moduleRoot string) []string {
+	if info, err := os.Stat(moduleRoot); err != nil || !info.IsDir() {
+		return nil
+	}
+	var stale []string
+	for file := range coverage {
+		if filepath.IsAbs(file) || strings.HasPrefix(file, "../") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(moduleRoot, filepath.FromSlash(file))); os.IsNotExist(err) {
+			stale = append(stale, file)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// sanitizeStaleEntries reports stale profile entries as a warning and,
+// when prune is set, drops them so they no longer count toward totals.
+func sanitizeStaleEntries(coverage map[string]domain.CoverageStat, moduleRoot string, prune bool) (map[string]domain.CoverageStat, []string) {
+	stale := staleEntries(coverage, moduleRoot)
+	if len(stale) == 0 {
+		return coverage, nil
+	}
+	listed := stale
+	if len(listed) > maxStaleListed {
+		listed = listed[:maxStaleListed]
+	}
+	names := strings.Join(listed, ", ")
+	if more := len(stale) - len(listed); more > 0 {
+		names += fmt.Sprintf(" and %d more", more)
+	}
+	if !prune {
+		return coverage, []string{fmt.Sprintf("%d profile entries reference files missing under the module root (%s); re-run tests or use --prune-stale to drop them", len(stale), names)}
+	}
+	pruned := make(map[string]domain.CoverageStat, len(coverage)-len(stale))
+	for file, stat := range coverage {
+		pruned[file] = stat
+	}
+	for _, file := range stale {
+		delete(pruned, file)
+	}
+	return pruned, []string{fmt.Sprintf("dropped %d stale profile entries for files missing under the module root (%s)", len(stale), names)}
+}
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestSanitizeStaleEntries(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "internal", "core"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "internal", "core", "a.go"), []byte("package core\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	coverage := map[string]domain.CoverageStat{
+		"internal/core/a.go":    {Covered: 1, Total: 2},
+		"internal/core/gone.go": {Covered: 0, Total: 8},
+		"internal/old/b.go":     {Covered: 1, Total: 4},
+	}
+
+	t.Run("warns without pruning", func(t *testing.T) {
+		got, warnings := sanitizeStaleEntries(coverage, root, false)
+		if len(got) != 3 {
+			t.Fatalf("expected entries kept, got %v", got)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "2 profile entries reference files missing under the module root (internal/core/gone.go, internal/old/b.go)") {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+	})
+
+	t.Run("prunes", func(t *testing.T) {
+		got, warnings := sanitizeStaleEntries(coverage, root, true)
+		if !reflect.DeepEqual(got, map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 1, Total: 2}}) {
+			t.Fatalf("unexpected pruned coverage: %v", got)
+		}
+		if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "dropped 2 stale profile entries") {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+		if len(coverage) != 3 {
+			t.Fatal("input map must not be modified")
+		}
+	})
+
+	t.Run("skips missing module root", func(t *testing.T) {
+		got, warnings := sanitizeStaleEntries(coverage, filepath.Join(root, "missing"), true)
+		if len(got) != 3 || warnings != nil {
+			t.Fatalf("expected no check, got %v %v", got, warnings)
+		}
+	})
+
+	t.Run("caps listed files", func(t *testing.T) {
+		many := make(map[string]domain.CoverageStat)
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+			many["gone/"+name+".go"] = domain.CoverageStat{Total: 1}
+		}
+		_, warnings := sanitizeStaleEntries(many, root, false)
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "gone/e.go and 2 more)") {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+	})
+}
+
+func TestReportPruneStale(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "internal", "core"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "internal", "core", "a.go"), []byte("package core\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+		}},
+	}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {filepath.Join(root, "internal", "core")}}, moduleRoot: root, modulePath: "example.com/mod"},
+		ProfileParser: fakeParser{stats: map[string]domain.CoverageStat{
+			"internal/core/a.go":    {Covered: 8, Total: 10},
+			"internal/core/gone.go": {Covered: 0, Total: 30},
+		}},
+		Out: io.Discard,
+	}
+
+	result, err := svc.ReportResult(context.Background(), ReportOptions{Profile: "c.out"})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if result.Passed || result.Domains[0].Percent != 20 {
+		t.Fatalf("expected stale entry to count without pruning, got %+v", result.Domains)
+	}
+
+	result, err = svc.ReportResult(context.Background(), ReportOptions{Profile: "c.out", PruneStale: true})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if !result.Passed || result.Domains[0].Percent != 80 {
+		t.Fatalf("expected stale entry dropped, got %+v", result.Domains)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "internal/core/gone.go") {
+		t.Fatalf("expected prune warning, got %v", result.Warnings)
+	}
+}
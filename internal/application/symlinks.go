@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// symlinkResolver maps paths to where they really live, so domains declared
+// through a symlinked directory (bazel-style output trees, the pnpm store)
+// match files a profile records under the link target, and the reverse.
+// A real path inside the module is re-expressed under moduleRoot, which keeps
+// module-relative keys stable when the module root itself is reached through
+// a symlink (e.g. /tmp on macOS). Directory lookups are cached.
+type symlinkResolver struct {
+	root     string
+	realRoot string
+	dirs     map[string]string
+}
+
+func newSymlinkResolver(moduleRoot string) *symlinkResolver {
+	r := &symlinkResolver{root: moduleRoot, realRoot: moduleRoot, dirs: make(map[string]string)}
+	if moduleRoot != "" {
+		if real, err := filepath.EvalSymlinks(moduleRoot); err == nil {
+			r.realRoot = real
+		}
+	}
+	return r
+}
+
+// dir returns the resolved form of directory d, or d itself when it does not
+// exist locally (e.g. a path recorded on another machine).
+func (r *symlinkResolver) dir(d string) string {
+	d = filepath.Clean(d)
+	if real, ok := r.dirs[d]; ok {
+		return real
+	}
+	real := d
+	if resolved, err := filepath.EvalSymlinks(d); err == nil {
+		real = resolved
+		if r.root != "" {
+			rel, err := filepath.Rel(r.realRoot, resolved)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				real = filepath.Join(r.root, rel)
+			}
+		}
+	}
+	r.dirs[d] = real
+	return real
+}
+
+// file resolves the directory holding an absolute file path. The file itself
+// is not followed; a symlinked source file is rare and costs a lookup each.
+func (r *symlinkResolver) file(p string) string {
+	if !filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(r.dir(filepath.Dir(p)), filepath.Base(p))
+}
+
+// resolveDomainDirs resolves the domains' directories and, unless
+// merge.resolve_symlinks is false, adds the real location of every directory
+// reached through a symlink next to the declared one.
+func resolveDomainDirs(ctx context.Context, resolver DomainResolver, domains []domain.Domain, moduleRoot string, merge MergeConfig) (map[string][]string, error) {
+	dirs, err := resolver.Resolve(ctx, domains)
+	if err != nil || !merge.SymlinksEnabled() {
+		return dirs, err
+	}
+	links := newSymlinkResolver(moduleRoot)
+	for name, list := range dirs {
+		out := append([]string(nil), list...)
+		for _, d := range list {
+			if real := links.dir(d); real != filepath.Clean(d) && !slices.Contains(out, real) {
+				out = append(out, real)
+			}
+		}
+		dirs[name] = out
+	}
+	return dirs, nil
+}
+
+// normalizeProfileCoverage is normalizeCoverageMap with the config's path
+// mappings applied and, unless disabled, symlinks resolved, so files are
+// keyed by where they really live in the module. Files of packages
+// coverpkg excludes are dropped.
+func normalizeProfileCoverage(files map[string]domain.CoverageStat, moduleRoot, modulePath string, cfg Config) map[string]domain.CoverageStat {
+	normalized := normalizeCoverageKeys(files, moduleRoot, modulePath, cfg.Merge.PathMappings, mergeSymlinks(cfg.Merge, moduleRoot))
+	return dropCoverPkgExcludes(normalized, cfg.CoverPkg, modulePath)
+}
+
+func mergeSymlinks(merge MergeConfig, moduleRoot string) *symlinkResolver {
+	if !merge.SymlinksEnabled() {
+		return nil
+	}
+	return newSymlinkResolver(moduleRoot)
+}
+
+// coverageKey is the module-relative slash path a profile file is keyed by.
+func coverageKey(file, moduleRoot, modulePath string, mappings []PathMapping, links *symlinkResolver) string {
+	normalized := normalizeCoverageFile(mapCoveragePath(file, mappings), modulePath, moduleRoot)
+	if links != nil {
+		normalized = links.file(normalized)
+	}
+	return filepath.ToSlash(moduleRelativePath(normalized, moduleRoot))
+}
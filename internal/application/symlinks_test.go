@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// symlinkTree builds root/store/app/src (real) and root/packages/app
+// pointing at it, the layout pnpm and bazel produce.
+func symlinkTree(t *testing.T) (root, link, real string) {
+	t.Helper()
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	real = filepath.Join(root, "store", "app")
+	if err := os.MkdirAll(filepath.Join(real, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "packages"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link = filepath.Join(root, "packages", "app")
+	if err := os.Symlink(real, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	return root, link, real
+}
+
+func TestResolveDomainDirsAddsSymlinkTargets(t *testing.T) {
+	root, link, real := symlinkTree(t)
+	resolver := fakeResolver{dirs: map[string][]string{"app": {link}}}
+
+	dirs, err := resolveDomainDirs(context.Background(), resolver, nil, root, MergeConfig{})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if !slices.Equal(dirs["app"], []string{link, real}) {
+		t.Fatalf("expected link and target, got %v", dirs["app"])
+	}
+
+	disabled := false
+	resolver = fakeResolver{dirs: map[string][]string{"app": {link}}}
+	dirs, _ = resolveDomainDirs(context.Background(), resolver, nil, root, MergeConfig{ResolveSymlinks: &disabled})
+	if !slices.Equal(dirs["app"], []string{link}) {
+		t.Fatalf("expected literal dirs when disabled, got %v", dirs["app"])
+	}
+}
+
+func TestNormalizeProfileCoverageResolvesSymlinks(t *testing.T) {
+	root, link, _ := symlinkTree(t)
+	files := map[string]domain.CoverageStat{
+		filepath.Join(link, "src", "a.js"): {Covered: 1, Total: 2},
+	}
+
+	got := normalizeProfileCoverage(files, root, "", Config{})
+	if _, ok := got["store/app/src/a.js"]; !ok {
+		t.Fatalf("expected file keyed by its real path, got %v", got)
+	}
+
+	disabled := false
+	got = normalizeProfileCoverage(files, root, "", Config{Merge: MergeConfig{ResolveSymlinks: &disabled}})
+	if _, ok := got["packages/app/src/a.js"]; !ok {
+		t.Fatalf("expected literal key when disabled, got %v", got)
+	}
+}
+
+func TestSymlinkResolverKeepsLinkedModuleRoot(t *testing.T) {
+	root, _, real := symlinkTree(t)
+	// The module itself is opened through a symlink, as /tmp is on macOS.
+	linkedRoot := filepath.Join(t.TempDir(), "checkout")
+	if err := os.Symlink(root, linkedRoot); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	links := newSymlinkResolver(linkedRoot)
+	want := filepath.Join(linkedRoot, "store", "app", "src", "a.js")
+	if got := links.file(filepath.Join(real, "src", "a.js")); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	missing := filepath.Join(linkedRoot, "gone", "b.js")
+	if got := links.file(missing); got != missing {
+		t.Fatalf("expected missing path unchanged, got %s", got)
+	}
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// startPhase opens a telemetry phase, or returns a no-op when telemetry is
+// not configured.
+func (s *Service) startPhase(ctx context.Context, phase string, attrs map[string]string) (context.Context, func(error)) {
+	if s.Telemetry == nil {
+		return ctx, func(error) {}
+	}
+	return s.Telemetry.StartPhase(ctx, phase, attrs)
+}
+
+func (s *Service) recordResult(ctx context.Context, command string, result domain.Result) {
+	if s.Telemetry != nil {
+		s.Telemetry.RecordResult(ctx, command, result)
+	}
+}
+
+// parseAll parses profiles inside a parse phase.
+func (s *Service) parseAll(ctx context.Context, profiles []string) (map[string]domain.CoverageStat, error) {
+	_, end := s.startPhase(ctx, PhaseParse, nil)
+	stats, err := s.ProfileParser.ParseAll(profiles)
+	end(err)
+	return stats, err
+}
+
+// tracedRunner times every test run of the wrapped runner as a run phase
+// labelled with the runner name.
+type tracedRunner struct {
+	CoverageRunner
+	telemetry Telemetry
+}
+
+func (r tracedRunner) Run(ctx context.Context, opts RunOptions) (string, error) {
+	ctx, end := r.telemetry.StartPhase(ctx, PhaseRun, map[string]string{"runner": r.Name(), "suite": "unit"})
+	profile, err := r.CoverageRunner.Run(ctx, opts)
+	end(err)
+	return profile, err
+}
+
+func (r tracedRunner) RunIntegration(ctx context.Context, opts IntegrationOptions) (string, error) {
+	ctx, end := r.telemetry.StartPhase(ctx, PhaseRun, map[string]string{"runner": r.Name(), "suite": "integration"})
+	profile, err := r.CoverageRunner.RunIntegration(ctx, opts)
+	end(err)
+	return profile, err
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type fakeTelemetry struct {
+	phases  []string
+	runners []string
+	results []string
+}
+
+func (f *fakeTelemetry) StartPhase(ctx context.Context, phase string, attrs map[string]string) (context.Context, func(error)) {
+	if runner := attrs["runner"]; runner != "" {
+		f.runners = append(f.runners, runner)
+	}
+	return ctx, func(error) { f.phases = append(f.phases, phase) }
+}
+
+func (f *fakeTelemetry) RecordResult(_ context.Context, command string, _ domain.Result) {
+	f.results = append(f.results, command)
+}
+
+func TestCheckRecordsTelemetry(t *testing.T) {
+	cfg := Config{Version: 1, Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{{Name: "core", Match: []string{"./internal/core/..."}}}}}
+	tel := &fakeTelemetry{}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "github.com/felixgeelhaar/coverctl"},
+		CoverageRunner: fakeRunner{profile: ".cover/coverage.out"},
+		ProfileParser:  fakeParser{stats: map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 8, Total: 10}}},
+		Reporter:       &fakeReporter{},
+		Telemetry:      tel,
+		Out:            io.Discard,
+	}
+
+	if err := svc.Check(context.Background(), CheckOptions{ConfigPath: ".coverctl.yaml"}); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	want := []string{PhaseRun, PhaseParse, PhaseAggregate, PhaseCheck}
+	if !reflect.DeepEqual(tel.phases, want) {
+		t.Fatalf("expected phases %v, got %v", want, tel.phases)
+	}
+	if len(tel.runners) != 1 || tel.runners[0] != (fakeRunner{}).Name() {
+		t.Fatalf("expected runner attribute, got %v", tel.runners)
+	}
+	if !reflect.DeepEqual(tel.results, []string{PhaseCheck}) {
+		t.Fatalf("expected check result recorded, got %v", tel.results)
+	}
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// noTimingsWarning is reported when --timing finds nothing recorded.
+const noTimingsWarning = "no test timings recorded yet; run coverctl check or coverctl run first"
+
+// attachTiming splits the last recorded test run across the result's
+// domains. Package times go to every domain whose directories hold the
+// package, like file coverage; domains that ran on their own keep their
+// wall-clock time. A nil store leaves the result untouched.
+func attachTiming(store TimingStore, domainDirs map[string][]string, moduleRoot, modulePath string, result *domain.Result) error {
+	if store == nil {
+		return nil
+	}
+	timings, ok, err := store.Load()
+	if err != nil {
+		return err
+	}
+	if !ok || timings.Empty() {
+		result.Warnings = append(result.Warnings, noTimingsWarning)
+		return nil
+	}
+
+	seconds := make(map[string]float64)
+	for pkg, s := range timings.Packages {
+		dir, ok := packageDir(pkg, modulePath)
+		if !ok {
+			continue
+		}
+		for name, dirs := range domainDirs {
+			if matchesAnyDir(dir, dirs, moduleRoot) {
+				seconds[name] += s
+			}
+		}
+	}
+	for name, s := range timings.Domains {
+		seconds[name] += s
+	}
+	result.Timing = domain.BuildDomainTimings(seconds, result.Domains)
+	return nil
+}
+
+// packageDir turns an import path inside the module into its
+// module-relative directory.
+func packageDir(pkg, modulePath string) (string, bool) {
+	if pkg == modulePath {
+		return ".", true
+	}
+	rel, ok := strings.CutPrefix(pkg, modulePath+"/")
+	if !ok {
+		return "", false
+	}
+	return filepath.FromSlash(rel), true
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+type memoryTimingStore struct {
+	timings domain.TestTimings
+	saved   bool
+}
+
+func (m *memoryTimingStore) Load() (domain.TestTimings, bool, error) {
+	return m.timings, m.saved, nil
+}
+func (m *memoryTimingStore) Save(t domain.TestTimings) error {
+	m.timings, m.saved = t, true
+	return nil
+}
+
+func TestAttachTiming(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	domainDirs := map[string][]string{
+		"core": {filepath.Join(root, "internal", "core")},
+		"api":  {filepath.Join(root, "internal", "api")},
+	}
+	store := &memoryTimingStore{saved: true, timings: domain.TestTimings{
+		Total: 40,
+		Packages: map[string]float64{
+			"example.com/repo/internal/core": 10,
+			"example.com/repo/internal/api":  4,
+			"example.com/other/pkg":          99,
+		},
+		Domains: map[string]float64{"e2e": 20},
+	}}
+	result := domain.Result{Domains: []domain.DomainResult{
+		{Domain: "core", Percent: 50},
+		{Domain: "api", Percent: 80},
+		{Domain: "e2e", Percent: 40},
+	}}
+	if err := attachTiming(store, domainDirs, root, "example.com/repo", &result); err != nil {
+		t.Fatalf("attachTiming: %v", err)
+	}
+	want := []domain.DomainTiming{
+		{Domain: "e2e", Seconds: 20, Percent: 40, SecondsPerPercent: 0.5},
+		{Domain: "core", Seconds: 10, Percent: 50, SecondsPerPercent: 0.2},
+		{Domain: "api", Seconds: 4, Percent: 80, SecondsPerPercent: 0.05},
+	}
+	if !slices.Equal(result.Timing, want) {
+		t.Fatalf("timing = %+v, want %+v", result.Timing, want)
+	}
+}
+
+func TestAttachTimingNothingRecorded(t *testing.T) {
+	var result domain.Result
+	if err := attachTiming(&memoryTimingStore{}, nil, "", "example.com/repo", &result); err != nil {
+		t.Fatalf("attachTiming: %v", err)
+	}
+	if len(result.Timing) != 0 || !slices.Contains(result.Warnings, noTimingsWarning) {
+		t.Fatalf("expected a warning and no timing, got %+v", result)
+	}
+
+	result = domain.Result{}
+	if err := attachTiming(nil, nil, "", "", &result); err != nil || len(result.Warnings) != 0 {
+		t.Fatalf("nil store should be a no-op, got %+v, %v", result, err)
+	}
+}
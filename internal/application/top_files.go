@@ -0,0 +1,49 @@
+package application
+
+import "github.com/felixgeelhaar/coverctl/internal/domain"
+
+// topUncoveredFiles returns up to n files with the most uncovered
+// statements among files that count toward a failing domain. Each entry
+// names only the failing domains it belongs to.
+func topUncoveredFiles(n int, files map[string]domain.CoverageStat, result domain.Result, exclude []string, covCtx *coverageContext) []domain.UncoveredFile {
+	if n <= 0 {
+		return nil
+	}
+	failing := make(map[string]bool)
+	for _, d := range result.Domains {
+		if d.Status == domain.StatusFail {
+			failing[d.Domain] = true
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+
+	var top []domain.UncoveredFile
+	for file, stat := range files {
+		if stat.Uncovered() == 0 || excluded(file, exclude) {
+			continue
+		}
+		owners, ignored := fileDomains(file, covCtx)
+		if ignored {
+			continue
+		}
+		var failingOwners []string
+		for _, name := range owners {
+			if failing[name] {
+				failingOwners = append(failingOwners, name)
+			}
+		}
+		if len(failingOwners) == 0 {
+			continue
+		}
+		entry := domain.NewUncoveredFile(file, stat)
+		entry.Domains = failingOwners
+		top = append(top, entry)
+	}
+	domain.SortUncoveredFiles(top)
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestReportTopFiles(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+		Exclude: []string{"internal/core/gen.go"},
+	}
+	stats := map[string]domain.CoverageStat{
+		"internal/core/a.go":   {Covered: 2, Total: 10},
+		"internal/core/b.go":   {Covered: 3, Total: 6},
+		"internal/core/c.go":   {Covered: 1, Total: 5},
+		"internal/core/gen.go": {Covered: 0, Total: 50},
+		"internal/api/h.go":    {Covered: 90, Total: 100},
+	}
+	svc := &Service{
+		ConfigLoader: fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector: fakeAutodetector{},
+		DomainResolver: fakeResolver{
+			dirs:       map[string][]string{"core": {"/repo/internal/core"}, "api": {"/repo/internal/api"}},
+			moduleRoot: "/repo",
+			modulePath: "example.com/mod",
+		},
+		ProfileParser: fakeParser{stats: stats},
+		Out:           io.Discard,
+	}
+
+	t.Run("lists worst files in failing domains", func(t *testing.T) {
+		result, err := svc.ReportResult(context.Background(), ReportOptions{Profile: "c.out", TopFiles: 2})
+		if err != nil {
+			t.Fatalf("report: %v", err)
+		}
+		var files []string
+		for _, f := range result.TopFiles {
+			files = append(files, f.File)
+		}
+		// api passes at 90%, so h.go is left out despite its 10 uncovered statements.
+		want := []string{"internal/core/a.go", "internal/core/c.go"}
+		if !reflect.DeepEqual(files, want) {
+			t.Fatalf("top files = %v, want %v", files, want)
+		}
+		if got := result.TopFiles[0]; got.Uncovered != 8 || !reflect.DeepEqual(got.Domains, []string{"core"}) {
+			t.Fatalf("unexpected first entry: %+v", got)
+		}
+	})
+
+	t.Run("omitted when not requested", func(t *testing.T) {
+		result, err := svc.ReportResult(context.Background(), ReportOptions{Profile: "c.out"})
+		if err != nil {
+			t.Fatalf("report: %v", err)
+		}
+		if result.TopFiles != nil {
+			t.Fatalf("expected no top files, got %+v", result.TopFiles)
+		}
+	})
+}
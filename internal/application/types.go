@@ -3,7 +3,10 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/felixgeelhaar/coverctl/internal/domain"
 )
@@ -15,8 +18,23 @@ const (
 	OutputJSON  OutputFormat = "json"
 	OutputHTML  OutputFormat = "html"
 	OutputBrief OutputFormat = "brief"
+	// OutputMarkdown renders results for CI job summaries; it is written
+	// alongside the primary output rather than selected with --output.
+	OutputMarkdown OutputFormat = "markdown"
+	// OutputGitLab and OutputAzure emit Cobertura XML shaped for GitLab's
+	// coverage_report artifact and Azure DevOps' code coverage publishing.
+	OutputGitLab OutputFormat = "gitlab"
+	OutputAzure  OutputFormat = "azure"
+	// OutputCSV and OutputTSV emit flat rows for spreadsheets and BI tools.
+	OutputCSV OutputFormat = "csv"
+	OutputTSV OutputFormat = "tsv"
 )
 
+// JSONSchema identifies the layout of check and report JSON output (and
+// the --report-file document). It only changes when fields are removed or
+// change meaning; new optional fields keep the version.
+const JSONSchema = "coverctl/v1"
+
 // Language represents a programming language.
 type Language string
 
@@ -286,33 +304,130 @@ var ErrConfigNotFound = errors.New("config not found")
 
 // Config represents validated, application-ready configuration.
 type Config struct {
-	Version     int
-	Language    Language      // Project language (auto-detected if empty)
-	Profile     ProfileConfig // Coverage profile configuration
-	Policy      domain.Policy
-	Exclude     []string
-	Files       []domain.FileRule
-	Diff        DiffConfig
-	Merge       MergeConfig
-	Integration IntegrationConfig
-	Annotations AnnotationsConfig
+	Version          int
+	Language         Language    // Project language (auto-detected if empty)
+	Runner           string      // Runner name that bypasses detection (go, python, node, ...)
+	Retry            RetryConfig // Re-runs of failed test runs (runner.retries)
+	CoverPkg         CoverPkgConfig
+	Matrix           []BuildVariant // GOOS/GOARCH/tag combinations the Go runner tests and merges
+	Profile          ProfileConfig  // Coverage profile configuration
+	Policy           domain.Policy
+	Exclude          []string
+	ExcludeFunctions []string // Regexps over "Func()" / "Type.Method()" whose statements are dropped
+	Files            []domain.FileRule
+	Diff             DiffConfig
+	NewCode          NewCodeConfig
+	Functions        FunctionsConfig
+	Merge            MergeConfig
+	Integration      IntegrationConfig
+	Annotations      AnnotationsConfig
+	Notify           NotifyConfig
+	Events           EventsConfig
+	Exceptions       []domain.PolicyException // Temporary, approved exemptions from minimums
+	Goals            []domain.Goal            // Coverage milestones tracked by `coverctl goals`
+	History          HistoryConfig
+}
+
+// RetryConfig re-runs a test run that failed (tests or build, not policy)
+// before the error is reported, to ride out flaky tests.
+type RetryConfig struct {
+	Retries    int  // Extra attempts after a failed run
+	OnlyFailed bool // Re-run only the failed packages (Go runner); other runners re-run everything
+}
+
+// CoverPkgConfig keeps packages out of the -coverpkg list the Go runner
+// instruments and out of domain totals, e.g. test helpers that domain
+// matches sweep in.
+type CoverPkgConfig struct {
+	Exclude     []string // Package patterns ("./internal/testutil/...") to leave out
+	AutoExclude *bool    // Also leave out testutil/testhelpers and test-only packages; nil means true
+	// Isolate runs each domain's own tests alone, with -coverpkg limited to
+	// the domain, so coverage exercised incidentally by another domain's
+	// tests is not credited to it.
+	Isolate bool
+}
+
+// AutoExcludeEnabled reports whether test helper packages are excluded
+// without being listed. It defaults to true.
+func (c CoverPkgConfig) AutoExcludeEnabled() bool {
+	return c.AutoExclude == nil || *c.AutoExclude
+}
+
+// BuildVariant is one GOOS/GOARCH/build-tag combination of a build matrix.
+// Files guarded by build constraints are only compiled, and so only
+// covered, in the variants whose constraints they satisfy.
+type BuildVariant struct {
+	Name   string // Names the variant's profile (coverage.<name>.out)
+	GOOS   string // Empty keeps the host's
+	GOARCH string // Empty keeps the host's
+	Tags   string // Added to the run's build tags
+}
+
+// HistoryConfig controls what `coverctl record` keeps per entry.
+type HistoryConfig struct {
+	TrackFiles bool // Record per-file statement counts for trend --file
 }
 
 // ProfileConfig configures coverage profile handling.
 type ProfileConfig struct {
-	Format Format // Coverage format (auto, go, lcov, cobertura, jacoco)
-	Path   string // Default profile path
+	Format Format             // Coverage format (auto, go, lcov, cobertura, jacoco)
+	Path   string             // Default profile path
+	MaxAge string             // Oldest a --from-profile profile may be (30m, 12h, 1d); empty disables the freshness guard
+	Stale  StaleProfileAction // What check does with a profile the freshness guard rejects
 }
 
+// StaleProfileAction is what check does when a --from-profile profile is
+// older than profile.max_age or than the source files it covers.
+type StaleProfileAction string
+
+const (
+	StaleProfileFail StaleProfileAction = "fail" // Default: the check errors
+	StaleProfileWarn StaleProfileAction = "warn" // The check goes on with a warning
+)
+
 type FileRule = domain.FileRule
 
 type DiffConfig struct {
 	Enabled bool
 	Base    string
+	Min     *float64 // Minimum coverage of changed lines (patch coverage); nil disables
+	// MaxUncoveredLines fails the check when more changed lines than this
+	// are uncovered, regardless of percentage; nil disables.
+	MaxUncoveredLines *int
+	FilesFrom         string // Read changed files from this list ("-" for stdin) instead of git
+}
+
+// NewCodeConfig holds lines last changed within an age window to their own
+// threshold (policy.new_code_since / policy.new_code_min), so legacy code
+// does not block adoption while new code is held to a high bar.
+type NewCodeConfig struct {
+	Since string  // Age window such as "90d"; empty disables
+	Min   float64 // Minimum coverage of lines changed within the window
+}
+
+// FunctionsConfig holds the share of functions with any coverage to a
+// minimum (functions.min), for teams that want every function touched.
+type FunctionsConfig struct {
+	Min *float64 // nil disables
 }
 
 type MergeConfig struct {
-	Profiles []string
+	Profiles        []string
+	PathMappings    []PathMapping // Rewrite profile path prefixes before normalization
+	ResolveSymlinks *bool         // Follow symlinks when matching files to domains; nil means true
+}
+
+// SymlinksEnabled reports whether symlinks are resolved during domain
+// matching. It defaults to true.
+func (m MergeConfig) SymlinksEnabled() bool {
+	return m.ResolveSymlinks == nil || *m.ResolveSymlinks
+}
+
+// PathMapping rewrites profile file paths starting with From to start with
+// To instead, e.g. a container checkout path to the local module root.
+type PathMapping struct {
+	From string
+	To   string
 }
 
 type IntegrationConfig struct {
@@ -327,6 +442,42 @@ type AnnotationsConfig struct {
 	Enabled bool
 }
 
+// NotifyFormat selects the webhook payload shape.
+type NotifyFormat string
+
+const (
+	NotifySlack NotifyFormat = "slack"
+	NotifyJSON  NotifyFormat = "json"
+)
+
+// NotifyConfig configures webhook notifications from check and record.
+type NotifyConfig struct {
+	Webhook    string       // Webhook URL; ${VAR} references are expanded when sending
+	Format     NotifyFormat // slack (default) or json
+	Regression *float64     // Notify when coverage drops by more than this many points
+	OnFailure  bool         // Notify when any domain is below its minimum
+	// Owners maps a domain owner to a webhook that receives only the
+	// reasons about domains that owner is listed on.
+	Owners map[string]string
+}
+
+// Enabled reports whether a webhook and at least one condition are set.
+func (n NotifyConfig) Enabled() bool {
+	return (n.Webhook != "" || len(n.Owners) > 0) && (n.Regression != nil || n.OnFailure)
+}
+
+// EventsConfig names the sinks that receive domain events from check and
+// record.
+type EventsConfig struct {
+	File    string // Append events as JSON lines to this file
+	Webhook string // POST each batch of events as JSON; ${VAR} references are expanded when sending
+}
+
+// Enabled reports whether any sink is configured.
+func (e EventsConfig) Enabled() bool {
+	return e.File != "" || e.Webhook != ""
+}
+
 type ConfigLoader interface {
 	Load(path string) (Config, error)
 	Exists(path string) (bool, error)
@@ -358,12 +509,96 @@ type CoverageRunner interface {
 	Detect(projectDir string) bool
 }
 
+// CommandRunner is implemented by runners that can run a domain's
+// test_command in place of their own test invocation.
+type CommandRunner interface {
+	// RunCommand runs command in the project directory and returns the
+	// profile it wrote to profilePath.
+	RunCommand(ctx context.Context, command []string, profilePath string) (string, error)
+}
+
+// FailedPackagesError is returned by runners that know which packages
+// failed, so a retry can re-run only those.
+type FailedPackagesError struct {
+	Packages []string
+	Err      error
+}
+
+func (e *FailedPackagesError) Error() string { return e.Err.Error() }
+
+func (e *FailedPackagesError) Unwrap() error { return e.Err }
+
+// TestFailureKind says why a package failed its test run.
+type TestFailureKind string
+
+const (
+	// TestFailureBuild: the package or its tests did not compile.
+	TestFailureBuild TestFailureKind = "build"
+	// TestFailureTest: one or more tests failed.
+	TestFailureTest TestFailureKind = "test"
+	// TestFailurePanic: a test or the test binary panicked.
+	TestFailurePanic TestFailureKind = "panic"
+)
+
+// TestFailure is one package that failed its test run.
+type TestFailure struct {
+	Package string          `json:"package"`
+	Kind    TestFailureKind `json:"kind"`
+	// Tests lists the failing tests, or the ones that panicked, innermost
+	// subtests only. It is empty for build failures.
+	Tests []string `json:"tests,omitempty"`
+}
+
+func (f TestFailure) String() string {
+	switch {
+	case f.Kind == TestFailureBuild:
+		return f.Package + " does not build"
+	case len(f.Tests) == 0 && f.Kind == TestFailurePanic:
+		return f.Package + " panicked"
+	case len(f.Tests) == 0:
+		return f.Package + " failed"
+	case f.Kind == TestFailurePanic:
+		return fmt.Sprintf("%s: %s panicked", f.Package, strings.Join(f.Tests, ", "))
+	}
+	return fmt.Sprintf("%s: %s failed", f.Package, strings.Join(f.Tests, ", "))
+}
+
+// TestFailuresError is returned by runners that read structured test
+// output, so the failure names the packages and tests at fault rather than
+// just the exit status in Err.
+type TestFailuresError struct {
+	Failures []TestFailure
+	Err      error
+}
+
+func (e *TestFailuresError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		parts[i] = f.String()
+	}
+	return fmt.Sprintf("%s (%v)", strings.Join(parts, "; "), e.Err)
+}
+
+func (e *TestFailuresError) Unwrap() error { return e.Err }
+
+// TestFailuresOf returns the failing packages err carries, if any.
+func TestFailuresOf(err error) []TestFailure {
+	var failures *TestFailuresError
+	if errors.As(err, &failures) {
+		return failures.Failures
+	}
+	return nil
+}
+
 // RunnerRegistry manages multiple coverage runners and selects the appropriate one.
 type RunnerRegistry interface {
 	// GetRunner returns a runner for the specified language.
 	GetRunner(lang Language) (CoverageRunner, error)
 	// DetectRunner auto-detects the appropriate runner for the project directory.
 	DetectRunner(projectDir string) (CoverageRunner, error)
+	// GetRunnerByName returns the named runner, failing when its toolchain
+	// is not installed.
+	GetRunnerByName(name string) (CoverageRunner, error)
 	// SupportedLanguages returns all languages with available runners.
 	SupportedLanguages() []Language
 }
@@ -383,19 +618,153 @@ type DiffProvider interface {
 	ChangedFiles(ctx context.Context, base string) ([]string, error)
 }
 
+// FileListDiffProvider is implemented by diff providers that can read changed
+// files from an exported list (diff.files_from) instead of version control.
+type FileListDiffProvider interface {
+	FromFileList(path string) DiffProvider
+}
+
+// RunMetadata identifies the revision and CI run a history entry was
+// recorded for.
+type RunMetadata struct {
+	Commit string
+	Branch string
+	Tag    string
+	PR     int
+	RunID  string
+}
+
+// RunMetadataProvider is implemented by diff providers that can read the
+// current commit, branch, and CI run from version control and CI variables.
+type RunMetadataProvider interface {
+	RunMetadata(ctx context.Context) RunMetadata
+}
+
+// LineDiffProvider is implemented by diff providers that can report changed
+// line ranges, enabling patch coverage (diff.min).
+type LineDiffProvider interface {
+	ChangedLines(ctx context.Context, base string) (map[string][]domain.LineRange, error)
+}
+
+// LineAgeProvider is implemented by diff providers that can tell when each
+// line was last changed, enabling new code thresholds
+// (policy.new_code_since).
+type LineAgeProvider interface {
+	// LinesChangedSince returns, per file, the line ranges last changed at or
+	// after since. files are module-relative slash paths.
+	LinesChangedSince(ctx context.Context, files []string, since time.Time) (map[string][]domain.LineRange, error)
+}
+
+// LineBlameProvider is implemented by diff providers that can attribute
+// lines to the commits that last changed them, enabling `coverctl blame`.
+type LineBlameProvider interface {
+	// BlameLines returns, per module-relative slash path, each line's blame.
+	BlameLines(ctx context.Context, files []string) (map[string]map[int]domain.BlameLine, error)
+}
+
+// LineProfileParser is implemented by profile parsers that can report
+// line-level hit counts, enabling patch coverage (diff.min).
+type LineProfileParser interface {
+	ParseAllLines(paths []string) (map[string]domain.LineCoverage, error)
+}
+
+// BranchProfileParser is implemented by profile parsers that can report
+// branch counters, enabling the branches metric.
+type BranchProfileParser interface {
+	ParseAllBranches(paths []string) (map[string]domain.CoverageStat, error)
+}
+
+// ModeReconciler is implemented by profile parsers whose format records a
+// coverage mode (Go's set, count, and atomic). ModeWarnings describes
+// profiles that mix modes, whose hit counts are reconciled when merged.
+type ModeReconciler interface {
+	ModeWarnings(paths []string) ([]string, error)
+}
+
+// ProfileExpander is implemented by profile parsers that accept glob
+// patterns and directories in place of profile paths. ExpandProfiles
+// returns the profiles they name; ExpansionWarnings describes the patterns
+// and directories that name none.
+type ProfileExpander interface {
+	ExpandProfiles(paths []string) []string
+	ExpansionWarnings(paths []string) []string
+}
+
 type AnnotationScanner interface {
 	Scan(ctx context.Context, moduleRoot string, files []string) (map[string]Annotation, error)
 }
 
+// FunctionScanner is implemented by annotation scanners that can locate
+// function declarations in source files, enabling exclude.functions.
+type FunctionScanner interface {
+	// ScanFunctions returns, per module-relative file, its functions.
+	ScanFunctions(ctx context.Context, moduleRoot string, files []string) (map[string][]domain.FunctionSpan, error)
+}
+
+// BlockProfileParser is implemented by profile parsers that can report the
+// statement blocks of a profile, enabling exclude.functions.
+type BlockProfileParser interface {
+	ParseAllBlocks(paths []string) (map[string][]domain.CoverageBlock, error)
+}
+
+// ProfileInspector is implemented by profile parsers that can check a
+// profile's structure beyond whether it parses, for validate-profile.
+type ProfileInspector interface {
+	InspectProfile(path string) (ProfileInspection, error)
+}
+
+// ProfileInspection describes the structure of one coverage profile.
+type ProfileInspection struct {
+	Format     Format   `json:"format"`
+	Files      int      `json:"files"`
+	Statements int      `json:"statements"`
+	Duplicates int      `json:"duplicates"`         // Blocks listed more than once; normal with go test -coverpkg and merged when read
+	Overlaps   []string `json:"overlaps,omitempty"` // Distinct blocks claiming the same source, e.g. profiles of two revisions
+	Errors     []string `json:"errors,omitempty"`   // Format errors, e.g. "line 1: invalid coverage mode line"
+}
+
 type Reporter interface {
 	Write(w io.Writer, result domain.Result, format OutputFormat) error
 }
 
+// HTMLOptions customizes HTML output.
+type HTMLOptions struct {
+	Title         string // Page title and heading (empty = "Coverage Report")
+	SelfContained bool   // Embed source snippets of uncovered lines so the file stands alone
+}
+
+// HTMLReporter is implemented by reporters whose HTML output accepts
+// HTMLOptions.
+type HTMLReporter interface {
+	WriteHTML(w io.Writer, result domain.Result, opts HTMLOptions) error
+}
+
 type RunOptions struct {
 	Domains     []domain.Domain
 	ProfilePath string
 	BuildFlags  BuildFlags // Build and test flags
 	Packages    []string   // Specific packages to test (empty = all packages via ./...)
+	Progress    io.Writer  // Optional: live progress line while tests run (runners that support it)
+	// PackageTime, when set, receives each package's test time in seconds
+	// (runners that report it).
+	PackageTime func(pkg string, seconds float64)
+	// AfterDomains, when set, is called by runDomainTests after each run
+	// that still has domain runs queued behind it, with the domains the run
+	// covered and every profile produced so far. Returning true skips the
+	// queued runs.
+	AfterDomains func(done []domain.Domain, profiles []string) bool
+	// Retry re-runs a failed run; runDomainTests applies it. With
+	// OnlyFailed, the Go runner also reports failed packages in a
+	// *FailedPackagesError so the retry can run just those.
+	Retry RetryConfig
+	// AppendProfile adds this run's coverage to the profile already at
+	// ProfilePath instead of replacing it (Go runner, failed-package retries).
+	AppendProfile bool
+	// CoverPkg names packages the Go runner leaves out of -coverpkg.
+	CoverPkg CoverPkgConfig
+	// Matrix, when set, makes the Go runner test once per variant and
+	// merge the variant profiles block by block into ProfilePath.
+	Matrix []BuildVariant
 }
 
 // BuildFlags contains options passed to go test
@@ -415,7 +784,8 @@ type IntegrationOptions struct {
 	RunArgs    []string
 	CoverDir   string
 	Profile    string
-	BuildFlags BuildFlags // Build and test flags
+	BuildFlags BuildFlags     // Build and test flags
+	CoverPkg   CoverPkgConfig // Packages left out of -coverpkg
 }
 
 type Annotation struct {
@@ -428,11 +798,12 @@ type IgnoreOptions struct {
 }
 
 type BadgeOptions struct {
-	ConfigPath  string
-	ProfilePath string
-	Output      string
-	Label       string
-	Style       string
+	ConfigPath   string
+	ProfilePath  string
+	Output       string
+	Label        string
+	Style        string
+	HistoryStore HistoryStore // Optional: annotate the badge with the change since the latest entry
 }
 
 type TrendOptions struct {
@@ -440,7 +811,8 @@ type TrendOptions struct {
 	ProfilePath string
 	HistoryPath string
 	Output      OutputFormat
-	Days        int // Number of days to analyze (0 = all)
+	Days        int    // Number of days to analyze (0 = all)
+	File        string // Show this file's recorded coverage (needs history.track_files)
 }
 
 type RecordOptions struct {
@@ -449,16 +821,118 @@ type RecordOptions struct {
 	HistoryPath string
 	Commit      string
 	Branch      string
+	Tag         string
+	PR          int
+	RunID       string
+	NoDetect    bool // Do not fill unset metadata from git and CI variables
 	Run         bool
 	Domains     []string
 	BuildFlags  BuildFlags
 	Language    Language
+	Runner      string
+	Signer      EntrySigner // Signs the entry and the profiles it came from; nil records unsigned
 }
 
 type RecordResult struct {
 	Warnings []string
 }
 
+// AuditSource names what changed the thresholds in an audit entry.
+type AuditSource string
+
+const (
+	AuditSuggest   AuditSource = "suggest"
+	AuditRatchetUp AuditSource = "ratchet-up"
+	// AuditManual marks edits found by comparing the config with the last
+	// audit entry's hash rather than made by a coverctl command.
+	AuditManual AuditSource = "manual"
+)
+
+// AuditEntry is one threshold change in the policy audit log. Thresholds
+// holds every threshold after the change, so the next entry can be diffed
+// against it.
+type AuditEntry struct {
+	Timestamp  time.Time          `json:"timestamp"`
+	Actor      string             `json:"actor,omitempty"`
+	Source     AuditSource        `json:"source"`
+	Config     string             `json:"config"`
+	ConfigHash string             `json:"configHash"`
+	Changes    []ConfigChange     `json:"changes"`
+	Thresholds map[string]float64 `json:"thresholds"`
+}
+
+// AuditLog is the append-only policy audit log.
+type AuditLog interface {
+	Load() ([]AuditEntry, error)
+	Append(entry AuditEntry) error
+}
+
+// AuditOptions configure Service.AuditConfig.
+type AuditOptions struct {
+	ConfigPath string
+	Source     AuditSource
+	Actor      string // Empty detects the CI user or git identity
+}
+
+// ActorProvider is implemented by diff providers that can tell who is
+// running coverctl, from CI variables or the git identity.
+type ActorProvider interface {
+	Actor(ctx context.Context) string
+}
+
+// EntrySigner signs history entries when they are recorded.
+type EntrySigner interface {
+	Sign(payload []byte) (domain.Signature, error)
+}
+
+// EntryVerifier checks signatures written by an EntrySigner.
+type EntryVerifier interface {
+	Verify(payload []byte, sig domain.Signature) error
+}
+
+// VerifyOptions configure Service.Verify.
+type VerifyOptions struct {
+	ProfilePaths  []string // Profiles to compare with the newest signed entry's digests
+	RequireSigned bool     // Count unsigned entries as failures
+	Verifier      EntryVerifier
+}
+
+// EntryStatus is the outcome of verifying one history entry.
+type EntryStatus string
+
+const (
+	EntryValid    EntryStatus = "valid"
+	EntryInvalid  EntryStatus = "invalid"
+	EntryUnsigned EntryStatus = "unsigned"
+)
+
+// EntryVerification is one history entry's signature check.
+type EntryVerification struct {
+	Index     int         `json:"index"`
+	Timestamp time.Time   `json:"timestamp"`
+	Commit    string      `json:"commit,omitempty"`
+	Status    EntryStatus `json:"status"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// ProfileVerification compares a profile with the digest the newest
+// valid signed entry recorded for it.
+type ProfileVerification struct {
+	Path    string `json:"path"`
+	SHA256  string `json:"sha256"`
+	Matches bool   `json:"matches"`
+}
+
+// VerifyResult is the outcome of Service.Verify.
+type VerifyResult struct {
+	Entries  []EntryVerification   `json:"entries"`
+	Valid    int                   `json:"valid"`
+	Invalid  int                   `json:"invalid"`
+	Unsigned int                   `json:"unsigned"`
+	Profiles []ProfileVerification `json:"profiles,omitempty"`
+	Passed   bool                  `json:"passed"`
+}
+
 type HistoryStore interface {
 	Load() (domain.History, error)
 	Save(h domain.History) error
@@ -466,9 +940,10 @@ type HistoryStore interface {
 }
 
 type SuggestOptions struct {
-	ConfigPath  string
-	ProfilePath string
-	Strategy    SuggestStrategy
+	ConfigPath   string
+	ProfilePath  string
+	Strategy     SuggestStrategy
+	HistoryStore HistoryStore // Required for SuggestHistory
 }
 
 type SuggestStrategy string
@@ -480,6 +955,8 @@ const (
 	SuggestAggressive SuggestStrategy = "aggressive"
 	// SuggestConservative suggests lower thresholds for gradual improvement
 	SuggestConservative SuggestStrategy = "conservative"
+	// SuggestHistory suggests thresholds that recorded history has reliably met
+	SuggestHistory SuggestStrategy = "history"
 )
 
 type Suggestion struct {
@@ -515,12 +992,13 @@ type DebtOptions struct {
 
 // DebtItem represents a single coverage debt item.
 type DebtItem struct {
-	Name      string  // Domain or file name
-	Type      string  // "domain" or "file"
-	Current   float64 // Current coverage percentage
-	Required  float64 // Required minimum coverage
-	Shortfall float64 // How much coverage is missing (required - current)
-	Lines     int     // Estimated lines of code needing tests
+	Name      string   // Domain or file name
+	Type      string   // "domain" or "file"
+	Current   float64  // Current coverage percentage
+	Required  float64  // Required minimum coverage
+	Shortfall float64  // How much coverage is missing (required - current)
+	Lines     int      // Estimated lines of code needing tests
+	Owners    []string `json:",omitempty"` // Domain owners; empty for files
 }
 
 // DebtResult contains the overall coverage debt analysis.
@@ -531,6 +1009,414 @@ type DebtResult struct {
 	HealthScore float64 // 0-100 score (higher is better)
 }
 
+// MetricsOptions configures `metrics`.
+type MetricsOptions struct {
+	ConfigPath  string
+	ProfilePath string
+}
+
+// MetricsResult is the coverage snapshot exported to monitoring systems.
+type MetricsResult struct {
+	Result domain.Result
+	Debt   DebtResult
+}
+
+// UncoveredOptions configures the uncovered-files listing.
+type UncoveredOptions struct {
+	ConfigPath  string
+	ProfilePath string
+	Domains     []string // Only files in these domains (empty = all files)
+	Pattern     string   // Only files matching this glob (module-relative)
+	Limit       int      // Maximum files returned (0 = no limit)
+}
+
+// UncoveredResult lists test-writing targets, most uncovered first.
+type UncoveredResult struct {
+	Files      []domain.UncoveredFile
+	Total      int  // Matching files before Limit was applied
+	LineRanges bool // Whether Files carry uncovered line ranges
+}
+
+// VetOptions configures `coverctl vet`.
+type VetOptions struct {
+	ConfigPath  string
+	ProfilePath string
+}
+
+// VetResult lists the files that fail their file rule as diagnostics.
+type VetResult struct {
+	Diagnostics []domain.Diagnostic `json:"diagnostics"`
+	Files       int                 `json:"files"` // Files a file rule applies to
+}
+
+// BlameOptions configures `coverctl blame`.
+type BlameOptions struct {
+	ConfigPath  string
+	ProfilePath string
+	Domains     []string // Only files in these domains (empty = all files)
+	Limit       int      // Maximum authors and commits listed (0 = no limit)
+}
+
+// BlameResult attributes uncovered lines to the authors and commits that
+// last changed them.
+type BlameResult struct {
+	domain.BlameSummary
+	TotalAuthors int `json:"totalAuthors"` // Authors before Limit was applied
+	TotalCommits int `json:"totalCommits"` // Commits before Limit was applied
+}
+
+// HeatmapOptions configures `coverctl heatmap`.
+type HeatmapOptions struct {
+	ConfigPath  string
+	ProfilePath string
+	Domains     []string // Only files in these domains (empty = all files)
+}
+
+// HeatmapResult is the directory tree behind the coverage treemap.
+type HeatmapResult struct {
+	Root  domain.HeatmapNode `json:"root"`
+	Files int                `json:"files"` // Files with statements in the tree
+}
+
+// PatchReportOptions configures `coverctl patch-report`.
+type PatchReportOptions struct {
+	ConfigPath  string
+	ProfilePath string
+	Base        string // Git ref to diff against; defaults to diff.base, then HEAD~1
+}
+
+// PatchReportResult is coverage of the lines changed since Base, overall
+// and per file.
+type PatchReportResult struct {
+	Base       string             `json:"base"`
+	Patch      domain.PatchResult `json:"patch"`
+	Files      []domain.PatchFile `json:"files"`
+	SourceRoot string             `json:"-"` // Module root the files are read from
+}
+
+// ValidateProfileOptions configures `coverctl validate-profile`.
+type ValidateProfileOptions struct {
+	ConfigPath  string
+	ProfilePath string
+}
+
+// ProfileValidation is the structure of a profile and what it would feed
+// each configured domain.
+type ProfileValidation struct {
+	Profile string `json:"profile"`
+	ProfileInspection
+	Valid     bool            `json:"valid"` // No format errors or overlapping blocks
+	Domains   []ProfileDomain `json:"domains,omitempty"`
+	Unmatched []string        `json:"unmatched,omitempty"` // Profile files in no domain
+}
+
+// ProfileDomain is the coverage a profile feeds one configured domain.
+type ProfileDomain struct {
+	Domain  string  `json:"domain"`
+	Files   int     `json:"files"`
+	Covered int     `json:"covered"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// ScaffoldOptions configures `coverctl scaffold`.
+type ScaffoldOptions struct {
+	ConfigPath  string
+	ProfilePath string
+	Domains     []string // Only files in these domains (empty = all files)
+}
+
+// ScaffoldTarget is a source file and its uncovered exported functions,
+// named as in exclude.functions ("Func()", "Type.Method()").
+type ScaffoldTarget struct {
+	File      string // Module-relative path
+	Functions []string
+}
+
+// ScaffoldFile is a generated test skeleton for one source file.
+type ScaffoldFile struct {
+	Source    string   `json:"source"`            // Module-relative source path
+	TestFile  string   `json:"testFile"`          // Module-relative path of the skeleton
+	Functions []string `json:"functions"`         // Functions that got a test
+	Skipped   []string `json:"skipped,omitempty"` // "Func(): reason" for functions left out
+	Content   []byte   `json:"-"`                 // Formatted Go source; empty when every function was skipped
+}
+
+// ScaffoldResult lists the generated skeletons, one per source file.
+type ScaffoldResult struct {
+	Files      []ScaffoldFile `json:"files"`
+	ModuleRoot string         `json:"-"`
+}
+
+// TestScaffolder writes test skeletons for uncovered functions.
+type TestScaffolder interface {
+	Scaffold(moduleRoot string, target ScaffoldTarget) (ScaffoldFile, error)
+}
+
+// LintOptions configures `coverctl lint`.
+type LintOptions struct {
+	ConfigPath  string
+	ProfilePath string // Profile whose files excludes, file rules, and annotations are checked against
+}
+
+// Lint rule names.
+const (
+	LintDeadDomain        = "dead-domain"
+	LintDeadMatch         = "dead-match"
+	LintUnusedExclude     = "unused-exclude"
+	LintConflictingRules  = "conflicting-file-rules"
+	LintUnknownAnnotation = "unknown-annotation-domain"
+)
+
+// LintFinding is one config problem lint found.
+type LintFinding struct {
+	Rule    string `json:"rule"`
+	Subject string `json:"subject"` // Domain, pattern, or file the finding is about
+	Message string `json:"message"`
+}
+
+// LintResult lists findings in rule order, and checks that were skipped.
+type LintResult struct {
+	Findings []LintFinding `json:"findings"`
+	Skipped  []string      `json:"skipped,omitempty"`
+}
+
+// ConfigDiffOptions names the two configs `coverctl diff-config` compares.
+// Each is a file path or a git revision and path ("origin/main:.coverctl.yaml").
+type ConfigDiffOptions struct {
+	Base string
+	Head string
+}
+
+// ConfigChangeKind classifies one semantic config change.
+type ConfigChangeKind string
+
+const (
+	ConfigRaised  ConfigChangeKind = "raised"
+	ConfigLowered ConfigChangeKind = "lowered"
+	ConfigAdded   ConfigChangeKind = "added"
+	ConfigRemoved ConfigChangeKind = "removed"
+	ConfigChanged ConfigChangeKind = "changed"
+)
+
+// ConfigChange is one policy-relevant difference between two configs.
+type ConfigChange struct {
+	Kind    ConfigChangeKind `json:"kind"`
+	Subject string           `json:"subject"` // "default min", "domain core min", "exclude", ...
+	Before  string           `json:"before,omitempty"`
+	After   string           `json:"after,omitempty"`
+}
+
+// ConfigDiff lists the changes from Base to Head in a stable order.
+type ConfigDiff struct {
+	Base    string         `json:"base"`
+	Head    string         `json:"head"`
+	Changes []ConfigChange `json:"changes"`
+}
+
+// ConfigSource loads a config from a file path or a git revision.
+type ConfigSource interface {
+	LoadConfig(ctx context.Context, ref string) (Config, error)
+}
+
+// AnnotateOptions configures `coverctl annotate`.
+type AnnotateOptions struct {
+	ConfigPath  string
+	ProfilePath string
+	Files       []string // Module-relative files to annotate
+	Match       []string // Also annotate profile files matching these exclude-style globs
+	DryRun      bool     // Report what would change without writing
+}
+
+// AnnotateStatus says what annotate did to one file.
+type AnnotateStatus string
+
+const (
+	AnnotateAdded       AnnotateStatus = "annotated"
+	AnnotatePresent     AnnotateStatus = "already-annotated"
+	AnnotateUnsupported AnnotateStatus = "unsupported"
+	AnnotateMissing     AnnotateStatus = "missing"
+)
+
+// AnnotatedFile is one file annotate visited.
+type AnnotatedFile struct {
+	File   string         `json:"file"` // Module-relative path
+	Status AnnotateStatus `json:"status"`
+	Line   string         `json:"line,omitempty"` // Comment inserted, or that would be with DryRun
+}
+
+// AnnotateResult lists the visited files in path order.
+type AnnotateResult struct {
+	Files   []AnnotatedFile `json:"files"`
+	DryRun  bool            `json:"dryRun,omitempty"`
+	Enabled bool            `json:"annotationsEnabled"` // annotations.enabled, without which the pragmas are not honoured
+}
+
+// SourceAnnotator inserts a coverctl:ignore pragma at the top of a source file.
+type SourceAnnotator interface {
+	Annotate(moduleRoot, file string, dryRun bool) (AnnotatedFile, error)
+}
+
+// SelectOptions configures `coverctl select`.
+type SelectOptions struct {
+	ConfigPath  string
+	Base        string // Git ref to diff against; defaults to diff.base, then HEAD~1
+	FilesFrom   string // Read changed files from this list ("-" for stdin) instead of git
+	ProfilesDir string // Directory of per-test profiles; empty selects by package
+}
+
+// TestProfile is the coverage profile of one test run on its own.
+type TestProfile struct {
+	Dir  string // Package directory relative to the module root, slash-separated
+	Test string // Top-level test function name
+	Path string
+}
+
+// TestProfileSource lists the per-test profiles under a directory.
+type TestProfileSource interface {
+	TestProfiles(dir string) ([]TestProfile, error)
+}
+
+// ForecastOptions configures `coverctl forecast`.
+type ForecastOptions struct {
+	ConfigPath string
+	Horizon    int // History entries to project ahead
+	Lookback   int // Recent entries per domain to fit; 0 uses all of them
+}
+
+// ForecastResult is the projected coverage of each configured domain.
+type ForecastResult struct {
+	Horizon  int                     `json:"horizon"`
+	Lookback int                     `json:"lookback"`
+	Domains  []domain.DomainForecast `json:"domains"`
+	// Skipped lists domains with fewer than two history entries.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// GoalsOptions configures `coverctl goals`.
+type GoalsOptions struct {
+	ConfigPath string
+	Lookback   int // Recent entries per domain to project from; 0 uses all of them
+}
+
+// GoalsResult is the progress of each configured goal, in config order.
+type GoalsResult struct {
+	Goals []domain.GoalProgress `json:"goals"`
+}
+
+// OrgReportOptions configures `coverctl aggregate`.
+type OrgReportOptions struct {
+	Inputs []string // Paths or globs of JSON reports and history files, one per service
+}
+
+// ServiceSnapshot is one input file of an org report: a service's JSON
+// report (--output json or --report-file) or its history file.
+type ServiceSnapshot struct {
+	Path    string
+	Result  *domain.Result  // Set for JSON reports
+	History *domain.History // Set for history files
+}
+
+// SnapshotSource expands input paths and globs and reads each file.
+type SnapshotSource interface {
+	Snapshots(patterns []string) ([]ServiceSnapshot, error)
+}
+
+// AggregateOptions selects the config and profiles for Aggregate.
+type AggregateOptions struct {
+	ConfigPath    string
+	ProfilePath   string
+	MergeProfiles []string // Additional profile files to merge
+	Domains       []string // Only these domains (empty = all domains)
+}
+
+// DebtPlanStore persists the active debt burn-down plan.
+type DebtPlanStore interface {
+	Load() (domain.DebtPlan, bool, error)
+	Save(p domain.DebtPlan) error
+}
+
+// AnalysisCache keeps prepared coverage between calls. Keys are digests of
+// every input, so entries never go stale; values round-trip through JSON.
+type AnalysisCache interface {
+	Get(key string, v any) bool
+	Put(key string, v any)
+}
+
+// TimingStore persists how long the last test run took.
+type TimingStore interface {
+	Load() (domain.TestTimings, bool, error)
+	Save(t domain.TestTimings) error
+}
+
+// DebtPlanOptions configures `debt plan`.
+type DebtPlanOptions struct {
+	TargetDate time.Time // Zero evaluates the saved plan instead of creating one
+	Output     OutputFormat
+}
+
+// DebtPlanResult contains a debt plan and how recorded history tracks against it.
+type DebtPlanResult struct {
+	Plan     domain.DebtPlan           `json:"plan"`
+	Created  bool                      `json:"created"`
+	AsOf     time.Time                 `json:"asOf"`
+	Progress []domain.DebtPlanProgress `json:"progress"`
+	OnTrack  bool                      `json:"onTrack"`
+}
+
+// ExplainOptions configures `coverctl explain-failure`.
+type ExplainOptions struct {
+	ConfigPath string
+	Profile    string
+	Report     *RunReport // Explain this run's failing domains instead of evaluating Profile
+	Files      int        // Lowest-covered files listed per domain; zero lists 3
+}
+
+// DomainExplanation tells how to bring one failing domain up to its
+// minimum.
+type DomainExplanation struct {
+	Domain   string                 `json:"domain"`
+	Percent  float64                `json:"percent"`
+	Required float64                `json:"required"`
+	Covered  int                    `json:"covered"`
+	Total    int                    `json:"total"`
+	Metric   domain.Metric          `json:"metric"`
+	ToCover  int                    `json:"toCover"` // More statements (or lines, branches) to cover
+	Owners   []string               `json:"owners,omitempty"`
+	Files    []domain.UncoveredFile `json:"files"`    // Lowest-covered files first
+	Commands []string               `json:"commands"` // Reproduce the failure locally
+}
+
+// ExplainResult lists remediation steps for every failing domain.
+type ExplainResult struct {
+	Passed   bool                `json:"passed"`
+	Domains  []DomainExplanation `json:"domains"`
+	Warnings []string            `json:"warnings,omitempty"`
+}
+
+// RatchetUpOptions configures `ratchet-up`.
+type RatchetUpOptions struct {
+	ConfigPath string
+	Days       int     // Window a threshold must have been met continuously
+	MaxStep    float64 // Largest increase applied to a single domain per run
+}
+
+// ThresholdChange describes one domain minimum raised by ratchet-up.
+type ThresholdChange struct {
+	Domain    string  `json:"domain"`
+	From      float64 `json:"from"`
+	To        float64 `json:"to"`
+	Sustained float64 `json:"sustained"`
+}
+
+// RatchetUpResult contains the threshold changes and the updated config.
+type RatchetUpResult struct {
+	Days    int               `json:"days"`
+	Changes []ThresholdChange `json:"changes"`
+	Skipped []string          `json:"skipped"` // Domains without enough history
+	Config  Config            `json:"-"`
+}
+
 // CompareOptions configures the coverage comparison.
 type CompareOptions struct {
 	ConfigPath  string
@@ -594,6 +1480,47 @@ type PRCommentResult struct {
 	Created     bool   `json:"created"` // true if created, false if updated
 }
 
+// Notification is a coverage alert raised by check or record.
+type Notification struct {
+	Webhook  string               `json:"-"`
+	Format   NotifyFormat         `json:"-"`
+	Source   string               `json:"source"` // "check" or "record"
+	Reasons  []string             `json:"reasons"`
+	Owners   []string             `json:"owners,omitempty"` // Owners of the domains the reasons name
+	Current  domain.HistoryEntry  `json:"current"`
+	Previous *domain.HistoryEntry `json:"previous,omitempty"`
+}
+
+// Notifier delivers notifications to an external channel.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// EventPublisher delivers domain events raised by a command ("check" or
+// "record") to the sinks in cfg.
+type EventPublisher interface {
+	Publish(ctx context.Context, cfg EventsConfig, source string, events []domain.DomainEvent) error
+}
+
+// Telemetry phase names used for spans and duration metrics.
+const (
+	PhaseCheck     = "check"
+	PhaseReport    = "report"
+	PhaseRun       = "run"
+	PhaseParse     = "parse"
+	PhaseAggregate = "aggregate"
+)
+
+// Telemetry exports phase timings and coverage values to an observability
+// backend.
+type Telemetry interface {
+	// StartPhase begins a timed phase and returns the context to run it in
+	// and a function that ends it with its outcome.
+	StartPhase(ctx context.Context, phase string, attrs map[string]string) (context.Context, func(error))
+	// RecordResult publishes overall and per-domain coverage for a command.
+	RecordResult(ctx context.Context, command string, result domain.Result)
+}
+
 // PRClient provides PR comment operations for any git hosting provider.
 type PRClient interface {
 	// Provider returns the provider type
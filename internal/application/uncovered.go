@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Uncovered lists files with unexecuted statements, ranked by how many
+// statements are uncovered, so callers can pick concrete files to test.
+// Files honour global excludes, per-domain excludes, and ignore
+// annotations the same way policy evaluation does. When the parser reports
+// line-level data, each file also carries its uncovered line ranges.
+func (s *Service) Uncovered(ctx context.Context, opts UncoveredOptions) (UncoveredResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return UncoveredResult{}, err
+	}
+	domains = filterDomainsByNames(domains, opts.Domains)
+	if len(domains) == 0 {
+		return UncoveredResult{}, errNoMatchingDomains(opts.Domains)
+	}
+	if opts.Pattern != "" {
+		if _, err := filepath.Match(opts.Pattern, ""); err != nil {
+			return UncoveredResult{}, fmt.Errorf("invalid pattern %q: %w", opts.Pattern, err)
+		}
+	}
+
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		return UncoveredResult{}, err
+	}
+
+	var files []domain.UncoveredFile
+	for file, stat := range covCtx.NormalizedCoverage {
+		if stat.Uncovered() == 0 || excluded(file, cfg.Exclude) {
+			continue
+		}
+		if opts.Pattern != "" {
+			if ok, _ := filepath.Match(opts.Pattern, file); !ok {
+				continue
+			}
+		}
+		owners, ignored := fileDomains(file, covCtx)
+		if ignored || (len(opts.Domains) > 0 && !inAnyDomain(owners, covCtx.DomainDirs)) {
+			continue
+		}
+		entry := domain.NewUncoveredFile(file, stat)
+		entry.Domains = owners
+		files = append(files, entry)
+	}
+	domain.SortUncoveredFiles(files)
+
+	result := UncoveredResult{Total: len(files)}
+	if opts.Limit > 0 && len(files) > opts.Limit {
+		files = files[:opts.Limit]
+	}
+
+	lines, ok, err := loadLineCoverage(s.ProfileParser, profiles, cfg.Exclude, covCtx.ModuleRoot, covCtx.ModulePath, cfg.Merge)
+	if err != nil {
+		return UncoveredResult{}, err
+	}
+	if ok {
+		result.LineRanges = true
+		for i := range files {
+			files[i].Ranges = lines[files[i].File].UncoveredRanges()
+		}
+	}
+	result.Files = files
+	return result, nil
+}
+
+// fileDomains returns the domains a module-relative file counts toward,
+// sorted, and whether an annotation removes it from coverage entirely.
+func fileDomains(file string, covCtx *coverageContext) ([]string, bool) {
+	if ann, ok := covCtx.Annotations[file]; ok {
+		if ann.Ignore {
+			return nil, true
+		}
+		if ann.Domain != "" {
+			return []string{ann.Domain}, false
+		}
+	}
+	native := filepath.FromSlash(file)
+	var owners []string
+	for name, dirs := range covCtx.DomainDirs {
+		if !matchesAnyDir(native, dirs, covCtx.ModuleRoot) {
+			continue
+		}
+		if excluded(file, covCtx.DomainExcludes[name]) {
+			continue
+		}
+		owners = append(owners, name)
+	}
+	sort.Strings(owners)
+	return owners, false
+}
+
+func inAnyDomain(names []string, domainDirs map[string][]string) bool {
+	for _, name := range names {
+		if _, ok := domainDirs[name]; ok {
+			return true
+		}
+	}
+	return false
+}
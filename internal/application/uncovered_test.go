@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestServiceUncovered(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+		Exclude: []string{"internal/core/gen.go"},
+	}
+	stats := map[string]domain.CoverageStat{
+		"internal/core/a.go":   {Covered: 2, Total: 10},
+		"internal/core/b.go":   {Covered: 5, Total: 6},
+		"internal/core/gen.go": {Covered: 0, Total: 50},
+		"internal/core/c.go":   {Covered: 4, Total: 4},
+		"internal/api/h.go":    {Covered: 1, Total: 4},
+	}
+	newService := func(dirs map[string][]string, parser ProfileParser) *Service {
+		return &Service{
+			ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+			Autodetector:   fakeAutodetector{},
+			DomainResolver: fakeResolver{dirs: dirs, moduleRoot: "/repo", modulePath: "example.com/mod"},
+			ProfileParser:  parser,
+			Out:            io.Discard,
+		}
+	}
+	allDirs := map[string][]string{"core": {"/repo/internal/core"}, "api": {"/repo/internal/api"}}
+
+	t.Run("ranks files by uncovered statements", func(t *testing.T) {
+		svc := newService(allDirs, fakeParser{stats: stats})
+		got, err := svc.Uncovered(context.Background(), UncoveredOptions{ConfigPath: ".coverctl.yaml", ProfilePath: "c.out"})
+		if err != nil {
+			t.Fatalf("uncovered: %v", err)
+		}
+		var files []string
+		for _, f := range got.Files {
+			files = append(files, f.File)
+		}
+		want := []string{"internal/core/a.go", "internal/api/h.go", "internal/core/b.go"}
+		if !reflect.DeepEqual(files, want) || got.Total != 3 || got.LineRanges {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+		if got.Files[0].Uncovered != 8 || !reflect.DeepEqual(got.Files[0].Domains, []string{"core"}) {
+			t.Fatalf("unexpected first file: %+v", got.Files[0])
+		}
+	})
+
+	t.Run("filters by domain, pattern, and limit", func(t *testing.T) {
+		svc := newService(map[string][]string{"core": {"/repo/internal/core"}}, fakeParser{stats: stats})
+		got, err := svc.Uncovered(context.Background(), UncoveredOptions{Domains: []string{"core"}, Pattern: "internal/*/?.go", Limit: 1})
+		if err != nil {
+			t.Fatalf("uncovered: %v", err)
+		}
+		if got.Total != 2 || len(got.Files) != 1 || got.Files[0].File != "internal/core/a.go" {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("attaches line ranges when available", func(t *testing.T) {
+		parser := fakeLineParser{
+			fakeParser: fakeParser{stats: stats},
+			lines: map[string]domain.LineCoverage{
+				"example.com/mod/internal/core/a.go": {3: 1, 4: 0, 5: 0, 7: 0, 9: 1, 10: 0},
+			},
+		}
+		svc := newService(allDirs, parser)
+		got, err := svc.Uncovered(context.Background(), UncoveredOptions{Limit: 1})
+		if err != nil {
+			t.Fatalf("uncovered: %v", err)
+		}
+		want := []domain.LineRange{{Start: 4, End: 7}, {Start: 10, End: 10}}
+		if !got.LineRanges || !reflect.DeepEqual(got.Files[0].Ranges, want) {
+			t.Fatalf("unexpected ranges: %+v", got.Files[0])
+		}
+	})
+
+	t.Run("rejects unknown domains and bad patterns", func(t *testing.T) {
+		svc := newService(allDirs, fakeParser{stats: stats})
+		if _, err := svc.Uncovered(context.Background(), UncoveredOptions{Domains: []string{"missing"}}); err == nil {
+			t.Fatal("expected error for unknown domain")
+		}
+		if _, err := svc.Uncovered(context.Background(), UncoveredOptions{Pattern: "["}); err == nil {
+			t.Fatal("expected error for malformed pattern")
+		}
+	})
+}
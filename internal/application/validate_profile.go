@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// ValidateProfile checks a profile before any check runs on it: its format,
+// block structure when the parser can inspect it, and which configured
+// domains its files would feed. A profile with format errors is reported,
+// not returned as an error, so every problem in it is listed at once.
+func (s *Service) ValidateProfile(ctx context.Context, opts ValidateProfileOptions) (ProfileValidation, error) {
+	if _, err := os.Stat(opts.ProfilePath); err != nil {
+		return ProfileValidation{}, fmt.Errorf("coverage profile not found: %s", opts.ProfilePath)
+	}
+	result := ProfileValidation{Profile: opts.ProfilePath}
+	inspector, inspects := s.ProfileParser.(ProfileInspector)
+	if inspects {
+		inspection, err := inspector.InspectProfile(opts.ProfilePath)
+		if err != nil {
+			return ProfileValidation{}, err
+		}
+		result.ProfileInspection = inspection
+		if len(inspection.Errors) > 0 {
+			return result, nil
+		}
+	}
+
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return ProfileValidation{}, err
+	}
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, []string{opts.ProfilePath})
+	if err != nil {
+		if ErrorCodeOf(err) != ErrCodeParseFormat {
+			return ProfileValidation{}, err
+		}
+		result.Errors = []string{err.Error()}
+		return result, nil
+	}
+	if !inspects {
+		result.Format = s.ProfileParser.Format()
+		result.Files = len(covCtx.NormalizedCoverage)
+		for _, stat := range covCtx.NormalizedCoverage {
+			result.Statements += stat.Total
+		}
+	}
+	result.Valid = len(result.Overlaps) == 0
+
+	files := map[string]int{}
+	for file := range covCtx.NormalizedCoverage {
+		if excluded(file, cfg.Exclude) {
+			continue
+		}
+		owners, ignored := fileDomains(file, covCtx)
+		if ignored {
+			continue
+		}
+		if len(owners) == 0 {
+			result.Unmatched = append(result.Unmatched, file)
+		}
+		for _, name := range owners {
+			files[name]++
+		}
+	}
+	sort.Strings(result.Unmatched)
+	for _, d := range domains {
+		stat := covCtx.DomainCoverage[d.Name]
+		result.Domains = append(result.Domains, ProfileDomain{
+			Domain:  d.Name,
+			Files:   files[d.Name],
+			Covered: stat.Covered,
+			Total:   stat.Total,
+			Percent: domain.Round1(stat.Percent()),
+		})
+	}
+	return result, nil
+}
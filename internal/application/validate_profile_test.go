@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// inspectingParser is a fakeParser that also inspects profiles.
+type inspectingParser struct {
+	fakeParser
+	inspection ProfileInspection
+}
+
+func (p inspectingParser) InspectProfile(path string) (ProfileInspection, error) {
+	return p.inspection, nil
+}
+
+func validateProfileService(t *testing.T, parser ProfileParser) (*Service, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(path, []byte("mode: set\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+			{Name: "api", Match: []string{"./internal/api/..."}},
+		}},
+	}
+	return newTestService(cfg, map[string][]string{"core": {"/repo/internal/core"}, "api": {"/repo/internal/api"}}, parser), path
+}
+
+func TestValidateProfileDomains(t *testing.T) {
+	parser := fakeParser{stats: map[string]domain.CoverageStat{
+		"internal/core/a.go": {Covered: 6, Total: 8},
+		"internal/core/b.go": {Covered: 0, Total: 2},
+		"cmd/main.go":        {Covered: 1, Total: 4},
+	}}
+	svc, path := validateProfileService(t, parser)
+
+	result, err := svc.ValidateProfile(context.Background(), ValidateProfileOptions{ProfilePath: path})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if !result.Valid || result.Files != 3 || result.Statements != 14 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	want := []ProfileDomain{
+		{Domain: "core", Files: 2, Covered: 6, Total: 10, Percent: 60},
+		{Domain: "api"},
+	}
+	if len(result.Domains) != 2 || result.Domains[0] != want[0] || result.Domains[1] != want[1] {
+		t.Fatalf("domains = %+v", result.Domains)
+	}
+	if len(result.Unmatched) != 1 || result.Unmatched[0] != "cmd/main.go" {
+		t.Fatalf("unmatched = %v", result.Unmatched)
+	}
+}
+
+func TestValidateProfileInspection(t *testing.T) {
+	broken := inspectingParser{inspection: ProfileInspection{Format: FormatGo, Errors: []string{"line 1: invalid coverage mode line"}}}
+	svc, path := validateProfileService(t, broken)
+	result, err := svc.ValidateProfile(context.Background(), ValidateProfileOptions{ProfilePath: path})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if result.Valid || len(result.Errors) != 1 || len(result.Domains) != 0 {
+		t.Fatalf("expected an invalid profile without domains, got %+v", result)
+	}
+
+	overlapping := inspectingParser{
+		fakeParser: fakeParser{stats: map[string]domain.CoverageStat{"internal/api/a.go": {Covered: 1, Total: 1}}},
+		inspection: ProfileInspection{Format: FormatGo, Files: 1, Statements: 1, Overlaps: []string{"internal/api/a.go: 1.1,5.2 overlaps 3.1,4.2"}},
+	}
+	svc, path = validateProfileService(t, overlapping)
+	result, err = svc.ValidateProfile(context.Background(), ValidateProfileOptions{ProfilePath: path})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if result.Valid || len(result.Domains) != 2 || result.Domains[1].Files != 1 {
+		t.Fatalf("expected an invalid profile still mapped to domains, got %+v", result)
+	}
+
+	if _, err := svc.ValidateProfile(context.Background(), ValidateProfileOptions{ProfilePath: filepath.Join(t.TempDir(), "missing.out")}); err == nil {
+		t.Fatal("expected an error for a missing profile")
+	}
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Vet evaluates the per-file rules against an existing profile and reports
+// each failing file as a diagnostic at its first uncovered line, so linter
+// pipelines can surface coverage policy next to other findings. Files an
+// active policy exception covers are not reported.
+func (s *Service) Vet(ctx context.Context, opts VetOptions) (VetResult, error) {
+	cfg, domains, err := s.loadOrDetect(opts.ConfigPath)
+	if err != nil {
+		return VetResult{}, err
+	}
+	if len(cfg.Files) == 0 {
+		return VetResult{Diagnostics: []domain.Diagnostic{}}, nil
+	}
+
+	profiles := buildProfileList(opts.ProfilePath, cfg.Merge.Profiles)
+	covCtx, err := s.prepareCoverageContext(ctx, cfg, domains, profiles)
+	if err != nil {
+		return VetResult{}, err
+	}
+	results, _ := evaluateFileRules(covCtx.NormalizedCoverage, cfg.Files, cfg.Exclude, covCtx.Annotations, cfg.Policy.Rounding)
+	result := VetResult{Files: len(results), Diagnostics: []domain.Diagnostic{}}
+	// Files covered by an unexpired exception are exempt, as they are in check.
+	exempted := domain.Result{Files: append([]domain.FileResult(nil), results...)}
+	applyExceptions(&exempted, cfg.Exceptions, cfg.Policy.Domains)
+	var failing []domain.FileResult
+	for i, r := range results {
+		if exempted.Files[i].Status != r.Status {
+			continue
+		}
+		if !r.IsPassing() {
+			failing = append(failing, r)
+		}
+	}
+	if len(failing) == 0 {
+		return result, nil
+	}
+
+	lines, _, err := loadLineCoverage(s.ProfileParser, profiles, cfg.Exclude, covCtx.ModuleRoot, covCtx.ModulePath, cfg.Merge)
+	if err != nil {
+		return VetResult{}, err
+	}
+	sort.Slice(failing, func(i, j int) bool { return failing[i].File < failing[j].File })
+	for _, r := range failing {
+		result.Diagnostics = append(result.Diagnostics, domain.FileRuleDiagnostic(r, lines[r.File]))
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestServiceVet(t *testing.T) {
+	cfg := Config{
+		Version: 1,
+		Policy: domain.Policy{DefaultMin: 50, Domains: []domain.Domain{
+			{Name: "core", Match: []string{"./internal/core/..."}},
+		}},
+		Files: []domain.FileRule{{Match: []string{"internal/core/*.go"}, Min: 80}},
+	}
+	parser := fakeLineParser{
+		fakeParser: fakeParser{stats: map[string]domain.CoverageStat{
+			"internal/core/b.go": {Covered: 1, Total: 4},
+			"internal/core/a.go": {Covered: 9, Total: 10},
+			"internal/core/c.go": {Covered: 2, Total: 4},
+		}},
+		lines: map[string]domain.LineCoverage{
+			"example.com/mod/internal/core/b.go": {3: 1, 7: 0, 8: 0},
+		},
+	}
+	svc := &Service{
+		ConfigLoader:   fakeConfigLoader{exists: true, cfg: cfg},
+		Autodetector:   fakeAutodetector{},
+		DomainResolver: fakeResolver{dirs: map[string][]string{"core": {"/repo/internal/core"}}, moduleRoot: "/repo", modulePath: "example.com/mod"},
+		ProfileParser:  parser,
+		Out:            io.Discard,
+	}
+	got, err := svc.Vet(context.Background(), VetOptions{ProfilePath: "c.out"})
+	if err != nil {
+		t.Fatalf("vet: %v", err)
+	}
+	if got.Files != 3 || len(got.Diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics across 3 files, got %+v", got)
+	}
+	if d := got.Diagnostics[0]; d.File != "internal/core/b.go" || d.Line != 7 {
+		t.Fatalf("expected b.go at its first uncovered line, got %+v", d)
+	}
+	if d := got.Diagnostics[1]; d.File != "internal/core/c.go" || d.Line != 1 {
+		t.Fatalf("expected c.go at line 1 without line data, got %+v", d)
+	}
+
+	cfg.Exceptions = []domain.PolicyException{
+		{File: "internal/core/b.go", Reason: "legacy", Approver: "lead", Expires: "2999-12-31"},
+		{File: "internal/core/c.go", Reason: "legacy", Approver: "lead", Expires: "2000-01-01"},
+	}
+	svc.ConfigLoader = fakeConfigLoader{exists: true, cfg: cfg}
+	got, err = svc.Vet(context.Background(), VetOptions{ProfilePath: "c.out"})
+	if err != nil {
+		t.Fatalf("vet: %v", err)
+	}
+	if len(got.Diagnostics) != 1 || got.Diagnostics[0].File != "internal/core/c.go" {
+		t.Fatalf("expected the active exception to exempt b.go but not the expired one c.go, got %+v", got.Diagnostics)
+	}
+
+	cfg.Files = nil
+	svc.ConfigLoader = fakeConfigLoader{exists: true, cfg: cfg}
+	if got, err := svc.Vet(context.Background(), VetOptions{}); err != nil || got.Diagnostics == nil || len(got.Diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics without file rules, got %+v %v", got, err)
+	}
+}
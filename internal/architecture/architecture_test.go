@@ -126,6 +126,29 @@ func TestLayerBoundary_ApplicationStaysClean(t *testing.T) {
 	}
 }
 
+// TestLayerBoundary_PublicAPIStaysHeadless asserts pkg/ does not import the
+// CLI or MCP entrypoints. The public API is a facade over application and
+// infrastructure; pulling in an entrypoint would drag flag parsing,
+// terminal output, and transports into every program that embeds it.
+func TestLayerBoundary_PublicAPIStaysHeadless(t *testing.T) {
+	root := repoRoot(t)
+	imports := importsOf(t, filepath.Join(root, "pkg"))
+
+	forbidden := []string{
+		"github.com/felixgeelhaar/coverctl/internal/cli",
+		"github.com/felixgeelhaar/coverctl/internal/mcp",
+	}
+	for file, paths := range imports {
+		for _, p := range paths {
+			for _, f := range forbidden {
+				if strings.HasPrefix(p, f) {
+					t.Errorf("pkg/%s imports %q; the public API must not depend on cli / mcp", file, p)
+				}
+			}
+		}
+	}
+}
+
 // fileSizeCeiling is the contract: a god-file is acknowledged debt with a
 // stated ceiling. Hitting the ceiling means the next change to that file
 // must be preceded by extraction work, not piled on top.
@@ -145,8 +168,8 @@ func TestFileSizeCeilings(t *testing.T) {
 	ceilings := []fileSizeCeiling{
 		{
 			relpath: "internal/cli/cli.go",
-			maxLOC:  1400,
-			reason:  "Dispatch is now a thin switch; each command lives in its own cmd_*.go. Adding back inline command bodies (instead of an extracted runXxx) is the regression to prevent.",
+			maxLOC:  850,
+			reason:  "Dispatch is now a thin switch; each command lives in its own cmd_*.go, help text in help.go and shell completions in completion.go. Adding back inline command bodies (instead of an extracted runXxx) is the regression to prevent.",
 		},
 		{
 			relpath: "internal/application/service.go",
@@ -247,6 +270,37 @@ func TestSchemaLanguageEnumMatchesConstants(t *testing.T) {
 	}
 }
 
+// TestOutputSchemaVersionMatchesConstant asserts the published JSON output
+// schema and application.JSONSchema name the same version, so a bump in one
+// place cannot ship without the other.
+func TestOutputSchemaVersionMatchesConstant(t *testing.T) {
+	root := repoRoot(t)
+
+	schemaBytes, err := os.ReadFile(filepath.Join(root, "schemas", "coverctl-output.schema.json"))
+	if err != nil {
+		t.Fatalf("read output schema: %v", err)
+	}
+	var schema struct {
+		Properties struct {
+			Schema struct {
+				Const string `json:"const"`
+			} `json:"schema"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		t.Fatalf("parse output schema: %v", err)
+	}
+
+	typesBytes, err := os.ReadFile(filepath.Join(root, "internal", "application", "types.go"))
+	if err != nil {
+		t.Fatalf("read types.go: %v", err)
+	}
+	needle := `const JSONSchema = "` + schema.Properties.Schema.Const + `"`
+	if schema.Properties.Schema.Const == "" || !strings.Contains(string(typesBytes), needle) {
+		t.Errorf("schemas/coverctl-output.schema.json properties.schema.const %q does not match application.JSONSchema in internal/application/types.go", schema.Properties.Schema.Const)
+	}
+}
+
 // TestLanguageRegistryIsCompleteAndConsistent asserts the canonical
 // application.Languages registry stays the single source of truth for
 // language metadata. Drift between registry, runners, schema, and detector
@@ -19,15 +19,19 @@ import (
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/autodetect"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/badge"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/bitbucket"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/cache"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/config"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/diff"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/events"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/github"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gitlab"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/notify"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/resolver"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/runners"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/theme"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/watcher"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/wizard"
 	"github.com/felixgeelhaar/coverctl/internal/mcp"
@@ -36,17 +40,38 @@ import (
 
 type Service interface {
 	Check(ctx context.Context, opts application.CheckOptions) error
+	Gate(ctx context.Context, opts application.CheckOptions) (domain.Gate, error)
 	RunOnly(ctx context.Context, opts application.RunOnlyOptions) error
 	Detect(ctx context.Context, opts application.DetectOptions) (application.Config, error)
+	DetectMerge(ctx context.Context, opts application.DetectOptions) (application.DetectMergeResult, error)
 	Report(ctx context.Context, opts application.ReportOptions) error
 	Ignore(ctx context.Context, opts application.IgnoreOptions) (application.Config, []domain.Domain, error)
 	Badge(ctx context.Context, opts application.BadgeOptions) (application.BadgeResult, error)
 	Trend(ctx context.Context, opts application.TrendOptions, store application.HistoryStore) (application.TrendResult, error)
+	Forecast(ctx context.Context, opts application.ForecastOptions, store application.HistoryStore) (application.ForecastResult, error)
+	Goals(ctx context.Context, opts application.GoalsOptions, store application.HistoryStore) (application.GoalsResult, error)
+	Verify(ctx context.Context, opts application.VerifyOptions, store application.HistoryStore) (application.VerifyResult, error)
 	Record(ctx context.Context, opts application.RecordOptions, store application.HistoryStore) error
 	Suggest(ctx context.Context, opts application.SuggestOptions) (application.SuggestResult, error)
 	Watch(ctx context.Context, opts application.WatchOptions, watcher application.FileWatcher, callback application.WatchCallback) error
 	Debt(ctx context.Context, opts application.DebtOptions) (application.DebtResult, error)
+	Metrics(ctx context.Context, opts application.MetricsOptions) (application.MetricsResult, error)
+	DebtPlan(ctx context.Context, opts application.DebtPlanOptions, history application.HistoryStore, plans application.DebtPlanStore) (application.DebtPlanResult, error)
+	RatchetUp(ctx context.Context, opts application.RatchetUpOptions, store application.HistoryStore) (application.RatchetUpResult, error)
+	ExplainFailure(ctx context.Context, opts application.ExplainOptions) (application.ExplainResult, error)
+	AuditConfig(ctx context.Context, opts application.AuditOptions, log application.AuditLog) (*application.AuditEntry, error)
 	Compare(ctx context.Context, opts application.CompareOptions) (application.CompareResult, error)
+	Blame(ctx context.Context, opts application.BlameOptions) (application.BlameResult, error)
+	Heatmap(ctx context.Context, opts application.HeatmapOptions) (application.HeatmapResult, error)
+	PatchReport(ctx context.Context, opts application.PatchReportOptions) (application.PatchReportResult, error)
+	ValidateProfile(ctx context.Context, opts application.ValidateProfileOptions) (application.ProfileValidation, error)
+	OrgReport(ctx context.Context, opts application.OrgReportOptions, source application.SnapshotSource) (domain.OrgReport, error)
+	SelectTests(ctx context.Context, opts application.SelectOptions, profiles application.TestProfileSource) (domain.TestSelection, error)
+	Scaffold(ctx context.Context, opts application.ScaffoldOptions, scaffolder application.TestScaffolder) (application.ScaffoldResult, error)
+	Annotate(ctx context.Context, opts application.AnnotateOptions, annotator application.SourceAnnotator) (application.AnnotateResult, error)
+	DiffConfig(ctx context.Context, opts application.ConfigDiffOptions, source application.ConfigSource) (application.ConfigDiff, error)
+	Lint(ctx context.Context, opts application.LintOptions) (application.LintResult, error)
+	Vet(ctx context.Context, opts application.VetOptions) (application.VetResult, error)
 	PRComment(ctx context.Context, opts application.PRCommentOptions) (application.PRCommentResult, error)
 }
 
@@ -56,10 +81,12 @@ type recordWarner interface {
 
 // GlobalOptions holds CLI-wide options that affect output behavior
 type GlobalOptions struct {
-	Quiet   bool // Suppress non-essential output
-	NoColor bool // Disable colored output
-	CI      bool // CI mode: quiet + no-color + GitHub Actions annotations
-	Debug   bool // Emit structured debug logs to stderr
+	Quiet   bool   // Suppress non-essential output
+	NoColor bool   // Disable colored output
+	CI      bool   // CI mode: quiet + no-color + GitHub Actions annotations
+	Debug   bool   // Emit structured debug logs to stderr
+	Chdir   string // Directory to run in, applied by ResolveWorkdir
+	NoCache bool   // Do not read or write the analysis cache
 }
 
 // IsQuiet returns true if output should be suppressed
@@ -74,6 +101,9 @@ func (g GlobalOptions) UseColor() bool {
 
 var initWizard = wizard.Run
 
+// configStdin backs "--config -", reading standard input at most once.
+var configStdin = config.NewStdin(os.Stdin)
+
 // withRuntimeLimit wraps ctx with a deadline parsed from durationStr. Returns
 // (ctx, cancel, nil) on success. Empty or "0" disables the limit (returns ctx
 // unchanged with a no-op cancel). Invalid duration string returns an error.
@@ -101,6 +131,22 @@ func withRuntimeLimit(ctx context.Context, durationStr string) (context.Context,
 	return c, cancel, nil
 }
 
+// progressWriter returns where a live test progress line should go, or nil
+// when none should be drawn: stderr must be a terminal, and quiet/CI mode,
+// JSON output, and verbose test output (-v) all turn it off.
+func progressWriter(stderr io.Writer, global GlobalOptions, output application.OutputFormat, verbose bool) io.Writer {
+	if global.IsQuiet() || output == application.OutputJSON || verbose || !theme.IsTerminal(stderr) {
+		return nil
+	}
+	return stderr
+}
+
+// analysisCacheDir holds prepared coverage shared between invocations.
+const analysisCacheDir = ".cover/cache"
+
+// timingPath is where check and run record test timings for report --timing.
+const timingPath = ".cover/timing.json"
+
 // parseGlobalFlags extracts global flags from args and returns:
 // - GlobalOptions with parsed flags
 // - command name (first non-flag argument)
@@ -123,7 +169,18 @@ loop:
 			global.CI = true
 		case "--debug":
 			global.Debug = true
+		case "--no-cache":
+			global.NoCache = true
+		case "-C", "--chdir":
+			if i+1 < len(args) {
+				i++
+				global.Chdir = args[i]
+			}
 		default:
+			if dir, ok := strings.CutPrefix(arg, "--chdir="); ok {
+				global.Chdir = dir
+				continue
+			}
 			// First non-global-flag is the command
 			cmd = arg
 			// Remaining args go to the command
@@ -143,6 +200,8 @@ func Run(args []string, stdout, stderr io.Writer, svc Service) int {
 
 	// Parse global flags and extract command
 	global, cmd, cmdArgs := parseGlobalFlags(args[1:])
+	theme.SetEnabled(global.UseColor())
+	cache.SetEnabled(!global.NoCache)
 
 	logger := setupLogger(stderr, global)
 	logger.Debug("coverctl invoked", "command", cmd, "version", Version)
@@ -163,61 +222,23 @@ func Run(args []string, stdout, stderr io.Writer, svc Service) int {
 
 	ctx := context.Background()
 
-	switch cmd {
-	case "version":
-		printVersion(stdout)
-		return 0
-	case "help":
-		if len(cmdArgs) < 1 {
-			usage(stdout)
-			return 0
-		}
-		return commandHelp(cmdArgs[0], stdout)
-	case "completion":
-		return runCompletion(cmdArgs, stdout, stderr)
-	case "check", "c":
-		return runCheck(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "run", "r":
-		return runRun(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "watch", "w":
-		return runWatchCmd(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "detect":
-		return runDetect(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "report":
-		return runReport(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "ignore":
-		return runIgnore(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "init", "i":
-		return runInit(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "badge":
-		return runBadge(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "trend":
-		return runTrend(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "record":
-		return runRecord(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "suggest":
-		return runSuggest(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "debt":
-		return runDebt(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "compare":
-		return runCompare(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "pr-comment":
-		return runPRComment(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "mcp":
-		return runMCP(ctx, cmdArgs, stdout, stderr, svc, global)
-	case "survey":
-		return runSurvey(ctx, cmdArgs, stdout, stderr, global)
-	default:
+	c, ok := findCommand(cmd)
+	if !ok {
 		usage(stderr)
 		return 2
 	}
+	if c.name != "help" && wantsHelp(cmdArgs) {
+		return commandHelp(c.name, stdout)
+	}
+	return c.run(ctx, cmdArgs, stdout, stderr, svc, global)
 }
 
 func BuildService(out *os.File) *application.Service {
 	module := gotool.NewCachedModuleResolver()
+	runnerPlugins, parserPlugins := pluginOptions()
 	// Use the runner registry for language auto-detection.
 	// The registry will detect the project type and delegate to the appropriate runner.
-	registry := runners.NewRegistry(module)
+	registry := runners.NewRegistry(module, runnerPlugins...)
 
 	// Get project directory for resolver
 	projectDir, _ := os.Getwd()
@@ -230,17 +251,21 @@ func BuildService(out *os.File) *application.Service {
 	multiResolver := resolver.NewMultiResolver(goResolver, projectDir, registry)
 
 	return &application.Service{
-		ConfigLoader:      config.Loader{},
+		ConfigLoader:      config.Loader{Stdin: configStdin},
 		Autodetector:      autodetect.Detector{Module: module, Registry: registry},
 		DomainResolver:    multiResolver,
 		CoverageRunner:    registry,
 		RunnerRegistry:    registry,
-		ProfileParser:     parsers.NewRegistry(),
+		ProfileParser:     parsers.NewRegistry(parserPlugins...),
 		DiffProvider:      diff.GitDiff{Module: module},
 		AnnotationScanner: annotations.Scanner{},
 		Reporter:          report.Writer{},
 		PRClients:         buildPRClients(),
 		CommentFormatter:  commentFormatter{},
+		Notifier:          notify.NewWebhook(),
+		Events:            events.NewPublisher(),
+		Telemetry:         buildTelemetry(os.Stderr),
+		Cache:             cache.New(analysisCacheDir),
 		Out:               out,
 	}
 }
@@ -332,22 +357,61 @@ func (commentFormatter) FormatCoverageComment(result domain.Result, comparison *
 
 func outputFlags(fs *flag.FlagSet) *application.OutputFormat {
 	output := application.OutputText
-	fs.Var((*outputValue)(&output), "output", "Output format: text|json|html|brief")
-	fs.Var((*outputValue)(&output), "o", "Output format: text|json|html|brief")
+	fs.Var((*outputValue)(&output), "output", "Output format: text|json|html|brief|gitlab|azure|csv|tsv")
+	fs.Var((*outputValue)(&output), "o", "Output format: text|json|html|brief|gitlab|azure|csv|tsv")
 	return &output
 }
 
+// htmlFlags registers the options that only apply to -o html.
+func htmlFlags(fs *flag.FlagSet) *application.HTMLOptions {
+	var html application.HTMLOptions
+	fs.StringVar(&html.Title, "title", "", "HTML report title (default \"Coverage Report\")")
+	fs.BoolVar(&html.SelfContained, "self-contained", false, "Embed uncovered source snippets in the HTML report")
+	return &html
+}
+
+// profileAgeOptions holds the --from-profile freshness guard flags.
+type profileAgeOptions struct {
+	maxAge string
+	stale  string
+}
+
+func profileAgeFlags(fs *flag.FlagSet) *profileAgeOptions {
+	var p profileAgeOptions
+	fs.StringVar(&p.maxAge, "max-profile-age", "", "With --from-profile, reject a profile older than this (30m, 12h, 1d) or than its source files")
+	fs.StringVar(&p.stale, "stale-profile", "", "What a stale profile does: fail or warn (default: profile.stale, else fail)")
+	return &p
+}
+
+// apply validates the flags and sets them on opts.
+func (p *profileAgeOptions) apply(opts *application.CheckOptions) error {
+	if p.maxAge != "" {
+		if _, err := domain.ParseAge(p.maxAge); err != nil {
+			return fmt.Errorf("invalid --max-profile-age: %w", err)
+		}
+	}
+	switch application.StaleProfileAction(p.stale) {
+	case "", application.StaleProfileFail, application.StaleProfileWarn:
+	default:
+		return fmt.Errorf("invalid --stale-profile %q: want fail or warn", p.stale)
+	}
+	opts.MaxProfileAge = p.maxAge
+	opts.StaleProfile = application.StaleProfileAction(p.stale)
+	return nil
+}
+
 type outputValue application.OutputFormat
 
 func (o *outputValue) String() string { return string(*o) }
 
 func (o *outputValue) Set(value string) error {
 	switch value {
-	case string(application.OutputText), string(application.OutputJSON), string(application.OutputHTML), string(application.OutputBrief):
+	case string(application.OutputText), string(application.OutputJSON), string(application.OutputHTML), string(application.OutputBrief),
+		string(application.OutputGitLab), string(application.OutputAzure), string(application.OutputCSV), string(application.OutputTSV):
 		*o = outputValue(value)
 		return nil
 	default:
-		return fmt.Errorf("invalid output format: %s (valid: text, json, html, brief)", value)
+		return fmt.Errorf("invalid output format: %s (valid: text, json, html, brief, gitlab, azure, csv, tsv)", value)
 	}
 }
 
@@ -417,7 +481,7 @@ func writeConfigFile(path string, cfg application.Config, stdout io.Writer, forc
 
 // validateConfig checks if the config file is valid without running tests
 func validateConfig(path string) error {
-	_, err := config.Loader{}.Load(path)
+	_, err := config.Loader{Stdin: configStdin}.Load(path)
 	if err != nil {
 		return fmt.Errorf("invalid config: %w", err)
 	}
@@ -425,47 +489,35 @@ func validateConfig(path string) error {
 }
 
 func usage(w io.Writer) {
-	fmt.Fprintf(w, `coverctl - Domain-driven coverage enforcement for any language
+	fmt.Fprint(w, `coverctl - Domain-driven coverage enforcement for any language
 
 Usage:
   coverctl [global-flags] <command> [flags]
   coverctl [--version | --help]
 
 Global Flags:
-  -q, --quiet     Suppress non-essential output
-      --no-color  Disable colored output
-      --ci        CI mode: quiet + GitHub Actions annotations
-      --debug     Emit JSON structured debug logs to stderr
-
-Commands:
-  check, c    Run coverage and enforce policy
-  run, r      Run coverage only, produce artifacts
-  watch, w    Watch for file changes and re-run coverage
-  init, i     Interactive setup wizard
-  detect      Autodetect domains and write config
-  report      Analyze an existing profile
-  badge       Generate an SVG coverage badge
-  trend       Show coverage trends over time
-  record      Record current coverage to history
-  suggest     Suggest optimal coverage thresholds
-  debt        Show coverage debt report
-  compare     Compare coverage between two profiles
-  ignore      Show configured excludes and ignore advice
-  pr-comment  Post coverage report as PR/MR comment (GitHub, GitLab, Bitbucket)
-  mcp         MCP (Model Context Protocol) server for AI agents
-
-Other:
-  help        Show help for a command
-  version     Show version information
-  completion  Generate shell completion scripts
-
-Version: %s
-
-Run 'coverctl help <command>' for more information on a command.
-`, Version)
+`)
+	for _, f := range globalFlags {
+		spelling := "    "
+		if f.short != "" {
+			spelling = "-" + f.short + ", "
+		}
+		fmt.Fprintf(w, "  %-15s %s\n", spelling+f.spelling(), f.usage)
+	}
+	var commands, other []string
+	for _, c := range visibleCommands() {
+		line := fmt.Sprintf("  %-11s %s", commandPattern(c, ", "), c.summary)
+		if c.other {
+			other = append(other, line)
+		} else {
+			commands = append(commands, line)
+		}
+	}
+	fmt.Fprintf(w, "\nCommands:\n%s\n\nOther:\n%s\n", strings.Join(commands, "\n"), strings.Join(other, "\n"))
+	fmt.Fprintf(w, "\nVersion: %s\n\nRun 'coverctl help <command>' for more information on a command.\n", Version)
 }
 
-func writeBadgeFile(path string, percent float64, label, style string) error {
+func writeBadgeFile(path string, result application.BadgeResult, label, style string) error {
 	cleanPath, err := pathutil.ValidatePath(path)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
@@ -483,8 +535,9 @@ func writeBadgeFile(path string, percent float64, label, style string) error {
 
 	return badge.Generate(file, badge.Options{
 		Label:   label,
-		Percent: percent,
+		Percent: result.Percent,
 		Style:   badgeStyle,
+		Delta:   result.Delta,
 	})
 }
 
@@ -522,9 +575,31 @@ func remediationHintForError(err error) string {
 	if errors.As(err, &modRoot) {
 		return mcp.ModuleRootRemediation
 	}
+	if code := application.ErrorCodeOf(err); code != "" {
+		return fmt.Sprintf("%s: %s", code, application.Remediation(code))
+	}
 	return ""
 }
 
+// writeJSONError writes a classified error to stdout as JSON when the
+// command's output is JSON, so tooling reading stdout can branch on the
+// error code. Unclassified errors (policy failures among them) are left to
+// stderr only, since the result may already have been written.
+func writeJSONError(w io.Writer, err error, format application.OutputFormat) {
+	code := application.ErrorCodeOf(err)
+	if format != application.OutputJSON || code == "" {
+		return
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(struct {
+		Error        string                    `json:"error"`
+		ErrorCode    application.ErrorCode     `json:"errorCode"`
+		Remediation  string                    `json:"remediation"`
+		TestFailures []application.TestFailure `json:"testFailures,omitempty"`
+	}{err.Error(), code, application.Remediation(code), application.TestFailuresOf(err)})
+}
+
 func printIgnoreInfo(cfg application.Config, domains []domain.Domain, w io.Writer) {
 	fmt.Fprintln(w, "Configured exclude patterns:")
 	if len(cfg.Exclude) == 0 {
@@ -542,30 +617,31 @@ func printIgnoreInfo(cfg application.Config, domains []domain.Domain, w io.Write
 }
 
 func printTrendResult(result application.TrendResult, w io.Writer) {
-	trendSymbol := "→"
-	switch result.Trend.Direction {
-	case domain.TrendUp:
-		trendSymbol = "↑"
-	case domain.TrendDown:
-		trendSymbol = "↓"
-	}
-
 	fmt.Fprintf(w, "Coverage Trend: %.1f%% %s %.1f%% (%+.1f%%)\n",
-		result.Previous, trendSymbol, result.Current, result.Trend.Delta)
+		result.Previous, trendArrow(result.Trend.Direction), result.Current, result.Trend.Delta)
 	fmt.Fprintln(w, "\nDomain Trends:")
 	for name, trend := range result.ByDomain {
-		symbol := "→"
-		switch trend.Direction {
-		case domain.TrendUp:
-			symbol = "↑"
-		case domain.TrendDown:
-			symbol = "↓"
+		fmt.Fprintf(w, "  %s: %s %+.1f%%\n", name, trendArrow(trend.Direction), trend.Delta)
+	}
+	if len(result.FileRegressions) > 0 {
+		fmt.Fprintln(w, "\nFile Regressions:")
+		for _, r := range result.FileRegressions {
+			fmt.Fprintf(w, "  %s: %.1f%% ↓ %.1f%% (%+.1f%%)\n", r.File, r.Previous, r.Current, r.Delta)
 		}
-		fmt.Fprintf(w, "  %s: %s %+.1f%%\n", name, symbol, trend.Delta)
 	}
 	fmt.Fprintf(w, "\nHistory: %d entries\n", len(result.Entries))
 }
 
+func trendArrow(direction domain.TrendDirection) string {
+	switch direction {
+	case domain.TrendUp:
+		return "↑"
+	case domain.TrendDown:
+		return "↓"
+	}
+	return "→"
+}
+
 func printSuggestResult(result application.SuggestResult, w io.Writer) {
 	fmt.Fprintln(w, "Threshold Suggestions:")
 	fmt.Fprintln(w, "")
@@ -584,11 +660,15 @@ func printSuggestResult(result application.SuggestResult, w io.Writer) {
 }
 
 func printDebtResult(result application.DebtResult, w io.Writer, format application.OutputFormat) {
-	if format == application.OutputJSON {
+	switch format {
+	case application.OutputJSON:
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		_ = enc.Encode(result)
 		return
+	case application.OutputCSV, application.OutputTSV:
+		_ = report.WriteDebtCSV(w, result, format)
+		return
 	}
 
 	// Text output
@@ -732,10 +812,10 @@ func runWatch(ctx context.Context, stdout, stderr io.Writer, svc Service, config
 			if global.CI {
 				fmt.Fprintf(stderr, "::error::Coverage run failed: %v\n", runErr)
 			} else {
-				fmt.Fprintf(stderr, "Coverage run failed: %v\n", runErr)
+				fmt.Fprintf(stderr, "%s %v\n", theme.For(stderr).Fail("✗ Coverage run failed:", "Coverage run failed:"), runErr)
 			}
 		} else if !global.IsQuiet() {
-			fmt.Fprintln(stdout, "Coverage run completed successfully")
+			fmt.Fprintln(stdout, theme.For(stdout).Pass("✓ Coverage run completed successfully", "Coverage run completed successfully"))
 		}
 	}
 
@@ -759,639 +839,3 @@ func runWatch(ctx context.Context, stdout, stderr io.Writer, svc Service, config
 	}
 	return 0
 }
-
-func printVersion(w io.Writer) {
-	fmt.Fprintf(w, "coverctl version %s\n", Version)
-	if Commit != "unknown" {
-		fmt.Fprintf(w, "  commit: %s\n", Commit)
-	}
-	if Date != "unknown" {
-		fmt.Fprintf(w, "  built:  %s\n", Date)
-	}
-}
-
-var commandHelpText = map[string]string{
-	"check": `coverctl check - Run coverage and enforce policy
-
-Usage:
-  coverctl check [flags]
-
-Aliases:
-  c
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile output path (default ".cover/coverage.out")
-      --from-profile     Use existing coverage profile instead of running tests
-  -d, --domain string    Filter to specific domain (repeatable)
-  -o, --output string    Output format: text|json|html|brief (default "text")
-                         Use 'brief' for single-line LLM/agent-optimized output
-      --show-delta       Show coverage change from previous run
-      --history string   History file path for delta display
-      --fail-under N     Fail if overall coverage is below N percent
-      --ratchet          Fail if coverage decreases from previous recorded value
-      --validate         Validate config file without running tests
-
-Build/Test Flags:
-      --tags string      Build tags (e.g., integration,e2e)
-      --race             Enable race detector
-      --short            Skip long-running tests
-  -v                     Verbose test output
-      --run string       Run only tests matching pattern
-      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
-      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
-      --test-arg string  Additional argument passed to go test (repeatable)
-
-Examples:
-  coverctl check
-  coverctl check -c custom.yaml
-  coverctl check --fail-under 80
-  coverctl check --ratchet
-  coverctl check --validate
-  coverctl check --from-profile --profile coverage.out
-  coverctl check --tags integration
-  coverctl check --race --timeout 30m
-  coverctl c -d core -d api`,
-
-	"run": `coverctl run - Run coverage only, produce artifacts
-
-Usage:
-  coverctl run [flags]
-
-Aliases:
-  r
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile output path (default ".cover/coverage.out")
-  -d, --domain string    Filter to specific domain (repeatable)
-
-Build/Test Flags:
-      --tags string      Build tags (e.g., integration,e2e)
-      --race             Enable race detector
-      --short            Skip long-running tests
-  -v                     Verbose test output
-      --run string       Run only tests matching pattern
-      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
-      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
-      --test-arg string  Additional argument passed to go test (repeatable)
-
-Examples:
-  coverctl run
-  coverctl run --tags integration
-  coverctl run --race -v
-  coverctl r -p coverage.out`,
-
-	"watch": `coverctl watch - Watch for file changes and re-run coverage
-
-Usage:
-  coverctl watch [flags]
-
-Aliases:
-  w
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile output path (default ".cover/coverage.out")
-  -d, --domain string    Filter to specific domain (repeatable)
-
-Build/Test Flags:
-      --tags string      Build tags (e.g., integration,e2e)
-      --race             Enable race detector
-      --short            Skip long-running tests
-  -v                     Verbose test output
-      --run string       Run only tests matching pattern
-      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
-      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
-      --test-arg string  Additional argument passed to go test (repeatable)
-
-Examples:
-  coverctl watch
-  coverctl watch --tags integration
-  coverctl w -d core`,
-
-	"init": `coverctl init - Interactive setup wizard
-
-Usage:
-  coverctl init [flags]
-
-Aliases:
-  i
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -f, --force            Overwrite existing config file
-      --no-interactive   Skip the interactive init wizard
-
-Examples:
-  coverctl init
-  coverctl i -f`,
-
-	"detect": `coverctl detect - Autodetect domains and write config
-
-Usage:
-  coverctl detect [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -f, --force            Overwrite config if it exists
-      --dry-run          Preview config without writing
-
-Examples:
-  coverctl detect
-  coverctl detect --dry-run
-  coverctl detect -f`,
-
-	"report": `coverctl report - Analyze an existing profile
-
-Usage:
-  coverctl report [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-  -d, --domain string    Filter to specific domain (repeatable)
-  -o, --output string    Output format: text|json|html|brief (default "text")
-                         Use 'brief' for single-line LLM/agent-optimized output
-      --show-delta       Show coverage change from previous run
-      --history string   History file path for delta display
-      --uncovered        Show only files with 0% coverage
-      --diff <ref>       Show coverage for files changed since git ref
-      --merge <file>     Merge additional coverage profile (repeatable)
-
-Examples:
-  coverctl report
-  coverctl report -p custom.out -o json
-  coverctl report -o html > coverage.html
-  coverctl report --uncovered
-  coverctl report --diff main
-  coverctl report --merge integration.out --merge e2e.out`,
-
-	"badge": `coverctl badge - Generate an SVG coverage badge
-
-Usage:
-  coverctl badge [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-  -o, --output string    Output file path (default "coverage.svg")
-      --label string     Badge label text (default "coverage")
-      --style string     Badge style: flat|flat-square (default "flat")
-
-Examples:
-  coverctl badge
-  coverctl badge -o badge.svg --style flat-square`,
-
-	"trend": `coverctl trend - Show coverage trends over time
-
-Usage:
-  coverctl trend [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-      --history string   History file path (default ".cover/history.json")
-  -o, --output string    Output format: text|json|html|brief (default "text")
-
-Examples:
-  coverctl trend
-  coverctl trend -o json`,
-
-	"record": `coverctl record - Record current coverage to history
-
-Usage:
-  coverctl record [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-      --history string   History file path (default ".cover/history.json")
-      --commit string    Git commit SHA (optional)
-      --branch string    Git branch name (optional)
-      --run              Run coverage before recording history
-  -l, --language string  Override language detection (go, python, nodejs, rust, java)
-  -d, --domain string    Filter to specific domain (repeatable)
-      --tags string      Build tags (e.g., integration,e2e)
-      --race             Enable race detector
-      --short            Skip long-running tests
-  -v                  Verbose test output
-      --test-run string  Run only tests matching pattern
-      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
-      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
-      --test-arg string  Additional argument passed to go test (repeatable)
-
-Examples:
-  coverctl record
-  coverctl record --commit abc123 --branch main
-  coverctl record --run --tags integration`,
-
-	"suggest": `coverctl suggest - Suggest optimal coverage thresholds
-
-Usage:
-  coverctl suggest [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-      --strategy string  Suggestion strategy: current|aggressive|conservative (default "current")
-      --apply            Update config with suggested thresholds
-  -f, --force            Overwrite config if it exists
-
-Examples:
-  coverctl suggest
-  coverctl suggest --strategy aggressive --apply`,
-
-	"debt": `coverctl debt - Show coverage debt report
-
-Usage:
-  coverctl debt [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-  -o, --output string    Output format: text|json|brief (default "text")
-
-Examples:
-  coverctl debt
-  coverctl debt -o json`,
-
-	"ignore": `coverctl ignore - Show configured excludes and ignore advice
-
-Usage:
-  coverctl ignore [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-
-Examples:
-  coverctl ignore`,
-
-	"compare": `coverctl compare - Compare coverage between two profiles
-
-Usage:
-  coverctl compare [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -b, --base string      Base coverage profile (required)
-  -H, --head string      Head coverage profile (default ".cover/coverage.out")
-  -o, --output string    Output format: text|json|brief (default "text")
-
-Examples:
-  coverctl compare --base main.out --head feature.out
-  coverctl compare -b main.out -o json`,
-
-	"pr-comment": `coverctl pr-comment - Post coverage report as PR/MR comment
-
-Supports GitHub, GitLab, and Bitbucket. Provider is auto-detected from
-environment variables or can be specified with --provider.
-
-Usage:
-  coverctl pr-comment [flags]
-
-Flags:
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-      --base string      Base coverage profile for comparison (optional)
-      --pr int           Pull request/MR number (required, auto-detected on GitLab/Bitbucket)
-      --owner string     Repository owner/namespace (auto-detected from env)
-      --repo string      Repository name (auto-detected from env)
-      --provider string  Git provider: github, gitlab, bitbucket, or auto (default "auto")
-      --update           Update existing comment instead of creating new (default true)
-      --dry-run          Generate comment without posting
-
-Environment Variables:
-  GitHub:
-    GITHUB_TOKEN           API token for authentication
-    GITHUB_REPOSITORY      Repository in owner/repo format
-
-  GitLab:
-    GITLAB_TOKEN           API token (or CI_JOB_TOKEN in GitLab CI)
-    CI_PROJECT_NAMESPACE   Project namespace (auto-set in GitLab CI)
-    CI_PROJECT_NAME        Project name (auto-set in GitLab CI)
-    CI_MERGE_REQUEST_IID   MR number (auto-set in GitLab CI)
-
-  Bitbucket:
-    BITBUCKET_USERNAME     Username for basic auth
-    BITBUCKET_APP_PASSWORD App password for authentication
-    BITBUCKET_WORKSPACE    Workspace name
-    BITBUCKET_REPO_SLUG    Repository slug
-    BITBUCKET_PR_ID        PR number (auto-set in Bitbucket Pipelines)
-
-Examples:
-  # GitHub (auto-detected)
-  coverctl pr-comment --pr 123
-
-  # GitLab (in CI, auto-detects everything)
-  coverctl pr-comment --provider gitlab
-
-  # Bitbucket with explicit values
-  coverctl pr-comment --provider bitbucket --owner myworkspace --repo myrepo --pr 45
-
-  # Dry run to preview comment
-  coverctl pr-comment --pr 123 --dry-run`,
-
-	"mcp": `coverctl mcp - MCP (Model Context Protocol) server for AI agents
-
-Usage:
-  coverctl mcp <subcommand> [flags]
-
-Subcommands:
-  serve       Start the MCP server (STDIO transport)
-
-Flags for 'serve':
-  -c, --config string    Config file path (default ".coverctl.yaml")
-  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
-      --history string   History file path (default ".cover/history.json")
-
-Description:
-  The MCP server enables AI agents (like Claude) to interact with coverctl
-  programmatically. It exposes coverage tools and resources via the Model
-  Context Protocol using STDIO transport.
-
-Tools (actions):
-  check     Run coverage tests and enforce policy thresholds
-  report    Analyze an existing coverage profile
-  record    Record current coverage to history
-
-Resources (read-only queries):
-  coverctl://debt      Coverage debt metrics
-  coverctl://trend     Coverage trends over time
-  coverctl://suggest   Threshold recommendations
-  coverctl://config    Current configuration
-
-Claude Desktop Configuration:
-  Add to ~/.config/claude/claude_desktop_config.json:
-
-  {
-    "mcpServers": {
-      "coverctl": {
-        "command": "coverctl",
-        "args": ["mcp", "serve"],
-        "cwd": "/path/to/your/go/project"
-      }
-    }
-  }
-
-Examples:
-  coverctl mcp serve
-  coverctl mcp serve -c custom.yaml
-  coverctl mcp serve --history .cover/history.json
-  coverctl mcp doctor                  # validate first-run setup
-  coverctl mcp doctor -c custom.yaml   # validate against a non-default config
-
-Subcommands:
-  serve   Start the MCP server (stdio).
-  doctor  Run first-run validation checks. Reports PASS/FAIL with
-          remediation per step: binary on PATH, working-directory
-          markers, config resolvable, MCP server construction, tool
-          dispatch smoke, mode auto-detect. Returns 0 only when every
-          check passes.`,
-
-	"survey": `coverctl survey - Sean Ellis 40% PMF feedback prompt
-
-Asks one question:
-  How would you feel if you could no longer use coverctl?
-
-Responses are appended to ~/.coverctl/survey.jsonl. Nothing is
-transmitted; aggregation is opt-in via the trace donation pipeline
-(deferred per docs/design/gtm-metrics-spec.md).
-
-Usage:
-  coverctl survey                    # interactive prompt
-  coverctl survey --answer very      # scripted: very|somewhat|not|skip
-  coverctl survey --data-dir ./tmp   # override storage location
-
-Why we ask:
-  The Sean Ellis 40% threshold is the standard PMF benchmark. If at
-  least 40% of users would be very disappointed without the product,
-  scaling GTM is justified; below that threshold we go back to
-  discovery before investing in growth.`,
-}
-
-func commandHelp(cmd string, w io.Writer) int {
-	if help, ok := commandHelpText[cmd]; ok {
-		fmt.Fprintln(w, help)
-		return 0
-	}
-	fmt.Fprintf(w, "Unknown command: %s\n\n", cmd)
-	usage(w)
-	return 2
-}
-
-func runCompletion(args []string, stdout, stderr io.Writer) int {
-	if len(args) < 1 {
-		fmt.Fprintln(stderr, "Usage: coverctl completion <bash|zsh|fish>")
-		return 2
-	}
-
-	switch args[0] {
-	case "bash":
-		fmt.Fprintln(stdout, bashCompletion)
-	case "zsh":
-		fmt.Fprintln(stdout, zshCompletion)
-	case "fish":
-		fmt.Fprintln(stdout, fishCompletion)
-	default:
-		fmt.Fprintf(stderr, "Unknown shell: %s\nSupported: bash, zsh, fish\n", args[0])
-		return 2
-	}
-	return 0
-}
-
-const bashCompletion = `# coverctl bash completion
-_coverctl() {
-    local cur prev commands global_flags
-    COMPREPLY=()
-    cur="${COMP_WORDS[COMP_CWORD]}"
-    prev="${COMP_WORDS[COMP_CWORD-1]}"
-    commands="check run watch init detect report badge trend record suggest debt ignore mcp survey help version completion c r w i"
-    global_flags="-q --quiet --no-color --ci --debug"
-
-    if [[ ${COMP_CWORD} -eq 1 ]]; then
-        COMPREPLY=( $(compgen -W "${commands} ${global_flags}" -- ${cur}) )
-        return 0
-    fi
-
-    case "${prev}" in
-        -c|--config)
-            COMPREPLY=( $(compgen -f -X '!*.yaml' -- ${cur}) )
-            return 0
-            ;;
-        -p|--profile)
-            COMPREPLY=( $(compgen -f -X '!*.out' -- ${cur}) )
-            return 0
-            ;;
-        -o|--output)
-            COMPREPLY=( $(compgen -W "text json html" -- ${cur}) )
-            return 0
-            ;;
-        --strategy)
-            COMPREPLY=( $(compgen -W "current aggressive conservative" -- ${cur}) )
-            return 0
-            ;;
-        --style)
-            COMPREPLY=( $(compgen -W "flat flat-square" -- ${cur}) )
-            return 0
-            ;;
-        completion)
-            COMPREPLY=( $(compgen -W "bash zsh fish" -- ${cur}) )
-            return 0
-            ;;
-        mcp)
-            COMPREPLY=( $(compgen -W "serve doctor" -- ${cur}) )
-            return 0
-            ;;
-    esac
-
-    COMPREPLY=( $(compgen -W "-c --config -p --profile -d --domain -o --output -f --force -h --help -q --quiet --no-color --ci --uncovered --diff --merge --show-delta --history --fail-under --ratchet --validate --tags --race --short -v --run --timeout --max-runtime --test-arg" -- ${cur}) )
-}
-complete -F _coverctl coverctl`
-
-const zshCompletion = `#compdef coverctl
-
-_coverctl() {
-    local -a commands
-    commands=(
-        'check:Run coverage and enforce policy'
-        'c:Run coverage and enforce policy (alias)'
-        'run:Run coverage only, produce artifacts'
-        'r:Run coverage only (alias)'
-        'watch:Watch for file changes and re-run coverage'
-        'w:Watch for file changes (alias)'
-        'init:Interactive setup wizard'
-        'i:Interactive setup wizard (alias)'
-        'detect:Autodetect domains and write config'
-        'report:Analyze an existing profile'
-        'badge:Generate an SVG coverage badge'
-        'trend:Show coverage trends over time'
-        'record:Record current coverage to history'
-        'suggest:Suggest optimal coverage thresholds'
-        'debt:Show coverage debt report'
-        'ignore:Show configured excludes and ignore advice'
-        'mcp:MCP server for AI agents'
-        'help:Show help for a command'
-        'version:Show version information'
-        'completion:Generate shell completion scripts'
-    )
-
-    _arguments -C \
-        '-q[Suppress non-essential output]' \
-        '--quiet[Suppress non-essential output]' \
-        '--no-color[Disable colored output]' \
-        '--ci[CI mode: quiet + GitHub Actions annotations]' \
-        '1: :->command' \
-        '*: :->args'
-
-    case $state in
-        command)
-            _describe 'command' commands
-            ;;
-        args)
-            case $words[2] in
-                check|c|run|r|watch|w|report|badge|trend|record|suggest|debt|ignore|init|i|detect)
-                    _arguments \
-                        '-c[Config file path]:file:_files -g "*.yaml"' \
-                        '--config[Config file path]:file:_files -g "*.yaml"' \
-                        '-p[Coverage profile path]:file:_files -g "*.out"' \
-                        '--profile[Coverage profile path]:file:_files -g "*.out"' \
-                        '--from-profile[Use existing coverage profile instead of running tests]' \
-                        '-d[Filter to domain]:domain:' \
-                        '--domain[Filter to domain]:domain:' \
-                        '-o[Output format]:format:(text json html)' \
-                        '--output[Output format]:format:(text json html)' \
-                        '-f[Force overwrite]' \
-                        '--force[Force overwrite]' \
-                        '--uncovered[Show only files with 0% coverage]' \
-                        '--diff[Show coverage for changed files]:ref:' \
-                        '--merge[Merge additional profile]:file:_files -g "*.out"' \
-                        '--show-delta[Show coverage change from previous run]' \
-                        '--history[History file path]:file:_files -g "*.json"' \
-                        '--fail-under[Fail if coverage below threshold]:percent:' \
-                        '--ratchet[Fail if coverage decreases]' \
-                        '--validate[Validate config without running tests]' \
-                        '--tags[Build tags]:tags:' \
-                        '--race[Enable race detector]' \
-                        '--short[Skip long-running tests]' \
-                        '-v[Verbose test output]' \
-                        '--run[Run tests matching pattern]:pattern:' \
-                        '--test-run[Run tests matching pattern]:pattern:' \
-                        '--timeout[Test timeout]:duration:' \
-                        '--test-arg[Additional test argument]:arg:' \
-                        '--language[Override language detection]:lang:(go python nodejs rust java)'
-                    ;;
-                completion)
-                    _arguments '1:shell:(bash zsh fish)'
-                    ;;
-                mcp)
-                    _arguments '1:subcommand:(serve)'
-                    ;;
-            esac
-            ;;
-    esac
-}
-
-_coverctl "$@"`
-
-const fishCompletion = `# coverctl fish completion
-complete -c coverctl -f
-
-# Global flags
-complete -c coverctl -s q -l quiet -d "Suppress non-essential output"
-complete -c coverctl -l no-color -d "Disable colored output"
-complete -c coverctl -l ci -d "CI mode: quiet + GitHub Actions annotations"
-
-# Commands
-complete -c coverctl -n "__fish_use_subcommand" -a "check" -d "Run coverage and enforce policy"
-complete -c coverctl -n "__fish_use_subcommand" -a "c" -d "Run coverage and enforce policy (alias)"
-complete -c coverctl -n "__fish_use_subcommand" -a "run" -d "Run coverage only, produce artifacts"
-complete -c coverctl -n "__fish_use_subcommand" -a "r" -d "Run coverage only (alias)"
-complete -c coverctl -n "__fish_use_subcommand" -a "watch" -d "Watch for file changes and re-run coverage"
-complete -c coverctl -n "__fish_use_subcommand" -a "w" -d "Watch for file changes (alias)"
-complete -c coverctl -n "__fish_use_subcommand" -a "init" -d "Interactive setup wizard"
-complete -c coverctl -n "__fish_use_subcommand" -a "i" -d "Interactive setup wizard (alias)"
-complete -c coverctl -n "__fish_use_subcommand" -a "detect" -d "Autodetect domains and write config"
-complete -c coverctl -n "__fish_use_subcommand" -a "report" -d "Analyze an existing profile"
-complete -c coverctl -n "__fish_use_subcommand" -a "badge" -d "Generate an SVG coverage badge"
-complete -c coverctl -n "__fish_use_subcommand" -a "trend" -d "Show coverage trends over time"
-complete -c coverctl -n "__fish_use_subcommand" -a "record" -d "Record current coverage to history"
-complete -c coverctl -n "__fish_use_subcommand" -a "suggest" -d "Suggest optimal coverage thresholds"
-complete -c coverctl -n "__fish_use_subcommand" -a "debt" -d "Show coverage debt report"
-complete -c coverctl -n "__fish_use_subcommand" -a "ignore" -d "Show configured excludes"
-complete -c coverctl -n "__fish_use_subcommand" -a "mcp" -d "MCP server for AI agents"
-complete -c coverctl -n "__fish_use_subcommand" -a "help" -d "Show help for a command"
-complete -c coverctl -n "__fish_use_subcommand" -a "version" -d "Show version information"
-complete -c coverctl -n "__fish_use_subcommand" -a "completion" -d "Generate shell completion"
-
-# Flags for all commands
-complete -c coverctl -s c -l config -d "Config file path" -r -F
-complete -c coverctl -s p -l profile -d "Coverage profile path" -r -F
-complete -c coverctl -l from-profile -d "Use existing coverage profile instead of running tests"
-complete -c coverctl -s d -l domain -d "Filter to specific domain" -r
-complete -c coverctl -s o -l output -d "Output format" -r -a "text json html"
-complete -c coverctl -s f -l force -d "Force overwrite"
-complete -c coverctl -s h -l help -d "Show help"
-complete -c coverctl -l uncovered -d "Show only files with 0% coverage"
-complete -c coverctl -l diff -d "Show coverage for changed files" -r
-complete -c coverctl -l merge -d "Merge additional coverage profile" -r -F
-complete -c coverctl -l show-delta -d "Show coverage change from previous run"
-complete -c coverctl -l history -d "History file path" -r -F
-complete -c coverctl -l fail-under -d "Fail if coverage below threshold" -r
-complete -c coverctl -l ratchet -d "Fail if coverage decreases"
-complete -c coverctl -l validate -d "Validate config without running tests"
-complete -c coverctl -l tags -d "Build tags (e.g., integration,e2e)" -r
-complete -c coverctl -l race -d "Enable race detector"
-complete -c coverctl -l short -d "Skip long-running tests"
-complete -c coverctl -s v -d "Verbose test output"
-complete -c coverctl -l run -d "Run tests matching pattern" -r
-complete -c coverctl -l test-run -d "Run tests matching pattern" -r
-complete -c coverctl -l timeout -d "Test timeout (e.g., 10m, 1h)" -r
-complete -c coverctl -l test-arg -d "Additional argument passed to go test" -r
-complete -c coverctl -l language -d "Override language detection" -r -a "go python nodejs rust java"
-
-# Completion subcommand
-complete -c coverctl -n "__fish_seen_subcommand_from completion" -a "bash zsh fish"
-
-# MCP subcommand
-complete -c coverctl -n "__fish_seen_subcommand_from mcp" -a "serve" -d "Start the MCP server"`
@@ -3,16 +3,26 @@ package cli
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/selfupdate"
 )
 
 func TestWithRuntimeLimit_Disabled(t *testing.T) {
@@ -92,24 +102,61 @@ func TestWriteConfigFile(t *testing.T) {
 }
 
 type fakeService struct {
-	checkErr      error
-	checkOpts     *application.CheckOptions
-	runErr        error
-	detectErr     error
-	detectCfg     application.Config
-	reportErr     error
-	ignoreErr     error
-	ignoreCfg     application.Config
-	ignoreDomains []domain.Domain
-	badgeErr      error
-	badgeResult   application.BadgeResult
-	trendErr      error
-	trendResult   application.TrendResult
-	recordErr     error
-	suggestErr    error
-	suggestResult application.SuggestResult
-	compareErr    error
-	compareResult application.CompareResult
+	checkErr       error
+	checkOpts      *application.CheckOptions
+	reportOpts     *application.ReportOptions
+	runErr         error
+	detectErr      error
+	detectCfg      application.Config
+	detectMerge    application.DetectMergeResult
+	reportErr      error
+	ignoreErr      error
+	ignoreCfg      application.Config
+	ignoreDomains  []domain.Domain
+	badgeErr       error
+	badgeResult    application.BadgeResult
+	trendErr       error
+	trendResult    application.TrendResult
+	forecastOpts   *application.ForecastOptions
+	forecastResult application.ForecastResult
+	goalsResult    application.GoalsResult
+	verifyResult   application.VerifyResult
+	auditCalls     *[]application.AuditOptions
+	explainOpts    *application.ExplainOptions
+	explainResult  application.ExplainResult
+	recordErr      error
+	suggestErr     error
+	suggestResult  application.SuggestResult
+	compareErr     error
+	compareResult  application.CompareResult
+	debtPlanErr    error
+	debtPlanResult application.DebtPlanResult
+	ratchetErr     error
+	ratchetResult  application.RatchetUpResult
+	gateErr        error
+	gateResult     domain.Gate
+	metricsErr     error
+	metricsResult  application.MetricsResult
+	blameOpts      *application.BlameOptions
+	blameResult    application.BlameResult
+	heatmapOpts    *application.HeatmapOptions
+	heatmapResult  application.HeatmapResult
+	patchOpts      *application.PatchReportOptions
+	patchReport    application.PatchReportResult
+	validateOpts   *application.ValidateProfileOptions
+	validation     application.ProfileValidation
+	orgOpts        *application.OrgReportOptions
+	orgReport      domain.OrgReport
+	scaffoldOpts   *application.ScaffoldOptions
+	scaffoldResult application.ScaffoldResult
+	annotateOpts   *application.AnnotateOptions
+	annotateResult application.AnnotateResult
+	diffConfigOpts *application.ConfigDiffOptions
+	configDiff     application.ConfigDiff
+	lintResult     application.LintResult
+	vetResult      application.VetResult
+	selectOpts     *application.SelectOptions
+	selection      domain.TestSelection
 }
 
 func (f fakeService) Check(_ context.Context, opts application.CheckOptions) error {
@@ -118,6 +165,18 @@ func (f fakeService) Check(_ context.Context, opts application.CheckOptions) err
 	}
 	return f.checkErr
 }
+func (f fakeService) Gate(_ context.Context, opts application.CheckOptions) (domain.Gate, error) {
+	if f.checkOpts != nil {
+		*f.checkOpts = opts
+	}
+	if f.gateErr != nil {
+		return domain.Gate{}, f.gateErr
+	}
+	return f.gateResult, nil
+}
+func (f fakeService) DetectMerge(_ context.Context, _ application.DetectOptions) (application.DetectMergeResult, error) {
+	return f.detectMerge, f.detectErr
+}
 func (f fakeService) RunOnly(_ context.Context, _ application.RunOnlyOptions) error {
 	return f.runErr
 }
@@ -127,7 +186,12 @@ func (f fakeService) Detect(_ context.Context, _ application.DetectOptions) (app
 	}
 	return f.detectCfg, nil
 }
-func (f fakeService) Report(_ context.Context, _ application.ReportOptions) error { return f.reportErr }
+func (f fakeService) Report(_ context.Context, opts application.ReportOptions) error {
+	if f.reportOpts != nil {
+		*f.reportOpts = opts
+	}
+	return f.reportErr
+}
 func (f fakeService) Ignore(_ context.Context, _ application.IgnoreOptions) (application.Config, []domain.Domain, error) {
 	if f.ignoreErr != nil {
 		return application.Config{}, nil, f.ignoreErr
@@ -161,6 +225,120 @@ func (f fakeService) Watch(_ context.Context, _ application.WatchOptions, _ appl
 func (f fakeService) Debt(_ context.Context, _ application.DebtOptions) (application.DebtResult, error) {
 	return application.DebtResult{HealthScore: 100}, nil
 }
+func (f fakeService) Metrics(_ context.Context, _ application.MetricsOptions) (application.MetricsResult, error) {
+	return f.metricsResult, f.metricsErr
+}
+func (f fakeService) DebtPlan(_ context.Context, _ application.DebtPlanOptions, _ application.HistoryStore, _ application.DebtPlanStore) (application.DebtPlanResult, error) {
+	if f.debtPlanErr != nil {
+		return application.DebtPlanResult{}, f.debtPlanErr
+	}
+	return f.debtPlanResult, nil
+}
+func (f fakeService) RatchetUp(_ context.Context, _ application.RatchetUpOptions, _ application.HistoryStore) (application.RatchetUpResult, error) {
+	if f.ratchetErr != nil {
+		return application.RatchetUpResult{}, f.ratchetErr
+	}
+	return f.ratchetResult, nil
+}
+func (f fakeService) Heatmap(_ context.Context, opts application.HeatmapOptions) (application.HeatmapResult, error) {
+	if f.heatmapOpts != nil {
+		*f.heatmapOpts = opts
+	}
+	return f.heatmapResult, nil
+}
+
+func (f fakeService) PatchReport(_ context.Context, opts application.PatchReportOptions) (application.PatchReportResult, error) {
+	if f.patchOpts != nil {
+		*f.patchOpts = opts
+	}
+	return f.patchReport, nil
+}
+
+func (f fakeService) ValidateProfile(_ context.Context, opts application.ValidateProfileOptions) (application.ProfileValidation, error) {
+	if f.validateOpts != nil {
+		*f.validateOpts = opts
+	}
+	return f.validation, nil
+}
+
+func (f fakeService) OrgReport(_ context.Context, opts application.OrgReportOptions, _ application.SnapshotSource) (domain.OrgReport, error) {
+	if f.orgOpts != nil {
+		*f.orgOpts = opts
+	}
+	return f.orgReport, nil
+}
+
+func (f fakeService) Forecast(_ context.Context, opts application.ForecastOptions, _ application.HistoryStore) (application.ForecastResult, error) {
+	if f.forecastOpts != nil {
+		*f.forecastOpts = opts
+	}
+	return f.forecastResult, nil
+}
+
+func (f fakeService) Goals(_ context.Context, _ application.GoalsOptions, _ application.HistoryStore) (application.GoalsResult, error) {
+	return f.goalsResult, nil
+}
+
+func (f fakeService) Verify(_ context.Context, _ application.VerifyOptions, _ application.HistoryStore) (application.VerifyResult, error) {
+	return f.verifyResult, nil
+}
+
+func (f fakeService) ExplainFailure(_ context.Context, opts application.ExplainOptions) (application.ExplainResult, error) {
+	if f.explainOpts != nil {
+		*f.explainOpts = opts
+	}
+	return f.explainResult, nil
+}
+
+func (f fakeService) AuditConfig(_ context.Context, opts application.AuditOptions, _ application.AuditLog) (*application.AuditEntry, error) {
+	if f.auditCalls != nil {
+		*f.auditCalls = append(*f.auditCalls, opts)
+	}
+	return nil, nil
+}
+
+func (f fakeService) SelectTests(_ context.Context, opts application.SelectOptions, _ application.TestProfileSource) (domain.TestSelection, error) {
+	if f.selectOpts != nil {
+		*f.selectOpts = opts
+	}
+	return f.selection, nil
+}
+
+func (f fakeService) Scaffold(_ context.Context, opts application.ScaffoldOptions, _ application.TestScaffolder) (application.ScaffoldResult, error) {
+	if f.scaffoldOpts != nil {
+		*f.scaffoldOpts = opts
+	}
+	return f.scaffoldResult, nil
+}
+
+func (f fakeService) Annotate(_ context.Context, opts application.AnnotateOptions, _ application.SourceAnnotator) (application.AnnotateResult, error) {
+	if f.annotateOpts != nil {
+		*f.annotateOpts = opts
+	}
+	return f.annotateResult, nil
+}
+
+func (f fakeService) DiffConfig(_ context.Context, opts application.ConfigDiffOptions, _ application.ConfigSource) (application.ConfigDiff, error) {
+	if f.diffConfigOpts != nil {
+		*f.diffConfigOpts = opts
+	}
+	return f.configDiff, nil
+}
+
+func (f fakeService) Lint(_ context.Context, _ application.LintOptions) (application.LintResult, error) {
+	return f.lintResult, nil
+}
+
+func (f fakeService) Vet(_ context.Context, _ application.VetOptions) (application.VetResult, error) {
+	return f.vetResult, nil
+}
+func (f fakeService) Blame(_ context.Context, opts application.BlameOptions) (application.BlameResult, error) {
+	if f.blameOpts != nil {
+		*f.blameOpts = opts
+	}
+	return f.blameResult, nil
+}
+
 func (f fakeService) Compare(_ context.Context, _ application.CompareOptions) (application.CompareResult, error) {
 	if f.compareErr != nil {
 		return application.CompareResult{}, f.compareErr
@@ -219,6 +397,166 @@ func TestRunCheckUsesExistingProfile(t *testing.T) {
 	}
 }
 
+func TestRunCheckDiffBase(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.CheckOptions
+	code := Run([]string{"coverctl", "check", "--diff-base", "auto"}, &out, &out, fakeService{checkOpts: &opts})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if opts.DiffBase != "auto" {
+		t.Fatalf("expected DiffBase auto, got %q", opts.DiffBase)
+	}
+}
+
+func TestRunCheckFailFast(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.CheckOptions
+	code := Run([]string{"coverctl", "check", "--fail-fast"}, &out, &out, fakeService{checkOpts: &opts})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !opts.FailFast {
+		t.Fatal("expected FailFast to be set")
+	}
+}
+
+func TestRunCheckIsolateDomains(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.CheckOptions
+	code := Run([]string{"coverctl", "check", "--isolate-domains"}, &out, &out, fakeService{checkOpts: &opts})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !opts.IsolateDomains {
+		t.Fatal("expected IsolateDomains to be set")
+	}
+}
+
+func TestRunCheckTopFiles(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.CheckOptions
+	code := Run([]string{"coverctl", "check", "--top-files", "5"}, &out, &out, fakeService{checkOpts: &opts})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if opts.TopFiles != 5 {
+		t.Fatalf("expected TopFiles 5, got %d", opts.TopFiles)
+	}
+}
+
+func TestRunCheckPruneStale(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.CheckOptions
+	code := Run([]string{"coverctl", "check", "--prune-stale"}, &out, &out, fakeService{checkOpts: &opts})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !opts.PruneStale {
+		t.Fatal("expected PruneStale to be set")
+	}
+}
+
+func TestRunCheckMaxProfileAge(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.CheckOptions
+	code := Run([]string{"coverctl", "check", "--from-profile", "--max-profile-age", "30m", "--stale-profile", "warn"}, &out, &out, fakeService{checkOpts: &opts})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if opts.MaxProfileAge != "30m" || opts.StaleProfile != application.StaleProfileWarn {
+		t.Fatalf("expected profile age options, got %q %q", opts.MaxProfileAge, opts.StaleProfile)
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "check", "--max-profile-age", "soon"}, &out, &out, fakeService{}); code != 2 || !strings.Contains(out.String(), "invalid --max-profile-age") {
+		t.Fatalf("expected exit 2 for an invalid age, got %d: %s", code, out.String())
+	}
+	out.Reset()
+	if code := Run([]string{"coverctl", "check", "--stale-profile", "ignore"}, &out, &out, fakeService{}); code != 2 || !strings.Contains(out.String(), "invalid --stale-profile") {
+		t.Fatalf("expected exit 2 for an invalid action, got %d: %s", code, out.String())
+	}
+}
+
+func TestProgressWriter(t *testing.T) {
+	var buf bytes.Buffer
+	if w := progressWriter(&buf, GlobalOptions{}, application.OutputText, false); w != nil {
+		t.Fatal("expected no progress when stderr is not a terminal")
+	}
+	file, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if w := progressWriter(file, GlobalOptions{}, application.OutputText, false); w != nil {
+		t.Fatal("expected no progress when stderr is a regular file")
+	}
+
+	var opts application.CheckOptions
+	Run([]string{"coverctl", "check"}, &buf, &buf, fakeService{checkOpts: &opts})
+	if opts.Progress != nil {
+		t.Fatal("expected check to leave Progress unset off a terminal")
+	}
+}
+
+func TestRunReportTiming(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.ReportOptions
+	if code := Run([]string{"coverctl", "report"}, &out, &out, fakeService{reportOpts: &opts}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if opts.TimingStore != nil {
+		t.Fatal("expected no timing store without --timing")
+	}
+	if code := Run([]string{"coverctl", "report", "--timing"}, &out, &out, fakeService{reportOpts: &opts}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if opts.TimingStore == nil {
+		t.Fatal("expected --timing to set a timing store")
+	}
+}
+
+func TestRunCheckHTMLOptions(t *testing.T) {
+	var out bytes.Buffer
+	var opts application.CheckOptions
+	code := Run([]string{"coverctl", "check", "-o", "html", "--title", "api", "--self-contained"}, &out, &out, fakeService{checkOpts: &opts})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	want := application.HTMLOptions{Title: "api", SelfContained: true}
+	if opts.HTML != want {
+		t.Fatalf("expected HTML options %+v, got %+v", want, opts.HTML)
+	}
+}
+
+func TestRunCheckSummary(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+
+	t.Run("defaults to GITHUB_STEP_SUMMARY", func(t *testing.T) {
+		t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+		var out bytes.Buffer
+		var opts application.CheckOptions
+		if code := Run([]string{"coverctl", "check"}, &out, &out, fakeService{checkOpts: &opts}); code != 0 {
+			t.Fatalf("expected exit 0, got %d", code)
+		}
+		if opts.Summary == nil {
+			t.Fatal("expected summary writer from GITHUB_STEP_SUMMARY")
+		}
+	})
+
+	t.Run("no-summary disables it", func(t *testing.T) {
+		t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+		var out bytes.Buffer
+		var opts application.CheckOptions
+		if code := Run([]string{"coverctl", "check", "--no-summary"}, &out, &out, fakeService{checkOpts: &opts}); code != 0 {
+			t.Fatalf("expected exit 0, got %d", code)
+		}
+		if opts.Summary != nil {
+			t.Fatal("expected no summary writer")
+		}
+	})
+}
+
 func TestRunDetectWritesConfig(t *testing.T) {
 	var out bytes.Buffer
 	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
@@ -234,6 +572,45 @@ func TestRunDetectWritesConfig(t *testing.T) {
 	}
 }
 
+func TestRunDetectMergeKeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+	original := "version: 1\npolicy:\n  default:\n    min: 80\n  domains:\n    - name: core # hand-tuned\n      match: [\"./internal/core/...\"]\n      min: 92\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	svc := fakeService{detectMerge: application.DetectMergeResult{
+		Added: []domain.Domain{{Name: "api", Match: []string{"./internal/api/..."}}},
+		Stale: []string{"legacy"},
+	}}
+
+	var out, errOut bytes.Buffer
+	if code := Run([]string{"coverctl", "detect", "--merge", "--config", path}, &out, &errOut, svc); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# hand-tuned", "min: 92", "- name: api", "./internal/api/..."} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("expected %q in merged config:\n%s", want, got)
+		}
+	}
+	if !strings.Contains(errOut.String(), "domain legacy matches no directories") {
+		t.Errorf("expected stale domain warning, got: %s", errOut.String())
+	}
+	if !strings.Contains(out.String(), "Added domain api") {
+		t.Errorf("expected added domain message, got: %s", out.String())
+	}
+}
+
+func TestRunDetectMergeRejectsForce(t *testing.T) {
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "detect", "--merge", "--force"}, &out, &out, fakeService{}); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+}
+
 func TestRunReportError(t *testing.T) {
 	var out bytes.Buffer
 	code := Run([]string{"coverctl", "report"}, &out, &out, fakeService{reportErr: errSentinel})
@@ -242,6 +619,60 @@ func TestRunReportError(t *testing.T) {
 	}
 }
 
+func TestRunReportJSONErrorCode(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := application.WithErrorCode(application.ErrCodeNoDomains, errors.New("no domains configured"))
+	code := Run([]string{"coverctl", "report", "-o", "json"}, &stdout, &stderr, fakeService{reportErr: err})
+	if code != 3 {
+		t.Fatalf("expected exit 3, got %d", code)
+	}
+	var payload struct {
+		Error       string `json:"error"`
+		ErrorCode   string `json:"errorCode"`
+		Remediation string `json:"remediation"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("expected JSON error on stdout: %v\n%s", err, stdout.String())
+	}
+	if payload.ErrorCode != "ERR_NO_DOMAINS" || payload.Remediation == "" || payload.Error != "no domains configured" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if !strings.Contains(stderr.String(), "ERR_NO_DOMAINS: ") {
+		t.Fatalf("expected code and hint on stderr, got %q", stderr.String())
+	}
+
+	stdout.Reset()
+	Run([]string{"coverctl", "report"}, &stdout, &stderr, fakeService{reportErr: err})
+	if stdout.Len() != 0 {
+		t.Fatalf("expected no stdout for text output, got %q", stdout.String())
+	}
+}
+
+func TestRunCheckJSONTestFailures(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	failures := &application.TestFailuresError{
+		Failures: []application.TestFailure{{Package: "example.com/a", Kind: application.TestFailurePanic, Tests: []string{"TestX"}}},
+		Err:      errors.New("exit status 2"),
+	}
+	err := application.WithErrorCode(application.ErrCodeRunnerFailed, fmt.Errorf("go test failed: %w", failures))
+	if code := Run([]string{"coverctl", "check", "-o", "json"}, &stdout, &stderr, fakeService{checkErr: err}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	var payload struct {
+		Error        string                    `json:"error"`
+		TestFailures []application.TestFailure `json:"testFailures"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("expected JSON error on stdout: %v\n%s", err, stdout.String())
+	}
+	if len(payload.TestFailures) != 1 || payload.TestFailures[0].Kind != application.TestFailurePanic {
+		t.Fatalf("unexpected test failures %+v", payload.TestFailures)
+	}
+	if !strings.Contains(payload.Error, "example.com/a: TestX panicked") {
+		t.Fatalf("expected the failing test in the error, got %q", payload.Error)
+	}
+}
+
 func TestRunReportSuccess(t *testing.T) {
 	var out bytes.Buffer
 	code := Run([]string{"coverctl", "report"}, &out, &out, fakeService{})
@@ -296,6 +727,65 @@ func TestRunInitCreatesFile(t *testing.T) {
 	}
 }
 
+func TestRunInitAnswersFile(t *testing.T) {
+	dir := t.TempDir()
+	answers := filepath.Join(dir, "answers.yaml")
+	if err := os.WriteFile(answers, []byte("default_min: 65\nrename:\n  module: core\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := initWizard
+	defer func() { initWizard = old }()
+	initWizard = func(cfg application.Config, stdout io.Writer, stdin io.Reader) (application.Config, bool, error) {
+		t.Fatal("wizard must not run with --answers")
+		return cfg, false, nil
+	}
+	var out bytes.Buffer
+	path := filepath.Join(dir, ".coverctl.yaml")
+	code := Run([]string{"coverctl", "init", "--config", path, "--answers", answers}, &out, &out, fakeService{detectCfg: minimalConfig()})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected config file: %v", err)
+	}
+	if !strings.Contains(string(data), "name: core") || !strings.Contains(string(data), "65") {
+		t.Fatalf("answers not applied:\n%s", data)
+	}
+
+	if err := os.WriteFile(answers, []byte("remove: [nope]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	code = Run([]string{"coverctl", "init", "--config", path, "--force", "--answers", answers}, &out, &out, fakeService{detectCfg: minimalConfig()})
+	if code != 2 {
+		t.Fatalf("expected exit 2 for invalid answers, got %d", code)
+	}
+}
+
+func TestRunInitPreset(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+	path := filepath.Join(dir, ".coverctl.yaml")
+	code := Run([]string{"coverctl", "init", "--config", path, "--no-interactive", "--preset", "strict"}, &out, &out, fakeService{detectCfg: minimalConfig()})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected config file: %v", err)
+	}
+	for _, want := range []string{"min: 85", "warn: 90", "'**/*.pb.go'", "enabled: true"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("expected %q in preset config:\n%s", want, data)
+		}
+	}
+
+	code = Run([]string{"coverctl", "init", "--config", path, "--force", "--no-interactive", "--preset", "lenient"}, &out, &out, fakeService{detectCfg: minimalConfig()})
+	if code != 2 {
+		t.Fatalf("expected exit 2 for an unknown preset, got %d", code)
+	}
+}
+
 func TestRunInitInteractiveBranch(t *testing.T) {
 	old := initWizard
 	defer func() { initWizard = old }()
@@ -467,6 +957,24 @@ func TestRunBadge(t *testing.T) {
 	}
 }
 
+func TestRunBadgeShowDelta(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "coverage.svg")
+	delta := 0.6
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "badge", "--output", outputPath, "--show-delta", "--history", filepath.Join(dir, "history.json")}, &out, &out, fakeService{badgeResult: application.BadgeResult{Percent: 84, Delta: &delta}})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read badge: %v", err)
+	}
+	if !strings.Contains(string(data), "84% ▲0.6") {
+		t.Fatalf("expected delta annotation in badge:\n%s", data)
+	}
+}
+
 func TestRunBadgeError(t *testing.T) {
 	dir := t.TempDir()
 	outputPath := filepath.Join(dir, "coverage.svg")
@@ -495,36 +1003,643 @@ func TestRunTrend(t *testing.T) {
 	if !strings.Contains(out.String(), "Coverage Trend") {
 		t.Fatalf("expected trend output, got: %s", out.String())
 	}
-}
 
-func TestRunTrendError(t *testing.T) {
-	var out bytes.Buffer
-	code := Run([]string{"coverctl", "trend"}, &out, &out, fakeService{trendErr: errSentinel})
-	if code != 3 {
-		t.Fatalf("expected exit 3, got %d", code)
+	out.Reset()
+	trendResult.FileRegressions = []domain.FileRegression{{File: "internal/core/a.go", Previous: 90, Current: 60, Delta: -30}}
+	if code := Run([]string{"coverctl", "trend"}, &out, &out, fakeService{trendResult: trendResult}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), "internal/core/a.go: 90.0% ↓ 60.0% (-30.0%)") {
+		t.Fatalf("expected file regression, got: %s", out.String())
 	}
-}
 
-func TestRunRecord(t *testing.T) {
-	var out bytes.Buffer
-	code := Run([]string{"coverctl", "record"}, &out, &out, fakeService{})
-	if code != 0 {
+	var stdout, stderr bytes.Buffer
+	trendResult.Anomalies = []domain.Anomaly{{Kind: domain.AnomalyCoverageDrop, Domain: "core", Previous: 90, Current: 50}}
+	if code := Run([]string{"coverctl", "trend"}, &stdout, &stderr, fakeService{trendResult: trendResult}); code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
-	if !strings.Contains(out.String(), "Coverage recorded") {
-		t.Fatalf("expected record success message, got: %s", out.String())
+	if !strings.Contains(stderr.String(), "Warning: domain core dropped from 90.0% to 50.0%") {
+		t.Fatalf("expected anomaly warning, got: %s", stderr.String())
 	}
 }
 
-func TestRunRecordError(t *testing.T) {
+func TestRunForecast(t *testing.T) {
+	eta := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	result := application.ForecastResult{
+		Horizon:  5,
+		Lookback: 10,
+		Domains: []domain.DomainForecast{
+			{Domain: "core", Entries: 4, Current: 72, Predicted: 82, PerEntry: 2, Confidence: 95, Min: 80, ReachesMin: &eta},
+			{Domain: "api", Entries: 4, Current: 85, Predicted: 84, PerEntry: -0.2, Confidence: 99, Min: 80, Met: true},
+			{Domain: "cli", Entries: 4, Current: 60, Predicted: 58, PerEntry: -0.4, Confidence: 90, Min: 80},
+		},
+		Skipped: []string{"new"},
+	}
+	var opts application.ForecastOptions
 	var out bytes.Buffer
-	code := Run([]string{"coverctl", "record"}, &out, &out, fakeService{recordErr: errSentinel})
-	if code != 3 {
-		t.Fatalf("expected exit 3, got %d", code)
+	if code := Run([]string{"coverctl", "forecast", "--horizon", "3"}, &out, &out, fakeService{forecastOpts: &opts, forecastResult: result}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if opts.Horizon != 3 || opts.Lookback != 10 {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+	for _, want := range []string{"2026-11-02", "met", "not at current trend", "Not enough history to forecast: new"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, out.String())
+		}
 	}
-}
 
-func TestRunSuggest(t *testing.T) {
+	out.Reset()
+	if code := Run([]string{"coverctl", "forecast", "-o", "json"}, &out, &out, fakeService{forecastResult: result}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), `"reachesMin": "2026-11-02T00:00:00Z"`) {
+		t.Fatalf("expected JSON output, got %s", out.String())
+	}
+	if code := Run([]string{"coverctl", "forecast", "--horizon", "0"}, &out, &out, fakeService{}); code != 2 {
+		t.Fatalf("expected exit 2 for a zero horizon, got %d", code)
+	}
+}
+
+func TestRunGoals(t *testing.T) {
+	eta := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	result := application.GoalsResult{Goals: []domain.GoalProgress{
+		{Domain: "core", Target: 90, By: "2026-Q3", Start: 70, Current: 80, Progress: 50, PerEntry: 1, ReachesTarget: &eta, Status: domain.GoalOffTrack},
+		{Domain: "api", Target: 80, By: "2026-12-01", Start: 70, Current: 82, Progress: 100, Status: domain.GoalMet},
+	}}
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "goals"}, &out, &out, fakeService{goalsResult: result}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	for _, want := range []string{"2026-Q3", "off-track", "2026-11-02", "met"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "goals"}, &out, &out, fakeService{}); code != 0 || !strings.Contains(out.String(), "No goals configured") {
+		t.Fatalf("expected a hint without goals, got %d: %s", code, out.String())
+	}
+}
+
+func TestRunTrendFile(t *testing.T) {
+	result := application.TrendResult{
+		Current:  60,
+		Previous: 90,
+		Trend:    domain.Trend{Direction: domain.TrendDown, Delta: -30},
+		File:     "internal/core/a.go",
+		FileTrend: []domain.FileTrendPoint{
+			{Timestamp: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), Commit: "abcdef123", Percent: 90, Covered: 9, Total: 10, Trend: domain.Trend{Direction: domain.TrendStable}},
+			{Timestamp: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), Percent: 60, Covered: 6, Total: 10, Trend: domain.Trend{Direction: domain.TrendDown, Delta: -30}},
+		},
+	}
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "trend", "--file", "internal/core/a.go"}, &out, &out, fakeService{trendResult: result}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	for _, want := range []string{"Coverage Trend for internal/core/a.go: 90.0% ↓ 60.0%", "2026-05-01  abcdef1", "6/10  ↓ -30.0%"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "trend", "--file", "a.go", "-o", "json"}, &out, &out, fakeService{trendResult: result}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), `"file": "internal/core/a.go"`) {
+		t.Fatalf("expected JSON output, got %s", out.String())
+	}
+	if code := Run([]string{"coverctl", "trend", "--file", "a.go", "-o", "csv"}, &out, &out, fakeService{trendResult: result}); code != 2 {
+		t.Fatalf("expected exit 2 for csv, got %d", code)
+	}
+}
+
+func TestRunTrendCSV(t *testing.T) {
+	var out bytes.Buffer
+	trendResult := application.TrendResult{
+		Entries: []domain.HistoryEntry{{
+			Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Overall:   80,
+			Domains:   map[string]domain.DomainEntry{"core": {Percent: 75.5}},
+		}},
+	}
+	code := Run([]string{"coverctl", "trend", "-o", "tsv"}, &out, &out, fakeService{trendResult: trendResult})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	want := "timestamp\toverall\tcore\n2026-01-02T03:04:05Z\t80.00\t75.50\n"
+	if out.String() != want {
+		t.Fatalf("expected %q, got %q", want, out.String())
+	}
+}
+
+func TestRunTrendError(t *testing.T) {
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "trend"}, &out, &out, fakeService{trendErr: errSentinel})
+	if code != 3 {
+		t.Fatalf("expected exit 3, got %d", code)
+	}
+}
+
+func TestRunDebtPlan(t *testing.T) {
+	onTrack := application.DebtPlanResult{
+		Plan:     domain.DebtPlan{Domains: []domain.DebtPlanDomain{{Name: "core", Baseline: 60, Required: 80, WeeklyGain: 2}}},
+		Progress: []domain.DebtPlanProgress{{Domain: "core", Expected: 70, Actual: 72, Required: 80, OnTrack: true}},
+		OnTrack:  true,
+	}
+	offTrack := onTrack
+	offTrack.Progress = []domain.DebtPlanProgress{{Domain: "core", Expected: 70, Actual: 62, Required: 80}}
+	offTrack.OnTrack = false
+
+	tests := []struct {
+		name     string
+		args     []string
+		svc      fakeService
+		wantCode int
+		wantOut  string
+	}{
+		{"on track", []string{"debt", "plan", "--target-date", "2025-12-31"}, fakeService{debtPlanResult: onTrack}, 0, "Plan is on track"},
+		{"off track", []string{"debt", "plan"}, fakeService{debtPlanResult: offTrack}, 1, "off track"},
+		{"bad date", []string{"debt", "plan", "--target-date", "31/12/2025"}, fakeService{}, 2, "invalid --target-date"},
+		{"service error", []string{"debt", "plan"}, fakeService{debtPlanErr: errSentinel}, 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			code := Run(append([]string{"coverctl"}, tt.args...), &out, &out, tt.svc)
+			if code != tt.wantCode {
+				t.Fatalf("expected exit %d, got %d: %s", tt.wantCode, code, out.String())
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Fatalf("expected output to contain %q, got: %s", tt.wantOut, out.String())
+			}
+		})
+	}
+}
+
+func TestRunVet(t *testing.T) {
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "vet"}, &out, &out, fakeService{}); code != 0 || out.Len() != 0 {
+		t.Fatalf("expected exit 0 and no output, got %d: %q", code, out.String())
+	}
+	svc := fakeService{vetResult: application.VetResult{Files: 2, Diagnostics: []domain.Diagnostic{
+		{File: "internal/core/a.go", Line: 42, Column: 1, Rule: domain.DiagnosticFileRule, Message: "coverage 50.0% is below the 80.0% file minimum"},
+	}}}
+	if code := Run([]string{"coverctl", "vet"}, &out, &out, svc); code != 1 {
+		t.Fatalf("expected exit 1 with a diagnostic, got %d", code)
+	}
+	if got := out.String(); got != "internal/core/a.go:42:1: coverage 50.0% is below the 80.0% file minimum (coverctl)\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+	out.Reset()
+	Run([]string{"coverctl", "vet", "-o", "json"}, &out, &out, svc)
+	if !strings.Contains(out.String(), `"rule": "file-min"`) || !strings.Contains(out.String(), `"line": 42`) {
+		t.Fatalf("unexpected JSON %s", out.String())
+	}
+}
+
+func TestRunBlame(t *testing.T) {
+	result := application.BlameResult{
+		BlameSummary: domain.BlameSummary{
+			Uncovered: 431,
+			Files:     12,
+			Authors:   []domain.BlameAuthor{{Author: "Alice", Email: "alice@example.com", Uncovered: 431, Files: 12, Commits: 9}},
+			Commits:   []domain.BlameCommit{{Commit: "0123456789abcdef", Author: "Alice", Uncovered: 120, Files: 3, Summary: "Add retries"}},
+		},
+		TotalAuthors: 1,
+		TotalCommits: 1,
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		var got application.BlameOptions
+		code := Run([]string{"coverctl", "blame", "-d", "core", "--limit", "5"}, &out, &out, fakeService{blameOpts: &got, blameResult: result})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		if got.Limit != 5 || len(got.Domains) != 1 || got.Domains[0] != "core" {
+			t.Fatalf("unexpected options: %+v", got)
+		}
+		for _, want := range []string{"Alice <alice@example.com>: 431 uncovered lines across 12 files (9 commits)", "0123456", "Add retries"} {
+			if !strings.Contains(out.String(), want) {
+				t.Fatalf("expected %q in output:\n%s", want, out.String())
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run([]string{"coverctl", "blame", "-o", "json"}, &out, &out, fakeService{blameResult: result}); code != 0 {
+			t.Fatalf("expected exit 0, got %d", code)
+		}
+		if !strings.Contains(out.String(), `"authors"`) || !strings.Contains(out.String(), `"totalAuthors": 1`) {
+			t.Fatalf("expected JSON summary, got %s", out.String())
+		}
+	})
+
+	t.Run("rejects negative limit", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run([]string{"coverctl", "blame", "--limit", "-1"}, &out, &out, fakeService{}); code != 2 {
+			t.Fatalf("expected exit 2, got %d", code)
+		}
+	})
+}
+
+func TestRunAggregate(t *testing.T) {
+	var got application.OrgReportOptions
+	svc := fakeService{orgOpts: &got, orgReport: domain.OrgReport{
+		Percent:  80,
+		Passed:   true,
+		Services: []domain.ServiceCoverage{{Service: "payments", Percent: 80, Passed: true}},
+	}}
+
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "aggregate", "--inputs", "reports/*.json", "-o", "json", "ledger/history.json"}, &out, &out, svc)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if len(got.Inputs) != 2 || got.Inputs[0] != "reports/*.json" || got.Inputs[1] != "ledger/history.json" {
+		t.Fatalf("unexpected inputs: %+v", got.Inputs)
+	}
+	if !strings.Contains(out.String(), `"service": "payments"`) {
+		t.Fatalf("expected JSON output, got %s", out.String())
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "aggregate"}, &out, &out, svc); code != 2 {
+		t.Fatalf("expected exit 2 without inputs, got %d", code)
+	}
+}
+
+func TestRunAnnotate(t *testing.T) {
+	var out, errOut bytes.Buffer
+	var got application.AnnotateOptions
+	result := application.AnnotateResult{DryRun: true, Files: []application.AnnotatedFile{
+		{File: "internal/gen/a.go", Status: application.AnnotateAdded, Line: "// coverctl:ignore"},
+		{File: "internal/gen/b.go", Status: application.AnnotatePresent},
+	}}
+	code := Run([]string{"coverctl", "annotate", "--match", "internal/gen/*.go", "--dry-run", "internal/old.go"}, &out, &errOut, fakeService{annotateOpts: &got, annotateResult: result})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+	}
+	if !got.DryRun || len(got.Match) != 1 || len(got.Files) != 1 || got.Files[0] != "internal/old.go" {
+		t.Fatalf("unexpected options: %+v", got)
+	}
+	if !strings.Contains(out.String(), "Would annotate internal/gen/a.go") || !strings.Contains(out.String(), "1 of 2 files would be annotated") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "annotations.enabled") {
+		t.Fatalf("expected disabled-annotations warning, got: %s", errOut.String())
+	}
+}
+
+func TestRunDiffConfig(t *testing.T) {
+	var out bytes.Buffer
+	var got application.ConfigDiffOptions
+	svc := fakeService{diffConfigOpts: &got, configDiff: application.ConfigDiff{Base: "main:.coverctl.yaml", Head: ".coverctl.yaml", Changes: []application.ConfigChange{
+		{Kind: application.ConfigRaised, Subject: "default min", Before: "70.0%", After: "75.0%"},
+	}}}
+	code := Run([]string{"coverctl", "diff-config", "-o", "markdown", "main:.coverctl.yaml"}, &out, &out, svc)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if got.Base != "main:.coverctl.yaml" || got.Head != ".coverctl.yaml" {
+		t.Fatalf("unexpected options: %+v", got)
+	}
+	if !strings.Contains(out.String(), "| ⬆️ raised | default min | 70.0% | 75.0% |") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	if code := Run([]string{"coverctl", "diff-config"}, &out, &out, svc); code != 2 {
+		t.Fatalf("expected exit 2 without a base, got %d", code)
+	}
+}
+
+func TestRunLint(t *testing.T) {
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "lint"}, &out, &out, fakeService{}); code != 0 || !strings.Contains(out.String(), "No config problems") {
+		t.Fatalf("expected clean lint, got %d: %s", code, out.String())
+	}
+	out.Reset()
+	svc := fakeService{lintResult: application.LintResult{Findings: []application.LintFinding{
+		{Rule: application.LintDeadDomain, Subject: "old", Message: "domain old matches no packages (./old/...)"},
+	}}}
+	if code := Run([]string{"coverctl", "lint"}, &out, &out, svc); code != 1 {
+		t.Fatalf("expected exit 1 with findings, got %d", code)
+	}
+	if !strings.Contains(out.String(), "dead-domain: domain old matches no packages") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
+
+func TestRunScaffold(t *testing.T) {
+	root := t.TempDir()
+	result := application.ScaffoldResult{ModuleRoot: root, Files: []application.ScaffoldFile{{
+		Source:    "a.go",
+		TestFile:  "a_scaffold_test.go",
+		Functions: []string{"Parse()"},
+		Skipped:   []string{"Map(): generic functions need explicit type arguments"},
+		Content:   []byte("package a\n"),
+	}}}
+
+	t.Run("prints skeletons", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		var got application.ScaffoldOptions
+		code := Run([]string{"coverctl", "scaffold", "-d", "core"}, &out, &errOut, fakeService{scaffoldOpts: &got, scaffoldResult: result})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+		}
+		if len(got.Domains) != 1 || got.Domains[0] != "core" {
+			t.Fatalf("unexpected options: %+v", got)
+		}
+		if out.String() != "// a_scaffold_test.go\npackage a\n" || !strings.Contains(errOut.String(), "skipped Map()") {
+			t.Fatalf("unexpected output %q / %q", out.String(), errOut.String())
+		}
+	})
+
+	t.Run("writes skeletons without overwriting", func(t *testing.T) {
+		var out bytes.Buffer
+		svc := fakeService{scaffoldResult: result}
+		if code := Run([]string{"coverctl", "scaffold", "--write"}, &out, &out, svc); code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		path := filepath.Join(root, "a_scaffold_test.go")
+		if data, err := os.ReadFile(path); err != nil || string(data) != "package a\n" {
+			t.Fatalf("expected skeleton written, got %q (%v)", data, err)
+		}
+		if err := os.WriteFile(path, []byte("edited"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		out.Reset()
+		if code := Run([]string{"coverctl", "scaffold", "--write"}, &out, &out, svc); code != 0 {
+			t.Fatalf("expected exit 0, got %d", code)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "edited" || !strings.Contains(out.String(), "--force") {
+			t.Fatalf("expected existing file kept, got %q: %s", data, out.String())
+		}
+		if code := Run([]string{"coverctl", "scaffold", "--write", "--force"}, &out, &out, svc); code != 0 {
+			t.Fatalf("expected exit 0, got %d", code)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "package a\n" {
+			t.Fatalf("expected skeleton overwritten, got %q", data)
+		}
+	})
+}
+
+func TestRunSelect(t *testing.T) {
+	var got application.SelectOptions
+	svc := fakeService{selectOpts: &got, selection: domain.SelectTests(
+		[]string{"internal/core/a.go", "internal/api/h.go"},
+		map[string][]domain.TestRef{"internal/core/a.go": {{Package: "./internal/core", Name: "TestA"}}},
+	)}
+
+	var out, errOut bytes.Buffer
+	code := Run([]string{"coverctl", "select", "--changed", "--base", "origin/main", "--profiles-dir", ".cover/tests"}, &out, &errOut, svc)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+	}
+	if got.Base != "origin/main" || got.ProfilesDir != ".cover/tests" {
+		t.Fatalf("unexpected options: %+v", got)
+	}
+	want := "go test -run '^(TestA)$' ./internal/core\ngo test ./internal/api\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "no recorded test covers internal/api/h.go") {
+		t.Fatalf("expected unmapped warning, got %q", errOut.String())
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "select"}, &out, &out, svc); code != 2 {
+		t.Fatalf("expected exit 2 without --changed, got %d", code)
+	}
+}
+
+func TestRunHeatmap(t *testing.T) {
+	result := application.HeatmapResult{
+		Root:  domain.BuildHeatmap(map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 3, Total: 4}}),
+		Files: 1,
+	}
+
+	t.Run("writes file", func(t *testing.T) {
+		var out bytes.Buffer
+		var got application.HeatmapOptions
+		path := filepath.Join(t.TempDir(), "heatmap.html")
+		code := Run([]string{"coverctl", "heatmap", "-d", "core", "-o", path}, &out, &out, fakeService{heatmapOpts: &got, heatmapResult: result})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		if len(got.Domains) != 1 || got.Domains[0] != "core" {
+			t.Fatalf("unexpected options: %+v", got)
+		}
+		if !strings.Contains(out.String(), "Heatmap written to "+path+" (1 files, 75.0%)") {
+			t.Fatalf("unexpected output: %s", out.String())
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read heatmap: %v", err)
+		}
+		if !strings.Contains(string(data), "internal/core/a.go") {
+			t.Fatal("expected file in heatmap data")
+		}
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run([]string{"coverctl", "heatmap", "-o", "-", "--title", "api"}, &out, &out, fakeService{heatmapResult: result}); code != 0 {
+			t.Fatalf("expected exit 0, got %d", code)
+		}
+		if !strings.HasPrefix(out.String(), "<!DOCTYPE html>") || !strings.Contains(out.String(), "<title>api</title>") {
+			t.Fatalf("expected HTML on stdout, got %s", out.String())
+		}
+	})
+}
+
+func TestRunPatchReport(t *testing.T) {
+	changed := map[string][]domain.LineRange{"internal/core/a.go": {{Start: 1, End: 3}}}
+	lines := map[string]domain.LineCoverage{"internal/core/a.go": {1: 1, 2: 0}}
+	result := application.PatchReportResult{
+		Base:  "origin/main",
+		Patch: domain.EvaluatePatch(changed, lines, 0, domain.RoundingRound),
+		Files: domain.PatchFiles(changed, lines),
+	}
+
+	var out bytes.Buffer
+	var got application.PatchReportOptions
+	path := filepath.Join(t.TempDir(), "patch.html")
+	if code := Run([]string{"coverctl", "patch-report", "--base", "origin/main", "-o", path}, &out, &out, fakeService{patchOpts: &got, patchReport: result}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if got.Base != "origin/main" {
+		t.Fatalf("unexpected options: %+v", got)
+	}
+	if !strings.Contains(out.String(), "Patch report written to "+path+" (1 files, 1/2 changed lines covered, 50.0%)") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "internal/core/a.go") {
+		t.Fatalf("expected the file in the report (%v)", err)
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "patch-report", "-o", "-"}, &out, &out, fakeService{patchReport: result}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.HasPrefix(out.String(), "<!DOCTYPE html>") {
+		t.Fatalf("expected HTML on stdout, got %s", out.String())
+	}
+}
+
+func TestRunValidateProfile(t *testing.T) {
+	valid := application.ProfileValidation{
+		Profile:           "coverage.out",
+		ProfileInspection: application.ProfileInspection{Format: application.FormatGo, Files: 2, Statements: 20, Duplicates: 3},
+		Valid:             true,
+		Domains: []application.ProfileDomain{
+			{Domain: "core", Files: 2, Covered: 15, Total: 20, Percent: 75},
+			{Domain: "api"},
+		},
+		Unmatched: []string{"cmd/main.go"},
+	}
+	var out bytes.Buffer
+	var got application.ValidateProfileOptions
+	if code := Run([]string{"coverctl", "validate-profile", "coverage.out"}, &out, &out, fakeService{validateOpts: &got, validation: valid}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if got.ProfilePath != "coverage.out" {
+		t.Fatalf("unexpected options: %+v", got)
+	}
+	for _, want := range []string{"Profile: coverage.out (go)", "Duplicate blocks: 3", "75.0%", "no files from this profile", "cmd/main.go", "Valid"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+
+	invalid := application.ProfileValidation{
+		Profile:           ".cover/coverage.out",
+		ProfileInspection: application.ProfileInspection{Format: application.FormatGo, Errors: []string{"line 1: invalid coverage mode line"}},
+	}
+	out.Reset()
+	if code := Run([]string{"coverctl", "validate-profile"}, &out, &out, fakeService{validation: invalid}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "line 1: invalid coverage mode line") {
+		t.Fatalf("expected the format error, got:\n%s", out.String())
+	}
+}
+
+func TestRunRatchetUp(t *testing.T) {
+	min := 80.0
+	result := application.RatchetUpResult{
+		Days:    14,
+		Changes: []application.ThresholdChange{{Domain: "core", From: 80, To: 82, Sustained: 85}},
+		Config:  application.Config{Version: 1, Policy: domain.Policy{DefaultMin: 80, Domains: []domain.Domain{{Name: "core", Match: []string{"./..."}, Min: &min}}}},
+	}
+
+	t.Run("dry run leaves config untouched", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+		var out bytes.Buffer
+		code := Run([]string{"coverctl", "ratchet-up", "-c", path, "--dry-run"}, &out, &out, fakeService{ratchetResult: result})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		if !strings.Contains(out.String(), "Would raise 1 threshold") {
+			t.Fatalf("unexpected output: %s", out.String())
+		}
+		if _, err := os.Stat(path); err == nil {
+			t.Fatal("expected dry run not to write config")
+		}
+	})
+
+	t.Run("edits config in place and writes json summary", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+		original := "# team policy\nversion: 1\nextends: base.yaml\npolicy:\n  domains:\n    - name: core # business rules\n      match: [\"./...\"]\n      min: 80\n"
+		if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		inherited := result
+		inherited.Changes = append(append([]application.ThresholdChange{}, result.Changes...), application.ThresholdChange{Domain: "shared", From: 70, To: 71, Sustained: 75})
+		var out, errOut bytes.Buffer
+		var audits []application.AuditOptions
+		code := Run([]string{"coverctl", "ratchet-up", "-c", path, "-o", "json"}, &out, &errOut, fakeService{ratchetResult: inherited, auditCalls: &audits})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+		}
+		if !strings.Contains(out.String(), `"to": 82`) || strings.Contains(out.String(), `"shared"`) {
+			t.Fatalf("expected only the written change in the summary, got: %s", out.String())
+		}
+		if !strings.Contains(errOut.String(), "domain shared is not defined") {
+			t.Fatalf("expected a warning for the inherited domain, got: %s", errOut.String())
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := strings.Replace(original, "min: 80", "min: 82", 1)
+		if string(data) != want {
+			t.Fatalf("expected only the min to change:\n%s\ngot:\n%s", want, data)
+		}
+		if len(audits) != 2 || audits[0].Source != application.AuditManual || audits[1].Source != application.AuditRatchetUp {
+			t.Fatalf("expected manual edits then the ratchet to be audited, got %+v", audits)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run([]string{"coverctl", "ratchet-up"}, &out, &out, fakeService{ratchetErr: errSentinel}); code != 3 {
+			t.Fatalf("expected exit 3, got %d", code)
+		}
+	})
+}
+
+func TestRunRecord(t *testing.T) {
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "record"}, &out, &out, fakeService{})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), "Coverage recorded") {
+		t.Fatalf("expected record success message, got: %s", out.String())
+	}
+}
+
+func TestNotesFlags(t *testing.T) {
+	fs := newFlagSet("trend")
+	notes := notesFlags(fs, true)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if store, ok := notes.store("h.json").(*history.FileStore); !ok || store.Path != "h.json" {
+		t.Fatalf("expected the history file store by default, got %#v", notes.store("h.json"))
+	}
+	if err := fs.Parse([]string{"--notes-branch", "origin/main"}); err != nil {
+		t.Fatal(err)
+	}
+	if store, ok := notes.store("h.json").(*history.NotesStore); !ok || store.Branch != "origin/main" {
+		t.Fatalf("expected --notes-branch to select git notes, got %#v", notes.store("h.json"))
+	}
+
+	recordFlags := newFlagSet("record")
+	notesFlags(recordFlags, false)
+	if recordFlags.Lookup("notes-branch") != nil {
+		t.Fatal("expected record not to register --notes-branch")
+	}
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "record", "--notes"}, &out, &out, fakeService{}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+}
+
+func TestRunRecordError(t *testing.T) {
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "record"}, &out, &out, fakeService{recordErr: errSentinel})
+	if code != 3 {
+		t.Fatalf("expected exit 3, got %d", code)
+	}
+}
+
+func TestRunSuggest(t *testing.T) {
 	var out bytes.Buffer
 	suggestResult := application.SuggestResult{
 		Suggestions: []application.Suggestion{
@@ -549,6 +1664,40 @@ func TestRunSuggestError(t *testing.T) {
 	}
 }
 
+func TestRunSuggestWriteConfigStdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	suggestResult := application.SuggestResult{
+		Suggestions: []application.Suggestion{
+			{Domain: "core", CurrentPercent: 85.0, CurrentMin: 80.0, SuggestedMin: 83.0, Reason: "based on current coverage"},
+		},
+		Config: minimalConfig(),
+	}
+	code := Run([]string{"coverctl", "suggest", "--config", "-", "--write-config", "-"}, &stdout, &stderr, fakeService{suggestResult: suggestResult})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.HasPrefix(stdout.String(), "version: 1") || strings.Contains(stdout.String(), "Threshold Suggestions") {
+		t.Fatalf("expected only YAML on stdout, got: %s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "Threshold Suggestions") {
+		t.Fatalf("expected summary on stderr, got: %s", stderr.String())
+	}
+}
+
+func TestRunStdinConfigConflicts(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"coverctl", "suggest", "--config", "-", "--apply"}, &stdout, &stderr, fakeService{}); code != 2 || !strings.Contains(stderr.String(), "--write-config") {
+		t.Fatalf("expected suggest --config - --apply to be refused, got %d: %s", code, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("expected nothing on stdout, got %q", stdout.String())
+	}
+	stderr.Reset()
+	if code := Run([]string{"coverctl", "select", "--changed", "--config", "-", "--files-from", "-"}, &stdout, &stderr, fakeService{}); code != 2 || !strings.Contains(stderr.String(), "standard input") {
+		t.Fatalf("expected select to refuse two stdin readers, got %d: %s", code, stderr.String())
+	}
+}
+
 func TestRunSuggestWithStrategy(t *testing.T) {
 	var out bytes.Buffer
 	suggestResult := application.SuggestResult{
@@ -750,7 +1899,7 @@ func TestGlobalFlagsParsing(t *testing.T) {
 }
 
 func TestCompletion(t *testing.T) {
-	shells := []string{"bash", "zsh", "fish"}
+	shells := []string{"bash", "zsh", "fish", "powershell"}
 	for _, shell := range shells {
 		t.Run(shell, func(t *testing.T) {
 			var out bytes.Buffer
@@ -767,8 +1916,588 @@ func TestCompletion(t *testing.T) {
 
 func TestCompletionUnknownShell(t *testing.T) {
 	var out bytes.Buffer
-	code := Run([]string{"coverctl", "completion", "powershell"}, &out, &out, fakeService{})
+	code := Run([]string{"coverctl", "completion", "tcsh"}, &out, &out, fakeService{})
 	if code != 2 {
 		t.Fatalf("expected exit 2, got %d", code)
 	}
 }
+
+func TestCompletionListsCommandFlags(t *testing.T) {
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "completion", "bash"}, &out, &out, fakeService{}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	script := out.String()
+	for _, want := range []string{"init|i)", "--answers", "--no-interactive", "pr-comment", "serve doctor"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("bash completion missing %q", want)
+		}
+	}
+	if strings.Contains(script, "survey") {
+		t.Error("hidden command survey should not be completed")
+	}
+}
+
+func TestCommandFlagsFoldShorthands(t *testing.T) {
+	c, ok := findCommand("i")
+	if !ok || c.name != "init" {
+		t.Fatalf("expected alias i to resolve to init, got %q", c.name)
+	}
+	flags := commandFlags(c, "")
+	byName := make(map[string]cliFlag)
+	for _, f := range flags {
+		byName[f.name] = f
+	}
+	if f := byName["config"]; f.short != "c" || f.def != ".coverctl.yaml" || f.isBool {
+		t.Fatalf("unexpected config flag: %+v", f)
+	}
+	if f := byName["force"]; f.short != "f" || !f.isBool {
+		t.Fatalf("unexpected force flag: %+v", f)
+	}
+	if _, ok := byName["c"]; ok {
+		t.Fatal("shorthand should be folded into --config")
+	}
+}
+
+func TestManPage(t *testing.T) {
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "man"}, &out, &out, fakeService{}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	page := out.String()
+	for _, want := range []string{".TH COVERCTL 1", `.SS "coverctl check"`, `\fB\-\-from\-profile\fR`, `\fB\-c\fR, \fB\-\-config\fR \fIvalue\fR`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("man page missing %q", want)
+		}
+	}
+}
+
+func TestRunGate(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "out", "gate.json")
+	mdPath := filepath.Join(dir, "out", "gate.md")
+	args := []string{"coverctl", "gate", "--summary-json", jsonPath, "--summary-md", mdPath}
+
+	t.Run("passing gate writes summaries", func(t *testing.T) {
+		var out bytes.Buffer
+		var opts application.CheckOptions
+		gate := domain.Gate{Passed: true, Overall: 82.5, Checks: []domain.GateCheck{{Name: domain.GateCheckPolicy, Status: domain.StatusPass, Detail: "1/1 domains meet their minimum"}}}
+		code := Run(args, &out, &out, fakeService{gateResult: gate, checkOpts: &opts})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		if !opts.Ratchet || opts.HistoryStore == nil {
+			t.Fatalf("expected ratchet enabled with history store, got %+v", opts)
+		}
+		if opts.BaselineStore == nil {
+			t.Fatal("expected the gate to pass the history as baseline for policy.new_domain")
+		}
+		if !strings.Contains(out.String(), "Coverage gate: PASS") {
+			t.Fatalf("unexpected output: %s", out.String())
+		}
+		data, err := os.ReadFile(jsonPath)
+		if err != nil || !strings.Contains(string(data), `"passed": true`) {
+			t.Fatalf("expected JSON summary, got %q (%v)", data, err)
+		}
+		md, err := os.ReadFile(mdPath)
+		if err != nil || !strings.Contains(string(md), "## Coverage gate") {
+			t.Fatalf("expected markdown summary, got %q (%v)", md, err)
+		}
+	})
+
+	t.Run("failing gate exits 1", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run(args, &out, &out, fakeService{gateResult: domain.Gate{Passed: false}}); code != 1 {
+			t.Fatalf("expected exit 1, got %d", code)
+		}
+	})
+
+	t.Run("runtime error exits 3", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run(args, &out, &out, fakeService{gateErr: errSentinel}); code != 3 {
+			t.Fatalf("expected exit 3, got %d", code)
+		}
+	})
+}
+
+func TestRunMetrics(t *testing.T) {
+	metrics := application.MetricsResult{
+		Result: domain.Result{Passed: true, Domains: []domain.DomainResult{{Domain: "core", Covered: 8, Total: 10, Percent: 80}}},
+		Debt:   application.DebtResult{HealthScore: 100},
+	}
+
+	t.Run("write to stdout", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run([]string{"coverctl", "metrics", "write", "-"}, &out, &out, fakeService{metricsResult: metrics}); code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		if !strings.Contains(out.String(), `coverctl_domain_coverage_percent{domain="core"} 80`) {
+			t.Fatalf("expected prometheus text, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("write textfile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "coverctl.prom")
+		var out bytes.Buffer
+		if code := Run([]string{"coverctl", "metrics", "write", path}, &out, &out, fakeService{metricsResult: metrics}); code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("expected textfile: %v", err)
+		}
+	})
+
+	t.Run("push", func(t *testing.T) {
+		var gotPath string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+		}))
+		defer server.Close()
+		var out bytes.Buffer
+		args := []string{"coverctl", "metrics", "push", "--gateway", server.URL, "--job", "ci"}
+		if code := Run(args, &out, &out, fakeService{metricsResult: metrics}); code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+		}
+		if gotPath != "/metrics/job/ci" {
+			t.Fatalf("unexpected push path %q", gotPath)
+		}
+	})
+
+	t.Run("usage errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"coverctl", "metrics"},
+			{"coverctl", "metrics", "push"},
+			{"coverctl", "metrics", "write"},
+		} {
+			var out bytes.Buffer
+			if code := Run(args, &out, &out, fakeService{}); code != 2 {
+				t.Fatalf("%v: expected exit 2, got %d", args, code)
+			}
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		var out bytes.Buffer
+		if code := Run([]string{"coverctl", "metrics", "write", "-"}, &out, &out, fakeService{metricsErr: errSentinel}); code != 3 {
+			t.Fatalf("expected exit 3, got %d", code)
+		}
+	})
+}
+
+func TestParseGlobalFlagsChdir(t *testing.T) {
+	for _, args := range [][]string{
+		{"-C", "sub", "check", "-o", "json"},
+		{"--chdir", "sub", "check", "-o", "json"},
+		{"--chdir=sub", "check", "-o", "json"},
+	} {
+		global, cmd, rest := parseGlobalFlags(args)
+		if global.Chdir != "sub" || cmd != "check" || len(rest) != 2 {
+			t.Fatalf("%v: got chdir %q cmd %q rest %v", args, global.Chdir, cmd, rest)
+		}
+	}
+}
+
+func TestParseGlobalFlagsNoCache(t *testing.T) {
+	global, cmd, _ := parseGlobalFlags([]string{"--no-cache", "debt"})
+	if !global.NoCache || cmd != "debt" {
+		t.Fatalf("got no-cache %v cmd %q", global.NoCache, cmd)
+	}
+}
+
+func TestResolveWorkdirDiscoversConfig(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".coverctl.yaml"), []byte("version: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deep := filepath.Join(root, "internal", "core")
+	if err := os.MkdirAll(deep, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	wantRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(deep)
+	if _, err := ResolveWorkdir([]string{"coverctl", "check"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cwd, _ := os.Getwd(); cwd != wantRoot {
+		t.Fatalf("expected to run in %s, got %s", wantRoot, cwd)
+	}
+
+	t.Chdir(deep)
+	if _, err := ResolveWorkdir([]string{"coverctl", "check", "--config", "other.yaml"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cwd, _ := os.Getwd(); filepath.Base(cwd) != "core" {
+		t.Fatalf("explicit --config must disable discovery, got %s", cwd)
+	}
+
+	if _, err := ResolveWorkdir([]string{"coverctl", "-C", filepath.Join(root, "internal"), "version"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cwd, _ := os.Getwd(); cwd != wantRoot {
+		t.Fatalf("expected -C then discovery to reach %s, got %s", wantRoot, cwd)
+	}
+}
+
+func TestResolveWorkdirKeepsRelativePaths(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".coverctl.yaml"), []byte("version: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "svc")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "cover.out"), []byte("mode: set\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wantSub, err := filepath.EvalSymlinks(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(sub)
+	args, err := ResolveWorkdir([]string{"coverctl", "--ci", "report", "-p", "cover.out", "--output=json", "-o", "out/report.json", "--report-file=run.json", "-q", "--history", "/abs/history.json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"coverctl", "--ci", "report", "-p", filepath.Join(wantSub, "cover.out"), "--output=json", "-o", filepath.Join(wantSub, "out", "report.json"), "--report-file=" + filepath.Join(wantSub, "run.json"), "-q", "--history", "/abs/history.json"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %q, got %q", want, args)
+	}
+
+	t.Chdir(sub)
+	args, err = ResolveWorkdir([]string{"coverctl", "validate-profile", "cover.out"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args[2] != filepath.Join(wantSub, "cover.out") {
+		t.Fatalf("expected the positional profile to be made absolute, got %q", args)
+	}
+
+}
+
+func TestFindConfigDirStopsAtRepoRoot(t *testing.T) {
+	outer := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outer, ".coverctl.yaml"), []byte("version: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(outer, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if dir, ok := findConfigDir(repo); ok {
+		t.Fatalf("expected no config inside the repository, got %s", dir)
+	}
+}
+
+func TestResolveWorkdirBadChdir(t *testing.T) {
+	_, err := ResolveWorkdir([]string{"coverctl", "--chdir", filepath.Join(t.TempDir(), "missing"), "check"})
+	if err == nil || !strings.Contains(err.Error(), "chdir") {
+		t.Fatalf("expected chdir error, got %v", err)
+	}
+}
+
+func TestCommandHelpFlag(t *testing.T) {
+	var out, errOut bytes.Buffer
+	code := Run([]string{"coverctl", "c", "--help"}, &out, &errOut, fakeService{})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), "coverctl check - Run coverage") || !strings.Contains(out.String(), "Examples:") {
+		t.Fatalf("expected check help on stdout, got %q", out.String())
+	}
+}
+
+func TestEveryCommandHasHelp(t *testing.T) {
+	for _, c := range visibleCommands() {
+		var out bytes.Buffer
+		if code := commandHelp(c.name, &out); code != 0 || !strings.Contains(out.String(), "coverctl "+c.name) {
+			t.Errorf("%s: missing help (exit %d): %q", c.name, code, out.String())
+		}
+	}
+}
+
+func TestRunVersionJSON(t *testing.T) {
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "version", "-o", "json"}, &out, &out, fakeService{}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	var info buildInfo
+	if err := json.Unmarshal(out.Bytes(), &info); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if info.Version == "" || info.GoVersion == "" || info.Platform == "" {
+		t.Fatalf("incomplete build info: %+v", info)
+	}
+}
+
+type fakeReleaseSource struct {
+	rel     selfupdate.Release
+	applied string
+}
+
+func (f *fakeReleaseSource) Latest(context.Context) (selfupdate.Release, error) { return f.rel, nil }
+func (f *fakeReleaseSource) Tag(_ context.Context, tag string) (selfupdate.Release, error) {
+	return selfupdate.Release{Tag: tag}, nil
+}
+func (f *fakeReleaseSource) Apply(_ context.Context, rel selfupdate.Release, target string) error {
+	f.applied = rel.Tag
+	return nil
+}
+
+func TestRunSelfUpdate(t *testing.T) {
+	oldSource, oldVersion := newReleaseSource, Version
+	defer func() { newReleaseSource, Version = oldSource, oldVersion }()
+	source := &fakeReleaseSource{rel: selfupdate.Release{Tag: "v2.0.0"}}
+	newReleaseSource = func() releaseSource { return source }
+	Version = "1.0.0"
+
+	var out bytes.Buffer
+	if code := Run([]string{"coverctl", "self-update", "--check"}, &out, &out, fakeService{}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), "Update available: 1.0.0 -> v2.0.0") || source.applied != "" {
+		t.Fatalf("--check must only report, got %q applied %q", out.String(), source.applied)
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "self-update"}, &out, &out, fakeService{}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if source.applied != "v2.0.0" {
+		t.Fatalf("expected v2.0.0 to be applied, got %q", source.applied)
+	}
+
+	Version = "2.0.0"
+	source.applied = ""
+	out.Reset()
+	Run([]string{"coverctl", "self-update"}, &out, &out, fakeService{})
+	if source.applied != "" || !strings.Contains(out.String(), "up to date") {
+		t.Fatalf("expected no update when current, got %q", out.String())
+	}
+}
+
+func TestRunCheckReportFileFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "check.json")
+	var out bytes.Buffer
+	var got application.CheckOptions
+	svc := fakeService{checkOpts: &got}
+	if code := Run([]string{"coverctl", "check", "--report-file", path}, &out, &out, svc); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if got.ReportFile == nil {
+		t.Fatal("expected report file writer to be passed to the service")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected report file to be created: %v", err)
+	}
+}
+
+func TestRunCIInit(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	cfg := minimalConfig()
+	cfg.Language = application.LanguagePython
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "ci", "init", "--provider", "github", "--preset", "balanced"}, &out, &out, fakeService{detectCfg: cfg})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	config, err := os.ReadFile(filepath.Join(dir, ".coverctl.yaml"))
+	if err != nil || !strings.Contains(string(config), "min: 75") {
+		t.Fatalf("expected preset config, got %v:\n%s", err, config)
+	}
+	workflow, err := os.ReadFile(filepath.Join(dir, ".github", "workflows", "coverctl.yml"))
+	if err != nil || !strings.Contains(string(workflow), "actions/setup-python") {
+		t.Fatalf("expected python workflow, got %v:\n%s", err, workflow)
+	}
+
+	code = Run([]string{"coverctl", "ci", "init", "--provider", "github"}, &out, &out, fakeService{detectCfg: cfg})
+	if code != 2 {
+		t.Fatalf("expected exit 2 for an existing pipeline, got %d", code)
+	}
+	out.Reset()
+	code = Run([]string{"coverctl", "ci", "init", "--provider", "gitlab"}, &out, &out, fakeService{detectCfg: cfg})
+	if code != 0 || !strings.Contains(out.String(), "Kept existing config") {
+		t.Fatalf("expected the existing config kept, got %d: %s", code, out.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".gitlab", "coverctl.gitlab-ci.yml")); err != nil {
+		t.Fatalf("expected gitlab pipeline: %v", err)
+	}
+	if code := Run([]string{"coverctl", "ci", "init", "--provider", "jenkins"}, &out, &out, fakeService{}); code != 2 {
+		t.Fatalf("expected exit 2 for an unknown provider, got %d", code)
+	}
+}
+
+func TestRunMerge(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"coverage-1.out": "mode: set\nexample.com/a/a.go:1.1,2.2 1 1\n",
+		"coverage-2.out": "mode: set\nexample.com/a/a.go:3.1,4.2 1 0\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	output := filepath.Join(t.TempDir(), "merged", "coverage.out")
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "merge", "--dir", dir, "--expect", "2", "-o", output}, &out, &out, fakeService{})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	data, err := os.ReadFile(output)
+	if err != nil || !strings.HasPrefix(string(data), "mode: set\n") || strings.Count(string(data), "\n") != 3 {
+		t.Fatalf("unexpected merged profile %v:\n%s", err, data)
+	}
+
+	out.Reset()
+	code = Run([]string{"coverctl", "merge", "--dir", dir, "--expect", "3", "--timeout", "0s", "-o", output}, &out, &out, fakeService{})
+	if code != 3 || !strings.Contains(out.String(), "missing shards 3") {
+		t.Fatalf("expected exit 3 naming shard 3, got %d: %s", code, out.String())
+	}
+	if code := Run([]string{"coverctl", "merge", "--expect", "2"}, &out, &out, fakeService{}); code != 2 {
+		t.Fatalf("expected exit 2 without --dir, got %d", code)
+	}
+}
+
+func TestRunGenFixture(t *testing.T) {
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "gen-fixture", "--files", "4", "--blocks", "10", "--domains", "2"}, &out, &out, fakeService{})
+	if code != 0 || !strings.HasPrefix(out.String(), "mode: atomic\n") || strings.Count(out.String(), "\n") != 11 {
+		t.Fatalf("expected a profile with 10 blocks on stdout, got %d:\n%s", code, out.String())
+	}
+	output := filepath.Join(t.TempDir(), "perf", "fixture.out")
+	out.Reset()
+	if code := Run([]string{"coverctl", "gen-fixture", "--files", "4", "--blocks", "10", "-o", output}, &out, &out, fakeService{}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if data, err := os.ReadFile(output); err != nil || strings.Count(string(data), "\n") != 11 {
+		t.Fatalf("unexpected fixture %v:\n%s", err, data)
+	}
+	if code := Run([]string{"coverctl", "gen-fixture", "--files", "10", "--blocks", "4"}, &out, &out, fakeService{}); code != 2 {
+		t.Fatalf("expected exit 2 for fewer blocks than files, got %d", code)
+	}
+}
+
+func TestRunVerify(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := filepath.Join(t.TempDir(), "key.pub")
+	if err := os.WriteFile(key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	svc := fakeService{verifyResult: application.VerifyResult{
+		Entries: []application.EntryVerification{{Index: 0, Status: application.EntryInvalid, Reason: "signature does not match the entry"}},
+		Invalid: 1,
+	}}
+	if code := Run([]string{"coverctl", "verify", "--key", key}, &out, &out, svc); code != 1 {
+		t.Fatalf("expected exit 1 for an invalid entry, got %d", code)
+	}
+	if !strings.Contains(out.String(), "1 invalid") || !strings.Contains(out.String(), "entry 0") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	out.Reset()
+	svc.verifyResult = application.VerifyResult{Valid: 2, Passed: true}
+	if code := Run([]string{"coverctl", "verify", "--key", key, "-o", "json"}, &out, &out, svc); code != 0 || !strings.Contains(out.String(), `"passed": true`) {
+		t.Fatalf("expected exit 0 with JSON, got %d: %s", code, out.String())
+	}
+	if code := Run([]string{"coverctl", "verify"}, &out, &out, svc); code != 2 {
+		t.Fatalf("expected exit 2 without --key, got %d", code)
+	}
+}
+
+func TestRunAudit(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	entry := `{"timestamp":"2026-10-01T09:00:00Z","actor":"alice","source":"ratchet-up","config":".coverctl.yaml","configHash":"aa",` +
+		`"changes":[{"kind":"raised","subject":"domain core min","before":"80.0%","after":"82.0%"}],"thresholds":{"domain core min":82}}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "audit.jsonl"), []byte(entry), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	var audits []application.AuditOptions
+	if code := Run([]string{"coverctl", "audit", "--audit-file", "audit.jsonl"}, &out, &out, fakeService{auditCalls: &audits}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	for _, want := range []string{"2026-10-01 09:00 UTC", "ratchet-up", "by alice", "domain core min", "80.0%", "82.0%"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output: %s", want, out.String())
+		}
+	}
+	if len(audits) != 1 || audits[0].Source != application.AuditManual {
+		t.Fatalf("expected manual edits to be detected, got %+v", audits)
+	}
+
+	out.Reset()
+	audits = nil
+	if code := Run([]string{"coverctl", "audit", "--audit-file", "missing.jsonl", "--no-detect", "-o", "json"}, &out, &out, fakeService{auditCalls: &audits}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if strings.TrimSpace(out.String()) != "[]" || len(audits) != 0 {
+		t.Fatalf("expected an empty JSON log without detection, got %s, %+v", out.String(), audits)
+	}
+}
+
+func TestRunExplainFailure(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	report := `{"schema":"coverctl/v1","command":"check","passed":false,"result":{"domains":[{"domain":"core","percent":52.3,"required":80,"status":"FAIL"}],"passed":false}}`
+	if err := os.WriteFile("check.json", []byte(report), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	var opts application.ExplainOptions
+	svc := fakeService{explainOpts: &opts, explainResult: application.ExplainResult{Domains: []application.DomainExplanation{{
+		Domain: "core", Percent: 52.3, Required: 80, Covered: 23, Total: 44, Metric: domain.MetricStatements, ToCover: 13,
+		Files:    []domain.UncoveredFile{{File: "internal/core/e.go", Total: 4, Uncovered: 4}},
+		Commands: []string{"coverctl check --domain core"},
+	}}}}
+	if code := Run([]string{"coverctl", "explain-failure", "--report", "check.json"}, &out, &out, svc); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, out.String())
+	}
+	if opts.Report == nil || opts.Report.Result.Domains[0].Domain != "core" || opts.Files != 3 {
+		t.Fatalf("expected the report to be passed on, got %+v", opts)
+	}
+	for _, want := range []string{"52.3% covered, 80.0% required", "Cover 13 more statements", "internal/core/e.go", "coverctl check --domain core"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output: %s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if code := Run([]string{"coverctl", "explain-failure", "--report", "missing.json"}, &out, &out, svc); code != 2 {
+		t.Fatalf("expected exit 2 for a missing report, got %d", code)
+	}
+}
+
+func TestRunMCPServeRefusesUnauthenticatedWildcard(t *testing.T) {
+	t.Setenv(mcpTokenEnv, "")
+	var out bytes.Buffer
+	code := Run([]string{"coverctl", "mcp", "serve", "--transport", "http", "--addr", ":8765"}, &out, &out, fakeService{})
+	if code != 2 {
+		t.Fatalf("expected exit 2, got %d: %s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "non-loopback") || !strings.Contains(out.String(), mcpTokenEnv) {
+		t.Fatalf("expected a message naming the token variable, got: %s", out.String())
+	}
+}
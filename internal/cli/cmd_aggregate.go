@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/snapshot"
+)
+
+// runAggregate implements `coverctl aggregate`.
+func runAggregate(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("aggregate")
+	fs.Usage = func() { commandHelp("aggregate", stderr) }
+	var inputs domainList
+	fs.Var(&inputs, "inputs", "JSON report or history file path or glob, one per service (repeatable)")
+	fs.Var(&inputs, "i", "JSON report or history file path or glob (shorthand)")
+	output := fs.String("output", "text", "Output format: text|json|markdown|html")
+	fs.StringVar(output, "o", "text", "Output format (shorthand)")
+	title := fs.String("title", "", "HTML page title")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	inputs = append(inputs, fs.Args()...)
+	if len(inputs) == 0 {
+		fmt.Fprintln(stderr, "Error: --inputs is required")
+		fs.Usage()
+		return 2
+	}
+
+	result, err := svc.OrgReport(ctx, application.OrgReportOptions{Inputs: inputs}, snapshot.Loader{})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if err := report.WriteOrgReport(stdout, result, application.OutputFormat(*output), *title); err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/annotations"
+)
+
+// runAnnotate implements `coverctl annotate`.
+func runAnnotate(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("annotate")
+	fs.Usage = func() { commandHelp("annotate", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	var match domainList
+	fs.Var(&match, "match", "Annotate profile files matching this glob (repeatable)")
+	dryRun := fs.Bool("dry-run", false, "Report what would change without editing files")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := svc.Annotate(ctx, application.AnnotateOptions{
+		ConfigPath:  *configPath,
+		ProfilePath: *profile,
+		Files:       fs.Args(),
+		Match:       match,
+		DryRun:      *dryRun,
+	}, annotations.Writer{})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !result.Enabled && !global.IsQuiet() {
+		fmt.Fprintln(stderr, "warning: annotations.enabled is off; set it in the config for the pragmas to take effect")
+	}
+	printAnnotateResult(result, stdout, *output)
+	return 0
+}
+
+func printAnnotateResult(result application.AnnotateResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+
+	added := 0
+	for _, f := range result.Files {
+		switch f.Status {
+		case application.AnnotateAdded:
+			added++
+			verb := "Annotated"
+			if result.DryRun {
+				verb = "Would annotate"
+			}
+			fmt.Fprintf(w, "%s %s (%s)\n", verb, f.File, f.Line)
+		case application.AnnotatePresent:
+			fmt.Fprintf(w, "Skipped %s: already annotated\n", f.File)
+		case application.AnnotateUnsupported:
+			fmt.Fprintf(w, "Skipped %s: no comment syntax known for this file type\n", f.File)
+		case application.AnnotateMissing:
+			fmt.Fprintf(w, "Skipped %s: file not found\n", f.File)
+		}
+	}
+	if result.DryRun {
+		fmt.Fprintf(w, "%d of %d files would be annotated.\n", added, len(result.Files))
+		return
+	}
+	fmt.Fprintf(w, "%d of %d files annotated.\n", added, len(result.Files))
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
+)
+
+// defaultAuditFile is where threshold changes are logged.
+const defaultAuditFile = ".cover/audit.jsonl"
+
+// runAudit implements `coverctl audit`: it records any thresholds edited by
+// hand since the last audit entry, then prints the log.
+func runAudit(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("audit")
+	fs.Usage = func() { commandHelp("audit", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	auditFile := fs.String("audit-file", defaultAuditFile, "Policy audit log path")
+	noDetect := fs.Bool("no-detect", false, "Print the log without recording manual edits")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *output != application.OutputText && *output != application.OutputJSON {
+		fmt.Fprintln(stderr, "audit supports text and json output")
+		return 2
+	}
+
+	log := history.AuditLog{Path: *auditFile}
+	if !*noDetect {
+		if _, err := svc.AuditConfig(ctx, application.AuditOptions{ConfigPath: *configPath, Source: application.AuditManual}, &log); err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+	}
+	entries, err := log.Load()
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if err := report.WriteAuditLog(stdout, entries, *output); err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	return 0
+}
+
+// auditConfig logs the thresholds a command changed in configPath. Commands
+// call it with AuditManual before writing, so edits made by hand since the
+// last entry are not attributed to them. A failure only warns: the config
+// has already been written.
+func auditConfig(ctx context.Context, svc Service, auditFile, configPath string, source application.AuditSource, stderr io.Writer) {
+	log := history.AuditLog{Path: auditFile}
+	if _, err := svc.AuditConfig(ctx, application.AuditOptions{ConfigPath: configPath, Source: source}, &log); err != nil {
+		fmt.Fprintln(stderr, "Warning: audit:", err)
+	}
+}
@@ -2,16 +2,16 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
 )
 
 // runBadge implements `coverctl badge`.
 func runBadge(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("badge", flag.ContinueOnError)
+	fs := newFlagSet("badge")
 	fs.Usage = func() { commandHelp("badge", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
@@ -21,20 +21,30 @@ func runBadge(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 	fs.StringVar(output, "o", "coverage.svg", "Output file path (shorthand)")
 	label := fs.String("label", "coverage", "Badge label text")
 	style := fs.String("style", "flat", "Badge style: flat|flat-square")
+	showDelta := fs.Bool("show-delta", false, "Annotate the badge with the change since the latest history entry")
+	historyPath := fs.String("history", "", "History file path for --show-delta")
 	if err := fs.Parse(args); err != nil {
 		return 2
 	}
-	result, err := svc.Badge(ctx, application.BadgeOptions{
+	opts := application.BadgeOptions{
 		ConfigPath:  *configPath,
 		ProfilePath: *profile,
 		Output:      *output,
 		Label:       *label,
 		Style:       *style,
-	})
+	}
+	if *showDelta {
+		histPath := *historyPath
+		if histPath == "" {
+			histPath = ".cover/history.json"
+		}
+		opts.HistoryStore = &history.FileStore{Path: histPath}
+	}
+	result, err := svc.Badge(ctx, opts)
 	if err != nil {
 		return exitCodeWithCI(err, 3, stderr, global)
 	}
-	if err := writeBadgeFile(*output, result.Percent, *label, *style); err != nil {
+	if err := writeBadgeFile(*output, result, *label, *style); err != nil {
 		return exitCodeWithCI(err, 3, stderr, global)
 	}
 	if !global.IsQuiet() {
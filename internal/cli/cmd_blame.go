@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// runBlame implements `coverctl blame`.
+func runBlame(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("blame")
+	fs.Usage = func() { commandHelp("blame", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	var domains domainList
+	fs.Var(&domains, "domain", "Filter to specific domain (repeatable)")
+	fs.Var(&domains, "d", "Filter to specific domain (shorthand)")
+	limit := fs.Int("limit", 10, "Maximum authors and commits listed (0 = all)")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *limit < 0 {
+		fmt.Fprintln(stderr, "--limit must not be negative")
+		return 2
+	}
+
+	result, err := svc.Blame(ctx, application.BlameOptions{
+		ConfigPath:  *configPath,
+		ProfilePath: *profile,
+		Domains:     domains,
+		Limit:       *limit,
+	})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printBlameResult(result, stdout, *output)
+	return 0
+}
+
+func printBlameResult(result application.BlameResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+
+	if result.Uncovered == 0 {
+		fmt.Fprintln(w, "No uncovered lines.")
+		return
+	}
+	fmt.Fprintf(w, "%d uncovered lines across %d files\n", result.Uncovered, result.Files)
+	if result.Unattributed > 0 {
+		fmt.Fprintf(w, "(%d lines in files git could not blame)\n", result.Unattributed)
+	}
+
+	fmt.Fprintf(w, "\nBy author (%d of %d):\n", len(result.Authors), result.TotalAuthors)
+	for _, a := range result.Authors {
+		name := a.Author
+		if a.Email != "" {
+			name = fmt.Sprintf("%s <%s>", a.Author, a.Email)
+		}
+		fmt.Fprintf(w, "  %s: %d uncovered lines across %d files (%d commits)\n", name, a.Uncovered, a.Files, a.Commits)
+	}
+
+	fmt.Fprintf(w, "\nBy commit (%d of %d):\n", len(result.Commits), result.TotalCommits)
+	for _, c := range result.Commits {
+		fmt.Fprintf(w, "  %s  %s  %-20s %5d lines  %s\n", shortCommit(c.Commit), c.Date.Format("2006-01-02"), c.Author, c.Uncovered, c.Summary)
+	}
+}
+
+func shortCommit(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 
@@ -14,22 +13,26 @@ import (
 // keep the dispatch table small and to make the per-command flag set + opts
 // construction visible in a focused file.
 func runCheck(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs := newFlagSet("check")
 	fs.Usage = func() { commandHelp("check", stderr) }
-	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path (\"-\" for stdin)")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 	output := outputFlags(fs)
+	html := htmlFlags(fs)
 	profile := &stringFlag{value: ".cover/coverage.out"}
 	fs.Var(profile, "profile", "Coverage profile output path")
 	fs.Var(profile, "p", "Coverage profile output path (shorthand)")
 	fromProfile := fs.Bool("from-profile", false, "Use existing coverage profile instead of running tests")
+	profileAge := profileAgeFlags(fs)
 	historyPath := fs.String("history", "", "History file path for delta display")
+	notes := notesFlags(fs, true)
 	showDelta := fs.Bool("show-delta", false, "Show coverage change from previous run")
 	failUnder := fs.Float64("fail-under", 0, "Fail if overall coverage is below this percentage")
 	ratchet := fs.Bool("ratchet", false, "Fail if coverage decreases from previous recorded value")
 	validate := fs.Bool("validate", false, "Validate config without running tests")
 	language := fs.String("language", "", "Override language detection (go, python, nodejs, rust, java)")
 	fs.StringVar(language, "l", "", "Override language detection (shorthand)")
+	runner := fs.String("runner", "", "Use this runner instead of auto-detection (go, python, node, rust, java, ...)")
 	tags := fs.String("tags", "", "Build tags (e.g., integration,e2e)")
 	race := fs.Bool("race", false, "Enable race detector")
 	short := fs.Bool("short", false, "Skip long-running tests")
@@ -44,7 +47,14 @@ func runCheck(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 	fs.Var(&domains, "d", "Filter to specific domain (shorthand)")
 	incremental := fs.Bool("incremental", false, "Only test packages with changed files")
 	incrementalRef := fs.String("incremental-ref", "HEAD~1", "Git ref to compare against for incremental mode")
+	summaryPath, noSummary := summaryFlags(fs)
+	diffBase := fs.String("diff-base", "", "Enable diff mode against this git ref (\"auto\" uses the merge-base with the target branch)")
+	topFiles := fs.Int("top-files", 0, "List the N files with the most uncovered statements in failing domains")
+	pruneStale := fs.Bool("prune-stale", false, "Drop profile entries for files that no longer exist")
+	failFast := fs.Bool("fail-fast", false, "Skip the remaining per-domain test runs once a finished domain is below its minimum")
+	isolate := fs.Bool("isolate-domains", false, "Test each domain alone with -coverpkg limited to it (coverpkg.isolate)")
 
+	reportFile := reportFileFlag(fs)
 	if err := fs.Parse(args); err != nil {
 		return 2
 	}
@@ -69,12 +79,19 @@ func runCheck(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 	opts := application.CheckOptions{
 		ConfigPath:     *configPath,
 		Output:         *output,
+		HTML:           *html,
 		Profile:        profile.value,
 		FromProfile:    *fromProfile,
 		Domains:        domains,
 		Incremental:    *incremental,
 		IncrementalRef: *incrementalRef,
+		DiffBase:       *diffBase,
 		Language:       application.Language(*language),
+		Runner:         *runner,
+		TopFiles:       *topFiles,
+		PruneStale:     *pruneStale,
+		FailFast:       *failFast,
+		IsolateDomains: *isolate,
 		BuildFlags: application.BuildFlags{
 			Tags:     *tags,
 			Race:     *race,
@@ -85,18 +102,43 @@ func runCheck(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 			TestArgs: testArgs,
 		},
 	}
+	if err := profileAge.apply(&opts); err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	opts.Progress = progressWriter(stderr, global, *output, *verbose)
+	opts.TimingStore = &history.TimingStore{Path: timingPath}
+	histPath := *historyPath
+	if histPath == "" {
+		histPath = ".cover/history.json"
+	}
+	opts.BaselineStore = notes.store(histPath)
 	if *showDelta || *ratchet {
-		histPath := *historyPath
-		if histPath == "" {
-			histPath = ".cover/history.json"
-		}
-		opts.HistoryStore = &history.FileStore{Path: histPath}
+		opts.HistoryStore = opts.BaselineStore
 	}
 	if *failUnder > 0 {
 		opts.FailUnder = failUnder
 	}
 	opts.Ratchet = *ratchet
 
+	summary, err := openSummary(*summaryPath, *noSummary)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if summary != nil {
+		defer summary.Close()
+		opts.Summary = summary
+	}
+
+	reportOut, err := openReportFile(*reportFile)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if reportOut != nil {
+		defer reportOut.Close()
+		opts.ReportFile = reportOut
+	}
+
 	err = svc.Check(ctx, opts)
+	writeJSONError(stdout, err, *output)
 	return exitCodeWithCI(err, 1, stderr, global)
 }
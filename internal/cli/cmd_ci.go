@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/citemplate"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/preset"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runCI implements `coverctl ci init`, bootstrapping a config and a CI
+// pipeline without prompting, so it also runs inside containers.
+func runCI(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	if len(args) == 0 || args[0] != "init" {
+		commandHelp("ci", stderr)
+		return 2
+	}
+
+	fs := newFlagSet("ci init")
+	fs.Usage = func() { commandHelp("ci", stderr) }
+	providerName := fs.String("provider", "", "CI provider: github|gitlab")
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	force := fs.Bool("force", false, "Overwrite existing config and pipeline files")
+	fs.BoolVar(force, "f", false, "Overwrite existing config and pipeline files (shorthand)")
+	presetRef := fs.String("preset", "", "Apply a preset: strict, balanced, legacy, or a preset URL or file")
+	branch := fs.String("branch", "main", "Branch whose pushes record coverage history")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *providerName == "" {
+		fmt.Fprintln(stderr, "ci init requires --provider (github or gitlab)")
+		return 2
+	}
+	provider, err := citemplate.ParseProvider(*providerName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	pipelinePath := provider.Path()
+	if !*force {
+		if _, err := os.Stat(pipelinePath); err == nil {
+			return exitCodeWithCI(fmt.Errorf("pipeline %s already exists", pipelinePath), 2, stderr, global)
+		}
+	}
+
+	cfg, err := svc.Detect(ctx, application.DetectOptions{})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	pipeline, err := citemplate.Render(provider, citemplate.Options{
+		Language:   cfg.Language,
+		ConfigPath: *configPath,
+		Branch:     *branch,
+	})
+	if err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+
+	// An existing config is kept, so ci init can be added to a project
+	// that is already set up.
+	_, statErr := os.Stat(*configPath)
+	keepConfig := statErr == nil && !*force
+	if !keepConfig {
+		if *presetRef != "" {
+			chosen, err := preset.NewLoader().Load(ctx, *presetRef)
+			if err != nil {
+				return exitCodeWithCI(err, 2, stderr, global)
+			}
+			cfg = chosen.Finish(chosen.Seed(cfg))
+		}
+		if err := writeConfigFile(*configPath, cfg, stdout, true); err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+	}
+
+	if err := writePipelineFile(pipelinePath, pipeline); err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+
+	if !global.IsQuiet() {
+		if keepConfig {
+			fmt.Fprintf(stdout, "Kept existing config %s\n", *configPath)
+		} else {
+			fmt.Fprintf(stdout, "Wrote config %s\n", *configPath)
+		}
+		fmt.Fprintf(stdout, "Wrote %s pipeline %s\n", provider, pipelinePath)
+		if provider == citemplate.GitLab {
+			fmt.Fprintf(stdout, "Include it from .gitlab-ci.yml:\n\ninclude:\n  - local: %s\n", pipelinePath)
+		}
+	}
+	return 0
+}
+
+// writePipelineFile writes a generated pipeline, creating its directory.
+func writePipelineFile(path string, data []byte) error {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cleanPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(cleanPath, data, 0o644)
+}
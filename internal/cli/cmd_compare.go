@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 
@@ -11,7 +10,7 @@ import (
 
 // runCompare implements `coverctl compare`.
 func runCompare(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	fs := newFlagSet("compare")
 	fs.Usage = func() { commandHelp("compare", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
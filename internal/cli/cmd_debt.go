@@ -2,15 +2,21 @@ package cli
 
 import (
 	"context"
-	"flag"
+	"encoding/json"
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
 )
 
 // runDebt implements `coverctl debt`.
 func runDebt(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("debt", flag.ContinueOnError)
+	if len(args) > 0 && args[0] == "plan" {
+		return runDebtPlan(ctx, args[1:], stdout, stderr, svc, global)
+	}
+	fs := newFlagSet("debt")
 	fs.Usage = func() { commandHelp("debt", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
@@ -31,3 +37,81 @@ func runDebt(ctx context.Context, args []string, stdout, stderr io.Writer, svc S
 	printDebtResult(result, stdout, *output)
 	return 0
 }
+
+// runDebtPlan implements `coverctl debt plan`. Exits 1 when recorded
+// history has fallen behind the saved burn-down line.
+func runDebtPlan(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("debt plan")
+	fs.Usage = func() { commandHelp("debt", stderr) }
+	targetDate := fs.String("target-date", "", "Date (YYYY-MM-DD) by which all debt should be closed; creates a new plan")
+	historyPath := fs.String("history", ".cover/history.json", "History file path")
+	planPath := fs.String("plan", ".cover/debt-plan.json", "Debt plan file path")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var target time.Time
+	if *targetDate != "" {
+		parsed, err := time.Parse("2006-01-02", *targetDate)
+		if err != nil {
+			fmt.Fprintf(stderr, "invalid --target-date %q: expected YYYY-MM-DD\n", *targetDate)
+			return 2
+		}
+		target = parsed
+	}
+
+	store := history.FileStore{Path: *historyPath}
+	plans := history.PlanStore{Path: *planPath}
+	result, err := svc.DebtPlan(ctx, application.DebtPlanOptions{
+		TargetDate: target,
+		Output:     *output,
+	}, &store, &plans)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printDebtPlanResult(result, stdout, *output)
+	if !result.OnTrack {
+		return 1
+	}
+	return 0
+}
+
+func printDebtPlanResult(result application.DebtPlanResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+
+	if len(result.Plan.Domains) == 0 {
+		fmt.Fprintln(w, "No domain debt to burn down - all recorded domains meet their minimum.")
+		return
+	}
+
+	fmt.Fprintln(w, "Coverage Debt Plan")
+	fmt.Fprintln(w, "==================")
+	fmt.Fprintf(w, "Started: %s  Target: %s  As of: %s\n",
+		result.Plan.CreatedAt.Format("2006-01-02"),
+		result.Plan.TargetDate.Format("2006-01-02"),
+		result.AsOf.Format("2006-01-02"))
+	fmt.Fprintln(w, "")
+	fmt.Fprintf(w, "%-20s %9s %9s %9s %10s %10s %10s  %s\n", "DOMAIN", "ACTUAL", "EXPECTED", "REQUIRED", "PLAN/WK", "SEEN/WK", "NEED/WK", "STATUS")
+	fmt.Fprintf(w, "%-20s %9s %9s %9s %10s %10s %10s  %s\n", "------", "------", "--------", "--------", "-------", "-------", "-------", "------")
+	for _, p := range result.Progress {
+		status := "on track"
+		if !p.OnTrack {
+			status = "off track"
+		}
+		fmt.Fprintf(w, "%-20s %8.1f%% %8.1f%% %8.1f%% %+9.1f%% %+9.1f%% %+9.1f%%  %s\n",
+			truncateLeft(p.Domain, 20), p.Actual, p.Expected, p.Required,
+			p.PlannedWeeklyGain, p.ObservedWeeklyGain, p.RequiredWeeklyGain, status)
+	}
+	fmt.Fprintln(w, "")
+	if result.OnTrack {
+		fmt.Fprintln(w, "Plan is on track.")
+	} else {
+		fmt.Fprintln(w, "Plan is off track: gain at least NEED/WK per week to finish by the target date.")
+	}
+}
@@ -2,25 +2,35 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/config"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
 )
 
 // runDetect implements `coverctl detect`.
 func runDetect(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("detect", flag.ContinueOnError)
+	fs := newFlagSet("detect")
 	fs.Usage = func() { commandHelp("detect", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 	dryRun := fs.Bool("dry-run", false, "Preview config without writing")
 	force := fs.Bool("force", false, "Overwrite config if it exists")
 	fs.BoolVar(force, "f", false, "Overwrite config if it exists (shorthand)")
+	merge := fs.Bool("merge", false, "Add newly detected domains to the existing config, keeping its settings and comments")
 	if err := fs.Parse(args); err != nil {
 		return 2
 	}
+	if *merge {
+		if *force {
+			fmt.Fprintln(stderr, "--merge and --force are mutually exclusive")
+			return 2
+		}
+		return runDetectMerge(ctx, *configPath, *dryRun, stdout, stderr, svc, global)
+	}
 	cfg, err := svc.Detect(ctx, application.DetectOptions{})
 	if err != nil {
 		return exitCodeWithCI(err, 3, stderr, global)
@@ -39,3 +49,58 @@ func runDetect(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 	}
 	return 0
 }
+
+// runDetectMerge adds newly detected domains to the config at path by
+// editing its YAML in place, so custom minimums, excludes, and comments
+// survive. Domains whose directories are gone are reported, not removed.
+func runDetectMerge(ctx context.Context, path string, dryRun bool, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	result, err := svc.DetectMerge(ctx, application.DetectOptions{ConfigPath: path})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	for _, name := range result.Stale {
+		fmt.Fprintf(stderr, "warning: domain %s matches no directories; remove it or fix its match patterns\n", name)
+	}
+	if len(result.Added) == 0 {
+		if !global.IsQuiet() {
+			fmt.Fprintf(stdout, "No new domains detected; %s is unchanged\n", path)
+		}
+		return 0
+	}
+
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return exitCodeWithCI(fmt.Errorf("invalid path: %w", err), 2, stderr, global)
+	}
+	info, err := os.Stat(cleanPath)
+	if err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	before, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	changes := make([]config.Change, 0, len(result.Added))
+	for _, d := range result.Added {
+		changes = append(changes, config.Change{Op: config.OpAddDomain, Domain: d.Name, Match: d.Match, Min: d.Min})
+	}
+	after, err := config.Edit(before, changes)
+	if err != nil {
+		return exitCodeWithCI(fmt.Errorf("merge detected domains: %w", err), 2, stderr, global)
+	}
+
+	if dryRun {
+		fmt.Fprint(stdout, config.Diff(path, before, after))
+		return 0
+	}
+	if err := os.WriteFile(cleanPath, after, info.Mode().Perm()); err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	if !global.IsQuiet() {
+		for _, d := range result.Added {
+			fmt.Fprintf(stdout, "Added domain %s (%v)\n", d.Name, d.Match)
+		}
+		fmt.Fprintf(stdout, "Config updated: %s\n", path)
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/config"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
+)
+
+// runDiffConfig implements `coverctl diff-config`.
+func runDiffConfig(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("diff-config")
+	fs.Usage = func() { commandHelp("diff-config", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Head config when only a base is given")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Head config when only a base is given (shorthand)")
+	output := fs.String("output", "text", "Output format: text|json|markdown")
+	fs.StringVar(output, "o", "text", "Output format (shorthand)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	refs := fs.Args()
+	if len(refs) == 0 || len(refs) > 2 {
+		fmt.Fprintln(stderr, "Error: diff-config takes a base config and an optional head config")
+		fs.Usage()
+		return 2
+	}
+	opts := application.ConfigDiffOptions{Base: refs[0], Head: *configPath}
+	if len(refs) == 2 {
+		opts.Head = refs[1]
+	}
+
+	diff, err := svc.DiffConfig(ctx, opts, config.Revisions{Loader: config.Loader{Stdin: configStdin}})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if err := report.WriteConfigDiff(stdout, diff, application.OutputFormat(*output)); err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	return 0
+}
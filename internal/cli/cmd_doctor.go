@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/runners"
+)
+
+// runDoctor implements `coverctl doctor`: it checks that the coverage
+// toolchain for every detected language is installed, that the config
+// loads, and that coverctl can write its artifacts, so a broken setup is
+// diagnosed before `check` fails halfway through a test run.
+//
+// Warnings (a usable but degraded setup) do not fail the command; any FAIL
+// exits 1.
+func runDoctor(ctx context.Context, args []string, stdout, stderr io.Writer, global GlobalOptions) int {
+	fs := newFlagSet("doctor")
+	fs.Usage = func() { commandHelp("doctor", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	runnerPlugins, _ := pluginOptions()
+	registry := runners.NewRegistry(gotool.NewCachedModuleResolver(), append(runnerPlugins, runners.WithProjectDir(projectDir))...)
+
+	report := doctorReport{Passed: true}
+	report.Checks = append(report.Checks, checkToolchains(ctx, registry, projectDir)...)
+	report.Checks = append(report.Checks, checkConfig(*configPath), checkArtifactDir(".cover"))
+	for _, c := range report.Checks {
+		if c.Status == runners.ToolchainFail {
+			report.Passed = false
+		}
+	}
+
+	printDoctorReport(report, stdout, *output)
+	if !report.Passed {
+		return 1
+	}
+	return 0
+}
+
+type doctorReport struct {
+	Passed bool          `json:"passed"`
+	Checks []doctorCheck `json:"checks"`
+}
+
+// doctorCheck is one `coverctl doctor` verdict. Status is one of the
+// runners.Toolchain* values.
+type doctorCheck struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Detail      string `json:"detail"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+func checkToolchains(ctx context.Context, registry *runners.Registry, projectDir string) []doctorCheck {
+	results := registry.DiagnoseToolchains(ctx, projectDir)
+	if len(results) == 0 {
+		return []doctorCheck{{
+			Name:        "project language",
+			Status:      runners.ToolchainWarn,
+			Detail:      "no supported project markers (go.mod, pyproject.toml, package.json, Cargo.toml, pom.xml, ...) found",
+			Remediation: "Run coverctl from the project root.",
+		}}
+	}
+	checks := make([]doctorCheck, 0, len(results))
+	for _, r := range results {
+		checks = append(checks, doctorCheck{
+			Name:        r.Runner + " toolchain",
+			Status:      r.Status,
+			Detail:      r.Detail,
+			Remediation: r.Remediation,
+		})
+	}
+	return checks
+}
+
+func checkConfig(path string) doctorCheck {
+	check := doctorCheck{Name: "config"}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		check.Status = runners.ToolchainWarn
+		check.Detail = fmt.Sprintf("%s not found; check will use autodetected domains", path)
+		check.Remediation = "Run `coverctl init` to write a config."
+		return check
+	}
+	if err := validateConfig(path); err != nil {
+		check.Status = runners.ToolchainFail
+		check.Detail = err.Error()
+		check.Remediation = fmt.Sprintf("Fix %s, or regenerate it with `coverctl detect`.", path)
+		return check
+	}
+	check.Status = runners.ToolchainPass
+	check.Detail = path + " is valid"
+	return check
+}
+
+// checkArtifactDir verifies coverctl can create files in dir, where
+// profiles and history are written. A dir that did not exist is removed
+// again afterwards.
+func checkArtifactDir(dir string) doctorCheck {
+	check := doctorCheck{Name: dir + " writable"}
+	_, statErr := os.Stat(dir)
+	err := os.MkdirAll(dir, 0o750)
+	if err == nil {
+		var f *os.File
+		if f, err = os.CreateTemp(dir, ".doctor-*"); err == nil {
+			_ = f.Close()
+			err = os.Remove(f.Name())
+		}
+	}
+	if errors.Is(statErr, fs.ErrNotExist) {
+		_ = os.Remove(dir)
+	}
+	if err != nil {
+		check.Status = runners.ToolchainFail
+		check.Detail = err.Error()
+		check.Remediation = fmt.Sprintf("Make %s writable, or point --profile and --history elsewhere.", dir)
+		return check
+	}
+	check.Status = runners.ToolchainPass
+	check.Detail = "profiles and history can be written"
+	return check
+}
+
+func printDoctorReport(report doctorReport, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+		return
+	}
+
+	failures := 0
+	for _, c := range report.Checks {
+		if c.Status == runners.ToolchainFail {
+			failures++
+		}
+		fmt.Fprintf(w, "[%s] %s — %s\n", strings.ToUpper(c.Status), c.Name, c.Detail)
+		if c.Remediation != "" {
+			fmt.Fprintf(w, "       fix: %s\n", strings.ReplaceAll(c.Remediation, "\n", "\n       "))
+		}
+	}
+	fmt.Fprintln(w)
+	if failures == 0 {
+		fmt.Fprintln(w, "All checks passed.")
+		return
+	}
+	fmt.Fprintf(w, "%d of %d checks failed.\n", failures, len(report.Checks))
+}
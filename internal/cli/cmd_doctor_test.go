@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDoctorJSON(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile(filepath.Join(dir, ".coverctl.yaml"), []byte("version: 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := runDoctor(context.Background(), []string{"-o", "json"}, &stdout, &stderr, GlobalOptions{})
+	if code != 1 {
+		t.Fatalf("expected exit 1 for invalid config, got %d (stderr: %s)", code, stderr.String())
+	}
+
+	var report doctorReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	statuses := map[string]string{}
+	for _, c := range report.Checks {
+		statuses[c.Name] = c.Status
+	}
+	want := map[string]string{
+		"project language": "warn",
+		"config":           "fail",
+		".cover writable":  "pass",
+	}
+	for name, status := range want {
+		if statuses[name] != status {
+			t.Errorf("expected %s to %s, got %q", name, status, statuses[name])
+		}
+	}
+	if report.Passed {
+		t.Error("expected passed=false")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".cover")); !os.IsNotExist(err) {
+		t.Errorf("expected doctor to remove the .cover dir it created, got %v", err)
+	}
+}
+
+func TestRunDoctorTextPassesWithWarnings(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var stdout, stderr bytes.Buffer
+	if code := runDoctor(context.Background(), nil, &stdout, &stderr, GlobalOptions{}); code != 0 {
+		t.Fatalf("expected exit 0 with only warnings, got %d:\n%s", code, stdout.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"[WARN] config", "fix: Run `coverctl init`", "[PASS] .cover writable", "All checks passed."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
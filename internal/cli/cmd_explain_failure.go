@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runExplainFailure implements `coverctl explain-failure`.
+func runExplainFailure(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("explain-failure")
+	fs.Usage = func() { commandHelp("explain-failure", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	reportPath := fs.String("report", "", "Explain the failures in this --report-file from an earlier run")
+	files := fs.Int("files", 3, "Lowest-covered files to list per domain")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *output != application.OutputText && *output != application.OutputJSON {
+		fmt.Fprintln(stderr, "explain-failure supports text and json output")
+		return 2
+	}
+	if *files < 1 {
+		fmt.Fprintln(stderr, "--files must be at least 1")
+		return 2
+	}
+
+	opts := application.ExplainOptions{ConfigPath: *configPath, Profile: *profile, Files: *files}
+	if *reportPath != "" {
+		report, err := readRunReport(*reportPath)
+		if err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+		opts.Report = &report
+	}
+	result, err := svc.ExplainFailure(ctx, opts)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !global.IsQuiet() {
+		for _, warning := range result.Warnings {
+			fmt.Fprintln(stderr, "Warning:", warning)
+		}
+	}
+	printExplainResult(result, stdout, *output)
+	return 0
+}
+
+// readRunReport loads a document written by --report-file.
+func readRunReport(path string) (application.RunReport, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return application.RunReport{}, fmt.Errorf("invalid path: %w", err)
+	}
+	data, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return application.RunReport{}, err
+	}
+	var report application.RunReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return application.RunReport{}, fmt.Errorf("read report %s: %w", path, err)
+	}
+	return report, nil
+}
+
+func printExplainResult(result application.ExplainResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+	if result.Passed {
+		fmt.Fprintln(w, "All domains meet their minimums; nothing to explain.")
+		return
+	}
+	for i, d := range result.Domains {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "Domain %s: %.1f%% covered, %.1f%% required (%d/%d %s)\n", d.Domain, d.Percent, d.Required, d.Covered, d.Total, d.Metric)
+		if len(d.Owners) > 0 {
+			fmt.Fprintf(w, "  Owners: %v\n", d.Owners)
+		}
+		fmt.Fprintf(w, "  Cover %d more %s to pass.\n", d.ToCover, d.Metric)
+		if len(d.Files) > 0 {
+			fmt.Fprintln(w, "  Lowest-covered files:")
+			for _, f := range d.Files {
+				fmt.Fprintf(w, "    %-48s %5.1f%%  (%d/%d)\n", f.File, f.Percent, f.Covered, f.Total)
+			}
+		}
+		fmt.Fprintln(w, "  Reproduce locally:")
+		for _, c := range d.Commands {
+			fmt.Fprintf(w, "    %s\n", c)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+)
+
+// runForecast implements `coverctl forecast`.
+func runForecast(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("forecast")
+	fs.Usage = func() { commandHelp("forecast", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	historyPath := fs.String("history", ".cover/history.json", "History file path")
+	horizon := fs.Int("horizon", 5, "History entries to project ahead")
+	lookback := fs.Int("lookback", 10, "Recent entries per domain to base the forecast on (0 = all)")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *output != application.OutputText && *output != application.OutputJSON {
+		fmt.Fprintln(stderr, "forecast supports text and json output")
+		return 2
+	}
+	if *horizon < 1 || *lookback < 0 {
+		fmt.Fprintln(stderr, "--horizon must be at least 1 and --lookback not negative")
+		return 2
+	}
+
+	store := history.FileStore{Path: *historyPath}
+	result, err := svc.Forecast(ctx, application.ForecastOptions{
+		ConfigPath: *configPath,
+		Horizon:    *horizon,
+		Lookback:   *lookback,
+	}, &store)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printForecastResult(result, stdout, *output)
+	return 0
+}
+
+func printForecastResult(result application.ForecastResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+
+	fmt.Fprintf(w, "Coverage Forecast (%d entries ahead)\n\n", result.Horizon)
+	fmt.Fprintf(w, "%-20s %9s %10s %9s %11s %8s  %s\n", "DOMAIN", "CURRENT", "PREDICTED", "PER RUN", "CONFIDENCE", "MIN", "REACHES MIN")
+	fmt.Fprintf(w, "%-20s %9s %10s %9s %11s %8s  %s\n", "------", "-------", "---------", "-------", "----------", "---", "-----------")
+	for _, f := range result.Domains {
+		reaches := "not at current trend"
+		switch {
+		case f.Met:
+			reaches = "met"
+		case f.ReachesMin != nil:
+			reaches = f.ReachesMin.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%-20s %8.1f%% %9.1f%% %+8.1f%% %10.1f%% %7.1f%%  %s\n",
+			f.Domain, f.Current, f.Predicted, f.PerEntry, f.Confidence, f.Min, reaches)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(w, "\nNot enough history to forecast: %s\n", strings.Join(result.Skipped, ", "))
+	}
+}
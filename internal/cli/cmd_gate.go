@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
+)
+
+// runGate implements `coverctl gate`: one pass over policy, file rules,
+// patch coverage, ratchet, and fail-under, with JSON and markdown summaries
+// written for CI artifacts and a single exit code.
+func runGate(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("gate")
+	fs.Usage = func() { commandHelp("gate", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	output := outputFlags(fs)
+	profile := &stringFlag{value: ".cover/coverage.out"}
+	fs.Var(profile, "profile", "Coverage profile path")
+	fs.Var(profile, "p", "Coverage profile path (shorthand)")
+	fromProfile := fs.Bool("from-profile", false, "Use existing coverage profile instead of running tests")
+	profileAge := profileAgeFlags(fs)
+	historyPath := fs.String("history", ".cover/history.json", "History file path for the ratchet check")
+	notes := notesFlags(fs, true)
+	ratchet := fs.Bool("ratchet", true, "Fail if overall coverage dropped since the last recorded run")
+	failUnder := fs.Float64("fail-under", 0, "Fail if overall coverage is below this percentage")
+	diffBase := fs.String("diff-base", "", "Enable diff mode against this git ref (\"auto\" uses the merge-base)")
+	summaryJSON := fs.String("summary-json", ".cover/gate.json", "Write the JSON gate summary to this path (empty disables)")
+	summaryMD := fs.String("summary-md", ".cover/gate.md", "Write the markdown gate summary to this path (empty disables)")
+	language := fs.String("language", "", "Override language detection (go, python, nodejs, rust, java)")
+	fs.StringVar(language, "l", "", "Override language detection (shorthand)")
+	runner := fs.String("runner", "", "Use this runner instead of auto-detection (go, python, node, rust, java, ...)")
+	tags := fs.String("tags", "", "Build tags (e.g., integration,e2e)")
+	race := fs.Bool("race", false, "Enable race detector")
+	timeout := fs.String("timeout", "", "Test timeout (e.g., 10m, 1h)")
+	maxRuntime := fs.String("max-runtime", "15m", "Hard ceiling on total command runtime (kills hung runners). 0 disables.")
+	var domains domainList
+	fs.Var(&domains, "domain", "Filter to specific domain (repeatable)")
+	fs.Var(&domains, "d", "Filter to specific domain (shorthand)")
+
+	reportFile := reportFileFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	runtimeCtx, runtimeCancel, err := withRuntimeLimit(ctx, *maxRuntime)
+	if err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+	defer runtimeCancel()
+
+	store := notes.store(*historyPath)
+	opts := application.CheckOptions{
+		ConfigPath:    *configPath,
+		Profile:       profile.value,
+		FromProfile:   *fromProfile,
+		Domains:       domains,
+		Language:      application.Language(*language),
+		Runner:        *runner,
+		DiffBase:      *diffBase,
+		Ratchet:       *ratchet,
+		HistoryStore:  store,
+		BaselineStore: store,
+		BuildFlags: application.BuildFlags{
+			Tags:    *tags,
+			Race:    *race,
+			Timeout: *timeout,
+		},
+	}
+	if *failUnder > 0 {
+		opts.FailUnder = failUnder
+	}
+	if err := profileAge.apply(&opts); err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+
+	reportOut, err := openReportFile(*reportFile)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if reportOut != nil {
+		defer reportOut.Close()
+		opts.ReportFile = reportOut
+	}
+
+	gate, err := svc.Gate(runtimeCtx, opts)
+	if err != nil {
+		writeJSONError(stdout, err, *output)
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+
+	if err := writeGateSummaries(gate, *summaryJSON, *summaryMD); err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+
+	if *output == application.OutputJSON {
+		if err := report.WriteGateJSON(stdout, gate); err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+	} else if !global.IsQuiet() || !gate.Passed {
+		printGate(gate, stdout)
+	}
+
+	if !gate.Passed {
+		return exitCodeWithCI(errors.New("coverage gate failed"), 1, stderr, global)
+	}
+	return 0
+}
+
+func writeGateSummaries(gate domain.Gate, jsonPath, mdPath string) error {
+	write := func(path string, fn func(io.Writer, domain.Gate) error) error {
+		if path == "" {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("create summary dir: %w", err)
+		}
+		f, err := os.Create(path) // #nosec G304 - path is user-provided CLI flag
+		if err != nil {
+			return fmt.Errorf("write gate summary: %w", err)
+		}
+		if err := fn(f, gate); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("write gate summary: %w", err)
+		}
+		return f.Close()
+	}
+	if err := write(jsonPath, report.WriteGateJSON); err != nil {
+		return err
+	}
+	return write(mdPath, report.WriteGateMarkdown)
+}
+
+func printGate(gate domain.Gate, w io.Writer) {
+	verdict := domain.StatusPass
+	if !gate.Passed {
+		verdict = domain.StatusFail
+	}
+	fmt.Fprintf(w, "Coverage gate: %s (overall %.1f%%)\n", verdict, gate.Overall)
+	for _, check := range gate.Checks {
+		fmt.Fprintf(w, "  %-11s %-5s %s\n", check.Name, check.Status, check.Detail)
+	}
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+	"github.com/felixgeelhaar/coverctl/internal/perf"
+)
+
+// runGenFixture implements the hidden `coverctl gen-fixture`, which writes
+// the synthetic profile the perf benchmarks use, for profiling coverctl
+// itself against a monorepo-sized input.
+func runGenFixture(args []string, stdout, stderr io.Writer, global GlobalOptions) int {
+	fs := newFlagSet("gen-fixture")
+	fs.Usage = func() { commandHelp("gen-fixture", stderr) }
+	files := fs.Int("files", perf.DefaultFiles, "Number of source files")
+	blocks := fs.Int("blocks", perf.DefaultBlocks, "Number of coverage blocks, spread over the files")
+	domains := fs.Int("domains", perf.DefaultDomains, "Number of top-level directories the files spread over")
+	module := fs.String("module", perf.DefaultModulePath, "Module path the profile's file paths start with")
+	seed := fs.Uint64("seed", 0, "Seed for hit counts; the same seed writes the same profile")
+	output := fs.String("output", "-", "Profile path (- for stdout)")
+	fs.StringVar(output, "o", "-", "Profile path (shorthand)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *files < 1 || *blocks < 1 || *domains < 1 {
+		fmt.Fprintln(stderr, "gen-fixture: --files, --blocks, and --domains must be positive")
+		return 2
+	}
+	opts := perf.Options{Files: *files, Blocks: *blocks, Domains: *domains, ModulePath: *module, Seed: *seed}
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(stderr, "gen-fixture: %v\n", err)
+		return 2
+	}
+
+	if *output == "-" {
+		if err := perf.Generate(stdout, opts); err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+		return 0
+	}
+	if err := writeFixture(*output, opts); err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !global.IsQuiet() {
+		fmt.Fprintf(stdout, "Wrote %d blocks in %d files to %s\n", *blocks, *files, *output)
+	}
+	return 0
+}
+
+// writeFixture writes the fixture for opts to path, creating its directory.
+func writeFixture(path string, opts perf.Options) error {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cleanPath), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return err
+	}
+	if err := perf.Generate(f, opts); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
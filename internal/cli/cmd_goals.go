@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+)
+
+// runGoals implements `coverctl goals`.
+func runGoals(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("goals")
+	fs.Usage = func() { commandHelp("goals", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	historyPath := fs.String("history", ".cover/history.json", "History file path")
+	lookback := fs.Int("lookback", 10, "Recent entries per domain to project from (0 = all)")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *output != application.OutputText && *output != application.OutputJSON {
+		fmt.Fprintln(stderr, "goals supports text and json output")
+		return 2
+	}
+	if *lookback < 0 {
+		fmt.Fprintln(stderr, "--lookback must not be negative")
+		return 2
+	}
+
+	store := history.FileStore{Path: *historyPath}
+	result, err := svc.Goals(ctx, application.GoalsOptions{
+		ConfigPath: *configPath,
+		Lookback:   *lookback,
+	}, &store)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printGoalsResult(result, stdout, *output)
+	return 0
+}
+
+func printGoalsResult(result application.GoalsResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+	if len(result.Goals) == 0 {
+		fmt.Fprintln(w, "No goals configured. Add goals: to the config to track coverage milestones.")
+		return
+	}
+
+	fmt.Fprint(w, "Coverage Goals\n\n")
+	fmt.Fprintf(w, "%-20s %8s %11s %9s %9s %9s  %-10s %s\n", "DOMAIN", "TARGET", "BY", "CURRENT", "PROGRESS", "PER RUN", "STATUS", "REACHES TARGET")
+	fmt.Fprintf(w, "%-20s %8s %11s %9s %9s %9s  %-10s %s\n", "------", "------", "--", "-------", "--------", "-------", "------", "--------------")
+	for _, g := range result.Goals {
+		reaches := "-"
+		switch {
+		case g.Status == domain.GoalMet:
+		case g.ReachesTarget != nil:
+			reaches = g.ReachesTarget.Format("2006-01-02")
+		case g.Status != domain.GoalUnknown:
+			reaches = "not at current trend"
+		}
+		fmt.Fprintf(w, "%-20s %7.1f%% %11s %8.1f%% %8.1f%% %+8.1f%%  %-10s %s\n",
+			g.Domain, g.Target, g.By, g.Current, g.Progress, g.PerEntry, g.Status, reaches)
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runHeatmap implements `coverctl heatmap`.
+func runHeatmap(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("heatmap")
+	fs.Usage = func() { commandHelp("heatmap", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	var domains domainList
+	fs.Var(&domains, "domain", "Filter to specific domain (repeatable)")
+	fs.Var(&domains, "d", "Filter to specific domain (shorthand)")
+	output := fs.String("output", "heatmap.html", "Output file path (- for stdout)")
+	fs.StringVar(output, "o", "heatmap.html", "Output file path (shorthand)")
+	title := fs.String("title", "", "HTML page title")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := svc.Heatmap(ctx, application.HeatmapOptions{
+		ConfigPath:  *configPath,
+		ProfilePath: *profile,
+		Domains:     domains,
+	})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if *output == "-" {
+		if err := report.WriteHeatmap(stdout, result, *title); err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+		return 0
+	}
+	if err := writeHeatmapFile(*output, result, *title); err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !global.IsQuiet() {
+		fmt.Fprintf(stdout, "Heatmap written to %s (%d files, %.1f%%)\n", *output, result.Files, result.Root.Percent)
+	}
+	return 0
+}
+
+func writeHeatmapFile(path string, result application.HeatmapResult, title string) error {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	file, err := os.Create(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return err
+	}
+	if err := report.WriteHeatmap(file, result, title); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -10,7 +9,7 @@ import (
 
 // runIgnore implements `coverctl ignore`.
 func runIgnore(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("ignore", flag.ContinueOnError)
+	fs := newFlagSet("ignore")
 	fs.Usage = func() { commandHelp("ignore", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
@@ -2,23 +2,26 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/preset"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/wizard"
 )
 
 // runInit implements `coverctl init`.
 func runInit(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs := newFlagSet("init")
 	fs.Usage = func() { commandHelp("init", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 	force := fs.Bool("force", false, "Overwrite existing config file")
 	fs.BoolVar(force, "f", false, "Overwrite existing config file (shorthand)")
 	noInteractive := fs.Bool("no-interactive", false, "Skip the interactive init wizard")
+	answersPath := fs.String("answers", "", "Apply wizard decisions from a YAML answers file instead of prompting")
+	presetRef := fs.String("preset", "", "Apply a preset: strict, balanced, legacy, or a preset URL or file")
 	if err := fs.Parse(args); err != nil {
 		return 2
 	}
@@ -26,7 +29,25 @@ func runInit(ctx context.Context, args []string, stdout, stderr io.Writer, svc S
 	if err != nil {
 		return exitCodeWithCI(err, 3, stderr, global)
 	}
-	if !*noInteractive {
+	// The preset seeds what the wizard asks about and finishes what it
+	// leaves out, so wizard and answers still override its minimums.
+	var chosen application.Preset
+	if *presetRef != "" {
+		if chosen, err = preset.NewLoader().Load(ctx, *presetRef); err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+		cfg = chosen.Seed(cfg)
+	}
+	switch {
+	case *answersPath != "":
+		answers, err := wizard.LoadAnswers(*answersPath)
+		if err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+		if cfg, err = wizard.ApplyAnswers(cfg, answers); err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+	case !*noInteractive:
 		var confirmed bool
 		cfg, confirmed, err = initWizard(cfg, stdout, os.Stdin)
 		if err != nil {
@@ -39,6 +60,7 @@ func runInit(ctx context.Context, args []string, stdout, stderr io.Writer, svc S
 			return 0
 		}
 	}
+	cfg = chosen.Finish(cfg)
 	if err := writeConfigFile(*configPath, cfg, stdout, *force); err != nil {
 		return exitCodeWithCI(err, 2, stderr, global)
 	}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// runLint implements `coverctl lint`.
+func runLint(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("lint")
+	fs.Usage = func() { commandHelp("lint", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := svc.Lint(ctx, application.LintOptions{ConfigPath: *configPath, ProfilePath: *profile})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printLintResult(result, stdout, *output)
+	if len(result.Findings) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func printLintResult(result application.LintResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+	for _, f := range result.Findings {
+		fmt.Fprintf(w, "%s: %s\n", f.Rule, f.Message)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(w, "Skipped without a profile: %s\n", strings.Join(result.Skipped, ", "))
+	}
+	if len(result.Findings) == 0 {
+		fmt.Fprintln(w, "No config problems found.")
+		return
+	}
+	fmt.Fprintf(w, "%d config problems found.\n", len(result.Findings))
+}
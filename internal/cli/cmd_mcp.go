@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 	"os"
@@ -47,10 +46,12 @@ func detectMCPMode() mcp.Mode {
 	return mcp.ModeAgent
 }
 
+// mcpTokenEnv holds the bearer token required by `mcp serve --transport http`.
+const mcpTokenEnv = "COVERCTL_MCP_TOKEN"
+
 // runMCP implements `coverctl mcp <subcommand>`.
 func runMCP(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
 	_ = svc
-	_ = global
 	if len(args) < 1 {
 		fmt.Fprintln(stderr, "Usage: coverctl mcp <subcommand>")
 		fmt.Fprintln(stderr, "Subcommands: serve, doctor")
@@ -60,14 +61,19 @@ func runMCP(ctx context.Context, args []string, stdout, stderr io.Writer, svc Se
 	case "doctor":
 		return runMCPDoctor(ctx, args[1:], stdout, stderr)
 	case "serve":
-		fs := flag.NewFlagSet("mcp serve", flag.ContinueOnError)
+		fs := newFlagSet("mcp serve")
 		fs.Usage = func() { commandHelp("mcp", stderr) }
 		configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 		fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 		historyPath := fs.String("history", ".cover/history.json", "History file path")
 		profilePath := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
 		fs.StringVar(profilePath, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
-		mode := fs.String("mode", "auto", "Tool surface mode: 'agent' (3 tools: check, suggest, debt), 'ci' (full 9-tool surface), or 'auto' (detect from CI environment variables)")
+		transportName := fs.String("transport", "stdio", "Transport: 'stdio' or 'http' (streamable HTTP with server-sent events)")
+		addr := fs.String("addr", mcp.DefaultHTTPAddr, "Listen address for --transport http (non-loopback needs "+mcpTokenEnv+")")
+		var allowHosts domainList
+		fs.Var(&allowHosts, "allow-host", "Host name clients may reach the HTTP server as, besides localhost (repeatable)")
+		logFile := fs.String("log-file", "", "Append session and protocol-error logs to this file as JSON")
+		mode := fs.String("mode", "auto", "Tool surface mode: 'agent' (5 tools: check, suggest, debt, uncovered, configure), 'ci' (full 11-tool surface), or 'auto' (detect from CI environment variables)")
 		if err := fs.Parse(args[1:]); err != nil {
 			return 2
 		}
@@ -84,6 +90,19 @@ func runMCP(ctx context.Context, args []string, stdout, stderr io.Writer, svc Se
 			fmt.Fprintf(stderr, "invalid --mode %q: must be 'agent', 'ci', or 'auto'\n", *mode)
 			return 2
 		}
+		if *transportName != "stdio" && *transportName != "http" {
+			fmt.Fprintf(stderr, "invalid --transport %q: must be 'stdio' or 'http'\n", *transportName)
+			return 2
+		}
+
+		if *logFile != "" {
+			closer, err := setupFileLogger(*logFile, global)
+			if err != nil {
+				fmt.Fprintf(stderr, "cannot open log file: %v\n", err)
+				return 2
+			}
+			defer closer.Close()
+		}
 
 		// BuildService requires *os.File for the legacy reporter; CLI's
 		// stdout is the canonical sink even when writing MCP frames over a
@@ -107,7 +126,24 @@ func runMCP(ctx context.Context, args []string, stdout, stderr io.Writer, svc Se
 			cancel()
 		}()
 
-		if err := mcpServer.Run(ctx); err != nil {
+		var err error
+		if *transportName == "http" {
+			// The token comes from the environment so it stays out of
+			// process listings and shell history.
+			httpOpts := mcp.HTTPOptions{Addr: *addr, Token: os.Getenv(mcpTokenEnv), AllowedHosts: allowHosts}
+			if err := httpOpts.Validate(); err != nil {
+				fmt.Fprintf(stderr, "%v (%s)\n", err, mcpTokenEnv)
+				return 2
+			}
+			if httpOpts.Token == "" {
+				fmt.Fprintf(stderr, "warning: %s is not set; any local process can call the MCP HTTP endpoint\n", mcpTokenEnv)
+			}
+			fmt.Fprintf(stderr, "coverctl MCP server listening on %s/mcp\n", *addr)
+			err = mcpServer.RunHTTP(ctx, httpOpts)
+		} else {
+			err = mcpServer.Run(ctx)
+		}
+		if err != nil {
 			fmt.Fprintf(stderr, "MCP server error: %v\n", err)
 			return 1
 		}
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 	"os"
@@ -44,7 +43,7 @@ import (
 // Returns 0 only when every step passes; non-zero otherwise. Designed
 // to be paste-able output for bug reports.
 func runMCPDoctor(ctx context.Context, args []string, stdout, stderr io.Writer) int {
-	fs := flag.NewFlagSet("mcp doctor", flag.ContinueOnError)
+	fs := newFlagSet("mcp doctor")
 	fs.Usage = func() { commandHelp("mcp", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path to validate")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
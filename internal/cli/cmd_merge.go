@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/shards"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runMerge implements `coverctl merge`, the fan-in step of sharded CI: it
+// waits for every shard's profile and merges them into one.
+func runMerge(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("merge")
+	fs.Usage = func() { commandHelp("merge", stderr) }
+	dir := fs.String("dir", "", "Directory the shard profiles are downloaded to")
+	expect := fs.Int("expect", 0, "Number of shard profiles to wait for")
+	pattern := fs.String("pattern", "", "Glob matched against profile file names (default: any recognised profile)")
+	timeout := fs.Duration("timeout", 10*time.Minute, "How long to wait for missing shards")
+	interval := fs.Duration("interval", shards.DefaultInterval, "How often to look for new shards")
+	output := fs.String("output", ".cover/coverage.out", "Merged profile path (- for stdout)")
+	fs.StringVar(output, "o", ".cover/coverage.out", "Merged profile path (shorthand)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *dir == "" {
+		fmt.Fprintln(stderr, "merge requires --dir")
+		return 2
+	}
+	if *expect < 1 {
+		fmt.Fprintln(stderr, "merge requires --expect with at least one shard")
+		return 2
+	}
+
+	exclude := ""
+	if *output != "-" {
+		exclude = *output
+	}
+	found, err := shards.Wait(ctx, shards.Options{
+		Dir:      *dir,
+		Pattern:  *pattern,
+		Exclude:  exclude,
+		Expect:   *expect,
+		Timeout:  *timeout,
+		Interval: *interval,
+	})
+	if err != nil {
+		var missing *shards.MissingError
+		if errors.As(err, &missing) {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+
+	if *output == "-" {
+		if _, err := shards.Merge(stdout, found); err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+		return 0
+	}
+	format, err := writeMergedProfile(*output, found)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !global.IsQuiet() {
+		if len(found) > *expect {
+			fmt.Fprintf(stdout, "Found %d shard profiles, more than the %d expected\n", len(found), *expect)
+		}
+		fmt.Fprintf(stdout, "Merged %d shard profiles into %s (%s)\n", len(found), *output, format)
+	}
+	return 0
+}
+
+// writeMergedProfile merges paths into the profile at path, creating its
+// directory.
+func writeMergedProfile(path string, paths []string) (string, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cleanPath), 0o755); err != nil {
+		return "", err
+	}
+	f, err := os.Create(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return "", err
+	}
+	format, err := shards.Merge(f, paths)
+	if err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return string(format), f.Close()
+}
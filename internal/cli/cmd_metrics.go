@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/prometheus"
+)
+
+// runMetrics implements `coverctl metrics push|write`, exporting coverage,
+// debt, and health score as Prometheus gauges.
+func runMetrics(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	if len(args) == 0 || (args[0] != "push" && args[0] != "write") {
+		commandHelp("metrics", stderr)
+		return 2
+	}
+	sub := args[0]
+
+	fs := newFlagSet("metrics " + sub)
+	fs.Usage = func() { commandHelp("metrics", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	gateway := fs.String("gateway", "", "Pushgateway URL (push only)")
+	job := fs.String("job", "coverctl", "Pushgateway job name (push only)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	var target string
+	switch sub {
+	case "push":
+		if *gateway == "" {
+			fmt.Fprintln(stderr, "metrics push requires --gateway")
+			return 2
+		}
+	case "write":
+		if fs.NArg() != 1 {
+			fmt.Fprintln(stderr, "metrics write requires a file path (use - for stdout)")
+			return 2
+		}
+		target = fs.Arg(0)
+	}
+
+	result, err := svc.Metrics(ctx, application.MetricsOptions{
+		ConfigPath:  *configPath,
+		ProfilePath: *profile,
+	})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+
+	switch {
+	case sub == "push":
+		err = prometheus.NewPushgateway(*gateway, *job).Push(ctx, result)
+	case target == "-":
+		err = prometheus.WriteText(stdout, result)
+	default:
+		err = prometheus.WriteTextfile(target, result)
+	}
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+
+	if !global.IsQuiet() && target != "-" {
+		if sub == "push" {
+			fmt.Fprintf(stdout, "Pushed coverage metrics to %s (job %q)\n", *gateway, *job)
+		} else {
+			fmt.Fprintf(stdout, "Wrote coverage metrics to %s\n", target)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runPatchReport implements `coverctl patch-report`.
+func runPatchReport(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("patch-report")
+	fs.Usage = func() { commandHelp("patch-report", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	base := fs.String("base", "", "Git ref to diff against (default: diff.base, then HEAD~1)")
+	output := fs.String("output", "patch-coverage.html", "Output file path (- for stdout)")
+	fs.StringVar(output, "o", "patch-coverage.html", "Output file path (shorthand)")
+	title := fs.String("title", "", "HTML page title")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := svc.PatchReport(ctx, application.PatchReportOptions{
+		ConfigPath:  *configPath,
+		ProfilePath: *profile,
+		Base:        *base,
+	})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if *output == "-" {
+		if err := report.WritePatchReport(stdout, result, *title); err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+		return 0
+	}
+	if err := writePatchReportFile(*output, result, *title); err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !global.IsQuiet() {
+		fmt.Fprintf(stdout, "Patch report written to %s (%d files, %d/%d changed lines covered, %.1f%%)\n",
+			*output, len(result.Files), result.Patch.Covered, result.Patch.Total, result.Patch.Percent)
+	}
+	return 0
+}
+
+func writePatchReportFile(path string, result application.PatchReportResult, title string) error {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	file, err := os.Create(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return err
+	}
+	if err := report.WritePatchReport(file, result, title); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
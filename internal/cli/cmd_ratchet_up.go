@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/config"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runRatchetUp implements `coverctl ratchet-up`.
+func runRatchetUp(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("ratchet-up")
+	fs.Usage = func() { commandHelp("ratchet-up", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	historyPath := fs.String("history", ".cover/history.json", "History file path")
+	notes := notesFlags(fs, true)
+	days := fs.Int("days", 14, "Days a threshold must have been met continuously")
+	maxStep := fs.Float64("max-step", 2, "Largest increase applied to a single domain per run")
+	auditFile := fs.String("audit-file", defaultAuditFile, "Policy audit log the raised thresholds are recorded in")
+	dryRun := fs.Bool("dry-run", false, "Report changes without writing the config")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *days <= 0 || *maxStep <= 0 {
+		fmt.Fprintln(stderr, "--days and --max-step must be positive")
+		return 2
+	}
+
+	store := notes.store(*historyPath)
+	result, err := svc.RatchetUp(ctx, application.RatchetUpOptions{
+		ConfigPath: *configPath,
+		Days:       *days,
+		MaxStep:    *maxStep,
+	}, store)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+
+	if len(result.Changes) > 0 && !*dryRun {
+		auditConfig(ctx, svc, *auditFile, *configPath, application.AuditManual, stderr)
+		applied, err := raiseDomainMins(*configPath, result.Changes, stderr)
+		if err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+		result.Changes = applied
+		auditConfig(ctx, svc, *auditFile, *configPath, application.AuditRatchetUp, stderr)
+	}
+	printRatchetUpResult(result, stdout, *output, *dryRun)
+	return 0
+}
+
+// raiseDomainMins writes the raised minimums into the config file through
+// config.Edit, so comments, key order, and extends survive. Domains the
+// file does not define, such as ones inherited through extends, are
+// skipped with a warning; the changes that were written are returned.
+func raiseDomainMins(path string, changes []application.ThresholdChange, stderr io.Writer) ([]application.ThresholdChange, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	info, err := os.Stat(cleanPath)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, err
+	}
+	applied := make([]application.ThresholdChange, 0, len(changes))
+	for _, c := range changes {
+		to := c.To
+		edited, err := config.Edit(raw, []config.Change{{Op: config.OpSetDomainMin, Domain: c.Domain, Min: &to}})
+		if errors.Is(err, config.ErrDomainNotFound) {
+			fmt.Fprintf(stderr, "warning: domain %s is not defined in %s (inherited through extends?); raise its minimum where it is defined\n", c.Domain, path)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		raw = edited
+		applied = append(applied, c)
+	}
+	if len(applied) == 0 {
+		return applied, nil
+	}
+	return applied, os.WriteFile(cleanPath, raw, info.Mode().Perm())
+}
+
+func printRatchetUpResult(result application.RatchetUpResult, w io.Writer, format application.OutputFormat, dryRun bool) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+
+	if len(result.Changes) == 0 {
+		fmt.Fprintf(w, "No thresholds raised: no domain sustained coverage above its minimum for %d days.\n", result.Days)
+	} else {
+		verb := "Raised"
+		if dryRun {
+			verb = "Would raise"
+		}
+		fmt.Fprintf(w, "%s %d threshold(s) sustained for %d days:\n", verb, len(result.Changes), result.Days)
+		for _, c := range result.Changes {
+			fmt.Fprintf(w, "  %-20s %5.1f%% -> %5.1f%% (sustained %.1f%%)\n", c.Domain, c.From, c.To, c.Sustained)
+		}
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(w, "Skipped (history shorter than %d days): %v\n", result.Days, result.Skipped)
+	}
+}
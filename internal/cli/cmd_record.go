@@ -2,28 +2,34 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
-	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/signing"
 )
 
 // runRecord implements `coverctl record`.
 func runRecord(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("record", flag.ContinueOnError)
+	fs := newFlagSet("record")
 	fs.Usage = func() { commandHelp("record", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
 	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
 	historyPath := fs.String("history", ".cover/history.json", "History file path")
-	commit := fs.String("commit", "", "Git commit SHA (optional)")
-	branch := fs.String("branch", "", "Git branch name (optional)")
+	notes := notesFlags(fs, false)
+	commit := fs.String("commit", "", "Git commit SHA (default: detected from CI or git)")
+	branch := fs.String("branch", "", "Git branch name (default: detected from CI or git)")
+	tag := fs.String("tag", "", "Git tag (default: detected from CI or git)")
+	pr := fs.Int("pr", 0, "Pull/merge request number (default: detected from CI)")
+	runID := fs.String("run-id", "", "CI run id (default: detected from CI)")
+	noDetect := fs.Bool("no-detect", false, "Do not detect commit, branch, and CI metadata")
+	signKey := fs.String("sign", "", "Sign the entry and its profiles with this Ed25519 private key (PEM)")
 	runCoverage := fs.Bool("run", false, "Run coverage before recording history")
 	language := fs.String("language", "", "Override language detection (go, python, nodejs, rust, java)")
 	fs.StringVar(language, "l", "", "Override language detection (shorthand)")
+	runner := fs.String("runner", "", "Use this runner instead of auto-detection (go, python, node, rust, java, ...)")
 	tags := fs.String("tags", "", "Build tags (e.g., integration,e2e)")
 	race := fs.Bool("race", false, "Enable race detector")
 	short := fs.Bool("short", false, "Skip long-running tests")
@@ -46,13 +52,26 @@ func runRecord(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 	defer runtimeCancel()
 	ctx = runtimeCtx
 
-	store := history.FileStore{Path: *historyPath}
+	var signer application.EntrySigner
+	if *signKey != "" {
+		s, err := signing.LoadSigner(*signKey)
+		if err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+		signer = s
+	}
+
+	store := notes.store(*historyPath)
 	recordOpts := application.RecordOptions{
 		ConfigPath:  *configPath,
 		ProfilePath: *profile,
 		HistoryPath: *historyPath,
 		Commit:      *commit,
 		Branch:      *branch,
+		Tag:         *tag,
+		PR:          *pr,
+		RunID:       *runID,
+		NoDetect:    *noDetect,
 		Run:         *runCoverage,
 		Domains:     domains,
 		BuildFlags: application.BuildFlags{
@@ -65,13 +84,15 @@ func runRecord(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 			TestArgs: testArgs,
 		},
 		Language: application.Language(*language),
+		Runner:   *runner,
+		Signer:   signer,
 	}
 
 	var recordResult application.RecordResult
 	if warnSvc, ok := svc.(recordWarner); ok {
-		recordResult, err = warnSvc.RecordWithWarnings(ctx, recordOpts, &store)
+		recordResult, err = warnSvc.RecordWithWarnings(ctx, recordOpts, store)
 	} else {
-		err = svc.Record(ctx, recordOpts, &store)
+		err = svc.Record(ctx, recordOpts, store)
 	}
 	if err != nil {
 		return exitCodeWithCI(err, 3, stderr, global)
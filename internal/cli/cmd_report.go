@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -11,34 +10,46 @@ import (
 
 // runReport implements `coverctl report`.
 func runReport(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	_ = stdout
-	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs := newFlagSet("report")
 	fs.Usage = func() { commandHelp("report", stderr) }
-	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path (\"-\" for stdin)")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 	output := outputFlags(fs)
+	html := htmlFlags(fs)
 	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
 	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
 	historyPath := fs.String("history", "", "History file path for delta display")
 	showDelta := fs.Bool("show-delta", false, "Show coverage change from previous run")
 	showUncovered := fs.Bool("uncovered", false, "Show only files with 0% coverage")
 	diffRef := fs.String("diff", "", "Show coverage for files changed since git ref")
+	fs.StringVar(diffRef, "diff-base", "", "Show coverage for files changed since git ref (alias for --diff; \"auto\" uses the merge-base)")
 	var mergeProfiles profileList
 	fs.Var(&mergeProfiles, "merge", "Merge additional coverage profile (repeatable)")
 	var domains domainList
 	fs.Var(&domains, "domain", "Filter to specific domain (repeatable)")
 	fs.Var(&domains, "d", "Filter to specific domain (shorthand)")
+	topFiles := fs.Int("top-files", 0, "List the N files with the most uncovered statements in failing domains")
+	pruneStale := fs.Bool("prune-stale", false, "Drop profile entries for files that no longer exist")
+	timing := fs.Bool("timing", false, "Show test time per domain from the last check or run")
+	summaryPath, noSummary := summaryFlags(fs)
+	reportFile := reportFileFlag(fs)
 	if err := fs.Parse(args); err != nil {
 		return 2
 	}
 	opts := application.ReportOptions{
 		ConfigPath:    *configPath,
 		Output:        *output,
+		HTML:          *html,
 		Profile:       *profile,
 		Domains:       domains,
 		ShowUncovered: *showUncovered,
 		DiffRef:       *diffRef,
 		MergeProfiles: mergeProfiles,
+		TopFiles:      *topFiles,
+		PruneStale:    *pruneStale,
+	}
+	if *timing {
+		opts.TimingStore = &history.TimingStore{Path: timingPath}
 	}
 	if *showDelta {
 		histPath := *historyPath
@@ -47,6 +58,23 @@ func runReport(ctx context.Context, args []string, stdout, stderr io.Writer, svc
 		}
 		opts.HistoryStore = &history.FileStore{Path: histPath}
 	}
-	err := svc.Report(ctx, opts)
+	summary, err := openSummary(*summaryPath, *noSummary)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if summary != nil {
+		defer summary.Close()
+		opts.Summary = summary
+	}
+	reportOut, err := openReportFile(*reportFile)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if reportOut != nil {
+		defer reportOut.Close()
+		opts.ReportFile = reportOut
+	}
+	err = svc.Report(ctx, opts)
+	writeJSONError(stdout, err, *output)
 	return exitCodeWithCI(err, 3, stderr, global)
 }
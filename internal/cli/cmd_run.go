@@ -2,17 +2,17 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
 )
 
 // runRun implements `coverctl run` (RunOnly: produce coverage artifacts
 // without policy evaluation).
 func runRun(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
 	_ = stdout
-	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs := newFlagSet("run")
 	fs.Usage = func() { commandHelp("run", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
@@ -20,6 +20,7 @@ func runRun(ctx context.Context, args []string, stdout, stderr io.Writer, svc Se
 	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile output path (shorthand)")
 	language := fs.String("language", "", "Override language detection (go, python, nodejs, rust, java)")
 	fs.StringVar(language, "l", "", "Override language detection (shorthand)")
+	runner := fs.String("runner", "", "Use this runner instead of auto-detection (go, python, node, rust, java, ...)")
 	tags := fs.String("tags", "", "Build tags (e.g., integration,e2e)")
 	race := fs.Bool("race", false, "Enable race detector")
 	short := fs.Bool("short", false, "Skip long-running tests")
@@ -27,6 +28,7 @@ func runRun(ctx context.Context, args []string, stdout, stderr io.Writer, svc Se
 	run := fs.String("run", "", "Run only tests matching pattern")
 	timeout := fs.String("timeout", "", "Test timeout (e.g., 10m, 1h)")
 	maxRuntime := fs.String("max-runtime", "15m", "Hard ceiling on total command runtime (kills hung runners). 0 disables.")
+	isolate := fs.Bool("isolate-domains", false, "Test each domain alone with -coverpkg limited to it (coverpkg.isolate)")
 	var testArgs testArgsList
 	fs.Var(&testArgs, "test-arg", "Additional argument passed to go test (repeatable)")
 	var domains domainList
@@ -43,10 +45,14 @@ func runRun(ctx context.Context, args []string, stdout, stderr io.Writer, svc Se
 	ctx = runtimeCtx
 
 	err = svc.RunOnly(ctx, application.RunOnlyOptions{
-		ConfigPath: *configPath,
-		Profile:    *profile,
-		Domains:    domains,
-		Language:   application.Language(*language),
+		ConfigPath:     *configPath,
+		Profile:        *profile,
+		Domains:        domains,
+		Language:       application.Language(*language),
+		Runner:         *runner,
+		Progress:       progressWriter(stderr, global, application.OutputText, *verbose),
+		TimingStore:    &history.TimingStore{Path: timingPath},
+		IsolateDomains: *isolate,
 		BuildFlags: application.BuildFlags{
 			Tags:     *tags,
 			Race:     *race,
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/scaffold"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// runScaffold implements `coverctl scaffold`.
+func runScaffold(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("scaffold")
+	fs.Usage = func() { commandHelp("scaffold", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	var domains domainList
+	fs.Var(&domains, "domain", "Filter to specific domain (repeatable)")
+	fs.Var(&domains, "d", "Filter to specific domain (shorthand)")
+	write := fs.Bool("write", false, "Write skeletons next to their sources instead of printing them")
+	force := fs.Bool("force", false, "With --write, overwrite existing skeleton files")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := svc.Scaffold(ctx, application.ScaffoldOptions{
+		ConfigPath:  *configPath,
+		ProfilePath: *profile,
+		Domains:     domains,
+	}, scaffold.Generator{})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	for _, f := range result.Files {
+		for _, skipped := range f.Skipped {
+			fmt.Fprintf(stderr, "warning: %s: skipped %s\n", f.Source, skipped)
+		}
+	}
+
+	if *write {
+		if err := writeScaffolds(result, *force, stdout, stderr, global); err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+		return 0
+	}
+	printScaffoldResult(result, stdout, *output)
+	return 0
+}
+
+// writeScaffolds writes each generated skeleton under the module root,
+// leaving existing files alone unless force is set.
+func writeScaffolds(result application.ScaffoldResult, force bool, stdout, stderr io.Writer, global GlobalOptions) error {
+	for _, f := range result.Files {
+		if len(f.Content) == 0 {
+			continue
+		}
+		cleanPath, err := pathutil.ValidatePath(filepath.Join(result.ModuleRoot, filepath.FromSlash(f.TestFile)))
+		if err != nil {
+			return fmt.Errorf("invalid path: %w", err)
+		}
+		if _, err := os.Stat(cleanPath); err == nil && !force {
+			fmt.Fprintf(stderr, "warning: %s exists; use --force to overwrite it\n", f.TestFile)
+			continue
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err := os.WriteFile(cleanPath, f.Content, 0o644); err != nil {
+			return err
+		}
+		if !global.IsQuiet() {
+			fmt.Fprintf(stdout, "Wrote %s (%d tests)\n", f.TestFile, len(f.Functions))
+		}
+	}
+	return nil
+}
+
+func printScaffoldResult(result application.ScaffoldResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+
+	written := false
+	for _, f := range result.Files {
+		if len(f.Content) == 0 {
+			continue
+		}
+		if written {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "// %s\n", f.TestFile)
+		_, _ = w.Write(f.Content)
+		written = true
+	}
+	if !written {
+		fmt.Fprintln(w, "No uncovered exported functions need test skeletons.")
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/testprofiles"
+)
+
+// runSelect implements `coverctl select`.
+func runSelect(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("select")
+	fs.Usage = func() { commandHelp("select", stderr) }
+	changed := fs.Bool("changed", false, "Select the tests covering files changed since --base")
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	base := fs.String("base", "", "Git ref to diff against (default diff.base, then HEAD~1)")
+	filesFrom := fs.String("files-from", "", "Read changed files from this list (\"-\" for stdin) instead of git")
+	profilesDir := fs.String("profiles-dir", "", "Directory of per-test profiles (<pkg dir>/<TestName>.out); empty selects whole packages")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if !*changed {
+		fmt.Fprintln(stderr, "Error: --changed is required")
+		fs.Usage()
+		return 2
+	}
+	if *configPath == "-" && *filesFrom == "-" {
+		fmt.Fprintln(stderr, "Error: --config - and --files-from - cannot both read standard input")
+		return 2
+	}
+
+	sel, err := svc.SelectTests(ctx, application.SelectOptions{
+		ConfigPath:  *configPath,
+		Base:        *base,
+		FilesFrom:   *filesFrom,
+		ProfilesDir: *profilesDir,
+	}, testprofiles.Dir{})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !global.IsQuiet() {
+		for _, file := range sel.Unmapped {
+			fmt.Fprintf(stderr, "warning: no recorded test covers %s; running its package in full\n", file)
+		}
+		if sel.Empty() {
+			fmt.Fprintln(stderr, "No Go files changed; nothing to select.")
+		}
+	}
+	printSelection(sel, stdout, *output)
+	return 0
+}
+
+// printSelection writes one go test command per line in text mode, so the
+// output can be piped to sh: named tests first, then whole packages.
+func printSelection(sel domain.TestSelection, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(sel)
+		return
+	}
+	if len(sel.TestPackages) > 0 {
+		fmt.Fprintf(w, "go test -run '%s' %s\n", sel.Run, strings.Join(sel.TestPackages, " "))
+	}
+	if len(sel.Packages) > 0 {
+		fmt.Fprintf(w, "go test %s\n", strings.Join(sel.Packages, " "))
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/selfupdate"
+)
+
+// releaseSource is the part of selfupdate.Client the command uses.
+type releaseSource interface {
+	Latest(ctx context.Context) (selfupdate.Release, error)
+	Tag(ctx context.Context, tag string) (selfupdate.Release, error)
+	Apply(ctx context.Context, rel selfupdate.Release, target string) error
+}
+
+var newReleaseSource = func() releaseSource { return selfupdate.NewClient() }
+
+// runSelfUpdate implements `coverctl self-update`.
+func runSelfUpdate(ctx context.Context, args []string, stdout, stderr io.Writer, global GlobalOptions) int {
+	fs := newFlagSet("self-update")
+	fs.Usage = func() { commandHelp("self-update", stderr) }
+	check := fs.Bool("check", false, "Only report whether a newer release exists")
+	version := fs.String("version", "", "Install this release tag instead of the latest (e.g. v1.14.0)")
+	force := fs.Bool("force", false, "Reinstall even when already up to date")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	source := newReleaseSource()
+	var rel selfupdate.Release
+	var err error
+	if *version != "" {
+		rel, err = source.Tag(ctx, *version)
+	} else {
+		rel, err = source.Latest(ctx)
+	}
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+
+	current := currentBuildInfo().Version
+	newer := selfupdate.Newer(current, rel.Tag)
+	if *check {
+		if newer {
+			fmt.Fprintf(stdout, "Update available: %s -> %s\n", current, rel.Tag)
+		} else {
+			fmt.Fprintf(stdout, "coverctl %s is up to date\n", current)
+		}
+		return 0
+	}
+	if !newer && *version == "" && !*force {
+		if !global.IsQuiet() {
+			fmt.Fprintf(stdout, "coverctl %s is up to date\n", current)
+		}
+		return 0
+	}
+
+	target, err := os.Executable()
+	if err == nil {
+		target, err = filepath.EvalSymlinks(target)
+	}
+	if err != nil {
+		return exitCodeWithCI(fmt.Errorf("locate running binary: %w", err), 3, stderr, global)
+	}
+	if err := source.Apply(ctx, rel, target); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			err = fmt.Errorf("%w; re-run with permission to write %s", err, target)
+		}
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	if !global.IsQuiet() {
+		fmt.Fprintf(stdout, "Updated coverctl %s -> %s (checksum verified)\n", current, rel.Tag)
+	}
+	return 0
+}
@@ -2,28 +2,35 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
 )
 
 // runSuggest implements `coverctl suggest`.
 func runSuggest(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("suggest", flag.ContinueOnError)
+	fs := newFlagSet("suggest")
 	fs.Usage = func() { commandHelp("suggest", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
 	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
-	strategy := fs.String("strategy", "current", "Suggestion strategy: current|aggressive|conservative")
+	strategy := fs.String("strategy", "current", "Suggestion strategy: current|aggressive|conservative|history")
+	historyPath := fs.String("history", ".cover/history.json", "History file path (used by --strategy history)")
 	apply := fs.Bool("apply", false, "Update config with suggested thresholds")
+	writeConfig := fs.String("write-config", "", "Write the suggested config to this path (\"-\" for stdout)")
+	auditFile := fs.String("audit-file", defaultAuditFile, "Policy audit log the written thresholds are recorded in")
 	force := fs.Bool("force", false, "Overwrite config if it exists")
 	fs.BoolVar(force, "f", false, "Overwrite config if it exists (shorthand)")
 	if err := fs.Parse(args); err != nil {
 		return 2
 	}
+	if *apply && *configPath == "-" {
+		fmt.Fprintln(stderr, "Error: --apply cannot update a config read from stdin; use --write-config PATH (or - for stdout) instead")
+		return 2
+	}
 
 	var suggestStrat application.SuggestStrategy
 	switch *strategy {
@@ -31,23 +38,47 @@ func runSuggest(ctx context.Context, args []string, stdout, stderr io.Writer, sv
 		suggestStrat = application.SuggestAggressive
 	case "conservative":
 		suggestStrat = application.SuggestConservative
+	case "history":
+		suggestStrat = application.SuggestHistory
 	default:
 		suggestStrat = application.SuggestCurrent
 	}
 
-	result, err := svc.Suggest(ctx, application.SuggestOptions{
+	opts := application.SuggestOptions{
 		ConfigPath:  *configPath,
 		ProfilePath: *profile,
 		Strategy:    suggestStrat,
-	})
+	}
+	if suggestStrat == application.SuggestHistory {
+		opts.HistoryStore = &history.FileStore{Path: *historyPath}
+	}
+	result, err := svc.Suggest(ctx, opts)
 	if err != nil {
 		return exitCodeWithCI(err, 3, stderr, global)
 	}
-	printSuggestResult(result, stdout)
+	summary := stdout
+	if *writeConfig == "-" {
+		// Keep stdout a pure YAML document for pipelines.
+		summary = stderr
+	}
+	printSuggestResult(result, summary)
+	if *writeConfig != "" {
+		if *writeConfig != "-" {
+			auditConfig(ctx, svc, *auditFile, *writeConfig, application.AuditManual, stderr)
+		}
+		if err := writeConfigFile(*writeConfig, result.Config, stdout, *force); err != nil {
+			return exitCodeWithCI(err, 2, stderr, global)
+		}
+		if *writeConfig != "-" {
+			auditConfig(ctx, svc, *auditFile, *writeConfig, application.AuditSuggest, stderr)
+		}
+	}
 	if *apply {
+		auditConfig(ctx, svc, *auditFile, *configPath, application.AuditManual, stderr)
 		if err := writeConfigFile(*configPath, result.Config, stdout, *force); err != nil {
 			return exitCodeWithCI(err, 2, stderr, global)
 		}
+		auditConfig(ctx, svc, *auditFile, *configPath, application.AuditSuggest, stderr)
 		if !global.IsQuiet() {
 			fmt.Fprintf(stdout, "\nConfig updated: %s\n", *configPath)
 		}
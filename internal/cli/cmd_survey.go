@@ -5,7 +5,6 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"flag"
 	"fmt"
 	"io"
 	"os"
@@ -34,7 +33,7 @@ import (
 func runSurvey(ctx context.Context, args []string, stdout, stderr io.Writer, global GlobalOptions) int {
 	_ = ctx
 	_ = global
-	fs := flag.NewFlagSet("survey", flag.ContinueOnError)
+	fs := newFlagSet("survey")
 	fs.Usage = func() { commandHelp("survey", stderr) }
 	nonInteractive := fs.String("answer", "", "Skip the prompt and record the given answer (very|somewhat|not|skip). Useful for scripted setups.")
 	dataDir := fs.String("data-dir", defaultSurveyDir(), "Directory to append survey responses (default: ~/.coverctl)")
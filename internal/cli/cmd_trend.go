@@ -2,36 +2,84 @@ package cli
 
 import (
 	"context"
-	"flag"
+	"encoding/json"
+	"fmt"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
-	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/report"
 )
 
 // runTrend implements `coverctl trend`.
 func runTrend(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("trend", flag.ContinueOnError)
+	fs := newFlagSet("trend")
 	fs.Usage = func() { commandHelp("trend", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
 	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
 	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
 	historyPath := fs.String("history", ".cover/history.json", "History file path")
+	notes := notesFlags(fs, true)
+	file := fs.String("file", "", "Show one file's recorded coverage (needs history.track_files)")
 	output := outputFlags(fs)
 	if err := fs.Parse(args); err != nil {
 		return 2
 	}
-	store := history.FileStore{Path: *historyPath}
+	if *file != "" && *output != application.OutputText && *output != application.OutputJSON {
+		fmt.Fprintln(stderr, "--file supports text and json output")
+		return 2
+	}
+	store := notes.store(*historyPath)
 	result, err := svc.Trend(ctx, application.TrendOptions{
 		ConfigPath:  *configPath,
 		ProfilePath: *profile,
 		HistoryPath: *historyPath,
 		Output:      *output,
-	}, &store)
+		File:        *file,
+	}, store)
 	if err != nil {
 		return exitCodeWithCI(err, 3, stderr, global)
 	}
+	if !global.IsQuiet() {
+		for _, a := range result.Anomalies {
+			fmt.Fprintln(stderr, "Warning:", a)
+		}
+	}
+	if *file != "" {
+		printFileTrend(result, stdout, *output)
+		return 0
+	}
+	if *output == application.OutputCSV || *output == application.OutputTSV {
+		if err := report.WriteTrendCSV(stdout, result.Entries, *output); err != nil {
+			return exitCodeWithCI(err, 3, stderr, global)
+		}
+		return 0
+	}
 	printTrendResult(result, stdout)
 	return 0
 }
+
+// printFileTrend shows one file's recorded coverage, oldest first.
+func printFileTrend(result application.TrendResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(struct {
+			File   string                  `json:"file"`
+			Points []domain.FileTrendPoint `json:"points"`
+		}{result.File, result.FileTrend})
+		return
+	}
+	fmt.Fprintf(w, "Coverage Trend for %s: %.1f%% %s %.1f%% (%+.1f%%)\n",
+		result.File, result.Previous, trendArrow(result.Trend.Direction), result.Current, result.Trend.Delta)
+	fmt.Fprintln(w)
+	for _, p := range result.FileTrend {
+		commit := p.Commit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		fmt.Fprintf(w, "  %s  %-7s  %5.1f%%  %d/%d  %s %+.1f%%\n",
+			p.Timestamp.UTC().Format("2006-01-02"), commit, p.Percent, p.Covered, p.Total, trendArrow(p.Trend.Direction), p.Trend.Delta)
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// runValidateProfile implements `coverctl validate-profile`.
+func runValidateProfile(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("validate-profile")
+	fs.Usage = func() { commandHelp("validate-profile", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fmt.Fprintln(stderr, "validate-profile takes one profile path")
+		return 2
+	}
+	if fs.NArg() == 1 {
+		*profile = fs.Arg(0)
+	}
+	if *output != application.OutputText && *output != application.OutputJSON {
+		fmt.Fprintln(stderr, "validate-profile supports text and json output")
+		return 2
+	}
+
+	result, err := svc.ValidateProfile(ctx, application.ValidateProfileOptions{
+		ConfigPath:  *configPath,
+		ProfilePath: *profile,
+	})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printProfileValidation(result, stdout, *output)
+	if !result.Valid {
+		return 1
+	}
+	return 0
+}
+
+func printProfileValidation(result application.ProfileValidation, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+
+	fmt.Fprintf(w, "Profile: %s (%s)\n", result.Profile, result.Format)
+	if len(result.Errors) > 0 {
+		fmt.Fprintln(w, "\nFormat errors:")
+		for _, e := range result.Errors {
+			fmt.Fprintf(w, "  %s\n", e)
+		}
+		fmt.Fprintln(w, "\nInvalid: fix the format errors before running check or report.")
+		return
+	}
+	fmt.Fprintf(w, "Files: %d, statements: %d\n", result.Files, result.Statements)
+	if result.Duplicates > 0 {
+		fmt.Fprintf(w, "Duplicate blocks: %d (normal with -coverpkg; merged when read)\n", result.Duplicates)
+	}
+	if len(result.Overlaps) > 0 {
+		fmt.Fprintln(w, "\nOverlapping blocks (profiles from different source revisions?):")
+		for _, o := range result.Overlaps {
+			fmt.Fprintf(w, "  %s\n", o)
+		}
+	}
+
+	if len(result.Domains) > 0 {
+		fmt.Fprintf(w, "\n%-20s %6s %9s  %s\n", "DOMAIN", "FILES", "COVERAGE", "STATEMENTS")
+		fmt.Fprintf(w, "%-20s %6s %9s  %s\n", "------", "-----", "--------", "----------")
+		for _, d := range result.Domains {
+			if d.Files == 0 {
+				fmt.Fprintf(w, "%-20s %6d %9s  no files from this profile\n", d.Domain, d.Files, "-")
+				continue
+			}
+			fmt.Fprintf(w, "%-20s %6d %8.1f%%  %d/%d\n", d.Domain, d.Files, d.Percent, d.Covered, d.Total)
+		}
+	}
+	if n := len(result.Unmatched); n > 0 {
+		fmt.Fprintf(w, "\nFiles in no domain (%d):\n", n)
+		for i, file := range result.Unmatched {
+			if i == 10 {
+				fmt.Fprintf(w, "  ... and %d more\n", n-i)
+				break
+			}
+			fmt.Fprintf(w, "  %s\n", file)
+		}
+	}
+
+	if result.Valid {
+		fmt.Fprintln(w, "\nValid")
+	} else {
+		fmt.Fprintln(w, "\nInvalid: regenerate the profile from a single test run of the current source.")
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/signing"
+)
+
+// runVerify implements `coverctl verify`.
+func runVerify(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("verify")
+	fs.Usage = func() { commandHelp("verify", stderr) }
+	historyPath := fs.String("history", ".cover/history.json", "History file path")
+	keyPath := fs.String("key", "", "Ed25519 public key (PEM) the entries were signed for")
+	var profiles domainList
+	fs.Var(&profiles, "profile", "Profile to compare with the newest signed entry (repeatable)")
+	fs.Var(&profiles, "p", "Profile to compare with the newest signed entry (shorthand)")
+	requireSigned := fs.Bool("require-signed", false, "Fail when any history entry is unsigned")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *output != application.OutputText && *output != application.OutputJSON {
+		fmt.Fprintln(stderr, "verify supports text and json output")
+		return 2
+	}
+	if *keyPath == "" {
+		fmt.Fprintln(stderr, "verify requires --key")
+		return 2
+	}
+	verifier, err := signing.LoadVerifier(*keyPath)
+	if err != nil {
+		return exitCodeWithCI(err, 2, stderr, global)
+	}
+
+	store := history.FileStore{Path: *historyPath}
+	result, err := svc.Verify(ctx, application.VerifyOptions{
+		ProfilePaths:  profiles,
+		RequireSigned: *requireSigned,
+		Verifier:      verifier,
+	}, &store)
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printVerifyResult(result, stdout, *output)
+	if !result.Passed {
+		return exitCodeWithCI(errors.New("history verification failed"), 1, stderr, global)
+	}
+	return 0
+}
+
+func printVerifyResult(result application.VerifyResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+	fmt.Fprintf(w, "Verified %d history entries: %d valid, %d invalid, %d unsigned\n",
+		len(result.Entries), result.Valid, result.Invalid, result.Unsigned)
+	for _, e := range result.Entries {
+		if e.Status == application.EntryValid {
+			continue
+		}
+		commit := e.Commit
+		if commit == "" {
+			commit = "-"
+		}
+		line := fmt.Sprintf("  entry %d  %s  %s  %s", e.Index, e.Timestamp.Format("2006-01-02 15:04"), commit, e.Status)
+		if e.Reason != "" {
+			line += ": " + e.Reason
+		}
+		fmt.Fprintln(w, line)
+	}
+	for _, p := range result.Profiles {
+		status := "matches the newest signed entry"
+		if !p.Matches {
+			status = "does not match the newest signed entry"
+		}
+		fmt.Fprintf(w, "Profile %s %s\n", p.Path, status)
+	}
+}
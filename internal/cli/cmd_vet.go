@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// runVet implements `coverctl vet`, which reports file rule failures as
+// linter diagnostics. Like go vet, it exits 1 when it reports anything.
+func runVet(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
+	fs := newFlagSet("vet")
+	fs.Usage = func() { commandHelp("vet", stderr) }
+	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
+	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
+	profile := fs.String("profile", ".cover/coverage.out", "Coverage profile path")
+	fs.StringVar(profile, "p", ".cover/coverage.out", "Coverage profile path (shorthand)")
+	output := outputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := svc.Vet(ctx, application.VetOptions{ConfigPath: *configPath, ProfilePath: *profile})
+	if err != nil {
+		return exitCodeWithCI(err, 3, stderr, global)
+	}
+	printVetResult(result, stdout, *output)
+	if len(result.Diagnostics) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printVetResult writes one diagnostic per line and nothing else, so the
+// text output can be fed to anything that parses go vet.
+func printVetResult(result application.VetResult, w io.Writer, format application.OutputFormat) {
+	if format == application.OutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+		return
+	}
+	for _, d := range result.Diagnostics {
+		fmt.Fprintln(w, d)
+	}
+}
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"flag"
 	"io"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -11,7 +10,7 @@ import (
 // runWatchCmd implements `coverctl watch`. (The legacy runWatch helper
 // remains the actual loop driver; this thin wrapper handles flag parsing.)
 func runWatchCmd(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int {
-	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs := newFlagSet("watch")
 	fs.Usage = func() { commandHelp("watch", stderr) }
 	configPath := fs.String("config", ".coverctl.yaml", "Config file path")
 	fs.StringVar(configPath, "c", ".coverctl.yaml", "Config file path (shorthand)")
@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// commandFunc is the signature every table entry dispatches to.
+type commandFunc func(ctx context.Context, args []string, stdout, stderr io.Writer, svc Service, global GlobalOptions) int
+
+// cliCommand is one entry of the command table. The table drives dispatch,
+// the usage listing, shell completion, and the man page, so a new command
+// only has to be added here.
+type cliCommand struct {
+	name        string
+	aliases     []string
+	summary     string
+	other       bool     // listed under "Other" in usage
+	hidden      bool     // dispatched, but not listed or completed
+	subcommands []string // first positional argument choices
+	run         commandFunc
+}
+
+// commandTable returns the commands in usage order. It is a function rather
+// than a variable because completion and man entries refer back to it.
+func commandTable() []cliCommand {
+	return []cliCommand{
+		{name: "check", aliases: []string{"c"}, summary: "Run coverage and enforce policy", run: runCheck},
+		{name: "gate", summary: "Run every CI check once and write gate summaries", run: runGate},
+		{name: "run", aliases: []string{"r"}, summary: "Run coverage only, produce artifacts", run: runRun},
+		{name: "watch", aliases: []string{"w"}, summary: "Watch for file changes and re-run coverage", run: runWatchCmd},
+		{name: "init", aliases: []string{"i"}, summary: "Interactive setup wizard", run: runInit},
+		{name: "detect", summary: "Autodetect domains and write config", run: runDetect},
+		{name: "ci", summary: "Bootstrap a config and CI pipeline", subcommands: []string{"init"}, run: runCI},
+		{name: "report", summary: "Analyze an existing profile", run: runReport},
+		{name: "explain-failure", summary: "Explain how to fix failing domains", run: runExplainFailure},
+		{name: "validate-profile", summary: "Check a coverage profile and the domains it feeds", run: runValidateProfile},
+		{name: "badge", summary: "Generate an SVG coverage badge", run: runBadge},
+		{name: "trend", summary: "Show coverage trends over time", run: runTrend},
+		{name: "forecast", summary: "Forecast domain coverage from recorded history", run: runForecast},
+		{name: "goals", summary: "Show progress toward coverage goals", run: runGoals},
+		{name: "record", summary: "Record current coverage to history", run: runRecord},
+		{name: "verify", summary: "Verify signed history entries and profiles", run: runVerify},
+		{name: "suggest", summary: "Suggest optimal coverage thresholds", run: runSuggest},
+		{name: "ratchet-up", summary: "Raise thresholds that history shows are reliably met", run: runRatchetUp},
+		{name: "audit", summary: "Show the log of threshold changes", run: runAudit},
+		{name: "debt", summary: "Show coverage debt report", subcommands: []string{"plan"}, run: runDebt},
+		{name: "metrics", summary: "Export coverage metrics to Prometheus", subcommands: []string{"push", "write"}, run: runMetrics},
+		{name: "compare", summary: "Compare coverage between two profiles", run: runCompare},
+		{name: "diff-config", summary: "Show policy changes between two configs or git revisions", run: runDiffConfig},
+		{name: "lint", summary: "Find dead domains, unused excludes, and other config rot", run: runLint},
+		{name: "vet", summary: "Report file rule failures as linter diagnostics", run: runVet},
+		{name: "blame", summary: "Attribute uncovered lines to authors and commits", run: runBlame},
+		{name: "heatmap", summary: "Export a coverage treemap of directories as HTML", run: runHeatmap},
+		{name: "patch-report", summary: "Export an HTML view of changed lines and their coverage", run: runPatchReport},
+		{name: "aggregate", summary: "Combine JSON reports and histories from several repositories", run: runAggregate},
+		{name: "merge", summary: "Wait for sharded CI profiles and merge them", run: runMerge},
+		{name: "scaffold", summary: "Generate test skeletons for uncovered exported functions", run: runScaffold},
+		{name: "select", summary: "Select the tests covering changed files for a fast first CI pass", run: runSelect},
+		{name: "ignore", summary: "Show configured excludes and ignore advice", run: runIgnore},
+		{name: "annotate", summary: "Add coverctl:ignore pragmas to files in bulk", run: runAnnotate},
+		{name: "pr-comment", summary: "Post coverage report as PR/MR comment (GitHub, GitLab, Bitbucket)", run: runPRComment},
+		{name: "mcp", summary: "MCP (Model Context Protocol) server for AI agents", subcommands: []string{"serve", "doctor"}, run: runMCP},
+		{name: "doctor", summary: "Check toolchains, config, and write access", run: func(ctx context.Context, args []string, stdout, stderr io.Writer, _ Service, global GlobalOptions) int {
+			return runDoctor(ctx, args, stdout, stderr, global)
+		}},
+		{name: "survey", summary: "Answer the product-market fit survey", hidden: true, run: func(ctx context.Context, args []string, stdout, stderr io.Writer, _ Service, global GlobalOptions) int {
+			return runSurvey(ctx, args, stdout, stderr, global)
+		}},
+		{name: "gen-fixture", summary: "Write a synthetic coverage profile for benchmarks", hidden: true, run: func(_ context.Context, args []string, stdout, stderr io.Writer, _ Service, global GlobalOptions) int {
+			return runGenFixture(args, stdout, stderr, global)
+		}},
+		{name: "help", summary: "Show help for a command", other: true, run: func(_ context.Context, args []string, stdout, _ io.Writer, _ Service, _ GlobalOptions) int {
+			if len(args) < 1 {
+				usage(stdout)
+				return 0
+			}
+			return commandHelp(args[0], stdout)
+		}},
+		{name: "version", summary: "Show version information", other: true, run: func(_ context.Context, args []string, stdout, stderr io.Writer, _ Service, _ GlobalOptions) int {
+			return runVersion(args, stdout, stderr)
+		}},
+		{name: "self-update", summary: "Replace coverctl with the latest verified GitHub release", other: true, run: func(ctx context.Context, args []string, stdout, stderr io.Writer, _ Service, global GlobalOptions) int {
+			return runSelfUpdate(ctx, args, stdout, stderr, global)
+		}},
+		{name: "completion", summary: "Generate shell completion scripts", other: true, subcommands: completionShells, run: func(_ context.Context, args []string, stdout, stderr io.Writer, _ Service, _ GlobalOptions) int {
+			return runCompletion(args, stdout, stderr)
+		}},
+		{name: "man", summary: "Generate the coverctl(1) man page", other: true, run: func(_ context.Context, _ []string, stdout, _ io.Writer, _ Service, _ GlobalOptions) int {
+			writeManPage(stdout)
+			return 0
+		}},
+	}
+}
+
+// findCommand looks a command up by name or alias.
+func findCommand(name string) (cliCommand, bool) {
+	for _, c := range commandTable() {
+		if c.name == name {
+			return c, true
+		}
+		for _, alias := range c.aliases {
+			if alias == name {
+				return c, true
+			}
+		}
+	}
+	return cliCommand{}, false
+}
+
+// visibleCommands returns the commands listed in usage and completion.
+func visibleCommands() []cliCommand {
+	var out []cliCommand
+	for _, c := range commandTable() {
+		if !c.hidden {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// cliFlag is a flag as it appears in completion and the man page: a long
+// name, its one-letter shorthand when the FlagSet defines one, and usage.
+type cliFlag struct {
+	name   string
+	short  string
+	usage  string
+	def    string
+	isBool bool
+}
+
+// spelling returns the flag as typed on the command line, preferring the
+// long form.
+func (f cliFlag) spelling() string {
+	if len(f.name) == 1 {
+		return "-" + f.name
+	}
+	return "--" + f.name
+}
+
+// flagValueChoices lists the fixed values of enumerated flags for completion.
+var flagValueChoices = map[string][]string{
+	"output":    {"text", "json", "html", "brief", "gitlab", "azure", "csv", "tsv"},
+	"runner":    {"go", "python", "node", "rust", "java", "csharp", "cpp", "php", "ruby", "swift", "dart", "scala", "elixir", "shell"},
+	"language":  {"go", "python", "nodejs", "rust", "java"},
+	"strategy":  {"current", "aggressive", "conservative"},
+	"style":     {"flat", "flat-square"},
+	"transport": {"stdio", "http"},
+	"mode":      {"agent", "ci", "auto"},
+	"provider":  {"auto", "github", "gitlab", "bitbucket"},
+	"answer":    {"very", "somewhat", "not", "skip"},
+}
+
+// flagFileGlobs maps path flags to the file pattern completion offers.
+var flagFileGlobs = map[string]string{
+	"config":  "*.yaml",
+	"answers": "*.yaml",
+	"profile": "*.out",
+	"merge":   "*.out",
+	"base":    "*.out",
+	"head":    "*.out",
+	"history": "*.json",
+}
+
+var (
+	flagCaptureMu sync.Mutex
+	flagCapture   *[]*flag.FlagSet
+)
+
+// newFlagSet creates a command's FlagSet. While commandFlags is collecting,
+// the set is recorded and its output discarded.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if flagCapture != nil {
+		fs.SetOutput(io.Discard)
+		*flagCapture = append(*flagCapture, fs)
+	}
+	return fs
+}
+
+// commandFlags returns the flags of a command (or of one of its
+// subcommands). It runs the command with --help, which every command
+// rejects right after defining its FlagSet, so completion and the man page
+// read the same definitions the command parses.
+func commandFlags(c cliCommand, sub string) []cliFlag {
+	if c.name == "help" || c.name == "completion" || c.name == "man" {
+		return nil
+	}
+	flagCaptureMu.Lock()
+	defer flagCaptureMu.Unlock()
+	var sets []*flag.FlagSet
+	flagCapture = &sets
+	defer func() { flagCapture = nil }()
+
+	args := []string{"--help"}
+	if sub != "" {
+		args = []string{sub, "--help"}
+	}
+	c.run(context.Background(), args, io.Discard, io.Discard, nil, GlobalOptions{Quiet: true})
+	if len(sets) == 0 {
+		return nil
+	}
+	return flagsOf(sets[0])
+}
+
+// flagsOf folds one-letter shorthands into the long flag bound to the same
+// variable.
+func flagsOf(fs *flag.FlagSet) []cliFlag {
+	var longs, shorts []*flag.Flag
+	fs.VisitAll(func(f *flag.Flag) {
+		if len(f.Name) == 1 {
+			shorts = append(shorts, f)
+		} else {
+			longs = append(longs, f)
+		}
+	})
+	used := make(map[string]bool)
+	var out []cliFlag
+	for _, f := range longs {
+		entry := cliFlag{name: f.Name, usage: f.Usage, def: f.DefValue, isBool: isBoolFlag(f)}
+		for _, s := range shorts {
+			if !used[s.Name] && s.Value == f.Value {
+				entry.short = s.Name
+				used[s.Name] = true
+				break
+			}
+		}
+		out = append(out, entry)
+	}
+	for _, s := range shorts {
+		if !used[s.Name] {
+			out = append(out, cliFlag{name: s.Name, usage: s.Usage, def: s.DefValue, isBool: isBoolFlag(s)})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// allCommandFlags merges the flags of a command and its subcommands, since
+// completion does not track which subcommand was typed.
+func allCommandFlags(c cliCommand) []cliFlag {
+	flags := commandFlags(c, "")
+	seen := make(map[string]bool)
+	for _, f := range flags {
+		seen[f.name] = true
+	}
+	for _, sub := range c.subcommands {
+		for _, f := range commandFlags(c, sub) {
+			if !seen[f.name] {
+				seen[f.name] = true
+				flags = append(flags, f)
+			}
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].name < flags[j].name })
+	return flags
+}
+
+// commandNames returns every visible command name and alias.
+func commandNames() []string {
+	var names []string
+	for _, c := range visibleCommands() {
+		names = append(names, c.name)
+		names = append(names, c.aliases...)
+	}
+	return names
+}
+
+// globalFlags are parsed by parseGlobalFlags before the command name.
+var globalFlags = []cliFlag{
+	{name: "quiet", short: "q", usage: "Suppress non-essential output", isBool: true},
+	{name: "no-color", usage: "Disable colored output", isBool: true},
+	{name: "ci", usage: "CI mode: quiet + GitHub Actions annotations", isBool: true},
+	{name: "debug", usage: "Emit JSON structured debug logs to stderr", isBool: true},
+	{name: "no-cache", usage: "Do not reuse or store prepared coverage analysis", isBool: true},
+	{name: "chdir", short: "C", usage: "Run as if coverctl was started in this directory"},
+}
+
+// firstLine trims a flag usage to its first line for one-line descriptions.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
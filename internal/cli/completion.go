@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// completionShells are the shells `coverctl completion` generates scripts for.
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+func runCompletion(args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprintf(stderr, "Usage: coverctl completion <%s>\n", strings.Join(completionShells, "|"))
+		return 2
+	}
+
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(stdout)
+	case "zsh":
+		writeZshCompletion(stdout)
+	case "fish":
+		writeFishCompletion(stdout)
+	case "powershell":
+		writePowerShellCompletion(stdout)
+	default:
+		fmt.Fprintf(stderr, "Unknown shell: %s\nSupported: %s\n", args[0], strings.Join(completionShells, ", "))
+		return 2
+	}
+	return 0
+}
+
+// commandPattern returns the name and aliases of c joined with sep.
+func commandPattern(c cliCommand, sep string) string {
+	return strings.Join(append([]string{c.name}, c.aliases...), sep)
+}
+
+// flagWords returns every spelling of flags, short forms included.
+func flagWords(flags []cliFlag) []string {
+	var words []string
+	for _, f := range flags {
+		words = append(words, f.spelling())
+		if f.short != "" {
+			words = append(words, "-"+f.short)
+		}
+	}
+	return words
+}
+
+func writeBashCompletion(w io.Writer) {
+	globals := flagWords(globalFlags)
+
+	// Value completion is keyed by flag spelling, gathered from every command.
+	values := make(map[string]string)
+	for _, c := range visibleCommands() {
+		for _, f := range allCommandFlags(c) {
+			var reply string
+			if choices, ok := flagValueChoices[f.name]; ok {
+				reply = fmt.Sprintf(`COMPREPLY=( $(compgen -W "%s" -- "${cur}") )`, strings.Join(choices, " "))
+			} else if glob, ok := flagFileGlobs[f.name]; ok {
+				reply = fmt.Sprintf(`COMPREPLY=( $(compgen -f -X '!%s' -- "${cur}") )`, glob)
+			} else {
+				continue
+			}
+			values[f.spelling()] = reply
+			if f.short != "" {
+				values["-"+f.short] = reply
+			}
+		}
+	}
+	spellings := make([]string, 0, len(values))
+	for s := range values {
+		spellings = append(spellings, s)
+	}
+	sort.Strings(spellings)
+
+	fmt.Fprintln(w, "# coverctl bash completion")
+	fmt.Fprintln(w, "_coverctl() {")
+	fmt.Fprintln(w, "    local cur prev cmd i opts")
+	fmt.Fprintln(w, "    COMPREPLY=()")
+	fmt.Fprintln(w, `    cur="${COMP_WORDS[COMP_CWORD]}"`)
+	fmt.Fprintln(w, `    prev="${COMP_WORDS[COMP_CWORD-1]}"`)
+	fmt.Fprintln(w, `    cmd=""`)
+	fmt.Fprintln(w, "    for ((i=1; i<COMP_CWORD; i++)); do")
+	fmt.Fprintln(w, `        if [[ ${COMP_WORDS[i]} != -* ]]; then`)
+	fmt.Fprintln(w, `            cmd="${COMP_WORDS[i]}"`)
+	fmt.Fprintln(w, "            break")
+	fmt.Fprintln(w, "        fi")
+	fmt.Fprintln(w, "    done")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    if [[ -z ${cmd} ]]; then`)
+	fmt.Fprintf(w, "        COMPREPLY=( $(compgen -W \"%s %s\" -- \"${cur}\") )\n", strings.Join(commandNames(), " "), strings.Join(globals, " "))
+	fmt.Fprintln(w, "        return 0")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    case "${prev}" in`)
+	for _, s := range spellings {
+		fmt.Fprintf(w, "        %s)\n            %s\n            return 0\n            ;;\n", s, values[s])
+	}
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    case "${cmd}" in`)
+	for _, c := range visibleCommands() {
+		words := append(append([]string(nil), c.subcommands...), flagWords(allCommandFlags(c))...)
+		fmt.Fprintf(w, "        %s)\n            opts=\"%s\"\n            ;;\n", commandPattern(c, "|"), strings.Join(words, " "))
+	}
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, `    COMPREPLY=( $(compgen -W "${opts}" -- "${cur}") )`)
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -F _coverctl coverctl")
+}
+
+// zshEscape escapes text for an _arguments description or _describe entry.
+func zshEscape(s string) string {
+	return strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`).Replace(firstLine(s))
+}
+
+// zshFlagSpec renders one _arguments spec for a flag spelling.
+func zshFlagSpec(spelling string, f cliFlag) string {
+	spec := fmt.Sprintf("'%s[%s]", spelling, zshEscape(f.usage))
+	switch {
+	case f.isBool:
+	case flagValueChoices[f.name] != nil:
+		spec += fmt.Sprintf(":%s:(%s)", f.name, strings.Join(flagValueChoices[f.name], " "))
+	case flagFileGlobs[f.name] != "":
+		spec += fmt.Sprintf(`:file:_files -g "%s"`, flagFileGlobs[f.name])
+	default:
+		spec += ":" + f.name + ":"
+	}
+	return spec + "'"
+}
+
+func writeZshCompletion(w io.Writer) {
+	fmt.Fprintln(w, "#compdef coverctl")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "_coverctl() {")
+	fmt.Fprintln(w, "    local -a commands")
+	fmt.Fprintln(w, "    commands=(")
+	for _, c := range visibleCommands() {
+		fmt.Fprintf(w, "        '%s:%s'\n", c.name, zshEscape(c.summary))
+		for _, alias := range c.aliases {
+			fmt.Fprintf(w, "        '%s:%s (alias)'\n", alias, zshEscape(c.summary))
+		}
+	}
+	fmt.Fprintln(w, "    )")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    _arguments -C \`)
+	for _, f := range globalFlags {
+		for _, spelling := range flagWords([]cliFlag{f}) {
+			fmt.Fprintf(w, "        %s \\\n", zshFlagSpec(spelling, f))
+		}
+	}
+	fmt.Fprintln(w, `        '1: :->command' \`)
+	fmt.Fprintln(w, `        '*:: :->args'`)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    case $state in")
+	fmt.Fprintln(w, "        command)")
+	fmt.Fprintln(w, "            _describe 'command' commands")
+	fmt.Fprintln(w, "            ;;")
+	fmt.Fprintln(w, "        args)")
+	fmt.Fprintln(w, "            case $words[1] in")
+	for _, c := range visibleCommands() {
+		var specs []string
+		if len(c.subcommands) > 0 {
+			specs = append(specs, fmt.Sprintf("'1:subcommand:(%s)'", strings.Join(c.subcommands, " ")))
+		}
+		for _, f := range allCommandFlags(c) {
+			for _, spelling := range flagWords([]cliFlag{f}) {
+				specs = append(specs, zshFlagSpec(spelling, f))
+			}
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "                %s)\n", commandPattern(c, "|"))
+		fmt.Fprintf(w, "                    _arguments \\\n                        %s\n", strings.Join(specs, " \\\n                        "))
+		fmt.Fprintln(w, "                    ;;")
+	}
+	fmt.Fprintln(w, "            esac")
+	fmt.Fprintln(w, "            ;;")
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `_coverctl "$@"`)
+}
+
+// fishQuote single-quotes s for fish, where double quotes expand variables.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(firstLine(s)) + "'"
+}
+
+// fishFlag renders the -s/-l options and value handling of one flag.
+func fishFlag(f cliFlag) string {
+	var parts []string
+	if f.short != "" {
+		parts = append(parts, "-s "+f.short)
+	}
+	if len(f.name) == 1 {
+		parts = append(parts, "-s "+f.name)
+	} else {
+		parts = append(parts, "-l "+f.name)
+	}
+	parts = append(parts, "-d "+fishQuote(f.usage))
+	switch {
+	case f.isBool:
+	case flagValueChoices[f.name] != nil:
+		parts = append(parts, "-r -a "+fishQuote(strings.Join(flagValueChoices[f.name], " ")))
+	case flagFileGlobs[f.name] != "":
+		parts = append(parts, "-r -F")
+	default:
+		parts = append(parts, "-r")
+	}
+	return strings.Join(parts, " ")
+}
+
+func writeFishCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# coverctl fish completion")
+	fmt.Fprintln(w, "complete -c coverctl -f")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "# Global flags")
+	for _, f := range globalFlags {
+		fmt.Fprintf(w, "complete -c coverctl -n __fish_use_subcommand %s\n", fishFlag(f))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "# Commands")
+	for _, c := range visibleCommands() {
+		fmt.Fprintf(w, "complete -c coverctl -n __fish_use_subcommand -a %s -d %s\n", c.name, fishQuote(c.summary))
+		for _, alias := range c.aliases {
+			fmt.Fprintf(w, "complete -c coverctl -n __fish_use_subcommand -a %s -d %s\n", alias, fishQuote(c.summary+" (alias)"))
+		}
+	}
+	for _, c := range visibleCommands() {
+		flags := allCommandFlags(c)
+		if len(flags) == 0 && len(c.subcommands) == 0 {
+			continue
+		}
+		condition := fishQuote("__fish_seen_subcommand_from " + commandPattern(c, " "))
+		fmt.Fprintf(w, "\n# %s\n", c.name)
+		if len(c.subcommands) > 0 {
+			fmt.Fprintf(w, "complete -c coverctl -n %s -a %s\n", condition, fishQuote(strings.Join(c.subcommands, " ")))
+		}
+		for _, f := range flags {
+			fmt.Fprintf(w, "complete -c coverctl -n %s %s\n", condition, fishFlag(f))
+		}
+	}
+}
+
+// psQuote single-quotes s for PowerShell.
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func psList(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = psQuote(word)
+	}
+	return "@(" + strings.Join(quoted, ", ") + ")"
+}
+
+func writePowerShellCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# coverctl PowerShell completion")
+	fmt.Fprintln(w, "Register-ArgumentCompleter -Native -CommandName coverctl -ScriptBlock {")
+	fmt.Fprintln(w, "    param($wordToComplete, $commandAst, $cursorPosition)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    $commandWords = @{")
+	for _, c := range visibleCommands() {
+		words := append(append([]string(nil), c.subcommands...), flagWords(allCommandFlags(c))...)
+		for _, name := range append([]string{c.name}, c.aliases...) {
+			fmt.Fprintf(w, "        %s = %s\n", psQuote(name), psList(words))
+		}
+	}
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintf(w, "    $topWords = %s\n", psList(append(commandNames(), flagWords(globalFlags)...)))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    $elements = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })")
+	fmt.Fprintln(w, "    if ($wordToComplete -ne '' -and $elements.Count -gt 0) {")
+	fmt.Fprintln(w, "        $elements = @($elements | Select-Object -SkipLast 1)")
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "    $command = $elements | Where-Object { $_ -notlike '-*' } | Select-Object -First 1")
+	fmt.Fprintln(w, "    $candidates = $topWords")
+	fmt.Fprintln(w, "    if ($command -and $commandWords.ContainsKey($command)) {")
+	fmt.Fprintln(w, "        $candidates = $commandWords[$command]")
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "    $candidates | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {")
+	fmt.Fprintln(w, "        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)")
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "}")
+}
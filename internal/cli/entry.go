@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/covdata"
+)
+
+// Main runs coverctl with the process arguments and standard streams and
+// returns the exit code. Both main packages (the module root, which release
+// builds use, and cmd/coverctl) call it, so setup, the telemetry flush, and
+// temp file cleanup cannot differ between them.
+func Main() int {
+	args, err := ResolveWorkdir(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	svc := BuildService(os.Stdout)
+	code := Run(args, os.Stdout, os.Stderr, svc)
+	ShutdownTelemetry(svc, os.Stderr)
+	_ = covdata.Cleanup()
+	return code
+}
@@ -0,0 +1,1263 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var commandHelpText = map[string]string{
+	"check": `coverctl check - Run coverage and enforce policy
+
+Usage:
+  coverctl check [flags]
+
+Aliases:
+  c
+
+Flags:
+  -c, --config string    Config file path, "-" for stdin (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile output path (default ".cover/coverage.out")
+      --from-profile     Use existing coverage profile instead of running tests
+      --max-profile-age <age>  With --from-profile, reject a profile older than age
+                         (30m, 12h, 1d) or than its source files (profile.max_age)
+      --stale-profile <action> What a stale profile does: fail|warn (profile.stale, default fail)
+  -d, --domain string    Filter to specific domain (repeatable)
+  -o, --output string    Output format: text|json|html|brief|gitlab|azure|csv|tsv (default "text")
+                         Use 'brief' for single-line LLM/agent-optimized output
+                         Use 'gitlab'/'azure' for Cobertura XML native to those CI systems
+                         Use 'csv'/'tsv' for one row per domain (spreadsheets, BI tools)
+      --title string     HTML report title (default "Coverage Report")
+      --self-contained   Embed uncovered source snippets in the HTML report
+      --show-delta       Show coverage change from previous run
+      --history string   History file path for delta display
+      --notes            Read history from git notes (refs/notes/coverctl)
+      --notes-branch <branch>
+                         Read notes from commits on this branch (default HEAD)
+      --fail-under N     Fail if overall coverage is below N percent
+      --ratchet          Fail if coverage decreases from previous recorded value
+      --validate         Validate config file without running tests
+      --diff-base <ref>  Enable diff mode against git ref ("auto" = merge-base with target branch)
+      --top-files N      List the N files with the most uncovered statements in failing domains
+      --prune-stale      Drop profile entries for files that no longer exist
+      --summary <file>   Append a markdown summary (default $GITHUB_STEP_SUMMARY when set)
+      --no-summary       Do not write a markdown summary
+      --report-file <file>  Always write the full result as JSON (domains, files,
+                         warnings, deltas, timing, config hash)
+
+Build/Test Flags:
+      --tags string      Build tags (e.g., integration,e2e)
+      --race             Enable race detector
+      --short            Skip long-running tests
+  -v                     Verbose test output
+      --run string       Run only tests matching pattern
+      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
+      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
+      --test-arg string  Additional argument passed to go test (repeatable)
+      --fail-fast        Skip the remaining per-domain test runs (test_args/test_command)
+                         once a finished domain is below its minimum
+      --isolate-domains  Test each domain alone with -coverpkg limited to it,
+                         so other domains' tests do not count (coverpkg.isolate)
+  -l, --language string  Override language detection
+      --runner string    Use this runner instead of auto-detection (go, python, node, rust, java, ...)
+
+Examples:
+  coverctl check
+  coverctl check -c custom.yaml
+  coverctl check --fail-under 80
+  coverctl check --ratchet
+  coverctl check --validate
+  coverctl check --from-profile --profile coverage.out
+  coverctl check --from-profile --prune-stale
+  coverctl check --from-profile --max-profile-age 30m
+  coverctl check --diff-base auto
+  coverctl check --top-files 5
+  coverctl check --report-file .cover/check.json
+  coverctl check --tags integration
+  coverctl check --race --timeout 30m
+  coverctl check --fail-fast
+  coverctl check --runner node
+  coverctl c -d core -d api`,
+
+	"gate": `coverctl gate - Run every CI check once and write gate summaries
+
+Usage:
+  coverctl gate [flags]
+
+Runs coverage once and evaluates domain policy, file rules, patch coverage
+(diff.min, diff.max_uncovered_lines), the history ratchet, --fail-under,
+group minimums, functions.min, and expired policy exceptions together. A
+JSON and a
+markdown summary are written for CI artifacts; the exit code is 1 if any
+check fails.
+
+Flags:
+  -c, --config string        Config file path (default ".coverctl.yaml")
+  -p, --profile string       Coverage profile path (default ".cover/coverage.out")
+      --from-profile         Use existing coverage profile instead of running tests
+      --max-profile-age <age>  Reject a --from-profile profile older than age or its sources
+      --stale-profile <action> What a stale profile does: fail|warn (default fail)
+  -d, --domain string        Filter to specific domain (repeatable)
+  -o, --output string        Output format: text|json (default "text")
+      --history string       History file for the ratchet and policy.new_domain (default ".cover/history.json")
+      --notes                Read history from git notes (refs/notes/coverctl)
+      --notes-branch <branch>  Read notes from commits on this branch (default HEAD)
+      --ratchet              Fail if overall coverage dropped since the last record (default true)
+      --fail-under N         Fail if overall coverage is below N percent
+      --diff-base <ref>      Enable diff mode against git ref ("auto" = merge-base)
+      --summary-json string  JSON summary path (default ".cover/gate.json"; empty disables)
+      --summary-md string    Markdown summary path (default ".cover/gate.md"; empty disables)
+      --report-file string   Always write the full result and checks as JSON
+      --language string      Override language detection
+      --runner string        Use this runner instead of auto-detection
+      --tags string          Build tags (e.g., integration,e2e)
+      --race                 Enable race detector
+      --timeout string       Test timeout forwarded to runner
+      --max-runtime string   Hard ceiling on total runtime (default "15m"; 0 disables)
+
+Examples:
+  coverctl gate
+  coverctl gate --from-profile --diff-base auto --fail-under 70
+  coverctl gate --summary-json gate.json --summary-md gate.md
+  coverctl gate --ratchet=false -o json`,
+
+	"run": `coverctl run - Run coverage only, produce artifacts
+
+Usage:
+  coverctl run [flags]
+
+Aliases:
+  r
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile output path (default ".cover/coverage.out")
+  -d, --domain string    Filter to specific domain (repeatable)
+
+Build/Test Flags:
+      --tags string      Build tags (e.g., integration,e2e)
+      --race             Enable race detector
+      --short            Skip long-running tests
+  -v                     Verbose test output
+      --run string       Run only tests matching pattern
+      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
+      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
+      --test-arg string  Additional argument passed to go test (repeatable)
+      --isolate-domains  Test each domain alone with -coverpkg limited to it (coverpkg.isolate)
+  -l, --language string  Override language detection
+      --runner string    Use this runner instead of auto-detection (go, python, node, rust, java, ...)
+
+Examples:
+  coverctl run
+  coverctl run --tags integration
+  coverctl run --race -v
+  coverctl r -p coverage.out`,
+
+	"watch": `coverctl watch - Watch for file changes and re-run coverage
+
+Usage:
+  coverctl watch [flags]
+
+Aliases:
+  w
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile output path (default ".cover/coverage.out")
+  -d, --domain string    Filter to specific domain (repeatable)
+
+Build/Test Flags:
+      --tags string      Build tags (e.g., integration,e2e)
+      --race             Enable race detector
+      --short            Skip long-running tests
+  -v                     Verbose test output
+      --run string       Run only tests matching pattern
+      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
+      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
+      --test-arg string  Additional argument passed to go test (repeatable)
+
+Examples:
+  coverctl watch
+  coverctl watch --tags integration
+  coverctl w -d core`,
+
+	"init": `coverctl init - Interactive setup wizard
+
+Usage:
+  coverctl init [flags]
+
+Aliases:
+  i
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -f, --force            Overwrite existing config file
+      --no-interactive   Skip the interactive init wizard
+      --answers string   Apply wizard decisions from a YAML answers file
+      --preset string    Apply a preset: strict, balanced, legacy, or a preset URL or file
+
+Without a terminal on stdout the wizard asks plain line-by-line questions;
+end of input accepts the remaining defaults.
+
+A preset sets the default minimum, warn levels, generated-code excludes,
+and diff mode on top of autodetection; the wizard and --answers can still
+change its minimums.
+
+Examples:
+  coverctl init
+  coverctl i -f
+  coverctl init --answers answers.yaml --force
+  coverctl init --preset strict --no-interactive
+  coverctl init --preset https://example.com/coverctl-preset.yaml`,
+
+	"ci": `coverctl ci - Bootstrap a config and CI pipeline
+
+Usage:
+  coverctl ci init --provider github|gitlab [flags]
+
+Flags:
+      --provider string   CI provider: github|gitlab (required)
+  -c, --config string     Config file path (default ".coverctl.yaml")
+  -f, --force             Overwrite existing config and pipeline files
+      --preset string     Apply a preset: strict, balanced, legacy, or a preset URL or file
+      --branch string     Branch whose pushes record coverage history (default "main")
+
+Writes a non-interactive config from autodetection and a pipeline that
+installs coverctl, runs check, records history on pushes to --branch, and
+uploads the history and a coverage badge as artifacts. The runner setup
+follows the detected language. An existing config is kept unless --force
+is given.
+
+GitHub gets .github/workflows/coverctl.yml. GitLab gets
+.gitlab/coverctl.gitlab-ci.yml, to include from .gitlab-ci.yml.
+
+Examples:
+  coverctl ci init --provider github
+  coverctl ci init --provider gitlab --preset balanced
+  coverctl ci init --provider github --branch develop --force`,
+
+	"detect": `coverctl detect - Autodetect domains and write config
+
+Usage:
+  coverctl detect [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -f, --force            Overwrite config if it exists
+      --merge            Add newly detected domains to the existing config,
+                         keeping its settings and comments; warns about
+                         domains whose directories no longer exist
+      --dry-run          Preview config (with --merge, the diff) without writing
+
+Examples:
+  coverctl detect
+  coverctl detect --dry-run
+  coverctl detect --merge
+  coverctl detect -f`,
+
+	"report": `coverctl report - Analyze an existing profile
+
+Usage:
+  coverctl report [flags]
+
+Flags:
+  -c, --config string    Config file path, "-" for stdin (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -d, --domain string    Filter to specific domain (repeatable)
+  -o, --output string    Output format: text|json|html|brief|gitlab|azure|csv|tsv (default "text")
+                         Use 'brief' for single-line LLM/agent-optimized output
+                         Use 'gitlab'/'azure' for Cobertura XML native to those CI systems
+                         Use 'csv'/'tsv' for one row per domain (spreadsheets, BI tools)
+      --title string     HTML report title (default "Coverage Report")
+      --self-contained   Embed uncovered source snippets in the HTML report
+      --show-delta       Show coverage change from previous run
+      --history string   History file path for delta display
+      --uncovered        Show only files with 0% coverage
+      --diff <ref>       Show coverage for files changed since git ref
+      --diff-base <ref>  Alias for --diff ("auto" = merge-base with target branch)
+      --top-files N      List the N files with the most uncovered statements in failing domains
+      --prune-stale      Drop profile entries for files that no longer exist
+      --timing           Show test time per domain from the last check or run
+      --summary <file>   Append a markdown summary (default $GITHUB_STEP_SUMMARY when set)
+      --no-summary       Do not write a markdown summary
+      --report-file <file>  Always write the full result as JSON
+      --merge <file>     Merge additional coverage profile (repeatable)
+
+Profiles may also be GOCOVERDIR directories written by -cover binaries;
+they are converted with 'go tool covdata textfmt' when read. Glob patterns
+and other directories expand to the profiles they hold; one that matches
+nothing is reported as a warning.
+
+Examples:
+  coverctl report
+  coverctl report -p custom.out -o json
+  coverctl report -o html > coverage.html
+  coverctl report -o html --self-contained --title "api coverage" > coverage.html
+  coverctl report -o gitlab > coverage.xml
+  coverctl report -o csv > coverage.csv
+  coverctl report --uncovered
+  coverctl report --diff main
+  coverctl report --top-files 10
+  coverctl report --timing
+  coverctl report --merge integration.out --merge e2e.out
+  coverctl report -p 'coverage-*.out'`,
+
+	"merge": `coverctl merge - Wait for sharded CI profiles and merge them
+
+Usage:
+  coverctl merge --dir DIR --expect N [flags]
+
+Flags:
+      --dir string         Directory the shard profiles are downloaded to
+      --expect int         Number of shard profiles to wait for
+      --pattern string     Glob matched against profile file names (default: any recognised profile)
+      --timeout duration   How long to wait for missing shards (default 10m)
+      --interval duration  How often to look for new shards (default 5s)
+  -o, --output string      Merged profile path, - for stdout (default ".cover/coverage.out")
+
+Polls --dir and its subdirectories until --expect profiles are present,
+then merges them. A profile counts once it is unchanged between two polls,
+so half-downloaded shards are not merged, and the --output file never
+counts. Go profiles merge into a Go profile with block counts
+added up; any other mix merges line hits into an LCOV tracefile. If the
+timeout passes first, merge fails with exit code 3 and lists the missing
+shard numbers, read from the last number in each profile's path
+(coverage-3.out, shard-3/coverage.out).
+
+Examples:
+  coverctl merge --dir artifacts/ --expect 8 --timeout 10m
+  coverctl merge --dir artifacts/ --expect 4 --pattern 'lcov-*.info' -o coverage/lcov.info
+  coverctl merge --dir artifacts/ --expect 8 && coverctl check --from-profile`,
+
+	"explain-failure": `coverctl explain-failure - Explain how to fix failing domains
+
+Usage:
+  coverctl explain-failure [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+      --report string    Explain the failures in this --report-file from an
+                         earlier check, report, or gate run
+      --files int        Lowest-covered files to list per domain (default 3)
+  -o, --output string    Output format: text|json (default "text")
+
+For every failing domain, prints current against required coverage, how
+many more statements (or lines, branches) to cover, the lowest-covered
+files, and the commands that reproduce the failure locally. Without
+--report the profile is evaluated as 'coverctl report' would; with it the
+failing domains are the ones that run recorded, and files still come from
+the profile.
+
+Examples:
+  coverctl explain-failure
+  coverctl check --report-file .cover/check.json || coverctl explain-failure --report .cover/check.json
+  coverctl explain-failure --files 5 -o json`,
+
+	"validate-profile": `coverctl validate-profile - Check a coverage profile and the domains it feeds
+
+Usage:
+  coverctl validate-profile [flags] [path]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out");
+                         a path argument takes precedence
+  -o, --output string    Output format: text|json (default "text")
+
+Checks the profile's format (Go, LCOV, Cobertura, JaCoCo, or a parser
+plugin's) without running tests. Go profiles are checked line by line, so
+every bad line is listed, not just the first: a missing or repeated mode
+line, malformed blocks, and overlapping blocks from profiles of different
+source revisions. Duplicate blocks, normal with -coverpkg, are counted but
+not an error. A profile that parses is then mapped onto the configured
+domains, showing the files and coverage each one would get and the files
+no domain matches.
+
+Exits 1 when the profile is invalid.
+
+Examples:
+  coverctl validate-profile
+  coverctl validate-profile coverage.lcov
+  coverctl validate-profile -o json .cover/coverage.out`,
+
+	"badge": `coverctl badge - Generate an SVG coverage badge
+
+Usage:
+  coverctl badge [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -o, --output string    Output file path (default "coverage.svg")
+      --label string     Badge label text (default "coverage")
+      --style string     Badge style: flat|flat-square (default "flat")
+      --show-delta       Append the change since the latest history entry ("84% ▲0.6")
+      --history string   History file path for --show-delta (default ".cover/history.json")
+
+Examples:
+  coverctl badge
+  coverctl badge -o badge.svg --style flat-square
+  coverctl badge --show-delta`,
+
+	"trend": `coverctl trend - Show coverage trends over time
+
+Usage:
+  coverctl trend [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+      --history string   History file path (default ".cover/history.json")
+      --notes            Read history from git notes (refs/notes/coverctl)
+      --notes-branch <branch>
+                         Read notes from commits on this branch (default HEAD)
+      --file string      Show one file's recorded coverage (text or json);
+                         needs history.track_files
+  -o, --output string    Output format: text|json|html|brief|csv|tsv (default "text")
+                         'csv'/'tsv' write one row per history entry with a
+                         column per domain
+
+With history.track_files enabled, record keeps per-file coverage and trend
+also lists files that dropped since the latest entry. Like record, trend
+warns on stderr about suspicious jumps from the latest entry.
+
+Examples:
+  coverctl trend
+  coverctl trend -o json
+  coverctl trend -o csv > trend.csv
+  coverctl trend --notes-branch origin/main
+  coverctl trend --file internal/core/service.go`,
+
+	"forecast": `coverctl forecast - Forecast domain coverage from recorded history
+
+Usage:
+  coverctl forecast [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+      --history string   History file path (default ".cover/history.json")
+      --horizon int      History entries to project ahead (default 5)
+      --lookback int     Recent entries per domain to base the forecast on;
+                         0 uses all of them (default 10)
+  -o, --output string    Output format: text|json (default "text")
+
+Fits a line through each domain's recent history entries and reports the
+coverage it predicts --horizon entries ahead, a confidence that falls as
+entries scatter around the line, and the date a domain below its minimum
+reaches it at the current trend, spacing future entries like past ones.
+Domains with fewer than two entries are listed as skipped.
+
+Examples:
+  coverctl forecast
+  coverctl forecast --horizon 10 --lookback 20
+  coverctl forecast -o json`,
+
+	"goals": `coverctl goals - Show progress toward coverage goals
+
+Usage:
+  coverctl goals [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+      --history string   History file path (default ".cover/history.json")
+      --lookback int     Recent entries per domain to project from;
+                         0 uses all of them (default 10)
+  -o, --output string    Output format: text|json (default "text")
+
+Measures each goal in the config's goals: list against recorded history:
+progress from the domain's oldest entry toward the target, the change per
+run, and the date the current trend reaches the target. A goal is met,
+on-track, off-track (the trend reaches it after its date, or never),
+missed (its date has passed), or unknown (fewer than two entries). Goals
+with warn: true also warn in check output while off track or missed.
+
+Examples:
+  coverctl goals
+  coverctl goals --lookback 20
+  coverctl goals -o json`,
+
+	"record": `coverctl record - Record current coverage to history
+
+Usage:
+  coverctl record [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+      --history string   History file path (default ".cover/history.json")
+      --notes            Store the entry as a git note on the commit
+                         (refs/notes/coverctl) instead of in --history
+      --commit string    Git commit SHA (default: detected)
+      --branch string    Git branch name (default: detected)
+      --tag string       Git tag (default: detected)
+      --pr int           Pull/merge request number (default: detected from CI)
+      --run-id string    CI run id (default: detected from CI)
+      --no-detect        Do not detect commit, branch, and CI metadata
+      --sign string      Sign the entry and its profiles with an Ed25519 private key (PEM)
+      --run              Run coverage before recording history
+  -l, --language string  Override language detection (go, python, nodejs, rust, java)
+      --runner string    Use this runner instead of auto-detection (go, python, node, rust, java, ...)
+  -d, --domain string    Filter to specific domain (repeatable)
+      --tags string      Build tags (e.g., integration,e2e)
+      --race             Enable race detector
+      --short            Skip long-running tests
+  -v                  Verbose test output
+      --test-run string  Run only tests matching pattern
+      --timeout string   Test timeout forwarded to runner (e.g., 10m, 1h)
+      --max-runtime string  Hard ceiling on total runtime (default "15m"; 0 disables)
+      --test-arg string  Additional argument passed to go test (repeatable)
+
+Examples:
+  coverctl record
+  coverctl record --commit abc123 --branch main
+  coverctl record --run --tags integration
+  coverctl record --notes && git push origin refs/notes/coverctl
+  coverctl record --sign coverctl-signing.pem
+
+Metadata Detection:
+  Unset metadata is read from CI variables (GitHub Actions, GitLab CI,
+  Bitbucket Pipelines), then from git: rev-parse HEAD, the current branch,
+  and a tag pointing at HEAD.
+
+Anomaly Warnings:
+  record warns when a domain drops more than 15 points or total statements
+  shrink by more than 30% since the latest entry; such jumps usually mean a
+  broken profile or skipped tests. The entry is still recorded.
+
+Signing:
+  --sign stores the SHA-256 of every profile with the entry and signs both;
+  'coverctl verify' checks them. Create a key pair with:
+    openssl genpkey -algorithm ed25519 -out coverctl-signing.pem
+    openssl pkey -in coverctl-signing.pem -pubout -out coverctl-signing.pub
+
+Git Notes:
+  --notes writes the entry as a JSON note on its commit under
+  refs/notes/coverctl, replacing an earlier note there, so history is
+  versioned with the repository. trend, check, gate, and ratchet-up read it
+  back with --notes, following --notes-branch (default HEAD). Share notes
+  with:
+    git push origin refs/notes/coverctl
+    git fetch origin refs/notes/coverctl:refs/notes/coverctl`,
+
+	"verify": `coverctl verify - Verify signed history entries and profiles
+
+Usage:
+  coverctl verify --key FILE [flags]
+
+Flags:
+      --key string       Ed25519 public key (PEM) the entries were signed for
+      --history string   History file path (default ".cover/history.json")
+  -p, --profile string   Profile to compare with the newest signed entry (repeatable)
+      --require-signed   Fail when any history entry is unsigned
+  -o, --output string    Output format: text|json (default "text")
+
+Checks the signature of every entry recorded with 'record --sign'. An
+entry whose coverage, metadata, or profile digests changed after signing,
+or that was signed with another key, is invalid. --profile compares a
+profile with the digests the newest valid signed entry recorded, proving
+the thresholds were evaluated against it.
+
+Exits 1 when any signature is invalid, a profile does not match, or, with
+--require-signed, an entry is unsigned.
+
+Examples:
+  coverctl verify --key coverctl-signing.pub
+  coverctl verify --key coverctl-signing.pub --require-signed -o json
+  coverctl verify --key coverctl-signing.pub -p .cover/coverage.out`,
+
+	"suggest": `coverctl suggest - Suggest optimal coverage thresholds
+
+Usage:
+  coverctl suggest [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+      --strategy string  Suggestion strategy: current|aggressive|conservative|history (default "current")
+      --history string   History file path for --strategy history (default ".cover/history.json")
+      --apply            Update config with suggested thresholds
+      --write-config string
+                         Write the suggested config to this path ("-" for
+                         stdout; the summary then goes to stderr)
+      --audit-file string
+                         Policy audit log written thresholds are recorded in
+                         (default ".cover/audit.jsonl")
+  -f, --force            Overwrite config if it exists
+
+The history strategy picks the 10th percentile of each domain's last 30
+recorded values, minus a buffer that grows with volatility, so the
+threshold is one the domain has reliably met. Domains with fewer than 5
+entries fall back to the current strategy.
+
+Examples:
+  coverctl suggest
+  coverctl suggest --strategy aggressive --apply
+  coverctl suggest --strategy history
+  coverctl suggest --config - --write-config - < policy.yaml > next.yaml`,
+
+	"ratchet-up": `coverctl ratchet-up - Raise thresholds that history shows are reliably met
+
+Usage:
+  coverctl ratchet-up [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+      --history string   History file path (default ".cover/history.json")
+      --notes            Read history from git notes (refs/notes/coverctl)
+      --notes-branch <branch>
+                         Read notes from commits on this branch (default HEAD)
+      --days int         Days a threshold must have been met continuously (default 14)
+      --max-step float   Largest increase applied to a single domain per run (default 2)
+      --dry-run          Report changes without writing the config
+      --audit-file string
+                         Policy audit log the raised thresholds are recorded
+                         in (default ".cover/audit.jsonl")
+  -o, --output string    Output format: text|json (default "text")
+
+Each domain's minimum is raised to the lowest coverage it recorded over the
+last --days days, capped at --max-step per run. Thresholds are never lowered.
+Domains whose history does not reach back --days days are skipped.
+Only the raised min values are written; comments, key order, and extends
+are kept. A domain inherited through extends is skipped with a warning.
+
+Intended for a scheduled CI job; use -o json for a machine-readable change
+summary suitable for an automated pull request.
+
+Examples:
+  coverctl ratchet-up --dry-run
+  coverctl ratchet-up --days 30 --max-step 1 -o json`,
+
+	"audit": `coverctl audit - Show the log of threshold changes
+
+Usage:
+  coverctl audit [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+      --audit-file string
+                         Policy audit log path (default ".cover/audit.jsonl")
+      --no-detect        Print the log without recording manual edits
+  -o, --output string    Output format: text|json (default "text")
+
+suggest --apply, suggest --write-config, and ratchet-up append every
+threshold they change to an append-only JSON Lines log, with who made the
+change, when, and the old and new values. The log also keeps a hash of the
+config: when the file no longer matches, audit records the thresholds
+edited by hand as a "manual" entry before printing the log. The actor is
+the CI user that triggered the run, else the git identity.
+
+Examples:
+  coverctl audit
+  coverctl audit -o json
+  coverctl audit --no-detect --audit-file compliance/audit.jsonl`,
+
+	"debt": `coverctl debt - Show coverage debt report
+
+Usage:
+  coverctl debt [flags]
+  coverctl debt plan [--target-date YYYY-MM-DD] [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -o, --output string    Output format: text|json|brief|csv|tsv (default "text")
+
+Plan Flags:
+      --target-date date Create a plan that closes all domain debt by this date
+      --history string   History file path (default ".cover/history.json")
+      --plan string      Debt plan file path (default ".cover/debt-plan.json")
+  -o, --output string    Output format: text|json (default "text")
+
+The plan uses the latest recorded history entry as its baseline and spreads
+each domain's shortfall evenly over the weeks until the target date. Run
+'coverctl debt plan' again after recording new history to see whether each
+domain is on track; the command exits 1 when any domain is behind.
+
+Examples:
+  coverctl debt
+  coverctl debt -o json
+  coverctl debt -o csv > debt.csv
+  coverctl debt plan --target-date 2025-12-31
+  coverctl debt plan`,
+
+	"metrics": `coverctl metrics - Export coverage metrics to Prometheus
+
+Usage:
+  coverctl metrics push --gateway URL [flags]
+  coverctl metrics write <file|-> [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+      --gateway string   Pushgateway URL (push only)
+      --job string       Pushgateway job name (default "coverctl")
+
+Exports overall and per-domain coverage, policy status, coverage debt, and
+health score as Prometheus gauges. 'push' replaces the job's metric group
+on a Pushgateway; 'write' writes a textfile for the node-exporter textfile
+collector, replacing it atomically. Use - to print to stdout.
+
+Examples:
+  coverctl metrics push --gateway http://push:9091 --job coverctl
+  coverctl metrics write /var/lib/node_exporter/textfile/coverctl.prom
+  coverctl metrics write -`,
+
+	"ignore": `coverctl ignore - Show configured excludes and ignore advice
+
+Usage:
+  coverctl ignore [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+
+Examples:
+  coverctl ignore`,
+
+	"annotate": `coverctl annotate - Add coverctl:ignore pragmas to files in bulk
+
+Usage:
+  coverctl annotate [flags] [file...]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path, read for --match (default ".cover/coverage.out")
+      --match string     Also annotate profile files matching this glob (repeatable)
+      --dry-run          Report what would change without editing files
+  -o, --output string    Output format: text|json (default "text")
+
+Inserts a "// coverctl:ignore" line ("# coverctl:ignore" for Python, Ruby,
+Elixir, and shell) at the top of each module-relative file, after any
+shebang or encoding line. Files that already carry the pragma, have no
+known comment syntax, or do not exist are skipped and reported. The
+pragmas only take effect with annotations.enabled: true in the config.
+
+Examples:
+  coverctl annotate internal/legacy/parser.go internal/legacy/lexer.go
+  coverctl annotate --match 'internal/gen/*.go' --dry-run
+  coverctl annotate --match 'internal/gen/*.go' -o json`,
+
+	"doctor": `coverctl doctor - Diagnose the coverage environment
+
+Usage:
+  coverctl doctor [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -o, --output string    Output format: text|json (default "text")
+
+Description:
+  Checks every detected language's coverage toolchain (go version,
+  pytest-cov or coverage.py, jest/c8/nyc, cargo-llvm-cov/tarpaulin/grcov,
+  JaCoCo in pom.xml or build.gradle, ...), validates the config, and
+  verifies that .cover is writable. Each check prints PASS, WARN, or FAIL
+  with a remediation hint. Exits 1 if any check fails; warnings do not
+  fail the command.
+
+Examples:
+  coverctl doctor
+  coverctl doctor -o json     # attach to CI triage`,
+
+	"compare": `coverctl compare - Compare coverage between two profiles
+
+Usage:
+  coverctl compare [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -b, --base string      Base coverage profile (required)
+  -H, --head string      Head coverage profile (default ".cover/coverage.out")
+  -o, --output string    Output format: text|json|brief (default "text")
+
+Examples:
+  coverctl compare --base main.out --head feature.out
+  coverctl compare -b main.out -o json`,
+
+	"diff-config": `coverctl diff-config - Show policy changes between two configs or git revisions
+
+Usage:
+  coverctl diff-config [flags] <base> [head]
+
+Flags:
+  -c, --config string    Head config when only a base is given (default ".coverctl.yaml")
+  -o, --output string    Output format: text|json|markdown (default "text")
+
+Each config is a file path, "-" for stdin, or a git revision and path such
+as origin/main:.coverctl.yaml. The diff is semantic: default, domain,
+group, file-rule, and new-code minimums raised or lowered; domains added or
+removed; and match, exclude, and exclude.functions patterns added or
+removed. Domain minimums are compared as enforced, so a raised default
+shows on every domain that inherits it. extends in a config read from git
+resolves against the working tree.
+
+Examples:
+  coverctl diff-config origin/main:.coverctl.yaml
+  coverctl diff-config old.yaml new.yaml
+  coverctl diff-config -o markdown origin/main:.coverctl.yaml > policy-diff.md`,
+
+	"lint": `coverctl lint - Find dead domains, unused excludes, and other config rot
+
+Usage:
+  coverctl lint [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -o, --output string    Output format: text|json (default "text")
+
+Rules:
+  dead-domain                 Every match pattern of a domain resolves to no packages
+  dead-match                  One match pattern of a domain resolves to no packages
+  unused-exclude              A global or domain exclude matches no file in the profile
+  conflicting-file-rules      Two file rules with different minimums cover one file
+  unknown-annotation-domain   A coverctl:domain annotation names an unknown domain
+
+The exclude, file-rule, and annotation rules read the files in the profile
+(and merge.profiles); without a profile they are skipped, and only file
+rules repeating a pattern are checked. Exits 1 when any rule fires.
+
+Examples:
+  coverctl lint
+  coverctl lint -o json`,
+
+	"vet": `coverctl vet - Report file rule failures as linter diagnostics
+
+Usage:
+  coverctl vet [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -o, --output string    Output format: text|json (default "text")
+
+Evaluates the files: rules against an existing profile (and
+merge.profiles) and prints each failing file as a diagnostic at its first
+uncovered line, in the file:line:col: message form go vet and
+golangci-lint print:
+
+  internal/core/a.go:42:1: coverage 61.5% is below the 80.0% file minimum (5 of 13 statements uncovered) (coverctl)
+
+Paths are relative to the module root. Without line-level coverage the
+position is line 1. Files an unexpired exceptions: entry covers are not
+reported, as check does not fail on them. Text output holds only
+diagnostics, so it can be fed
+to reviewdog, editor problem matchers, or CI annotations. Exits 1 when
+any file fails, like go vet.
+
+Examples:
+  coverctl vet
+  coverctl vet -o json
+  coverctl vet | reviewdog -efm="%f:%l:%c: %m" -name=coverctl -reporter=github-pr-review`,
+
+	"blame": `coverctl blame - Attribute uncovered lines to authors and commits
+
+Usage:
+  coverctl blame [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -d, --domain string    Filter to specific domain (repeatable)
+      --limit int        Maximum authors and commits listed, 0 for all (default 10)
+  -o, --output string    Output format: text|json (default "text")
+
+Runs git blame on every file with uncovered lines and totals those lines by
+the author and commit that last changed them. Needs line-level coverage and
+a git checkout with history. Files git cannot blame (untracked, generated)
+are counted as unattributed.
+
+Examples:
+  coverctl blame
+  coverctl blame -d core --limit 5
+  coverctl blame -o json > blame.json`,
+
+	"heatmap": `coverctl heatmap - Export a coverage treemap of directories as HTML
+
+Usage:
+  coverctl heatmap [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -d, --domain string    Filter to specific domain (repeatable)
+  -o, --output string    Output file path, - for stdout (default "heatmap.html")
+      --title string     HTML page title (default "Coverage Heatmap")
+
+Writes a self-contained HTML treemap of the module: each directory and file
+is a rectangle sized by its statements and coloured by its coverage, from
+red at 0% to green at 100%. Click a directory to zoom in. Excludes and
+ignore annotations apply as in check.
+
+Examples:
+  coverctl heatmap
+  coverctl heatmap -d core -o core-heatmap.html
+  coverctl heatmap --title "api coverage" -o - > heatmap.html`,
+
+	"patch-report": `coverctl patch-report - Export an HTML view of changed lines and their coverage
+
+Usage:
+  coverctl patch-report [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+      --base string      Git ref to diff against (default: diff.base, then HEAD~1)
+  -o, --output string    Output file path, - for stdout (default "patch-coverage.html")
+      --title string     HTML page title (default "Patch Coverage")
+
+Writes a self-contained HTML page for reviewers: a summary of patch
+coverage, then every changed file with executable changed lines, its
+changed hunks shown with a little context. Covered changed lines are green,
+uncovered ones red. diff.min and diff.max_uncovered_lines decide the
+PASS/FAIL shown in the summary; the command itself exits 0.
+
+Examples:
+  coverctl patch-report --base origin/main
+  coverctl patch-report -o - --title "PR #42" > pr-coverage.html`,
+
+	"aggregate": `coverctl aggregate - Combine JSON reports and histories from several repositories
+
+Usage:
+  coverctl aggregate --inputs <path|glob> [flags] [path|glob...]
+
+Flags:
+  -i, --inputs string    JSON report or history file path or glob (repeatable)
+  -o, --output string    Output format: text|json|markdown|html (default "text")
+      --title string     HTML page title (default "Organization Coverage")
+
+Each input file is one service: a JSON report written with -o json or
+--report-file, or a history file written by record. The service is named
+after the file (payments.json), or after its directory when the file has a
+generic name such as history.json or report.json. History inputs add each
+service's change since its previous entry and an org-wide trend: per day,
+the mean of every service's latest recorded coverage.
+
+Overall coverage is weighted by statements when every input is a JSON
+report, and the mean of service coverage otherwise. A pattern that matches
+no files is an error.
+
+Examples:
+  coverctl aggregate --inputs 'reports/*.json'
+  coverctl aggregate -i 'histories/*/history.json' -o html > org.html
+  coverctl aggregate -o json reports/payments.json reports/ledger.json`,
+
+	"scaffold": `coverctl scaffold - Generate test skeletons for uncovered exported functions
+
+Usage:
+  coverctl scaffold [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+  -d, --domain string    Filter to specific domain (repeatable)
+      --write            Write skeletons next to their sources instead of printing them
+      --force            With --write, overwrite existing skeleton files
+  -o, --output string    Output format: text|json (default "text")
+
+Finds exported functions and methods that no test executes, using the
+statement blocks in the profile and the function boundaries in the
+source, and generates one table-driven test per function with TODOs for
+the test cases. Skeletons for foo.go go to foo_scaffold_test.go in the
+same package. Generic functions and functions whose TestX name already
+exists are skipped with a warning. Go projects only.
+
+Examples:
+  coverctl scaffold --domain core
+  coverctl scaffold -d core --write
+  coverctl scaffold -o json`,
+
+	"select": `coverctl select - Select the tests covering changed files for a fast first CI pass
+
+Usage:
+  coverctl select --changed [flags]
+
+Flags:
+      --changed              Select the tests covering files changed since --base (required)
+  -c, --config string        Config file path (default ".coverctl.yaml")
+      --base string          Git ref to diff against (default diff.base, then HEAD~1)
+      --files-from string    Read changed files from this list ("-" for stdin) instead of git
+      --profiles-dir string  Directory of per-test profiles; empty selects whole packages
+  -o, --output string        Output format: text|json (default "text")
+
+Without --profiles-dir every package with a changed Go file is selected.
+With it, coverctl reads one profile per top-level test, laid out as
+<dir>/<package dir>/<TestName>.out, and selects the tests whose profile
+executes a changed file. Packages with changed test files, and packages of
+changed files no profile covers, still run in full.
+
+Text output is one go test command per line, ready to pipe to sh; -o json
+lists the changed files, tests, -run regex, and packages.
+
+Examples:
+  coverctl select --changed --base origin/main
+  coverctl select --changed --profiles-dir .cover/tests | sh
+  coverctl select --changed -o json`,
+
+	"pr-comment": `coverctl pr-comment - Post coverage report as PR/MR comment
+
+Supports GitHub, GitLab, and Bitbucket. Provider is auto-detected from
+environment variables or can be specified with --provider.
+
+Usage:
+  coverctl pr-comment [flags]
+
+Flags:
+  -c, --config string    Config file path (default ".coverctl.yaml")
+  -p, --profile string   Coverage profile path (default ".cover/coverage.out")
+      --base string      Base coverage profile for comparison (optional)
+      --pr int           Pull request/MR number (required, auto-detected on GitLab/Bitbucket)
+      --owner string     Repository owner/namespace (auto-detected from env)
+      --repo string      Repository name (auto-detected from env)
+      --provider string  Git provider: github, gitlab, bitbucket, or auto (default "auto")
+      --update           Update existing comment instead of creating new (default true)
+      --dry-run          Generate comment without posting
+
+Environment Variables:
+  GitHub:
+    GITHUB_TOKEN           API token for authentication
+    GITHUB_REPOSITORY      Repository in owner/repo format
+
+  GitLab:
+    GITLAB_TOKEN           API token (or CI_JOB_TOKEN in GitLab CI)
+    CI_PROJECT_NAMESPACE   Project namespace (auto-set in GitLab CI)
+    CI_PROJECT_NAME        Project name (auto-set in GitLab CI)
+    CI_MERGE_REQUEST_IID   MR number (auto-set in GitLab CI)
+
+  Bitbucket:
+    BITBUCKET_USERNAME     Username for basic auth
+    BITBUCKET_APP_PASSWORD App password for authentication
+    BITBUCKET_WORKSPACE    Workspace name
+    BITBUCKET_REPO_SLUG    Repository slug
+    BITBUCKET_PR_ID        PR number (auto-set in Bitbucket Pipelines)
+
+Examples:
+  # GitHub (auto-detected)
+  coverctl pr-comment --pr 123
+
+  # GitLab (in CI, auto-detects everything)
+  coverctl pr-comment --provider gitlab
+
+  # Bitbucket with explicit values
+  coverctl pr-comment --provider bitbucket --owner myworkspace --repo myrepo --pr 45
+
+  # Dry run to preview comment
+  coverctl pr-comment --pr 123 --dry-run`,
+
+	"mcp": `coverctl mcp - MCP (Model Context Protocol) server for AI agents
+
+Usage:
+  coverctl mcp <subcommand> [flags]
+
+Subcommands:
+  serve       Start the MCP server (STDIO or HTTP transport)
+
+Flags for 'serve':
+  -c, --config string      Config file path (default ".coverctl.yaml")
+  -p, --profile string     Coverage profile path (default ".cover/coverage.out")
+      --history string     History file path (default ".cover/history.json")
+      --mode string        Tool surface: agent, ci, or auto (default "auto")
+      --transport string   Transport: stdio or http (default "stdio")
+      --addr string        Listen address for --transport http (default "127.0.0.1:8765");
+                           a non-loopback address needs COVERCTL_MCP_TOKEN
+      --allow-host string  Host name clients may use besides localhost (repeatable)
+      --log-file string    Append session and protocol-error logs to this file (JSON)
+
+Description:
+  The MCP server enables AI agents (like Claude) to interact with coverctl
+  programmatically. It exposes coverage tools and resources via the Model
+  Context Protocol using STDIO transport, or streamable HTTP at /mcp with
+  --transport http. Set COVERCTL_MCP_TOKEN to require clients to send
+  "Authorization: Bearer <token>" over HTTP. Requests must address the
+  server as localhost, 127.0.0.1, [::1], or an --allow-host name.
+
+Tools (actions):
+  check     Run coverage tests and enforce policy thresholds
+  report    Analyze an existing coverage profile
+  record    Record current coverage to history
+
+Resources (read-only queries):
+  coverctl://debt      Coverage debt metrics
+  coverctl://trend     Coverage trends over time
+  coverctl://suggest   Threshold recommendations
+  coverctl://config    Current configuration
+
+Claude Desktop Configuration:
+  Add to ~/.config/claude/claude_desktop_config.json:
+
+  {
+    "mcpServers": {
+      "coverctl": {
+        "command": "coverctl",
+        "args": ["mcp", "serve"],
+        "cwd": "/path/to/your/go/project"
+      }
+    }
+  }
+
+Examples:
+  coverctl mcp serve
+  coverctl mcp serve -c custom.yaml
+  coverctl mcp serve --history .cover/history.json
+  coverctl mcp serve --log-file .cover/mcp.log
+  COVERCTL_MCP_TOKEN=secret coverctl mcp serve --transport http --addr :8765 --allow-host mcp.internal
+  coverctl mcp doctor                  # validate first-run setup
+  coverctl mcp doctor -c custom.yaml   # validate against a non-default config
+
+Subcommands:
+  serve   Start the MCP server (stdio).
+  doctor  Run first-run validation checks. Reports PASS/FAIL with
+          remediation per step: binary on PATH, working-directory
+          markers, config resolvable, MCP server construction, tool
+          dispatch smoke, mode auto-detect. Returns 0 only when every
+          check passes.`,
+
+	"version": `coverctl version - Show version information
+
+Usage:
+  coverctl version [flags]
+
+Flags:
+  -o, --output string    Output format: text|json (default "text")
+
+Prints the version, commit, and build date stamped at release time. Builds
+from 'go install' report the module version and VCS revision instead.
+
+Examples:
+  coverctl version
+  coverctl version -o json
+  coverctl --version`,
+
+	"self-update": `coverctl self-update - Replace coverctl with the latest verified GitHub release
+
+Usage:
+  coverctl self-update [flags]
+
+Flags:
+      --check            Only report whether a newer release exists
+      --version string   Install this release tag instead of the latest (e.g. v1.14.0)
+      --force            Reinstall even when already up to date
+
+Downloads the release archive for this OS and architecture from
+github.com/felixgeelhaar/coverctl, verifies its SHA-256 against the
+release's checksums.txt, and atomically replaces the running binary. The old
+binary is left untouched if any step fails. Package-manager installs
+(Homebrew, go install) should be updated with that package manager instead.
+
+Examples:
+  coverctl self-update --check
+  coverctl self-update
+  coverctl self-update --version v1.13.0`,
+
+	"completion": `coverctl completion - Generate shell completion scripts
+
+Usage:
+  coverctl completion <bash|zsh|fish|powershell>
+
+Completions are generated from the command definitions, so they list every
+flag the installed version accepts.
+
+Examples:
+  eval "$(coverctl completion bash)"
+  eval "$(coverctl completion zsh)"
+  coverctl completion fish | source
+  coverctl completion powershell | Out-String | Invoke-Expression`,
+
+	"man": `coverctl man - Generate the coverctl(1) man page
+
+Usage:
+  coverctl man
+
+Examples:
+  coverctl man > /usr/local/share/man/man1/coverctl.1
+  coverctl man | man -l -`,
+
+	"survey": `coverctl survey - Sean Ellis 40% PMF feedback prompt
+
+Asks one question:
+  How would you feel if you could no longer use coverctl?
+
+Responses are appended to ~/.coverctl/survey.jsonl. Nothing is
+transmitted; aggregation is opt-in via the trace donation pipeline
+(deferred per docs/design/gtm-metrics-spec.md).
+
+Usage:
+  coverctl survey                    # interactive prompt
+  coverctl survey --answer very      # scripted: very|somewhat|not|skip
+  coverctl survey --data-dir ./tmp   # override storage location
+
+Why we ask:
+  The Sean Ellis 40% threshold is the standard PMF benchmark. If at
+  least 40% of users would be very disappointed without the product,
+  scaling GTM is justified; below that threshold we go back to
+  discovery before investing in growth.`,
+
+	"gen-fixture": `coverctl gen-fixture - Write a synthetic coverage profile for benchmarks
+
+Usage:
+  coverctl gen-fixture [flags]
+
+Flags:
+      --files int        Number of source files (default 100000)
+      --blocks int       Number of coverage blocks, spread over the files (default 1000000)
+      --domains int      Number of top-level directories the files spread over (default 100)
+      --module string    Module path the profile's file paths start with (default "example.com/fixture")
+      --seed uint        Seed for hit counts; the same seed writes the same profile
+  -o, --output string    Profile path, - for stdout (default "-")
+
+Writes a Go cover profile (mode atomic) with files under
+internal/d<N>/, the same input the perf benchmarks parse and aggregate.
+Use it to profile coverctl against a monorepo-sized repository.
+
+Examples:
+  coverctl gen-fixture -o /tmp/fixture.out
+  coverctl gen-fixture --files 1000 --blocks 10000 | wc -l`,
+}
+
+func commandHelp(cmd string, w io.Writer) int {
+	c, ok := findCommand(cmd)
+	if !ok {
+		fmt.Fprintf(w, "Unknown command: %s\n\n", cmd)
+		usage(w)
+		return 2
+	}
+	if help, ok := commandHelpText[c.name]; ok {
+		fmt.Fprintln(w, help)
+		return 0
+	}
+	writeGeneratedHelp(c, w)
+	return 0
+}
+
+// writeGeneratedHelp renders help from the command table and FlagSet for
+// commands without a hand-written entry.
+func writeGeneratedHelp(c cliCommand, w io.Writer) {
+	fmt.Fprintf(w, "coverctl %s - %s\n\nUsage:\n  coverctl %s [flags]\n", c.name, c.summary, c.name)
+	if len(c.aliases) > 0 {
+		fmt.Fprintf(w, "\nAliases:\n  %s\n", strings.Join(c.aliases, ", "))
+	}
+	flags := allCommandFlags(c)
+	if len(flags) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	for _, f := range flags {
+		spelling := "    "
+		if f.short != "" {
+			spelling = "-" + f.short + ", "
+		}
+		fmt.Fprintf(w, "  %-24s %s\n", spelling+f.spelling(), firstLine(f.usage))
+	}
+}
+
+// wantsHelp reports whether command args ask for help before any "--".
+func wantsHelp(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "-h", "-help", "--help":
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"io"
 	"log/slog"
+	"os"
 )
 
 // setupLogger builds a slog.Logger from global flags and installs it as the
@@ -31,3 +32,19 @@ func setupLogger(stderr io.Writer, global GlobalOptions) *slog.Logger {
 	slog.SetDefault(logger)
 	return logger
 }
+
+// setupFileLogger installs a JSON logger that appends to path, for
+// long-running modes like `mcp serve` whose stderr is often discarded by
+// the parent process. Info and above are kept; --debug lowers it to Debug.
+func setupFileLogger(path string, global GlobalOptions) (io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304 - path is an operator-supplied flag
+	if err != nil {
+		return nil, err
+	}
+	level := slog.LevelInfo
+	if global.Debug {
+		level = slog.LevelDebug
+	}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level})))
+	return f, nil
+}
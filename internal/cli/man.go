@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// roffEscape escapes text for a roff body line.
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		// A leading dot or quote would be read as a request.
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			line = `\&` + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeManFlag renders one flag as a tagged paragraph.
+func writeManFlag(w io.Writer, f cliFlag) {
+	fmt.Fprintln(w, ".TP")
+	tag := `\fB` + roffEscape(f.spelling()) + `\fR`
+	if f.short != "" {
+		tag = `\fB\-` + f.short + `\fR, ` + tag
+	}
+	if !f.isBool {
+		tag += ` \fIvalue\fR`
+	}
+	fmt.Fprintln(w, tag)
+	usage := f.usage
+	if !f.isBool && f.def != "" {
+		usage += fmt.Sprintf(" (default %q)", f.def)
+	}
+	fmt.Fprintln(w, roffEscape(usage))
+}
+
+// writeManPage renders coverctl(1) from the command table and each
+// command's FlagSet.
+func writeManPage(w io.Writer) {
+	date := ""
+	if Date != "unknown" {
+		date = Date
+		if len(date) >= len("2006-01-02") {
+			date = date[:len("2006-01-02")]
+		}
+	}
+	fmt.Fprintf(w, ".TH COVERCTL 1 %q %q \"User Commands\"\n", date, "coverctl "+Version)
+	fmt.Fprintln(w, ".SH NAME")
+	fmt.Fprintln(w, `coverctl \- domain\-driven coverage enforcement for any language`)
+	fmt.Fprintln(w, ".SH SYNOPSIS")
+	fmt.Fprintln(w, `\fBcoverctl\fR [\fIglobal\-flags\fR] \fIcommand\fR [\fIflags\fR]`)
+	fmt.Fprintln(w, ".SH DESCRIPTION")
+	fmt.Fprintln(w, roffEscape("coverctl runs tests with coverage, aggregates the results into domains, and enforces a per-domain coverage policy read from .coverctl.yaml. Run 'coverctl help <command>' for examples."))
+	fmt.Fprintln(w, ".SH GLOBAL FLAGS")
+	for _, f := range globalFlags {
+		writeManFlag(w, f)
+	}
+	fmt.Fprintln(w, ".SH COMMANDS")
+	for _, c := range visibleCommands() {
+		fmt.Fprintf(w, ".SS %q\n", "coverctl "+c.name)
+		summary := c.summary + "."
+		if len(c.aliases) > 0 {
+			summary += " Alias: " + strings.Join(c.aliases, ", ") + "."
+		}
+		if len(c.subcommands) > 0 {
+			summary += " Subcommands: " + strings.Join(c.subcommands, ", ") + "."
+		}
+		fmt.Fprintln(w, roffEscape(summary))
+		for _, f := range allCommandFlags(c) {
+			writeManFlag(w, f)
+		}
+	}
+	fmt.Fprintln(w, ".SH FILES")
+	fmt.Fprintln(w, ".TP")
+	fmt.Fprintln(w, `\fI.coverctl.yaml\fR`)
+	fmt.Fprintln(w, "Coverage policy and domain configuration.")
+	fmt.Fprintln(w, ".TP")
+	fmt.Fprintln(w, `\fI.cover/\fR`)
+	fmt.Fprintln(w, "Coverage profiles, history, and reports.")
+	fmt.Fprintln(w, ".SH SEE ALSO")
+	fmt.Fprintln(w, `https://felixgeelhaar.github.io/coverctl/`)
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"flag"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/history"
+)
+
+// notesOptions selects git notes (refs/notes/coverctl) instead of the
+// history file as the coverage history store.
+type notesOptions struct {
+	enabled bool
+	branch  string
+}
+
+// notesFlags registers --notes and, for commands that read history,
+// --notes-branch.
+func notesFlags(fs *flag.FlagSet, reads bool) *notesOptions {
+	var n notesOptions
+	fs.BoolVar(&n.enabled, "notes", false, "Store coverage history in git notes (refs/notes/coverctl) instead of --history")
+	if reads {
+		fs.StringVar(&n.branch, "notes-branch", "", "Read notes from commits reachable from this branch (default HEAD; implies --notes)")
+	}
+	return &n
+}
+
+// store returns the history store the flags select: git notes, or the
+// history file at path.
+func (n *notesOptions) store(path string) application.HistoryStore {
+	if n.enabled || n.branch != "" {
+		return &history.NotesStore{Branch: n.branch}
+	}
+	return &history.FileStore{Path: path}
+}
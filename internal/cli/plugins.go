@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/plugin"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/runners"
+)
+
+// pluginOptions registers the coverctl-runner-* and coverctl-parser-*
+// plugins found on PATH. Built-in runners and formats keep priority: a
+// plugin runner is detected only when no built-in one matches, and is
+// otherwise selected with `runner: <name>`.
+func pluginOptions() ([]runners.RegistryOption, []parsers.Option) {
+	found := plugin.Discover(os.Getenv("PATH"))
+	runnerOpts := make([]runners.RegistryOption, 0, len(found.Runners))
+	for _, r := range found.Runners {
+		runnerOpts = append(runnerOpts, runners.WithRunner(r))
+	}
+	parserOpts := make([]parsers.Option, 0, len(found.Parsers))
+	for _, p := range found.Parsers {
+		parserOpts = append(parserOpts, parsers.WithParser(p))
+	}
+	return runnerOpts, parserOpts
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// summaryFlags registers --summary and --no-summary. The summary target
+// defaults to $GITHUB_STEP_SUMMARY so Actions runs get a job summary
+// without extra configuration.
+func summaryFlags(fs *flag.FlagSet) (path *string, disabled *bool) {
+	path = fs.String("summary", "", "Append a markdown summary to this file (default $GITHUB_STEP_SUMMARY when set)")
+	disabled = fs.Bool("no-summary", false, "Do not write a markdown summary")
+	return path, disabled
+}
+
+// openSummary opens the markdown summary destination for appending. It
+// returns nil when no destination applies; the caller closes the file.
+func openSummary(path string, disabled bool) (*os.File, error) {
+	if disabled {
+		return nil, nil
+	}
+	if path == "" {
+		path = os.Getenv("GITHUB_STEP_SUMMARY")
+	}
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644) // #nosec G304 - path is user-provided CLI flag or CI env
+	if err != nil {
+		return nil, fmt.Errorf("open summary: %w", err)
+	}
+	return f, nil
+}
+
+// reportFileFlag registers --report-file, the machine-readable RunReport
+// destination for check, report, and gate.
+func reportFileFlag(fs *flag.FlagSet) *string {
+	return fs.String("report-file", "", "Always write the full result as JSON to this file, whatever the output format")
+}
+
+// openReportFile creates the --report-file destination, truncating any
+// previous report. It returns nil when path is empty; the caller closes it.
+func openReportFile(path string) (*os.File, error) {
+	if path == "" {
+		return nil, nil
+	}
+	clean := filepath.Clean(path)
+	if err := os.MkdirAll(filepath.Dir(clean), 0o755); err != nil {
+		return nil, fmt.Errorf("create report file dir: %w", err)
+	}
+	f, err := os.Create(clean) // #nosec G304 - path is user-provided CLI flag
+	if err != nil {
+		return nil, fmt.Errorf("open report file: %w", err)
+	}
+	return f, nil
+}
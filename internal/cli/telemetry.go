@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/telemetry"
+)
+
+// telemetryFlushTimeout bounds the export of buffered spans and metrics at
+// exit so an unreachable collector cannot hang CI.
+const telemetryFlushTimeout = 5 * time.Second
+
+// buildTelemetry returns an OpenTelemetry exporter when the OTEL_* endpoint
+// variables are set, and nil otherwise.
+func buildTelemetry(stderr io.Writer) application.Telemetry {
+	if !telemetry.Enabled(os.Getenv) {
+		return nil
+	}
+	otel, err := telemetry.NewFromEnv(context.Background(), Version)
+	if err != nil {
+		fmt.Fprintf(stderr, "warning: OpenTelemetry export disabled: %v\n", err)
+		return nil
+	}
+	return otel
+}
+
+// ShutdownTelemetry flushes spans and metrics recorded by svc. Call it once
+// after Run returns.
+func ShutdownTelemetry(svc *application.Service, stderr io.Writer) {
+	otel, ok := svc.Telemetry.(*telemetry.OTel)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryFlushTimeout)
+	defer cancel()
+	if err := otel.Shutdown(ctx); err != nil {
+		fmt.Fprintf(stderr, "warning: OpenTelemetry flush failed: %v\n", err)
+	}
+}
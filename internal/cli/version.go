@@ -1,5 +1,13 @@
 package cli
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+)
+
 // Version information, set at build time via ldflags
 var (
 	// Version is the semantic version (e.g., "1.2.3")
@@ -9,3 +17,86 @@ var (
 	// Date is the build date
 	Date = "unknown"
 )
+
+// buildInfo is the version metadata printed by `coverctl version`.
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// currentBuildInfo returns the ldflags metadata, falling back to the module
+// and VCS stamps the Go toolchain embeds, so `go install` builds still
+// report where they came from.
+func currentBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "unknown" {
+				info.Commit = setting.Value
+				if len(info.Commit) > 7 {
+					info.Commit = info.Commit[:7]
+				}
+			}
+		case "vcs.time":
+			if info.Date == "unknown" {
+				info.Date = setting.Value
+			}
+		}
+	}
+	return info
+}
+
+func printVersion(w io.Writer) {
+	info := currentBuildInfo()
+	fmt.Fprintf(w, "coverctl version %s\n", info.Version)
+	if info.Commit != "unknown" {
+		fmt.Fprintf(w, "  commit: %s\n", info.Commit)
+	}
+	if info.Date != "unknown" {
+		fmt.Fprintf(w, "  built:  %s\n", info.Date)
+	}
+	fmt.Fprintf(w, "  go:     %s %s\n", info.GoVersion, info.Platform)
+}
+
+// runVersion implements `coverctl version`.
+func runVersion(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("version")
+	fs.Usage = func() { commandHelp("version", stderr) }
+	output := fs.String("output", "text", "Output format: text|json")
+	fs.StringVar(output, "o", "text", "Output format (shorthand)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	switch *output {
+	case "text":
+		printVersion(stdout)
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(currentBuildInfo()); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 3
+		}
+	default:
+		fmt.Fprintf(stderr, "unknown output format %q (want text or json)\n", *output)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultConfigFile is the config every command reads unless --config says
+// otherwise.
+const defaultConfigFile = ".coverctl.yaml"
+
+// ResolveWorkdir changes into the directory coverctl should run in. It
+// applies the global -C/--chdir flag first, then, unless the command names a
+// config with -c/--config, walks up to the nearest ancestor holding
+// .coverctl.yaml the way git finds .git, so commands work from any
+// subdirectory of a project. The walk stops at the repository root.
+//
+// It returns args with relative file paths the user typed made absolute
+// against the directory discovery started from, so "-p cover.out" in a
+// subdirectory still names the file there; defaults stay relative to the
+// project root. It must run before BuildService: the resolvers capture the
+// working directory when they are built.
+func ResolveWorkdir(args []string) ([]string, error) {
+	if len(args) < 2 {
+		return args, nil
+	}
+	global, cmd, cmdArgs := parseGlobalFlags(args[1:])
+	if global.Chdir != "" {
+		if err := os.Chdir(global.Chdir); err != nil {
+			return args, fmt.Errorf("chdir: %w", err)
+		}
+	}
+	if hasConfigFlag(cmdArgs) {
+		return args, nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return args, err
+	}
+	root, ok := findConfigDir(cwd)
+	if !ok || root == cwd {
+		return args, nil
+	}
+	if err := os.Chdir(root); err != nil {
+		return args, fmt.Errorf("chdir to %s: %w", root, err)
+	}
+	resolved := append([]string{}, args[:len(args)-len(cmdArgs)]...)
+	return append(resolved, absPathArgs(cmd, cmdArgs, cwd)...), nil
+}
+
+// pathFlags are the command flags whose values are file or directory paths.
+var pathFlags = map[string]bool{
+	"answers": true, "audit-file": true, "b": true, "base": true,
+	"data-dir": true, "dir": true, "files-from": true, "H": true,
+	"head": true, "history": true, "i": true, "inputs": true, "key": true,
+	"log-file": true, "merge": true, "p": true, "plan": true,
+	"profile": true, "profiles-dir": true, "report": true,
+	"report-file": true, "sign": true, "summary": true,
+	"summary-json": true, "summary-md": true, "write-config": true,
+}
+
+// positionalPathCommands take profile or report paths as arguments.
+var positionalPathCommands = map[string]bool{"aggregate": true, "validate-profile": true}
+
+// absPathArgs rewrites the relative path values in a command's args to
+// absolute paths under dir. -o/--output is a path only when its value looks
+// like one, since most commands use it for a format name, and --preset only
+// when it names a file in dir rather than a built-in preset or URL.
+func absPathArgs(cmd string, args []string, dir string) []string {
+	out := append([]string{}, args...)
+	abs := func(value string) string {
+		if value == "" || value == "-" || filepath.IsAbs(value) {
+			return value
+		}
+		return filepath.Join(dir, value)
+	}
+	rewrite := func(name, value string) (string, bool) {
+		switch {
+		case pathFlags[name]:
+			return abs(value), true
+		case name == "o" || name == "output":
+			if strings.ContainsAny(value, "./"+string(filepath.Separator)) {
+				return abs(value), true
+			}
+			return value, true
+		case name == "preset":
+			if _, err := os.Stat(filepath.Join(dir, value)); err == nil {
+				return abs(value), true
+			}
+			return value, true
+		}
+		return value, false
+	}
+	flagsDone := false
+	for i := 0; i < len(out); i++ {
+		arg := out[i]
+		if !flagsDone && arg == "--" {
+			flagsDone = true
+			continue
+		}
+		if flagsDone || !strings.HasPrefix(arg, "-") || arg == "-" {
+			if positionalPathCommands[cmd] {
+				if matches, _ := filepath.Glob(filepath.Join(dir, arg)); len(matches) > 0 {
+					out[i] = abs(arg)
+				}
+			}
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		dashes := arg[:len(arg)-len(name)]
+		if name, value, ok := strings.Cut(name, "="); ok {
+			if value, known := rewrite(name, value); known {
+				out[i] = dashes + name + "=" + value
+			}
+			continue
+		}
+		if i+1 < len(out) {
+			if value, known := rewrite(name, out[i+1]); known {
+				out[i+1] = value
+				i++
+			}
+		}
+	}
+	return out
+}
+
+// hasConfigFlag reports whether args set the config path explicitly.
+func hasConfigFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+		name, _, _ = strings.Cut(name, "=")
+		if name == "config" || name == "c" {
+			return true
+		}
+	}
+	return false
+}
+
+// findConfigDir returns the nearest directory at or above dir containing
+// .coverctl.yaml. It does not look past a directory containing .git, so a
+// config in an enclosing checkout or the home directory is never picked up.
+func findConfigDir(dir string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, defaultConfigFile)); err == nil {
+			return dir, true
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// BlameLine attributes one source line to the commit that last changed it.
+type BlameLine struct {
+	Commit  string
+	Author  string
+	Email   string
+	Time    time.Time
+	Summary string
+}
+
+// BlameAuthor totals the uncovered lines one author last changed.
+type BlameAuthor struct {
+	Author    string `json:"author"`
+	Email     string `json:"email,omitempty"`
+	Uncovered int    `json:"uncovered"`
+	Files     int    `json:"files"`
+	Commits   int    `json:"commits"`
+}
+
+// BlameCommit totals the uncovered lines one commit last changed.
+type BlameCommit struct {
+	Commit    string    `json:"commit"`
+	Author    string    `json:"author"`
+	Date      time.Time `json:"date"`
+	Summary   string    `json:"summary,omitempty"`
+	Uncovered int       `json:"uncovered"`
+	Files     int       `json:"files"`
+}
+
+// BlameSummary attributes uncovered lines to authors and commits, each
+// list sorted by uncovered lines, most first.
+type BlameSummary struct {
+	Uncovered    int           `json:"uncovered"`
+	Files        int           `json:"files"`
+	Unattributed int           `json:"unattributed"` // Uncovered lines git could not blame
+	Authors      []BlameAuthor `json:"authors"`
+	Commits      []BlameCommit `json:"commits"`
+}
+
+// SummarizeBlame cross-references the uncovered lines in coverage with blame,
+// both keyed by the same file paths. Authors are told apart by email when
+// git reports one.
+func SummarizeBlame(coverage map[string]LineCoverage, blame map[string]map[int]BlameLine) BlameSummary {
+	summary := BlameSummary{Authors: []BlameAuthor{}, Commits: []BlameCommit{}}
+	type tally struct {
+		entry   int
+		files   map[string]bool
+		commits map[string]bool
+	}
+	authors := make(map[string]*tally)
+	commits := make(map[string]*tally)
+
+	for file, lines := range coverage {
+		counted := false
+		for n, hits := range lines {
+			if hits > 0 {
+				continue
+			}
+			summary.Uncovered++
+			counted = true
+			line, ok := blame[file][n]
+			if !ok {
+				summary.Unattributed++
+				continue
+			}
+
+			key := line.Email
+			if key == "" {
+				key = line.Author
+			}
+			a, ok := authors[key]
+			if !ok {
+				a = &tally{entry: len(summary.Authors), files: map[string]bool{}, commits: map[string]bool{}}
+				authors[key] = a
+				summary.Authors = append(summary.Authors, BlameAuthor{Author: line.Author, Email: line.Email})
+			}
+			summary.Authors[a.entry].Uncovered++
+			a.files[file] = true
+			a.commits[line.Commit] = true
+
+			c, ok := commits[line.Commit]
+			if !ok {
+				c = &tally{entry: len(summary.Commits), files: map[string]bool{}}
+				commits[line.Commit] = c
+				summary.Commits = append(summary.Commits, BlameCommit{Commit: line.Commit, Author: line.Author, Date: line.Time, Summary: line.Summary})
+			}
+			summary.Commits[c.entry].Uncovered++
+			c.files[file] = true
+		}
+		if counted {
+			summary.Files++
+		}
+	}
+
+	for _, a := range authors {
+		summary.Authors[a.entry].Files = len(a.files)
+		summary.Authors[a.entry].Commits = len(a.commits)
+	}
+	for _, c := range commits {
+		summary.Commits[c.entry].Files = len(c.files)
+	}
+	sort.Slice(summary.Authors, func(i, j int) bool {
+		x, y := summary.Authors[i], summary.Authors[j]
+		if x.Uncovered != y.Uncovered {
+			return x.Uncovered > y.Uncovered
+		}
+		return x.Author < y.Author
+	})
+	sort.Slice(summary.Commits, func(i, j int) bool {
+		x, y := summary.Commits[i], summary.Commits[j]
+		if x.Uncovered != y.Uncovered {
+			return x.Uncovered > y.Uncovered
+		}
+		return x.Commit < y.Commit
+	})
+	return summary
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeBlame(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	alice1 := BlameLine{Commit: "a1", Author: "Alice", Email: "alice@example.com", Time: day, Summary: "Add retries"}
+	alice2 := BlameLine{Commit: "a2", Author: "Alice", Email: "alice@example.com", Time: day}
+	bob := BlameLine{Commit: "b1", Author: "Bob", Email: "bob@example.com", Time: day}
+
+	coverage := map[string]LineCoverage{
+		"core/a.go": {1: 0, 2: 0, 3: 1, 4: 0},
+		"core/b.go": {1: 0, 2: 5},
+		"gen/c.go":  {1: 0},
+		"api/d.go":  {1: 3},
+	}
+	blame := map[string]map[int]BlameLine{
+		"core/a.go": {1: alice1, 2: alice1, 3: bob, 4: bob},
+		"core/b.go": {1: alice2, 2: bob},
+	}
+
+	s := SummarizeBlame(coverage, blame)
+	if s.Uncovered != 5 || s.Files != 3 || s.Unattributed != 1 {
+		t.Fatalf("unexpected totals: %+v", s)
+	}
+	if len(s.Authors) != 2 {
+		t.Fatalf("expected 2 authors, got %+v", s.Authors)
+	}
+	if a := s.Authors[0]; a.Author != "Alice" || a.Uncovered != 3 || a.Files != 2 || a.Commits != 2 {
+		t.Fatalf("unexpected top author: %+v", a)
+	}
+	if b := s.Authors[1]; b.Author != "Bob" || b.Uncovered != 1 || b.Files != 1 || b.Commits != 1 {
+		t.Fatalf("unexpected second author: %+v", b)
+	}
+	if c := s.Commits[0]; c.Commit != "a1" || c.Uncovered != 2 || c.Files != 1 || c.Summary != "Add retries" {
+		t.Fatalf("unexpected top commit: %+v", c)
+	}
+	if len(s.Commits) != 3 {
+		t.Fatalf("expected 3 commits, got %+v", s.Commits)
+	}
+}
+
+func TestSummarizeBlameEmpty(t *testing.T) {
+	s := SummarizeBlame(nil, nil)
+	if s.Authors == nil || s.Commits == nil || s.Uncovered != 0 {
+		t.Fatalf("expected empty, non-nil lists: %+v", s)
+	}
+}
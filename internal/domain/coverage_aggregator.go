@@ -128,8 +128,9 @@ type DefaultPathNormalizer struct {
 
 // NormalizePath normalizes a coverage file path.
 func (n *DefaultPathNormalizer) NormalizePath(file string) string {
+	file = CanonicalPath(file)
 	clean := filepath.Clean(file)
-	if filepath.IsAbs(clean) {
+	if filepath.IsAbs(clean) || IsWindowsAbs(file) {
 		return clean
 	}
 	if n.ModulePath != "" {
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// week is the unit debt burn-down rates are expressed in.
+const week = 7 * 24 * time.Hour
+
+// ErrTargetDateNotInFuture is returned when a debt plan's target date does
+// not leave any time to burn down the debt.
+var ErrTargetDateNotInFuture = errors.New("target date must be after the plan start")
+
+// DebtPlan is a commitment to close every domain's coverage shortfall by a
+// target date. The baseline is the history entry the plan was created from,
+// so later entries can be compared against a straight-line burn-down.
+type DebtPlan struct {
+	CreatedAt  time.Time        `json:"createdAt"`
+	TargetDate time.Time        `json:"targetDate"`
+	Domains    []DebtPlanDomain `json:"domains"`
+}
+
+// DebtPlanDomain is the burn-down line for a single domain.
+type DebtPlanDomain struct {
+	Name       string  `json:"name"`
+	Baseline   float64 `json:"baseline"`
+	Required   float64 `json:"required"`
+	WeeklyGain float64 `json:"weeklyGain"`
+}
+
+// DebtPlanProgress compares a domain's latest coverage to where the plan
+// expects it to be.
+type DebtPlanProgress struct {
+	Domain             string  `json:"domain"`
+	Expected           float64 `json:"expected"`
+	Actual             float64 `json:"actual"`
+	Required           float64 `json:"required"`
+	PlannedWeeklyGain  float64 `json:"plannedWeeklyGain"`
+	ObservedWeeklyGain float64 `json:"observedWeeklyGain"`
+	RequiredWeeklyGain float64 `json:"requiredWeeklyGain"`
+	OnTrack            bool    `json:"onTrack"`
+}
+
+// NewDebtPlan builds a burn-down plan from a history entry. Only domains
+// below their recorded minimum are included; the weekly gain is the
+// shortfall spread evenly over the weeks until target.
+func NewDebtPlan(baseline HistoryEntry, target time.Time) (DebtPlan, error) {
+	weeks := weeksBetween(baseline.Timestamp, target)
+	if weeks <= 0 {
+		return DebtPlan{}, ErrTargetDateNotInFuture
+	}
+
+	plan := DebtPlan{CreatedAt: baseline.Timestamp, TargetDate: target}
+	for name, entry := range baseline.Domains {
+		if entry.Min <= 0 || entry.Percent >= entry.Min {
+			continue
+		}
+		plan.Domains = append(plan.Domains, DebtPlanDomain{
+			Name:       name,
+			Baseline:   entry.Percent,
+			Required:   entry.Min,
+			WeeklyGain: Round1((entry.Min - entry.Percent) / weeks),
+		})
+	}
+	sort.Slice(plan.Domains, func(i, j int) bool {
+		return plan.Domains[i].Name < plan.Domains[j].Name
+	})
+	return plan, nil
+}
+
+// Expected returns the coverage the plan expects for d at time at. The
+// line is capped at the required minimum once the target date passes.
+func (d DebtPlanDomain) Expected(createdAt, target, at time.Time) float64 {
+	total := weeksBetween(createdAt, target)
+	elapsed := weeksBetween(createdAt, at)
+	if elapsed <= 0 || total <= 0 {
+		return d.Baseline
+	}
+	if elapsed >= total {
+		return d.Required
+	}
+	return Round1(d.Baseline + (d.Required-d.Baseline)*elapsed/total)
+}
+
+// Progress evaluates the plan against history. The latest entry provides
+// actual coverage and the reference time; entries recorded since the plan
+// was created provide the observed weekly gain.
+func (p DebtPlan) Progress(history History) []DebtPlanProgress {
+	latest := history.LatestEntry()
+	if latest == nil {
+		return nil
+	}
+
+	progress := make([]DebtPlanProgress, 0, len(p.Domains))
+	for _, d := range p.Domains {
+		actual := d.Baseline
+		if entry, ok := latest.Domains[d.Name]; ok {
+			actual = entry.Percent
+		}
+		expected := d.Expected(p.CreatedAt, p.TargetDate, latest.Timestamp)
+
+		remaining := 0.0
+		if actual < d.Required {
+			remaining = d.Required - actual
+		}
+		requiredGain := remaining
+		if weeks := weeksBetween(latest.Timestamp, p.TargetDate); weeks > 0 {
+			requiredGain = remaining / weeks
+		}
+
+		progress = append(progress, DebtPlanProgress{
+			Domain:             d.Name,
+			Expected:           expected,
+			Actual:             actual,
+			Required:           d.Required,
+			PlannedWeeklyGain:  d.WeeklyGain,
+			ObservedWeeklyGain: observedWeeklyGain(history, d.Name, p.CreatedAt),
+			RequiredWeeklyGain: Round1(requiredGain),
+			OnTrack:            actual >= expected,
+		})
+	}
+	return progress
+}
+
+// OnTrack reports whether every domain in the plan is at or ahead of its
+// burn-down line.
+func OnTrack(progress []DebtPlanProgress) bool {
+	for _, p := range progress {
+		if !p.OnTrack {
+			return false
+		}
+	}
+	return true
+}
+
+// observedWeeklyGain is the average weekly change for a domain across the
+// entries recorded at or after since. Fewer than two entries, or entries
+// recorded within the same instant, yield zero.
+func observedWeeklyGain(history History, name string, since time.Time) float64 {
+	var first, last *HistoryEntry
+	for i := range history.Entries {
+		e := &history.Entries[i]
+		if e.Timestamp.Before(since) {
+			continue
+		}
+		if _, ok := e.Domains[name]; !ok {
+			continue
+		}
+		if first == nil || e.Timestamp.Before(first.Timestamp) {
+			first = e
+		}
+		if last == nil || e.Timestamp.After(last.Timestamp) {
+			last = e
+		}
+	}
+	if first == nil || last == nil {
+		return 0
+	}
+	weeks := weeksBetween(first.Timestamp, last.Timestamp)
+	if weeks <= 0 {
+		return 0
+	}
+	return Round1((last.Domains[name].Percent - first.Domains[name].Percent) / weeks)
+}
+
+func weeksBetween(from, to time.Time) float64 {
+	return float64(to.Sub(from)) / float64(week)
+}
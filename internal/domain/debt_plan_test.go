@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewDebtPlan(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	baseline := HistoryEntry{
+		Timestamp: start,
+		Domains: map[string]DomainEntry{
+			"core": {Name: "core", Percent: 60, Min: 80},
+			"api":  {Name: "api", Percent: 90, Min: 80},
+			"cli":  {Name: "cli", Percent: 40, Min: 0},
+		},
+	}
+
+	t.Run("spreads shortfall over weeks", func(t *testing.T) {
+		plan, err := NewDebtPlan(baseline, start.Add(10*week))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(plan.Domains) != 1 {
+			t.Fatalf("expected only domains in debt, got %+v", plan.Domains)
+		}
+		d := plan.Domains[0]
+		if d.Name != "core" || d.Baseline != 60 || d.Required != 80 || d.WeeklyGain != 2 {
+			t.Fatalf("unexpected plan domain: %+v", d)
+		}
+	})
+
+	t.Run("rejects past target", func(t *testing.T) {
+		_, err := NewDebtPlan(baseline, start.Add(-time.Hour))
+		if !errors.Is(err, ErrTargetDateNotInFuture) {
+			t.Fatalf("expected ErrTargetDateNotInFuture, got %v", err)
+		}
+	})
+}
+
+func TestDebtPlanDomainExpected(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	target := start.Add(10 * week)
+	d := DebtPlanDomain{Name: "core", Baseline: 60, Required: 80, WeeklyGain: 2}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{"before start", start.Add(-week), 60},
+		{"halfway", start.Add(5 * week), 70},
+		{"after target", target.Add(week), 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.Expected(start, target, tt.at); got != tt.want {
+				t.Fatalf("expected %.1f, got %.1f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDebtPlanProgress(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	plan := DebtPlan{
+		CreatedAt:  start,
+		TargetDate: start.Add(10 * week),
+		Domains: []DebtPlanDomain{
+			{Name: "core", Baseline: 60, Required: 80, WeeklyGain: 2},
+			{Name: "api", Baseline: 50, Required: 70, WeeklyGain: 2},
+		},
+	}
+	history := History{Entries: []HistoryEntry{
+		{Timestamp: start.Add(-week), Domains: map[string]DomainEntry{"core": {Percent: 10}}},
+		{Timestamp: start, Domains: map[string]DomainEntry{"core": {Percent: 60}, "api": {Percent: 50}}},
+		{Timestamp: start.Add(4 * week), Domains: map[string]DomainEntry{"core": {Percent: 72}, "api": {Percent: 52}}},
+	}}
+
+	progress := plan.Progress(history)
+	if len(progress) != 2 {
+		t.Fatalf("expected 2 progress rows, got %d", len(progress))
+	}
+
+	core := progress[0]
+	if !core.OnTrack || core.Expected != 68 || core.Actual != 72 {
+		t.Fatalf("unexpected core progress: %+v", core)
+	}
+	if core.ObservedWeeklyGain != 3 {
+		t.Fatalf("expected observed gain to ignore pre-plan entries, got %.1f", core.ObservedWeeklyGain)
+	}
+	if core.RequiredWeeklyGain != 1.3 {
+		t.Fatalf("expected required gain 1.3, got %.1f", core.RequiredWeeklyGain)
+	}
+
+	api := progress[1]
+	if api.OnTrack || api.Expected != 58 {
+		t.Fatalf("unexpected api progress: %+v", api)
+	}
+	if OnTrack(progress) {
+		t.Fatal("expected plan to be off track")
+	}
+}
+
+func TestDebtPlanProgressEmptyHistory(t *testing.T) {
+	plan := DebtPlan{Domains: []DebtPlanDomain{{Name: "core"}}}
+	if progress := plan.Progress(History{}); progress != nil {
+		t.Fatalf("expected nil progress, got %+v", progress)
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "fmt"
+
+// DiagnosticFileRule names the per-file minimum rule in diagnostics.
+const DiagnosticFileRule = "file-min"
+
+// Diagnostic is a coverage policy failure at a source position, in the
+// shape linters report, so it can flow through an existing lint pipeline.
+type Diagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// String formats the diagnostic as file:line:col: message (coverctl), the
+// form go vet and golangci-lint print and editors and reviewdog parse.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s (coverctl)", d.File, d.Line, d.Column, d.Message)
+}
+
+// FileRuleDiagnostic reports a file that fails its file rule at its first
+// uncovered line, or at line 1 when lines has none.
+func FileRuleDiagnostic(result FileResult, lines LineCoverage) Diagnostic {
+	d := Diagnostic{File: result.File, Line: 1, Column: 1, Rule: DiagnosticFileRule}
+	ranges := lines.UncoveredRanges()
+	if len(ranges) > 0 {
+		d.Line = ranges[0].Start
+	}
+	d.Message = fmt.Sprintf("coverage %.1f%% is below the %.1f%% file minimum (%d of %d statements uncovered",
+		result.Percent, result.Required, result.Total-result.Covered, result.Total)
+	if len(ranges) > 1 {
+		d.Message += fmt.Sprintf(", %d uncovered ranges", len(ranges))
+	}
+	d.Message += ")"
+	return d
+}
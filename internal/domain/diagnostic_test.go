@@ -0,0 +1,15 @@
+package domain
+
+import "testing"
+
+func TestFileRuleDiagnostic(t *testing.T) {
+	result := FileResult{File: "internal/core/a.go", Covered: 8, Total: 13, Percent: 61.5, Required: 80, Status: StatusFail}
+	d := FileRuleDiagnostic(result, LineCoverage{3: 1, 42: 0, 43: 0, 50: 1, 61: 0})
+	want := "internal/core/a.go:42:1: coverage 61.5% is below the 80.0% file minimum (5 of 13 statements uncovered, 2 uncovered ranges) (coverctl)"
+	if d.String() != want || d.Rule != DiagnosticFileRule {
+		t.Fatalf("got %q (%s), want %q", d.String(), d.Rule, want)
+	}
+	if d := FileRuleDiagnostic(result, nil); d.Line != 1 || d.Column != 1 {
+		t.Fatalf("expected line 1 without line data, got %d:%d", d.Line, d.Column)
+	}
+}
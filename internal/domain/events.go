@@ -28,11 +28,11 @@ func NewBaseEvent() BaseEvent {
 // CoverageEvaluatedEvent is raised when coverage is evaluated against a policy.
 type CoverageEvaluatedEvent struct {
 	BaseEvent
-	PolicyName     string
-	OverallPercent float64
-	Passed         bool
-	DomainCount    int
-	FailedCount    int
+	PolicyName     string  `json:"policyName"`
+	OverallPercent float64 `json:"overallPercent"`
+	Passed         bool    `json:"passed"`
+	DomainCount    int     `json:"domainCount"`
+	FailedCount    int     `json:"failedCount"`
 }
 
 // EventType returns the event type identifier.
@@ -55,10 +55,10 @@ func NewCoverageEvaluatedEvent(policyName string, overallPercent float64, passed
 // ThresholdViolatedEvent is raised when coverage falls below a threshold.
 type ThresholdViolatedEvent struct {
 	BaseEvent
-	DomainName string
-	Actual     float64
-	Required   float64
-	Shortfall  float64
+	DomainName string  `json:"domain"`
+	Actual     float64 `json:"actual"`
+	Required   float64 `json:"required"`
+	Shortfall  float64 `json:"shortfall"`
 }
 
 // EventType returns the event type identifier.
@@ -80,10 +80,10 @@ func NewThresholdViolatedEvent(domainName string, actual, required float64) Thre
 // CoverageImprovedEvent is raised when coverage improves.
 type CoverageImprovedEvent struct {
 	BaseEvent
-	DomainName string
-	Previous   float64
-	Current    float64
-	Delta      float64
+	DomainName string  `json:"domain"`
+	Previous   float64 `json:"previous"`
+	Current    float64 `json:"current"`
+	Delta      float64 `json:"delta"`
 }
 
 // EventType returns the event type identifier.
@@ -105,10 +105,10 @@ func NewCoverageImprovedEvent(domainName string, previous, current float64) Cove
 // CoverageRegressedEvent is raised when coverage decreases.
 type CoverageRegressedEvent struct {
 	BaseEvent
-	DomainName string
-	Previous   float64
-	Current    float64
-	Delta      float64
+	DomainName string  `json:"domain"`
+	Previous   float64 `json:"previous"`
+	Current    float64 `json:"current"`
+	Delta      float64 `json:"delta"`
 }
 
 // EventType returns the event type identifier.
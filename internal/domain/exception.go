@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExceptionDateLayout is the date format of exception expiry dates.
+const ExceptionDateLayout = "2006-01-02"
+
+// PolicyException temporarily exempts one domain or file rule match from
+// its minimum. Exactly one of Domain and File is set. Expires is the last
+// day the exception applies.
+type PolicyException struct {
+	Domain   string
+	File     string // Path or glob (with ** across directories) matched against file rule results
+	Reason   string
+	Approver string
+	Expires  string // YYYY-MM-DD
+}
+
+// Target names what the exception covers, e.g. "domain core".
+func (e PolicyException) Target() string {
+	if e.File != "" {
+		return "file " + e.File
+	}
+	return "domain " + e.Domain
+}
+
+// ExpiredAt reports whether the exception no longer applies at now. Dates
+// are compared in UTC, so an exception holds through its whole last day.
+func (e PolicyException) ExpiredAt(now time.Time) bool {
+	return now.UTC().Format(ExceptionDateLayout) > e.Expires
+}
+
+// ExceptionResult records how a configured exception was used in a run.
+type ExceptionResult struct {
+	Domain   string `json:"domain,omitempty"`
+	File     string `json:"file,omitempty"`
+	Reason   string `json:"reason"`
+	Approver string `json:"approver"`
+	Expires  string `json:"expires"`
+	// Expired means the exception is past its date and failed the run.
+	Expired bool `json:"expired"`
+	// Applied means the exception turned at least one FAIL into WARN.
+	Applied bool `json:"applied"`
+}
+
+// ApplyExceptions reports failing domains and file rules covered by an
+// active exception as WARN, and fails the run for every exception past
+// its expiry date, so an exemption cannot silently outlive its approval.
+// Passed is recomputed from the domain, group, and file statuses.
+func (r *Result) ApplyExceptions(exceptions []PolicyException, now time.Time) {
+	if len(exceptions) == 0 {
+		return
+	}
+	r.Exceptions = make([]ExceptionResult, 0, len(exceptions))
+	anyExpired := false
+	for _, e := range exceptions {
+		res := ExceptionResult{Domain: e.Domain, File: e.File, Reason: e.Reason, Approver: e.Approver, Expires: e.Expires}
+		if e.ExpiredAt(now) {
+			res.Expired = true
+			anyExpired = true
+			r.Warnings = append(r.Warnings, fmt.Sprintf("exception for %s expired on %s (approved by %s); renew or remove it", e.Target(), e.Expires, e.Approver))
+			r.Exceptions = append(r.Exceptions, res)
+			continue
+		}
+		if e.Domain != "" {
+			for i := range r.Domains {
+				d := &r.Domains[i]
+				if d.Domain != e.Domain || d.Status != StatusFail {
+					continue
+				}
+				d.Status = StatusWarn
+				res.Applied = true
+				r.Warnings = append(r.Warnings, fmt.Sprintf("domain %s: %.1f%% is below min %.1f%%, exempt until %s (approved by %s: %s)", d.Domain, d.Percent, d.Required, e.Expires, e.Approver, e.Reason))
+			}
+		}
+		if e.File != "" {
+			for i := range r.Files {
+				f := &r.Files[i]
+				if f.Status != StatusFail || !exceptionMatchesFile(e.File, f.File) {
+					continue
+				}
+				f.Status = StatusWarn
+				res.Applied = true
+				r.Warnings = append(r.Warnings, fmt.Sprintf("file %s: %.1f%% is below min %.1f%%, exempt until %s (approved by %s: %s)", f.File, f.Percent, f.Required, e.Expires, e.Approver, e.Reason))
+			}
+		}
+		r.Exceptions = append(r.Exceptions, res)
+	}
+
+	r.Passed = !anyExpired
+	for _, d := range r.Domains {
+		if d.Status == StatusFail {
+			r.Passed = false
+		}
+	}
+	for _, g := range r.Groups {
+		if g.Status == StatusFail {
+			r.Passed = false
+		}
+	}
+	for _, f := range r.Files {
+		if f.Status == StatusFail {
+			r.Passed = false
+		}
+	}
+}
+
+func exceptionMatchesFile(pattern, file string) bool {
+	return pattern == file || MatchGlob(pattern, file)
+}
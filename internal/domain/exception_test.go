@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func exceptionFixture() Result {
+	return Result{
+		Domains: []DomainResult{
+			{Domain: "core", Percent: 85, Required: 80, Status: StatusPass},
+			{Domain: "billing", Percent: 42.5, Required: 80, Status: StatusFail},
+		},
+		Files: []FileResult{
+			{File: "internal/legacy/parser.go", Percent: 10, Required: 60, Status: StatusFail},
+		},
+		Passed: false,
+	}
+}
+
+func TestApplyExceptionsExemptsUntilExpiry(t *testing.T) {
+	result := exceptionFixture()
+	now := time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)
+	result.ApplyExceptions([]PolicyException{
+		{Domain: "billing", Reason: "rewrite in progress", Approver: "alice", Expires: "2026-03-31"},
+		{File: "internal/legacy/*.go", Reason: "deleted next release", Approver: "bob", Expires: "2026-06-30"},
+	}, now)
+
+	if !result.Passed {
+		t.Fatalf("expected exempted failures to pass, warnings: %v", result.Warnings)
+	}
+	if result.Domains[1].Status != StatusWarn || result.Files[0].Status != StatusWarn {
+		t.Fatalf("expected exempted results to be WARN: %+v %+v", result.Domains[1], result.Files[0])
+	}
+	if len(result.Exceptions) != 2 || !result.Exceptions[0].Applied || !result.Exceptions[1].Applied {
+		t.Fatalf("expected both exceptions listed as applied: %+v", result.Exceptions)
+	}
+	if len(result.Warnings) != 2 || !strings.Contains(result.Warnings[0], "approved by alice") {
+		t.Fatalf("unexpected warnings: %v", result.Warnings)
+	}
+}
+
+func TestApplyExceptionsFileDoubleStar(t *testing.T) {
+	result := exceptionFixture()
+	result.Files = append(result.Files, FileResult{File: "internal/legacy/v1/old.go", Percent: 5, Required: 60, Status: StatusFail})
+	result.Domains = result.Domains[:1]
+	result.ApplyExceptions([]PolicyException{
+		{File: "internal/legacy/**", Reason: "deleted next release", Approver: "bob", Expires: "2026-06-30"},
+	}, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	if !result.Passed || result.Files[0].Status != StatusWarn || result.Files[1].Status != StatusWarn {
+		t.Fatalf("expected ** to exempt files in nested directories: %+v", result.Files)
+	}
+}
+
+func TestApplyExceptionsExpiredFailsRun(t *testing.T) {
+	result := exceptionFixture()
+	result.Files = nil
+	result.ApplyExceptions([]PolicyException{
+		{Domain: "core", Reason: "old", Approver: "alice", Expires: "2026-01-01"},
+	}, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	if result.Passed {
+		t.Fatal("expected an expired exception to fail the run")
+	}
+	if got := result.Exceptions[0]; !got.Expired || got.Applied {
+		t.Fatalf("unexpected exception result: %+v", got)
+	}
+	if !strings.Contains(result.Warnings[0], "expired on 2026-01-01") {
+		t.Fatalf("unexpected warnings: %v", result.Warnings)
+	}
+	if result.Domains[1].Status != StatusFail {
+		t.Fatalf("expected billing to stay FAIL: %+v", result.Domains[1])
+	}
+}
+
+func TestApplyExceptionsUnusedIsListed(t *testing.T) {
+	result := exceptionFixture()
+	result.ApplyExceptions([]PolicyException{
+		{Domain: "core", Reason: "flaky", Approver: "alice", Expires: "2099-01-01"},
+	}, time.Now())
+
+	if result.Passed {
+		t.Fatal("expected unexempted failures to still fail")
+	}
+	if len(result.Exceptions) != 1 || result.Exceptions[0].Applied || result.Exceptions[0].Expired {
+		t.Fatalf("expected an unused, active exception: %+v", result.Exceptions)
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// DomainForecast projects one domain's coverage from the line through its
+// recent history entries.
+type DomainForecast struct {
+	Domain     string     `json:"domain"`
+	Entries    int        `json:"entries"` // History entries the projection is based on
+	Current    float64    `json:"current"`
+	Predicted  float64    `json:"predicted"`            // Coverage horizon entries ahead
+	PerEntry   float64    `json:"perEntry"`             // Slope of the fitted line
+	Confidence float64    `json:"confidence"`           // 0-100; lower when entries scatter around the line
+	Min        float64    `json:"min"`                  // Threshold the ETA is estimated against
+	Met        bool       `json:"met"`                  // Current coverage already meets Min
+	ReachesMin *time.Time `json:"reachesMin,omitempty"` // Estimated date Min is reached at the current trend
+}
+
+// ForecastDomain projects the named domain horizon entries ahead from its
+// lookback most recent entries (all of them when lookback is zero or less).
+// It needs at least two entries; with fewer, ok is false. ReachesMin is left
+// unset when the domain already meets min, its trend is flat or falling, or
+// the entries share one timestamp.
+func (s *TrendAnalysisService) ForecastDomain(history *History, domainName string, lookback, horizon int, min float64) (forecast DomainForecast, ok bool) {
+	var entries []HistoryEntry
+	for _, entry := range history.Entries {
+		if _, found := entry.Domains[domainName]; found {
+			entries = append(entries, entry)
+		}
+	}
+	if lookback > 0 && len(entries) > lookback {
+		entries = entries[len(entries)-lookback:]
+	}
+	if len(entries) < 2 {
+		return DomainForecast{}, false
+	}
+
+	values := make([]float64, len(entries))
+	for i, entry := range entries {
+		values[i] = entry.Domains[domainName].Percent
+	}
+	slope, intercept, confidence := linearFit(values)
+	last := float64(len(values) - 1)
+
+	forecast = DomainForecast{
+		Domain:     domainName,
+		Entries:    len(entries),
+		Current:    values[len(values)-1],
+		Predicted:  Round1(clampPercent(slope*(last+float64(horizon)) + intercept)),
+		PerEntry:   Round1(slope),
+		Confidence: confidence,
+		Min:        min,
+		Met:        values[len(values)-1] >= min,
+	}
+	if forecast.Met || slope <= 0 {
+		return forecast, true
+	}
+
+	first, latest := entries[0].Timestamp, entries[len(entries)-1].Timestamp
+	interval := latest.Sub(first) / time.Duration(len(entries)-1)
+	if interval <= 0 {
+		return forecast, true
+	}
+	ahead := math.Max(1, math.Ceil((min-intercept)/slope-last))
+	eta := latest.Add(time.Duration(ahead) * interval)
+	forecast.ReachesMin = &eta
+	return forecast, true
+}
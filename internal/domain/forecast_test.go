@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func forecastHistory(percents ...float64) *History {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	h := &History{}
+	for i, p := range percents {
+		h.Entries = append(h.Entries, HistoryEntry{
+			Timestamp: start.Add(time.Duration(i) * 24 * time.Hour),
+			Domains:   map[string]DomainEntry{"core": {Name: "core", Percent: p}},
+		})
+	}
+	return h
+}
+
+func TestForecastDomainRising(t *testing.T) {
+	f, ok := NewTrendAnalysisService().ForecastDomain(forecastHistory(50, 60, 62, 64, 66), "core", 4, 5, 80)
+	if !ok {
+		t.Fatal("expected a forecast")
+	}
+	if f.Entries != 4 || f.Current != 66 || f.PerEntry != 2 || f.Predicted != 76 || f.Confidence != 100 || f.Met {
+		t.Fatalf("unexpected forecast %+v", f)
+	}
+	// 66% on May 5th, +2 points a day: 80% seven days later.
+	if f.ReachesMin == nil || !f.ReachesMin.Equal(time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected ETA %v", f.ReachesMin)
+	}
+}
+
+func TestForecastDomainNoETA(t *testing.T) {
+	service := NewTrendAnalysisService()
+	for name, tc := range map[string]struct {
+		history *History
+		met     bool
+	}{
+		"falling": {history: forecastHistory(70, 68, 66)},
+		"met":     {history: forecastHistory(70, 80, 90), met: true},
+	} {
+		f, ok := service.ForecastDomain(tc.history, "core", 0, 1, 80)
+		if !ok || f.ReachesMin != nil || f.Met != tc.met {
+			t.Errorf("%s: unexpected forecast %+v", name, f)
+		}
+	}
+	if f, ok := service.ForecastDomain(forecastHistory(60, 100, 100), "core", 0, 10, 80); !ok || f.Predicted != 100 {
+		t.Errorf("expected prediction clamped to 100, got %+v", f)
+	}
+	if _, ok := service.ForecastDomain(forecastHistory(70), "core", 0, 1, 80); ok {
+		t.Error("expected no forecast from a single entry")
+	}
+	if _, ok := service.ForecastDomain(forecastHistory(70, 75), "api", 0, 1, 80); ok {
+		t.Error("expected no forecast for an unrecorded domain")
+	}
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CoverageBlock is one block of a statement-level profile: the lines it
+// spans and how many of its statements ran.
+type CoverageBlock struct {
+	Lines LineRange
+	Stat  CoverageStat
+}
+
+// FunctionSpan is a function declaration and the lines its body spans.
+// Name is "Func()" for functions and "Type.Method()" for methods.
+type FunctionSpan struct {
+	Name  string
+	Lines LineRange
+}
+
+// Exported reports whether the function is exported and, for a method,
+// whether its receiver type is exported too, so it is callable from
+// outside the package.
+func (f FunctionSpan) Exported() bool {
+	name := strings.TrimSuffix(f.Name, "()")
+	if name == "" {
+		return false
+	}
+	for _, part := range strings.Split(name, ".") {
+		r, _ := utf8.DecodeRuneInString(part)
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// FunctionCoverage sums the blocks that start inside the function's lines.
+func FunctionCoverage(fn FunctionSpan, blocks []CoverageBlock) CoverageStat {
+	var stat CoverageStat
+	for _, b := range blocks {
+		if fn.Lines.Contains(b.Lines.Start) {
+			stat.Covered += b.Stat.Covered
+			stat.Total += b.Stat.Total
+		}
+	}
+	return stat
+}
+
+// ExcludeBlocks returns stat without the statements of blocks that start
+// inside any of the ranges, so excluded functions drop out of both the
+// covered and the total count.
+func ExcludeBlocks(stat CoverageStat, blocks []CoverageBlock, ranges []LineRange) CoverageStat {
+	for _, b := range blocks {
+		for _, r := range ranges {
+			if r.Contains(b.Lines.Start) {
+				stat.Covered -= b.Stat.Covered
+				stat.Total -= b.Stat.Total
+				break
+			}
+		}
+	}
+	if stat.Covered < 0 {
+		stat.Covered = 0
+	}
+	if stat.Total < stat.Covered {
+		stat.Total = stat.Covered
+	}
+	return stat
+}
+
+// FunctionsResult is the share of functions with any covered statement, as
+// go tool cover -func counts them, held to functions.min.
+type FunctionsResult struct {
+	Covered   int      `json:"covered"`
+	Total     int      `json:"total"`
+	Percent   float64  `json:"percent"`
+	Required  float64  `json:"required"`
+	Status    Status   `json:"status"`
+	Uncovered []string `json:"uncovered,omitempty"` // "file:Func()" for each function no test reached
+}
+
+// EvaluateFunctions counts, per file, the functions of spans with
+// statements in blocks and those with at least one covered statement.
+// Functions without statements are left out. The result fails when it has
+// functions and their percentage does not meet required under rounding.
+func EvaluateFunctions(spans map[string][]FunctionSpan, blocks map[string][]CoverageBlock, required float64, rounding Rounding) FunctionsResult {
+	result := FunctionsResult{Required: required, Status: StatusPass}
+	files := make([]string, 0, len(spans))
+	for file := range spans {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		for _, fn := range spans[file] {
+			stat := FunctionCoverage(fn, blocks[file])
+			if stat.Total == 0 {
+				continue
+			}
+			result.Total++
+			if stat.Covered > 0 {
+				result.Covered++
+			} else {
+				result.Uncovered = append(result.Uncovered, file+":"+fn.Name)
+			}
+		}
+	}
+	raw := CoverageStat{Covered: result.Covered, Total: result.Total}.Percent()
+	result.Percent = rounding.Percent(raw)
+	if result.Total > 0 && !rounding.Meets(raw, required) {
+		result.Status = StatusFail
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "testing"
+
+func TestExcludeBlocks(t *testing.T) {
+	blocks := []CoverageBlock{
+		{Lines: LineRange{Start: 3, End: 5}, Stat: CoverageStat{Covered: 2, Total: 2}},
+		{Lines: LineRange{Start: 10, End: 11}, Stat: CoverageStat{Covered: 0, Total: 3}},
+		{Lines: LineRange{Start: 20, End: 22}, Stat: CoverageStat{Covered: 1, Total: 1}},
+	}
+	stat := CoverageStat{Covered: 3, Total: 6}
+
+	got := ExcludeBlocks(stat, blocks, []LineRange{{Start: 9, End: 12}})
+	if got != (CoverageStat{Covered: 3, Total: 3}) {
+		t.Fatalf("got %+v, want 3/3", got)
+	}
+	if got := ExcludeBlocks(stat, blocks, nil); got != stat {
+		t.Fatalf("no ranges changed the stat: %+v", got)
+	}
+	if got := ExcludeBlocks(stat, blocks, []LineRange{{Start: 1, End: 30}}); got != (CoverageStat{}) {
+		t.Fatalf("excluding everything left %+v", got)
+	}
+}
+
+func TestFunctionSpanExported(t *testing.T) {
+	tests := map[string]bool{
+		"Parse()":       true,
+		"parse()":       false,
+		"Box.String()":  true,
+		"box.String()":  false,
+		"Box.reset()":   false,
+		"Émile.Hello()": true,
+		"()":            false,
+	}
+	for name, want := range tests {
+		if got := (FunctionSpan{Name: name}).Exported(); got != want {
+			t.Errorf("%s: expected exported=%v", name, want)
+		}
+	}
+}
+
+func TestFunctionCoverage(t *testing.T) {
+	blocks := []CoverageBlock{
+		{Lines: LineRange{Start: 3, End: 5}, Stat: CoverageStat{Covered: 2, Total: 2}},
+		{Lines: LineRange{Start: 10, End: 11}, Stat: CoverageStat{Covered: 0, Total: 3}},
+		{Lines: LineRange{Start: 12, End: 12}, Stat: CoverageStat{Covered: 1, Total: 1}},
+	}
+	got := FunctionCoverage(FunctionSpan{Name: "Parse()", Lines: LineRange{Start: 9, End: 14}}, blocks)
+	if got != (CoverageStat{Covered: 1, Total: 4}) {
+		t.Fatalf("got %+v, want 1/4", got)
+	}
+}
+
+func TestEvaluateFunctions(t *testing.T) {
+	blocks := map[string][]CoverageBlock{
+		"pkg/a.go": {
+			{Lines: LineRange{Start: 4, End: 4}, Stat: CoverageStat{Covered: 0, Total: 3}},
+			{Lines: LineRange{Start: 8, End: 9}, Stat: CoverageStat{Covered: 1, Total: 2}},
+		},
+		"pkg/b.go": {
+			{Lines: LineRange{Start: 2, End: 2}, Stat: CoverageStat{Covered: 1, Total: 1}},
+		},
+	}
+	spans := map[string][]FunctionSpan{
+		"pkg/a.go": {
+			{Name: "Thing.String()", Lines: LineRange{Start: 3, End: 5}},
+			{Name: "Run()", Lines: LineRange{Start: 7, End: 10}},
+			{Name: "empty()", Lines: LineRange{Start: 12, End: 12}},
+		},
+		"pkg/b.go": {{Name: "init()", Lines: LineRange{Start: 1, End: 3}}},
+	}
+
+	got := EvaluateFunctions(spans, blocks, 70, RoundingRound)
+	if got.Covered != 2 || got.Total != 3 || got.Percent != 66.7 || got.Status != StatusFail {
+		t.Fatalf("unexpected result %+v", got)
+	}
+	if len(got.Uncovered) != 1 || got.Uncovered[0] != "pkg/a.go:Thing.String()" {
+		t.Fatalf("unexpected uncovered functions %v", got.Uncovered)
+	}
+	if got := EvaluateFunctions(nil, nil, 70, RoundingRound); got.Status != StatusPass || got.Total != 0 {
+		t.Fatalf("no functions should pass, got %+v", got)
+	}
+}
@@ -0,0 +1,189 @@
+package domain
+
+import "fmt"
+
+// StatusSkip marks a gate check that did not apply to this run.
+const StatusSkip Status = "SKIP"
+
+// Gate check names, in the order they are reported.
+const (
+	GateCheckPolicy     = "policy"
+	GateCheckFiles      = "files"
+	GateCheckDiff       = "diff"
+	GateCheckNewCode    = "new-code"
+	GateCheckRatchet    = "ratchet"
+	GateCheckFailUnder  = "fail-under"
+	GateCheckGroups     = "groups"
+	GateCheckFunctions  = "functions"
+	GateCheckExceptions = "exceptions"
+)
+
+// GateCheck is the outcome of one check within a CI gate.
+type GateCheck struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Gate aggregates every coverage check of a CI run into a single verdict.
+type Gate struct {
+	Passed  bool        `json:"passed"`
+	Overall float64     `json:"overall"`
+	Checks  []GateCheck `json:"checks"`
+	Result  Result      `json:"result"`
+}
+
+// EvaluateGate derives per-check outcomes from a policy result. previous is
+// the latest recorded history entry for the ratchet check and failUnder an
+// optional overall floor; either may be nil to skip that check. Both
+// compare the unrounded overall under the result's rounding.
+func EvaluateGate(result Result, previous *HistoryEntry, failUnder *float64) Gate {
+	overall := result.OverallPercent()
+	gate := Gate{Overall: overall, Result: result}
+
+	gate.Checks = append(gate.Checks, policyCheck(result.Domains))
+	gate.Checks = append(gate.Checks, fileRulesCheck(result.Files))
+	gate.Checks = append(gate.Checks, patchCheck(result.Patch))
+	gate.Checks = append(gate.Checks, newCodeCheck(result.NewCode))
+
+	ratchet := GateCheck{Name: GateCheckRatchet, Status: StatusSkip, Detail: "no recorded history"}
+	if previous != nil {
+		ratchet.Status = StatusPass
+		ratchet.Detail = fmt.Sprintf("%.1f%% vs %.1f%% previously", overall, previous.Overall)
+		if !result.MeetsOverall(previous.Overall) {
+			ratchet.Status = StatusFail
+		}
+	}
+	gate.Checks = append(gate.Checks, ratchet)
+
+	floor := GateCheck{Name: GateCheckFailUnder, Status: StatusSkip, Detail: "no overall floor set"}
+	if failUnder != nil {
+		floor.Status = StatusPass
+		floor.Detail = fmt.Sprintf("%.1f%% overall (required %.1f%%)", overall, *failUnder)
+		if !result.MeetsOverall(*failUnder) {
+			floor.Status = StatusFail
+		}
+	}
+	gate.Checks = append(gate.Checks, floor)
+	gate.Checks = append(gate.Checks, groupsCheck(result.Groups))
+	gate.Checks = append(gate.Checks, functionsCheck(result.Functions))
+	gate.Checks = append(gate.Checks, exceptionsCheck(result.Exceptions))
+
+	gate.Passed = true
+	for _, check := range gate.Checks {
+		if check.Status == StatusFail {
+			gate.Passed = false
+		}
+	}
+	return gate
+}
+
+func policyCheck(domains []DomainResult) GateCheck {
+	check := GateCheck{Name: GateCheckPolicy, Status: StatusPass}
+	if len(domains) == 0 {
+		check.Detail = "no domains evaluated"
+		return check
+	}
+	passing := 0
+	for _, d := range domains {
+		if d.Status == StatusFail {
+			check.Status = StatusFail
+			continue
+		}
+		passing++
+	}
+	check.Detail = fmt.Sprintf("%d/%d domains meet their minimum", passing, len(domains))
+	return check
+}
+
+func fileRulesCheck(files []FileResult) GateCheck {
+	check := GateCheck{Name: GateCheckFiles, Status: StatusSkip, Detail: "no file rules matched"}
+	if len(files) == 0 {
+		return check
+	}
+	failing := 0
+	for _, f := range files {
+		if f.Status == StatusFail {
+			failing++
+		}
+	}
+	check.Status = StatusPass
+	if failing > 0 {
+		check.Status = StatusFail
+	}
+	check.Detail = fmt.Sprintf("%d/%d files below their rule", failing, len(files))
+	return check
+}
+
+func patchCheck(patch *PatchResult) GateCheck {
+	check := GateCheck{Name: GateCheckDiff, Status: StatusSkip, Detail: "diff.min and diff.max_uncovered_lines not configured"}
+	if patch == nil {
+		return check
+	}
+	check.Status = patch.Status
+	check.Detail = fmt.Sprintf("%.1f%% of %d changed lines (%s)", patch.Percent, patch.Total, patch.Requirement())
+	return check
+}
+
+func newCodeCheck(newCode *NewCodeResult) GateCheck {
+	check := GateCheck{Name: GateCheckNewCode, Status: StatusSkip, Detail: "policy.new_code_since not configured"}
+	if newCode == nil {
+		return check
+	}
+	check.Status = newCode.Status
+	check.Detail = fmt.Sprintf("%.1f%% of %d lines changed in the last %s (required %.1f%%)", newCode.Percent, newCode.Total, newCode.Since, newCode.Required)
+	return check
+}
+
+func groupsCheck(groups []GroupResult) GateCheck {
+	check := GateCheck{Name: GateCheckGroups, Status: StatusSkip, Detail: "no group minimums set"}
+	required, passing := 0, 0
+	for _, g := range groups {
+		if g.Required == nil {
+			continue
+		}
+		required++
+		if g.Status == StatusFail {
+			check.Status = StatusFail
+			continue
+		}
+		passing++
+	}
+	if required == 0 {
+		return check
+	}
+	if check.Status != StatusFail {
+		check.Status = StatusPass
+	}
+	check.Detail = fmt.Sprintf("%d/%d groups meet their minimum", passing, required)
+	return check
+}
+
+func functionsCheck(functions *FunctionsResult) GateCheck {
+	check := GateCheck{Name: GateCheckFunctions, Status: StatusSkip, Detail: "functions.min not configured"}
+	if functions == nil {
+		return check
+	}
+	check.Status = functions.Status
+	check.Detail = fmt.Sprintf("%.1f%% of %d functions reached (required %.1f%%)", functions.Percent, functions.Total, functions.Required)
+	return check
+}
+
+func exceptionsCheck(exceptions []ExceptionResult) GateCheck {
+	check := GateCheck{Name: GateCheckExceptions, Status: StatusSkip, Detail: "no policy exceptions"}
+	if len(exceptions) == 0 {
+		return check
+	}
+	expired := 0
+	for _, e := range exceptions {
+		if e.Expired {
+			expired++
+		}
+	}
+	check.Status = StatusPass
+	if expired > 0 {
+		check.Status = StatusFail
+	}
+	check.Detail = fmt.Sprintf("%d/%d exceptions expired", expired, len(exceptions))
+	return check
+}
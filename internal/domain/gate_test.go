@@ -0,0 +1,151 @@
+package domain
+
+import "testing"
+
+func TestEvaluateGate(t *testing.T) {
+	result := Result{
+		Domains: []DomainResult{
+			{Domain: "core", Covered: 80, Total: 100, Status: StatusPass},
+			{Domain: "api", Covered: 40, Total: 100, Status: StatusFail},
+		},
+		Patch: &PatchResult{Covered: 9, Total: 10, Percent: 90, Required: 80, Status: StatusPass},
+	}
+
+	t.Run("skips checks without inputs", func(t *testing.T) {
+		gate := EvaluateGate(result, nil, nil)
+		want := map[string]Status{
+			GateCheckPolicy:     StatusFail,
+			GateCheckFiles:      StatusSkip,
+			GateCheckDiff:       StatusPass,
+			GateCheckNewCode:    StatusSkip,
+			GateCheckRatchet:    StatusSkip,
+			GateCheckFailUnder:  StatusSkip,
+			GateCheckGroups:     StatusSkip,
+			GateCheckFunctions:  StatusSkip,
+			GateCheckExceptions: StatusSkip,
+		}
+		if len(gate.Checks) != len(want) {
+			t.Fatalf("expected %d checks, got %d", len(want), len(gate.Checks))
+		}
+		for _, check := range gate.Checks {
+			if check.Status != want[check.Name] {
+				t.Errorf("%s: status %s, want %s", check.Name, check.Status, want[check.Name])
+			}
+		}
+		if gate.Passed {
+			t.Fatal("expected gate to fail on policy")
+		}
+		if gate.Overall != 60 {
+			t.Fatalf("expected overall 60, got %v", gate.Overall)
+		}
+	})
+
+	t.Run("ratchet and fail-under", func(t *testing.T) {
+		passing := Result{Domains: []DomainResult{{Domain: "core", Covered: 70, Total: 100, Status: StatusPass}}}
+		floor := 65.0
+		gate := EvaluateGate(passing, &HistoryEntry{Overall: 72}, &floor)
+		if gate.Passed {
+			t.Fatal("expected ratchet regression to fail the gate")
+		}
+		if gate.Checks[4].Status != StatusFail || gate.Checks[5].Status != StatusPass {
+			t.Fatalf("unexpected checks: %+v", gate.Checks)
+		}
+	})
+
+	t.Run("fail-under and ratchet follow rounding", func(t *testing.T) {
+		floor := 80.0
+		// 79.96% rounds to 80.0% but truncates to 79.9%.
+		domains := []DomainResult{{Domain: "core", Covered: 7996, Total: 10000, Status: StatusPass}}
+		tests := []struct {
+			rounding Rounding
+			passed   bool
+			overall  float64
+		}{
+			{RoundingRound, true, 80},
+			{RoundingFloor, false, 79.9},
+			{RoundingExact, false, 79.9},
+		}
+		for _, tt := range tests {
+			result := Result{Domains: domains, Rounding: tt.rounding}
+			gate := EvaluateGate(result, &HistoryEntry{Overall: 80}, &floor)
+			if gate.Passed != tt.passed || gate.Overall != tt.overall {
+				t.Fatalf("%s: expected passed=%v overall=%v, got %+v", tt.rounding, tt.passed, tt.overall, gate)
+			}
+		}
+	})
+}
+
+func TestEvaluateGateNewCode(t *testing.T) {
+	result := Result{
+		Domains: []DomainResult{{Domain: "core", Covered: 50, Total: 100, Status: StatusPass}},
+		NewCode: &NewCodeResult{PatchResult: PatchResult{Covered: 7, Total: 10, Percent: 70, Required: 90, Status: StatusFail}, Since: "90d"},
+	}
+	gate := EvaluateGate(result, nil, nil)
+	if gate.Passed {
+		t.Fatal("expected new code below its threshold to fail the gate")
+	}
+	check := gate.Checks[3]
+	if check.Name != GateCheckNewCode || check.Status != StatusFail {
+		t.Fatalf("unexpected new-code check: %+v", check)
+	}
+	if check.Detail != "70.0% of 10 lines changed in the last 90d (required 90.0%)" {
+		t.Fatalf("unexpected detail: %s", check.Detail)
+	}
+}
+
+func TestEvaluateGateFailsOnResultOnlyChecks(t *testing.T) {
+	required := 80.0
+	passingDomains := []DomainResult{{Domain: "core", Covered: 90, Total: 100, Status: StatusPass}}
+	tests := []struct {
+		name   string
+		result Result
+		check  string
+		detail string
+	}{
+		{
+			name: "group minimum",
+			result: Result{Domains: passingDomains, Groups: []GroupResult{
+				{Group: "backend", Percent: 70, Required: &required, Status: StatusFail},
+				{Group: "tools", Percent: 20, Status: StatusPass},
+			}},
+			check:  GateCheckGroups,
+			detail: "0/1 groups meet their minimum",
+		},
+		{
+			name:   "functions.min",
+			result: Result{Domains: passingDomains, Functions: &FunctionsResult{Covered: 6, Total: 10, Percent: 60, Required: 75, Status: StatusFail}},
+			check:  GateCheckFunctions,
+			detail: "60.0% of 10 functions reached (required 75.0%)",
+		},
+		{
+			name: "expired exception",
+			result: Result{Domains: passingDomains, Exceptions: []ExceptionResult{
+				{Domain: "core", Expires: "2026-01-01", Expired: true},
+				{Domain: "api", Expires: "2099-01-01", Applied: true},
+			}},
+			check:  GateCheckExceptions,
+			detail: "1/2 exceptions expired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gate := EvaluateGate(tt.result, nil, nil)
+			if gate.Passed {
+				t.Fatal("expected the gate to fail")
+			}
+			for _, check := range gate.Checks {
+				if check.Name != tt.check {
+					if check.Status == StatusFail {
+						t.Fatalf("unexpected failing check %+v", check)
+					}
+					continue
+				}
+				if check.Status != StatusFail || check.Detail != tt.detail {
+					t.Fatalf("unexpected %s check: %+v", tt.check, check)
+				}
+				return
+			}
+			t.Fatalf("no %s check in %+v", tt.check, gate.Checks)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MatchGlob reports whether file matches pattern. Patterns without "**"
+// follow filepath.Match; a "**" segment matches any number of directories,
+// including none, so "**/*.pb.go" matches "foo.pb.go" and
+// "internal/api/v1/foo.pb.go", and "**/mocks/**" matches "a/b/mocks/m.go".
+func MatchGlob(pattern, file string) bool {
+	if !strings.Contains(pattern, "**") {
+		ok, _ := filepath.Match(pattern, file)
+		return ok
+	}
+	return matchSegments(strings.Split(filepath.ToSlash(pattern), "/"), strings.Split(filepath.ToSlash(file), "/"))
+}
+
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(rest, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
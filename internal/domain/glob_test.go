@@ -0,0 +1,23 @@
+package domain
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"internal/legacy/*.go", "internal/legacy/parser.go", true},
+		{"internal/legacy/*.go", "internal/legacy/v1/parser.go", false},
+		{"**/*.pb.go", "foo.pb.go", true},
+		{"**/*.pb.go", "internal/api/v1/foo.pb.go", true},
+		{"**/mocks/**", "a/b/mocks/m.go", true},
+		{"internal/**/gen.go", "internal/gen.go", true},
+		{"internal/**/gen.go", "cmd/gen.go", false},
+	}
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Goal is a coverage milestone: Domain should reach Target by the end of
+// By. Unlike a minimum, a goal never fails a run.
+type Goal struct {
+	Domain string
+	Target float64
+	By     string // YYYY-MM-DD, or a quarter such as 2026-Q3
+	Warn   bool   // Check warns while the goal is off track
+}
+
+// Deadline returns the last day of By, in UTC.
+func (g Goal) Deadline() (time.Time, error) {
+	if year, quarter, ok := strings.Cut(g.By, "-Q"); ok {
+		y, yerr := strconv.Atoi(year)
+		q, qerr := strconv.Atoi(quarter)
+		if yerr != nil || qerr != nil || len(year) != 4 || q < 1 || q > 4 {
+			return time.Time{}, fmt.Errorf("goal date %q is not a YYYY-MM-DD date or YYYY-QN quarter", g.By)
+		}
+		return time.Date(y, time.Month(3*q+1), 0, 0, 0, 0, 0, time.UTC), nil
+	}
+	day, err := time.Parse(ExceptionDateLayout, g.By)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("goal date %q is not a YYYY-MM-DD date or YYYY-QN quarter", g.By)
+	}
+	return day, nil
+}
+
+// GoalStatus is where a goal stands against its deadline.
+type GoalStatus string
+
+const (
+	GoalMet      GoalStatus = "met"
+	GoalOnTrack  GoalStatus = "on-track"
+	GoalOffTrack GoalStatus = "off-track" // The current trend reaches the target late or never
+	GoalMissed   GoalStatus = "missed"    // The deadline passed below the target
+	GoalUnknown  GoalStatus = "unknown"   // Too little history to project
+)
+
+// GoalProgress is a goal measured against recorded history.
+type GoalProgress struct {
+	Domain        string     `json:"domain"`
+	Target        float64    `json:"target"`
+	By            string     `json:"by"`
+	Entries       int        `json:"entries"` // History entries the projection is based on
+	Start         float64    `json:"start"`   // Coverage at the oldest entry recording the domain
+	Current       float64    `json:"current"`
+	Progress      float64    `json:"progress"` // How far Current has come from Start toward Target, 0-100
+	PerEntry      float64    `json:"perEntry"`
+	ReachesTarget *time.Time `json:"reachesTarget,omitempty"` // Estimated date the target is reached at the current trend
+	Status        GoalStatus `json:"status"`
+}
+
+// TrackGoal measures goal against history at now, projecting from the
+// domain's lookback most recent entries (all of them when lookback is zero
+// or less). The goal's By must parse; see Goal.Deadline.
+func (s *TrendAnalysisService) TrackGoal(history *History, goal Goal, lookback int, now time.Time) (GoalProgress, error) {
+	deadline, err := goal.Deadline()
+	if err != nil {
+		return GoalProgress{}, err
+	}
+	progress := GoalProgress{Domain: goal.Domain, Target: goal.Target, By: goal.By, Status: GoalUnknown}
+	var values []float64
+	for _, entry := range history.Entries {
+		if d, found := entry.Domains[goal.Domain]; found {
+			values = append(values, d.Percent)
+		}
+	}
+	if len(values) == 0 {
+		return progress, nil
+	}
+	progress.Start, progress.Current = values[0], values[len(values)-1]
+	progress.Progress = 100
+	if progress.Start < goal.Target {
+		progress.Progress = Round1(math.Max(0, math.Min(100, (progress.Current-progress.Start)/(goal.Target-progress.Start)*100)))
+	}
+
+	forecast, ok := s.ForecastDomain(history, goal.Domain, lookback, 1, goal.Target)
+	if ok {
+		progress.Entries = forecast.Entries
+		progress.PerEntry = forecast.PerEntry
+		progress.ReachesTarget = forecast.ReachesMin
+	}
+	switch {
+	case progress.Current >= goal.Target:
+		progress.Status = GoalMet
+	case now.UTC().Format(ExceptionDateLayout) > deadline.Format(ExceptionDateLayout):
+		progress.Status = GoalMissed
+	case !ok:
+	case progress.ReachesTarget == nil || progress.ReachesTarget.UTC().Format(ExceptionDateLayout) > deadline.Format(ExceptionDateLayout):
+		progress.Status = GoalOffTrack
+	default:
+		progress.Status = GoalOnTrack
+	}
+	return progress, nil
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGoalDeadline(t *testing.T) {
+	for by, want := range map[string]time.Time{
+		"2026-09-15": time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC),
+		"2026-Q1":    time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		"2026-Q3":    time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC),
+		"2026-Q4":    time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+	} {
+		got, err := Goal{By: by}.Deadline()
+		if err != nil || !got.Equal(want) {
+			t.Errorf("%s: got %v, %v; want %v", by, got, err, want)
+		}
+	}
+	for _, by := range []string{"", "Q3", "2026-Q5", "26-Q1", "2026/09/15"} {
+		if _, err := (Goal{By: by}).Deadline(); err == nil {
+			t.Errorf("%q: expected an error", by)
+		}
+	}
+}
+
+func TestTrackGoal(t *testing.T) {
+	service := NewTrendAnalysisService()
+	// 60% on May 1st to 66% on May 4th, +2 points a day: 80% on May 11th.
+	history := forecastHistory(60, 62, 64, 66)
+	may := time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC)
+	for name, tc := range map[string]struct {
+		goal Goal
+		now  time.Time
+		want GoalStatus
+	}{
+		"on track":  {goal: Goal{Domain: "core", Target: 80, By: "2026-05-31"}, now: may, want: GoalOnTrack},
+		"off track": {goal: Goal{Domain: "core", Target: 80, By: "2026-05-08"}, now: may, want: GoalOffTrack},
+		"missed":    {goal: Goal{Domain: "core", Target: 80, By: "2026-05-04"}, now: may, want: GoalMissed},
+		"met":       {goal: Goal{Domain: "core", Target: 65, By: "2026-05-01"}, now: may, want: GoalMet},
+		"unknown":   {goal: Goal{Domain: "api", Target: 80, By: "2026-Q3"}, now: may, want: GoalUnknown},
+	} {
+		progress, err := service.TrackGoal(history, tc.goal, 0, tc.now)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if progress.Status != tc.want {
+			t.Errorf("%s: status %s, want %s (%+v)", name, progress.Status, tc.want, progress)
+		}
+	}
+
+	progress, _ := service.TrackGoal(history, Goal{Domain: "core", Target: 80, By: "2026-Q2"}, 0, may)
+	if progress.Start != 60 || progress.Current != 66 || progress.Progress != 30 || progress.PerEntry != 2 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+	if progress.ReachesTarget == nil || !progress.ReachesTarget.Equal(time.Date(2026, 5, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected ETA %v", progress.ReachesTarget)
+	}
+	if _, err := service.TrackGoal(history, Goal{Domain: "core", Target: 80, By: "soon"}, 0, may); err == nil {
+		t.Fatal("expected an error for an invalid date")
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+// GroupPolicy sets an optional minimum for the combined coverage of every
+// domain whose Group names it.
+type GroupPolicy struct {
+	Name string
+	Min  *float64
+}
+
+// GroupResult is the subtotal of a domain group. Required is nil when the
+// group has no minimum of its own; such a group always passes.
+type GroupResult struct {
+	Group    string   `json:"group"`
+	Domains  []string `json:"domains"`
+	Covered  int      `json:"covered"`
+	Total    int      `json:"total"`
+	Percent  float64  `json:"percent"`
+	Required *float64 `json:"required,omitempty"`
+	Status   Status   `json:"status"`
+}
+
+// EvaluateGroups sums domain results into their groups, in the order each
+// group first appears among the domains. Domains without a group are left
+// out, and so is a group policy no domain refers to.
+func EvaluateGroups(policy Policy, domains []DomainResult) []GroupResult {
+	groupOf := make(map[string]string, len(policy.Domains))
+	for _, d := range policy.Domains {
+		if d.Group != "" {
+			groupOf[d.Name] = d.Group
+		}
+	}
+	if len(groupOf) == 0 {
+		return nil
+	}
+	mins := make(map[string]*float64, len(policy.Groups))
+	for _, g := range policy.Groups {
+		mins[g.Name] = g.Min
+	}
+
+	var results []GroupResult
+	index := make(map[string]int)
+	for _, d := range domains {
+		name, ok := groupOf[d.Domain]
+		if !ok {
+			continue
+		}
+		i, seen := index[name]
+		if !seen {
+			i = len(results)
+			index[name] = i
+			results = append(results, GroupResult{Group: name, Required: mins[name]})
+		}
+		g := &results[i]
+		g.Domains = append(g.Domains, d.Domain)
+		g.Covered += d.Covered
+		g.Total += d.Total
+	}
+	for i := range results {
+		g := &results[i]
+		raw := CoverageStat{Covered: g.Covered, Total: g.Total}.Percent()
+		g.Percent = policy.Rounding.Percent(raw)
+		g.Status = StatusPass
+		if g.Required != nil && !policy.Rounding.Meets(raw, *g.Required) {
+			g.Status = StatusFail
+		}
+	}
+	return results
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEvaluateGroups(t *testing.T) {
+	backendMin := 80.0
+	policy := Policy{
+		DefaultMin: 50,
+		Domains: []Domain{
+			{Name: "api", Group: "backend"},
+			{Name: "web", Group: "frontend"},
+			{Name: "db", Group: "backend"},
+			{Name: "tools"},
+		},
+		Groups: []GroupPolicy{{Name: "backend", Min: &backendMin}, {Name: "unused", Min: &backendMin}},
+	}
+	coverage := map[string]CoverageStat{
+		"api":   {Covered: 90, Total: 100},
+		"web":   {Covered: 10, Total: 20},
+		"db":    {Covered: 50, Total: 100},
+		"tools": {Covered: 1, Total: 1},
+	}
+
+	result := Evaluate(policy, coverage)
+	if len(result.Groups) != 2 {
+		t.Fatalf("expected backend and frontend groups, got %+v", result.Groups)
+	}
+	backend, frontend := result.Groups[0], result.Groups[1]
+	if backend.Group != "backend" || !slices.Equal(backend.Domains, []string{"api", "db"}) {
+		t.Fatalf("unexpected backend group: %+v", backend)
+	}
+	if backend.Covered != 140 || backend.Total != 200 || backend.Percent != 70 || backend.Status != StatusFail {
+		t.Fatalf("expected backend 70%% failing its 80%% min, got %+v", backend)
+	}
+	if frontend.Required != nil || frontend.Status != StatusPass {
+		t.Fatalf("expected frontend without min to pass, got %+v", frontend)
+	}
+	if result.Passed {
+		t.Fatal("expected failing group to fail the result")
+	}
+}
+
+func TestEvaluateGroupsWithoutGroups(t *testing.T) {
+	policy := Policy{Domains: []Domain{{Name: "core"}}}
+	if groups := EvaluateGroups(policy, []DomainResult{{Domain: "core"}}); groups != nil {
+		t.Fatalf("expected no groups, got %+v", groups)
+	}
+}
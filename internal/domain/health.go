@@ -0,0 +1,42 @@
+package domain
+
+// Health sums a result up in one line a dashboard can track per run.
+type Health struct {
+	Score          float64 `json:"score"` // Domains and file rules at or above their minimum, as a share of all, 0-100
+	PassingDomains int     `json:"passing_domains"`
+	FailingDomains int     `json:"failing_domains"`
+	Shortfall      float64 `json:"shortfall"` // Points below the minimum, summed over domains and file rules
+	Events         int     `json:"events"`    // Domain events the evaluation raised
+}
+
+// ComputeHealth scores r the way the debt report does: every domain and
+// file rule result below its minimum counts against the score and adds its
+// shortfall. events is the number of domain events evaluating r raised.
+func (r Result) ComputeHealth(events int) Health {
+	health := Health{
+		PassingDomains: r.PassingDomainCount(),
+		FailingDomains: r.FailingDomainCount(),
+		Events:         events,
+	}
+	var below, total int
+	for _, d := range r.Domains {
+		total++
+		if short := d.Shortfall(); short > 0 {
+			below++
+			health.Shortfall += short
+		}
+	}
+	for _, f := range r.Files {
+		total++
+		if short := f.Shortfall(); short > 0 {
+			below++
+			health.Shortfall += short
+		}
+	}
+	health.Shortfall = Round1(health.Shortfall)
+	health.Score = 100
+	if total > 0 {
+		health.Score = Round1(float64(total-below) / float64(total) * 100)
+	}
+	return health
+}
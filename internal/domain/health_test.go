@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestComputeHealth(t *testing.T) {
+	r := Result{
+		Domains: []DomainResult{
+			{Domain: "core", Percent: 70, Required: 80, Status: StatusFail},
+			{Domain: "api", Percent: 90, Required: 80, Status: StatusPass},
+			{Domain: "billing", Percent: 60, Required: 75, Status: StatusWarn}, // Exempted by an exception
+		},
+		Files: []FileResult{
+			{File: "core/a.go", Percent: 95, Required: 90, Status: StatusPass},
+			{File: "core/b.go", Percent: 87.5, Required: 90, Status: StatusFail},
+		},
+	}
+	got := r.ComputeHealth(2)
+	want := Health{Score: 40, PassingDomains: 1, FailingDomains: 1, Shortfall: 27.5, Events: 2}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if empty := (Result{}).ComputeHealth(1); empty.Score != 100 || empty.Events != 1 {
+		t.Fatalf("expected a perfect score without results, got %+v", empty)
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// HeatmapNode is one directory or file in the coverage treemap. A
+// directory's counts are the sum of everything below it.
+type HeatmapNode struct {
+	Name      string        `json:"name"`
+	Path      string        `json:"path"`
+	Covered   int           `json:"covered"`
+	Total     int           `json:"total"`
+	Uncovered int           `json:"uncovered"`
+	Percent   float64       `json:"percent"`
+	Children  []HeatmapNode `json:"children,omitempty"`
+}
+
+// BuildHeatmap nests module-relative files into a directory tree. Files
+// without statements are dropped, directories that only wrap a single
+// subdirectory are collapsed into it ("internal/core"), and children are
+// ordered by statements, largest first, so the biggest areas come first.
+func BuildHeatmap(files map[string]CoverageStat) HeatmapNode {
+	root := &heatmapDir{children: map[string]*heatmapDir{}}
+	for file, stat := range files {
+		if stat.Total == 0 {
+			continue
+		}
+		parts := strings.Split(strings.Trim(file, "/"), "/")
+		dir := root
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := dir.children[part]
+			if !ok {
+				next = &heatmapDir{children: map[string]*heatmapDir{}}
+				dir.children[part] = next
+			}
+			dir = next
+		}
+		dir.files = append(dir.files, HeatmapNode{
+			Name:    parts[len(parts)-1],
+			Path:    file,
+			Covered: stat.Covered,
+			Total:   stat.Total,
+		})
+	}
+	return root.node(".", "")
+}
+
+// heatmapDir accumulates a directory while files are added.
+type heatmapDir struct {
+	children map[string]*heatmapDir
+	files    []HeatmapNode
+}
+
+func (d *heatmapDir) node(name, path string) HeatmapNode {
+	// Collapse chains like internal/ -> core/ that hold nothing else.
+	for len(d.files) == 0 && len(d.children) == 1 {
+		for childName, child := range d.children {
+			path = joinHeatmapPath(path, childName)
+			if name == "." {
+				name = childName
+			} else {
+				name += "/" + childName
+			}
+			d = child
+		}
+	}
+
+	n := HeatmapNode{Name: name, Path: path}
+	for childName, child := range d.children {
+		n.Children = append(n.Children, child.node(childName, joinHeatmapPath(path, childName)))
+	}
+	n.Children = append(n.Children, d.files...)
+	for i := range n.Children {
+		c := &n.Children[i]
+		if len(c.Children) == 0 {
+			stat := CoverageStat{Covered: c.Covered, Total: c.Total}
+			c.Uncovered = stat.Uncovered()
+			c.Percent = stat.PercentRounded()
+		}
+		n.Covered += c.Covered
+		n.Total += c.Total
+	}
+	sort.Slice(n.Children, func(i, j int) bool {
+		if n.Children[i].Total != n.Children[j].Total {
+			return n.Children[i].Total > n.Children[j].Total
+		}
+		return n.Children[i].Name < n.Children[j].Name
+	})
+	stat := CoverageStat{Covered: n.Covered, Total: n.Total}
+	n.Uncovered = stat.Uncovered()
+	n.Percent = stat.PercentRounded()
+	return n
+}
+
+func joinHeatmapPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
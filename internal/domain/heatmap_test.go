@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestBuildHeatmap(t *testing.T) {
+	root := BuildHeatmap(map[string]CoverageStat{
+		"internal/core/a.go":     {Covered: 2, Total: 10},
+		"internal/core/b.go":     {Covered: 6, Total: 6},
+		"internal/api/h.go":      {Covered: 20, Total: 40},
+		"internal/api/v1/r.go":   {Covered: 0, Total: 4},
+		"internal/core/empty.go": {Covered: 0, Total: 0},
+	})
+
+	if root.Name != "internal" || root.Path != "internal" {
+		t.Fatalf("expected single top-level directory to collapse into root, got %q (%q)", root.Name, root.Path)
+	}
+	if root.Covered != 28 || root.Total != 60 || root.Uncovered != 32 || root.Percent != 46.7 {
+		t.Fatalf("unexpected root totals: %+v", root)
+	}
+	if len(root.Children) != 2 || root.Children[0].Name != "api" || root.Children[1].Name != "core" {
+		t.Fatalf("expected api before core by size, got %+v", root.Children)
+	}
+
+	api := root.Children[0]
+	if api.Path != "internal/api" || api.Total != 44 || len(api.Children) != 2 {
+		t.Fatalf("unexpected api node: %+v", api)
+	}
+	if h := api.Children[0]; h.Name != "h.go" || h.Path != "internal/api/h.go" || h.Percent != 50 {
+		t.Fatalf("unexpected file node: %+v", h)
+	}
+	if v1 := api.Children[1]; v1.Path != "internal/api/v1" || v1.Uncovered != 4 || v1.Percent != 0 {
+		t.Fatalf("unexpected nested directory: %+v", v1)
+	}
+
+	core := root.Children[1]
+	if len(core.Children) != 2 {
+		t.Fatalf("expected files without statements to be dropped, got %+v", core.Children)
+	}
+}
+
+func TestBuildHeatmapEmpty(t *testing.T) {
+	root := BuildHeatmap(nil)
+	if root.Name != "." || root.Total != 0 || len(root.Children) != 0 {
+		t.Fatalf("unexpected empty heatmap: %+v", root)
+	}
+}
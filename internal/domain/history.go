@@ -4,11 +4,18 @@ import "time"
 
 // HistoryEntry represents a single coverage measurement over time.
 type HistoryEntry struct {
-	Timestamp time.Time              `json:"timestamp"`
-	Commit    string                 `json:"commit,omitempty"`
-	Branch    string                 `json:"branch,omitempty"`
-	Overall   float64                `json:"overall"`
-	Domains   map[string]DomainEntry `json:"domains"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Commit     string                 `json:"commit,omitempty"`
+	Branch     string                 `json:"branch,omitempty"`
+	Tag        string                 `json:"tag,omitempty"`
+	PR         int                    `json:"pr,omitempty"`
+	RunID      string                 `json:"runId,omitempty"`
+	Overall    float64                `json:"overall"`
+	Statements int                    `json:"statements,omitempty"` // Zero in entries recorded before it was tracked
+	Domains    map[string]DomainEntry `json:"domains"`
+	Files      FileHistory            `json:"files,omitempty"`
+	Profiles   []ProfileDigest        `json:"profiles,omitempty"`  // Recorded with a signature
+	Signature  *Signature             `json:"signature,omitempty"` // See SigningPayload
 }
 
 // DomainEntry represents coverage for a single domain at a point in time.
@@ -86,3 +93,41 @@ func CalculateTrend(previous, current float64) Trend {
 		Delta:     delta,
 	}
 }
+
+// SustainedMinimum returns the lowest coverage a domain has recorded since
+// the given time, including the last entry recorded at or before it (that
+// value was in effect when the window opened). It reports false when history
+// does not reach back to since, because the domain cannot then be shown to
+// have held any value for the whole window.
+func (h *History) SustainedMinimum(domainName string, since time.Time) (float64, bool) {
+	var (
+		lowest   float64
+		anchored bool
+		anchor   time.Time
+		anchorV  float64
+		inWindow bool
+	)
+	for _, e := range h.Entries {
+		d, ok := e.Domains[domainName]
+		if !ok {
+			continue
+		}
+		if !e.Timestamp.After(since) {
+			if !anchored || e.Timestamp.After(anchor) {
+				anchored, anchor, anchorV = true, e.Timestamp, d.Percent
+			}
+			continue
+		}
+		if !inWindow || d.Percent < lowest {
+			lowest = d.Percent
+		}
+		inWindow = true
+	}
+	if !anchored {
+		return 0, false
+	}
+	if !inWindow || anchorV < lowest {
+		lowest = anchorV
+	}
+	return lowest, true
+}
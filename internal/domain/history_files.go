@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// FileHistory holds per-file statement counts in a history entry, keyed by
+// module-relative path. It is recorded only with history.track_files, and
+// serialised as base64 gzip-compressed JSON because it dwarfs the rest of
+// the entry on large modules.
+type FileHistory map[string]CoverageStat
+
+// MarshalJSON encodes the counts as a compressed string.
+func (f FileHistory) MarshalJSON() ([]byte, error) {
+	compact := make(map[string][2]int, len(f))
+	for file, stat := range f {
+		compact[file] = [2]int{stat.Covered, stat.Total}
+	}
+	raw, err := json.Marshal(compact)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// UnmarshalJSON decodes a string written by MarshalJSON.
+func (f *FileHistory) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return fmt.Errorf("file history: %w", err)
+	}
+	gz, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("file history: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return fmt.Errorf("file history: %w", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("file history: %w", err)
+	}
+	var compact map[string][2]int
+	if err := json.Unmarshal(raw, &compact); err != nil {
+		return fmt.Errorf("file history: %w", err)
+	}
+	*f = make(FileHistory, len(compact))
+	for file, counts := range compact {
+		(*f)[file] = CoverageStat{Covered: counts[0], Total: counts[1]}
+	}
+	return nil
+}
+
+// FileTrendPoint is one file's coverage in one history entry.
+type FileTrendPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Commit    string    `json:"commit,omitempty"`
+	Percent   float64   `json:"percent"`
+	Covered   int       `json:"covered"`
+	Total     int       `json:"total"`
+	// Trend is the change since the file's previous point; stable for the
+	// first point.
+	Trend Trend `json:"trend"`
+}
+
+// FileTrend returns the file's coverage in every entry that tracked it, in
+// time order.
+func (h *History) FileTrend(file string) []FileTrendPoint {
+	entries := append([]HistoryEntry(nil), h.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+	var points []FileTrendPoint
+	for _, e := range entries {
+		stat, ok := e.Files[file]
+		if !ok {
+			continue
+		}
+		p := FileTrendPoint{
+			Timestamp: e.Timestamp,
+			Commit:    e.Commit,
+			Percent:   stat.PercentRounded(),
+			Covered:   stat.Covered,
+			Total:     stat.Total,
+			Trend:     Trend{Direction: TrendStable},
+		}
+		if len(points) > 0 {
+			p.Trend = CalculateTrend(points[len(points)-1].Percent, p.Percent)
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// FileRegression is a file whose coverage dropped since it was recorded.
+type FileRegression struct {
+	File     string  `json:"file"`
+	Previous float64 `json:"previous"`
+	Current  float64 `json:"current"`
+	Delta    float64 `json:"delta"`
+}
+
+// FileRegressions compares current per-file coverage with a recorded entry
+// and returns the files whose coverage went down by more than the
+// CalculateTrend noise margin, largest drop first. Files missing on either
+// side are skipped.
+func FileRegressions(recorded FileHistory, current map[string]CoverageStat) []FileRegression {
+	var out []FileRegression
+	for file, stat := range current {
+		before, ok := recorded[file]
+		if !ok || before.Total == 0 || stat.Total == 0 {
+			continue
+		}
+		prev, cur := before.PercentRounded(), stat.PercentRounded()
+		if CalculateTrend(prev, cur).Direction != TrendDown {
+			continue
+		}
+		out = append(out, FileRegression{File: file, Previous: prev, Current: cur, Delta: Round1(cur - prev)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Delta != out[j].Delta {
+			return out[i].Delta < out[j].Delta
+		}
+		return out[i].File < out[j].File
+	})
+	return out
+}
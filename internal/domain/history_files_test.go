@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileHistoryJSONRoundTrip(t *testing.T) {
+	entry := HistoryEntry{
+		Timestamp: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		Overall:   80,
+		Files:     FileHistory{"internal/core/a.go": {Covered: 3, Total: 4}, "main.go": {Covered: 0, Total: 2}},
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(data), "internal/core/a.go") {
+		t.Fatalf("expected compressed files, got %s", data)
+	}
+	var got HistoryEntry
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(got.Files, entry.Files) {
+		t.Fatalf("round trip mismatch: %+v", got.Files)
+	}
+
+	data, _ = json.Marshal(HistoryEntry{Overall: 80})
+	if strings.Contains(string(data), `"files"`) {
+		t.Fatalf("expected files omitted when untracked, got %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"files":"not base64!"}`), &got); err == nil {
+		t.Fatal("expected error for corrupt file history")
+	}
+}
+
+func TestHistoryFileTrend(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC) }
+	h := History{Entries: []HistoryEntry{
+		{Timestamp: day(3), Commit: "c3", Files: FileHistory{"a.go": {Covered: 2, Total: 4}}},
+		{Timestamp: day(1), Commit: "c1", Files: FileHistory{"a.go": {Covered: 3, Total: 4}}},
+		{Timestamp: day(2), Commit: "c2"},
+		{Timestamp: day(4), Commit: "c4", Files: FileHistory{"b.go": {Covered: 1, Total: 1}}},
+	}}
+	points := h.FileTrend("a.go")
+	if len(points) != 2 || points[0].Commit != "c1" || points[1].Commit != "c3" {
+		t.Fatalf("unexpected points %+v", points)
+	}
+	if points[0].Trend.Direction != TrendStable || points[1].Trend.Direction != TrendDown || points[1].Percent != 50 {
+		t.Fatalf("unexpected trend %+v", points)
+	}
+	if len(h.FileTrend("missing.go")) != 0 {
+		t.Fatal("expected no points for an untracked file")
+	}
+}
+
+func TestFileRegressions(t *testing.T) {
+	recorded := FileHistory{
+		"a.go": {Covered: 9, Total: 10},
+		"b.go": {Covered: 5, Total: 10},
+		"c.go": {Covered: 10, Total: 10},
+		"d.go": {Covered: 1, Total: 1},
+	}
+	current := map[string]CoverageStat{
+		"a.go":   {Covered: 8, Total: 10},
+		"b.go":   {Covered: 6, Total: 10},
+		"c.go":   {Covered: 5, Total: 10},
+		"new.go": {Covered: 0, Total: 3},
+	}
+	got := FileRegressions(recorded, current)
+	want := []FileRegression{
+		{File: "c.go", Previous: 100, Current: 50, Delta: -50},
+		{File: "a.go", Previous: 90, Current: 80, Delta: -10},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected regressions %+v", got)
+	}
+}
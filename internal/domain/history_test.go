@@ -88,3 +88,32 @@ func TestCalculateTrend(t *testing.T) {
 		})
 	}
 }
+
+func TestHistorySustainedMinimum(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	entry := func(ago time.Duration, pct float64) HistoryEntry {
+		return HistoryEntry{Timestamp: now.Add(-ago), Domains: map[string]DomainEntry{"core": {Percent: pct}}}
+	}
+
+	tests := []struct {
+		name    string
+		entries []HistoryEntry
+		want    float64
+		wantOK  bool
+	}{
+		{"history too short", []HistoryEntry{entry(3*day, 80), entry(day, 82)}, 0, false},
+		{"anchor value counts", []HistoryEntry{entry(20*day, 70), entry(10*day, 85), entry(day, 90)}, 70, true},
+		{"lowest in window", []HistoryEntry{entry(30*day, 60), entry(15*day, 80), entry(8*day, 78), entry(day, 84)}, 78, true},
+		{"only anchor", []HistoryEntry{entry(20*day, 75)}, 75, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := History{Entries: tt.entries}
+			got, ok := h.SustainedMinimum("core", now.Add(-14*day))
+			if ok != tt.wantOK || got != tt.want {
+				t.Fatalf("expected (%.1f, %v), got (%.1f, %v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "fmt"
+
+// Metric is the unit coverage is counted in. Statements is the profile's
+// native unit: statements for Go profiles, lines for LCOV, Cobertura, and
+// JaCoCo reports, which record nothing finer.
+type Metric string
+
+const (
+	MetricStatements Metric = "statements"
+	MetricLines      Metric = "lines"
+	MetricBranches   Metric = "branches"
+)
+
+// ParseMetric validates a configured metric; empty is statements.
+func ParseMetric(s string) (Metric, error) {
+	switch m := Metric(s); m {
+	case "", MetricStatements:
+		return MetricStatements, nil
+	case MetricLines, MetricBranches:
+		return m, nil
+	}
+	return "", fmt.Errorf("metric %q must be statements, lines, or branches", s)
+}
+
+// MetricFor returns the metric d is measured by: its own, else the
+// policy's, else statements.
+func (p Policy) MetricFor(d Domain) Metric {
+	switch {
+	case d.Metric != "":
+		return d.Metric
+	case p.Metric != "":
+		return p.Metric
+	}
+	return MetricStatements
+}
+
+// reported is the metric as a domain result records it, empty for the
+// default so results measured by statements are unchanged.
+func (m Metric) reported() Metric {
+	if m == MetricStatements {
+		return ""
+	}
+	return m
+}
+
+// LineStat counts the instrumented lines of lines and those hit at least
+// once.
+func LineStat(lines LineCoverage) CoverageStat {
+	var stat CoverageStat
+	for _, hits := range lines {
+		stat.Total++
+		if hits > 0 {
+			stat.Covered++
+		}
+	}
+	return stat
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestParseMetric(t *testing.T) {
+	for in, want := range map[string]Metric{"": MetricStatements, "statements": MetricStatements, "lines": MetricLines, "branches": MetricBranches} {
+		if got, err := ParseMetric(in); err != nil || got != want {
+			t.Errorf("%q: got %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseMetric("functions"); err == nil {
+		t.Fatal("expected an error for an unknown metric")
+	}
+}
+
+func TestEvaluateMetric(t *testing.T) {
+	policy := Policy{DefaultMin: 50, Metric: MetricLines, Domains: []Domain{
+		{Name: "core"},
+		{Name: "api", Metric: MetricBranches},
+		{Name: "cli", Metric: MetricStatements},
+	}}
+	result := Evaluate(policy, map[string]CoverageStat{})
+	want := []Metric{MetricLines, MetricBranches, ""}
+	for i, d := range result.Domains {
+		if d.Metric != want[i] {
+			t.Errorf("%s: metric %q, want %q", d.Domain, d.Metric, want[i])
+		}
+	}
+}
+
+func TestLineStat(t *testing.T) {
+	if got := LineStat(LineCoverage{1: 2, 2: 0, 5: 1}); got != (CoverageStat{Covered: 2, Total: 3}) {
+		t.Fatalf("unexpected stat %+v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewCodeResult is coverage measured over lines last changed within an age
+// window (policy.new_code_since), so recent code can be held to a higher bar
+// than legacy code.
+type NewCodeResult struct {
+	PatchResult
+	Since string `json:"since"`
+}
+
+// ParseAge parses an age window such as "90d", "12w", or any Go duration
+// ("720h").
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q: use days (90d), weeks (12w), or a duration (720h)", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"90d", 90 * 24 * time.Hour},
+		{"12w", 12 * 7 * 24 * time.Hour},
+		{"720h", 720 * time.Hour},
+		{" 1d ", 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "d", "-3d", "0w", "soon", "-1h"} {
+		if _, err := ParseAge(bad); err == nil {
+			t.Errorf("ParseAge(%q): expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NewDomainPolicy decides how a domain with no recorded history is held to
+// its threshold on its first runs.
+type NewDomainPolicy string
+
+const (
+	// NewDomainDefault applies the configured minimum, as for any domain.
+	NewDomainDefault NewDomainPolicy = "default"
+	// NewDomainWarn reports a new domain below its minimum as WARN instead of FAIL.
+	NewDomainWarn NewDomainPolicy = "warn"
+	// NewDomainCurrent lowers a new domain's minimum to its current coverage.
+	NewDomainCurrent NewDomainPolicy = "current"
+)
+
+// Valid reports whether p is a known policy; empty means NewDomainDefault.
+func (p NewDomainPolicy) Valid() bool {
+	switch p {
+	case "", NewDomainDefault, NewDomainWarn, NewDomainCurrent:
+		return true
+	}
+	return false
+}
+
+// KnownDomains returns the names of every domain recorded in any history entry.
+func (h History) KnownDomains() map[string]struct{} {
+	known := make(map[string]struct{})
+	for _, entry := range h.Entries {
+		for name := range entry.Domains {
+			known[name] = struct{}{}
+		}
+	}
+	return known
+}
+
+// ApplyNewDomainPolicy relaxes the thresholds of domains that history has
+// never recorded. Without any history there is no baseline, so no domain
+// counts as new. Passed is recomputed from the domain statuses, so call it
+// right after Evaluate, before other checks can fail the result.
+func (r *Result) ApplyNewDomainPolicy(policy NewDomainPolicy, history History) {
+	if policy == "" || policy == NewDomainDefault || len(history.Entries) == 0 {
+		return
+	}
+	known := history.KnownDomains()
+	r.NewDomains = nil
+	for i := range r.Domains {
+		d := &r.Domains[i]
+		if _, ok := known[d.Domain]; ok {
+			continue
+		}
+		r.NewDomains = append(r.NewDomains, d.Domain)
+		if d.Status != StatusFail {
+			continue
+		}
+		switch policy {
+		case NewDomainWarn:
+			d.Status = StatusWarn
+			r.Warnings = append(r.Warnings, fmt.Sprintf("domain %s is new: %.1f%% is below min %.1f%%, reported as a warning (policy.new_domain: warn)", d.Domain, d.Percent, d.Required))
+		case NewDomainCurrent:
+			r.Warnings = append(r.Warnings, fmt.Sprintf("domain %s is new: min lowered from %.1f%% to its current %.1f%% (policy.new_domain: current)", d.Domain, d.Required, d.Percent))
+			d.Required = d.Percent
+			d.Status = StatusPass
+		}
+	}
+	sort.Strings(r.NewDomains)
+
+	r.Passed = true
+	for _, d := range r.Domains {
+		if d.Status == StatusFail {
+			r.Passed = false
+		}
+	}
+	for _, g := range r.Groups {
+		if g.Status == StatusFail {
+			r.Passed = false
+		}
+	}
+}
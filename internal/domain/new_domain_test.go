@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func newDomainFixture() (Result, History) {
+	result := Result{
+		Domains: []DomainResult{
+			{Domain: "core", Percent: 85, Required: 80, Status: StatusPass},
+			{Domain: "billing", Percent: 42.5, Required: 80, Status: StatusFail},
+		},
+		Passed: false,
+	}
+	history := History{Entries: []HistoryEntry{
+		{Domains: map[string]DomainEntry{"core": {Name: "core", Percent: 84}}},
+	}}
+	return result, history
+}
+
+func TestApplyNewDomainPolicyWarn(t *testing.T) {
+	result, history := newDomainFixture()
+	result.ApplyNewDomainPolicy(NewDomainWarn, history)
+
+	if !result.Passed {
+		t.Fatal("expected new failing domain to no longer fail the result")
+	}
+	if result.Domains[1].Status != StatusWarn || result.Domains[1].Required != 80 {
+		t.Fatalf("unexpected billing result: %+v", result.Domains[1])
+	}
+	if !slices.Equal(result.NewDomains, []string{"billing"}) {
+		t.Fatalf("expected billing listed as new, got %v", result.NewDomains)
+	}
+	if len(result.Warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", result.Warnings)
+	}
+}
+
+func TestApplyNewDomainPolicyCurrent(t *testing.T) {
+	result, history := newDomainFixture()
+	result.ApplyNewDomainPolicy(NewDomainCurrent, history)
+
+	if !result.Passed {
+		t.Fatal("expected result to pass")
+	}
+	if result.Domains[1].Status != StatusPass || result.Domains[1].Required != 42.5 {
+		t.Fatalf("expected min lowered to current coverage, got %+v", result.Domains[1])
+	}
+}
+
+func TestApplyNewDomainPolicyNeedsHistory(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		policy  NewDomainPolicy
+		history History
+	}{
+		{"default policy", NewDomainDefault, History{Entries: []HistoryEntry{{}}}},
+		{"no history", NewDomainWarn, History{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := newDomainFixture()
+			result.ApplyNewDomainPolicy(tt.policy, tt.history)
+			if result.Passed || result.Domains[1].Status != StatusFail || result.NewDomains != nil {
+				t.Fatalf("expected result unchanged, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestNewDomainPolicyValid(t *testing.T) {
+	for _, p := range []NewDomainPolicy{"", NewDomainDefault, NewDomainWarn, NewDomainCurrent} {
+		if !p.Valid() {
+			t.Errorf("expected %q to be valid", p)
+		}
+	}
+	if NewDomainPolicy("strict").Valid() {
+		t.Error("expected unknown policy to be invalid")
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// NotifyRule describes which coverage changes warrant a notification.
+type NotifyRule struct {
+	Regression *float64 // Drop in percentage points that triggers; nil disables
+	OnFailure  bool     // Any domain below its minimum triggers
+}
+
+// NotifyReason is one reason a rule fires, with the domain it concerns;
+// Domain is empty for overall coverage.
+type NotifyReason struct {
+	Domain string
+	Text   string
+}
+
+// Reasons lists why the rule fires for current compared to previous, in a
+// stable order. previous may be nil, which disables regression checks. An
+// empty result means no notification is due.
+func (r NotifyRule) Reasons(current HistoryEntry, previous *HistoryEntry) []string {
+	found := r.DomainReasons(current, previous)
+	reasons := make([]string, 0, len(found))
+	for _, reason := range found {
+		reasons = append(reasons, reason.Text)
+	}
+	return reasons
+}
+
+// DomainReasons is Reasons with each reason tied to its domain, so
+// notifications can be routed to the domain's owners.
+func (r NotifyRule) DomainReasons(current HistoryEntry, previous *HistoryEntry) []NotifyReason {
+	var reasons []NotifyReason
+
+	if r.Regression != nil && previous != nil {
+		if drop := Round1(previous.Overall - current.Overall); drop > *r.Regression {
+			reasons = append(reasons, NotifyReason{Text: fmt.Sprintf("overall coverage dropped %.1f points (%.1f%% -> %.1f%%)", drop, previous.Overall, current.Overall)})
+		}
+		for _, name := range sortedDomainNames(current.Domains) {
+			prev, ok := previous.Domains[name]
+			if !ok {
+				continue
+			}
+			cur := current.Domains[name]
+			if drop := Round1(prev.Percent - cur.Percent); drop > *r.Regression {
+				reasons = append(reasons, NotifyReason{Domain: name, Text: fmt.Sprintf("domain %s dropped %.1f points (%.1f%% -> %.1f%%)", name, drop, prev.Percent, cur.Percent)})
+			}
+		}
+	}
+
+	if r.OnFailure {
+		for _, name := range sortedDomainNames(current.Domains) {
+			d := current.Domains[name]
+			if d.Status == StatusFail {
+				reasons = append(reasons, NotifyReason{Domain: name, Text: fmt.Sprintf("domain %s is below its minimum (%.1f%% < %.1f%%)", name, d.Percent, d.Min)})
+			}
+		}
+	}
+	return reasons
+}
+
+// EntryFromResult summarizes a policy result as a history entry so results
+// that were not recorded can be compared against recorded history.
+func EntryFromResult(result Result, at time.Time) HistoryEntry {
+	entry := HistoryEntry{
+		Timestamp: at,
+		Overall:   result.OverallPercent(),
+		Domains:   make(map[string]DomainEntry, len(result.Domains)),
+	}
+	for _, d := range result.Domains {
+		entry.Domains[d.Domain] = DomainEntry{Name: d.Domain, Percent: d.Percent, Min: d.Required, Status: d.Status}
+	}
+	return entry
+}
+
+func sortedDomainNames(domains map[string]DomainEntry) []string {
+	names := make([]string, 0, len(domains))
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
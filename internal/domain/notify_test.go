@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotifyRuleReasons(t *testing.T) {
+	threshold := 1.0
+	previous := &HistoryEntry{
+		Overall: 80,
+		Domains: map[string]DomainEntry{
+			"api":  {Name: "api", Percent: 75, Min: 70, Status: StatusPass},
+			"core": {Name: "core", Percent: 85, Min: 80, Status: StatusPass},
+		},
+	}
+	current := HistoryEntry{
+		Overall: 78.5,
+		Domains: map[string]DomainEntry{
+			"api":  {Name: "api", Percent: 68, Min: 70, Status: StatusFail},
+			"core": {Name: "core", Percent: 84.5, Min: 80, Status: StatusPass},
+		},
+	}
+
+	t.Run("regression and failure", func(t *testing.T) {
+		reasons := NotifyRule{Regression: &threshold, OnFailure: true}.Reasons(current, previous)
+		if len(reasons) != 3 {
+			t.Fatalf("expected 3 reasons, got %v", reasons)
+		}
+		if !strings.HasPrefix(reasons[0], "overall coverage dropped 1.5 points") {
+			t.Errorf("unexpected overall reason: %s", reasons[0])
+		}
+		if !strings.HasPrefix(reasons[1], "domain api dropped 7.0 points") {
+			t.Errorf("unexpected domain reason: %s", reasons[1])
+		}
+		if !strings.HasPrefix(reasons[2], "domain api is below its minimum") {
+			t.Errorf("unexpected failure reason: %s", reasons[2])
+		}
+	})
+
+	t.Run("reasons name their domain", func(t *testing.T) {
+		reasons := NotifyRule{Regression: &threshold, OnFailure: true}.DomainReasons(current, previous)
+		if len(reasons) != 3 || reasons[0].Domain != "" || reasons[1].Domain != "api" || reasons[2].Domain != "api" {
+			t.Fatalf("unexpected domain reasons: %+v", reasons)
+		}
+	})
+
+	t.Run("quiet without previous entry or rules", func(t *testing.T) {
+		if reasons := (NotifyRule{Regression: &threshold}).Reasons(current, nil); len(reasons) != 0 {
+			t.Fatalf("expected no reasons without history, got %v", reasons)
+		}
+		if reasons := (NotifyRule{}).Reasons(current, previous); len(reasons) != 0 {
+			t.Fatalf("expected no reasons for empty rule, got %v", reasons)
+		}
+	})
+}
+
+func TestEntryFromResult(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	result := Result{Domains: []DomainResult{{Domain: "core", Covered: 3, Total: 4, Percent: 75, Required: 80, Status: StatusFail}}}
+	entry := EntryFromResult(result, at)
+	if entry.Overall != 75 || entry.Domains["core"].Min != 80 || entry.Domains["core"].Status != StatusFail || !entry.Timestamp.Equal(at) {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ServiceCoverage is one repository's coverage in an org-wide aggregate,
+// taken from its JSON report or the latest entry of its history file.
+type ServiceCoverage struct {
+	Service string  `json:"service"`
+	Source  string  `json:"source"`
+	Percent float64 `json:"percent"`
+	// Covered and Total are summed over the service's domains; zero when
+	// the input was a history file, which records percentages only.
+	Covered  int            `json:"covered,omitempty"`
+	Total    int            `json:"total,omitempty"`
+	Passed   bool           `json:"passed"`
+	Failing  []string       `json:"failing,omitempty"`
+	Domains  []DomainResult `json:"domains"`
+	Recorded *time.Time     `json:"recorded,omitempty"`
+	// Delta is the change since the previous history entry, when the
+	// input was a history file with at least two entries.
+	Delta *float64 `json:"delta,omitempty"`
+}
+
+const orgTrendDateLayout = "2006-01-02"
+
+// OrgTrendPoint is org-wide coverage on one day: the mean of each
+// service's most recent recorded coverage as of that day.
+type OrgTrendPoint struct {
+	Date     string  `json:"date"`
+	Percent  float64 `json:"percent"`
+	Services int     `json:"services"`
+}
+
+// OrgReport combines coverage across repositories.
+type OrgReport struct {
+	Percent  float64           `json:"percent"`
+	Passed   bool              `json:"passed"`
+	Services []ServiceCoverage `json:"services"`
+	Trend    []OrgTrendPoint   `json:"trend,omitempty"`
+}
+
+// ServiceFromResult summarises a service's evaluated report.
+func ServiceFromResult(service, source string, r Result) ServiceCoverage {
+	sc := ServiceCoverage{Service: service, Source: source, Passed: r.Passed, Domains: r.Domains}
+	for _, d := range r.Domains {
+		sc.Covered += d.Covered
+		sc.Total += d.Total
+		if d.Status == StatusFail {
+			sc.Failing = append(sc.Failing, d.Domain)
+		}
+	}
+	sc.Percent = r.OverallPercent()
+	return sc
+}
+
+// ServiceFromHistory summarises a service from its latest history entry.
+// It returns false when the history has no entries.
+func ServiceFromHistory(service, source string, h History) (ServiceCoverage, bool) {
+	latest := h.LatestEntry()
+	if latest == nil {
+		return ServiceCoverage{}, false
+	}
+	recorded := latest.Timestamp
+	sc := ServiceCoverage{Service: service, Source: source, Percent: latest.Overall, Passed: true, Recorded: &recorded}
+	names := make([]string, 0, len(latest.Domains))
+	for name := range latest.Domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e := latest.Domains[name]
+		status := e.Status
+		if status == "" {
+			status = StatusPass
+			if e.Percent < e.Min {
+				status = StatusFail
+			}
+		}
+		if status == StatusFail {
+			sc.Passed = false
+			sc.Failing = append(sc.Failing, name)
+		}
+		sc.Domains = append(sc.Domains, DomainResult{Domain: name, Percent: e.Percent, Required: e.Min, Status: status})
+	}
+	if previous := previousEntry(h, latest.Timestamp); previous != nil {
+		delta := Round1(latest.Overall - previous.Overall)
+		sc.Delta = &delta
+	}
+	return sc, true
+}
+
+// previousEntry returns the most recent entry recorded before latest.
+func previousEntry(h History, latest time.Time) *HistoryEntry {
+	var prev *HistoryEntry
+	for i := range h.Entries {
+		e := &h.Entries[i]
+		if e.Timestamp.Before(latest) && (prev == nil || e.Timestamp.After(prev.Timestamp)) {
+			prev = e
+		}
+	}
+	return prev
+}
+
+// BuildOrgReport sorts services by name and totals them. Overall coverage
+// is statement-weighted when every service reports statement counts and
+// the mean of service percentages otherwise. histories, keyed by service,
+// feed the org-wide trend.
+func BuildOrgReport(services []ServiceCoverage, histories map[string]History) OrgReport {
+	report := OrgReport{Passed: true, Services: append([]ServiceCoverage(nil), services...)}
+	sort.SliceStable(report.Services, func(i, j int) bool { return report.Services[i].Service < report.Services[j].Service })
+
+	weighted := len(report.Services) > 0
+	var covered, total int
+	var sum float64
+	for _, s := range report.Services {
+		if !s.Passed {
+			report.Passed = false
+		}
+		if s.Total == 0 {
+			weighted = false
+		}
+		covered += s.Covered
+		total += s.Total
+		sum += s.Percent
+	}
+	switch {
+	case weighted:
+		report.Percent = Round1(float64(covered) / float64(total) * 100)
+	case len(report.Services) > 0:
+		report.Percent = Round1(sum / float64(len(report.Services)))
+	}
+	report.Trend = orgTrend(histories)
+	return report
+}
+
+// orgTrend averages, for every day any service recorded coverage, each
+// service's latest recorded overall as of the end of that day. Services
+// join the average from their first entry on.
+func orgTrend(histories map[string]History) []OrgTrendPoint {
+	type sample struct {
+		at      time.Time
+		service string
+		percent float64
+	}
+	var samples []sample
+	for service, h := range histories {
+		for _, e := range h.Entries {
+			samples = append(samples, sample{at: e.Timestamp.UTC(), service: service, percent: e.Overall})
+		}
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].at.Before(samples[j].at) })
+
+	latest := make(map[string]float64)
+	var points []OrgTrendPoint
+	for i, s := range samples {
+		latest[s.service] = s.percent
+		day := s.at.Format(orgTrendDateLayout)
+		if i+1 < len(samples) && samples[i+1].at.Format(orgTrendDateLayout) == day {
+			continue
+		}
+		var sum float64
+		for _, p := range latest {
+			sum += p
+		}
+		points = append(points, OrgTrendPoint{Date: day, Percent: Round1(sum / float64(len(latest))), Services: len(latest)})
+	}
+	return points
+}
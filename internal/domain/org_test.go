@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestServiceFromResult(t *testing.T) {
+	r := Result{Passed: false, Domains: []DomainResult{
+		{Domain: "core", Covered: 80, Total: 100, Status: StatusPass},
+		{Domain: "api", Covered: 10, Total: 100, Status: StatusFail},
+	}}
+	sc := ServiceFromResult("payments", "reports/payments.json", r)
+	if sc.Percent != 45 || sc.Covered != 90 || sc.Total != 200 || sc.Passed {
+		t.Fatalf("unexpected service: %+v", sc)
+	}
+	if len(sc.Failing) != 1 || sc.Failing[0] != "api" {
+		t.Fatalf("expected api failing, got %v", sc.Failing)
+	}
+}
+
+func TestServiceFromHistory(t *testing.T) {
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	h := History{Entries: []HistoryEntry{
+		{Timestamp: day, Overall: 70},
+		{Timestamp: day.Add(24 * time.Hour), Overall: 72.5, Domains: map[string]DomainEntry{
+			"core": {Name: "core", Percent: 90, Min: 80},
+			"api":  {Name: "api", Percent: 50, Min: 60},
+		}},
+	}}
+	sc, ok := ServiceFromHistory("ledger", "ledger/history.json", h)
+	if !ok {
+		t.Fatal("expected a service from non-empty history")
+	}
+	if sc.Percent != 72.5 || sc.Passed || sc.Delta == nil || *sc.Delta != 2.5 {
+		t.Fatalf("unexpected service: %+v", sc)
+	}
+	if len(sc.Domains) != 2 || sc.Domains[0].Domain != "api" || sc.Domains[0].Status != StatusFail {
+		t.Fatalf("expected sorted domains with api failing, got %+v", sc.Domains)
+	}
+	if _, ok := ServiceFromHistory("empty", "empty.json", History{}); ok {
+		t.Fatal("expected no service from empty history")
+	}
+}
+
+func TestBuildOrgReport(t *testing.T) {
+	weighted := BuildOrgReport([]ServiceCoverage{
+		{Service: "b", Covered: 30, Total: 100, Percent: 30, Passed: true},
+		{Service: "a", Covered: 90, Total: 100, Percent: 90, Passed: false},
+	}, nil)
+	if weighted.Percent != 60 || weighted.Passed || weighted.Services[0].Service != "a" {
+		t.Fatalf("unexpected weighted report: %+v", weighted)
+	}
+
+	mean := BuildOrgReport([]ServiceCoverage{
+		{Service: "a", Covered: 10, Total: 1000, Percent: 1, Passed: true},
+		{Service: "b", Percent: 80, Passed: true},
+	}, nil)
+	if mean.Percent != 40.5 || !mean.Passed {
+		t.Fatalf("expected the mean when a service lacks statement counts, got %+v", mean)
+	}
+}
+
+func TestOrgTrend(t *testing.T) {
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	report := BuildOrgReport(nil, map[string]History{
+		"a": {Entries: []HistoryEntry{{Timestamp: day, Overall: 60}, {Timestamp: day.Add(48 * time.Hour), Overall: 70}}},
+		"b": {Entries: []HistoryEntry{{Timestamp: day.Add(time.Hour), Overall: 80}, {Timestamp: day.Add(24 * time.Hour), Overall: 90}}},
+	})
+	want := []OrgTrendPoint{
+		{Date: "2026-03-01", Percent: 70, Services: 2},
+		{Date: "2026-03-02", Percent: 75, Services: 2},
+		{Date: "2026-03-03", Percent: 80, Services: 2},
+	}
+	if len(report.Trend) != len(want) {
+		t.Fatalf("expected %d points, got %+v", len(want), report.Trend)
+	}
+	for i := range want {
+		if report.Trend[i] != want[i] {
+			t.Fatalf("point %d: expected %+v, got %+v", i, want[i], report.Trend[i])
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LineRange is an inclusive range of line numbers in a file.
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Contains reports whether line falls within the range.
+func (r LineRange) Contains(line int) bool {
+	return line >= r.Start && line <= r.End
+}
+
+// LineCoverage maps instrumented line numbers to hit counts for one file.
+// Lines absent from the map are not executable and never count toward
+// patch coverage.
+type LineCoverage map[int]int
+
+// UncoveredLine identifies a changed, executable line that no test hit.
+type UncoveredLine struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// String formats the line as file:line.
+func (u UncoveredLine) String() string {
+	return fmt.Sprintf("%s:%d", u.File, u.Line)
+}
+
+// PatchResult is coverage measured over changed lines only.
+type PatchResult struct {
+	Covered   int             `json:"covered"`
+	Total     int             `json:"total"`
+	Percent   float64         `json:"percent"`
+	Required  float64         `json:"required"`
+	Status    Status          `json:"status"`
+	Uncovered []UncoveredLine `json:"uncovered"`
+	// MaxUncovered is the diff.max_uncovered_lines budget; nil when unset.
+	MaxUncovered *int `json:"max_uncovered,omitempty"`
+}
+
+// ApplyBudget fails the patch when it leaves more than max changed lines
+// uncovered, however high its percentage.
+func (p *PatchResult) ApplyBudget(max int) {
+	p.MaxUncovered = &max
+	if len(p.Uncovered) > max {
+		p.Status = StatusFail
+	}
+}
+
+// Requirement describes what the patch was held to, e.g.
+// "required 80.0%, max 20 uncovered". With only a budget configured the
+// percentage is omitted.
+func (p PatchResult) Requirement() string {
+	if p.MaxUncovered == nil {
+		return fmt.Sprintf("required %.1f%%", p.Required)
+	}
+	budget := fmt.Sprintf("%d uncovered, max %d", len(p.Uncovered), *p.MaxUncovered)
+	if p.Required == 0 {
+		return budget
+	}
+	return fmt.Sprintf("required %.1f%%, %s", p.Required, budget)
+}
+
+// EvaluatePatch intersects changed line ranges with line-level coverage and
+// checks the result against required under rounding. A patch that touches
+// no executable lines passes at 100%.
+func EvaluatePatch(changed map[string][]LineRange, coverage map[string]LineCoverage, required float64, rounding Rounding) PatchResult {
+	result := PatchResult{Required: required, Uncovered: []UncoveredLine{}}
+
+	files := make([]string, 0, len(changed))
+	for file := range changed {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		lines, ok := coverage[file]
+		if !ok {
+			continue
+		}
+		numbers := make([]int, 0, len(lines))
+		for n := range lines {
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+
+		for _, n := range numbers {
+			if !inRanges(n, changed[file]) {
+				continue
+			}
+			result.Total++
+			if lines[n] > 0 {
+				result.Covered++
+			} else {
+				result.Uncovered = append(result.Uncovered, UncoveredLine{File: file, Line: n})
+			}
+		}
+	}
+
+	raw := 100.0
+	if result.Total > 0 {
+		raw = float64(result.Covered) / float64(result.Total) * 100
+	}
+	result.Percent = rounding.Percent(raw)
+	result.Status = StatusPass
+	if !rounding.Meets(raw, required) {
+		result.Status = StatusFail
+	}
+	return result
+}
+
+// PatchFile is the patch coverage of one changed file.
+type PatchFile struct {
+	File    string      `json:"file"`
+	Covered int         `json:"covered"`
+	Total   int         `json:"total"` // Changed executable lines
+	Percent float64     `json:"percent"`
+	Changed []LineRange `json:"changed"`
+	// Lines is the file's full line coverage, so views can mark changed
+	// lines that no test reached.
+	Lines LineCoverage `json:"-"`
+}
+
+// PatchFiles breaks patch coverage down by file, keeping files with at
+// least one changed executable line, least covered first.
+func PatchFiles(changed map[string][]LineRange, coverage map[string]LineCoverage) []PatchFile {
+	var files []PatchFile
+	for file, ranges := range changed {
+		lines, ok := coverage[file]
+		if !ok {
+			continue
+		}
+		f := PatchFile{File: file, Changed: append([]LineRange(nil), ranges...), Lines: lines}
+		for n, hits := range lines {
+			if !inRanges(n, ranges) {
+				continue
+			}
+			f.Total++
+			if hits > 0 {
+				f.Covered++
+			}
+		}
+		if f.Total == 0 {
+			continue
+		}
+		f.Percent = Round1(float64(f.Covered) / float64(f.Total) * 100)
+		sort.Slice(f.Changed, func(i, j int) bool { return f.Changed[i].Start < f.Changed[j].Start })
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Percent != files[j].Percent {
+			return files[i].Percent < files[j].Percent
+		}
+		return files[i].File < files[j].File
+	})
+	return files
+}
+
+func inRanges(line int, ranges []LineRange) bool {
+	for _, r := range ranges {
+		if r.Contains(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeLineCoverage folds src into dst, keeping the highest hit count for
+// each line. Lines reported by either side stay instrumented.
+func MergeLineCoverage(dst, src map[string]LineCoverage) {
+	for file, lines := range src {
+		merged := dst[file]
+		if merged == nil {
+			merged = make(LineCoverage, len(lines))
+			dst[file] = merged
+		}
+		for n, hits := range lines {
+			if prev, ok := merged[n]; !ok || hits > prev {
+				merged[n] = hits
+			}
+		}
+	}
+}
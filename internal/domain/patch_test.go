@@ -0,0 +1,108 @@
+package domain
+
+import "testing"
+
+func TestEvaluatePatch(t *testing.T) {
+	coverage := map[string]LineCoverage{
+		"a.go": {10: 1, 11: 0, 12: 3, 20: 0},
+		"b.go": {5: 0},
+	}
+
+	tests := []struct {
+		name          string
+		changed       map[string][]LineRange
+		required      float64
+		wantPercent   float64
+		wantStatus    Status
+		wantUncovered []string
+	}{
+		{
+			name:          "only changed executable lines count",
+			changed:       map[string][]LineRange{"a.go": {{Start: 9, End: 12}}},
+			required:      80,
+			wantPercent:   66.7,
+			wantStatus:    StatusFail,
+			wantUncovered: []string{"a.go:11"},
+		},
+		{
+			name:          "multiple files sorted",
+			changed:       map[string][]LineRange{"b.go": {{Start: 1, End: 10}}, "a.go": {{Start: 12, End: 12}, {Start: 20, End: 20}}},
+			required:      30,
+			wantPercent:   33.3,
+			wantStatus:    StatusPass,
+			wantUncovered: []string{"a.go:20", "b.go:5"},
+		},
+		{
+			name:        "no executable lines passes",
+			changed:     map[string][]LineRange{"a.go": {{Start: 1, End: 5}}, "docs.md": {{Start: 1, End: 3}}},
+			required:    90,
+			wantPercent: 100,
+			wantStatus:  StatusPass,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluatePatch(tt.changed, coverage, tt.required, RoundingRound)
+			if got.Percent != tt.wantPercent || got.Status != tt.wantStatus {
+				t.Fatalf("expected %.1f%% %s, got %.1f%% %s", tt.wantPercent, tt.wantStatus, got.Percent, got.Status)
+			}
+			if len(got.Uncovered) != len(tt.wantUncovered) {
+				t.Fatalf("expected uncovered %v, got %v", tt.wantUncovered, got.Uncovered)
+			}
+			for i, u := range got.Uncovered {
+				if u.String() != tt.wantUncovered[i] {
+					t.Fatalf("expected uncovered %v, got %v", tt.wantUncovered, got.Uncovered)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchResultApplyBudget(t *testing.T) {
+	coverage := map[string]LineCoverage{"a.go": {10: 1, 11: 0, 12: 0}}
+	changed := map[string][]LineRange{"a.go": {{Start: 10, End: 12}}}
+
+	within := EvaluatePatch(changed, coverage, 0, RoundingRound)
+	within.ApplyBudget(2)
+	if within.Status != StatusPass || within.Requirement() != "2 uncovered, max 2" {
+		t.Fatalf("expected budget to hold, got %s %q", within.Status, within.Requirement())
+	}
+
+	over := EvaluatePatch(changed, coverage, 30, RoundingRound)
+	over.ApplyBudget(1)
+	if over.Status != StatusFail {
+		t.Fatalf("expected 2 uncovered lines to exceed a budget of 1, got %s", over.Status)
+	}
+	if got := over.Requirement(); got != "required 30.0%, 2 uncovered, max 1" {
+		t.Fatalf("unexpected requirement %q", got)
+	}
+}
+
+func TestPatchFiles(t *testing.T) {
+	coverage := map[string]LineCoverage{
+		"a.go": {10: 1, 11: 0, 12: 3, 20: 0},
+		"b.go": {5: 0},
+		"c.go": {1: 1},
+	}
+	changed := map[string][]LineRange{
+		"a.go":    {{Start: 20, End: 20}, {Start: 9, End: 12}},
+		"b.go":    {{Start: 1, End: 10}},
+		"c.go":    {{Start: 5, End: 8}},
+		"docs.md": {{Start: 1, End: 3}},
+	}
+
+	files := PatchFiles(changed, coverage)
+	if len(files) != 2 {
+		t.Fatalf("expected two files with changed executable lines, got %+v", files)
+	}
+	if files[0].File != "b.go" || files[0].Percent != 0 || files[0].Total != 1 {
+		t.Errorf("unexpected first file %+v", files[0])
+	}
+	a := files[1]
+	if a.File != "a.go" || a.Covered != 2 || a.Total != 4 || a.Percent != 50 {
+		t.Errorf("unexpected a.go %+v", a)
+	}
+	if a.Changed[0].Start != 9 || a.Changed[1].Start != 20 {
+		t.Errorf("expected sorted ranges, got %+v", a.Changed)
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"path"
+	"strings"
+)
+
+// CanonicalPath puts a file path read from a coverage report into the form
+// coverctl compares paths in, whatever OS produced the report: forward
+// slashes, no trailing carriage return or surrounding whitespace, no "./"
+// or duplicate separators, and an upper-case drive letter. A report written
+// on Windows ("C:\src\app\core.go", often with CRLF line endings) then
+// matches the same domains as one written on Linux.
+func CanonicalPath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+	p = strings.ReplaceAll(p, `\`, "/")
+	if hasDriveLetter(p) {
+		p = strings.ToUpper(p[:1]) + p[1:]
+	}
+	unc := strings.HasPrefix(p, "//")
+	p = path.Clean(p)
+	if unc {
+		// path.Clean collapses the leading "//" of a UNC share.
+		p = "/" + p
+	}
+	return p
+}
+
+// IsWindowsAbs reports whether a canonical path is an absolute Windows path:
+// a drive path ("C:/src") or a UNC share ("//server/share").
+func IsWindowsAbs(p string) bool {
+	if strings.HasPrefix(p, "//") {
+		return true
+	}
+	return hasDriveLetter(p) && len(p) > 2 && p[2] == '/'
+}
+
+func hasDriveLetter(p string) bool {
+	if len(p) < 2 || p[1] != ':' {
+		return false
+	}
+	c := p[0]
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
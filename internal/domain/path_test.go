@@ -0,0 +1,41 @@
+package domain
+
+import "testing"
+
+func TestCanonicalPath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`internal/core/a.go`, "internal/core/a.go"},
+		{`internal\core\a.go`, "internal/core/a.go"},
+		{"internal/core/a.go\r", "internal/core/a.go"},
+		{`.\src\app.js`, "src/app.js"},
+		{`c:\work\proj\src\app.js`, "C:/work/proj/src/app.js"},
+		{`C:/work//proj/./src/app.js`, "C:/work/proj/src/app.js"},
+		{`\\build\share\src\app.js`, "//build/share/src/app.js"},
+		{"/repo/internal/core/a.go", "/repo/internal/core/a.go"},
+		{"github.com/acme/app/internal/core/a.go", "github.com/acme/app/internal/core/a.go"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := CanonicalPath(tt.in); got != tt.want {
+			t.Errorf("CanonicalPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsWindowsAbs(t *testing.T) {
+	tests := map[string]bool{
+		"C:/work/app.js":     true,
+		"//server/share/a":   true,
+		"C:relative.go":      false,
+		"/repo/a.go":         false,
+		"internal/core/a.go": false,
+	}
+	for in, want := range tests {
+		if got := IsWindowsAbs(in); got != want {
+			t.Errorf("IsWindowsAbs(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -38,6 +38,21 @@ type Domain struct {
 	Min     *float64
 	Warn    *float64 // Optional warn threshold (must be >= Min)
 	Exclude []string // Optional patterns to exclude from this domain
+	Group   string   // Optional group the domain is subtotalled under
+	Owners  []string // Optional owners (emails, chat handles, teams) accountable for the domain
+	Weight  *float64 // Optional weight of the domain's statements in overall coverage; unset counts as 1
+	Metric  Metric   // Optional metric the domain is measured by; empty uses the policy's
+	// TestArgs are appended to the runner's test command when this domain's
+	// coverage is generated; TestCommand replaces the command entirely.
+	// Either one gives the domain a test run of its own.
+	TestArgs    []string
+	TestCommand []string
+}
+
+// HasTestOverride reports whether the domain's coverage comes from its own
+// test run rather than the shared one.
+func (d Domain) HasTestOverride() bool {
+	return len(d.TestArgs) > 0 || len(d.TestCommand) > 0
 }
 
 // MinThreshold returns the minimum coverage threshold for this domain,
@@ -58,6 +73,10 @@ func (d Domain) HasWarnThreshold() bool {
 type Policy struct {
 	DefaultMin float64
 	Domains    []Domain
+	NewDomain  NewDomainPolicy // Threshold handling for domains without history
+	Groups     []GroupPolicy   // Optional minimums for domain groups
+	Metric     Metric          // Metric domains are measured by; empty means statements
+	Rounding   Rounding        // How percentages are compared with thresholds; empty means round
 }
 
 type Status string
@@ -75,7 +94,23 @@ type DomainResult struct {
 	Percent  float64  `json:"percent"`
 	Required float64  `json:"required"`
 	Status   Status   `json:"status"`
-	Delta    *float64 `json:"delta,omitempty"` // Change from previous run
+	Delta    *float64 `json:"delta,omitempty"`  // Change from previous run
+	Owners   []string `json:"owners,omitempty"` // From the domain's owners in the policy
+	Weight   *float64 `json:"weight,omitempty"` // From the domain's weight in the policy
+	Metric   Metric   `json:"metric,omitempty"` // Set when the domain is measured by lines or branches
+	// Sources splits the coverage by the profile it came from (unit,
+	// integration, merged files). Set by ApplySources when more than one
+	// profile was merged; thresholds apply to Percent, the combined value.
+	Sources []SourceCoverage `json:"sources,omitempty"`
+}
+
+// DomainDelta is a domain's change from the latest history entry.
+type DomainDelta struct {
+	Domain   string         `json:"domain"`
+	Previous float64        `json:"previous"`
+	Current  float64        `json:"current"`
+	Delta    float64        `json:"delta"`
+	Trend    TrendDirection `json:"trend"`
 }
 
 // IsPassing returns true if this domain meets its coverage requirement.
@@ -102,6 +137,27 @@ func (d DomainResult) Shortfall() float64 {
 	return Round1(d.Required - d.Percent)
 }
 
+// ToCover returns how many more statements (or lines or branches, per the
+// domain's metric) must be covered for the domain to meet its requirement
+// under rounding, as Evaluate compares them. Returns 0 if the domain is
+// passing; a requirement the domain cannot reach costs every uncovered one.
+func (d DomainResult) ToCover(rounding Rounding) int {
+	uncovered := d.Total - d.Covered
+	if d.Total <= 0 || rounding.Meets(d.Stat().Percent(), d.Required) {
+		return 0
+	}
+	// Start just below the exact answer; rounding can only lower it.
+	n := int(math.Floor((d.Required-0.05)*float64(d.Total)/100)) - d.Covered - 1
+	n = max(n, 0)
+	for ; n < uncovered; n++ {
+		stat := CoverageStat{Covered: d.Covered + n, Total: d.Total}
+		if rounding.Meets(stat.Percent(), d.Required) {
+			return n
+		}
+	}
+	return uncovered
+}
+
 // Stat returns the coverage statistics for this domain result.
 func (d DomainResult) Stat() CoverageStat {
 	return CoverageStat{Covered: d.Covered, Total: d.Total}
@@ -147,22 +203,109 @@ func (f FileResult) Stat() CoverageStat {
 
 type Result struct {
 	Domains  []DomainResult `json:"domains"`
+	Groups   []GroupResult  `json:"groups,omitempty"`
 	Files    []FileResult   `json:"files,omitempty"`
+	Patch    *PatchResult   `json:"patch,omitempty"`
 	Passed   bool           `json:"passed"`
 	Warnings []string       `json:"warnings,omitempty"`
+
+	// NewCode is coverage of lines last changed within
+	// policy.new_code_since; nil when no age window is configured.
+	NewCode *NewCodeResult `json:"new_code,omitempty"`
+
+	// Functions is the share of functions any test reached; nil when
+	// functions.min is not configured.
+	Functions *FunctionsResult `json:"functions,omitempty"`
+
+	// Deltas compares each domain with the latest history entry. It is set
+	// by ApplyDeltas and empty when no history was consulted.
+	Deltas []DomainDelta `json:"deltas,omitempty"`
+
+	// EmptyDomains names domains whose match patterns resolved to
+	// directories but received no coverage data.
+	EmptyDomains []string `json:"empty_domains,omitempty"`
+
+	// NewDomains names domains that history has never recorded. It is set
+	// by ApplyNewDomainPolicy when policy.new_domain is warn or current.
+	NewDomains []string `json:"new_domains,omitempty"`
+
+	// TopFiles lists the files with the most uncovered statements in
+	// failing domains, when a report asked for them.
+	TopFiles []UncoveredFile `json:"top_files,omitempty"`
+
+	// Timing splits the last recorded test time across domains, when a
+	// report asked for it.
+	Timing []DomainTiming `json:"timing,omitempty"`
+
+	// Exceptions lists every configured policy exception and whether it
+	// was applied or has expired. It is set by ApplyExceptions.
+	Exceptions []ExceptionResult `json:"exceptions,omitempty"`
+
+	// Health is the run's one-line KPI. It is set by check.
+	Health *Health `json:"health,omitempty"`
+
+	// Lines holds per-line hits keyed by SourceRoot-relative path. It is
+	// only populated for output formats that embed line data.
+	Lines      map[string]LineCoverage `json:"-"`
+	SourceRoot string                  `json:"-"`
+
+	// Rounding is the policy.rounding the result was evaluated under. It
+	// decides how the overall percentage meets --fail-under and the ratchet.
+	Rounding Rounding `json:"-"`
 }
 
-// OverallPercent calculates the overall coverage percentage across all domains.
+// OverallPercent calculates the overall coverage percentage across all
+// domains, counting each domain's statements by its weight, as results
+// report it under the result's rounding.
 func (r Result) OverallPercent() float64 {
-	var totalCovered, totalStatements int
+	return r.Rounding.Percent(r.overallRaw())
+}
+
+// MeetsOverall reports whether the unrounded overall percentage meets
+// threshold under the result's rounding.
+func (r Result) MeetsOverall(threshold float64) bool {
+	return r.Rounding.Meets(r.overallRaw(), threshold)
+}
+
+func (r Result) overallRaw() float64 {
+	var covered, total float64
 	for _, d := range r.Domains {
-		totalCovered += d.Covered
-		totalStatements += d.Total
+		w := weightOf(d.Weight)
+		covered += w * float64(d.Covered)
+		total += w * float64(d.Total)
+	}
+	if total == 0 {
+		return 0
+	}
+	return covered / total * 100
+}
+
+// WeightedOverall is the overall coverage percentage of per-domain
+// coverage, counting each domain's statements by its weight in domains.
+// Domains without a weight, or missing from domains, count once, so with
+// no weights set this is plain statement coverage.
+func WeightedOverall(coverage map[string]CoverageStat, domains []Domain) float64 {
+	weights := make(map[string]*float64, len(domains))
+	for _, d := range domains {
+		weights[d.Name] = d.Weight
+	}
+	var covered, total float64
+	for name, stat := range coverage {
+		w := weightOf(weights[name])
+		covered += w * float64(stat.Covered)
+		total += w * float64(stat.Total)
 	}
-	if totalStatements == 0 {
+	if total == 0 {
 		return 0
 	}
-	return Round1(float64(totalCovered) / float64(totalStatements) * 100)
+	return Round1(covered / total * 100)
+}
+
+func weightOf(weight *float64) float64 {
+	if weight == nil {
+		return 1
+	}
+	return *weight
 }
 
 // PassingDomainCount returns the number of domains that are passing.
@@ -232,11 +375,19 @@ func (r *Result) ApplyDeltas(history History) {
 		return
 	}
 
+	r.Deltas = nil
 	for i := range r.Domains {
 		domainName := r.Domains[i].Domain
 		if prevEntry, ok := latest.Domains[domainName]; ok {
 			delta := Round1(r.Domains[i].Percent - prevEntry.Percent)
 			r.Domains[i].Delta = &delta
+			r.Deltas = append(r.Deltas, DomainDelta{
+				Domain:   domainName,
+				Previous: prevEntry.Percent,
+				Current:  r.Domains[i].Percent,
+				Delta:    delta,
+				Trend:    CalculateTrend(prevEntry.Percent, r.Domains[i].Percent).Direction,
+			})
 		}
 	}
 }
@@ -312,12 +463,13 @@ func Evaluate(policy Policy, coverage map[string]CoverageStat) Result {
 		if d.Min != nil {
 			required = *d.Min
 		}
-		percent := Round1(stat.Percent())
+		raw := stat.Percent()
+		percent := policy.Rounding.Percent(raw)
 		status := StatusPass
-		if percent < required {
+		if !policy.Rounding.Meets(raw, required) {
 			status = StatusFail
 			passed = false
-		} else if d.Warn != nil && percent < *d.Warn {
+		} else if d.Warn != nil && !policy.Rounding.Meets(raw, *d.Warn) {
 			// Above min but below warn threshold
 			status = StatusWarn
 		}
@@ -328,10 +480,19 @@ func Evaluate(policy Policy, coverage map[string]CoverageStat) Result {
 			Percent:  percent,
 			Required: required,
 			Status:   status,
+			Owners:   d.Owners,
+			Weight:   d.Weight,
+			Metric:   policy.MetricFor(d).reported(),
 		})
 	}
 
-	return Result{Domains: results, Passed: passed}
+	groups := EvaluateGroups(policy, results)
+	for _, g := range groups {
+		if g.Status == StatusFail {
+			passed = false
+		}
+	}
+	return Result{Domains: results, Groups: groups, Passed: passed, Rounding: policy.Rounding}
 }
 
 // Round1 rounds a float64 to one decimal place.
@@ -7,7 +7,7 @@ func TestEvaluatePolicy(t *testing.T) {
 	policy := Policy{
 		DefaultMin: 80,
 		Domains: []Domain{
-			{Name: "core", Min: &min},
+			{Name: "core", Min: &min, Owners: []string{"@core-team"}},
 			{Name: "api"},
 		},
 	}
@@ -23,6 +23,9 @@ func TestEvaluatePolicy(t *testing.T) {
 	if got := result.Domains[0].Status; got != StatusFail {
 		t.Fatalf("expected core to fail, got %s", got)
 	}
+	if got := result.Domains[0].Owners; len(got) != 1 || got[0] != "@core-team" {
+		t.Fatalf("expected core owners in result, got %v", got)
+	}
 	if got := result.Domains[1].Status; got != StatusPass {
 		t.Fatalf("expected api to pass, got %s", got)
 	}
@@ -228,6 +231,26 @@ func TestDomainResultBehavior(t *testing.T) {
 		}
 	})
 
+	t.Run("ToCover", func(t *testing.T) {
+		cases := []struct {
+			result DomainResult
+			want   int
+		}{
+			{DomainResult{Covered: 70, Total: 100, Percent: 70, Required: 80}, 10},
+			{DomainResult{Covered: 181, Total: 250, Percent: 72.4, Required: 80}, 19},
+			{DomainResult{Covered: 7, Total: 9, Percent: 77.8, Required: 80}, 1},
+			// 7995/10000 rounds to 80.0%, so 4995 more pass rather than 5000.
+			{DomainResult{Covered: 3000, Total: 10000, Percent: 30, Required: 80}, 4995},
+			{DomainResult{Covered: 5, Total: 10, Percent: 50, Required: 101}, 5},
+			{DomainResult{Covered: 85, Total: 100, Percent: 85, Required: 80}, 0},
+		}
+		for _, tc := range cases {
+			if got := tc.result.ToCover(RoundingRound); got != tc.want {
+				t.Errorf("ToCover() for %d/%d at %.1f%% = %d, want %d", tc.result.Covered, tc.result.Total, tc.result.Required, got, tc.want)
+			}
+		}
+	})
+
 	t.Run("Stat", func(t *testing.T) {
 		dr := DomainResult{Covered: 80, Total: 100}
 		stat := dr.Stat()
@@ -296,6 +319,31 @@ func TestResultBehavior(t *testing.T) {
 	})
 }
 
+func TestWeightedOverall(t *testing.T) {
+	three, zero := 3.0, 0.0
+	domains := []Domain{{Name: "core", Weight: &three}, {Name: "tools", Weight: &zero}, {Name: "api"}}
+	coverage := map[string]CoverageStat{
+		"core":  {Covered: 90, Total: 100},
+		"tools": {Covered: 0, Total: 500},
+		"api":   {Covered: 50, Total: 100},
+	}
+	// (3*90 + 50) / (3*100 + 100) = 320/400 = 80%; tools counts for nothing.
+	if got := WeightedOverall(coverage, domains); got != 80 {
+		t.Fatalf("WeightedOverall() = %v, want 80", got)
+	}
+	if got := WeightedOverall(coverage, nil); got != 20 {
+		t.Fatalf("WeightedOverall() without weights = %v, want 20", got)
+	}
+
+	result := Evaluate(Policy{DefaultMin: 50, Domains: domains}, coverage)
+	if result.Domains[0].Weight == nil || *result.Domains[0].Weight != 3 {
+		t.Fatalf("expected the weight on the domain result, got %+v", result.Domains[0])
+	}
+	if got := result.OverallPercent(); got != 80 {
+		t.Fatalf("OverallPercent() = %v, want 80", got)
+	}
+}
+
 func TestRound1(t *testing.T) {
 	tests := []struct {
 		input float64
@@ -0,0 +1,48 @@
+package domain
+
+import "math"
+
+// Rounding decides how a coverage percentage is compared with a threshold
+// (policy.rounding).
+type Rounding string
+
+const (
+	// RoundingRound rounds to one decimal first, so 79.96% meets an 80%
+	// minimum. Empty means round.
+	RoundingRound Rounding = "round"
+	// RoundingFloor truncates to one decimal first, so 79.96% is 79.9%.
+	RoundingFloor Rounding = "floor"
+	// RoundingExact compares the unrounded percentage.
+	RoundingExact Rounding = "exact"
+)
+
+// roundingEpsilon absorbs float error in percentages that are exact in
+// decimal, such as 29/100*100 = 28.999999999999996.
+const roundingEpsilon = 1e-9
+
+// Valid reports whether r is a known rounding; empty means RoundingRound.
+func (r Rounding) Valid() bool {
+	switch r {
+	case "", RoundingRound, RoundingFloor, RoundingExact:
+		return true
+	}
+	return false
+}
+
+// Percent returns the unrounded percentage p as results report it: rounded
+// to one decimal, or truncated under floor and exact, so a percentage that
+// fails its threshold never displays as meeting it.
+func (r Rounding) Percent(p float64) float64 {
+	if r == RoundingFloor || r == RoundingExact {
+		return math.Floor(p*10+roundingEpsilon) / 10
+	}
+	return Round1(p)
+}
+
+// Meets reports whether the unrounded percentage p meets threshold.
+func (r Rounding) Meets(p, threshold float64) bool {
+	if r == RoundingExact {
+		return p+roundingEpsilon >= threshold
+	}
+	return r.Percent(p) >= threshold
+}
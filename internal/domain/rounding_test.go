@@ -0,0 +1,59 @@
+package domain
+
+import "testing"
+
+func TestRounding(t *testing.T) {
+	cases := []struct {
+		rounding  Rounding
+		percent   float64
+		threshold float64
+		shown     float64
+		meets     bool
+	}{
+		{"", 79.96, 80, 80, true},
+		{RoundingRound, 79.96, 80, 80, true},
+		{RoundingRound, 79.94, 80, 79.9, false},
+		{RoundingFloor, 79.96, 80, 79.9, false},
+		{RoundingFloor, 80, 80, 80, true},
+		{RoundingExact, 79.96, 80, 79.9, false},
+		{RoundingExact, 79.96, 79.95, 79.9, true},
+		{RoundingFloor, 79.96, 79.95, 79.9, false},
+		// 29/100*100 is 28.999999999999996 in floating point.
+		{RoundingExact, float64(29) / 100 * 100, 29, 29, true},
+		{RoundingFloor, float64(29) / 100 * 100, 29, 29, true},
+	}
+	for _, tc := range cases {
+		if got := tc.rounding.Percent(tc.percent); got != tc.shown {
+			t.Errorf("%q.Percent(%v) = %v, want %v", tc.rounding, tc.percent, got, tc.shown)
+		}
+		if got := tc.rounding.Meets(tc.percent, tc.threshold); got != tc.meets {
+			t.Errorf("%q.Meets(%v, %v) = %v, want %v", tc.rounding, tc.percent, tc.threshold, got, tc.meets)
+		}
+	}
+	if Rounding("ceil").Valid() || !RoundingExact.Valid() || !Rounding("").Valid() {
+		t.Error("unexpected Valid results")
+	}
+}
+
+func TestEvaluateRounding(t *testing.T) {
+	coverage := map[string]CoverageStat{"core": {Covered: 7996, Total: 10000}}
+	for _, tc := range []struct {
+		rounding Rounding
+		status   Status
+		percent  float64
+		toCover  int
+	}{
+		{RoundingRound, StatusPass, 80, 0},
+		{RoundingFloor, StatusFail, 79.9, 4},
+		{RoundingExact, StatusFail, 79.9, 4},
+	} {
+		result := Evaluate(Policy{DefaultMin: 80, Rounding: tc.rounding, Domains: []Domain{{Name: "core"}}}, coverage)
+		got := result.Domains[0]
+		if got.Status != tc.status || got.Percent != tc.percent {
+			t.Errorf("%s: got %s at %v%%, want %s at %v%%", tc.rounding, got.Status, got.Percent, tc.status, tc.percent)
+		}
+		if n := got.ToCover(tc.rounding); n != tc.toCover {
+			t.Errorf("%s: ToCover() = %d, want %d", tc.rounding, n, tc.toCover)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TestRef names one top-level test function.
+type TestRef struct {
+	Package string `json:"package"` // go test package pattern, e.g. "./internal/core"
+	Name    string `json:"name"`
+}
+
+// TestSelection is the part of a test suite worth running first for a
+// change: named tests known to cover the changed code, and packages to run
+// in full where no such knowledge exists.
+type TestSelection struct {
+	Changed []string  `json:"changed"` // Changed Go files
+	Tests   []TestRef `json:"tests,omitempty"`
+	// Run is a go test -run regex matching Tests; TestPackages are their
+	// packages, minus those already listed in Packages.
+	Run          string   `json:"run,omitempty"`
+	TestPackages []string `json:"testPackages,omitempty"`
+	// Packages run in full: every changed package without attribution, and
+	// otherwise those with changed tests or changed files no test covers.
+	Packages []string `json:"packages,omitempty"`
+	Unmapped []string `json:"unmapped,omitempty"` // Changed files no recorded test covers
+}
+
+// Empty reports whether the change selects no tests at all.
+func (s TestSelection) Empty() bool {
+	return len(s.Tests) == 0 && len(s.Packages) == 0
+}
+
+// GoPackage returns the go test package pattern for a slash-separated
+// directory relative to the module root.
+func GoPackage(dir string) string {
+	dir = path.Clean(dir)
+	if dir == "." || dir == "" {
+		return "."
+	}
+	return "./" + dir
+}
+
+// SelectTests maps changed files to the tests to run. coveredBy maps
+// module-relative files to the tests whose own profiles cover them; when it
+// is nil every changed package runs in full. Changed test files always run
+// their package in full, since their new tests have no attribution yet.
+// Files other than Go source are ignored.
+func SelectTests(changed []string, coveredBy map[string][]TestRef) TestSelection {
+	var sel TestSelection
+	full := make(map[string]bool)
+	tests := make(map[TestRef]bool)
+	for _, file := range changed {
+		file = path.Clean(file)
+		if path.Ext(file) != ".go" {
+			continue
+		}
+		sel.Changed = append(sel.Changed, file)
+		pkg := GoPackage(path.Dir(file))
+		switch {
+		case coveredBy == nil || strings.HasSuffix(file, "_test.go"):
+			full[pkg] = true
+		case len(coveredBy[file]) == 0:
+			full[pkg] = true
+			sel.Unmapped = append(sel.Unmapped, file)
+		default:
+			for _, t := range coveredBy[file] {
+				tests[t] = true
+			}
+		}
+	}
+	sort.Strings(sel.Changed)
+	sort.Strings(sel.Unmapped)
+	sel.Packages = sortedKeys(full)
+
+	names := make(map[string]bool)
+	pkgs := make(map[string]bool)
+	for t := range tests {
+		sel.Tests = append(sel.Tests, t)
+		names[regexp.QuoteMeta(t.Name)] = true
+		if !full[t.Package] {
+			pkgs[t.Package] = true
+		}
+	}
+	sort.Slice(sel.Tests, func(i, j int) bool {
+		if sel.Tests[i].Package != sel.Tests[j].Package {
+			return sel.Tests[i].Package < sel.Tests[j].Package
+		}
+		return sel.Tests[i].Name < sel.Tests[j].Name
+	})
+	sel.TestPackages = sortedKeys(pkgs)
+	if len(sel.TestPackages) > 0 {
+		sel.Run = "^(" + strings.Join(sortedKeys(names), "|") + ")$"
+	}
+	return sel
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectTestsByPackage(t *testing.T) {
+	sel := SelectTests([]string{"internal/core/a.go", "internal/core/b.go", "main.go", "README.md"}, nil)
+	if !reflect.DeepEqual(sel.Packages, []string{".", "./internal/core"}) {
+		t.Fatalf("unexpected packages %v", sel.Packages)
+	}
+	if len(sel.Changed) != 3 || sel.Run != "" || len(sel.Tests) != 0 || sel.Empty() {
+		t.Fatalf("unexpected selection %+v", sel)
+	}
+}
+
+func TestSelectTestsByAttribution(t *testing.T) {
+	coveredBy := map[string][]TestRef{
+		"internal/core/a.go": {{Package: "./internal/core", Name: "TestA"}, {Package: "./internal/api", Name: "TestHandle"}},
+		"internal/api/h.go":  {{Package: "./internal/api", Name: "TestHandle"}},
+		"internal/cli/c.go":  {{Package: "./internal/cli", Name: "TestRun"}},
+	}
+	sel := SelectTests([]string{
+		"internal/core/a.go",
+		"internal/api/h.go",
+		"internal/cli/c.go",
+		"internal/cli/c_test.go",
+		"internal/new/n.go",
+	}, coveredBy)
+
+	wantTests := []TestRef{
+		{Package: "./internal/api", Name: "TestHandle"},
+		{Package: "./internal/cli", Name: "TestRun"},
+		{Package: "./internal/core", Name: "TestA"},
+	}
+	if !reflect.DeepEqual(sel.Tests, wantTests) {
+		t.Fatalf("unexpected tests %+v", sel.Tests)
+	}
+	if sel.Run != "^(TestA|TestHandle|TestRun)$" {
+		t.Fatalf("unexpected run regex %q", sel.Run)
+	}
+	if !reflect.DeepEqual(sel.TestPackages, []string{"./internal/api", "./internal/core"}) {
+		t.Fatalf("unexpected test packages %v", sel.TestPackages)
+	}
+	if !reflect.DeepEqual(sel.Packages, []string{"./internal/cli", "./internal/new"}) {
+		t.Fatalf("unexpected packages %v", sel.Packages)
+	}
+	if !reflect.DeepEqual(sel.Unmapped, []string{"internal/new/n.go"}) {
+		t.Fatalf("unexpected unmapped %v", sel.Unmapped)
+	}
+}
+
+func TestSelectTestsNothingChanged(t *testing.T) {
+	sel := SelectTests([]string{"docs/index.md"}, map[string][]TestRef{})
+	if !sel.Empty() || sel.Changed != nil {
+		t.Fatalf("expected empty selection, got %+v", sel)
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Signature proves a history entry was recorded by the holder of a key
+// and has not changed since.
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"keyId"` // Identifies the public key that verifies Value
+	Value     string `json:"value"` // Base64 signature over the entry's SigningPayload
+}
+
+// ProfileDigest is the SHA-256 of a coverage profile an entry was
+// computed from.
+type ProfileDigest struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// SigningPayload returns the bytes a signature covers: every field of the
+// entry except the signature itself. It is written independently of the
+// history file's encoding, so re-saving history or a change to how file
+// counts are compressed never invalidates a signature.
+func (e HistoryEntry) SigningPayload() ([]byte, error) {
+	files := make(map[string][2]int, len(e.Files))
+	for file, stat := range e.Files {
+		files[file] = [2]int{stat.Covered, stat.Total}
+	}
+	return json.Marshal(struct {
+		Version    int                    `json:"v"`
+		Timestamp  string                 `json:"timestamp"`
+		Commit     string                 `json:"commit"`
+		Branch     string                 `json:"branch"`
+		Tag        string                 `json:"tag"`
+		PR         int                    `json:"pr"`
+		RunID      string                 `json:"runId"`
+		Overall    float64                `json:"overall"`
+		Statements int                    `json:"statements"`
+		Domains    map[string]DomainEntry `json:"domains"`
+		Files      map[string][2]int      `json:"files"`
+		Profiles   []ProfileDigest        `json:"profiles"`
+	}{
+		Version:    1,
+		Timestamp:  e.Timestamp.UTC().Format(time.RFC3339Nano),
+		Commit:     e.Commit,
+		Branch:     e.Branch,
+		Tag:        e.Tag,
+		PR:         e.PR,
+		RunID:      e.RunID,
+		Overall:    e.Overall,
+		Statements: e.Statements,
+		Domains:    e.Domains,
+		Files:      files,
+		Profiles:   e.Profiles,
+	})
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSigningPayloadSurvivesRoundTrip(t *testing.T) {
+	entry := HistoryEntry{
+		Timestamp: time.Date(2026, 5, 1, 12, 30, 0, 123, time.FixedZone("CEST", 2*3600)),
+		Commit:    "abc123",
+		Overall:   81.3,
+		Domains:   map[string]DomainEntry{"core": {Name: "core", Percent: 81.3, Min: 80, Status: StatusPass}},
+		Files:     FileHistory{"core/a.go": {Covered: 3, Total: 4}},
+		Profiles:  []ProfileDigest{{Path: ".cover/coverage.out", SHA256: "00ff"}},
+	}
+	before, err := entry.SigningPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry.Signature = &Signature{Algorithm: "ed25519", KeyID: "k", Value: "sig"}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded HistoryEntry
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	after, err := loaded.SigningPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Fatalf("payload changed after a round trip:\n%s\n%s", before, after)
+	}
+
+	loaded.Overall = 90
+	if changed, _ := loaded.SigningPayload(); bytes.Equal(before, changed) {
+		t.Fatal("expected the payload to cover overall coverage")
+	}
+}
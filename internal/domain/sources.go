@@ -0,0 +1,39 @@
+package domain
+
+// SourceCoverage is one profile source's share of a domain's coverage. Both
+// percentages are taken over the domain's combined statement count, so the
+// first source's Percent plus every later source's Added is the combined
+// coverage.
+type SourceCoverage struct {
+	Source string `json:"source"`
+	// Percent is the coverage this source reaches on its own.
+	Percent float64 `json:"percent"`
+	// Added is how many points this source adds over the sources before it.
+	Added float64 `json:"added"`
+}
+
+// ApplySources sets each domain's Sources. alone[i] holds the per-domain
+// stats of source i by itself and cumulative[i] those of sources 0..i merged;
+// both are indexed like labels.
+func (r *Result) ApplySources(labels []string, alone, cumulative []map[string]CoverageStat) {
+	for i := range r.Domains {
+		d := &r.Domains[i]
+		d.Sources = nil
+		if d.Total == 0 {
+			continue
+		}
+		share := func(stat CoverageStat) float64 {
+			return float64(stat.Covered) / float64(d.Total) * 100
+		}
+		previous := 0.0
+		for j, label := range labels {
+			current := share(cumulative[j][d.Domain])
+			d.Sources = append(d.Sources, SourceCoverage{
+				Source:  label,
+				Percent: Round1(share(alone[j][d.Domain])),
+				Added:   Round1(current - previous),
+			})
+			previous = current
+		}
+	}
+}
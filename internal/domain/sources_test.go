@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplySources(t *testing.T) {
+	r := Result{Domains: []DomainResult{
+		{Domain: "core", Covered: 81, Total: 100, Percent: 81},
+		{Domain: "empty"},
+	}}
+	alone := []map[string]CoverageStat{
+		{"core": {Covered: 72, Total: 90}},
+		{"core": {Covered: 40, Total: 100}},
+	}
+	cumulative := []map[string]CoverageStat{
+		alone[0],
+		{"core": {Covered: 81, Total: 100}},
+	}
+
+	r.ApplySources([]string{"unit", "integration"}, alone, cumulative)
+
+	want := []SourceCoverage{
+		{Source: "unit", Percent: 72, Added: 72},
+		{Source: "integration", Percent: 40, Added: 9},
+	}
+	if !reflect.DeepEqual(r.Domains[0].Sources, want) {
+		t.Fatalf("sources = %+v, want %+v", r.Domains[0].Sources, want)
+	}
+	if r.Domains[1].Sources != nil {
+		t.Fatalf("expected no sources for a domain without statements")
+	}
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TestTimings records how long the last test run took. Packages holds
+// per-package seconds reported by the test tool; Domains holds wall-clock
+// seconds for domains that ran their own tests (test_command or test_args).
+type TestTimings struct {
+	RecordedAt time.Time          `json:"recorded_at"`
+	Total      float64            `json:"total_seconds"`
+	Packages   map[string]float64 `json:"packages,omitempty"`
+	Domains    map[string]float64 `json:"domains,omitempty"`
+}
+
+// Empty reports whether nothing was timed.
+func (t TestTimings) Empty() bool {
+	return t.Total == 0 && len(t.Packages) == 0 && len(t.Domains) == 0
+}
+
+// DomainTiming is one domain's share of test time and what it buys.
+type DomainTiming struct {
+	Domain  string  `json:"domain"`
+	Seconds float64 `json:"seconds"`
+	Percent float64 `json:"percent"`
+	// SecondsPerPercent is Seconds divided by Percent: the test time spent
+	// per point of coverage. Zero when the domain has no coverage.
+	SecondsPerPercent float64 `json:"seconds_per_percent"`
+}
+
+// BuildDomainTimings pairs per-domain seconds with each domain's coverage,
+// most expensive first. Domains without recorded time are left out.
+func BuildDomainTimings(seconds map[string]float64, domains []DomainResult) []DomainTiming {
+	var out []DomainTiming
+	for _, d := range domains {
+		s, ok := seconds[d.Domain]
+		if !ok {
+			continue
+		}
+		t := DomainTiming{Domain: d.Domain, Seconds: roundTo(s, 2), Percent: d.Percent}
+		if d.Percent > 0 {
+			t.SecondsPerPercent = roundTo(s/d.Percent, 3)
+		}
+		out = append(out, t)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Seconds != out[j].Seconds {
+			return out[i].Seconds > out[j].Seconds
+		}
+		return out[i].Domain < out[j].Domain
+	})
+	return out
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
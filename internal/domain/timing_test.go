@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestBuildDomainTimings(t *testing.T) {
+	domains := []DomainResult{
+		{Domain: "api", Percent: 80},
+		{Domain: "core", Percent: 50},
+		{Domain: "cli", Percent: 0},
+		{Domain: "docs", Percent: 90},
+	}
+	got := BuildDomainTimings(map[string]float64{"api": 12, "core": 30, "cli": 4.321}, domains)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 timed domains, got %+v", got)
+	}
+	if got[0].Domain != "core" || got[1].Domain != "api" || got[2].Domain != "cli" {
+		t.Fatalf("expected most expensive first, got %+v", got)
+	}
+	if got[0].SecondsPerPercent != 0.6 {
+		t.Errorf("core s/pt = %v, want 0.6", got[0].SecondsPerPercent)
+	}
+	if got[2].Seconds != 4.32 || got[2].SecondsPerPercent != 0 {
+		t.Errorf("uncovered domain should have no per-point cost, got %+v", got[2])
+	}
+}
+
+func TestTestTimingsEmpty(t *testing.T) {
+	if !(TestTimings{}).Empty() {
+		t.Fatal("zero timings should be empty")
+	}
+	if (TestTimings{Domains: map[string]float64{"api": 1}}).Empty() {
+		t.Fatal("timings with a domain should not be empty")
+	}
+}
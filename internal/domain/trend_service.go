@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
 
 // TrendAnalysisService is a domain service that analyzes coverage trends
 // over time and raises events when significant changes occur.
@@ -135,6 +140,83 @@ func (s *TrendAnalysisService) recordTrendEvents(result TrendAnalysisResult) {
 	}
 }
 
+// Thresholds for DetectAnomalies.
+const (
+	// AnomalyDomainDrop is the percentage-point drop in one domain between
+	// consecutive entries that is flagged as suspicious.
+	AnomalyDomainDrop = 15.0
+	// AnomalyStatementShrink is the percentage of total statements that may
+	// disappear between consecutive entries before it is flagged.
+	AnomalyStatementShrink = 30.0
+)
+
+// AnomalyKind identifies what made an entry look suspicious.
+type AnomalyKind string
+
+const (
+	AnomalyCoverageDrop     AnomalyKind = "coverage_drop"
+	AnomalyStatementsShrunk AnomalyKind = "statements_shrunk"
+)
+
+// Anomaly is a change between consecutive entries too large to be an
+// ordinary regression. It usually means a broken profile or tests that
+// were skipped, not a real loss of coverage.
+type Anomaly struct {
+	Kind     AnomalyKind `json:"kind"`
+	Domain   string      `json:"domain,omitempty"` // Set for coverage drops
+	Previous float64     `json:"previous"`         // Percent, or statement count
+	Current  float64     `json:"current"`
+}
+
+// String describes the anomaly as a warning.
+func (a Anomaly) String() string {
+	if a.Kind == AnomalyStatementsShrunk {
+		return fmt.Sprintf("total statements fell from %.0f to %.0f (-%.0f%%) since the last entry; the profile may be incomplete or packages may have been skipped",
+			a.Previous, a.Current, 100*(a.Previous-a.Current)/a.Previous)
+	}
+	return fmt.Sprintf("domain %s dropped from %.1f%% to %.1f%% (%+.1f points) since the last entry; check for a broken profile or skipped tests",
+		a.Domain, a.Previous, a.Current, a.Current-a.Previous)
+}
+
+// DetectAnomalies flags domains that lost more than AnomalyDomainDrop
+// points, and total statements shrinking by more than
+// AnomalyStatementShrink percent, between two consecutive entries. The
+// statement check is skipped when either entry predates statement counts.
+func (s *TrendAnalysisService) DetectAnomalies(previous, current *HistoryEntry) []Anomaly {
+	if previous == nil || current == nil {
+		return nil
+	}
+	var anomalies []Anomaly
+	if previous.Statements > 0 && current.Statements > 0 &&
+		float64(previous.Statements-current.Statements) > float64(previous.Statements)*AnomalyStatementShrink/100 {
+		anomalies = append(anomalies, Anomaly{
+			Kind:     AnomalyStatementsShrunk,
+			Previous: float64(previous.Statements),
+			Current:  float64(current.Statements),
+		})
+	}
+
+	trends := s.AnalyzeTrend(previous, current).DomainTrends
+	names := make([]string, 0, len(trends))
+	for name := range trends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := trends[name]
+		if _, ok := previous.Domains[name]; !ok || t.Trend.Delta >= -AnomalyDomainDrop {
+			continue
+		}
+		anomalies = append(anomalies, Anomaly{
+			Kind:     AnomalyCoverageDrop,
+			Domain:   name,
+			Previous: t.Previous.Value(),
+			Current:  t.Current.Value(),
+		})
+	}
+	return anomalies
+}
+
 // AnalyzeHistory analyzes a sequence of history entries and returns trend statistics.
 func (s *TrendAnalysisService) AnalyzeHistory(history *History, since time.Time) HistoryAnalysisResult {
 	entries := history.EntriesAfter(since)
@@ -142,6 +224,45 @@ func (s *TrendAnalysisService) AnalyzeHistory(history *History, since time.Time)
 		return HistoryAnalysisResult{}
 	}
 
+	values := make([]float64, len(entries))
+	for i, entry := range entries {
+		values[i] = entry.Overall
+	}
+
+	result := summarizeSeries(values)
+	result.Period = time.Since(since)
+	return result
+}
+
+// AnalyzeDomainWindow analyzes the most recent window entries that recorded
+// the named domain. A window of zero or less uses every entry.
+func (s *TrendAnalysisService) AnalyzeDomainWindow(history *History, domainName string, window int) HistoryAnalysisResult {
+	var entries []HistoryEntry
+	for _, entry := range history.Entries {
+		if _, ok := entry.Domains[domainName]; ok {
+			entries = append(entries, entry)
+		}
+	}
+	if window > 0 && len(entries) > window {
+		entries = entries[len(entries)-window:]
+	}
+	if len(entries) == 0 {
+		return HistoryAnalysisResult{}
+	}
+
+	values := make([]float64, len(entries))
+	for i, entry := range entries {
+		values[i] = entry.Domains[domainName].Percent
+	}
+
+	result := summarizeSeries(values)
+	result.Period = entries[len(entries)-1].Timestamp.Sub(entries[0].Timestamp)
+	return result
+}
+
+// summarizeSeries computes the statistics shared by the history analyses
+// from coverage values in chronological order.
+func summarizeSeries(values []float64) HistoryAnalysisResult {
 	var (
 		highest     float64
 		lowest      float64 = 100
@@ -152,17 +273,17 @@ func (s *TrendAnalysisService) AnalyzeHistory(history *History, since time.Time)
 		prevPercent float64
 	)
 
-	for i, entry := range entries {
-		if entry.Overall > highest {
-			highest = entry.Overall
+	for i, v := range values {
+		if v > highest {
+			highest = v
 		}
-		if entry.Overall < lowest {
-			lowest = entry.Overall
+		if v < lowest {
+			lowest = v
 		}
-		sum += entry.Overall
+		sum += v
 
 		if i > 0 {
-			delta := entry.Overall - prevPercent
+			delta := v - prevPercent
 			switch {
 			case delta > 0.5:
 				upDays++
@@ -172,29 +293,44 @@ func (s *TrendAnalysisService) AnalyzeHistory(history *History, since time.Time)
 				stableDays++
 			}
 		}
-		prevPercent = entry.Overall
+		prevPercent = v
 	}
 
-	avg := sum / float64(len(entries))
+	avg := sum / float64(len(values))
 
 	return HistoryAnalysisResult{
-		EntriesCount: len(entries),
+		EntriesCount: len(values),
 		Highest:      NewPercentage(highest),
 		Lowest:       NewPercentage(lowest),
 		Average:      NewPercentage(avg),
+		P10:          NewPercentage(percentile(values, 10)),
 		UpDays:       upDays,
 		DownDays:     downDays,
 		StableDays:   stableDays,
-		Period:       time.Since(since),
 	}
 }
 
+// percentile returns the nearest-rank p-th percentile of values.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
 // HistoryAnalysisResult contains statistics about coverage history.
 type HistoryAnalysisResult struct {
 	EntriesCount int
 	Highest      Percentage
 	Lowest       Percentage
 	Average      Percentage
+	P10          Percentage // 10th percentile; 90% of entries were at or above it
 	UpDays       int
 	DownDays     int
 	StableDays   int
@@ -255,46 +391,49 @@ func (s *TrendAnalysisService) PredictNextCoverage(history *History, lookbackEnt
 		return NewPercentage(entries[len(entries)-1].Overall), 0.5
 	}
 
-	// Simple linear regression
+	values := make([]float64, len(entries))
+	for i, entry := range entries {
+		values[i] = entry.Overall
+	}
+	slope, intercept, confidence := linearFit(values)
+
+	// Predict next value (x = n)
+	predictedValue := clampPercent(slope*float64(len(values)) + intercept)
+
+	return NewPercentage(predictedValue), confidence
+}
+
+// linearFit fits values, taken at x = 0, 1, 2, ..., to a line by least
+// squares. confidence is 0-100 and falls as the values scatter around the
+// line.
+func linearFit(values []float64) (slope, intercept, confidence float64) {
 	var sumX, sumY, sumXY, sumX2 float64
-	n := float64(len(entries))
+	n := float64(len(values))
 
-	for i, entry := range entries {
+	for i, y := range values {
 		x := float64(i)
-		y := entry.Overall
 		sumX += x
 		sumY += y
 		sumXY += x * y
 		sumX2 += x * x
 	}
 
-	slope := (n*sumXY - sumX*sumY) / (n*sumX2 - sumX*sumX)
-	intercept := (sumY - slope*sumX) / n
-
-	// Predict next value (x = n)
-	nextX := n
-	predictedValue := slope*nextX + intercept
-
-	// Clamp to valid range
-	if predictedValue < 0 {
-		predictedValue = 0
-	}
-	if predictedValue > 100 {
-		predictedValue = 100
-	}
+	slope = (n*sumXY - sumX*sumY) / (n*sumX2 - sumX*sumX)
+	intercept = (sumY - slope*sumX) / n
 
 	// Confidence based on variance
 	var variance float64
-	for i, entry := range entries {
+	for i, y := range values {
 		expected := slope*float64(i) + intercept
-		diff := entry.Overall - expected
+		diff := y - expected
 		variance += diff * diff
 	}
 	variance /= n
 
 	// Convert variance to confidence (lower variance = higher confidence)
-	// Using exponential decay: confidence = e^(-variance/100)
-	confidence = 1.0 / (1.0 + variance/100)
+	return slope, intercept, Round1(100 / (1.0 + variance/100))
+}
 
-	return NewPercentage(predictedValue), Round1(confidence * 100)
+func clampPercent(v float64) float64 {
+	return math.Max(0, math.Min(100, v))
 }
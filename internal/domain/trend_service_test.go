@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -247,6 +248,47 @@ func TestHistoryAnalysis(t *testing.T) {
 		}
 	})
 
+	t.Run("AnalyzeDomainWindow keeps the most recent entries for a domain", func(t *testing.T) {
+		service := NewTrendAnalysisService()
+
+		now := time.Now()
+		var entries []HistoryEntry
+		for i := 0; i < 12; i++ {
+			entries = append(entries, HistoryEntry{
+				Timestamp: now.Add(time.Duration(i) * time.Hour),
+				Domains:   map[string]DomainEntry{"core": {Percent: float64(60 + i)}},
+			})
+		}
+		entries = append(entries, HistoryEntry{Timestamp: now.Add(13 * time.Hour), Domains: map[string]DomainEntry{"api": {Percent: 10}}})
+		history := &History{Entries: entries}
+
+		result := service.AnalyzeDomainWindow(history, "core", 10)
+
+		if result.EntriesCount != 10 {
+			t.Errorf("Expected 10 entries, got %d", result.EntriesCount)
+		}
+		if result.Lowest.Value() != 62.0 {
+			t.Errorf("Expected lowest 62, got %v", result.Lowest.Value())
+		}
+		if result.P10.Value() != 62.0 {
+			t.Errorf("Expected p10 62, got %v", result.P10.Value())
+		}
+		if result.Period != 9*time.Hour {
+			t.Errorf("Expected 9h period, got %v", result.Period)
+		}
+	})
+
+	t.Run("AnalyzeDomainWindow returns empty result for unknown domain", func(t *testing.T) {
+		service := NewTrendAnalysisService()
+		history := &History{Entries: []HistoryEntry{{Domains: map[string]DomainEntry{"core": {Percent: 80}}}}}
+
+		result := service.AnalyzeDomainWindow(history, "missing", 30)
+
+		if result.EntriesCount != 0 {
+			t.Errorf("Expected 0 entries, got %d", result.EntriesCount)
+		}
+	})
+
 	t.Run("Volatility returns correct value", func(t *testing.T) {
 		result := HistoryAnalysisResult{
 			EntriesCount: 5,
@@ -346,3 +388,54 @@ func TestPredictNextCoverage(t *testing.T) {
 		}
 	})
 }
+
+func TestDetectAnomalies(t *testing.T) {
+	previous := &HistoryEntry{
+		Overall:    80.0,
+		Statements: 1000,
+		Domains: map[string]DomainEntry{
+			"api":  {Name: "api", Percent: 80.0},
+			"core": {Name: "core", Percent: 90.0},
+			"cli":  {Name: "cli", Percent: 70.0},
+		},
+	}
+	current := &HistoryEntry{
+		Overall:    60.0,
+		Statements: 600,
+		Domains: map[string]DomainEntry{
+			"api":  {Name: "api", Percent: 60.0},
+			"core": {Name: "core", Percent: 88.0},
+			"cli":  {Name: "cli", Percent: 50.0},
+			"new":  {Name: "new", Percent: 0},
+		},
+	}
+
+	anomalies := NewTrendAnalysisService().DetectAnomalies(previous, current)
+	if len(anomalies) != 3 {
+		t.Fatalf("expected 3 anomalies, got %+v", anomalies)
+	}
+	if anomalies[0].Kind != AnomalyStatementsShrunk || anomalies[0].Previous != 1000 || anomalies[0].Current != 600 {
+		t.Errorf("unexpected statement anomaly %+v", anomalies[0])
+	}
+	if anomalies[1].Domain != "api" || anomalies[2].Domain != "cli" || anomalies[1].Kind != AnomalyCoverageDrop {
+		t.Errorf("unexpected domain anomalies %+v", anomalies[1:])
+	}
+	if got := anomalies[0].String(); !strings.Contains(got, "from 1000 to 600 (-40%)") {
+		t.Errorf("unexpected message %q", got)
+	}
+	if got := anomalies[1].String(); !strings.Contains(got, "domain api dropped from 80.0% to 60.0% (-20.0 points)") {
+		t.Errorf("unexpected message %q", got)
+	}
+}
+
+func TestDetectAnomaliesIgnoresOrdinaryChanges(t *testing.T) {
+	service := NewTrendAnalysisService()
+	previous := &HistoryEntry{Domains: map[string]DomainEntry{"core": {Name: "core", Percent: 80.0}}}
+	current := &HistoryEntry{Statements: 100, Domains: map[string]DomainEntry{"core": {Name: "core", Percent: 70.0}}}
+	if anomalies := service.DetectAnomalies(previous, current); len(anomalies) != 0 {
+		t.Fatalf("expected no anomalies, got %+v", anomalies)
+	}
+	if anomalies := service.DetectAnomalies(nil, current); anomalies != nil {
+		t.Fatalf("expected no anomalies without a previous entry, got %+v", anomalies)
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "sort"
+
+// UncoveredFile is a file with statements no test executed, ranked as a
+// test-writing target.
+type UncoveredFile struct {
+	File      string      `json:"file"`
+	Domains   []string    `json:"domains,omitempty"`
+	Covered   int         `json:"covered"`
+	Total     int         `json:"total"`
+	Uncovered int         `json:"uncovered"`
+	Percent   float64     `json:"percent"`
+	Ranges    []LineRange `json:"ranges,omitempty"` // Uncovered line ranges when line data is available
+}
+
+// NewUncoveredFile builds the target entry for a file's statement counts.
+func NewUncoveredFile(file string, stat CoverageStat) UncoveredFile {
+	return UncoveredFile{
+		File:      file,
+		Covered:   stat.Covered,
+		Total:     stat.Total,
+		Uncovered: stat.Uncovered(),
+		Percent:   stat.PercentRounded(),
+	}
+}
+
+// SortUncoveredFiles orders files by uncovered statements, most first, with
+// the file name as tie-breaker.
+func SortUncoveredFiles(files []UncoveredFile) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Uncovered != files[j].Uncovered {
+			return files[i].Uncovered > files[j].Uncovered
+		}
+		return files[i].File < files[j].File
+	})
+}
+
+// SortLowestCovered orders files by coverage percentage, lowest first, then
+// by uncovered statements, most first, with the file name as tie-breaker.
+func SortLowestCovered(files []UncoveredFile) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Percent != files[j].Percent {
+			return files[i].Percent < files[j].Percent
+		}
+		if files[i].Uncovered != files[j].Uncovered {
+			return files[i].Uncovered > files[j].Uncovered
+		}
+		return files[i].File < files[j].File
+	})
+}
+
+// UncoveredRanges groups the file's unexecuted lines into ranges. Lines
+// that are not instrumented (blank lines, comments) do not split a range;
+// an executed line does.
+func (c LineCoverage) UncoveredRanges() []LineRange {
+	numbers := make([]int, 0, len(c))
+	for n := range c {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	var ranges []LineRange
+	open := false
+	for _, n := range numbers {
+		if c[n] > 0 {
+			open = false
+			continue
+		}
+		if open {
+			ranges[len(ranges)-1].End = n
+			continue
+		}
+		ranges = append(ranges, LineRange{Start: n, End: n})
+		open = true
+	}
+	return ranges
+}
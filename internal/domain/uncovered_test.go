@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineCoverageUncoveredRanges(t *testing.T) {
+	lines := LineCoverage{
+		3: 1,
+		4: 0, 5: 0, 8: 0, // gap at 6-7 is not instrumented
+		9:  2,
+		12: 0,
+	}
+	want := []LineRange{{Start: 4, End: 8}, {Start: 12, End: 12}}
+	if got := lines.UncoveredRanges(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := (LineCoverage{1: 1}).UncoveredRanges(); got != nil {
+		t.Fatalf("expected no ranges for fully covered file, got %v", got)
+	}
+}
+
+func TestSortUncoveredFiles(t *testing.T) {
+	files := []UncoveredFile{
+		NewUncoveredFile("b.go", CoverageStat{Covered: 8, Total: 10}),
+		NewUncoveredFile("c.go", CoverageStat{Covered: 0, Total: 5}),
+		NewUncoveredFile("a.go", CoverageStat{Covered: 3, Total: 5}),
+	}
+	SortUncoveredFiles(files)
+	var order []string
+	for _, f := range files {
+		order = append(order, f.File)
+	}
+	if want := []string{"c.go", "a.go", "b.go"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	if files[1].Uncovered != 2 || files[1].Percent != 60 {
+		t.Fatalf("unexpected entry: %+v", files[1])
+	}
+}
+
+func TestSortLowestCovered(t *testing.T) {
+	files := []UncoveredFile{
+		NewUncoveredFile("b.go", CoverageStat{Covered: 5, Total: 10}),
+		NewUncoveredFile("c.go", CoverageStat{Covered: 9, Total: 10}),
+		NewUncoveredFile("a.go", CoverageStat{Covered: 1, Total: 2}),
+		NewUncoveredFile("d.go", CoverageStat{Covered: 0, Total: 3}),
+	}
+	SortLowestCovered(files)
+	var order []string
+	for _, f := range files {
+		order = append(order, f.File)
+	}
+	if want := []string{"d.go", "b.go", "a.go", "c.go"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+}
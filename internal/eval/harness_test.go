@@ -32,6 +32,9 @@ func (stubService) PRComment(context.Context, application.PRCommentOptions) (app
 func (stubService) Debt(context.Context, application.DebtOptions) (application.DebtResult, error) {
 	return application.DebtResult{}, nil
 }
+func (stubService) Uncovered(context.Context, application.UncoveredOptions) (application.UncoveredResult, error) {
+	return application.UncoveredResult{}, nil
+}
 func (stubService) Trend(context.Context, application.TrendOptions, application.HistoryStore) (application.TrendResult, error) {
 	return application.TrendResult{}, nil
 }
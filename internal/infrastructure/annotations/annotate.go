@@ -0,0 +1,122 @@
+package annotations
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// Writer implements application.SourceAnnotator.
+type Writer struct{}
+
+var _ application.SourceAnnotator = Writer{}
+
+// hashComment lists extensions whose line comments start with "#"; the
+// other supported extensions use "//".
+var hashComment = map[string]bool{
+	".py":   true,
+	".rb":   true,
+	".ex":   true,
+	".exs":  true,
+	".sh":   true,
+	".bash": true,
+}
+
+// encodingLine matches a Python or Ruby source encoding declaration, which
+// must stay within the first two lines.
+var encodingLine = regexp.MustCompile(`^#.*coding[:=]`)
+
+// Annotate inserts a coverctl:ignore line comment at the top of file, after
+// any shebang or encoding declaration. Go files get a blank line after the
+// pragma so it does not become the package doc comment.
+func (Writer) Annotate(moduleRoot, file string, dryRun bool) (application.AnnotatedFile, error) {
+	out := application.AnnotatedFile{File: file}
+	ext := filepath.Ext(file)
+	if !supportedExtensions[ext] {
+		out.Status = application.AnnotateUnsupported
+		return out, nil
+	}
+	out.Line = "// " + pragmaIgnore
+	if hashComment[ext] {
+		out.Line = "# " + pragmaIgnore
+	}
+
+	cleanPath, err := pathutil.ValidatePath(filepath.Join(moduleRoot, filepath.FromSlash(file)))
+	if err != nil {
+		return out, err
+	}
+	info, err := os.Stat(cleanPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		out.Status, out.Line = application.AnnotateMissing, ""
+		return out, nil
+	} else if err != nil {
+		return out, err
+	}
+	src, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return out, err
+	}
+	if hasIgnorePragma(src) {
+		out.Status, out.Line = application.AnnotatePresent, ""
+		return out, nil
+	}
+
+	out.Status = application.AnnotateAdded
+	if dryRun {
+		return out, nil
+	}
+	return out, os.WriteFile(cleanPath, insertPragma(src, out.Line, ext == ".go"), info.Mode().Perm())
+}
+
+// hasIgnorePragma reports whether the lines Scanner reads already ignore
+// the file.
+func hasIgnorePragma(src []byte) bool {
+	lines := bytes.SplitN(src, []byte("\n"), maxScanLines+1)
+	if len(lines) > maxScanLines {
+		lines = lines[:maxScanLines]
+	}
+	for _, line := range lines {
+		if bytes.Contains(line, []byte(pragmaIgnore)) {
+			return true
+		}
+	}
+	return false
+}
+
+// insertPragma puts line after the leading shebang and encoding lines of
+// src, keeping the file's line endings.
+func insertPragma(src []byte, line string, blankAfter bool) []byte {
+	newline := "\n"
+	if bytes.Contains(src, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+	offset := 0
+	for i := 0; i < 2 && offset < len(src); i++ {
+		end := bytes.IndexByte(src[offset:], '\n')
+		if end < 0 {
+			break
+		}
+		text := strings.TrimRight(string(src[offset:offset+end]), "\r")
+		if !(i == 0 && strings.HasPrefix(text, "#!")) && !encodingLine.MatchString(text) {
+			break
+		}
+		offset += end + 1
+	}
+	insert := line + newline
+	if blankAfter {
+		insert += newline
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(src) + len(insert))
+	buf.Write(src[:offset])
+	buf.WriteString(insert)
+	buf.Write(src[offset:])
+	return buf.Bytes()
+}
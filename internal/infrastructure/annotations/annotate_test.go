@@ -0,0 +1,78 @@
+package annotations
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func TestWriterAnnotate(t *testing.T) {
+	tmp := t.TempDir()
+	files := map[string]string{
+		"gen.go":     "//go:build tools\n\npackage gen\n",
+		"tool.py":    "#!/usr/bin/env python\n# -*- coding: utf-8 -*-\nprint('hi')\n",
+		"done.go":    "// coverctl:ignore\n\npackage done\n",
+		"notes.txt":  "notes\n",
+		"crlf.js":    "const a = 1;\r\n",
+		"script.sh":  "#!/bin/sh\necho hi\n",
+		"dryrun.rs":  "fn main() {}\n",
+		"missing.go": "",
+	}
+	for name, content := range files {
+		if name == "missing.go" {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(tmp, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		file   string
+		dryRun bool
+		status application.AnnotateStatus
+		want   string
+	}{
+		{file: "gen.go", status: application.AnnotateAdded, want: "// coverctl:ignore\n\n//go:build tools\n\npackage gen\n"},
+		{file: "tool.py", status: application.AnnotateAdded, want: "#!/usr/bin/env python\n# -*- coding: utf-8 -*-\n# coverctl:ignore\nprint('hi')\n"},
+		{file: "script.sh", status: application.AnnotateAdded, want: "#!/bin/sh\n# coverctl:ignore\necho hi\n"},
+		{file: "crlf.js", status: application.AnnotateAdded, want: "// coverctl:ignore\r\nconst a = 1;\r\n"},
+		{file: "done.go", status: application.AnnotatePresent, want: files["done.go"]},
+		{file: "notes.txt", status: application.AnnotateUnsupported, want: files["notes.txt"]},
+		{file: "dryrun.rs", dryRun: true, status: application.AnnotateAdded, want: files["dryrun.rs"]},
+		{file: "missing.go", status: application.AnnotateMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got, err := (Writer{}).Annotate(tmp, tt.file, tt.dryRun)
+			if err != nil {
+				t.Fatalf("annotate: %v", err)
+			}
+			if got.Status != tt.status {
+				t.Fatalf("expected status %s, got %+v", tt.status, got)
+			}
+			if tt.status == application.AnnotateMissing {
+				return
+			}
+			data, err := os.ReadFile(filepath.Join(tmp, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Fatalf("unexpected content %q", data)
+			}
+		})
+	}
+
+	// The scanner honours what the writer inserts.
+	out, err := (Scanner{}).Scan(context.Background(), tmp, []string{"gen.go", "tool.py"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out["gen.go"].Ignore || !out["tool.py"].Ignore {
+		t.Fatalf("expected annotated files ignored, got %+v", out)
+	}
+}
@@ -0,0 +1,87 @@
+package annotations
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+var _ application.FunctionScanner = Scanner{}
+
+// ScanFunctions parses Go files and returns the line span of every function
+// and method with a body. Files that are missing or not Go are skipped.
+func (Scanner) ScanFunctions(_ context.Context, moduleRoot string, files []string) (map[string][]domain.FunctionSpan, error) {
+	result := make(map[string][]domain.FunctionSpan)
+	for _, file := range files {
+		if filepath.Ext(file) != ".go" {
+			continue
+		}
+		path := file
+		if moduleRoot != "" {
+			path = filepath.Join(moduleRoot, filepath.FromSlash(file))
+		}
+		cleanPath, err := pathutil.ValidatePath(path)
+		if err != nil {
+			continue // Skip invalid paths
+		}
+		src, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		fset := token.NewFileSet()
+		parsed, err := parser.ParseFile(fset, cleanPath, src, parser.SkipObjectResolution)
+		if err != nil {
+			continue // Unparseable source cannot be matched to functions
+		}
+		for _, decl := range parsed.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			result[file] = append(result[file], domain.FunctionSpan{
+				Name: FunctionName(fn),
+				Lines: domain.LineRange{
+					Start: fset.Position(fn.Pos()).Line,
+					End:   fset.Position(fn.End()).Line,
+				},
+			})
+		}
+	}
+	return result, nil
+}
+
+// FunctionName renders fn as "Func()" or, for methods, "Type.Method()". The
+// test scaffolder uses it too, so its names match the ones scanned here.
+func FunctionName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name + "()"
+	}
+	return ReceiverType(fn.Recv.List[0].Type) + "." + fn.Name.Name + "()"
+}
+
+// ReceiverType strips pointers and type parameters from a receiver type.
+func ReceiverType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return ReceiverType(t.X)
+	case *ast.ParenExpr:
+		return ReceiverType(t.X)
+	case *ast.IndexExpr:
+		return ReceiverType(t.X)
+	case *ast.IndexListExpr:
+		return ReceiverType(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
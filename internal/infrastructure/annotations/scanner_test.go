@@ -105,3 +105,39 @@ func TestScannerIgnoresMissingFile(t *testing.T) {
 		t.Fatalf("expected missing file to be ignored: %v", err)
 	}
 }
+
+func TestScanFunctionsNamesFunctionsAndMethods(t *testing.T) {
+	tmp := t.TempDir()
+	content := `package pkg
+
+type Box[T any] struct{ v T }
+
+func (b *Box[T]) String() string {
+	return "box"
+}
+
+func helper() int {
+	return 1
+}
+`
+	if err := os.WriteFile(filepath.Join(tmp, "box.go"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out, err := (Scanner{}).ScanFunctions(context.Background(), tmp, []string{"box.go", "missing.go", "main.py"})
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	spans := out["box.go"]
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 functions, got %+v", spans)
+	}
+	if spans[0].Name != "Box.String()" || spans[0].Lines.Start != 5 || spans[0].Lines.End != 7 {
+		t.Fatalf("unexpected method span: %+v", spans[0])
+	}
+	if spans[1].Name != "helper()" || spans[1].Lines.Start != 9 || spans[1].Lines.End != 11 {
+		t.Fatalf("unexpected function span: %+v", spans[1])
+	}
+	if len(out) != 1 {
+		t.Fatalf("expected only box.go, got %v", out)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"math"
+	"unicode/utf8"
 )
 
 type Style string
@@ -17,6 +19,7 @@ type Options struct {
 	Label   string
 	Percent float64
 	Style   Style
+	Delta   *float64 // Optional change since the previous run, shown as "84% ▲0.6"
 }
 
 const svgTemplate = `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.PercentText}}">
@@ -61,11 +64,14 @@ func Generate(w io.Writer, opts Options) error {
 	}
 
 	percentText := formatPercent(opts.Percent)
+	if opts.Delta != nil {
+		percentText += " " + formatDelta(*opts.Delta)
+	}
 	color := colorForPercent(opts.Percent)
 
 	// Calculate widths based on text length
 	labelWidth := len(opts.Label)*7 + 10
-	valueWidth := len(percentText)*7 + 10
+	valueWidth := utf8.RuneCountInString(percentText)*7 + 10
 	totalWidth := labelWidth + valueWidth
 
 	rx := 3
@@ -83,7 +89,7 @@ func Generate(w io.Writer, opts Options) error {
 		LabelX:         labelWidth * 5,                   // Centered in label section (scaled by 10)
 		ValueX:         (labelWidth + valueWidth/2) * 10, // Centered in value section
 		LabelTextWidth: (len(opts.Label) * 7) * 10,
-		ValueTextWidth: (len(percentText) * 7) * 10,
+		ValueTextWidth: (utf8.RuneCountInString(percentText) * 7) * 10,
 		Rx:             rx,
 	}
 
@@ -102,6 +108,19 @@ func formatPercent(p float64) string {
 	return fmt.Sprintf("%.1f%%", p)
 }
 
+// formatDelta renders a change in points with a direction arrow: "▲0.6",
+// "▼1.2", or "±0" when unchanged.
+func formatDelta(d float64) string {
+	switch {
+	case d > 0:
+		return fmt.Sprintf("▲%.1f", d)
+	case d < 0:
+		return fmt.Sprintf("▼%.1f", math.Abs(d))
+	default:
+		return "±0"
+	}
+}
+
 func colorForPercent(p float64) string {
 	switch {
 	case p >= 90:
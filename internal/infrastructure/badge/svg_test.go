@@ -135,3 +135,36 @@ func TestGenerateBadge0Percent(t *testing.T) {
 		t.Fatal("expected 0%")
 	}
 }
+
+func TestGenerateBadgeDelta(t *testing.T) {
+	tests := []struct {
+		delta float64
+		want  string
+	}{
+		{0.6, "84% ▲0.6"},
+		{-1.25, "84% ▼1.2"},
+		{0, "84% ±0"},
+	}
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		delta := tt.delta
+		if err := Generate(buf, Options{Label: "coverage", Percent: 84, Delta: &delta}); err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		if !strings.Contains(buf.String(), ">"+tt.want+"</text>") {
+			t.Fatalf("expected %q in badge:\n%s", tt.want, buf.String())
+		}
+	}
+
+	plain, annotated := new(bytes.Buffer), new(bytes.Buffer)
+	delta := 0.6
+	if err := Generate(plain, Options{Label: "coverage", Percent: 84}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if err := Generate(annotated, Options{Label: "coverage", Percent: 84, Delta: &delta}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !strings.Contains(plain.String(), `width="97"`) || !strings.Contains(annotated.String(), `width="132"`) {
+		t.Fatalf("expected the value section to widen by the delta's characters")
+	}
+}
@@ -0,0 +1,123 @@
+// Package cache keeps prepared coverage analysis between calls: in memory
+// for the life of the process, which is what the MCP server benefits from,
+// and on disk so separate CLI invocations share it. Callers key entries by
+// a digest of their inputs and check anything the key cannot cover, such as
+// source files, on read; the store itself only evicts.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultMaxEntries bounds how many entries are kept on disk.
+const DefaultMaxEntries = 16
+
+var disabled atomic.Bool
+
+// SetEnabled turns every Store on or off for the rest of the process
+// (--no-cache).
+func SetEnabled(on bool) {
+	disabled.Store(!on)
+}
+
+// Store is a JSON cache backed by files in Dir.
+type Store struct {
+	Dir        string
+	MaxEntries int // 0 uses DefaultMaxEntries
+
+	mu  sync.Mutex
+	mem map[string][]byte
+}
+
+// New returns a Store that writes under dir.
+func New(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// Get decodes the entry for key into v and reports whether it was found.
+func (s *Store) Get(key string, v any) bool {
+	if disabled.Load() {
+		return false
+	}
+	s.mu.Lock()
+	data, ok := s.mem[key]
+	s.mu.Unlock()
+	if !ok {
+		var err error
+		data, err = os.ReadFile(s.path(key))
+		if err != nil {
+			return false
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false
+	}
+	s.remember(key, data)
+	return true
+}
+
+// Put stores v under key. Failing to write the disk copy is not an error:
+// the cache only ever saves work.
+func (s *Store) Put(key string, v any) {
+	if disabled.Load() {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	s.remember(key, data)
+	if err := os.MkdirAll(s.Dir, 0o750); err != nil {
+		return
+	}
+	if err := os.WriteFile(s.path(key), data, 0o600); err != nil {
+		return
+	}
+	s.evict()
+}
+
+func (s *Store) remember(key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mem == nil {
+		s.mem = make(map[string][]byte)
+	}
+	s.mem[key] = data
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.Dir, key+".json")
+}
+
+// evict removes the oldest files beyond MaxEntries.
+func (s *Store) evict() {
+	limit := s.MaxEntries
+	if limit <= 0 {
+		limit = DefaultMaxEntries
+	}
+	matches, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil || len(matches) <= limit {
+		return
+	}
+	type entry struct {
+		path    string
+		modTime int64
+	}
+	entries := make([]entry, 0, len(matches))
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry{m, info.ModTime().UnixNano()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime > entries[j].modTime })
+	for _, e := range entries[min(limit, len(entries)):] {
+		_ = os.Remove(e.path)
+	}
+}
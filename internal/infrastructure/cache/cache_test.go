@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type payload struct {
+	Name  string
+	Count int
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	s := New(dir)
+	var got payload
+	if s.Get("k", &got) {
+		t.Fatal("expected a miss on an empty store")
+	}
+	s.Put("k", payload{Name: "core", Count: 3})
+	if !s.Get("k", &got) || got != (payload{Name: "core", Count: 3}) {
+		t.Fatalf("expected in-memory hit, got %+v", got)
+	}
+
+	// A fresh store, like the next CLI invocation, reads the disk copy.
+	got = payload{}
+	if !New(dir).Get("k", &got) || got.Name != "core" {
+		t.Fatalf("expected disk hit, got %+v", got)
+	}
+}
+
+func TestStoreEvictsOldest(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{Dir: dir, MaxEntries: 2}
+	for i, key := range []string{"a", "b", "c"} {
+		s.Put(key, payload{Count: i})
+		// Spread mtimes so eviction order is deterministic.
+		stamp := time.Now().Add(time.Duration(i-3) * time.Minute)
+		_ = os.Chtimes(filepath.Join(dir, key+".json"), stamp, stamp)
+	}
+	s.Put("d", payload{Count: 3})
+	if _, err := os.Stat(filepath.Join(dir, "a.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected oldest entry to be evicted, stat err %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 entries on disk, got %v", matches)
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	t.Cleanup(func() { SetEnabled(true) })
+	s := New(t.TempDir())
+	s.Put("k", payload{Count: 1})
+	SetEnabled(false)
+	var got payload
+	if s.Get("k", &got) {
+		t.Fatal("expected no hits while disabled")
+	}
+	s.Put("other", payload{})
+	SetEnabled(true)
+	if s.Get("other", &got) {
+		t.Fatal("expected writes to be skipped while disabled")
+	}
+	if !s.Get("k", &got) {
+		t.Fatal("expected earlier entry once re-enabled")
+	}
+}
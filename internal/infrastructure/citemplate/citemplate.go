@@ -0,0 +1,251 @@
+// Package citemplate renders ready-to-use CI pipeline files that run
+// coverctl, for `coverctl ci init`.
+package citemplate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// Provider is a CI system coverctl can generate a pipeline for.
+type Provider string
+
+const (
+	GitHub Provider = "github"
+	GitLab Provider = "gitlab"
+)
+
+// ParseProvider returns the provider named s.
+func ParseProvider(s string) (Provider, error) {
+	switch p := Provider(strings.ToLower(s)); p {
+	case GitHub, GitLab:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown CI provider %q (use github or gitlab)", s)
+}
+
+// Path is where the pipeline file for p is written. The GitLab file is
+// meant to be included from .gitlab-ci.yml, which it never replaces.
+func (p Provider) Path() string {
+	if p == GitLab {
+		return ".gitlab/coverctl.gitlab-ci.yml"
+	}
+	return ".github/workflows/coverctl.yml"
+}
+
+// Options parameterize a pipeline.
+type Options struct {
+	Language   application.Language
+	ConfigPath string // Config file the pipeline checks against
+	Branch     string // Branch whose pushes record history
+}
+
+// toolchain is what a language needs on the runner before coverctl can
+// run its tests.
+type toolchain struct {
+	Setup   string // GitHub Actions setup step, as list items under steps
+	Image   string // GitLab CI job image
+	Install string // Command installing test dependencies, if any
+}
+
+var toolchains = map[application.Language]toolchain{
+	application.LanguageGo: {
+		Setup: "- uses: actions/setup-go@v5\n  with:\n    go-version-file: go.mod",
+		Image: "golang:1.25",
+	},
+	application.LanguagePython: {
+		Setup:   "- uses: actions/setup-python@v5\n  with:\n    python-version: '3.12'",
+		Image:   "python:3.12",
+		Install: "pip install pytest pytest-cov",
+	},
+	application.LanguageJavaScript: {
+		Setup:   "- uses: actions/setup-node@v4\n  with:\n    node-version: lts/*",
+		Image:   "node:lts",
+		Install: "npm ci",
+	},
+	application.LanguageTypeScript: {
+		Setup:   "- uses: actions/setup-node@v4\n  with:\n    node-version: lts/*",
+		Image:   "node:lts",
+		Install: "npm ci",
+	},
+	application.LanguageJava: {
+		Setup: "- uses: actions/setup-java@v4\n  with:\n    distribution: temurin\n    java-version: '21'",
+		Image: "eclipse-temurin:21",
+	},
+	application.LanguageRust: {
+		Setup:   "- uses: dtolnay/rust-toolchain@stable\n  with:\n    components: llvm-tools-preview",
+		Image:   "rust:latest",
+		Install: "cargo install cargo-llvm-cov",
+	},
+	application.LanguageCSharp: {
+		Setup: "- uses: actions/setup-dotnet@v4\n  with:\n    dotnet-version: '8.0.x'",
+		Image: "mcr.microsoft.com/dotnet/sdk:8.0",
+	},
+	application.LanguagePHP: {
+		Setup:   "- uses: shivammathur/setup-php@v2\n  with:\n    php-version: '8.3'\n    coverage: xdebug",
+		Image:   "php:8.3",
+		Install: "composer install",
+	},
+	application.LanguageRuby: {
+		Setup: "- uses: ruby/setup-ruby@v1\n  with:\n    ruby-version: '3.3'\n    bundler-cache: true",
+		Image: "ruby:3.3",
+		// The GitHub setup step installs gems through bundler-cache.
+	},
+	application.LanguageDart: {
+		Setup: "- uses: dart-lang/setup-dart@v1",
+		Image: "dart:stable",
+	},
+	application.LanguageScala: {
+		Setup: "- uses: actions/setup-java@v4\n  with:\n    distribution: temurin\n    java-version: '21'\n- uses: sbt/setup-sbt@v1",
+		Image: "eclipse-temurin:21", // Projects without an sbt or mill wrapper add sbt here
+	},
+	application.LanguageElixir: {
+		Setup:   "- uses: erlef/setup-beam@v1\n  with:\n    otp-version: '27'\n    elixir-version: '1.17'",
+		Image:   "elixir:1.17",
+		Install: "mix deps.get",
+	},
+	application.LanguageSwift: {
+		Setup: "- uses: swift-actions/setup-swift@v2",
+		Image: "swift:latest",
+	},
+}
+
+// fallbackImage runs languages without a dedicated image; it has curl and
+// a C toolchain.
+const fallbackImage = "buildpack-deps:stable"
+
+// installCoverctl downloads the latest Linux release binary.
+const installCoverctl = "curl -sSfL https://github.com/felixgeelhaar/coverctl/releases/latest/download/coverctl-linux-amd64.tar.gz | tar -xz -C /tmp"
+
+var templates = map[Provider]*template.Template{
+	GitHub: template.Must(template.New("github").Funcs(template.FuncMap{"indent": indent}).Parse(githubTemplate)),
+	GitLab: template.Must(template.New("gitlab").Parse(gitlabTemplate)),
+}
+
+// Render returns the pipeline file for p.
+func Render(p Provider, opts Options) ([]byte, error) {
+	tmpl, ok := templates[p]
+	if !ok {
+		return nil, fmt.Errorf("unknown CI provider %q (use github or gitlab)", p)
+	}
+	if opts.ConfigPath == "" {
+		opts.ConfigPath = ".coverctl.yaml"
+	}
+	if opts.Branch == "" {
+		opts.Branch = "main"
+	}
+	tc := toolchains[opts.Language]
+	if tc.Image == "" {
+		tc.Image = fallbackImage
+	}
+	data := struct {
+		Options
+		toolchain
+		InstallCoverctl string
+	}{opts, tc, installCoverctl}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render %s pipeline: %w", p, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// indent prefixes every line of s with n spaces.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+const githubTemplate = `# Generated by coverctl ci init.
+name: Coverage
+
+on:
+  push:
+    branches: [{{.Branch}}]
+  pull_request:
+
+jobs:
+  coverage:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+{{- if .Setup}}
+
+{{indent 6 .Setup}}
+{{- end}}
+{{- if .Install}}
+
+      - name: Install test dependencies
+        run: {{.Install}}
+{{- end}}
+
+      - name: Install coverctl
+        run: |
+          {{.InstallCoverctl}}
+          sudo install /tmp/coverctl-linux-amd64 /usr/local/bin/coverctl
+
+      - name: Restore coverage history
+        uses: actions/cache@v4
+        with:
+          path: .cover/history.json
+          key: coverctl-history-${{"{{"}} github.run_id {{"}}"}}
+          restore-keys: coverctl-history-
+
+      - name: Check coverage
+        run: coverctl --ci check -c {{.ConfigPath}}
+
+      - name: Record history
+        if: ${{"{{"}} !cancelled() && github.event_name == 'push' {{"}}"}}
+        run: coverctl record -c {{.ConfigPath}}
+
+      - name: Generate badge
+        if: ${{"{{"}} !cancelled() {{"}}"}}
+        run: coverctl badge -c {{.ConfigPath}} -o coverage.svg
+
+      - name: Upload coverage artifacts
+        if: ${{"{{"}} !cancelled() {{"}}"}}
+        uses: actions/upload-artifact@v4
+        with:
+          name: coverage
+          path: |
+            coverage.svg
+            .cover/history.json
+          if-no-files-found: ignore
+`
+
+const gitlabTemplate = `# Generated by coverctl ci init. Include it from .gitlab-ci.yml:
+#
+#   include:
+#     - local: .gitlab/coverctl.gitlab-ci.yml
+
+coverage:
+  stage: test
+  image: {{.Image}}
+  cache:
+    key: coverctl-history
+    paths:
+      - .cover/history.json
+  before_script:
+    - {{.InstallCoverctl}}
+    - install /tmp/coverctl-linux-amd64 /usr/local/bin/coverctl
+{{- if .Install}}
+    - {{.Install}}
+{{- end}}
+  script:
+    - coverctl --ci check -c {{.ConfigPath}}
+  after_script:
+    - coverctl badge -c {{.ConfigPath}} -o coverage.svg
+    - if [ "$CI_COMMIT_BRANCH" = "{{.Branch}}" ]; then coverctl record -c {{.ConfigPath}}; fi
+  artifacts:
+    when: always
+    paths:
+      - coverage.svg
+      - .cover/history.json
+`
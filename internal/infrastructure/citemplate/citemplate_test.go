@@ -0,0 +1,49 @@
+package citemplate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRender(t *testing.T) {
+	for _, lang := range []application.Language{application.LanguageGo, application.LanguageJavaScript, application.LanguageShell, ""} {
+		for _, p := range []Provider{GitHub, GitLab} {
+			data, err := Render(p, Options{Language: lang, ConfigPath: "ci/coverctl.yaml", Branch: "trunk"})
+			if err != nil {
+				t.Fatalf("%s/%s: %v", p, lang, err)
+			}
+			var doc map[string]any
+			if err := yaml.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("%s/%s: invalid YAML: %v\n%s", p, lang, err, data)
+			}
+			for _, want := range []string{"coverctl --ci check -c ci/coverctl.yaml", "coverctl record", "coverctl badge", "trunk", "coverage.svg"} {
+				if !strings.Contains(string(data), want) {
+					t.Fatalf("%s/%s: expected %q in:\n%s", p, lang, want, data)
+				}
+			}
+		}
+	}
+
+	github, _ := Render(GitHub, Options{Language: application.LanguageJavaScript})
+	for _, want := range []string{"      - uses: actions/setup-node@v4\n        with:\n          node-version: lts/*", "run: npm ci", "branches: [main]", "${{ !cancelled() }}"} {
+		if !strings.Contains(string(github), want) {
+			t.Fatalf("expected %q in:\n%s", want, github)
+		}
+	}
+	gitlab, _ := Render(GitLab, Options{Language: application.LanguageShell})
+	if !strings.Contains(string(gitlab), "image: "+fallbackImage) {
+		t.Fatalf("expected the fallback image in:\n%s", gitlab)
+	}
+}
+
+func TestParseProvider(t *testing.T) {
+	if p, err := ParseProvider("GitLab"); err != nil || p != GitLab {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	if _, err := ParseProvider("jenkins"); err == nil {
+		t.Fatal("expected an error for an unknown provider")
+	}
+}
@@ -36,6 +36,7 @@ import (
 // caller's stdout/stderr.
 type Runner struct {
 	Logger *slog.Logger // nil → slog.Default
+	Stdin  io.Reader    // nil → no input
 	Stdout io.Writer
 	Stderr io.Writer
 	// Env, when non-nil, replaces the entire environment passed to the child
@@ -90,6 +91,7 @@ func (r Runner) Exec(ctx context.Context, dir, binary string, args []string) err
 	if r.Env != nil {
 		cmd.Env = r.Env
 	}
+	cmd.Stdin = r.Stdin
 	cmd.Stdout = ioOrDefault(r.Stdout, nil)
 	cmd.Stderr = ioOrDefault(r.Stderr, nil)
 	err := cmd.Run()
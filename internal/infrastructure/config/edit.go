@@ -0,0 +1,346 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Edit operations accepted by Edit.
+const (
+	OpSetDefaultMin = "set_default_min" // Min
+	OpSetDomainMin  = "set_domain_min"  // Domain, Min
+	OpAddDomain     = "add_domain"      // Domain, Match, optional Min
+	OpRemoveDomain  = "remove_domain"   // Domain
+	OpAddExclude    = "add_exclude"     // Pattern, optional Domain
+)
+
+// ErrDomainNotFound reports a change to a domain the file does not define,
+// for example one inherited through extends.
+var ErrDomainNotFound = errors.New("domain not found")
+
+// Change is one structured edit to a config file.
+type Change struct {
+	Op      string
+	Domain  string
+	Match   []string
+	Min     *float64
+	Pattern string
+}
+
+// Edit applies changes to the YAML document in raw and returns the edited
+// document. It works on the YAML node tree rather than round-tripping
+// through Config, so comments, key order, and unrelated settings survive.
+// The result must still pass the checks Load applies.
+func Edit(raw []byte, changes []Change) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+		setKey(doc.Content[0], "version", valueNode(1))
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("config is not a YAML mapping")
+	}
+
+	for i, c := range changes {
+		if err := applyChange(root, c); err != nil {
+			return nil, fmt.Errorf("change %d (%s): %w", i+1, c.Op, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	var check fileConfig
+	if err := yaml.Unmarshal(buf.Bytes(), &check); err != nil {
+		return nil, fmt.Errorf("edited config is invalid: %w", err)
+	}
+	if err := validateFileConfig(&check); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func applyChange(root *yaml.Node, c Change) error {
+	switch c.Op {
+	case OpSetDefaultMin:
+		if c.Min == nil {
+			return errors.New("min is required")
+		}
+		if err := checkMin(c.Min); err != nil {
+			return err
+		}
+		defaults := mapping(mapping(root, "policy"), "default")
+		setKey(defaults, "min", valueNode(*c.Min))
+		return nil
+
+	case OpSetDomainMin:
+		if c.Min == nil {
+			return errors.New("min is required")
+		}
+		if err := checkMin(c.Min); err != nil {
+			return err
+		}
+		domains := sequence(mapping(root, "policy"), "domains")
+		i := findDomain(domains, c.Domain)
+		if i < 0 {
+			return fmt.Errorf("%w: %q", ErrDomainNotFound, c.Domain)
+		}
+		setKey(domains.Content[i], "min", valueNode(*c.Min))
+		return nil
+
+	case OpAddDomain:
+		if c.Domain == "" {
+			return domain.ErrEmptyDomainName
+		}
+		if len(c.Match) == 0 {
+			return errors.New("match is required")
+		}
+		if err := checkMin(c.Min); err != nil {
+			return err
+		}
+		domains := sequence(mapping(root, "policy"), "domains")
+		if findDomain(domains, c.Domain) >= 0 {
+			return fmt.Errorf("domain %q already exists", c.Domain)
+		}
+		entry := &yaml.Node{Kind: yaml.MappingNode}
+		setKey(entry, "name", valueNode(c.Domain))
+		match := valueNode(c.Match)
+		if len(domains.Content) > 0 {
+			// Follow the style of the existing entries.
+			if prev := lookup(domains.Content[len(domains.Content)-1], "match"); prev != nil {
+				match.Style = prev.Style
+			}
+		}
+		setKey(entry, "match", match)
+		if c.Min != nil {
+			setKey(entry, "min", valueNode(*c.Min))
+		}
+		domains.Content = append(domains.Content, entry)
+		return nil
+
+	case OpRemoveDomain:
+		domains := sequence(mapping(root, "policy"), "domains")
+		i := findDomain(domains, c.Domain)
+		if i < 0 {
+			return fmt.Errorf("%w: %q", ErrDomainNotFound, c.Domain)
+		}
+		domains.Content = append(domains.Content[:i], domains.Content[i+1:]...)
+		return nil
+
+	case OpAddExclude:
+		if strings.TrimSpace(c.Pattern) == "" {
+			return errors.New("pattern is required")
+		}
+		parent, excludeKey := root, "exclude"
+		if c.Domain != "" {
+			domains := sequence(mapping(root, "policy"), "domains")
+			i := findDomain(domains, c.Domain)
+			if i < 0 {
+				return fmt.Errorf("%w: %q", ErrDomainNotFound, c.Domain)
+			}
+			parent = domains.Content[i]
+		}
+		// A global exclude written as {files, functions} keeps globs under files.
+		if v := lookup(parent, "exclude"); parent == root && v != nil && v.Kind == yaml.MappingNode {
+			parent = v
+			excludeKey = "files"
+		}
+		excludes := sequence(parent, excludeKey)
+		for _, n := range excludes.Content {
+			if n.Value == c.Pattern {
+				return nil
+			}
+		}
+		excludes.Content = append(excludes.Content, valueNode(c.Pattern))
+		return nil
+
+	default:
+		return fmt.Errorf("unknown operation %q (want %s, %s, %s, %s, or %s)",
+			c.Op, OpSetDefaultMin, OpSetDomainMin, OpAddDomain, OpRemoveDomain, OpAddExclude)
+	}
+}
+
+func checkMin(minVal *float64) error {
+	if minVal == nil {
+		return nil
+	}
+	_, err := domain.NewThreshold(*minVal)
+	return err
+}
+
+// mapping returns the mapping under key in m, creating it if needed.
+func mapping(m *yaml.Node, key string) *yaml.Node {
+	if v := lookup(m, key); v != nil && v.Kind == yaml.MappingNode {
+		return v
+	}
+	v := &yaml.Node{Kind: yaml.MappingNode}
+	setKey(m, key, v)
+	return v
+}
+
+// sequence returns the sequence under key in m, creating it if needed.
+func sequence(m *yaml.Node, key string) *yaml.Node {
+	if v := lookup(m, key); v != nil && v.Kind == yaml.SequenceNode {
+		return v
+	}
+	v := &yaml.Node{Kind: yaml.SequenceNode}
+	setKey(m, key, v)
+	return v
+}
+
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setKey replaces the value under key, keeping the key's position and any
+// comments on the old value, or appends the key when it is missing.
+func setKey(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			old := m.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+func findDomain(domains *yaml.Node, name string) int {
+	for i, d := range domains.Content {
+		if n := lookup(d, "name"); n != nil && n.Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// valueNode encodes a string, number, or string slice as a YAML node.
+func valueNode(v any) *yaml.Node {
+	var n yaml.Node
+	_ = n.Encode(v) // Cannot fail for these types.
+	return &n
+}
+
+// Diff returns a unified diff from before to after with three lines of
+// context, labelled with path. It returns "" when the two are equal.
+func Diff(path string, before, after []byte) string {
+	a, b := splitLines(before), splitLines(after)
+
+	// Longest common subsequence, then walk it to emit edits in order.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	type edit struct {
+		op   byte // ' ', '-', '+'
+		text string
+		i, j int // 0-based line numbers in a and b before this edit
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', a[i], i, j})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	const context = 3
+	var out strings.Builder
+	for k := 0; k < len(edits); {
+		if edits[k].op == ' ' {
+			k++
+			continue
+		}
+		// Extend the hunk while changes are within 2*context of each other.
+		start := max(k-context, 0)
+		end := k
+		for n := k; n < len(edits); n++ {
+			if edits[n].op != ' ' {
+				end = n
+			} else if n-end > 2*context {
+				break
+			}
+		}
+		end = min(end+context+1, len(edits))
+
+		var aLen, bLen int
+		for _, e := range edits[start:end] {
+			if e.op != '+' {
+				aLen++
+			}
+			if e.op != '-' {
+				bLen++
+			}
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", path, path)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(edits[start].i, aLen), hunkRange(edits[start].j, bLen))
+		for _, e := range edits[start:end] {
+			out.WriteByte(e.op)
+			out.WriteString(e.text)
+			out.WriteByte('\n')
+		}
+		k = end
+	}
+	return out.String()
+}
+
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+func splitLines(data []byte) []string {
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const editFixture = `# Coverage policy for the service.
+version: 1
+policy:
+  default:
+    min: 70 # raise once the legacy code is gone
+  domains:
+    # Business rules
+    - name: core
+      match: ["./internal/core/..."]
+      min: 85
+    - name: legacy
+      match: ["./internal/legacy/..."]
+exclude:
+  - internal/generated/*
+`
+
+func ptr(v float64) *float64 { return &v }
+
+func TestEditPreservesCommentsAndOrder(t *testing.T) {
+	out, err := Edit([]byte(editFixture), []Change{
+		{Op: OpSetDefaultMin, Min: ptr(75)},
+		{Op: OpRemoveDomain, Domain: "legacy"},
+		{Op: OpAddDomain, Domain: "api", Match: []string{"./internal/api/..."}, Min: ptr(80)},
+		{Op: OpAddExclude, Pattern: "internal/mocks/*"},
+		{Op: OpAddExclude, Pattern: "internal/generated/*"},
+		{Op: OpAddExclude, Domain: "core", Pattern: "internal/core/fixtures/*"},
+	})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	got := string(out)
+
+	for _, want := range []string{
+		"# Coverage policy for the service.",
+		"min: 75 # raise once the legacy code is gone",
+		"# Business rules",
+		`match: ["./internal/core/..."]`,
+		"- name: api",
+		"- internal/mocks/*",
+		"- internal/core/fixtures/*",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in edited config:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "legacy/...") {
+		t.Errorf("expected legacy domain removed:\n%s", got)
+	}
+	if strings.Count(got, "internal/generated/*") != 1 {
+		t.Errorf("expected duplicate exclude to be skipped:\n%s", got)
+	}
+	if strings.Index(got, "version:") > strings.Index(got, "policy:") {
+		t.Errorf("expected key order preserved:\n%s", got)
+	}
+}
+
+func TestEditSetDomainMin(t *testing.T) {
+	out, err := Edit([]byte(editFixture), []Change{
+		{Op: OpSetDomainMin, Domain: "core", Min: ptr(87.5)},
+		{Op: OpSetDomainMin, Domain: "legacy", Min: ptr(72)},
+	})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{"# Business rules", "min: 87.5", "match: [\"./internal/legacy/...\"]\n      min: 72", "min: 70 # raise once"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in edited config:\n%s", want, got)
+		}
+	}
+	if _, err := Edit([]byte(editFixture), []Change{{Op: OpSetDomainMin, Domain: "api", Min: ptr(80)}}); !errors.Is(err, ErrDomainNotFound) {
+		t.Fatalf("expected ErrDomainNotFound, got %v", err)
+	}
+}
+
+func TestEditRejectsInvalidChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change Change
+		want   string
+	}{
+		{"unknown op", Change{Op: "rename"}, "unknown operation"},
+		{"min out of range", Change{Op: OpSetDefaultMin, Min: ptr(120)}, "between 0 and 100"},
+		{"missing min", Change{Op: OpSetDefaultMin}, "min is required"},
+		{"duplicate domain", Change{Op: OpAddDomain, Domain: "core", Match: []string{"./x/..."}}, "already exists"},
+		{"domain without match", Change{Op: OpAddDomain, Domain: "api"}, "match is required"},
+		{"remove missing domain", Change{Op: OpRemoveDomain, Domain: "nope"}, "not found"},
+		{"exclude on missing domain", Change{Op: OpAddExclude, Domain: "nope", Pattern: "x/*"}, "not found"},
+		{"min on missing domain", Change{Op: OpSetDomainMin, Domain: "nope", Min: ptr(80)}, "not found"},
+		{"domain min without min", Change{Op: OpSetDomainMin, Domain: "core"}, "min is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Edit([]byte(editFixture), []Change{tt.change})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestEditAddExcludeToExcludeMapping(t *testing.T) {
+	in := "version: 1\nexclude:\n  files: [\"gen/*\"]\n  functions: ['String\\(\\)$']\n"
+	out, err := Edit([]byte(in), []Change{{Op: OpAddExclude, Pattern: "mocks/*"}})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	var cfg fileConfig
+	if err := yaml.Unmarshal(out, &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(cfg.Exclude.Files) != 2 || cfg.Exclude.Files[1] != "mocks/*" || len(cfg.Exclude.Functions) != 1 {
+		t.Fatalf("unexpected exclude after edit: %+v\n%s", cfg.Exclude, out)
+	}
+}
+
+func TestEditEmptyConfig(t *testing.T) {
+	out, err := Edit(nil, []Change{{Op: OpAddDomain, Domain: "core", Match: []string{"./core/..."}}})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "version: 1\n") || !strings.Contains(string(out), "- name: core") {
+		t.Fatalf("unexpected config:\n%s", out)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	want := "--- a/cfg.yaml\n+++ b/cfg.yaml\n" +
+		"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n" +
+		"@@ -8,3 +8,4 @@\n h\n i\n j\n+k\n"
+	if got := Diff("cfg.yaml", []byte(before), []byte(after)); got != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+	if got := Diff("cfg.yaml", []byte(before), []byte(before)); got != "" {
+		t.Fatalf("expected empty diff, got %q", got)
+	}
+}
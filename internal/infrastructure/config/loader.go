@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -14,30 +16,103 @@ import (
 	"github.com/felixgeelhaar/coverctl/internal/pathutil"
 )
 
-type Loader struct{}
+// Loader reads .coverctl.yaml files. Stdin backs the "-" config path; when
+// it is nil, loading "-" fails.
+type Loader struct {
+	Stdin *Stdin
+}
 
 type fileConfig struct {
 	Version     int             `yaml:"version"`
 	Extends     string          `yaml:"extends,omitempty"`  // Path to parent config for inheritance
 	Language    string          `yaml:"language,omitempty"` // Project language (auto, go, python, etc.)
+	Runner      fileRunner      `yaml:"runner,omitempty"`   // Runner name that bypasses detection, and retries
+	CoverPkg    fileCoverPkg    `yaml:"coverpkg,omitempty"` // Packages left out of -coverpkg and domain totals
+	Matrix      []fileVariant   `yaml:"matrix,omitempty"`   // GOOS/GOARCH/tag combinations tested and merged
 	Profile     fileProfile     `yaml:"profile,omitempty"`  // Coverage profile settings
 	Policy      filePolicy      `yaml:"policy"`
-	Exclude     []string        `yaml:"exclude,omitempty"`
+	Exclude     fileExclude     `yaml:"exclude,omitempty"`
 	Files       []fileFileRule  `yaml:"files,omitempty"`
 	Diff        fileDiff        `yaml:"diff,omitempty"`
+	Functions   fileFunctions   `yaml:"functions,omitempty"` // Minimum share of functions with any coverage
 	Merge       fileMerge       `yaml:"merge,omitempty"`
 	Integration fileIntegration `yaml:"integration,omitempty"`
 	Annotations fileAnnotations `yaml:"annotations,omitempty"`
+	Notify      fileNotify      `yaml:"notify,omitempty"`
+	Events      fileEvents      `yaml:"events,omitempty"`
+	Exceptions  []fileException `yaml:"exceptions,omitempty"`
+	Goals       []fileGoal      `yaml:"goals,omitempty"`
+	History     fileHistory     `yaml:"history,omitempty"`
+}
+
+// fileExclude accepts either a list of file globs or a mapping with files
+// and functions, so existing configs keep their plain list form.
+type fileExclude struct {
+	Files     []string `yaml:"files,omitempty"`
+	Functions []string `yaml:"functions,omitempty"` // Regexps over "Func()" / "Type.Method()"
+}
+
+func (e *fileExclude) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		return value.Decode(&e.Files)
+	}
+	type plain fileExclude
+	return value.Decode((*plain)(e))
+}
+
+func (e fileExclude) MarshalYAML() (interface{}, error) {
+	if len(e.Functions) == 0 {
+		return e.Files, nil
+	}
+	type plain fileExclude
+	return plain(e), nil
+}
+
+// fileRunner accepts either a runner name or a mapping with the name and
+// retry settings, so existing configs keep their plain string form.
+type fileRunner struct {
+	Name       string `yaml:"name,omitempty"`
+	Retries    int    `yaml:"retries,omitempty"`     // Extra attempts after a failed test run
+	OnlyFailed bool   `yaml:"only_failed,omitempty"` // Go: retry only the failed packages
+}
+
+func (r *fileRunner) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&r.Name)
+	}
+	type plain fileRunner
+	return value.Decode((*plain)(r))
+}
+
+func (r fileRunner) MarshalYAML() (interface{}, error) {
+	if r.Retries == 0 && !r.OnlyFailed {
+		return r.Name, nil
+	}
+	type plain fileRunner
+	return plain(r), nil
 }
 
 type fileProfile struct {
-	Format string `yaml:"format,omitempty"` // Coverage format (auto, go, lcov, cobertura, jacoco)
-	Path   string `yaml:"path,omitempty"`   // Default profile path
+	Format string `yaml:"format,omitempty"`  // Coverage format (auto, go, lcov, cobertura, jacoco)
+	Path   string `yaml:"path,omitempty"`    // Default profile path
+	MaxAge string `yaml:"max_age,omitempty"` // Oldest a --from-profile profile may be (30m, 12h, 1d)
+	Stale  string `yaml:"stale,omitempty"`   // fail or warn when a profile is too old
 }
 
 type filePolicy struct {
-	Default fileDefault  `yaml:"default"`
-	Domains []fileDomain `yaml:"domains"`
+	Default      fileDefault  `yaml:"default"`
+	Domains      []fileDomain `yaml:"domains"`
+	NewCodeSince string       `yaml:"new_code_since,omitempty"` // Age window for new code (90d, 12w)
+	NewCodeMin   *float64     `yaml:"new_code_min,omitempty"`   // Minimum coverage of new code
+	NewDomain    string       `yaml:"new_domain,omitempty"`     // warn, current, or default for domains without history
+	Groups       []fileGroup  `yaml:"groups,omitempty"`         // Minimums for domain groups
+	Metric       string       `yaml:"metric,omitempty"`         // statements, lines, or branches
+	Rounding     string       `yaml:"rounding,omitempty"`       // round, floor, or exact
+}
+
+type fileGroup struct {
+	Name string   `yaml:"name"`
+	Min  *float64 `yaml:"min,omitempty"`
 }
 
 type fileDefault struct {
@@ -45,11 +120,17 @@ type fileDefault struct {
 }
 
 type fileDomain struct {
-	Name    string   `yaml:"name"`
-	Match   []string `yaml:"match"`
-	Min     *float64 `yaml:"min"`
-	Warn    *float64 `yaml:"warn,omitempty"`
-	Exclude []string `yaml:"exclude,omitempty"`
+	Name        string   `yaml:"name"`
+	Match       []string `yaml:"match"`
+	Min         *float64 `yaml:"min"`
+	Warn        *float64 `yaml:"warn,omitempty"`
+	Exclude     []string `yaml:"exclude,omitempty"`
+	Group       string   `yaml:"group,omitempty"`
+	Owners      []string `yaml:"owners,omitempty"`
+	Weight      *float64 `yaml:"weight,omitempty"` // Weight of the domain's statements in overall coverage (default 1)
+	Metric      string   `yaml:"metric,omitempty"` // Overrides policy.metric for the domain
+	TestArgs    []string `yaml:"test_args,omitempty"`
+	TestCommand []string `yaml:"test_command,omitempty"`
 }
 
 type fileFileRule struct {
@@ -58,12 +139,35 @@ type fileFileRule struct {
 }
 
 type fileDiff struct {
-	Enabled bool   `yaml:"enabled"`
-	Base    string `yaml:"base,omitempty"`
+	Enabled           bool     `yaml:"enabled"`
+	Base              string   `yaml:"base,omitempty"`
+	Min               *float64 `yaml:"min,omitempty"`                 // Minimum coverage of changed lines
+	MaxUncoveredLines *int     `yaml:"max_uncovered_lines,omitempty"` // Budget of uncovered changed lines
+	FilesFrom         string   `yaml:"files_from,omitempty"`          // Changed-files list ("-" for stdin) instead of git
+}
+
+type fileCoverPkg struct {
+	Exclude     []string `yaml:"exclude,omitempty"`      // Package patterns, e.g. ./internal/testutil/...
+	AutoExclude *bool    `yaml:"auto_exclude,omitempty"` // Leave out testutil/testhelpers and test-only packages (default true)
+	Isolate     bool     `yaml:"isolate,omitempty"`      // Test each domain alone with -coverpkg limited to it
+}
+
+type fileVariant struct {
+	Name   string `yaml:"name"`
+	GOOS   string `yaml:"goos,omitempty"`
+	GOARCH string `yaml:"goarch,omitempty"`
+	Tags   string `yaml:"tags,omitempty"` // Comma-separated build tags added to the run's
 }
 
 type fileMerge struct {
-	Profiles []string `yaml:"profiles,omitempty"`
+	Profiles        []string          `yaml:"profiles,omitempty"`
+	PathMappings    []filePathMapping `yaml:"path_mappings,omitempty"`
+	ResolveSymlinks *bool             `yaml:"resolve_symlinks,omitempty"` // Follow symlinks when matching files to domains (default true)
+}
+
+type filePathMapping struct {
+	From string `yaml:"from"` // Path prefix as it appears in the profile
+	To   string `yaml:"to"`   // Local prefix that replaces it
 }
 
 type fileIntegration struct {
@@ -78,7 +182,46 @@ type fileAnnotations struct {
 	Enabled bool `yaml:"enabled"`
 }
 
+type fileFunctions struct {
+	Min *float64 `yaml:"min,omitempty"`
+}
+
+type fileHistory struct {
+	TrackFiles bool `yaml:"track_files,omitempty"` // Record per-file coverage in each history entry
+}
+
+type fileEvents struct {
+	File    string `yaml:"file,omitempty"`    // JSON Lines file events are appended to
+	Webhook string `yaml:"webhook,omitempty"` // May reference ${ENV_VAR}; expanded when sending
+}
+
+type fileException struct {
+	Domain   string `yaml:"domain,omitempty"` // Domain exempted from its minimum
+	File     string `yaml:"file,omitempty"`   // File rule path or glob exempted from its minimum
+	Reason   string `yaml:"reason"`
+	Approver string `yaml:"approver"`
+	Expires  string `yaml:"expires"` // Last day the exception applies (YYYY-MM-DD)
+}
+
+type fileGoal struct {
+	Domain string  `yaml:"domain"`
+	Target float64 `yaml:"target"`
+	By     string  `yaml:"by"`             // YYYY-MM-DD or a quarter such as 2026-Q3
+	Warn   bool    `yaml:"warn,omitempty"` // Warn in check output while off track
+}
+
+type fileNotify struct {
+	Webhook    string            `yaml:"webhook,omitempty"`    // May reference ${ENV_VAR}; expanded when sending
+	Format     string            `yaml:"format,omitempty"`     // slack (default) or json
+	Regression *float64          `yaml:"regression,omitempty"` // Drop in points that triggers a notification
+	OnFailure  bool              `yaml:"on_failure,omitempty"` // Notify when any domain fails
+	Owners     map[string]string `yaml:"owners,omitempty"`     // Webhook per domain owner
+}
+
 func (l Loader) Exists(path string) (bool, error) {
+	if path == StdinPath {
+		return true, nil
+	}
 	_, err := os.Stat(path)
 	if err == nil {
 		return true, nil
@@ -139,43 +282,33 @@ func FindConfigFrom(startDir string) (string, error) {
 }
 
 func (l Loader) Load(path string) (application.Config, error) {
-	return l.loadWithCycleCheck(path, make(map[string]struct{}))
+	cfg, err := l.loadWithCycleCheck(path, make(map[string]struct{}))
+	if err != nil {
+		return application.Config{}, err
+	}
+	// The config has already drained stdin, so the list would always be
+	// empty and a diff gate would pass with nothing to check.
+	if path == StdinPath && cfg.Diff.FilesFrom == StdinPath {
+		return application.Config{}, application.WithErrorCode(application.ErrCodeConfigInvalid,
+			fmt.Errorf("diff.files_from %q cannot be used with a config read from stdin; both would read standard input", StdinPath))
+	}
+	return cfg, nil
 }
 
 // loadWithCycleCheck loads a config file, recursively loading parent configs
 // and merging them. visited tracks already-loaded configs to detect cycles.
 func (l Loader) loadWithCycleCheck(path string, visited map[string]struct{}) (application.Config, error) {
-	cleanPath, err := pathutil.ValidatePath(path)
-	if err != nil {
-		return application.Config{}, fmt.Errorf("invalid path: %w", err)
-	}
-
-	// Convert to absolute path for cycle detection
-	absPath, err := filepath.Abs(cleanPath)
-	if err != nil {
-		return application.Config{}, fmt.Errorf("resolving path: %w", err)
-	}
-
-	// Check for circular reference
-	if _, ok := visited[absPath]; ok {
-		return application.Config{}, fmt.Errorf("circular config inheritance detected: %s", absPath)
-	}
-	visited[absPath] = struct{}{}
-
-	raw, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	raw, absPath, err := l.read(path, visited)
 	if err != nil {
 		return application.Config{}, err
 	}
 
 	var cfg fileConfig
 	if err := yaml.Unmarshal(raw, &cfg); err != nil {
-		return application.Config{}, err
+		return application.Config{}, application.WithErrorCode(application.ErrCodeConfigInvalid, err)
 	}
-	if cfg.Version == 0 {
-		cfg.Version = 1
-	}
-	if cfg.Version != 1 {
-		return application.Config{}, fmt.Errorf("unsupported config version: %d", cfg.Version)
+	if err := validateFileConfig(&cfg); err != nil {
+		return application.Config{}, application.WithErrorCode(application.ErrCodeConfigInvalid, err)
 	}
 
 	// Handle config inheritance
@@ -218,20 +351,231 @@ func (l Loader) loadWithCycleCheck(path string, visited map[string]struct{}) (ap
 	return childCfg, nil
 }
 
+// read returns the raw config at path and the absolute path its extends is
+// resolved against. The "-" path reads standard input and resolves extends
+// from the working directory.
+func (l Loader) read(path string, visited map[string]struct{}) ([]byte, string, error) {
+	if path == StdinPath {
+		if _, ok := visited[StdinPath]; ok {
+			return nil, "", errors.New("circular config inheritance detected: stdin")
+		}
+		visited[StdinPath] = struct{}{}
+		raw, err := l.Stdin.read()
+		if err != nil {
+			return nil, "", err
+		}
+		absPath, err := filepath.Abs(StdinPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("resolving path: %w", err)
+		}
+		return raw, absPath, nil
+	}
+
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid path: %w", err)
+	}
+
+	// Convert to absolute path for cycle detection
+	absPath, err := filepath.Abs(cleanPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolving path: %w", err)
+	}
+
+	// Check for circular reference
+	if _, ok := visited[absPath]; ok {
+		return nil, "", fmt.Errorf("circular config inheritance detected: %s", absPath)
+	}
+	visited[absPath] = struct{}{}
+
+	raw, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, "", err
+	}
+	return raw, absPath, nil
+}
+
+// validateFileConfig defaults a missing version to 1 and rejects settings
+// this version of coverctl cannot honour.
+func validateFileConfig(cfg *fileConfig) error {
+	if cfg.Version == 0 {
+		cfg.Version = 1
+	}
+	if cfg.Version != 1 {
+		return fmt.Errorf("unsupported config version: %d", cfg.Version)
+	}
+	switch application.NotifyFormat(cfg.Notify.Format) {
+	case "", application.NotifySlack, application.NotifyJSON:
+	default:
+		return fmt.Errorf("unsupported notify format: %s", cfg.Notify.Format)
+	}
+	if !domain.NewDomainPolicy(cfg.Policy.NewDomain).Valid() {
+		return fmt.Errorf("unsupported policy.new_domain: %s (want warn, current, or default)", cfg.Policy.NewDomain)
+	}
+	if cfg.Profile.MaxAge != "" {
+		if _, err := domain.ParseAge(cfg.Profile.MaxAge); err != nil {
+			return fmt.Errorf("profile.max_age: %w", err)
+		}
+	}
+	switch application.StaleProfileAction(cfg.Profile.Stale) {
+	case "", application.StaleProfileFail, application.StaleProfileWarn:
+	default:
+		return fmt.Errorf("unsupported profile.stale: %s (want fail or warn)", cfg.Profile.Stale)
+	}
+	if cfg.Policy.NewCodeSince != "" {
+		if _, err := domain.ParseAge(cfg.Policy.NewCodeSince); err != nil {
+			return fmt.Errorf("policy.new_code_since: %w", err)
+		}
+		if cfg.Policy.NewCodeMin == nil {
+			return errors.New("policy.new_code_min is required with policy.new_code_since")
+		}
+	}
+	if cfg.Runner.Retries < 0 {
+		return fmt.Errorf("runner.retries must not be negative: %d", cfg.Runner.Retries)
+	}
+	if cfg.Functions.Min != nil {
+		if _, err := domain.NewThreshold(*cfg.Functions.Min); err != nil {
+			return fmt.Errorf("functions.min: %w", err)
+		}
+	}
+	if cfg.Diff.MaxUncoveredLines != nil && *cfg.Diff.MaxUncoveredLines < 0 {
+		return fmt.Errorf("diff.max_uncovered_lines must not be negative: %d", *cfg.Diff.MaxUncoveredLines)
+	}
+	for i, m := range cfg.Merge.PathMappings {
+		if m.From == "" {
+			return fmt.Errorf("merge.path_mappings[%d]: from is required", i)
+		}
+	}
+	for _, pattern := range cfg.Exclude.Functions {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("exclude.functions %q: %w", pattern, err)
+		}
+	}
+	if _, err := domain.ParseMetric(cfg.Policy.Metric); err != nil {
+		return fmt.Errorf("policy.metric: %w", err)
+	}
+	if !domain.Rounding(cfg.Policy.Rounding).Valid() {
+		return fmt.Errorf("unsupported policy.rounding: %s (want round, floor, or exact)", cfg.Policy.Rounding)
+	}
+	for _, d := range cfg.Policy.Domains {
+		if len(d.TestArgs) > 0 && len(d.TestCommand) > 0 {
+			return fmt.Errorf("domain %s: test_args and test_command are mutually exclusive", d.Name)
+		}
+		if d.Weight != nil && *d.Weight < 0 {
+			return fmt.Errorf("domain %s: weight must not be negative: %g", d.Name, *d.Weight)
+		}
+		if _, err := domain.ParseMetric(d.Metric); err != nil {
+			return fmt.Errorf("domain %s: %w", d.Name, err)
+		}
+	}
+	seen := make(map[string]bool, len(cfg.Policy.Groups))
+	for i, g := range cfg.Policy.Groups {
+		switch {
+		case g.Name == "":
+			return fmt.Errorf("policy.groups[%d]: name is required", i)
+		case seen[g.Name]:
+			return fmt.Errorf("policy.groups: group %s is listed twice", g.Name)
+		}
+		seen[g.Name] = true
+		if g.Min != nil {
+			if _, err := domain.NewThreshold(*g.Min); err != nil {
+				return fmt.Errorf("group %s: %w", g.Name, err)
+			}
+		}
+	}
+	if err := validateExceptions(cfg.Exceptions); err != nil {
+		return err
+	}
+	if err := validateMatrix(cfg.Matrix); err != nil {
+		return err
+	}
+	return validateGoals(cfg.Goals)
+}
+
+// validateMatrix requires every build variant to have a unique name.
+func validateMatrix(matrix []fileVariant) error {
+	seen := make(map[string]bool, len(matrix))
+	for i, v := range matrix {
+		switch {
+		case v.Name == "":
+			return fmt.Errorf("matrix[%d]: name is required", i)
+		case seen[v.Name]:
+			return fmt.Errorf("matrix: variant %s is listed twice", v.Name)
+		}
+		seen[v.Name] = true
+	}
+	return nil
+}
+
+// validateExceptions requires every exception to name exactly one target
+// and to record why, who approved it, and when it ends.
+func validateExceptions(exceptions []fileException) error {
+	for i, e := range exceptions {
+		switch {
+		case (e.Domain == "") == (e.File == ""):
+			return fmt.Errorf("exceptions[%d]: exactly one of domain or file is required", i)
+		case e.Reason == "":
+			return fmt.Errorf("exceptions[%d]: reason is required", i)
+		case e.Approver == "":
+			return fmt.Errorf("exceptions[%d]: approver is required", i)
+		case e.Expires == "":
+			return fmt.Errorf("exceptions[%d]: expires is required", i)
+		}
+		if _, err := time.Parse(domain.ExceptionDateLayout, e.Expires); err != nil {
+			return fmt.Errorf("exceptions[%d]: expires %q is not a YYYY-MM-DD date", i, e.Expires)
+		}
+		if e.File != "" {
+			if _, err := filepath.Match(e.File, ""); err != nil {
+				return fmt.Errorf("exceptions[%d]: file %q: %w", i, e.File, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateGoals requires every goal to name a domain, a valid target, and
+// a date or quarter to reach it by.
+func validateGoals(goals []fileGoal) error {
+	for i, g := range goals {
+		if g.Domain == "" {
+			return fmt.Errorf("goals[%d]: domain is required", i)
+		}
+		if _, err := domain.NewThreshold(g.Target); err != nil {
+			return fmt.Errorf("goals[%d]: %w", i, err)
+		}
+		if _, err := goalFromFile(g).Deadline(); err != nil {
+			return fmt.Errorf("goals[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // buildAppConfig converts a fileConfig to an application.Config
 func buildAppConfig(cfg fileConfig) application.Config {
 	policy := domain.Policy{
 		DefaultMin: cfg.Policy.Default.Min,
 		Domains:    make([]domain.Domain, 0, len(cfg.Policy.Domains)),
+		NewDomain:  domain.NewDomainPolicy(cfg.Policy.NewDomain),
+		Metric:     domain.Metric(cfg.Policy.Metric),
+		Rounding:   domain.Rounding(cfg.Policy.Rounding),
+	}
+	for _, g := range cfg.Policy.Groups {
+		policy.Groups = append(policy.Groups, domain.GroupPolicy{Name: g.Name, Min: g.Min})
 	}
 
 	for _, d := range cfg.Policy.Domains {
 		policy.Domains = append(policy.Domains, domain.Domain{
-			Name:    d.Name,
-			Match:   d.Match,
-			Min:     d.Min,
-			Warn:    d.Warn,
-			Exclude: append([]string(nil), d.Exclude...),
+			Name:        d.Name,
+			Match:       d.Match,
+			Min:         d.Min,
+			Warn:        d.Warn,
+			Exclude:     append([]string(nil), d.Exclude...),
+			Group:       d.Group,
+			Owners:      append([]string(nil), d.Owners...),
+			Weight:      d.Weight,
+			Metric:      domain.Metric(d.Metric),
+			TestArgs:    append([]string(nil), d.TestArgs...),
+			TestCommand: append([]string(nil), d.TestCommand...),
 		})
 	}
 
@@ -246,19 +590,37 @@ func buildAppConfig(cfg fileConfig) application.Config {
 	return application.Config{
 		Version:  cfg.Version,
 		Language: application.Language(cfg.Language),
+		Runner:   cfg.Runner.Name,
+		Retry:    application.RetryConfig{Retries: cfg.Runner.Retries, OnlyFailed: cfg.Runner.OnlyFailed},
+		CoverPkg: application.CoverPkgConfig{
+			Exclude:     append([]string(nil), cfg.CoverPkg.Exclude...),
+			AutoExclude: cfg.CoverPkg.AutoExclude,
+			Isolate:     cfg.CoverPkg.Isolate,
+		},
+		Matrix: matrixFromFile(cfg.Matrix),
 		Profile: application.ProfileConfig{
 			Format: application.Format(cfg.Profile.Format),
 			Path:   cfg.Profile.Path,
+			MaxAge: cfg.Profile.MaxAge,
+			Stale:  application.StaleProfileAction(cfg.Profile.Stale),
 		},
-		Policy:  policy,
-		Exclude: cfg.Exclude,
-		Files:   fileRules,
+		Policy:           policy,
+		Exclude:          cfg.Exclude.Files,
+		ExcludeFunctions: append([]string(nil), cfg.Exclude.Functions...),
+		Files:            fileRules,
 		Diff: application.DiffConfig{
-			Enabled: cfg.Diff.Enabled,
-			Base:    cfg.Diff.Base,
+			Enabled:           cfg.Diff.Enabled,
+			Base:              cfg.Diff.Base,
+			Min:               cfg.Diff.Min,
+			MaxUncoveredLines: cfg.Diff.MaxUncoveredLines,
+			FilesFrom:         cfg.Diff.FilesFrom,
 		},
+		NewCode:   newCodeFromFile(cfg.Policy),
+		Functions: application.FunctionsConfig{Min: cfg.Functions.Min},
 		Merge: application.MergeConfig{
-			Profiles: append([]string(nil), cfg.Merge.Profiles...),
+			Profiles:        append([]string(nil), cfg.Merge.Profiles...),
+			PathMappings:    pathMappingsFromFile(cfg.Merge.PathMappings),
+			ResolveSymlinks: cfg.Merge.ResolveSymlinks,
 		},
 		Integration: application.IntegrationConfig{
 			Enabled:  cfg.Integration.Enabled,
@@ -270,7 +632,117 @@ func buildAppConfig(cfg fileConfig) application.Config {
 		Annotations: application.AnnotationsConfig{
 			Enabled: cfg.Annotations.Enabled,
 		},
+		Notify: application.NotifyConfig{
+			Webhook:    cfg.Notify.Webhook,
+			Format:     application.NotifyFormat(cfg.Notify.Format),
+			Regression: cfg.Notify.Regression,
+			OnFailure:  cfg.Notify.OnFailure,
+			Owners:     cfg.Notify.Owners,
+		},
+		Events:     application.EventsConfig{File: cfg.Events.File, Webhook: cfg.Events.Webhook},
+		Exceptions: exceptionsFromFile(cfg.Exceptions),
+		Goals:      goalsFromFile(cfg.Goals),
+		History:    application.HistoryConfig{TrackFiles: cfg.History.TrackFiles},
+	}
+}
+
+func newCodeFromFile(p filePolicy) application.NewCodeConfig {
+	if p.NewCodeSince == "" || p.NewCodeMin == nil {
+		return application.NewCodeConfig{}
+	}
+	return application.NewCodeConfig{Since: p.NewCodeSince, Min: *p.NewCodeMin}
+}
+
+func exceptionsFromFile(in []fileException) []domain.PolicyException {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]domain.PolicyException, 0, len(in))
+	for _, e := range in {
+		out = append(out, domain.PolicyException{Domain: e.Domain, File: e.File, Reason: e.Reason, Approver: e.Approver, Expires: e.Expires})
+	}
+	return out
+}
+
+func exceptionsToFile(in []domain.PolicyException) []fileException {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]fileException, 0, len(in))
+	for _, e := range in {
+		out = append(out, fileException{Domain: e.Domain, File: e.File, Reason: e.Reason, Approver: e.Approver, Expires: e.Expires})
+	}
+	return out
+}
+
+func goalFromFile(g fileGoal) domain.Goal {
+	return domain.Goal{Domain: g.Domain, Target: g.Target, By: g.By, Warn: g.Warn}
+}
+
+func goalsFromFile(in []fileGoal) []domain.Goal {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]domain.Goal, 0, len(in))
+	for _, g := range in {
+		out = append(out, goalFromFile(g))
+	}
+	return out
+}
+
+func goalsToFile(in []domain.Goal) []fileGoal {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]fileGoal, 0, len(in))
+	for _, g := range in {
+		out = append(out, fileGoal{Domain: g.Domain, Target: g.Target, By: g.By, Warn: g.Warn})
+	}
+	return out
+}
+
+func matrixFromFile(in []fileVariant) []application.BuildVariant {
+	if len(in) == 0 {
+		return nil
 	}
+	out := make([]application.BuildVariant, 0, len(in))
+	for _, v := range in {
+		out = append(out, application.BuildVariant{Name: v.Name, GOOS: v.GOOS, GOARCH: v.GOARCH, Tags: v.Tags})
+	}
+	return out
+}
+
+func matrixToFile(in []application.BuildVariant) []fileVariant {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]fileVariant, 0, len(in))
+	for _, v := range in {
+		out = append(out, fileVariant{Name: v.Name, GOOS: v.GOOS, GOARCH: v.GOARCH, Tags: v.Tags})
+	}
+	return out
+}
+
+func pathMappingsFromFile(in []filePathMapping) []application.PathMapping {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]application.PathMapping, 0, len(in))
+	for _, m := range in {
+		out = append(out, application.PathMapping{From: m.From, To: m.To})
+	}
+	return out
+}
+
+func pathMappingsToFile(in []application.PathMapping) []filePathMapping {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]filePathMapping, 0, len(in))
+	for _, m := range in {
+		out = append(out, filePathMapping{From: m.From, To: m.To})
+	}
+	return out
 }
 
 // mergeConfigs merges child config onto parent config.
@@ -288,6 +760,14 @@ func mergeConfigs(parent, child application.Config) application.Config {
 		result.Language = child.Language
 	}
 
+	// Runner: use child if set
+	if child.Runner != "" {
+		result.Runner = child.Runner
+	}
+	if child.Retry.Retries != 0 {
+		result.Retry = child.Retry
+	}
+
 	// Profile: use child values if set
 	if child.Profile.Format != "" {
 		result.Profile.Format = child.Profile.Format
@@ -295,12 +775,38 @@ func mergeConfigs(parent, child application.Config) application.Config {
 	if child.Profile.Path != "" {
 		result.Profile.Path = child.Profile.Path
 	}
+	if child.Profile.MaxAge != "" {
+		result.Profile.MaxAge = child.Profile.MaxAge
+	}
+	if child.Profile.Stale != "" {
+		result.Profile.Stale = child.Profile.Stale
+	}
 
 	// DefaultMin: use child if set (non-zero)
 	if child.Policy.DefaultMin != 0 {
 		result.Policy.DefaultMin = child.Policy.DefaultMin
 	}
 
+	// Groups: child group minimums replace the parent's
+	if len(child.Policy.Groups) > 0 {
+		result.Policy.Groups = child.Policy.Groups
+	}
+
+	// New domain policy: use child if set
+	if child.Policy.NewDomain != "" {
+		result.Policy.NewDomain = child.Policy.NewDomain
+	}
+
+	// Metric: use child if set
+	if child.Policy.Metric != "" {
+		result.Policy.Metric = child.Policy.Metric
+	}
+
+	// Rounding: use child if set
+	if child.Policy.Rounding != "" {
+		result.Policy.Rounding = child.Policy.Rounding
+	}
+
 	// Domains: child overrides parent domains with same name, adds new ones
 	if len(child.Policy.Domains) > 0 {
 		domainMap := make(map[string]domain.Domain)
@@ -335,6 +841,9 @@ func mergeConfigs(parent, child application.Config) application.Config {
 	if len(child.Exclude) > 0 {
 		result.Exclude = append(result.Exclude, child.Exclude...)
 	}
+	if len(child.ExcludeFunctions) > 0 {
+		result.ExcludeFunctions = append(result.ExcludeFunctions, child.ExcludeFunctions...)
+	}
 
 	// Files: child file rules override parent (complete replacement)
 	if len(child.Files) > 0 {
@@ -346,11 +855,46 @@ func mergeConfigs(parent, child application.Config) application.Config {
 		result.Diff = child.Diff
 	}
 
+	// New code threshold: child overrides if it sets an age window
+	if child.NewCode.Since != "" {
+		result.NewCode = child.NewCode
+	}
+
+	// Functions threshold: child overrides if set
+	if child.Functions.Min != nil {
+		result.Functions = child.Functions
+	}
+
 	// Merge profiles: append child profiles
 	if len(child.Merge.Profiles) > 0 {
 		result.Merge.Profiles = append(result.Merge.Profiles, child.Merge.Profiles...)
 	}
 
+	// Path mappings: child mappings come first so they win over the parent's
+	if len(child.Merge.PathMappings) > 0 {
+		result.Merge.PathMappings = append(append([]application.PathMapping(nil), child.Merge.PathMappings...), result.Merge.PathMappings...)
+	}
+
+	if child.Merge.ResolveSymlinks != nil {
+		result.Merge.ResolveSymlinks = child.Merge.ResolveSymlinks
+	}
+
+	// Coverpkg excludes: append child patterns
+	if len(child.CoverPkg.Exclude) > 0 {
+		result.CoverPkg.Exclude = append(append([]string(nil), result.CoverPkg.Exclude...), child.CoverPkg.Exclude...)
+	}
+	if child.CoverPkg.AutoExclude != nil {
+		result.CoverPkg.AutoExclude = child.CoverPkg.AutoExclude
+	}
+	if child.CoverPkg.Isolate {
+		result.CoverPkg.Isolate = true
+	}
+
+	// Matrix: child variants replace the parent's
+	if len(child.Matrix) > 0 {
+		result.Matrix = append([]application.BuildVariant(nil), child.Matrix...)
+	}
+
 	// Integration: child overrides if enabled
 	if child.Integration.Enabled {
 		result.Integration = child.Integration
@@ -361,6 +905,31 @@ func mergeConfigs(parent, child application.Config) application.Config {
 		result.Annotations = child.Annotations
 	}
 
+	// Notify: child overrides if it names a webhook
+	if child.Notify.Webhook != "" || len(child.Notify.Owners) > 0 {
+		result.Notify = child.Notify
+	}
+
+	// Events: child overrides if it names a sink
+	if child.Events.File != "" || child.Events.Webhook != "" {
+		result.Events = child.Events
+	}
+
+	// History: child enables file tracking
+	if child.History.TrackFiles {
+		result.History = child.History
+	}
+
+	// Exceptions: append child exceptions to the parent's
+	if len(child.Exceptions) > 0 {
+		result.Exceptions = append(append([]domain.PolicyException(nil), result.Exceptions...), child.Exceptions...)
+	}
+
+	// Goals: append child goals to the parent's
+	if len(child.Goals) > 0 {
+		result.Goals = append(append([]domain.Goal(nil), result.Goals...), child.Goals...)
+	}
+
 	return result
 }
 
@@ -372,22 +941,43 @@ func Write(w io.Writer, cfg application.Config) error {
 	out := fileConfig{
 		Version:  version,
 		Language: string(cfg.Language),
+		Runner:   fileRunner{Name: cfg.Runner, Retries: cfg.Retry.Retries, OnlyFailed: cfg.Retry.OnlyFailed},
+		CoverPkg: fileCoverPkg{
+			Exclude:     append([]string(nil), cfg.CoverPkg.Exclude...),
+			AutoExclude: cfg.CoverPkg.AutoExclude,
+			Isolate:     cfg.CoverPkg.Isolate,
+		},
+		Matrix: matrixToFile(cfg.Matrix),
 		Profile: fileProfile{
 			Format: string(cfg.Profile.Format),
 			Path:   cfg.Profile.Path,
+			MaxAge: cfg.Profile.MaxAge,
+			Stale:  string(cfg.Profile.Stale),
 		},
 		Policy: filePolicy{
-			Default: fileDefault{Min: cfg.Policy.DefaultMin},
-			Domains: make([]fileDomain, 0, len(cfg.Policy.Domains)),
+			Default:   fileDefault{Min: cfg.Policy.DefaultMin},
+			Domains:   make([]fileDomain, 0, len(cfg.Policy.Domains)),
+			NewDomain: string(cfg.Policy.NewDomain),
+			Metric:    string(cfg.Policy.Metric),
+			Rounding:  string(cfg.Policy.Rounding),
+		},
+		Exclude: fileExclude{
+			Files:     cfg.Exclude,
+			Functions: append([]string(nil), cfg.ExcludeFunctions...),
 		},
-		Exclude: cfg.Exclude,
-		Files:   make([]fileFileRule, 0, len(cfg.Files)),
+		Files: make([]fileFileRule, 0, len(cfg.Files)),
 		Diff: fileDiff{
-			Enabled: cfg.Diff.Enabled,
-			Base:    cfg.Diff.Base,
+			Enabled:           cfg.Diff.Enabled,
+			Base:              cfg.Diff.Base,
+			Min:               cfg.Diff.Min,
+			MaxUncoveredLines: cfg.Diff.MaxUncoveredLines,
+			FilesFrom:         cfg.Diff.FilesFrom,
 		},
+		Functions: fileFunctions{Min: cfg.Functions.Min},
 		Merge: fileMerge{
-			Profiles: append([]string(nil), cfg.Merge.Profiles...),
+			Profiles:        append([]string(nil), cfg.Merge.Profiles...),
+			PathMappings:    pathMappingsToFile(cfg.Merge.PathMappings),
+			ResolveSymlinks: cfg.Merge.ResolveSymlinks,
 		},
 		Integration: fileIntegration{
 			Enabled:  cfg.Integration.Enabled,
@@ -397,14 +987,38 @@ func Write(w io.Writer, cfg application.Config) error {
 			Profile:  cfg.Integration.Profile,
 		},
 		Annotations: fileAnnotations{Enabled: cfg.Annotations.Enabled},
+		Notify: fileNotify{
+			Webhook:    cfg.Notify.Webhook,
+			Format:     string(cfg.Notify.Format),
+			Regression: cfg.Notify.Regression,
+			OnFailure:  cfg.Notify.OnFailure,
+			Owners:     cfg.Notify.Owners,
+		},
+		Events:     fileEvents{File: cfg.Events.File, Webhook: cfg.Events.Webhook},
+		Exceptions: exceptionsToFile(cfg.Exceptions),
+		Goals:      goalsToFile(cfg.Goals),
+		History:    fileHistory{TrackFiles: cfg.History.TrackFiles},
+	}
+	for _, g := range cfg.Policy.Groups {
+		out.Policy.Groups = append(out.Policy.Groups, fileGroup{Name: g.Name, Min: g.Min})
+	}
+	if cfg.NewCode.Since != "" {
+		out.Policy.NewCodeSince = cfg.NewCode.Since
+		out.Policy.NewCodeMin = &cfg.NewCode.Min
 	}
 	for _, d := range cfg.Policy.Domains {
 		out.Policy.Domains = append(out.Policy.Domains, fileDomain{
-			Name:    d.Name,
-			Match:   d.Match,
-			Min:     d.Min,
-			Warn:    d.Warn,
-			Exclude: append([]string(nil), d.Exclude...),
+			Name:        d.Name,
+			Match:       d.Match,
+			Min:         d.Min,
+			Warn:        d.Warn,
+			Exclude:     append([]string(nil), d.Exclude...),
+			Group:       d.Group,
+			Owners:      append([]string(nil), d.Owners...),
+			Weight:      d.Weight,
+			Metric:      string(d.Metric),
+			TestArgs:    append([]string(nil), d.TestArgs...),
+			TestCommand: append([]string(nil), d.TestCommand...),
 		})
 	}
 	for _, rule := range cfg.Files {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -96,9 +97,13 @@ func TestLoadInvalidYAML(t *testing.T) {
 	if err := os.WriteFile(path, []byte(":bad"), 0o644); err != nil {
 		t.Fatalf("write: %v", err)
 	}
-	if _, err := (Loader{}).Load(path); err == nil {
+	_, err := (Loader{}).Load(path)
+	if err == nil {
 		t.Fatalf("expected error")
 	}
+	if code := application.ErrorCodeOf(err); code != application.ErrCodeConfigInvalid {
+		t.Fatalf("expected %s, got %q", application.ErrCodeConfigInvalid, code)
+	}
 }
 
 func TestLoadUnsupportedVersion(t *testing.T) {
@@ -167,6 +172,152 @@ func TestLoadDiffBaseExplicit(t *testing.T) {
 	}
 }
 
+func TestLoadDiffMin(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\ndiff:\n  enabled: true\n  min: 90\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Diff.Min == nil || *cfg.Diff.Min != 90 {
+		t.Fatalf("expected diff.min 90, got %v", cfg.Diff.Min)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "min: 90") {
+		t.Fatalf("expected diff.min to round-trip, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadDiffMaxUncoveredLines(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\ndiff:\n  enabled: true\n  max_uncovered_lines: 20\n"
+	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Diff.MaxUncoveredLines == nil || *cfg.Diff.MaxUncoveredLines != 20 {
+		t.Fatalf("expected diff.max_uncovered_lines 20, got %v", cfg.Diff.MaxUncoveredLines)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "max_uncovered_lines: 20") {
+		t.Fatalf("expected diff.max_uncovered_lines to round-trip, got:\n%s", buf.String())
+	}
+
+	content = strings.Replace(content, "20", "-1", 1)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil {
+		t.Fatal("expected negative budget to be rejected")
+	}
+}
+
+func TestLoadDiffFilesFrom(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\ndiff:\n  enabled: true\n  files_from: changed.txt\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Diff.FilesFrom != "changed.txt" {
+		t.Fatalf("expected diff.files_from changed.txt, got %q", cfg.Diff.FilesFrom)
+	}
+}
+
+func TestLoadNotify(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\nnotify:\n  webhook: ${SLACK_WEBHOOK_URL}\n  regression: 1\n  on_failure: true\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Notify.Webhook != "${SLACK_WEBHOOK_URL}" {
+		t.Fatalf("expected webhook kept unexpanded, got %q", cfg.Notify.Webhook)
+	}
+	if cfg.Notify.Regression == nil || *cfg.Notify.Regression != 1 || !cfg.Notify.OnFailure {
+		t.Fatalf("unexpected notify config: %+v", cfg.Notify)
+	}
+
+	bad := "version: 1\npolicy:\n  default:\n    min: 75\nnotify:\n  webhook: https://example.com\n  format: teams\n"
+	if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil {
+		t.Fatal("expected error for unsupported notify format")
+	}
+}
+
+func TestLoadHistoryTrackFiles(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\nhistory:\n  track_files: true\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !cfg.History.TrackFiles {
+		t.Fatal("expected history.track_files enabled")
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if !strings.Contains(buf.String(), "track_files: true") {
+		t.Fatalf("expected track_files written, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadEvents(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\nevents:\n  file: .cover/events.jsonl\n  webhook: ${EVENTS_URL}\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Events.File != ".cover/events.jsonl" || cfg.Events.Webhook != "${EVENTS_URL}" {
+		t.Fatalf("unexpected events config %+v", cfg.Events)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if !strings.Contains(buf.String(), "file: .cover/events.jsonl") {
+		t.Fatalf("expected events written, got:\n%s", buf.String())
+	}
+}
+
 func TestLoadDiffDisabledNoDefault(t *testing.T) {
 	// When diff is disabled, base should not get a default
 	content := "version: 1\npolicy:\n  default:\n    min: 75\ndiff:\n  enabled: false\n"
@@ -273,6 +424,110 @@ func TestLoadWithMergeProfiles(t *testing.T) {
 	}
 }
 
+func TestLoadWithPathMappings(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\nmerge:\n  path_mappings:\n    - from: /app\n      to: services/api\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := []application.PathMapping{{From: "/app", To: "services/api"}}
+	if len(cfg.Merge.PathMappings) != 1 || cfg.Merge.PathMappings[0] != want[0] {
+		t.Fatalf("expected %v, got %v", want, cfg.Merge.PathMappings)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "path_mappings:") {
+		t.Fatalf("expected path_mappings in written config:\n%s", buf.String())
+	}
+}
+
+func TestLoadResolveSymlinks(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\nmerge:\n  resolve_symlinks: false\n"
+	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Merge.SymlinksEnabled() {
+		t.Fatal("expected symlink resolution to be disabled")
+	}
+	if !(application.MergeConfig{}).SymlinksEnabled() {
+		t.Fatal("expected symlink resolution on by default")
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "resolve_symlinks: false") {
+		t.Fatalf("expected resolve_symlinks in written config:\n%s", buf.String())
+	}
+}
+
+func TestLoadNewCodePolicy(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 60\n  new_code_since: 90d\n  new_code_min: 90\n"
+	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.NewCode != (application.NewCodeConfig{Since: "90d", Min: 90}) {
+		t.Fatalf("unexpected new code config: %+v", cfg.NewCode)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "new_code_since: 90d") || !strings.Contains(buf.String(), "new_code_min: 90") {
+		t.Fatalf("expected new code keys in written config:\n%s", buf.String())
+	}
+}
+
+func TestLoadNewCodePolicyValidation(t *testing.T) {
+	for name, policy := range map[string]string{
+		"bad age":     "  new_code_since: soon\n  new_code_min: 90\n",
+		"missing min": "  new_code_since: 90d\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			content := "version: 1\npolicy:\n  default:\n    min: 60\n" + policy
+			path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if _, err := (Loader{}).Load(path); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+}
+
+func TestLoadPathMappingRequiresFrom(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\nmerge:\n  path_mappings:\n    - to: services/api\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil {
+		t.Fatal("expected error for path mapping without from")
+	}
+}
+
 func TestLoadWithAnnotations(t *testing.T) {
 	content := "version: 1\npolicy:\n  default:\n    min: 75\nannotations:\n  enabled: true\n"
 	tmp := t.TempDir()
@@ -439,92 +694,405 @@ func TestWriteWithDomainExcludes(t *testing.T) {
 	}
 }
 
-func TestLoadWithExtends(t *testing.T) {
-	tmp := t.TempDir()
-
-	// Create parent config
-	parentContent := `version: 1
-policy:
-  default:
-    min: 70
-  domains:
-    - name: core
-      match: ["./internal/core/..."]
-      min: 80
-    - name: api
-      match: ["./internal/api/..."]
-      min: 75
-exclude:
-  - "**/*_test.go"
-`
-	parentPath := filepath.Join(tmp, "base.yaml")
-	if err := os.WriteFile(parentPath, []byte(parentContent), 0o644); err != nil {
-		t.Fatalf("write parent: %v", err)
-	}
-
-	// Create child config that extends parent
-	childContent := `version: 1
-extends: base.yaml
+func TestLoadExcludeFunctions(t *testing.T) {
+	content := `version: 1
 policy:
   default:
     min: 75
-  domains:
-    - name: core
-      match: ["./internal/core/..."]
-      min: 90
+exclude:
+  files: ["internal/generated/*"]
+  functions: ['String\(\)$', '^Get']
 `
-	childPath := filepath.Join(tmp, "child.yaml")
-	if err := os.WriteFile(childPath, []byte(childContent), 0o644); err != nil {
-		t.Fatalf("write child: %v", err)
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
 	}
-
-	cfg, err := (Loader{}).Load(childPath)
+	cfg, err := (Loader{}).Load(path)
 	if err != nil {
 		t.Fatalf("load: %v", err)
 	}
-
-	// Check that child default min overrides parent
-	if cfg.Policy.DefaultMin != 75 {
-		t.Errorf("expected default min 75, got %f", cfg.Policy.DefaultMin)
+	if len(cfg.Exclude) != 1 || cfg.Exclude[0] != "internal/generated/*" {
+		t.Fatalf("unexpected file excludes: %v", cfg.Exclude)
 	}
-
-	// Check that we have both domains (api from parent, core overridden)
-	if len(cfg.Policy.Domains) != 2 {
-		t.Fatalf("expected 2 domains, got %d", len(cfg.Policy.Domains))
+	if len(cfg.ExcludeFunctions) != 2 || cfg.ExcludeFunctions[0] != `String\(\)$` {
+		t.Fatalf("unexpected function excludes: %v", cfg.ExcludeFunctions)
 	}
 
-	// Find core domain and check it was overridden
-	var foundCore, foundApi bool
-	for _, d := range cfg.Policy.Domains {
-		if d.Name == "core" {
-			foundCore = true
-			if d.Min == nil || *d.Min != 90 {
-				t.Errorf("expected core min 90, got %v", d.Min)
-			}
-		}
-		if d.Name == "api" {
-			foundApi = true
-			if d.Min == nil || *d.Min != 75 {
-				t.Errorf("expected api min 75 (from parent), got %v", d.Min)
-			}
-		}
-	}
-	if !foundCore {
-		t.Error("expected core domain")
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
 	}
-	if !foundApi {
-		t.Error("expected api domain from parent")
+	if !strings.Contains(buf.String(), "functions:") || !strings.Contains(buf.String(), "files:") {
+		t.Fatalf("expected exclude mapping in output, got:\n%s", buf.String())
 	}
+}
 
-	// Check excludes are combined
-	if len(cfg.Exclude) != 1 {
-		t.Errorf("expected 1 exclude from parent, got %d", len(cfg.Exclude))
+func TestLoadExcludeFunctionsInvalidRegexp(t *testing.T) {
+	content := "version: 1\npolicy:\n  default:\n    min: 75\nexclude:\n  functions: ['(']\n"
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "exclude.functions") {
+		t.Fatalf("expected exclude.functions error, got %v", err)
 	}
 }
 
-func TestLoadWithCircularExtends(t *testing.T) {
+func TestLoadNewDomainPolicy(t *testing.T) {
 	tmp := t.TempDir()
-
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte("version: 1\npolicy:\n  default:\n    min: 80\n  new_domain: warn\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Policy.NewDomain != domain.NewDomainWarn {
+		t.Fatalf("expected new_domain warn, got %q", cfg.Policy.NewDomain)
+	}
+
+	if err := os.WriteFile(path, []byte("version: 1\npolicy:\n  default:\n    min: 80\n  new_domain: lenient\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "new_domain") {
+		t.Fatalf("expected new_domain error, got %v", err)
+	}
+}
+
+func TestLoadDomainGroups(t *testing.T) {
+	content := `version: 1
+policy:
+  default:
+    min: 75
+  groups:
+    - name: backend
+      min: 80
+  domains:
+    - name: api
+      match: ["./internal/api/..."]
+      group: backend
+`
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Policy.Domains[0].Group != "backend" {
+		t.Fatalf("expected api in backend group, got %q", cfg.Policy.Domains[0].Group)
+	}
+	if len(cfg.Policy.Groups) != 1 || *cfg.Policy.Groups[0].Min != 80 {
+		t.Fatalf("unexpected groups: %+v", cfg.Policy.Groups)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "group: backend") || !strings.Contains(buf.String(), "groups:") {
+		t.Fatalf("expected groups in output, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadDomainGroupsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing name": "  groups:\n    - min: 80\n",
+		"duplicate":    "  groups:\n    - name: a\n    - name: a\n",
+		"bad min":      "  groups:\n    - name: a\n      min: 120\n",
+	}
+	for name, groups := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+			content := "version: 1\npolicy:\n  default:\n    min: 75\n" + groups
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if _, err := (Loader{}).Load(path); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadExceptions(t *testing.T) {
+	content := `version: 1
+policy:
+  default:
+    min: 75
+exceptions:
+  - domain: billing
+    reason: rewrite in progress
+    approver: alice
+    expires: 2026-03-31
+  - file: internal/legacy/*.go
+    reason: deleted next release
+    approver: bob
+    expires: 2026-06-30
+`
+	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := []domain.PolicyException{
+		{Domain: "billing", Reason: "rewrite in progress", Approver: "alice", Expires: "2026-03-31"},
+		{File: "internal/legacy/*.go", Reason: "deleted next release", Approver: "bob", Expires: "2026-06-30"},
+	}
+	if !reflect.DeepEqual(cfg.Exceptions, want) {
+		t.Fatalf("unexpected exceptions: %+v", cfg.Exceptions)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "approver: alice") || !strings.Contains(buf.String(), "expires: \"2026-06-30\"") {
+		t.Fatalf("expected exceptions in output, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadExceptionsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no target":     "  - reason: r\n    approver: a\n    expires: 2026-01-01\n",
+		"both targets":  "  - domain: d\n    file: f.go\n    reason: r\n    approver: a\n    expires: 2026-01-01\n",
+		"no reason":     "  - domain: d\n    approver: a\n    expires: 2026-01-01\n",
+		"no approver":   "  - domain: d\n    reason: r\n    expires: 2026-01-01\n",
+		"no expiry":     "  - domain: d\n    reason: r\n    approver: a\n",
+		"bad expiry":    "  - domain: d\n    reason: r\n    approver: a\n    expires: next week\n",
+		"bad file glob": "  - file: \"[\"\n    reason: r\n    approver: a\n    expires: 2026-01-01\n",
+	}
+	for name, exceptions := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+			content := "version: 1\npolicy:\n  default:\n    min: 75\nexceptions:\n" + exceptions
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			_, err := (Loader{}).Load(path)
+			if err == nil || !strings.Contains(err.Error(), "exceptions[0]") {
+				t.Fatalf("expected exceptions[0] error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadGoals(t *testing.T) {
+	content := `version: 1
+policy:
+  default:
+    min: 75
+goals:
+  - domain: core
+    target: 90
+    by: 2026-Q3
+    warn: true
+  - domain: api
+    target: 85
+    by: 2026-12-01
+`
+	path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := []domain.Goal{
+		{Domain: "core", Target: 90, By: "2026-Q3", Warn: true},
+		{Domain: "api", Target: 85, By: "2026-12-01"},
+	}
+	if !reflect.DeepEqual(cfg.Goals, want) {
+		t.Fatalf("unexpected goals: %+v", cfg.Goals)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "by: 2026-Q3") || !strings.Contains(buf.String(), "warn: true") {
+		t.Fatalf("expected goals in output, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadGoalsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no domain":   "  - target: 90\n    by: 2026-Q3\n",
+		"bad target":  "  - domain: core\n    target: 120\n    by: 2026-Q3\n",
+		"no date":     "  - domain: core\n    target: 90\n",
+		"bad quarter": "  - domain: core\n    target: 90\n    by: 2026-Q5\n",
+	}
+	for name, goals := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".coverctl.yaml")
+			content := "version: 1\npolicy:\n  default:\n    min: 75\ngoals:\n" + goals
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			_, err := (Loader{}).Load(path)
+			if err == nil || !strings.Contains(err.Error(), "goals[0]") {
+				t.Fatalf("expected goals[0] error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadWithDomainTestOverrides(t *testing.T) {
+	content := `version: 1
+policy:
+  default:
+    min: 75
+  domains:
+    - name: db
+      match: ["./internal/db/..."]
+      test_args: ["-tags=integration"]
+    - name: web
+      match: ["./web/..."]
+      test_command: ["make", "cover", "OUT={profile}"]
+`
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := (Loader{}).Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	db, web := cfg.Policy.Domains[0], cfg.Policy.Domains[1]
+	if len(db.TestArgs) != 1 || db.TestArgs[0] != "-tags=integration" {
+		t.Fatalf("unexpected test_args %v", db.TestArgs)
+	}
+	if len(web.TestCommand) != 3 || web.TestCommand[2] != "OUT={profile}" {
+		t.Fatalf("unexpected test_command %v", web.TestCommand)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "test_args:") || !strings.Contains(buf.String(), "test_command:") {
+		t.Fatalf("expected overrides in output, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadRejectsBothTestOverrides(t *testing.T) {
+	content := `version: 1
+policy:
+  default:
+    min: 75
+  domains:
+    - name: web
+      match: ["./web/..."]
+      test_args: ["-short"]
+      test_command: ["make", "cover"]
+`
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_, err := (Loader{}).Load(path)
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutual exclusion error, got %v", err)
+	}
+}
+
+func TestLoadWithExtends(t *testing.T) {
+	tmp := t.TempDir()
+
+	// Create parent config
+	parentContent := `version: 1
+policy:
+  default:
+    min: 70
+  domains:
+    - name: core
+      match: ["./internal/core/..."]
+      min: 80
+    - name: api
+      match: ["./internal/api/..."]
+      min: 75
+exclude:
+  - "**/*_test.go"
+`
+	parentPath := filepath.Join(tmp, "base.yaml")
+	if err := os.WriteFile(parentPath, []byte(parentContent), 0o644); err != nil {
+		t.Fatalf("write parent: %v", err)
+	}
+
+	// Create child config that extends parent
+	childContent := `version: 1
+extends: base.yaml
+policy:
+  default:
+    min: 75
+  domains:
+    - name: core
+      match: ["./internal/core/..."]
+      min: 90
+`
+	childPath := filepath.Join(tmp, "child.yaml")
+	if err := os.WriteFile(childPath, []byte(childContent), 0o644); err != nil {
+		t.Fatalf("write child: %v", err)
+	}
+
+	cfg, err := (Loader{}).Load(childPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	// Check that child default min overrides parent
+	if cfg.Policy.DefaultMin != 75 {
+		t.Errorf("expected default min 75, got %f", cfg.Policy.DefaultMin)
+	}
+
+	// Check that we have both domains (api from parent, core overridden)
+	if len(cfg.Policy.Domains) != 2 {
+		t.Fatalf("expected 2 domains, got %d", len(cfg.Policy.Domains))
+	}
+
+	// Find core domain and check it was overridden
+	var foundCore, foundApi bool
+	for _, d := range cfg.Policy.Domains {
+		if d.Name == "core" {
+			foundCore = true
+			if d.Min == nil || *d.Min != 90 {
+				t.Errorf("expected core min 90, got %v", d.Min)
+			}
+		}
+		if d.Name == "api" {
+			foundApi = true
+			if d.Min == nil || *d.Min != 75 {
+				t.Errorf("expected api min 75 (from parent), got %v", d.Min)
+			}
+		}
+	}
+	if !foundCore {
+		t.Error("expected core domain")
+	}
+	if !foundApi {
+		t.Error("expected api domain from parent")
+	}
+
+	// Check excludes are combined
+	if len(cfg.Exclude) != 1 {
+		t.Errorf("expected 1 exclude from parent, got %d", len(cfg.Exclude))
+	}
+}
+
+func TestLoadWithCircularExtends(t *testing.T) {
+	tmp := t.TempDir()
+
 	// Create config A that extends B
 	configA := `version: 1
 extends: b.yaml
@@ -687,3 +1255,325 @@ policy:
 		t.Fatalf("expected 2 domains, got %d", len(cfg.Policy.Domains))
 	}
 }
+
+func TestLoadConfigRunner(t *testing.T) {
+	dir := t.TempDir()
+	parent := filepath.Join(dir, "base.yaml")
+	child := filepath.Join(dir, ".coverctl.yaml")
+	if err := os.WriteFile(parent, []byte("version: 1\nrunner: go\npolicy:\n  default:\n    min: 70\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(child, []byte("version: 1\nextends: base.yaml\nrunner: node\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg, err := Loader{}.Load(child)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Runner != "node" {
+		t.Fatalf("expected child runner to override parent, got %q", cfg.Runner)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "runner: node") {
+		t.Fatalf("expected runner in written config:\n%s", buf.String())
+	}
+}
+
+func TestLoadConfigRunnerRetries(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\nrunner:\n  name: go\n  retries: 2\n  only_failed: true\npolicy:\n  default:\n    min: 70\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Runner != "go" || cfg.Retry != (application.RetryConfig{Retries: 2, OnlyFailed: true}) {
+		t.Fatalf("got runner %q, retry %+v", cfg.Runner, cfg.Retry)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "retries: 2") || !strings.Contains(buf.String(), "only_failed: true") {
+		t.Fatalf("expected retries in written config:\n%s", buf.String())
+	}
+
+	if err := os.WriteFile(path, []byte("version: 1\nrunner:\n  retries: -1\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "runner.retries") {
+		t.Fatalf("expected negative retries to be rejected, got %v", err)
+	}
+}
+
+func TestLoadConfigCoverPkg(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\ncoverpkg:\n  exclude:\n    - ./internal/fixtures/...\n  auto_exclude: false\n  isolate: true\npolicy:\n  default:\n    min: 70\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.CoverPkg.Exclude, []string{"./internal/fixtures/..."}) || cfg.CoverPkg.AutoExcludeEnabled() || !cfg.CoverPkg.Isolate {
+		t.Fatalf("got coverpkg %+v", cfg.CoverPkg)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "- ./internal/fixtures/...") || !strings.Contains(buf.String(), "auto_exclude: false") || !strings.Contains(buf.String(), "isolate: true") {
+		t.Fatalf("expected coverpkg in written config:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := Write(&buf, application.Config{Version: 1}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if strings.Contains(buf.String(), "coverpkg") {
+		t.Fatalf("expected no coverpkg section by default:\n%s", buf.String())
+	}
+}
+
+func TestLoadConfigWeight(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\npolicy:\n  default:\n    min: 70\n  domains:\n    - name: core\n      match: [\"./core/...\"]\n      weight: 3\n    - name: tools\n      match: [\"./tools/...\"]\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if w := cfg.Policy.Domains[0].Weight; w == nil || *w != 3 || cfg.Policy.Domains[1].Weight != nil {
+		t.Fatalf("got domains %+v", cfg.Policy.Domains)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "weight: 3") || strings.Count(buf.String(), "weight:") != 1 {
+		t.Fatalf("expected one weight in output:\n%s", buf.String())
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Replace(data, "weight: 3", "weight: -1", 1)), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "weight must not be negative") {
+		t.Fatalf("expected a negative weight error, got %v", err)
+	}
+}
+
+func TestLoadConfigMetric(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\npolicy:\n  default:\n    min: 70\n  metric: lines\n  domains:\n    - name: core\n      match: [\"./core/...\"]\n      metric: branches\n    - name: tools\n      match: [\"./tools/...\"]\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Policy.Metric != domain.MetricLines || cfg.Policy.Domains[0].Metric != domain.MetricBranches || cfg.Policy.Domains[1].Metric != "" {
+		t.Fatalf("got policy %+v", cfg.Policy)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "metric: lines") || !strings.Contains(buf.String(), "metric: branches") {
+		t.Fatalf("expected both metrics in output:\n%s", buf.String())
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Replace(data, "metric: branches", "metric: functions", 1)), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "domain core: metric") {
+		t.Fatalf("expected an invalid metric error, got %v", err)
+	}
+}
+
+func TestLoadConfigRounding(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\npolicy:\n  default:\n    min: 80\n  rounding: floor\n  domains:\n    - name: core\n      match: [\"./core/...\"]\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Policy.Rounding != domain.RoundingFloor {
+		t.Fatalf("got rounding %q, want floor", cfg.Policy.Rounding)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "rounding: floor") {
+		t.Fatalf("expected rounding in output:\n%s", buf.String())
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Replace(data, "rounding: floor", "rounding: ceil", 1)), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "unsupported policy.rounding: ceil") {
+		t.Fatalf("expected an invalid rounding error, got %v", err)
+	}
+}
+
+func TestLoadConfigProfileMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\nprofile:\n  max_age: 30m\n  stale: warn\npolicy:\n  default:\n    min: 80\n  domains:\n    - name: core\n      match: [\"./core/...\"]\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Profile.MaxAge != "30m" || cfg.Profile.Stale != application.StaleProfileWarn {
+		t.Fatalf("got profile %+v", cfg.Profile)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "max_age: 30m") || !strings.Contains(buf.String(), "stale: warn") {
+		t.Fatalf("expected profile age settings in output:\n%s", buf.String())
+	}
+
+	for _, tc := range []struct{ old, new, want string }{
+		{"max_age: 30m", "max_age: soon", "profile.max_age"},
+		{"stale: warn", "stale: ignore", "unsupported profile.stale: ignore"},
+	} {
+		if err := os.WriteFile(path, []byte(strings.Replace(data, tc.old, tc.new, 1)), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q error, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestLoadConfigMatrix(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\nmatrix:\n  - name: linux\n    goos: linux\n  - name: windows\n    goos: windows\n    goarch: amd64\n    tags: cgo\npolicy:\n  default:\n    min: 80\n  domains:\n    - name: core\n      match: [\"./core/...\"]\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := []application.BuildVariant{
+		{Name: "linux", GOOS: "linux"},
+		{Name: "windows", GOOS: "windows", GOARCH: "amd64", Tags: "cgo"},
+	}
+	if !reflect.DeepEqual(cfg.Matrix, want) {
+		t.Fatalf("matrix = %+v, want %+v", cfg.Matrix, want)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "goos: windows") || !strings.Contains(buf.String(), "tags: cgo") {
+		t.Fatalf("expected the matrix in output:\n%s", buf.String())
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Replace(data, "name: windows", "name: linux", 1)), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "variant linux is listed twice") {
+		t.Fatalf("expected a duplicate variant error, got %v", err)
+	}
+}
+
+func TestLoadConfigFunctionsMin(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\npolicy:\n  default:\n    min: 70\n  domains:\n    - name: core\n      match: [\"./core/...\"]\nfunctions:\n  min: 90\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Functions.Min == nil || *cfg.Functions.Min != 90 {
+		t.Fatalf("got functions %+v", cfg.Functions)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "functions:\n  min: 90") {
+		t.Fatalf("expected functions.min in output:\n%s", buf.String())
+	}
+	cfg.Functions.Min = nil
+	buf.Reset()
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if strings.Contains(buf.String(), "functions:") {
+		t.Fatalf("expected no functions section:\n%s", buf.String())
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Replace(data, "min: 90", "min: 120", 1)), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := (Loader{}).Load(path); err == nil || !strings.Contains(err.Error(), "functions.min") {
+		t.Fatalf("expected an invalid functions.min error, got %v", err)
+	}
+}
+
+func TestLoadConfigOwners(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".coverctl.yaml")
+	data := "version: 1\npolicy:\n  default:\n    min: 70\n  domains:\n    - name: billing\n      match: [\"./billing/...\"]\n      owners: [\"@acme/payments\", \"pay@acme.dev\"]\nnotify:\n  on_failure: true\n  owners:\n    \"@acme/payments\": ${PAYMENTS_WEBHOOK}\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg, err := Loader{}.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Policy.Domains[0].Owners, []string{"@acme/payments", "pay@acme.dev"}) {
+		t.Fatalf("got owners %v", cfg.Policy.Domains[0].Owners)
+	}
+	if cfg.Notify.Owners["@acme/payments"] != "${PAYMENTS_WEBHOOK}" || !cfg.Notify.Enabled() {
+		t.Fatalf("got notify %+v", cfg.Notify)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	written, err := Loader{Stdin: NewStdin(&buf)}.Load(StdinPath)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !reflect.DeepEqual(written.Policy.Domains[0].Owners, cfg.Policy.Domains[0].Owners) || !reflect.DeepEqual(written.Notify.Owners, cfg.Notify.Owners) {
+		t.Fatalf("owners lost on write: %+v / %+v", written.Policy.Domains[0], written.Notify)
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// Revisions implements application.ConfigSource. A ref naming an existing
+// file (or "-") is loaded as usual; "REV:PATH" is read with git show, and
+// extends in it resolves against the working tree.
+type Revisions struct {
+	Loader Loader
+	// Exec runs git with args and returns its stdout; nil runs the git binary.
+	Exec func(ctx context.Context, args []string) ([]byte, error)
+}
+
+var _ application.ConfigSource = Revisions{}
+
+// LoadConfig loads the config ref names.
+func (r Revisions) LoadConfig(ctx context.Context, ref string) (application.Config, error) {
+	rev, path, isRevision := splitRevision(ref)
+	if !isRevision {
+		return r.Loader.Load(ref)
+	}
+	run := r.Exec
+	if run == nil {
+		run = gitOutput
+	}
+	raw, err := run(ctx, []string{"show", rev + ":" + path})
+	if err != nil {
+		return application.Config{}, fmt.Errorf("git show %s:%s: %w", rev, path, err)
+	}
+	return Loader{Stdin: NewStdin(bytes.NewReader(raw))}.Load(StdinPath)
+}
+
+// splitRevision splits "REV:PATH" when ref is not an existing file.
+func splitRevision(ref string) (rev, path string, ok bool) {
+	if ref == StdinPath {
+		return "", "", false
+	}
+	if _, err := os.Stat(ref); err == nil {
+		return "", "", false
+	}
+	i := strings.Index(ref, ":")
+	if i <= 0 || i == len(ref)-1 {
+		return "", "", false
+	}
+	return ref[:i], ref[i+1:], true
+}
+
+func gitOutput(ctx context.Context, args []string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
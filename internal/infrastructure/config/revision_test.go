@@ -0,0 +1,41 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRevisionsLoadConfig(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, ".coverctl.yaml")
+	if err := os.WriteFile(path, []byte("version: 1\npolicy:\n  default:\n    min: 80\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var gitArgs []string
+	source := Revisions{Exec: func(_ context.Context, args []string) ([]byte, error) {
+		gitArgs = args
+		if args[1] != "main:.coverctl.yaml" {
+			return nil, errors.New("fatal: path does not exist")
+		}
+		return []byte("version: 1\npolicy:\n  default:\n    min: 70\n"), nil
+	}}
+
+	cfg, err := source.LoadConfig(context.Background(), path)
+	if err != nil || cfg.Policy.DefaultMin != 80 || gitArgs != nil {
+		t.Fatalf("expected file load, got %v (%v), git %v", cfg.Policy.DefaultMin, err, gitArgs)
+	}
+	cfg, err = source.LoadConfig(context.Background(), "main:.coverctl.yaml")
+	if err != nil || cfg.Policy.DefaultMin != 70 {
+		t.Fatalf("expected revision load, got %v (%v)", cfg.Policy.DefaultMin, err)
+	}
+	if !reflect.DeepEqual(gitArgs, []string{"show", "main:.coverctl.yaml"}) {
+		t.Fatalf("unexpected git args %v", gitArgs)
+	}
+	if _, err := source.LoadConfig(context.Background(), "main:missing.yaml"); err == nil {
+		t.Fatal("expected error for a missing revision path")
+	}
+}
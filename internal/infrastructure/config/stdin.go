@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// StdinPath is the config path that reads the config from standard input.
+const StdinPath = "-"
+
+// Stdin reads a config from r once and replays it, so a command that loads
+// its config several times sees the same document.
+type Stdin struct {
+	r    io.Reader
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// NewStdin returns a Stdin reading from r, normally os.Stdin.
+func NewStdin(r io.Reader) *Stdin {
+	return &Stdin{r: r}
+}
+
+func (s *Stdin) read() ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("reading config from stdin is not supported here")
+	}
+	s.once.Do(func() {
+		s.data, s.err = io.ReadAll(s.r)
+		if s.err == nil && len(s.data) == 0 {
+			s.err = errors.New("no config on stdin")
+		}
+	})
+	return s.data, s.err
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadStdin(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmp, "base.yaml"), []byte("version: 1\npolicy:\n  default:\n    min: 60\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	t.Chdir(tmp)
+
+	loader := Loader{Stdin: NewStdin(strings.NewReader("version: 1\nextends: base.yaml\npolicy:\n  domains:\n    - name: core\n      match: [\"./core/...\"]\n"))}
+	ok, err := loader.Exists(StdinPath)
+	if err != nil || !ok {
+		t.Fatalf("expected stdin config to exist, got %v, %v", ok, err)
+	}
+	// A command may load its config more than once; each load sees the same document.
+	for i := 0; i < 2; i++ {
+		cfg, err := loader.Load(StdinPath)
+		if err != nil {
+			t.Fatalf("load %d: %v", i, err)
+		}
+		if cfg.Policy.DefaultMin != 60 || len(cfg.Policy.Domains) != 1 {
+			t.Fatalf("load %d: unexpected config %+v", i, cfg.Policy)
+		}
+	}
+}
+
+func TestLoadStdinUnavailable(t *testing.T) {
+	if _, err := (Loader{}).Load(StdinPath); err == nil {
+		t.Fatal("expected error without a stdin source")
+	}
+	if _, err := (Loader{Stdin: NewStdin(strings.NewReader(""))}).Load(StdinPath); err == nil {
+		t.Fatal("expected error for empty stdin")
+	}
+}
+
+func TestLoadStdinRejectsFilesFromStdin(t *testing.T) {
+	loader := Loader{Stdin: NewStdin(strings.NewReader("version: 1\ndiff:\n  enabled: true\n  files_from: \"-\"\n"))}
+	_, err := loader.Load(StdinPath)
+	if err == nil || !strings.Contains(err.Error(), "files_from") {
+		t.Fatalf("expected files_from \"-\" to be rejected with a stdin config, got %v", err)
+	}
+}
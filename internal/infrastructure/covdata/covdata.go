@@ -0,0 +1,138 @@
+// Package covdata reads Go's binary coverage format: the covmeta.* and
+// covcounters.* files a -cover binary writes to GOCOVERDIR. Directories of
+// them are converted to text profiles with `go tool covdata textfmt`, so
+// they can be passed anywhere a profile path is accepted.
+package covdata
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/cmdrun"
+)
+
+// IsDir reports whether path is a directory holding Go binary coverage
+// data, i.e. at least one covmeta.* file.
+func IsDir(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	matches, err := filepath.Glob(filepath.Join(path, "covmeta.*"))
+	return err == nil && len(matches) > 0
+}
+
+// Converter turns covdata directories into text profiles, converting each
+// directory once per process. The zero value runs the go tool.
+type Converter struct {
+	// Exec runs `go <args>`; nil uses the go command on PATH.
+	Exec func(ctx context.Context, args []string) error
+	// Dir receives the text profiles; empty uses a private directory under
+	// the system temp directory, removed by Cleanup.
+	Dir string
+
+	mu        sync.Mutex
+	converted map[string]string
+	tempDir   string
+}
+
+// tempDirs are the private directories converters created, for Cleanup.
+var (
+	tempDirsMu sync.Mutex
+	tempDirs   []string
+)
+
+// Cleanup removes the text profiles converters wrote to private temp
+// directories. The CLI calls it before exiting.
+func Cleanup() error {
+	tempDirsMu.Lock()
+	defer tempDirsMu.Unlock()
+	var errs []error
+	for _, dir := range tempDirs {
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	tempDirs = nil
+	return errors.Join(errs...)
+}
+
+// TextProfile returns the text profile for path. Paths that are not covdata
+// directories are returned unchanged.
+func (c *Converter) TextProfile(path string) (string, error) {
+	if !IsDir(path) {
+		return path, nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if out, ok := c.converted[abs]; ok {
+		return out, nil
+	}
+	dir, err := c.outputDir()
+	if err != nil {
+		return "", err
+	}
+	// A fresh file: a fixed name in a shared temp directory could be
+	// created or swapped by another user first.
+	f, err := os.CreateTemp(dir, "coverctl-covdata-*.out")
+	if err != nil {
+		return "", err
+	}
+	out := f.Name()
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := c.exec(context.Background(), []string{"tool", "covdata", "textfmt", "-i=" + abs, "-o=" + out}); err != nil {
+		_ = os.Remove(out)
+		return "", fmt.Errorf("convert covdata directory %s: %w", path, err)
+	}
+	if c.converted == nil {
+		c.converted = map[string]string{}
+	}
+	c.converted[abs] = out
+	return out, nil
+}
+
+// outputDir returns Dir, or creates the converter's private temp directory
+// on first use. The caller holds c.mu.
+func (c *Converter) outputDir() (string, error) {
+	if c.Dir != "" {
+		return c.Dir, nil
+	}
+	if c.tempDir == "" {
+		dir, err := os.MkdirTemp("", "coverctl-covdata-")
+		if err != nil {
+			return "", err
+		}
+		tempDirsMu.Lock()
+		tempDirs = append(tempDirs, dir)
+		tempDirsMu.Unlock()
+		c.tempDir = dir
+	}
+	return c.tempDir, nil
+}
+
+func (c *Converter) exec(ctx context.Context, args []string) error {
+	if c.Exec != nil {
+		return c.Exec(ctx, args)
+	}
+	var stderr bytes.Buffer
+	if err := (cmdrun.Runner{Stdout: &stderr, Stderr: &stderr}).Exec(ctx, "", "go", args); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
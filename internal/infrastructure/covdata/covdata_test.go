@@ -0,0 +1,83 @@
+package covdata
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.False(t, IsDir(dir), "a directory without covmeta files")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "covmeta.1234"), nil, 0o644))
+	assert.True(t, IsDir(dir))
+
+	file := filepath.Join(dir, "coverage.out")
+	require.NoError(t, os.WriteFile(file, []byte("mode: set\n"), 0o644))
+	assert.False(t, IsDir(file))
+	assert.False(t, IsDir(filepath.Join(dir, "missing")))
+}
+
+func TestConverterTextProfile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "covmeta.1234"), nil, 0o644))
+	out := t.TempDir()
+
+	var calls [][]string
+	c := &Converter{Dir: out, Exec: func(_ context.Context, args []string) error {
+		calls = append(calls, args)
+		return nil
+	}}
+
+	profile, err := c.TextProfile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, out, filepath.Dir(profile))
+	require.Len(t, calls, 1)
+	assert.Equal(t, []string{"tool", "covdata", "textfmt", "-i=" + dir, "-o=" + profile}, calls[0])
+
+	again, err := c.TextProfile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, profile, again)
+	assert.Len(t, calls, 1, "a directory is converted once")
+
+	file := filepath.Join(out, "coverage.out")
+	passed, err := c.TextProfile(file)
+	require.NoError(t, err)
+	assert.Equal(t, file, passed, "non-covdata paths pass through")
+}
+
+func TestConverterTextProfileError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "covmeta.1234"), nil, 0o644))
+	c := &Converter{Dir: t.TempDir(), Exec: func(context.Context, []string) error {
+		return errors.New("exit status 1")
+	}}
+
+	_, err := c.TextProfile(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "convert covdata directory")
+}
+
+func TestConverterPrivateTempDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "covmeta.1234"), nil, 0o644))
+	c := &Converter{Exec: func(context.Context, []string) error { return nil }}
+
+	profile, err := c.TextProfile(dir)
+	require.NoError(t, err)
+	tmp := filepath.Dir(profile)
+	assert.NotEqual(t, filepath.Clean(os.TempDir()), tmp, "profiles go to a private directory")
+	info, err := os.Stat(tmp)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+	require.NoError(t, Cleanup())
+	_, err = os.Stat(tmp)
+	assert.True(t, os.IsNotExist(err), "Cleanup removes the directory")
+}
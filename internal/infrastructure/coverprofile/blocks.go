@@ -0,0 +1,56 @@
+package coverprofile
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// ParseAllBlocks returns the statement blocks of Go profiles per file,
+// merged the same way ParseAll merges them: a block seen in several
+// profiles counts once and is covered if any profile covered it.
+func (Parser) ParseAllBlocks(paths []string) (map[string][]domain.CoverageBlock, error) {
+	merged, err := parseProfiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]domain.CoverageBlock, len(merged))
+	for filePath, lines := range merged {
+		blocks := make([]domain.CoverageBlock, 0, len(lines))
+		for lineKey, stat := range lines {
+			start, end, err := spanLines(lineKey[len(filePath)+1:])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", lineKey, err)
+			}
+			blocks = append(blocks, domain.CoverageBlock{
+				Lines: domain.LineRange{Start: start, End: end},
+				Stat:  stat,
+			})
+		}
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i].Lines.Start < blocks[j].Lines.Start
+		})
+		result[filePath] = blocks
+	}
+	return result, nil
+}
+
+// spanLines returns the start and end line of "startLine.startCol,endLine.endCol".
+func spanLines(span string) (int, int, error) {
+	startPos, endPos, ok := strings.Cut(span, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid coverage block")
+	}
+	start, err := strconv.Atoi(strings.SplitN(startPos, ".", 2)[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block start")
+	}
+	end, err := strconv.Atoi(strings.SplitN(endPos, ".", 2)[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block end")
+	}
+	return start, end, nil
+}
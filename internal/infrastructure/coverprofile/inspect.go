@@ -0,0 +1,170 @@
+package coverprofile
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// maxListed caps the errors and overlaps InspectProfile lists.
+const maxListed = 10
+
+// InspectProfile checks a Go profile line by line instead of stopping at the
+// first error: the mode line, every block line, blocks listed more than once
+// (normal with -coverpkg), and different blocks claiming the same source,
+// which happens when profiles of two revisions are concatenated.
+func (Parser) InspectProfile(path string) (application.ProfileInspection, error) {
+	inspection := application.ProfileInspection{Format: application.FormatGo}
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return inspection, fmt.Errorf("invalid path: %w", err)
+	}
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return inspection, err
+	}
+	defer file.Close()
+
+	var errs []string
+	blocks := map[string]map[string]int{} // file -> span -> statements
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	sawMode := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNo++
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff") // byte order mark from Windows editors
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if mode, ok := strings.CutPrefix(line, "mode:"); ok {
+			switch mode = strings.TrimSpace(mode); {
+			case lineNo > 1 && sawMode:
+				errs = append(errs, fmt.Sprintf("line %d: repeated mode line (profiles concatenated without merging?)", lineNo))
+			case lineNo > 1:
+				errs = append(errs, fmt.Sprintf("line %d: mode line is not the first line", lineNo))
+			case mode != "set" && mode != "count" && mode != "atomic":
+				errs = append(errs, fmt.Sprintf("line 1: unknown coverage mode %q", mode))
+			}
+			sawMode = true
+			continue
+		}
+		if lineNo == 1 {
+			errs = append(errs, fmt.Sprintf("line 1: invalid coverage mode line %q (want \"mode: set|count|atomic\", not test output)", truncate(line)))
+			continue
+		}
+		filePath, blockKey, _, stmts, err := parseLine(line)
+		if err == nil {
+			_, _, err = spanPositions(blockKey[len(filePath)+1:])
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v: %q", lineNo, err, truncate(line)))
+			continue
+		}
+		spans := blocks[filePath]
+		if spans == nil {
+			spans = map[string]int{}
+			blocks[filePath] = spans
+		}
+		span := blockKey[len(filePath)+1:]
+		if _, seen := spans[span]; seen {
+			inspection.Duplicates++
+			continue
+		}
+		spans[span] = stmts
+		inspection.Statements += stmts
+	}
+	if err := scanner.Err(); err != nil {
+		return inspection, err
+	}
+	if !sawMode && len(errs) == 0 {
+		errs = append(errs, "empty profile: no mode line")
+	}
+
+	var overlaps []string
+	for filePath, spans := range blocks {
+		overlaps = append(overlaps, fileOverlaps(filePath, spans)...)
+	}
+	sort.Strings(overlaps)
+	inspection.Files = len(blocks)
+	inspection.Errors = capList(errs)
+	inspection.Overlaps = capList(overlaps)
+	return inspection, nil
+}
+
+// fileOverlaps lists the pairs of distinct blocks in one file whose source
+// ranges overlap.
+func fileOverlaps(filePath string, spans map[string]int) []string {
+	type block struct {
+		span       string
+		start, end [2]int
+	}
+	sorted := make([]block, 0, len(spans))
+	for span := range spans {
+		start, end, _ := spanPositions(span)
+		sorted = append(sorted, block{span: span, start: start, end: end})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return before(sorted[i].start, sorted[j].start) })
+	var overlaps []string
+	for i := 1; i < len(sorted); i++ {
+		prev, cur := sorted[i-1], sorted[i]
+		if before(cur.start, prev.end) {
+			overlaps = append(overlaps, fmt.Sprintf("%s: %s overlaps %s", filePath, prev.span, cur.span))
+		}
+	}
+	return overlaps
+}
+
+// spanPositions parses "startLine.startCol,endLine.endCol".
+func spanPositions(span string) (start, end [2]int, err error) {
+	from, to, ok := strings.Cut(span, ",")
+	if !ok {
+		return start, end, fmt.Errorf("invalid coverage block")
+	}
+	if start, err = position(from); err != nil {
+		return start, end, err
+	}
+	if end, err = position(to); err != nil {
+		return start, end, err
+	}
+	if before(end, start) {
+		return start, end, fmt.Errorf("block ends before it starts")
+	}
+	return start, end, nil
+}
+
+func position(s string) ([2]int, error) {
+	line, col, ok := strings.Cut(s, ".")
+	l, err1 := strconv.Atoi(line)
+	c, err2 := strconv.Atoi(col)
+	if !ok || err1 != nil || err2 != nil {
+		return [2]int{}, fmt.Errorf("invalid block position %q", s)
+	}
+	return [2]int{l, c}, nil
+}
+
+func before(a, b [2]int) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+func truncate(line string) string {
+	if len(line) > 60 {
+		return line[:60] + "..."
+	}
+	return line
+}
+
+func capList(items []string) []string {
+	if len(items) <= maxListed {
+		return items
+	}
+	return append(items[:maxListed:maxListed], fmt.Sprintf("... and %d more", len(items)-maxListed))
+}
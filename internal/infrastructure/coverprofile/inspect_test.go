@@ -0,0 +1,71 @@
+package coverprofile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func inspectContent(t *testing.T, content string) (files, statements, duplicates int, overlaps, errs []string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	inspection, err := Parser{}.InspectProfile(path)
+	if err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+	return inspection.Files, inspection.Statements, inspection.Duplicates, inspection.Overlaps, inspection.Errors
+}
+
+func TestInspectProfileValid(t *testing.T) {
+	files, statements, duplicates, overlaps, errs := inspectContent(t, "mode: atomic\n"+
+		"internal/core/foo.go:1.2,3.4 2 1\n"+
+		"internal/core/foo.go:3.4,7.8 3 0\n"+
+		"internal/core/foo.go:1.2,3.4 2 0\n"+
+		"internal/api/bar.go:1.2,3.4 1 1\n")
+	if files != 2 || statements != 6 || duplicates != 1 {
+		t.Fatalf("got %d files, %d statements, %d duplicates", files, statements, duplicates)
+	}
+	if len(overlaps) != 0 || len(errs) != 0 {
+		t.Fatalf("expected a clean profile, got overlaps %v, errors %v", overlaps, errs)
+	}
+}
+
+func TestInspectProfileErrors(t *testing.T) {
+	_, _, _, _, errs := inspectContent(t, "ok  \texample.com/a\t0.1s\n"+
+		"internal/core/foo.go:1.2,3.4 2 1\n"+
+		"mode: set\n"+
+		"internal/core/foo.go:1.2 2 1\n")
+	want := []string{"line 1: invalid coverage mode line", "line 3: mode line is not the first line", "line 4: invalid coverage block"}
+	if len(errs) < len(want) {
+		t.Fatalf("expected at least %d errors, got %v", len(want), errs)
+	}
+	joined := strings.Join(errs, "\n")
+	for _, w := range want {
+		if !strings.Contains(joined, w) {
+			t.Fatalf("expected %q in %v", w, errs)
+		}
+	}
+
+	_, _, _, _, errs = inspectContent(t, "mode: set\ninternal/core/foo.go:1.2,3.4 2 1\nmode: set\n")
+	if len(errs) != 1 || !strings.Contains(errs[0], "line 3: repeated mode line") {
+		t.Fatalf("expected a repeated mode line error, got %v", errs)
+	}
+
+	_, _, _, _, errs = inspectContent(t, "")
+	if len(errs) != 1 || !strings.Contains(errs[0], "empty profile") {
+		t.Fatalf("expected an empty profile error, got %v", errs)
+	}
+}
+
+func TestInspectProfileOverlaps(t *testing.T) {
+	_, _, _, overlaps, _ := inspectContent(t, "mode: set\n"+
+		"internal/core/foo.go:1.2,5.4 2 1\n"+
+		"internal/core/foo.go:3.1,8.2 2 1\n")
+	if len(overlaps) != 1 || overlaps[0] != "internal/core/foo.go: 1.2,5.4 overlaps 3.1,8.2" {
+		t.Fatalf("overlaps = %v", overlaps)
+	}
+}
@@ -0,0 +1,88 @@
+package coverprofile
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// ParseLines reads a Go coverage profile and returns per-line hit counts.
+// Every line spanned by a block inherits the block's count; where blocks
+// overlap, the highest count wins.
+func (Parser) ParseLines(path string) (map[string]domain.LineCoverage, error) {
+	lines, _, err := parseLines(path)
+	return lines, err
+}
+
+// parseLines is ParseLines that also returns the profile's mode.
+func parseLines(path string) (map[string]domain.LineCoverage, string, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid path: %w", err)
+	}
+
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	result := make(map[string]domain.LineCoverage)
+	scanner := bufio.NewScanner(file)
+	mode := ""
+	lineNo := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNo++
+		if lineNo == 1 {
+			if mode, err = parseMode(line); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		filePath, start, end, count, err := parseBlock(line)
+		if err != nil {
+			return nil, "", fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		lines := result[filePath]
+		if lines == nil {
+			lines = make(domain.LineCoverage)
+			result[filePath] = lines
+		}
+		for n := start; n <= end; n++ {
+			if prev, ok := lines[n]; !ok || count > prev {
+				lines[n] = count
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	return result, mode, nil
+}
+
+// parseBlock parses "file:startLine.startCol,endLine.endCol numStmts count".
+func parseBlock(line string) (string, int, int, int, error) {
+	block, span, _, countPart, ok := splitProfileLine(line)
+	if !ok {
+		return "", 0, 0, 0, fmt.Errorf("invalid coverage line")
+	}
+	filePath := block[:len(block)-len(span)-1]
+	start, end, err := spanLines(span)
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+	count, err := strconv.Atoi(countPart)
+	if err != nil {
+		return "", 0, 0, 0, fmt.Errorf("invalid count")
+	}
+	return filePath, start, end, count, nil
+}
@@ -0,0 +1,23 @@
+package coverprofile
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMergeDowngradesMixedModes(t *testing.T) {
+	unit := writeProfile(t, "unit.out", "mode: count\ninternal/core/foo.go:1.1,2.2 1 9\ninternal/core/foo.go:4.1,4.9 1 0\n")
+	e2e := writeProfile(t, "e2e.out", "mode: set\ninternal/core/foo.go:1.1,2.2 1 1\ninternal/core/bar.go:1.1,3.2 2 1\n")
+
+	var buf bytes.Buffer
+	if err := Merge(&buf, []string{unit, e2e}); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	want := "mode: set\ninternal/core/foo.go:1.1,2.2 1 1\ninternal/core/foo.go:4.1,4.9 1 0\ninternal/core/bar.go:1.1,3.2 2 1\n"
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if err := Merge(&buf, []string{writeProfile(t, "bad.out", "not a profile\n")}); err == nil {
+		t.Fatal("expected an error for a profile without a mode line")
+	}
+}
@@ -0,0 +1,137 @@
+package coverprofile
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// Coverage modes a Go profile can be written in. set records whether a
+// block ran; count and atomic record how often.
+const (
+	modeSet    = "set"
+	modeCount  = "count"
+	modeAtomic = "atomic"
+)
+
+// mergedMode reconciles the modes of profiles being merged. Counts can only
+// be added up when every profile has them, so any set profile downgrades
+// the merge to set. count and atomic profiles count alike and merge as
+// count. mixed reports a set profile merged with counting ones.
+func mergedMode(modes []string) (mode string, mixed bool) {
+	var set, counting bool
+	for _, m := range modes {
+		if m == modeSet {
+			set = true
+		} else {
+			counting = true
+		}
+	}
+	switch {
+	case set && counting:
+		return modeSet, true
+	case set:
+		return modeSet, false
+	default:
+		return modeCount, false
+	}
+}
+
+// ParseAllLines merges per-line hit counts from multiple Go profiles. When
+// every profile counts, hits are added up across profiles; when any was
+// written in set mode, every line is reduced to 0 or 1 so counts from one
+// profile cannot outweigh another that only recorded execution.
+func (p Parser) ParseAllLines(paths []string) (map[string]domain.LineCoverage, error) {
+	merged := make(map[string]domain.LineCoverage)
+	modes := make([]string, 0, len(paths))
+	for _, path := range paths {
+		lines, mode, err := parseLines(path)
+		if err != nil {
+			return nil, err
+		}
+		modes = append(modes, mode)
+		for file, hits := range lines {
+			dst := merged[file]
+			if dst == nil {
+				dst = make(domain.LineCoverage, len(hits))
+				merged[file] = dst
+			}
+			for n, count := range hits {
+				dst[n] += count
+			}
+		}
+	}
+	if mode, _ := mergedMode(modes); mode == modeSet {
+		for _, lines := range merged {
+			for n, count := range lines {
+				lines[n] = min(count, 1)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// ModeWarnings reports when the profiles mix set mode with count or atomic
+// mode, naming the profiles in each mode.
+func (Parser) ModeWarnings(paths []string) ([]string, error) {
+	byMode := make(map[string][]string)
+	modes := make([]string, 0, len(paths))
+	for _, path := range paths {
+		mode, err := profileMode(path)
+		if err != nil {
+			return nil, err
+		}
+		byMode[mode] = append(byMode[mode], path)
+		modes = append(modes, mode)
+	}
+	if _, mixed := mergedMode(modes); !mixed {
+		return nil, nil
+	}
+	names := make([]string, 0, len(byMode))
+	for mode := range byMode {
+		names = append(names, mode)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, mode := range names {
+		parts = append(parts, fmt.Sprintf("%s: %s", mode, strings.Join(byMode[mode], ", ")))
+	}
+	return []string{fmt.Sprintf("merged profiles mix coverage modes (%s); hit counts were reduced to set mode (0 or 1). Run every suite with the same -covermode to keep counts", strings.Join(parts, "; "))}, nil
+}
+
+// profileMode reads the mode line of a Go profile.
+func profileMode(path string) (string, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %w", err)
+	}
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("invalid coverage mode line")
+	}
+	return parseMode(scanner.Text())
+}
+
+// parseMode returns the mode of a profile's first line.
+func parseMode(line string) (string, error) {
+	line = strings.TrimPrefix(line, "\ufeff") // byte order mark from Windows editors
+	mode, ok := strings.CutPrefix(line, "mode:")
+	if !ok {
+		return "", fmt.Errorf("invalid coverage mode line")
+	}
+	return strings.TrimSpace(mode), nil
+}
@@ -0,0 +1,87 @@
+package coverprofile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProfile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestMergedMode(t *testing.T) {
+	tests := []struct {
+		modes []string
+		mode  string
+		mixed bool
+	}{
+		{[]string{"set", "set"}, "set", false},
+		{[]string{"count", "atomic"}, "count", false},
+		{[]string{"atomic"}, "count", false},
+		{[]string{"count", "set"}, "set", true},
+	}
+	for _, tt := range tests {
+		mode, mixed := mergedMode(tt.modes)
+		if mode != tt.mode || mixed != tt.mixed {
+			t.Errorf("mergedMode(%v) = %s, %v; want %s, %v", tt.modes, mode, mixed, tt.mode, tt.mixed)
+		}
+	}
+}
+
+func TestParseAllLinesSumsCounts(t *testing.T) {
+	unit := writeProfile(t, "unit.out", "mode: count\ninternal/core/foo.go:1.1,2.2 1 3\n")
+	e2e := writeProfile(t, "e2e.out", "mode: atomic\ninternal/core/foo.go:1.1,2.2 1 4\ninternal/core/foo.go:4.1,4.9 1 0\n")
+
+	lines, err := (Parser{}).ParseAllLines([]string{unit, e2e})
+	if err != nil {
+		t.Fatalf("parse lines: %v", err)
+	}
+	got := lines["internal/core/foo.go"]
+	if got[1] != 7 || got[2] != 7 || got[4] != 0 {
+		t.Fatalf("expected counts summed across profiles, got %v", got)
+	}
+}
+
+func TestParseAllLinesDowngradesMixedModes(t *testing.T) {
+	unit := writeProfile(t, "unit.out", "mode: count\ninternal/core/foo.go:1.1,2.2 1 9\ninternal/core/foo.go:4.1,4.9 1 0\n")
+	e2e := writeProfile(t, "e2e.out", "mode: set\ninternal/core/foo.go:1.1,2.2 1 1\n")
+
+	lines, err := (Parser{}).ParseAllLines([]string{unit, e2e})
+	if err != nil {
+		t.Fatalf("parse lines: %v", err)
+	}
+	got := lines["internal/core/foo.go"]
+	if got[1] != 1 || got[2] != 1 || got[4] != 0 {
+		t.Fatalf("expected set-mode hits, got %v", got)
+	}
+}
+
+func TestModeWarnings(t *testing.T) {
+	count := writeProfile(t, "unit.out", "mode: count\n")
+	atomic := writeProfile(t, "race.out", "mode: atomic\n")
+	set := writeProfile(t, "e2e.out", "mode: set\n")
+
+	warnings, err := (Parser{}).ModeWarnings([]string{count, atomic})
+	if err != nil || warnings != nil {
+		t.Fatalf("count and atomic merge without warning, got %v, %v", warnings, err)
+	}
+
+	warnings, err = (Parser{}).ModeWarnings([]string{count, set})
+	if err != nil {
+		t.Fatalf("mode warnings: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "count: "+count+"; set: "+set) {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+
+	if _, err := (Parser{}).ModeWarnings([]string{writeProfile(t, "bad.out", "oops\n")}); err == nil {
+		t.Fatal("expected error for a profile without a mode line")
+	}
+}
@@ -86,6 +86,7 @@ func parseProfile(path string) (map[string]map[string]domain.CoverageStat, error
 		line := scanner.Text()
 		lineNo++
 		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff") // byte order mark from Windows editors
 			if !strings.HasPrefix(line, "mode:") {
 				return nil, fmt.Errorf("invalid coverage mode line")
 			}
@@ -117,15 +118,11 @@ func parseProfile(path string) (map[string]map[string]domain.CoverageStat, error
 }
 
 func parseLine(line string) (string, string, int, int, error) {
-	parts := strings.Fields(line)
-	if len(parts) < 3 {
+	filePart, _, stmtPart, countPart, ok := splitProfileLine(line)
+	if !ok {
 		return "", "", 0, 0, fmt.Errorf("invalid coverage line")
 	}
-	filePart := parts[0]
-	stmtPart := parts[1]
-	countPart := parts[2]
-
-	filePath := strings.SplitN(filePart, ":", 2)[0]
+	filePath := filePart[:strings.LastIndex(filePart, ":")]
 	stmtCount, err := strconv.Atoi(stmtPart)
 	if err != nil {
 		return "", "", 0, 0, fmt.Errorf("invalid statement count")
@@ -141,3 +138,25 @@ func parseLine(line string) (string, string, int, int, error) {
 	}
 	return filePath, filePart, covered, stmtCount, nil
 }
+
+// splitProfileLine splits "file:span numStmts count" from the right, so a
+// file name containing spaces or a Windows drive colon ("C:\src\a.go") stays
+// intact and a CRLF line ending is ignored. block is "file:span".
+func splitProfileLine(line string) (block, span, stmts, count string, ok bool) {
+	line = strings.TrimSpace(line)
+	i := strings.LastIndexAny(line, " \t")
+	if i < 0 {
+		return "", "", "", "", false
+	}
+	rest, count := strings.TrimRight(line[:i], " \t"), line[i+1:]
+	j := strings.LastIndexAny(rest, " \t")
+	if j < 0 {
+		return "", "", "", "", false
+	}
+	block, stmts = strings.TrimRight(rest[:j], " \t"), rest[j+1:]
+	k := strings.LastIndex(block, ":")
+	if k <= 0 {
+		return "", "", "", "", false
+	}
+	return block, block[k+1:], stmts, count, true
+}
@@ -110,3 +110,84 @@ func TestParseAllMergesProfiles(t *testing.T) {
 		t.Fatalf("expected covered 2, got %d", stat.Covered)
 	}
 }
+
+func TestParseLines(t *testing.T) {
+	content := "mode: count\n" +
+		"internal/core/foo.go:3.2,5.4 2 0\n" +
+		"internal/core/foo.go:5.6,6.8 1 4\n"
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "coverage.out")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	lines, err := (Parser{}).ParseLines(path)
+	if err != nil {
+		t.Fatalf("parse lines: %v", err)
+	}
+	got := lines["internal/core/foo.go"]
+	if len(got) != 4 || got[3] != 0 || got[4] != 0 || got[5] != 4 || got[6] != 4 {
+		t.Fatalf("unexpected line coverage: %v", got)
+	}
+}
+
+func TestParseWindowsProfile(t *testing.T) {
+	// Written on Windows: CRLF endings, and a file outside any module keeps
+	// its drive path, which may contain spaces.
+	content := "\ufeffmode: atomic\r\n" +
+		"github.com/acme/app/internal/core/foo.go:1.2,3.4 2 1\r\n" +
+		"github.com/acme/app/internal/core/foo.go:5.6,7.8 3 0\r\n" +
+		"C:\\work\\My App\\cmd\\main.go:1.2,3.4 1 1\r\n"
+
+	path := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	stats, err := (Parser{}).Parse(path)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := stats["github.com/acme/app/internal/core/foo.go"]; got.Total != 5 || got.Covered != 2 {
+		t.Fatalf("unexpected core stats: %+v", got)
+	}
+	if got := stats[`C:\work\My App\cmd\main.go`]; got.Total != 1 || got.Covered != 1 {
+		t.Fatalf("unexpected drive path stats: %v", stats)
+	}
+
+	lines, err := (Parser{}).ParseLines(path)
+	if err != nil {
+		t.Fatalf("parse lines: %v", err)
+	}
+	if got := lines[`C:\work\My App\cmd\main.go`]; got[1] != 1 || got[3] != 1 {
+		t.Fatalf("unexpected line coverage: %v", lines)
+	}
+}
+
+func TestParseAllBlocksMergesProfiles(t *testing.T) {
+	tmp := t.TempDir()
+	first := filepath.Join(tmp, "a.out")
+	second := filepath.Join(tmp, "b.out")
+	if err := os.WriteFile(first, []byte("mode: set\npkg/a.go:5.2,7.3 2 0\npkg/a.go:1.2,3.4 1 1\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(second, []byte("mode: set\npkg/a.go:5.2,7.3 2 1\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	blocks, err := (Parser{}).ParseAllBlocks([]string{first, second})
+	if err != nil {
+		t.Fatalf("parse blocks: %v", err)
+	}
+	got := blocks["pkg/a.go"]
+	if len(got) != 2 {
+		t.Fatalf("expected 2 blocks, got %+v", got)
+	}
+	if got[0].Lines.Start != 1 || got[0].Lines.End != 3 || got[0].Stat.Covered != 1 {
+		t.Fatalf("unexpected first block: %+v", got[0])
+	}
+	if got[1].Lines.Start != 5 || got[1].Lines.End != 7 || got[1].Stat.Covered != 2 || got[1].Stat.Total != 2 {
+		t.Fatalf("unexpected merged block: %+v", got[1])
+	}
+}
@@ -0,0 +1,143 @@
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// LinesChangedSince blames each file and returns the line ranges whose last
+// commit is at or after since. Uncommitted lines count as changed now.
+// Files git cannot blame (untracked, generated, outside the repository) are
+// skipped.
+func (g GitDiff) LinesChangedSince(ctx context.Context, files []string, since time.Time) (map[string][]domain.LineRange, error) {
+	changed := make(map[string][]domain.LineRange)
+	err := g.blameFiles(ctx, files, func(file string, lines []blamedLine) {
+		var ranges []domain.LineRange
+		for _, l := range lines {
+			if l.committed.Before(since) {
+				continue
+			}
+			if n := len(ranges); n > 0 && ranges[n-1].End == l.final-1 {
+				ranges[n-1].End = l.final
+			} else {
+				ranges = append(ranges, domain.LineRange{Start: l.final, End: l.final})
+			}
+		}
+		if len(ranges) > 0 {
+			changed[file] = ranges
+		}
+	})
+	return changed, err
+}
+
+// BlameLines attributes every line of each file to the commit that last
+// changed it. Files git cannot blame are skipped.
+func (g GitDiff) BlameLines(ctx context.Context, files []string) (map[string]map[int]domain.BlameLine, error) {
+	blame := make(map[string]map[int]domain.BlameLine)
+	err := g.blameFiles(ctx, files, func(file string, lines []blamedLine) {
+		byLine := make(map[int]domain.BlameLine, len(lines))
+		for _, l := range lines {
+			byLine[l.final] = l.BlameLine
+		}
+		blame[file] = byLine
+	})
+	return blame, err
+}
+
+var (
+	_ application.LineAgeProvider   = GitDiff{}
+	_ application.LineBlameProvider = GitDiff{}
+)
+
+// blameFiles runs `git blame --line-porcelain` on each module-relative file
+// from the module root and hands the parsed lines to fn.
+func (g GitDiff) blameFiles(ctx context.Context, files []string, fn func(file string, lines []blamedLine)) error {
+	moduleRoot, err := g.Module.ModuleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	run := g.exec()
+	if _, err := run(ctx, moduleRoot, []string{"rev-parse", "--is-inside-work-tree"}); err != nil {
+		return fmt.Errorf("git blame needs a git repository: %w", err)
+	}
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		out, err := run(ctx, moduleRoot, []string{"blame", "--line-porcelain", "--", filepath.FromSlash(file)})
+		if err != nil {
+			continue
+		}
+		fn(file, parseBlame(out))
+	}
+	return nil
+}
+
+// blamedLine is one line of blame output: its number in the current file,
+// the commit that last changed it, and that commit's committer time.
+type blamedLine struct {
+	domain.BlameLine
+	final     int
+	committed time.Time
+}
+
+// parseBlame reads `git blame --line-porcelain` output. Each line's block
+// opens with "<sha> <orig> <final>", carries the commit's headers, and ends
+// with the tab-prefixed source line.
+func parseBlame(out []byte) []blamedLine {
+	var lines []blamedLine
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	header := true
+	var cur blamedLine
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "\t") {
+			if cur.final > 0 {
+				lines = append(lines, cur)
+			}
+			header, cur = true, blamedLine{}
+			continue
+		}
+		if header {
+			header = false
+			if fields := strings.Fields(line); len(fields) >= 3 {
+				cur.Commit = fields[0]
+				cur.final, _ = strconv.Atoi(fields[2])
+			}
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			cur.Author = value
+		case "author-mail":
+			cur.Email = strings.Trim(value, "<>")
+		case "author-time":
+			cur.Time = unixTime(value)
+		case "committer-time":
+			cur.committed = unixTime(value)
+		case "summary":
+			cur.Summary = value
+		}
+	}
+	return lines
+}
+
+func unixTime(s string) time.Time {
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
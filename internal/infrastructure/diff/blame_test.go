@@ -0,0 +1,104 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
+)
+
+// blameLine renders one `git blame --line-porcelain` block.
+func blameLine(final int, committed time.Time, content string) string {
+	return fmt.Sprintf("%040x %d %d\nauthor Alice\nauthor-mail <alice@example.com>\nauthor-time %d\ncommitter c\ncommitter-time %d\nsummary Add core\nfilename a.go\n\t%s\n",
+		final, final, final, committed.Unix(), committed.Unix(), content)
+}
+
+func TestGitDiffLinesChangedSinceRanges(t *testing.T) {
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	old := since.AddDate(-1, 0, 0)
+	recent := since.AddDate(0, 1, 0)
+	out := blameLine(1, old, "package core") +
+		blameLine(2, recent, "func A() {") +
+		blameLine(3, recent, "\treturn") +
+		blameLine(4, old, "}") +
+		blameLine(5, since, "var x = 1")
+
+	diff := GitDiff{
+		Module: gotool.ModuleResolver{},
+		Exec: func(context.Context, string, []string) ([]byte, error) {
+			return []byte(out), nil
+		},
+	}
+	changed, err := diff.LinesChangedSince(context.Background(), []string{"a.go"}, since)
+	if err != nil {
+		t.Fatalf("lines changed since: %v", err)
+	}
+	want := []domain.LineRange{{Start: 2, End: 3}, {Start: 5, End: 5}}
+	if !reflect.DeepEqual(changed["a.go"], want) {
+		t.Fatalf("expected %v, got %v", want, changed["a.go"])
+	}
+}
+
+func TestGitDiffLinesChangedSince(t *testing.T) {
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	diff := GitDiff{
+		Module: gotool.ModuleResolver{},
+		Exec: func(ctx context.Context, dir string, args []string) ([]byte, error) {
+			switch {
+			case args[0] == "rev-parse":
+				return []byte("true\n"), nil
+			case strings.HasSuffix(args[len(args)-1], "untracked.go"):
+				return nil, errors.New("no such path in HEAD")
+			default:
+				return []byte(blameLine(1, since.AddDate(0, 0, 1), "package core")), nil
+			}
+		},
+	}
+	changed, err := diff.LinesChangedSince(context.Background(), []string{"internal/core/a.go", "internal/core/untracked.go"}, since)
+	if err != nil {
+		t.Fatalf("lines changed since: %v", err)
+	}
+	want := map[string][]domain.LineRange{"internal/core/a.go": {{Start: 1, End: 1}}}
+	if !reflect.DeepEqual(changed, want) {
+		t.Fatalf("expected %v, got %v", want, changed)
+	}
+}
+
+func TestGitDiffLinesChangedSinceOutsideRepository(t *testing.T) {
+	diff := GitDiff{
+		Module: gotool.ModuleResolver{},
+		Exec: func(context.Context, string, []string) ([]byte, error) {
+			return nil, errors.New("not a git repository")
+		},
+	}
+	if _, err := diff.LinesChangedSince(context.Background(), []string{"a.go"}, time.Now()); err == nil {
+		t.Fatal("expected error outside a git repository")
+	}
+}
+
+func TestGitDiffBlameLines(t *testing.T) {
+	authored := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	diff := GitDiff{
+		Module: gotool.ModuleResolver{},
+		Exec: func(ctx context.Context, dir string, args []string) ([]byte, error) {
+			return []byte(blameLine(1, authored, "package core") + blameLine(2, authored, "")), nil
+		},
+	}
+	blame, err := diff.BlameLines(context.Background(), []string{"internal/core/a.go"})
+	if err != nil {
+		t.Fatalf("blame lines: %v", err)
+	}
+	want := domain.BlameLine{Commit: fmt.Sprintf("%040x", 2), Author: "Alice", Email: "alice@example.com", Time: authored, Summary: "Add core"}
+	if got := blame["internal/core/a.go"][2]; got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if len(blame["internal/core/a.go"]) != 2 {
+		t.Fatalf("expected 2 blamed lines, got %v", blame)
+	}
+}
@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// FileList reads changed files from an exported list, one path per line,
+// for pipelines that have no .git directory. Path "-" reads from Stdin.
+// Blank lines and lines starting with '#' are ignored.
+type FileList struct {
+	Path  string
+	Stdin io.Reader
+}
+
+// ChangedFiles returns the listed files. The base ref is ignored; the list
+// already describes the change.
+func (f FileList) ChangedFiles(_ context.Context, _ string) ([]string, error) {
+	if f.Path == "-" {
+		stdin := f.Stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		return readFileList(stdin)
+	}
+
+	cleanPath, err := pathutil.ValidatePath(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid diff.files_from path: %w", err)
+	}
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("open changed files list: %w", err)
+	}
+	defer file.Close()
+	return readFileList(file)
+}
+
+func readFileList(r io.Reader) ([]string, error) {
+	var files []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		files = append(files, filepath.Clean(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read changed files list: %w", err)
+	}
+	return files, nil
+}
+
+// FromFileList lets diff.files_from replace git as the source of changed files.
+func (g GitDiff) FromFileList(path string) application.DiffProvider {
+	return FileList{Path: path}
+}
+
+var (
+	_ application.DiffProvider         = FileList{}
+	_ application.FileListDiffProvider = GitDiff{}
+)
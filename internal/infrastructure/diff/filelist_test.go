@@ -0,0 +1,50 @@
+package diff
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileListChangedFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changed.txt")
+	content := "# exported by CI\ninternal/core/a.go\n\n./internal/api/b.go\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	files, err := FileList{Path: path}.ChangedFiles(context.Background(), "ignored")
+	if err != nil {
+		t.Fatalf("changed files: %v", err)
+	}
+	if len(files) != 2 || files[0] != "internal/core/a.go" || files[1] != "internal/api/b.go" {
+		t.Fatalf("unexpected files: %v", files)
+	}
+}
+
+func TestFileListStdin(t *testing.T) {
+	list := FileList{Path: "-", Stdin: strings.NewReader("a.go\nb.go\n")}
+	files, err := list.ChangedFiles(context.Background(), "")
+	if err != nil {
+		t.Fatalf("changed files: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %v", files)
+	}
+}
+
+func TestFileListMissingFile(t *testing.T) {
+	list := FileList{Path: filepath.Join(t.TempDir(), "missing.txt")}
+	if _, err := list.ChangedFiles(context.Background(), ""); err == nil {
+		t.Fatal("expected error for missing list")
+	}
+}
+
+func TestGitDiffFromFileList(t *testing.T) {
+	provider := GitDiff{}.FromFileList("changed.txt")
+	if list, ok := provider.(FileList); !ok || list.Path != "changed.txt" {
+		t.Fatalf("expected FileList provider, got %#v", provider)
+	}
+}
@@ -2,6 +2,8 @@ package diff
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -13,6 +15,7 @@ import (
 type GitDiff struct {
 	Module gotool.ModuleInfo
 	Exec   func(ctx context.Context, dir string, args []string) ([]byte, error)
+	Getenv func(key string) string
 }
 
 func (g GitDiff) ChangedFiles(ctx context.Context, base string) ([]string, error) {
@@ -20,15 +23,12 @@ func (g GitDiff) ChangedFiles(ctx context.Context, base string) ([]string, error
 	if err != nil {
 		return nil, err
 	}
-	if base == "" {
-		base = "origin/main"
+	base, err = g.resolveBase(ctx, moduleRoot, base)
+	if err != nil {
+		return nil, err
 	}
 	args := []string{"diff", "--name-only", base + "...HEAD"}
-	execFn := g.Exec
-	if execFn == nil {
-		execFn = runGitOutput
-	}
-	out, err := execFn(ctx, moduleRoot, args)
+	out, err := g.exec()(ctx, moduleRoot, args)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +46,69 @@ func (g GitDiff) ChangedFiles(ctx context.Context, base string) ([]string, error
 
 var _ application.DiffProvider = GitDiff{}
 
+// AutoBase is the diff.base value that resolves the comparison point from
+// CI environment variables or the repository's default branch.
+const AutoBase = "auto"
+
+// baseRefEnvVars name CI variables holding the target branch of a pull or
+// merge request, in lookup order.
+var baseRefEnvVars = []string{
+	"GITHUB_BASE_REF",
+	"CI_MERGE_REQUEST_TARGET_BRANCH_NAME",
+	"CI_MERGE_REQUEST_TARGET_BRANCH",
+}
+
+// resolveBase turns a configured base into a concrete git ref. An empty base
+// means origin/main; "auto" resolves to the merge-base of HEAD and the target
+// branch (from CI variables, else origin's default branch).
+func (g GitDiff) resolveBase(ctx context.Context, dir, base string) (string, error) {
+	switch base {
+	case "":
+		return "origin/main", nil
+	case AutoBase:
+	default:
+		return base, nil
+	}
+
+	target := g.targetBranch(ctx, dir)
+	out, err := g.exec()(ctx, dir, []string{"merge-base", target, "HEAD"})
+	if err != nil {
+		return "", fmt.Errorf("resolve diff base: merge-base %s HEAD: %s", target, strings.TrimSpace(string(out)))
+	}
+	sha := strings.TrimSpace(string(out))
+	if sha == "" {
+		return "", fmt.Errorf("resolve diff base: no merge-base between %s and HEAD", target)
+	}
+	return sha, nil
+}
+
+// targetBranch returns the remote ref a change will merge into.
+func (g GitDiff) targetBranch(ctx context.Context, dir string) string {
+	getenv := g.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+	for _, key := range baseRefEnvVars {
+		if branch := strings.TrimSpace(getenv(key)); branch != "" {
+			return "origin/" + branch
+		}
+	}
+	out, err := g.exec()(ctx, dir, []string{"symbolic-ref", "--quiet", "refs/remotes/origin/HEAD"})
+	if err == nil {
+		if ref := strings.TrimSpace(string(out)); strings.HasPrefix(ref, "refs/remotes/") {
+			return strings.TrimPrefix(ref, "refs/remotes/")
+		}
+	}
+	return "origin/main"
+}
+
+func (g GitDiff) exec() func(ctx context.Context, dir string, args []string) ([]byte, error) {
+	if g.Exec != nil {
+		return g.Exec
+	}
+	return runGitOutput
+}
+
 func runGitOutput(ctx context.Context, dir string, args []string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = dir
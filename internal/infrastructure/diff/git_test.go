@@ -247,3 +247,68 @@ func TestRunGitOutput(t *testing.T) {
 		t.Fatalf("expected git version output, got: %s", string(out))
 	}
 }
+
+func TestGitDiffAutoBase(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		symbolic   string
+		wantTarget string
+	}{
+		{name: "github base ref", env: map[string]string{"GITHUB_BASE_REF": "develop"}, wantTarget: "origin/develop"},
+		{name: "gitlab target branch", env: map[string]string{"CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "release"}, wantTarget: "origin/release"},
+		{name: "remote default branch", symbolic: "refs/remotes/origin/trunk\n", wantTarget: "origin/trunk"},
+		{name: "fallback", wantTarget: "origin/main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mergeBaseTarget string
+			var diffArgs []string
+			diff := GitDiff{
+				Module: gotool.ModuleResolver{},
+				Getenv: func(key string) string { return tt.env[key] },
+				Exec: func(ctx context.Context, dir string, args []string) ([]byte, error) {
+					switch args[0] {
+					case "symbolic-ref":
+						if tt.symbolic == "" {
+							return nil, errors.New("not a symbolic ref")
+						}
+						return []byte(tt.symbolic), nil
+					case "merge-base":
+						mergeBaseTarget = args[1]
+						return []byte("abc123\n"), nil
+					default:
+						diffArgs = args
+						return []byte("file.go\n"), nil
+					}
+				},
+			}
+			if _, err := diff.ChangedFiles(context.Background(), AutoBase); err != nil {
+				t.Fatalf("changed files: %v", err)
+			}
+			if mergeBaseTarget != tt.wantTarget {
+				t.Fatalf("merge-base target = %q, want %q", mergeBaseTarget, tt.wantTarget)
+			}
+			if diffArgs[len(diffArgs)-1] != "abc123...HEAD" {
+				t.Fatalf("expected diff against merge-base, got %v", diffArgs)
+			}
+		})
+	}
+}
+
+func TestGitDiffAutoBaseMergeBaseError(t *testing.T) {
+	diff := GitDiff{
+		Module: gotool.ModuleResolver{},
+		Getenv: func(string) string { return "" },
+		Exec: func(ctx context.Context, dir string, args []string) ([]byte, error) {
+			if args[0] == "merge-base" {
+				return []byte("fatal: Not a valid object name origin/main"), errors.New("exit status 128")
+			}
+			return nil, errors.New("no symbolic ref")
+		},
+	}
+	_, err := diff.ChangedLines(context.Background(), AutoBase)
+	if err == nil || !strings.Contains(err.Error(), "resolve diff base") {
+		t.Fatalf("expected resolve error, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// ChangedLines returns the line ranges added or modified on HEAD relative to
+// base, keyed by file path. Deleted files and pure deletions are omitted.
+func (g GitDiff) ChangedLines(ctx context.Context, base string) (map[string][]domain.LineRange, error) {
+	moduleRoot, err := g.Module.ModuleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base, err = g.resolveBase(ctx, moduleRoot, base)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"diff", "-U0", "--no-color", "--no-ext-diff", base + "...HEAD"}
+	out, err := g.exec()(ctx, moduleRoot, args)
+	if err != nil {
+		return nil, err
+	}
+	return parseUnifiedDiff(out)
+}
+
+// parseUnifiedDiff extracts new-side hunk ranges from `git diff -U0` output.
+func parseUnifiedDiff(out []byte) (map[string][]domain.LineRange, error) {
+	changed := make(map[string][]domain.LineRange)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var current string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			target := strings.TrimPrefix(line, "+++ ")
+			if target == "/dev/null" {
+				current = ""
+				continue
+			}
+			current = filepath.ToSlash(filepath.Clean(strings.TrimPrefix(target, "b/")))
+		case strings.HasPrefix(line, "@@ ") && current != "":
+			r, ok, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				changed[current] = append(changed[current], r)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// parseHunkHeader parses the new-side range of "@@ -a,b +c,d @@". It
+// reports ok=false for hunks that only delete lines.
+func parseHunkHeader(header string) (domain.LineRange, bool, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return domain.LineRange{}, false, fmt.Errorf("invalid hunk header: %q", header)
+	}
+	spec := strings.TrimPrefix(fields[2], "+")
+	startStr, countStr, hasCount := strings.Cut(spec, ",")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return domain.LineRange{}, false, fmt.Errorf("invalid hunk header: %q", header)
+	}
+	count := 1
+	if hasCount {
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			return domain.LineRange{}, false, fmt.Errorf("invalid hunk header: %q", header)
+		}
+	}
+	if count == 0 {
+		return domain.LineRange{}, false, nil
+	}
+	return domain.LineRange{Start: start, End: start + count - 1}, true, nil
+}
+
+var _ application.LineDiffProvider = GitDiff{}
@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
+)
+
+const sampleUnifiedDiff = `diff --git a/internal/core/a.go b/internal/core/a.go
+index 1111111..2222222 100644
+--- a/internal/core/a.go
++++ b/internal/core/a.go
+@@ -10,0 +11,3 @@ func A() {
++	x := 1
++	y := 2
++	return x + y
+@@ -40 +43 @@ func B() {
+-	old()
++	new()
+@@ -50,2 +52,0 @@ func C() {
+-	gone()
+-	gone()
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,3 +0,0 @@
+-package old
+diff --git a/new.go b/new.go
+new file mode 100644
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,2 @@
++package main
++func main() {}
+`
+
+func TestGitDiffChangedLines(t *testing.T) {
+	var capturedArgs []string
+	diff := GitDiff{
+		Module: gotool.ModuleResolver{},
+		Exec: func(ctx context.Context, dir string, args []string) ([]byte, error) {
+			capturedArgs = args
+			return []byte(sampleUnifiedDiff), nil
+		},
+	}
+	changed, err := diff.ChangedLines(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("changed lines: %v", err)
+	}
+	if !strings.Contains(strings.Join(capturedArgs, " "), "-U0") {
+		t.Fatalf("expected -U0 in args, got %v", capturedArgs)
+	}
+
+	want := map[string][]domain.LineRange{
+		"internal/core/a.go": {{Start: 11, End: 13}, {Start: 43, End: 43}},
+		"new.go":             {{Start: 1, End: 2}},
+	}
+	if len(changed) != len(want) {
+		t.Fatalf("expected %d files, got %v", len(want), changed)
+	}
+	for file, ranges := range want {
+		got := changed[file]
+		if len(got) != len(ranges) {
+			t.Fatalf("%s: expected %v, got %v", file, ranges, got)
+		}
+		for i := range ranges {
+			if got[i] != ranges[i] {
+				t.Fatalf("%s: expected %v, got %v", file, ranges, got)
+			}
+		}
+	}
+}
+
+func TestParseHunkHeaderInvalid(t *testing.T) {
+	if _, _, err := parseHunkHeader("@@ -1 x @@"); err == nil {
+		t.Fatal("expected error for malformed header")
+	}
+}
@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// CI variables holding each metadata field, in lookup order: GitHub Actions,
+// GitLab CI, Bitbucket Pipelines.
+var (
+	commitEnvVars = []string{"GITHUB_SHA", "CI_COMMIT_SHA", "BITBUCKET_COMMIT"}
+	// GITHUB_HEAD_REF is the source branch of a pull request; GITHUB_REF_NAME
+	// there would be "<n>/merge".
+	branchEnvVars = []string{"GITHUB_HEAD_REF", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_BRANCH", "BITBUCKET_BRANCH"}
+	tagEnvVars    = []string{"CI_COMMIT_TAG", "BITBUCKET_TAG"}
+	prEnvVars     = []string{"CI_MERGE_REQUEST_IID", "BITBUCKET_PR_ID"}
+	runIDEnvVars  = []string{"GITHUB_RUN_ID", "CI_PIPELINE_ID", "BITBUCKET_BUILD_NUMBER"}
+	actorEnvVars  = []string{"GITHUB_ACTOR", "GITLAB_USER_LOGIN", "BITBUCKET_STEP_TRIGGERER_UUID"}
+)
+
+// RunMetadata reads the current commit, branch, tag, pull request, and CI run
+// id. CI variables take precedence because CI checkouts are often detached;
+// git fills in whatever they leave unset. Fields that cannot be determined
+// are left empty.
+func (g GitDiff) RunMetadata(ctx context.Context) application.RunMetadata {
+	getenv := g.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+	meta := application.RunMetadata{
+		Commit: firstEnv(getenv, commitEnvVars),
+		Branch: firstEnv(getenv, branchEnvVars),
+		Tag:    firstEnv(getenv, tagEnvVars),
+		RunID:  firstEnv(getenv, runIDEnvVars),
+	}
+	if name := getenv("GITHUB_REF_NAME"); name != "" {
+		switch getenv("GITHUB_REF_TYPE") {
+		case "tag":
+			if meta.Tag == "" {
+				meta.Tag = name
+			}
+		case "branch":
+			if meta.Branch == "" && !strings.HasPrefix(getenv("GITHUB_REF"), "refs/pull/") {
+				meta.Branch = name
+			}
+		}
+	}
+	meta.PR = githubPRNumber(getenv("GITHUB_REF"))
+	if meta.PR == 0 {
+		if n, err := strconv.Atoi(firstEnv(getenv, prEnvVars)); err == nil {
+			meta.PR = n
+		}
+	}
+
+	if meta.Commit == "" {
+		meta.Commit = g.gitLine(ctx, "rev-parse", "HEAD")
+	}
+	if meta.Branch == "" {
+		meta.Branch = g.gitLine(ctx, "symbolic-ref", "--quiet", "--short", "HEAD")
+	}
+	if meta.Tag == "" {
+		meta.Tag = g.gitLine(ctx, "describe", "--tags", "--exact-match", "HEAD")
+	}
+	return meta
+}
+
+var _ application.RunMetadataProvider = GitDiff{}
+
+// Actor names who is running coverctl: the CI user that triggered the run,
+// else the git identity ("Name <email>"), else the login name.
+func (g GitDiff) Actor(ctx context.Context) string {
+	getenv := g.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+	if actor := firstEnv(getenv, actorEnvVars); actor != "" {
+		return actor
+	}
+	name := g.gitLine(ctx, "config", "user.name")
+	email := g.gitLine(ctx, "config", "user.email")
+	switch {
+	case name != "" && email != "":
+		return name + " <" + email + ">"
+	case name != "" || email != "":
+		return name + email
+	}
+	return firstEnv(getenv, []string{"USER", "USERNAME"})
+}
+
+var _ application.ActorProvider = GitDiff{}
+
+// gitLine runs git in the working directory and returns its trimmed output,
+// or "" when the command fails (not a repository, detached HEAD, no tag).
+func (g GitDiff) gitLine(ctx context.Context, args ...string) string {
+	out, err := g.exec()(ctx, "", args)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func firstEnv(getenv func(string) string, keys []string) string {
+	for _, key := range keys {
+		if v := strings.TrimSpace(getenv(key)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// githubPRNumber extracts n from a pull request ref "refs/pull/<n>/merge".
+func githubPRNumber(ref string) int {
+	rest, ok := strings.CutPrefix(ref, "refs/pull/")
+	if !ok {
+		return 0
+	}
+	num, _, _ := strings.Cut(rest, "/")
+	n, err := strconv.Atoi(num)
+	if err != nil {
+		return 0
+	}
+	return n
+}
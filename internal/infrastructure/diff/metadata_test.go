@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func envMap(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestRunMetadataGitHubPullRequest(t *testing.T) {
+	g := GitDiff{
+		Getenv: envMap(map[string]string{
+			"GITHUB_SHA":      "abc123",
+			"GITHUB_REF":      "refs/pull/42/merge",
+			"GITHUB_REF_NAME": "42/merge",
+			"GITHUB_REF_TYPE": "branch",
+			"GITHUB_HEAD_REF": "feature/x",
+			"GITHUB_RUN_ID":   "9001",
+		}),
+		Exec: func(context.Context, string, []string) ([]byte, error) {
+			return nil, errors.New("no tag")
+		},
+	}
+	got := g.RunMetadata(context.Background())
+	want := application.RunMetadata{Commit: "abc123", Branch: "feature/x", PR: 42, RunID: "9001"}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRunMetadataGitLabTag(t *testing.T) {
+	g := GitDiff{
+		Getenv: envMap(map[string]string{
+			"CI_COMMIT_SHA":  "def456",
+			"CI_COMMIT_TAG":  "v1.2.0",
+			"CI_PIPELINE_ID": "77",
+		}),
+		Exec: func(_ context.Context, _ string, args []string) ([]byte, error) {
+			if args[0] == "symbolic-ref" {
+				return nil, errors.New("detached")
+			}
+			t.Fatalf("unexpected git call: %v", args)
+			return nil, nil
+		},
+	}
+	got := g.RunMetadata(context.Background())
+	want := application.RunMetadata{Commit: "def456", Tag: "v1.2.0", RunID: "77"}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRunMetadataFallsBackToGit(t *testing.T) {
+	g := GitDiff{
+		Getenv: envMap(nil),
+		Exec: func(_ context.Context, _ string, args []string) ([]byte, error) {
+			switch strings.Join(args, " ") {
+			case "rev-parse HEAD":
+				return []byte("0123abcd\n"), nil
+			case "symbolic-ref --quiet --short HEAD":
+				return []byte("main\n"), nil
+			default:
+				return []byte("fatal: no tag exactly matches"), errors.New("exit status 128")
+			}
+		},
+	}
+	got := g.RunMetadata(context.Background())
+	want := application.RunMetadata{Commit: "0123abcd", Branch: "main"}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestActor(t *testing.T) {
+	git := func(_ context.Context, _ string, args []string) ([]byte, error) {
+		switch strings.Join(args, " ") {
+		case "config user.name":
+			return []byte("Ada Lovelace\n"), nil
+		case "config user.email":
+			return []byte("ada@example.com\n"), nil
+		}
+		return nil, errors.New("exit status 1")
+	}
+	noGit := func(context.Context, string, []string) ([]byte, error) { return nil, errors.New("not a repository") }
+
+	cases := []struct {
+		name string
+		g    GitDiff
+		want string
+	}{
+		{"ci user", GitDiff{Getenv: envMap(map[string]string{"GITHUB_ACTOR": "octocat", "USER": "runner"}), Exec: git}, "octocat"},
+		{"git identity", GitDiff{Getenv: envMap(map[string]string{"USER": "ada"}), Exec: git}, "Ada Lovelace <ada@example.com>"},
+		{"login name", GitDiff{Getenv: envMap(map[string]string{"USER": "ada"}), Exec: noGit}, "ada"},
+	}
+	for _, tc := range cases {
+		if got := tc.g.Actor(context.Background()); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
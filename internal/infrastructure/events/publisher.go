@@ -0,0 +1,103 @@
+// Package events publishes domain events to a JSON Lines file and a
+// webhook.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/webhook"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// Record is the serialised form of one event, written as one JSON line and
+// sent in webhook batches.
+type Record struct {
+	Type       string             `json:"type"`
+	Source     string             `json:"source"` // Command that raised the event: "check" or "record"
+	OccurredAt time.Time          `json:"occurredAt"`
+	Data       domain.DomainEvent `json:"data"`
+}
+
+// Batch is the webhook payload: every event one command raised.
+type Batch struct {
+	Source string   `json:"source"`
+	Events []Record `json:"events"`
+}
+
+// Publisher writes events to the sinks named in application.EventsConfig.
+type Publisher struct {
+	httpClient *http.Client
+}
+
+// NewPublisher creates a publisher with the default webhook timeout.
+func NewPublisher() *Publisher {
+	return &Publisher{httpClient: webhook.NewClient()}
+}
+
+// NewPublisherWithHTTP creates a publisher with a custom HTTP client.
+func NewPublisherWithHTTP(httpClient *http.Client) *Publisher {
+	return &Publisher{httpClient: httpClient}
+}
+
+// Publish appends the events to cfg.File and posts them to cfg.Webhook,
+// trying both sinks even when one fails.
+func (p *Publisher) Publish(ctx context.Context, cfg application.EventsConfig, source string, events []domain.DomainEvent) error {
+	records := make([]Record, 0, len(events))
+	for _, e := range events {
+		records = append(records, Record{Type: e.EventType(), Source: source, OccurredAt: e.OccurredAt().UTC(), Data: e})
+	}
+
+	var errs []error
+	if cfg.File != "" {
+		if err := appendLines(cfg.File, records); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cfg.Webhook != "" {
+		if err := webhook.PostJSON(ctx, p.httpClient, "events webhook", cfg.Webhook, Batch{Source: source, Events: records}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// appendLines writes one JSON object per record to the end of path,
+// creating the file and its directory when missing.
+func appendLines(path string, records []Record) error {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid events file: %w", err)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(cleanPath), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(cleanPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) // #nosec G302 G304 - path is validated above; events are read by other tools
+	if err != nil {
+		return err
+	}
+	// One write keeps a batch contiguous when several runs append at once.
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var _ application.EventPublisher = (*Publisher)(nil)
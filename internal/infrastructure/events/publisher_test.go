@@ -0,0 +1,94 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestPublishFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "events.jsonl")
+	p := NewPublisher()
+	cfg := application.EventsConfig{File: path}
+	for i := 0; i < 2; i++ {
+		err := p.Publish(context.Background(), cfg, "check", []domain.DomainEvent{
+			domain.NewThresholdViolatedEvent("core", 70, 80),
+		})
+		if err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected two appended lines, got %d", len(lines))
+	}
+	data, _ := lines[0]["data"].(map[string]any)
+	if lines[0]["type"] != "ThresholdViolated" || lines[0]["source"] != "check" || data["domain"] != "core" || data["shortfall"] != 10.0 {
+		t.Fatalf("unexpected record %v", lines[0])
+	}
+}
+
+func TestPublishWebhook(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid JSON body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("COVERCTL_TEST_EVENTS_URL", server.URL)
+	err := NewPublisherWithHTTP(server.Client()).Publish(context.Background(), application.EventsConfig{Webhook: "${COVERCTL_TEST_EVENTS_URL}"}, "record", []domain.DomainEvent{
+		domain.NewCoverageRegressedEvent("core", 80, 70),
+		domain.NewCoverageImprovedEvent("api", 60, 65),
+	})
+	if err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	events, _ := got["events"].([]any)
+	if got["source"] != "record" || len(events) != 2 {
+		t.Fatalf("unexpected batch %v", got)
+	}
+}
+
+func TestPublishReportsEverySinkFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cfg := application.EventsConfig{File: dir, Webhook: server.URL}
+	err := NewPublisherWithHTTP(server.Client()).Publish(context.Background(), cfg, "check", []domain.DomainEvent{
+		domain.NewCoverageEvaluatedEvent("default", 80, true, 1, 0),
+	})
+	if err == nil || !strings.Contains(err.Error(), "502") || strings.Count(err.Error(), "\n") != 1 {
+		t.Fatalf("expected file and webhook errors, got %v", err)
+	}
+	if err := NewPublisher().Publish(context.Background(), application.EventsConfig{Webhook: "ftp://example.com"}, "check", nil); err == nil {
+		t.Fatal("expected an invalid URL error")
+	}
+}
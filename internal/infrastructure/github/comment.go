@@ -27,15 +27,7 @@ func FormatCoverageComment(result domain.Result, comparison *application.Compare
 	}
 
 	// Overall coverage table
-	var totalCovered, totalStatements int
-	for _, d := range result.Domains {
-		totalCovered += d.Covered
-		totalStatements += d.Total
-	}
-	overallPercent := 0.0
-	if totalStatements > 0 {
-		overallPercent = float64(totalCovered) / float64(totalStatements) * 100
-	}
+	overallPercent := result.OverallPercent()
 
 	b.WriteString("| Metric | Value |")
 	if comparison != nil {
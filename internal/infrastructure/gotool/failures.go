@@ -0,0 +1,113 @@
+package gotool
+
+import (
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// testFailures classifies the failing packages of a `go test -json` run as
+// build failures, test failures, or panics. Go 1.24 and later mark build
+// failures with FailedBuild; older toolchains are recognised by the
+// "[build failed]" line go test prints for the package.
+type testFailures struct {
+	order    []string
+	byPkg    map[string]*application.TestFailure
+	panicked map[string]bool
+}
+
+func (f *testFailures) observe(ev testEvent) {
+	pkg := ev.Package
+	switch ev.Action {
+	case "output":
+		if strings.HasPrefix(ev.Output, "panic: ") {
+			f.markPanic(pkg, ev.Test)
+		} else if ev.Test == "" && (strings.Contains(ev.Output, "[build failed]") || strings.Contains(ev.Output, "[setup failed]")) {
+			f.failure(pkg).Kind = application.TestFailureBuild
+		}
+	case "fail":
+		failure := f.failure(pkg)
+		switch {
+		case ev.FailedBuild != "":
+			failure.Kind = application.TestFailureBuild
+		case ev.Test != "" && failure.Kind == application.TestFailureTest:
+			addTest(failure, ev.Test)
+		}
+		if ev.Test == "" {
+			f.finished(pkg)
+		}
+	}
+}
+
+// markPanic records a panic in pkg, inside test when one was running.
+func (f *testFailures) markPanic(pkg, test string) {
+	failure := f.failure(pkg)
+	if failure.Kind == application.TestFailureBuild {
+		return
+	}
+	if failure.Kind != application.TestFailurePanic {
+		failure.Kind = application.TestFailurePanic
+		failure.Tests = nil
+	}
+	if test != "" {
+		addTest(failure, test)
+	}
+}
+
+// failure returns the entry for pkg, creating a test failure.
+func (f *testFailures) failure(pkg string) *application.TestFailure {
+	if f.byPkg == nil {
+		f.byPkg = map[string]*application.TestFailure{}
+	}
+	failure, ok := f.byPkg[pkg]
+	if !ok {
+		failure = &application.TestFailure{Package: pkg, Kind: application.TestFailureTest}
+		f.byPkg[pkg] = failure
+	}
+	return failure
+}
+
+// finished records that pkg's final result was a failure.
+func (f *testFailures) finished(pkg string) {
+	for _, seen := range f.order {
+		if seen == pkg {
+			return
+		}
+	}
+	f.order = append(f.order, pkg)
+}
+
+// addTest adds test to failure unless one of its subtests is listed
+// already; go test reports a parent as failed after its failing subtests.
+func addTest(failure *application.TestFailure, test string) {
+	for _, listed := range failure.Tests {
+		if listed == test || strings.HasPrefix(listed, test+"/") {
+			return
+		}
+	}
+	failure.Tests = append(failure.Tests, test)
+}
+
+// Failures lists the packages whose final result was a failure, in the
+// order they finished.
+func (f *testFailures) Failures() []application.TestFailure {
+	failures := make([]application.TestFailure, 0, len(f.order))
+	for _, pkg := range f.order {
+		failures = append(failures, *f.byPkg[pkg])
+	}
+	return failures
+}
+
+// Packages lists the failed packages, for a retry of just those.
+func (f *testFailures) Packages() []string {
+	return f.order
+}
+
+// wrap returns runErr as a *application.TestFailuresError when the run's
+// failures were classified.
+func (f *testFailures) wrap(runErr error) error {
+	if runErr == nil || len(f.order) == 0 {
+		return runErr
+	}
+	return &application.TestFailuresError{Failures: f.Failures(), Err: runErr}
+}
@@ -0,0 +1,57 @@
+package gotool
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func TestTestFailures(t *testing.T) {
+	var out strings.Builder
+	w := &testEventWriter{w: &out}
+	for _, line := range []string{
+		`{"Action":"build-output","ImportPath":"example.com/a [example.com/a.test]","Output":"a_test.go:3:1: undefined: x\n"}`,
+		`{"Action":"fail","Package":"example.com/a","FailedBuild":"example.com/a [example.com/a.test]"}`,
+		`{"Action":"fail","Package":"example.com/b","Test":"TestX/case"}`,
+		`{"Action":"fail","Package":"example.com/b","Test":"TestX"}`,
+		`{"Action":"fail","Package":"example.com/b","Test":"TestY"}`,
+		`{"Action":"fail","Package":"example.com/b"}`,
+		`{"Action":"output","Package":"example.com/c","Test":"TestZ","Output":"panic: boom\n"}`,
+		`{"Action":"fail","Package":"example.com/c","Test":"TestZ"}`,
+		`{"Action":"fail","Package":"example.com/c"}`,
+		`{"Action":"output","Package":"example.com/d","Output":"FAIL\texample.com/d [build failed]\n"}`,
+		`{"Action":"fail","Package":"example.com/d"}`,
+		`{"Action":"pass","Package":"example.com/e"}`,
+	} {
+		_, _ = io.WriteString(w, line+"\n")
+	}
+
+	want := []application.TestFailure{
+		{Package: "example.com/a", Kind: application.TestFailureBuild},
+		{Package: "example.com/b", Kind: application.TestFailureTest, Tests: []string{"TestX/case", "TestY"}},
+		{Package: "example.com/c", Kind: application.TestFailurePanic, Tests: []string{"TestZ"}},
+		{Package: "example.com/d", Kind: application.TestFailureBuild},
+	}
+	if got := w.failures.Failures(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("failures = %+v, want %+v", got, want)
+	}
+	if !strings.Contains(out.String(), "undefined: x") {
+		t.Fatalf("expected build output to pass through, got %q", out.String())
+	}
+
+	err := w.failures.wrap(errors.New("exit status 1"))
+	if got := application.TestFailuresOf(err); len(got) != 4 {
+		t.Fatalf("expected the error to carry 4 failures, got %v", got)
+	}
+	const msg = "example.com/a does not build; example.com/b: TestX/case, TestY failed; example.com/c: TestZ panicked; example.com/d does not build (exit status 1)"
+	if err.Error() != msg {
+		t.Fatalf("error = %q", err.Error())
+	}
+	if plain := (&testFailures{}).wrap(errors.New("signal: killed")); plain.Error() != "signal: killed" {
+		t.Fatalf("expected an unclassified error to pass through, got %v", plain)
+	}
+}
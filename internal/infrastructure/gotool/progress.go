@@ -0,0 +1,196 @@
+package gotool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// testEvent is one line of `go test -json` output.
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Output  string
+	Elapsed float64
+	// FailedBuild names the package that failed to build, on the fail
+	// event of a package whose build failed (Go 1.24+).
+	FailedBuild string
+}
+
+// progressStream consumes `go test -json` output and keeps a single live
+// line on w: packages finished out of total, the package running now, and
+// elapsed time. Output of failing packages is held back and replayed when
+// the run ends so a failure still explains itself.
+type progressStream struct {
+	mu       sync.Mutex
+	w        io.Writer
+	total    int
+	start    time.Time
+	now      func() time.Time
+	done     int
+	seen     map[string]bool
+	running  []string
+	output   map[string][]string
+	failed   []string
+	failures testFailures
+	partial  []byte
+	stop     chan struct{}
+	// onPackage, when set, receives each finished package's elapsed time.
+	onPackage func(pkg string, seconds float64)
+}
+
+func newProgressStream(w io.Writer, total int) *progressStream {
+	return &progressStream{
+		w:      w,
+		total:  total,
+		start:  time.Now(),
+		now:    time.Now,
+		seen:   map[string]bool{},
+		output: map[string][]string{},
+	}
+}
+
+// Tick redraws the line every interval so elapsed time moves while a slow
+// package runs. Finish stops it.
+func (p *progressStream) Tick(interval time.Duration) {
+	p.stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				p.render()
+				p.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Write implements io.Writer for the test process's stdout.
+func (p *progressStream) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.partial = append(p.partial, b...)
+	for {
+		i := bytes.IndexByte(p.partial, '\n')
+		if i < 0 {
+			break
+		}
+		p.handle(p.partial[:i])
+		p.partial = p.partial[i+1:]
+	}
+	p.render()
+	return len(b), nil
+}
+
+func (p *progressStream) handle(line []byte) {
+	var ev testEvent
+	if err := json.Unmarshal(line, &ev); err != nil || ev.Action == "" {
+		// Anything that is not an event, e.g. a build error printed
+		// before the test binary started, is kept for the failure replay.
+		if text := string(line); text != "" {
+			p.output[""] = append(p.output[""], text+"\n")
+		}
+		return
+	}
+	p.failures.observe(ev)
+	pkg := ev.Package
+	switch ev.Action {
+	case "build-output":
+		// Keyed by ImportPath ("pkg [pkg.test]"), not Package, so it is
+		// replayed with the unattributed output.
+		p.output[""] = append(p.output[""], ev.Output)
+	case "output":
+		p.started(pkg)
+		p.output[pkg] = append(p.output[pkg], ev.Output)
+	case "start", "run":
+		p.started(pkg)
+	case "pass", "fail", "skip":
+		if ev.Test != "" {
+			return
+		}
+		p.finished(pkg)
+		if p.onPackage != nil && ev.Action != "skip" {
+			p.onPackage(pkg, ev.Elapsed)
+		}
+		if ev.Action == "fail" {
+			p.failed = append(p.failed, pkg)
+		} else {
+			delete(p.output, pkg)
+		}
+	}
+}
+
+func (p *progressStream) started(pkg string) {
+	if pkg == "" || p.seen[pkg] {
+		return
+	}
+	p.seen[pkg] = true
+	p.running = append(p.running, pkg)
+}
+
+func (p *progressStream) finished(pkg string) {
+	p.started(pkg)
+	for i, r := range p.running {
+		if r == pkg {
+			p.running = append(p.running[:i], p.running[i+1:]...)
+			break
+		}
+	}
+	p.done++
+}
+
+func (p *progressStream) render() {
+	count := fmt.Sprintf("%d", p.done)
+	if p.total > 0 {
+		count = fmt.Sprintf("%d/%d", p.done, p.total)
+	}
+	line := fmt.Sprintf("Testing [%s] %s", count, formatElapsed(p.now().Sub(p.start)))
+	if n := len(p.running); n > 0 {
+		line += "  " + p.running[n-1]
+	}
+	fmt.Fprintf(p.w, "\r\033[K%s", line)
+}
+
+// Finish clears the progress line and, when the run failed, replays the
+// output of every failing package.
+func (p *progressStream) Finish(runErr error) {
+	if p.stop != nil {
+		close(p.stop)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.partial) > 0 {
+		p.handle(p.partial)
+		p.partial = nil
+	}
+	fmt.Fprint(p.w, "\r\033[K")
+	if runErr == nil {
+		fmt.Fprintf(p.w, "Tested %d packages in %s\n", p.done, formatElapsed(p.now().Sub(p.start)))
+		return
+	}
+	for _, line := range p.output[""] {
+		fmt.Fprint(p.w, line)
+	}
+	for _, pkg := range p.failed {
+		for _, line := range p.output[pkg] {
+			fmt.Fprint(p.w, line)
+		}
+	}
+}
+
+func formatElapsed(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,92 @@
+package gotool
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const progressEvents = `{"Action":"start","Package":"example.com/a"}
+{"Action":"start","Package":"example.com/b"}
+{"Action":"output","Package":"example.com/a","Output":"ok  \texample.com/a\n"}
+{"Action":"pass","Package":"example.com/a"}
+{"Action":"run","Package":"example.com/b","Test":"TestB"}
+{"Action":"output","Package":"example.com/b","Test":"TestB","Output":"    b_test.go:9: boom\n"}
+{"Action":"fail","Package":"example.com/b","Test":"TestB"}
+{"Action":"fail","Package":"example.com/b"}
+`
+
+func newTestStream(w *bytes.Buffer, total int) *progressStream {
+	p := newProgressStream(w, total)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.start = start
+	p.now = func() time.Time { return start.Add(75 * time.Second) }
+	return p
+}
+
+func TestProgressStreamCountsPackages(t *testing.T) {
+	var out bytes.Buffer
+	p := newTestStream(&out, 3)
+	// Feed a few bytes at a time to check partial lines are reassembled.
+	for data := []byte(progressEvents); len(data) > 0; {
+		n := min(len(data), 17)
+		_, _ = p.Write(data[:n])
+		data = data[n:]
+	}
+
+	if p.done != 2 {
+		t.Fatalf("done = %d, want 2", p.done)
+	}
+	if !strings.Contains(out.String(), "Testing [1/3] 1m15s  example.com/b") {
+		t.Fatalf("expected live line naming the running package, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "Testing [2/3] 1m15s") {
+		t.Fatalf("expected final count, got %q", out.String())
+	}
+}
+
+func TestProgressStreamFinishReplaysFailures(t *testing.T) {
+	var out bytes.Buffer
+	p := newTestStream(&out, 0)
+	_, _ = p.Write([]byte("# example.com/c\nc.go:1: syntax error\n" + progressEvents))
+	out.Reset()
+	p.Finish(errors.New("exit status 1"))
+
+	got := out.String()
+	if !strings.Contains(got, "b_test.go:9: boom") {
+		t.Errorf("expected failing package output, got %q", got)
+	}
+	if !strings.Contains(got, "c.go:1: syntax error") {
+		t.Errorf("expected non-event output, got %q", got)
+	}
+	if strings.Contains(got, "ok  \texample.com/a") {
+		t.Errorf("passing package output should not be replayed, got %q", got)
+	}
+}
+
+func TestProgressStreamFinishSuccess(t *testing.T) {
+	var out bytes.Buffer
+	p := newTestStream(&out, 0)
+	_, _ = p.Write([]byte(`{"Action":"pass","Package":"example.com/a"}` + "\n"))
+	out.Reset()
+	p.Finish(nil)
+	if got := out.String(); got != "\r\033[KTested 1 packages in 1m15s\n" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	cases := map[time.Duration]string{
+		1500 * time.Millisecond: "1s",
+		59 * time.Second:        "59s",
+		61 * time.Second:        "1m01s",
+		10 * time.Minute:        "10m00s",
+	}
+	for d, want := range cases {
+		if got := formatElapsed(d); got != want {
+			t.Errorf("formatElapsed(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
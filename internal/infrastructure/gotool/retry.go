@@ -0,0 +1,81 @@
+package gotool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// testEventWriter consumes `go test -json` output, passes the test output
+// through to w as plain text, and classifies the packages that failed so a
+// retry can re-run just those and the error can name them.
+type testEventWriter struct {
+	w        io.Writer
+	failures testFailures
+	partial  []byte
+}
+
+func (t *testEventWriter) Write(b []byte) (int, error) {
+	t.partial = append(t.partial, b...)
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if err := t.handle(t.partial[:i]); err != nil {
+			return 0, err
+		}
+		t.partial = t.partial[i+1:]
+	}
+	return len(b), nil
+}
+
+func (t *testEventWriter) handle(line []byte) error {
+	var ev testEvent
+	if err := json.Unmarshal(line, &ev); err != nil || ev.Action == "" {
+		_, err := t.w.Write(append(line, '\n'))
+		return err
+	}
+	t.failures.observe(ev)
+	if ev.Action == "output" || ev.Action == "build-output" {
+		_, err := io.WriteString(t.w, ev.Output)
+		return err
+	}
+	return nil
+}
+
+// appendProfile adds the blocks of the profile at src to the one at dst and
+// removes src. Blocks both profiles share are merged by every profile
+// reader, so the retried packages' coverage simply adds to the first run's.
+func appendProfile(dst, src string) error {
+	data, err := os.ReadFile(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer os.Remove(src)
+	if _, err := os.Stat(dst); errors.Is(err, fs.ErrNotExist) {
+		return os.WriteFile(dst, data, 0o600)
+	}
+	if bytes.HasPrefix(data, []byte("mode:")) {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		} else {
+			data = nil
+		}
+	}
+	f, err := os.OpenFile(dst, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
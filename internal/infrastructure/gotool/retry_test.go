@@ -0,0 +1,25 @@
+package gotool
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestTestEventWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &testEventWriter{w: &out}
+	_, _ = io.WriteString(w, "# example.com/c\n")
+	_, _ = io.WriteString(w, `{"Action":"output","Package":"example.com/a","Output":"--- FAIL: TestX\n"}`+"\n")
+	_, _ = io.WriteString(w, `{"Action":"fail","Package":"example.com/a","Test":"TestX"}`+"\n")
+	_, _ = io.WriteString(w, `{"Action":"fail","Package":"example.com/a","Elapsed":0.5}`+"\n")
+	_, _ = io.WriteString(w, `{"Action":"pass","Package":"example.com/b","Elapsed":1.5}`+"\n")
+
+	if got := out.String(); got != "# example.com/c\n--- FAIL: TestX\n" {
+		t.Fatalf("output = %q", got)
+	}
+	if !slices.Equal(w.failures.Packages(), []string{"example.com/a"}) {
+		t.Fatalf("failed = %v", w.failures.Packages())
+	}
+}
@@ -3,14 +3,18 @@ package gotool
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/domain"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/cmdrun"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/coverprofile"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
 )
 
 // Runner implements the CoverageRunner interface for Go projects.
@@ -19,6 +23,10 @@ type Runner struct {
 	Exec       func(ctx context.Context, dir string, args []string) error
 	ExecOutput func(ctx context.Context, dir string, args []string) ([]byte, error)
 	ExecEnv    func(ctx context.Context, dir string, env []string, cmd string, args []string) error
+	// ExecStream runs `go <args>` with stdout sent to w and, when env is
+	// non-nil, that environment; used for go test, whose output is parsed
+	// for progress, package times, and failures.
+	ExecStream func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error
 }
 
 // Name returns the runner's identifier.
@@ -61,30 +69,148 @@ func (r Runner) Run(ctx context.Context, opts application.RunOptions) (string, e
 		return "", err
 	}
 
-	coverpkg := buildCoverPkg(opts.Domains)
-	args := []string{"test", "-covermode=atomic", "-coverprofile=" + profilePath}
+	// A failed-package retry writes beside the profile and is appended to it.
+	runProfile := profilePath
+	if opts.AppendProfile {
+		runProfile = profilePath + ".retry"
+	}
+
+	coverpkg := r.coverPackages(ctx, moduleRoot, opts.Domains, opts.CoverPkg)
+	var failed []string
+	if len(opts.Matrix) > 0 {
+		failed, err = r.runMatrix(ctx, moduleRoot, runProfile, coverpkg, opts)
+	} else {
+		failed, err = r.runTests(ctx, moduleRoot, nil, testArgs(runProfile, coverpkg, opts.BuildFlags, opts.Packages), opts)
+		if err != nil {
+			err = fmt.Errorf("go test failed: %w", err)
+		}
+	}
+	if opts.AppendProfile {
+		if appendErr := appendProfile(profilePath, runProfile); appendErr != nil && err == nil {
+			err = appendErr
+		}
+	}
+	if err != nil {
+		if opts.Retry.OnlyFailed && len(failed) > 0 {
+			return "", &application.FailedPackagesError{Packages: failed, Err: err}
+		}
+		return "", err
+	}
+	return profilePath, nil
+}
+
+// testArgs builds the go test command line writing profile.
+func testArgs(profile, coverpkg string, flags application.BuildFlags, packages []string) []string {
+	args := []string{"test", "-covermode=atomic", "-coverprofile=" + profile}
 	if coverpkg != "" {
 		args = append(args, "-coverpkg="+coverpkg)
 	}
+	args = appendBuildFlags(args, flags)
+	// Use specified packages or default to all
+	if len(packages) > 0 {
+		return append(args, packages...)
+	}
+	return append(args, "./...")
+}
 
-	// Add build flags
-	args = appendBuildFlags(args, opts.BuildFlags)
+// runMatrix runs go test once per build variant, with the variant's GOOS,
+// GOARCH, and tags, writing coverage.<variant>.out beside profilePath, then
+// merges the variant profiles block by block into profilePath. A file that
+// a variant's build constraints leave out is absent from its profile rather
+// than uncovered in it, so the merged profile credits it with the coverage
+// of the variants that compile it. A variant for a foreign GOOS needs a
+// go_$GOOS_$GOARCH_exec wrapper on PATH to run its tests. Each variant runs
+// through runTests, so progress, package times, and failed-package retries
+// work as they do for a single run.
+func (r Runner) runMatrix(ctx context.Context, moduleRoot, profilePath, coverpkg string, opts application.RunOptions) ([]string, error) {
+	ext := filepath.Ext(profilePath)
+	profiles := make([]string, 0, len(opts.Matrix))
+	for _, variant := range opts.Matrix {
+		variantProfile := strings.TrimSuffix(profilePath, ext) + "." + pathutil.SafeFileName(variant.Name) + ext
+		flags := opts.BuildFlags
+		flags.Tags = joinTags(flags.Tags, variant.Tags)
+		env := os.Environ()
+		if variant.GOOS != "" {
+			env = append(env, "GOOS="+variant.GOOS)
+		}
+		if variant.GOARCH != "" {
+			env = append(env, "GOARCH="+variant.GOARCH)
+		}
+		if failed, err := r.runTests(ctx, moduleRoot, env, testArgs(variantProfile, coverpkg, flags, opts.Packages), opts); err != nil {
+			return failed, fmt.Errorf("go test failed for matrix variant %s: %w", variant.Name, err)
+		}
+		profiles = append(profiles, variantProfile)
+	}
 
-	// Use specified packages or default to all
-	if len(opts.Packages) > 0 {
-		args = append(args, opts.Packages...)
-	} else {
-		args = append(args, "./...")
+	f, err := os.Create(profilePath) // #nosec G304 - profile path comes from config or flags
+	if err != nil {
+		return nil, err
+	}
+	if err := coverprofile.Merge(f, profiles); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("merge matrix profiles: %w", err)
 	}
+	return nil, f.Close()
+}
 
-	execFn := r.Exec
-	if execFn == nil {
-		execFn = runCommand
+// joinTags combines comma-separated build tag lists.
+func joinTags(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "," + b
+}
+
+// runTests runs go test -json, with a live progress line or with the
+// output passed through as plain text, and returns the failed packages. A
+// failed run's error is a *application.TestFailuresError naming the
+// packages that did not build, failed, or panicked.
+func (r Runner) runTests(ctx context.Context, moduleRoot string, env, args []string, opts application.RunOptions) ([]string, error) {
+	if opts.Progress != nil {
+		return r.runWithProgress(ctx, moduleRoot, env, args, opts)
 	}
-	if err := execFn(ctx, moduleRoot, args); err != nil {
-		return "", fmt.Errorf("go test failed: %w", err)
+
+	var w io.Writer = os.Stdout
+	if opts.PackageTime != nil {
+		w = &packageTimeWriter{w: os.Stdout, report: opts.PackageTime}
 	}
-	return profilePath, nil
+	out := &testEventWriter{w: w}
+	err := r.execStream()(ctx, moduleRoot, env, jsonTestArgs(args), out)
+	return out.failures.Packages(), out.failures.wrap(err)
+}
+
+// jsonTestArgs adds -json right after the "test" subcommand.
+func jsonTestArgs(args []string) []string {
+	return append([]string{args[0], "-json"}, args[1:]...)
+}
+
+func (r Runner) execStream() func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+	if r.ExecStream != nil {
+		return r.ExecStream
+	}
+	return runCommandTo
+}
+
+// runWithProgress runs go test with -json and turns the event stream into
+// a live progress line on opts.Progress. The package total comes from go
+// list; when that fails the line shows only the finished count. Package
+// times go to opts.PackageTime as packages finish. It returns the packages
+// that failed.
+func (r Runner) runWithProgress(ctx context.Context, moduleRoot string, env, args []string, opts application.RunOptions) ([]string, error) {
+	total := 0
+	if pkgs, err := r.listPackages(ctx, moduleRoot, opts.Packages); err == nil {
+		total = len(pkgs)
+	}
+	stream := newProgressStream(opts.Progress, total)
+	stream.onPackage = opts.PackageTime
+	stream.Tick(time.Second)
+
+	err := r.execStream()(ctx, moduleRoot, env, jsonTestArgs(args), stream)
+	stream.Finish(err)
+	return stream.failed, stream.failures.wrap(err)
 }
 
 func (r Runner) RunIntegration(ctx context.Context, opts application.IntegrationOptions) (string, error) {
@@ -128,7 +254,7 @@ func (r Runner) RunIntegration(ctx context.Context, opts application.Integration
 		return "", fmt.Errorf("no packages resolved for integration coverage")
 	}
 
-	coverpkg := buildCoverPkg(opts.Domains)
+	coverpkg := r.coverPackages(ctx, moduleRoot, opts.Domains, opts.CoverPkg)
 	execFn := r.Exec
 	if execFn == nil {
 		execFn = runCommand
@@ -245,10 +371,59 @@ func buildCoverPkg(domains []domain.Domain) string {
 	return strings.Join(patterns, ",")
 }
 
+// coverPackages is buildCoverPkg without the packages cfg excludes. The
+// domain patterns are expanded with go list so excluded and test-only
+// packages can be dropped; when nothing is dropped, or go list fails, the
+// patterns are used as they are. Excluded packages are also left out of
+// domain totals, so a failed lookup costs instrumentation, not accuracy.
+func (r Runner) coverPackages(ctx context.Context, moduleRoot string, domains []domain.Domain, cfg application.CoverPkgConfig) string {
+	coverpkg := buildCoverPkg(domains)
+	if len(cfg.Exclude) == 0 && !cfg.AutoExcludeEnabled() {
+		return coverpkg
+	}
+	execOut := r.ExecOutput
+	if execOut == nil {
+		execOut = runCommandOutput
+	}
+	args := append([]string{"list", "-e", "-f", "{{.ImportPath}}\t{{.Dir}}\t{{len .GoFiles}}"}, strings.Split(coverpkg, ",")...)
+	out, err := execOut(ctx, moduleRoot, args)
+	if err != nil {
+		return coverpkg
+	}
+	modulePath, _ := r.Module.ModulePath(ctx)
+	var kept []string
+	dropped := false
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		importPath, dir, goFiles := fields[0], fields[1], fields[2]
+		rel, err := filepath.Rel(moduleRoot, dir)
+		if err != nil {
+			rel = dir
+		}
+		testOnly := goFiles == "0" && cfg.AutoExcludeEnabled()
+		if testOnly || cfg.Excludes(filepath.ToSlash(rel), modulePath) {
+			dropped = true
+			continue
+		}
+		kept = append(kept, importPath)
+	}
+	if !dropped || len(kept) == 0 {
+		return coverpkg
+	}
+	return strings.Join(kept, ",")
+}
+
 func runCommand(ctx context.Context, dir string, args []string) error {
 	return cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr}.Exec(ctx, dir, "go", args)
 }
 
+func runCommandTo(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+	return cmdrun.Runner{Stdout: w, Stderr: os.Stderr, Env: env}.Exec(ctx, dir, "go", args)
+}
+
 // runCommandOutput runs `go <args>` and returns combined stdout/stderr. Kept
 // as direct exec — cmdrun's Exec writes to a Writer; capturing into a buffer
 // is a different concern and used only for short-lived `go list` style queries
@@ -1,10 +1,13 @@
 package gotool
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -28,6 +31,51 @@ func TestBuildCoverPkg(t *testing.T) {
 	}
 }
 
+// staticModule is a ModuleInfo with fixed answers.
+type staticModule struct{ root, path string }
+
+func (m staticModule) ModuleRoot(context.Context) (string, error) { return m.root, nil }
+func (m staticModule) ModulePath(context.Context) (string, error) { return m.path, nil }
+
+func TestCoverPackagesExcludes(t *testing.T) {
+	listing := "example.com/app/internal/core\t/repo/internal/core\t3\n" +
+		"example.com/app/internal/core/testutil\t/repo/internal/core/testutil\t1\n" +
+		"example.com/app/internal/core/e2e\t/repo/internal/core/e2e\t0\n" +
+		"example.com/app/internal/fixtures\t/repo/internal/fixtures\t2\n" +
+		"example.com/app/internal/api\t/repo/internal/api\t4\n"
+	var listArgs []string
+	runner := Runner{
+		Module: staticModule{root: "/repo", path: "example.com/app"},
+		ExecOutput: func(ctx context.Context, dir string, args []string) ([]byte, error) {
+			listArgs = args
+			return []byte(listing), nil
+		},
+	}
+	domains := []domain.Domain{{Name: "app", Match: []string{"./internal/..."}}}
+
+	got := runner.coverPackages(context.Background(), "/repo", domains, application.CoverPkgConfig{Exclude: []string{"example.com/app/internal/fixtures/..."}})
+	if got != "example.com/app/internal/core,example.com/app/internal/api" {
+		t.Fatalf("unexpected coverpkg %q", got)
+	}
+	if listArgs[len(listArgs)-1] != "./internal/..." {
+		t.Fatalf("expected domain patterns listed, got %v", listArgs)
+	}
+
+	disabled := false
+	got = runner.coverPackages(context.Background(), "/repo", domains, application.CoverPkgConfig{AutoExclude: &disabled})
+	if got != "./internal/..." {
+		t.Fatalf("expected patterns unchanged without excludes, got %q", got)
+	}
+
+	runner.ExecOutput = func(ctx context.Context, dir string, args []string) ([]byte, error) {
+		return nil, errors.New("go list failed")
+	}
+	got = runner.coverPackages(context.Background(), "/repo", domains, application.CoverPkgConfig{})
+	if got != "./internal/..." {
+		t.Fatalf("expected patterns when go list fails, got %q", got)
+	}
+}
+
 func TestModuleRoot(t *testing.T) {
 	root, err := (ModuleResolver{}).ModuleRoot(context.Background())
 	if err != nil {
@@ -104,7 +152,7 @@ func TestRunnerRun(t *testing.T) {
 	profile := filepath.Join(tmp, "coverage.out")
 	runner := Runner{
 		Module: ModuleResolver{},
-		Exec: func(ctx context.Context, dir string, args []string) error {
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
 			for _, arg := range args {
 				if strings.HasPrefix(arg, "-coverprofile=") {
 					path := strings.TrimPrefix(arg, "-coverprofile=")
@@ -126,6 +174,191 @@ func TestRunnerRun(t *testing.T) {
 	}
 }
 
+func TestRunnerRunWithProgress(t *testing.T) {
+	tmp := t.TempDir()
+	var gotArgs []string
+	var progress bytes.Buffer
+	runner := Runner{
+		Module: ModuleResolver{},
+		ExecOutput: func(ctx context.Context, dir string, args []string) ([]byte, error) {
+			return []byte("example.com/a\nexample.com/b\n"), nil
+		},
+		Exec: func(ctx context.Context, dir string, args []string) error {
+			t.Fatal("plain exec should not be used when progress is shown")
+			return nil
+		},
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+			gotArgs = args
+			_, _ = io.WriteString(w, `{"Action":"pass","Package":"example.com/a"}`+"\n")
+			return nil
+		},
+	}
+	_, err := runner.Run(context.Background(), application.RunOptions{
+		ProfilePath: filepath.Join(tmp, "coverage.out"),
+		Progress:    &progress,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(gotArgs) < 2 || gotArgs[0] != "test" || gotArgs[1] != "-json" {
+		t.Fatalf("expected go test -json, got %v", gotArgs)
+	}
+	if !strings.Contains(progress.String(), "Testing [1/2]") {
+		t.Fatalf("expected progress against go list total, got %q", progress.String())
+	}
+	if !strings.Contains(progress.String(), "Tested 1 packages") {
+		t.Fatalf("expected completion line, got %q", progress.String())
+	}
+}
+
+func TestRunnerRunReportsFailedPackages(t *testing.T) {
+	tmp := t.TempDir()
+	var gotArgs []string
+	runner := Runner{
+		Module: ModuleResolver{},
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+			gotArgs = args
+			_, _ = io.WriteString(w, `{"Action":"fail","Package":"example.com/a","Test":"TestX"}`+"\n")
+			_, _ = io.WriteString(w, `{"Action":"fail","Package":"example.com/a"}`+"\n")
+			_, _ = io.WriteString(w, `{"Action":"pass","Package":"example.com/b"}`+"\n")
+			return errors.New("exit status 1")
+		},
+	}
+	_, err := runner.Run(context.Background(), application.RunOptions{
+		ProfilePath: filepath.Join(tmp, "coverage.out"),
+		Retry:       application.RetryConfig{Retries: 1, OnlyFailed: true},
+	})
+	var failed *application.FailedPackagesError
+	if !errors.As(err, &failed) || !slices.Equal(failed.Packages, []string{"example.com/a"}) {
+		t.Fatalf("expected failed package example.com/a, got %v", err)
+	}
+	if len(gotArgs) < 2 || gotArgs[1] != "-json" {
+		t.Fatalf("expected go test -json, got %v", gotArgs)
+	}
+}
+
+func TestRunnerRunReportsTestFailures(t *testing.T) {
+	runner := Runner{
+		Module: ModuleResolver{},
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+			_, _ = io.WriteString(w, `{"Action":"fail","Package":"example.com/a","Test":"TestX"}`+"\n")
+			_, _ = io.WriteString(w, `{"Action":"fail","Package":"example.com/a"}`+"\n")
+			return errors.New("exit status 1")
+		},
+	}
+	_, err := runner.Run(context.Background(), application.RunOptions{ProfilePath: filepath.Join(t.TempDir(), "coverage.out")})
+	failures := application.TestFailuresOf(err)
+	if len(failures) != 1 || failures[0].Package != "example.com/a" || failures[0].Kind != application.TestFailureTest {
+		t.Fatalf("expected a test failure in example.com/a, got %v", err)
+	}
+	if err.Error() != "go test failed: example.com/a: TestX failed (exit status 1)" {
+		t.Fatalf("unexpected error %q", err.Error())
+	}
+}
+
+func TestRunnerRunAppendProfile(t *testing.T) {
+	tmp := t.TempDir()
+	profile := filepath.Join(tmp, "coverage.out")
+	if err := os.WriteFile(profile, []byte("mode: atomic\nexample.com/a/a.go:1.1,2.2 1 0\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runner := Runner{
+		Module: ModuleResolver{},
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+			for _, arg := range args {
+				if out, ok := strings.CutPrefix(arg, "-coverprofile="); ok {
+					return os.WriteFile(out, []byte("mode: atomic\nexample.com/a/a.go:1.1,2.2 1 3\n"), 0o644)
+				}
+			}
+			return nil
+		},
+	}
+	if _, err := runner.Run(context.Background(), application.RunOptions{ProfilePath: profile, AppendProfile: true}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	data, err := os.ReadFile(profile)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	want := "mode: atomic\nexample.com/a/a.go:1.1,2.2 1 0\nexample.com/a/a.go:1.1,2.2 1 3\n"
+	if string(data) != want {
+		t.Fatalf("profile = %q, want %q", data, want)
+	}
+	if _, err := os.Stat(profile + ".retry"); !os.IsNotExist(err) {
+		t.Fatalf("expected the retry profile to be removed, got %v", err)
+	}
+}
+
+func TestRunnerRunMatrix(t *testing.T) {
+	tmp := t.TempDir()
+	profile := filepath.Join(tmp, "coverage.out")
+	// Each variant compiles the shared file plus its own; the shared
+	// block is covered only on windows.
+	variantProfiles := map[string]string{
+		"GOOS=linux":   "mode: atomic\nexample.com/a/a.go:1.1,2.2 1 0\nexample.com/a/a_linux.go:1.1,2.2 1 2\n",
+		"GOOS=windows": "mode: atomic\nexample.com/a/a.go:1.1,2.2 1 1\nexample.com/a/a_windows.go:1.1,2.2 1 0\n",
+	}
+	var calls [][]string
+	runner := Runner{
+		Module: ModuleResolver{},
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+			goos := env[len(env)-1]
+			calls = append(calls, append([]string{goos}, args...))
+			for _, arg := range args {
+				if out, ok := strings.CutPrefix(arg, "-coverprofile="); ok {
+					return os.WriteFile(out, []byte(variantProfiles[goos]), 0o644)
+				}
+			}
+			return nil
+		},
+	}
+	got, err := runner.Run(context.Background(), application.RunOptions{
+		ProfilePath: profile,
+		BuildFlags:  application.BuildFlags{Tags: "integration"},
+		Matrix: []application.BuildVariant{
+			{Name: "linux", GOOS: "linux"},
+			{Name: "windows/cgo", GOOS: "windows", Tags: "cgo"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if got != profile {
+		t.Fatalf("profile = %s, want %s", got, profile)
+	}
+	if len(calls) != 2 || !slices.Contains(calls[0], "-json") || !slices.Contains(calls[0], "-tags=integration") || !slices.Contains(calls[1], "-tags=integration,cgo") {
+		t.Fatalf("unexpected go test calls %v", calls)
+	}
+	if !slices.Contains(calls[1], "-coverprofile="+filepath.Join(tmp, "coverage.windows-cgo.out")) {
+		t.Fatalf("expected the windows variant profile beside the merged one, got %v", calls[1])
+	}
+	data, err := os.ReadFile(profile)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	want := "mode: count\nexample.com/a/a.go:1.1,2.2 1 1\nexample.com/a/a_linux.go:1.1,2.2 1 2\nexample.com/a/a_windows.go:1.1,2.2 1 0\n"
+	if string(data) != want {
+		t.Fatalf("merged profile = %q, want %q", data, want)
+	}
+
+	runner.ExecStream = func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
+		_, _ = io.WriteString(w, `{"Action":"fail","Package":"example.com/a"}`+"\n")
+		return errors.New("exit status 1")
+	}
+	_, err = runner.Run(context.Background(), application.RunOptions{
+		ProfilePath: profile,
+		Matrix:      []application.BuildVariant{{Name: "linux", GOOS: "linux"}},
+		Retry:       application.RetryConfig{OnlyFailed: true},
+	})
+	if err == nil || !strings.Contains(err.Error(), "matrix variant linux") {
+		t.Fatalf("expected the failing variant to be named, got %v", err)
+	}
+	var failedErr *application.FailedPackagesError
+	if !errors.As(err, &failedErr) || !slices.Equal(failedErr.Packages, []string{"example.com/a"}) {
+		t.Fatalf("expected the failed package to be offered for a retry, got %v", err)
+	}
+}
+
 func TestRunnerRunIntegration(t *testing.T) {
 	tmp := t.TempDir()
 	profile := filepath.Join(tmp, "integration.out")
@@ -182,7 +415,7 @@ func TestRunnerRunExecError(t *testing.T) {
 	tmp := t.TempDir()
 	runner := Runner{
 		Module: ModuleResolver{},
-		Exec: func(ctx context.Context, dir string, args []string) error {
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
 			return errors.New("go test compilation failed")
 		},
 	}
@@ -498,7 +731,7 @@ func TestRunnerRunWithBuildFlags(t *testing.T) {
 	var capturedArgs []string
 	runner := Runner{
 		Module: ModuleResolver{},
-		Exec: func(ctx context.Context, dir string, args []string) error {
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
 			capturedArgs = args
 			for _, arg := range args {
 				if strings.HasPrefix(arg, "-coverprofile=") {
@@ -537,7 +770,7 @@ func TestRunnerRunDefaultProfilePath(t *testing.T) {
 	var capturedProfilePath string
 	runner := Runner{
 		Module: ModuleResolver{},
-		Exec: func(ctx context.Context, dir string, args []string) error {
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
 			for _, arg := range args {
 				if strings.HasPrefix(arg, "-coverprofile=") {
 					capturedProfilePath = strings.TrimPrefix(arg, "-coverprofile=")
@@ -569,7 +802,7 @@ func TestRunnerRunWithPackages(t *testing.T) {
 	var capturedArgs []string
 	runner := Runner{
 		Module: ModuleResolver{},
-		Exec: func(ctx context.Context, dir string, args []string) error {
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
 			capturedArgs = args
 			for _, arg := range args {
 				if strings.HasPrefix(arg, "-coverprofile=") {
@@ -601,7 +834,7 @@ func TestRunnerRunWithDomains(t *testing.T) {
 	min := 80.0
 	runner := Runner{
 		Module: ModuleResolver{},
-		Exec: func(ctx context.Context, dir string, args []string) error {
+		ExecStream: func(ctx context.Context, dir string, env []string, args []string, w io.Writer) error {
 			capturedArgs = args
 			for _, arg := range args {
 				if strings.HasPrefix(arg, "-coverprofile=") {
@@ -0,0 +1,52 @@
+package gotool
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// packageTimeWriter passes plain `go test` output through to w and reports
+// the time on each package result line ("ok  \tpkg\t1.23s ..."). Cached
+// results carry no time and are skipped.
+type packageTimeWriter struct {
+	w       io.Writer
+	report  func(pkg string, seconds float64)
+	partial []byte
+}
+
+func (p *packageTimeWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.partial = append(p.partial, b[:n]...)
+	for {
+		i := bytes.IndexByte(p.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if pkg, seconds, ok := parsePackageTime(string(p.partial[:i])); ok {
+			p.report(pkg, seconds)
+		}
+		p.partial = p.partial[i+1:]
+	}
+	return n, err
+}
+
+func parsePackageTime(line string) (string, float64, bool) {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 3 {
+		return "", 0, false
+	}
+	if status := strings.TrimSpace(fields[0]); status != "ok" && status != "FAIL" {
+		return "", 0, false
+	}
+	elapsed, ok := strings.CutSuffix(fields[2], "s")
+	if !ok {
+		return "", 0, false
+	}
+	seconds, err := strconv.ParseFloat(elapsed, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return fields[1], seconds, true
+}
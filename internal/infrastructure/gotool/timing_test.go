@@ -0,0 +1,54 @@
+package gotool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParsePackageTime(t *testing.T) {
+	cases := []struct {
+		line    string
+		pkg     string
+		seconds float64
+		ok      bool
+	}{
+		{"ok  \texample.com/a\t1.234s\tcoverage: 80.0% of statements", "example.com/a", 1.234, true},
+		{"FAIL\texample.com/b\t0.5s", "example.com/b", 0.5, true},
+		{"ok  \texample.com/c\t(cached)\tcoverage: 10.0% of statements", "", 0, false},
+		{"?   \texample.com/d\t[no test files]", "", 0, false},
+		{"--- FAIL: TestX (0.00s)", "", 0, false},
+	}
+	for _, tc := range cases {
+		pkg, seconds, ok := parsePackageTime(tc.line)
+		if pkg != tc.pkg || seconds != tc.seconds || ok != tc.ok {
+			t.Errorf("parsePackageTime(%q) = %q, %v, %v", tc.line, pkg, seconds, ok)
+		}
+	}
+}
+
+func TestPackageTimeWriter(t *testing.T) {
+	var out bytes.Buffer
+	got := map[string]float64{}
+	w := &packageTimeWriter{w: &out, report: func(pkg string, s float64) { got[pkg] = s }}
+	input := "=== RUN TestA\nok  \texample.com/a\t2.5s\n"
+	_, _ = w.Write([]byte(input[:20]))
+	_, _ = w.Write([]byte(input[20:]))
+	if out.String() != input {
+		t.Fatalf("output should pass through unchanged, got %q", out.String())
+	}
+	if len(got) != 1 || got["example.com/a"] != 2.5 {
+		t.Fatalf("unexpected package times %v", got)
+	}
+}
+
+func TestProgressStreamReportsPackageTime(t *testing.T) {
+	var out bytes.Buffer
+	p := newTestStream(&out, 0)
+	got := map[string]float64{}
+	p.onPackage = func(pkg string, s float64) { got[pkg] = s }
+	_, _ = p.Write([]byte(`{"Action":"pass","Package":"example.com/a","Elapsed":1.25}` + "\n" +
+		`{"Action":"skip","Package":"example.com/b","Elapsed":0}` + "\n"))
+	if len(got) != 1 || got["example.com/a"] != 1.25 {
+		t.Fatalf("unexpected package times %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// AuditLog stores the policy audit log as JSON Lines, one entry per line.
+// Entries are only ever appended.
+type AuditLog struct {
+	Path string
+}
+
+// Load reads every entry, oldest first.
+// Returns no entries if the file doesn't exist.
+func (l *AuditLog) Load() ([]application.AuditEntry, error) {
+	f, err := os.Open(l.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []application.AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry application.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", l.Path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Append adds entry to the end of the log, creating the file and its
+// directory when missing.
+func (l *AuditLog) Append(entry application.AuditEntry) error {
+	cleanPath, err := pathutil.ValidatePath(l.Path)
+	if err != nil {
+		return fmt.Errorf("invalid audit file: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cleanPath), 0o750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(cleanPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) // #nosec G302 G304 - path is validated above; the log is meant to be shared
+	if err != nil {
+		return err
+	}
+	// One write keeps the line whole when several runs append at once.
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var _ application.AuditLog = (*AuditLog)(nil)
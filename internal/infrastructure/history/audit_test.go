@@ -0,0 +1,45 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func TestAuditLog(t *testing.T) {
+	log := AuditLog{Path: filepath.Join(t.TempDir(), "nested", "audit.jsonl")}
+	entries, err := log.Load()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected an empty log, got %v, %v", entries, err)
+	}
+
+	first := application.AuditEntry{
+		Timestamp:  time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		Actor:      "alice",
+		Source:     application.AuditRatchetUp,
+		Config:     ".coverctl.yaml",
+		ConfigHash: "aa",
+		Changes:    []application.ConfigChange{{Kind: application.ConfigRaised, Subject: "domain core min", Before: "80.0%", After: "82.0%"}},
+		Thresholds: map[string]float64{"domain core min": 82},
+	}
+	second := first
+	second.Source, second.ConfigHash = application.AuditManual, "bb"
+	for _, entry := range []application.AuditEntry{first, second} {
+		if err := log.Append(entry); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	entries, err = log.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Source != application.AuditRatchetUp || entries[1].ConfigHash != "bb" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if entries[0].Changes[0].After != "82.0%" || entries[0].Thresholds["domain core min"] != 82 {
+		t.Fatalf("round trip mismatch: %+v", entries[0])
+	}
+}
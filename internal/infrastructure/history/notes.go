@@ -0,0 +1,160 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// DefaultNotesRef is the notes ref coverage history is stored under.
+const DefaultNotesRef = "refs/notes/coverctl"
+
+// NotesStore keeps coverage history in git notes: each entry is a JSON note
+// on the commit it was recorded for, so history travels with the repository
+// (git push origin refs/notes/coverctl) instead of living in a file.
+type NotesStore struct {
+	Dir        string // Repository directory; empty means the working directory
+	Ref        string // Notes ref; empty means DefaultNotesRef
+	Branch     string // Load reads notes on commits reachable from this revision; empty means HEAD
+	MaxEntries int    // Load returns at most this many of the newest entries; 0 means DefaultMaxEntries
+	Exec       func(ctx context.Context, dir string, args []string) ([]byte, error)
+}
+
+var _ application.HistoryStore = (*NotesStore)(nil)
+
+// Record and field separators in the `git log` output Load parses.
+const (
+	notesRecordSep = "\x1e"
+	notesFieldSep  = "\x00"
+)
+
+// Load reads the notes on the commits reachable from Branch, oldest first.
+// Commits without a note are skipped; an entry recorded without a commit
+// takes the SHA of the commit it is attached to.
+func (s *NotesStore) Load() (domain.History, error) {
+	out, err := s.git(context.Background(), "log", "--notes="+s.ref(), "--format=%H%x00%N%x1e", s.branch(), "--")
+	if err != nil {
+		return domain.History{}, fmt.Errorf("read git notes: %w", err)
+	}
+	var entries []domain.HistoryEntry
+	for _, record := range strings.Split(string(out), notesRecordSep) {
+		sha, note, ok := strings.Cut(strings.TrimSpace(record), notesFieldSep)
+		note = strings.TrimSpace(note)
+		if !ok || note == "" {
+			continue
+		}
+		var entry domain.HistoryEntry
+		if err := json.Unmarshal([]byte(note), &entry); err != nil {
+			return domain.History{}, fmt.Errorf("parse git note on %s: %w", sha, err)
+		}
+		if entry.Commit == "" {
+			entry.Commit = sha
+		}
+		entries = append(entries, entry)
+	}
+	// git log lists the newest commit first.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	max := s.MaxEntries
+	if max == 0 {
+		max = DefaultMaxEntries
+	}
+	if len(entries) > max {
+		entries = entries[len(entries)-max:]
+	}
+	return domain.History{Entries: entries}, nil
+}
+
+// Save writes a note for every entry that names its commit. Notes on
+// commits not in h are left alone.
+func (s *NotesStore) Save(h domain.History) error {
+	for _, entry := range h.Entries {
+		if entry.Commit == "" {
+			continue
+		}
+		if err := s.Append(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Append writes entry as the note on entry.Commit, or on HEAD when the entry
+// has no commit, replacing any note recorded for that commit before.
+func (s *NotesStore) Append(entry domain.HistoryEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// The note goes through a file: an entry with file history can exceed
+	// the limit on a single command-line argument.
+	tmp, err := os.CreateTemp("", "coverctl-note-*.json")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	commit := entry.Commit
+	if commit == "" {
+		commit = "HEAD"
+	}
+	if _, err := s.git(context.Background(), "notes", "--ref="+s.ref(), "add", "-f", "-F", tmpName, commit); err != nil {
+		return fmt.Errorf("write git note on %s: %w", commit, err)
+	}
+	return nil
+}
+
+func (s *NotesStore) ref() string {
+	if s.Ref != "" {
+		return s.Ref
+	}
+	return DefaultNotesRef
+}
+
+func (s *NotesStore) branch() string {
+	if s.Branch != "" {
+		return s.Branch
+	}
+	return "HEAD"
+}
+
+func (s *NotesStore) git(ctx context.Context, args ...string) ([]byte, error) {
+	if s.Exec != nil {
+		return s.Exec(ctx, s.Dir, args)
+	}
+	return runGit(ctx, s.Dir, args)
+}
+
+// runGit returns git's stdout; on failure the error carries its stderr, so
+// warnings never end up in the parsed output.
+func runGit(ctx context.Context, dir string, args []string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
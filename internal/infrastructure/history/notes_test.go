@@ -0,0 +1,131 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestNotesStoreLoad(t *testing.T) {
+	var gotArgs []string
+	store := NotesStore{
+		Dir:    "/repo",
+		Branch: "main",
+		Exec: func(_ context.Context, dir string, args []string) ([]byte, error) {
+			if dir != "/repo" {
+				t.Fatalf("expected git to run in /repo, got %q", dir)
+			}
+			gotArgs = args
+			// Newest commit first, as git log lists them; ccc has no note.
+			return []byte("ccc\x00\x1e\n" +
+				"bbb\x00{\"overall\":80,\"commit\":\"bbb\"}\n\x1e\n" +
+				"aaa\x00{\"overall\":70}\n\x1e\n"), nil
+		},
+	}
+	h, err := store.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := []string{"log", "--notes=refs/notes/coverctl", "--format=%H%x00%N%x1e", "main", "--"}
+	if strings.Join(gotArgs, " ") != strings.Join(want, " ") {
+		t.Fatalf("expected args %v, got %v", want, gotArgs)
+	}
+	if len(h.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(h.Entries))
+	}
+	if h.Entries[0].Commit != "aaa" || h.Entries[0].Overall != 70 {
+		t.Fatalf("expected the oldest entry first with its commit filled in, got %+v", h.Entries[0])
+	}
+	if h.Entries[1].Commit != "bbb" || h.Entries[1].Overall != 80 {
+		t.Fatalf("unexpected newest entry %+v", h.Entries[1])
+	}
+
+	store.MaxEntries = 1
+	h, err = store.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(h.Entries) != 1 || h.Entries[0].Commit != "bbb" {
+		t.Fatalf("expected only the newest entry, got %+v", h.Entries)
+	}
+}
+
+func TestNotesStoreLoadErrors(t *testing.T) {
+	store := NotesStore{Exec: func(context.Context, string, []string) ([]byte, error) {
+		return nil, errors.New("fatal: not a git repository")
+	}}
+	if _, err := store.Load(); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Fatalf("expected the git error, got %v", err)
+	}
+
+	store.Exec = func(context.Context, string, []string) ([]byte, error) {
+		return []byte("aaa\x00not json\n\x1e"), nil
+	}
+	if _, err := store.Load(); err == nil || !strings.Contains(err.Error(), "aaa") {
+		t.Fatalf("expected a parse error naming the commit, got %v", err)
+	}
+}
+
+func TestNotesStoreAppend(t *testing.T) {
+	var gotArgs []string
+	var note domain.HistoryEntry
+	store := NotesStore{
+		Ref: "refs/notes/cov",
+		Exec: func(_ context.Context, _ string, args []string) ([]byte, error) {
+			gotArgs = args
+			data, err := os.ReadFile(args[len(args)-2])
+			if err != nil {
+				return nil, err
+			}
+			return nil, json.Unmarshal(data, &note)
+		},
+	}
+	entry := domain.HistoryEntry{Timestamp: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), Commit: "abc123", Overall: 82.5}
+	if err := store.Append(entry); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if strings.Join(gotArgs[:4], " ") != "notes --ref=refs/notes/cov add -f" || gotArgs[len(gotArgs)-1] != "abc123" {
+		t.Fatalf("unexpected args %v", gotArgs)
+	}
+	if note.Commit != "abc123" || note.Overall != 82.5 || !note.Timestamp.Equal(entry.Timestamp) {
+		t.Fatalf("unexpected note %+v", note)
+	}
+	if _, err := os.Stat(gotArgs[len(gotArgs)-2]); !os.IsNotExist(err) {
+		t.Fatal("expected the note file to be removed")
+	}
+
+	if err := store.Append(domain.HistoryEntry{Overall: 60}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if gotArgs[len(gotArgs)-1] != "HEAD" {
+		t.Fatalf("expected an entry without a commit to be noted on HEAD, got %v", gotArgs)
+	}
+}
+
+func TestNotesStoreSave(t *testing.T) {
+	var commits []string
+	store := NotesStore{Exec: func(_ context.Context, _ string, args []string) ([]byte, error) {
+		commits = append(commits, args[len(args)-1])
+		return nil, nil
+	}}
+	err := store.Save(domain.History{Entries: []domain.HistoryEntry{{Commit: "aaa"}, {}, {Commit: "bbb"}}})
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if strings.Join(commits, ",") != "aaa,bbb" {
+		t.Fatalf("expected notes on aaa and bbb only, got %v", commits)
+	}
+
+	store.Exec = func(context.Context, string, []string) ([]byte, error) {
+		return nil, errors.New("error: Please tell me who you are.")
+	}
+	if err := store.Save(domain.History{Entries: []domain.HistoryEntry{{Commit: "aaa"}}}); err == nil {
+		t.Fatal("expected the git error")
+	}
+}
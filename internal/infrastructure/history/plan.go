@@ -0,0 +1,48 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// PlanStore provides JSON file-based storage for a debt burn-down plan.
+type PlanStore struct {
+	Path string
+}
+
+// Load reads the plan from the JSON file.
+// Returns ok=false if no plan has been saved yet.
+func (s *PlanStore) Load() (domain.DebtPlan, bool, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return domain.DebtPlan{}, false, nil
+		}
+		return domain.DebtPlan{}, false, err
+	}
+
+	var p domain.DebtPlan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return domain.DebtPlan{}, false, err
+	}
+	return p, true, nil
+}
+
+// Save writes the plan to the JSON file, replacing any previous plan.
+func (s *PlanStore) Save(p domain.DebtPlan) error {
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.Path, data, 0o600)
+}
@@ -0,0 +1,42 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestPlanStore(t *testing.T) {
+	t.Run("missing file reports no plan", func(t *testing.T) {
+		store := PlanStore{Path: filepath.Join(t.TempDir(), "missing.json")}
+		_, ok, err := store.Load()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok {
+			t.Fatal("expected ok=false for missing plan")
+		}
+	})
+
+	t.Run("round trips a plan", func(t *testing.T) {
+		store := PlanStore{Path: filepath.Join(t.TempDir(), "nested", "debt-plan.json")}
+		target := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+		want := domain.DebtPlan{
+			CreatedAt:  target.Add(-30 * 24 * time.Hour),
+			TargetDate: target,
+			Domains:    []domain.DebtPlanDomain{{Name: "core", Baseline: 60, Required: 80, WeeklyGain: 4.7}},
+		}
+		if err := store.Save(want); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+		got, ok, err := store.Load()
+		if err != nil || !ok {
+			t.Fatalf("load: ok=%v err=%v", ok, err)
+		}
+		if !got.TargetDate.Equal(want.TargetDate) || len(got.Domains) != 1 || got.Domains[0] != want.Domains[0] {
+			t.Fatalf("round trip mismatch: %+v", got)
+		}
+	})
+}
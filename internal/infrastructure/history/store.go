@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/felixgeelhaar/coverctl/internal/domain"
 )
@@ -41,19 +42,15 @@ func (s *FileStore) Load() (domain.History, error) {
 	return h, nil
 }
 
-// Save writes the history to the JSON file.
+// Save writes the history to the JSON file, holding the same lock as Append
+// so a concurrent Append is never overwritten mid-update.
 func (s *FileStore) Save(h domain.History) error {
-	dir := filepath.Dir(s.Path)
-	if err := os.MkdirAll(dir, 0o750); err != nil {
-		return err
-	}
-
-	data, err := json.MarshalIndent(h, "", "  ")
+	lock, err := s.acquireLock()
 	if err != nil {
 		return err
 	}
-
-	return os.WriteFile(s.Path, data, 0o600)
+	defer lock.release()
+	return s.write(h)
 }
 
 // Append adds a new entry to the history and saves it.
@@ -83,5 +80,51 @@ func (s *FileStore) Append(entry domain.HistoryEntry) error {
 		h.Entries = h.Entries[len(h.Entries)-max:]
 	}
 
-	return s.Save(h)
+	return s.write(h)
+}
+
+// renameAttempts bounds retries of the final rename. Windows refuses to
+// replace a file another process (e.g. a concurrent Load) has open.
+const renameAttempts = 5
+
+// write replaces the history file atomically: the JSON goes to a temporary
+// file in the same directory, which is then renamed over the old one, so a
+// reader never sees a partial file and a crash leaves the previous history
+// intact. The caller must hold the lock.
+func (s *FileStore) write(h domain.History) error {
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = os.Rename(tmpName, s.Path)
+		if err == nil || attempt == renameAttempts {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * 20 * time.Millisecond)
+	}
 }
@@ -3,6 +3,7 @@ package history
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -170,3 +171,60 @@ func TestFileStoreAppend(t *testing.T) {
 		}
 	})
 }
+
+func TestFileStoreConcurrentAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	const writers = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Separate stores, like separate processes sharing the file.
+			store := FileStore{Path: path}
+			errs <- store.Append(domain.HistoryEntry{Overall: float64(i)})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	store := FileStore{Path: path}
+	h, err := store.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(h.Entries) != writers {
+		t.Fatalf("expected %d entries, got %d", writers, len(h.Entries))
+	}
+}
+
+func TestFileStoreSaveLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := FileStore{Path: filepath.Join(dir, "history.json")}
+	if err := store.Save(domain.History{Entries: []domain.HistoryEntry{{Overall: 80}}}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := store.Save(domain.History{Entries: []domain.HistoryEntry{{Overall: 81}}}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	for _, e := range entries {
+		if e.Name() != "history.json" && e.Name() != "history.json.lock" {
+			t.Fatalf("unexpected leftover file %s", e.Name())
+		}
+	}
+	h, _ := store.Load()
+	if len(h.Entries) != 1 || h.Entries[0].Overall != 81 {
+		t.Fatalf("expected replaced history, got %+v", h.Entries)
+	}
+}
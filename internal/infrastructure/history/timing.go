@@ -0,0 +1,49 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// TimingStore provides JSON file-based storage for the last test run's
+// timings.
+type TimingStore struct {
+	Path string
+}
+
+// Load reads the timings from the JSON file.
+// Returns ok=false if no run has been timed yet.
+func (s *TimingStore) Load() (domain.TestTimings, bool, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return domain.TestTimings{}, false, nil
+		}
+		return domain.TestTimings{}, false, err
+	}
+
+	var t domain.TestTimings
+	if err := json.Unmarshal(data, &t); err != nil {
+		return domain.TestTimings{}, false, err
+	}
+	return t, true, nil
+}
+
+// Save writes the timings to the JSON file, replacing the previous run's.
+func (s *TimingStore) Save(t domain.TestTimings) error {
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.Path, data, 0o600)
+}
@@ -0,0 +1,43 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestTimingStore(t *testing.T) {
+	t.Run("missing file reports no timings", func(t *testing.T) {
+		store := TimingStore{Path: filepath.Join(t.TempDir(), "missing.json")}
+		_, ok, err := store.Load()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok {
+			t.Fatal("expected ok=false for missing timings")
+		}
+	})
+
+	t.Run("round trips timings", func(t *testing.T) {
+		store := TimingStore{Path: filepath.Join(t.TempDir(), "nested", "timing.json")}
+		want := domain.TestTimings{
+			RecordedAt: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			Total:      42.5,
+			Packages:   map[string]float64{"example.com/core": 12.25},
+			Domains:    map[string]float64{"e2e": 30},
+		}
+		if err := store.Save(want); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+		got, ok, err := store.Load()
+		if err != nil || !ok {
+			t.Fatalf("load: ok=%v err=%v", ok, err)
+		}
+		if !got.RecordedAt.Equal(want.RecordedAt) || got.Total != want.Total ||
+			got.Packages["example.com/core"] != 12.25 || got.Domains["e2e"] != 30 {
+			t.Fatalf("round trip mismatch: %+v", got)
+		}
+	})
+}
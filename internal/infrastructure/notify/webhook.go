@@ -0,0 +1,89 @@
+// Package notify delivers coverage notifications to chat and webhook
+// endpoints.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/webhook"
+)
+
+// Webhook posts notifications as Slack Block Kit messages or generic JSON.
+type Webhook struct {
+	httpClient *http.Client
+}
+
+// NewWebhook creates a webhook notifier with the default timeout.
+func NewWebhook() *Webhook {
+	return &Webhook{httpClient: webhook.NewClient()}
+}
+
+// NewWebhookWithHTTP creates a webhook notifier with a custom HTTP client.
+func NewWebhookWithHTTP(httpClient *http.Client) *Webhook {
+	return &Webhook{httpClient: httpClient}
+}
+
+// Notify renders n in its configured format and posts it to n.Webhook.
+// ${VAR} references in the URL are expanded from the environment so the
+// secret URL can stay out of the config file.
+func (w *Webhook) Notify(ctx context.Context, n application.Notification) error {
+	var payload any
+	switch n.Format {
+	case application.NotifyJSON:
+		payload = n
+	case application.NotifySlack, "":
+		payload = slackMessage(n)
+	default:
+		return fmt.Errorf("unsupported notify format: %s", n.Format)
+	}
+	return webhook.PostJSON(ctx, w.httpClient, "webhook", n.Webhook, payload)
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+func slackMessage(n application.Notification) slackPayload {
+	title := fmt.Sprintf("Coverage alert from coverctl %s", n.Source)
+	summary := fmt.Sprintf("Overall coverage: *%.1f%%*", n.Current.Overall)
+	if n.Previous != nil {
+		summary += fmt.Sprintf(" (previously %.1f%%)", n.Previous.Overall)
+	}
+	if n.Current.Branch != "" || n.Current.Commit != "" {
+		summary += fmt.Sprintf("\n%s %s", n.Current.Branch, n.Current.Commit)
+	}
+	if len(n.Owners) > 0 {
+		summary += "\nOwners: " + strings.Join(n.Owners, ", ")
+	}
+
+	var reasons strings.Builder
+	for _, reason := range n.Reasons {
+		reasons.WriteString("• " + reason + "\n")
+	}
+
+	return slackPayload{
+		Text: title + ": " + strings.Join(n.Reasons, "; "),
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: title}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: summary}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: strings.TrimRight(reasons.String(), "\n")}},
+		},
+	}
+}
+
+var _ application.Notifier = (*Webhook)(nil)
@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("invalid JSON body: %v", err)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+	}))
+	defer server.Close()
+
+	n := application.Notification{
+		Webhook: server.URL,
+		Source:  "record",
+		Reasons: []string{"overall coverage dropped 2.0 points (80.0% -> 78.0%)"},
+		Current: domain.HistoryEntry{Overall: 78},
+	}
+	hook := NewWebhookWithHTTP(server.Client())
+
+	t.Run("slack blocks", func(t *testing.T) {
+		n.Format = application.NotifySlack
+		if err := hook.Notify(context.Background(), n); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+		if blocks, ok := got["blocks"].([]any); !ok || len(blocks) != 3 {
+			t.Fatalf("expected 3 slack blocks, got %v", got["blocks"])
+		}
+		if text, _ := got["text"].(string); !strings.Contains(text, "dropped 2.0 points") {
+			t.Fatalf("expected fallback text with reason, got %q", text)
+		}
+	})
+
+	t.Run("slack lists owners", func(t *testing.T) {
+		owned := n
+		owned.Format = application.NotifySlack
+		owned.Owners = []string{"@payments"}
+		if err := hook.Notify(context.Background(), owned); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+		blocks, _ := got["blocks"].([]any)
+		summary, _ := json.Marshal(blocks[1])
+		if !strings.Contains(string(summary), "Owners: @payments") {
+			t.Fatalf("expected owners in summary, got %s", summary)
+		}
+	})
+
+	t.Run("generic json", func(t *testing.T) {
+		n.Format = application.NotifyJSON
+		if err := hook.Notify(context.Background(), n); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+		if got["source"] != "record" || got["reasons"] == nil {
+			t.Fatalf("unexpected json payload: %v", got)
+		}
+	})
+
+	t.Run("expands environment in URL", func(t *testing.T) {
+		t.Setenv("COVERCTL_TEST_WEBHOOK", server.URL)
+		n.Webhook = "${COVERCTL_TEST_WEBHOOK}"
+		if err := hook.Notify(context.Background(), n); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+	})
+}
+
+func TestWebhookNotifyErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	hook := NewWebhookWithHTTP(server.Client())
+
+	if err := hook.Notify(context.Background(), application.Notification{Webhook: server.URL + "/secret-token"}); err == nil || strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("expected status error without URL path, got %v", err)
+	}
+	if err := hook.Notify(context.Background(), application.Notification{Webhook: "file:///etc/passwd"}); err == nil {
+		t.Fatal("expected invalid URL error")
+	}
+}
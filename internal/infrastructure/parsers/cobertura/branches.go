@@ -0,0 +1,103 @@
+package cobertura
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// ParseBranches reads a Cobertura XML file and returns per-file branch
+// counts from the condition-coverage of branch lines, such as "50% (1/2)".
+// A line listed under both its class and a method is counted once.
+func (p *Parser) ParseBranches(path string) (map[string]domain.CoverageStat, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("open cobertura file: %w", err)
+	}
+	defer file.Close()
+
+	var cov coverage
+	if err := xml.NewDecoder(file).Decode(&cov); err != nil {
+		return nil, fmt.Errorf("decode cobertura xml: %w", err)
+	}
+
+	stats := make(map[string]domain.CoverageStat)
+	for _, pkg := range cov.Packages {
+		for _, cls := range pkg.Classes {
+			if cls.Filename == "" {
+				continue
+			}
+			branches := make(map[int]domain.CoverageStat)
+			record := func(lines []line) {
+				for _, ln := range lines {
+					stat, ok := conditionCoverage(ln)
+					if !ok {
+						continue
+					}
+					if prev, seen := branches[ln.Number]; !seen || stat.Covered > prev.Covered {
+						branches[ln.Number] = stat
+					}
+				}
+			}
+			record(cls.Lines)
+			for _, m := range cls.Methods {
+				record(m.Lines)
+			}
+
+			existing := stats[cls.Filename]
+			for _, stat := range branches {
+				existing.Total += stat.Total
+				existing.Covered += stat.Covered
+			}
+			stats[cls.Filename] = existing
+		}
+	}
+	return stats, nil
+}
+
+// conditionCoverage reads the covered and total branches of a branch line.
+func conditionCoverage(ln line) (domain.CoverageStat, bool) {
+	if !ln.Branch {
+		return domain.CoverageStat{}, false
+	}
+	_, counts, ok := strings.Cut(ln.ConditionCoverage, "(")
+	counts, _, _ = strings.Cut(counts, ")")
+	c, t, found := strings.Cut(counts, "/")
+	if !ok || !found {
+		return domain.CoverageStat{}, false
+	}
+	covered, cerr := strconv.Atoi(strings.TrimSpace(c))
+	total, terr := strconv.Atoi(strings.TrimSpace(t))
+	if cerr != nil || terr != nil || covered < 0 || total < covered {
+		return domain.CoverageStat{}, false
+	}
+	return domain.CoverageStat{Covered: covered, Total: total}, true
+}
+
+// ParseAllBranches merges branch counts from multiple Cobertura files.
+func (p *Parser) ParseAllBranches(paths []string) (map[string]domain.CoverageStat, error) {
+	merged := make(map[string]domain.CoverageStat)
+	for _, path := range paths {
+		stats, err := p.ParseBranches(path)
+		if err != nil {
+			return nil, err
+		}
+		for file, stat := range stats {
+			existing := merged[file]
+			existing.Total += stat.Total
+			existing.Covered += stat.Covered
+			merged[file] = existing
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,69 @@
+package cobertura
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// ParseLines reads a Cobertura XML file and returns per-line hit counts,
+// including lines nested under methods.
+func (p *Parser) ParseLines(path string) (map[string]domain.LineCoverage, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("open cobertura file: %w", err)
+	}
+	defer file.Close()
+
+	var cov coverage
+	if err := xml.NewDecoder(file).Decode(&cov); err != nil {
+		return nil, fmt.Errorf("decode cobertura xml: %w", err)
+	}
+
+	result := make(map[string]domain.LineCoverage)
+	record := func(filename string, lines []line) {
+		hits := result[filename]
+		if hits == nil {
+			hits = make(domain.LineCoverage)
+			result[filename] = hits
+		}
+		for _, ln := range lines {
+			if prev, ok := hits[ln.Number]; !ok || ln.Hits > prev {
+				hits[ln.Number] = ln.Hits
+			}
+		}
+	}
+	for _, pkg := range cov.Packages {
+		for _, cls := range pkg.Classes {
+			if cls.Filename == "" {
+				continue
+			}
+			record(cls.Filename, cls.Lines)
+			for _, m := range cls.Methods {
+				record(cls.Filename, m.Lines)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ParseAllLines merges per-line hit counts from multiple Cobertura files.
+func (p *Parser) ParseAllLines(paths []string) (map[string]domain.LineCoverage, error) {
+	merged := make(map[string]domain.LineCoverage)
+	for _, path := range paths {
+		lines, err := p.ParseLines(path)
+		if err != nil {
+			return nil, err
+		}
+		domain.MergeLineCoverage(merged, lines)
+	}
+	return merged, nil
+}
@@ -42,8 +42,10 @@ type method struct {
 }
 
 type line struct {
-	Number int `xml:"number,attr"`
-	Hits   int `xml:"hits,attr"`
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr"` // e.g. "50% (1/2)" on branch lines
 }
 
 // Parser implements ProfileParser for Cobertura XML format.
@@ -379,3 +379,38 @@ func createTempFile(t *testing.T, content string) string {
 	require.NoError(t, err)
 	return tmpfile
 }
+
+func TestParser_ParseAllBranches(t *testing.T) {
+	content := `<?xml version="1.0"?>
+<coverage version="1.0">
+  <packages>
+    <package name="mypackage">
+      <classes>
+        <class name="MyClass" filename="mypackage/myclass.py">
+          <methods>
+            <method name="process">
+              <lines>
+                <line number="5" hits="3" branch="true" condition-coverage="50% (1/2)"/>
+              </lines>
+            </method>
+          </methods>
+          <lines>
+            <line number="5" hits="3" branch="true" condition-coverage="50% (1/2)"/>
+            <line number="6" hits="1"/>
+            <line number="9" hits="2" branch="true" condition-coverage="75% (3/4)"/>
+            <line number="12" hits="0" branch="true" condition-coverage="bogus"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+	tmpfile := createTempFile(t, content)
+
+	stats, err := New().ParseAllBranches([]string{tmpfile})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats["mypackage/myclass.py"].Covered) // line 5 counted once
+	assert.Equal(t, 6, stats["mypackage/myclass.py"].Total)
+}
@@ -0,0 +1,117 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/covdata"
+)
+
+// expandProfiles replaces glob patterns and plain directories among paths
+// with the profiles they name, so per-shard outputs such as
+// coverage-*.out merge without being listed one by one. A directory
+// contributes the files directly inside it in a recognised format; Go
+// covdata directories stay as given. Other paths are kept, so a missing
+// file still fails parsing. warnings lists patterns and directories that
+// name no profile.
+func (r *Registry) expandProfiles(paths []string) (expanded, warnings []string) {
+	seen := make(map[string]bool, len(paths))
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			expanded = append(expanded, path)
+		}
+	}
+	for _, path := range paths {
+		if !isPattern(path) {
+			if !isProfileDir(path) {
+				add(path)
+				continue
+			}
+			files := r.dirProfiles(path)
+			if len(files) == 0 {
+				warnings = append(warnings, fmt.Sprintf("profile directory %s holds no coverage profiles", path))
+			}
+			for _, file := range files {
+				add(file)
+			}
+			continue
+		}
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("profile pattern %s is invalid: %v", path, err))
+			continue
+		}
+		var found bool
+		for _, match := range matches {
+			if isProfileDir(match) {
+				for _, file := range r.dirProfiles(match) {
+					found = true
+					add(file)
+				}
+				continue
+			}
+			if info, err := os.Stat(match); err == nil && (info.Mode().IsRegular() || covdata.IsDir(match)) {
+				found = true
+				add(match)
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("profile pattern %s matches no files", path))
+		}
+	}
+	return expanded, warnings
+}
+
+// isPattern reports whether path holds glob metacharacters.
+func isPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// isProfileDir reports whether path is a directory of profiles rather than
+// a file or a Go covdata directory.
+func isProfileDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir() && !covdata.IsDir(path)
+}
+
+// dirProfiles returns the files directly in dir whose format is
+// recognised, sorted by name. Hidden files are skipped.
+func (r *Registry) dirProfiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if format, err := r.detector.DetectFormat(path); err == nil && format != application.FormatAuto {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// ExpandProfiles returns paths with glob patterns and directories replaced
+// by the profiles they name.
+func (r *Registry) ExpandProfiles(paths []string) []string {
+	expanded, _ := r.expandProfiles(paths)
+	return expanded
+}
+
+// ExpansionWarnings reports glob patterns and directories among paths that
+// name no profile.
+func (r *Registry) ExpansionWarnings(paths []string) []string {
+	_, warnings := r.expandProfiles(paths)
+	return warnings
+}
+
+var _ application.ProfileExpander = (*Registry)(nil)
@@ -0,0 +1,48 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_ParseAll_ExpandsPatternsAndDirectories(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	write("coverage-1.out", "mode: set\nexample.com/a/a.go:1.1,2.2 1 1\n")
+	write("coverage-2.out", "mode: set\nexample.com/b/b.go:1.1,2.2 1 0\n")
+	write("lcov/lcov-web.info", "SF:web/app.js\nDA:1,1\nDA:2,0\nend_of_record\n")
+	write("lcov/notes.txt", "not a profile\n")
+
+	registry := NewRegistry()
+	paths := []string{filepath.Join(dir, "coverage-*.out"), filepath.Join(dir, "lcov"), filepath.Join(dir, "coverage-1.out")}
+	stats, err := registry.ParseAll(paths)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats["example.com/a/a.go"].Covered, "coverage-1.out is parsed once")
+	assert.Equal(t, 1, stats["example.com/b/b.go"].Total)
+	assert.Equal(t, 2, stats["web/app.js"].Total)
+	assert.Empty(t, registry.ExpansionWarnings(paths))
+
+	single, err := registry.Parse(filepath.Join(dir, "coverage-*.out"))
+	require.NoError(t, err)
+	assert.Len(t, single, 2)
+
+	lines, err := registry.ParseLines(filepath.Join(dir, "lcov"))
+	require.NoError(t, err)
+	assert.Len(t, lines["web/app.js"], 2)
+
+	missing := []string{filepath.Join(dir, "shard-*.out"), t.TempDir()}
+	stats, err = registry.ParseAll(missing)
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+	warnings := registry.ExpansionWarnings(missing)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "matches no files")
+	assert.Contains(t, warnings[1], "holds no coverage profiles")
+}
@@ -0,0 +1,62 @@
+package jacoco
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// ParseBranches reads a JaCoCo XML file and returns per-file branch counts
+// from each line's missed and covered branches.
+func (p *Parser) ParseBranches(path string) (map[string]domain.CoverageStat, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("open jacoco file: %w", err)
+	}
+	defer file.Close()
+
+	var rpt report
+	if err := xml.NewDecoder(file).Decode(&rpt); err != nil {
+		return nil, fmt.Errorf("decode jacoco xml: %w", err)
+	}
+
+	stats := make(map[string]domain.CoverageStat)
+	for _, pkg := range rpt.Packages {
+		for _, sf := range pkg.SourceFiles {
+			filename := pkg.Name + "/" + sf.Name
+			existing := stats[filename]
+			for _, ln := range sf.Lines {
+				existing.Total += ln.Mb + ln.Cb
+				existing.Covered += ln.Cb
+			}
+			stats[filename] = existing
+		}
+	}
+	return stats, nil
+}
+
+// ParseAllBranches merges branch counts from multiple JaCoCo files.
+func (p *Parser) ParseAllBranches(paths []string) (map[string]domain.CoverageStat, error) {
+	merged := make(map[string]domain.CoverageStat)
+	for _, path := range paths {
+		stats, err := p.ParseBranches(path)
+		if err != nil {
+			return nil, err
+		}
+		for file, stat := range stats {
+			existing := merged[file]
+			existing.Total += stat.Total
+			existing.Covered += stat.Covered
+			merged[file] = existing
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,65 @@
+package jacoco
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// ParseLines reads a JaCoCo XML file and returns per-line hit counts. JaCoCo
+// records instructions rather than executions, so the covered instruction
+// count stands in for the hit count.
+func (p *Parser) ParseLines(path string) (map[string]domain.LineCoverage, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("open jacoco file: %w", err)
+	}
+	defer file.Close()
+
+	var rpt report
+	if err := xml.NewDecoder(file).Decode(&rpt); err != nil {
+		return nil, fmt.Errorf("decode jacoco xml: %w", err)
+	}
+
+	result := make(map[string]domain.LineCoverage)
+	for _, pkg := range rpt.Packages {
+		for _, sf := range pkg.SourceFiles {
+			filename := pkg.Name + "/" + sf.Name
+			hits := result[filename]
+			if hits == nil {
+				hits = make(domain.LineCoverage)
+				result[filename] = hits
+			}
+			for _, ln := range sf.Lines {
+				if ln.Mi+ln.Ci == 0 {
+					continue
+				}
+				if prev, ok := hits[ln.Nr]; !ok || ln.Ci > prev {
+					hits[ln.Nr] = ln.Ci
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// ParseAllLines merges per-line hit counts from multiple JaCoCo files.
+func (p *Parser) ParseAllLines(paths []string) (map[string]domain.LineCoverage, error) {
+	merged := make(map[string]domain.LineCoverage)
+	for _, path := range paths {
+		lines, err := p.ParseLines(path)
+		if err != nil {
+			return nil, err
+		}
+		domain.MergeLineCoverage(merged, lines)
+	}
+	return merged, nil
+}
@@ -144,3 +144,35 @@ func createTempFile(t *testing.T, name, content string) string {
 	require.NoError(t, err)
 	return tmpfile
 }
+
+func TestParser_ParseLines(t *testing.T) {
+	path := createTempFile(t, "jacoco.xml", minimalJaCoCo)
+
+	lines, err := New().ParseLines(path)
+
+	require.NoError(t, err)
+	got := lines["com/example/app/Main.java"]
+	require.Len(t, got, 4)
+	assert.Positive(t, got[3])
+	assert.Equal(t, 0, got[7])
+}
+
+func TestParser_ParseAllBranches(t *testing.T) {
+	path := createTempFile(t, "jacoco.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<report name="branches">
+  <package name="com/example/app">
+    <sourcefile name="Main.java">
+      <line nr="3" mi="0" ci="4" mb="1" cb="1"/>
+      <line nr="5" mi="0" ci="3" mb="0" cb="4"/>
+      <line nr="7" mi="2" ci="0" mb="2" cb="0"/>
+    </sourcefile>
+  </package>
+</report>`)
+
+	stats, err := New().ParseAllBranches([]string{path, path})
+
+	require.NoError(t, err)
+	s := stats["com/example/app/Main.java"]
+	assert.Equal(t, 10, s.Covered)
+	assert.Equal(t, 16, s.Total)
+}
@@ -0,0 +1,95 @@
+package lcov
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// ParseBranches reads an LCOV file and returns per-file branch counts from
+// BRDA records, or from the BRF and BRH summaries when they count more.
+func (p *Parser) ParseBranches(path string) (map[string]domain.CoverageStat, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("open lcov file: %w", err)
+	}
+	defer file.Close()
+
+	stats := make(map[string]domain.CoverageStat)
+	scanner := bufio.NewScanner(file)
+
+	var currentFile string
+	var covered, total int
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			currentFile = strings.TrimPrefix(line, "SF:")
+			covered, total = 0, 0
+
+		case strings.HasPrefix(line, "BRDA:"):
+			// BRDA:line_number,block,branch,taken; taken is "-" when the
+			// block never ran.
+			parts := strings.Split(strings.TrimPrefix(line, "BRDA:"), ",")
+			if len(parts) >= 4 {
+				total++
+				if taken, _ := strconv.Atoi(parts[3]); taken > 0 {
+					covered++
+				}
+			}
+
+		case strings.HasPrefix(line, "BRF:"):
+			if brf, _ := strconv.Atoi(strings.TrimPrefix(line, "BRF:")); brf > total {
+				total = brf
+			}
+
+		case strings.HasPrefix(line, "BRH:"):
+			if brh, _ := strconv.Atoi(strings.TrimPrefix(line, "BRH:")); brh > covered {
+				covered = brh
+			}
+
+		case line == "end_of_record":
+			if currentFile != "" {
+				stats[currentFile] = domain.CoverageStat{Covered: covered, Total: total}
+			}
+			currentFile = ""
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan lcov file: %w", err)
+	}
+	if currentFile != "" {
+		stats[currentFile] = domain.CoverageStat{Covered: covered, Total: total}
+	}
+	return stats, nil
+}
+
+// ParseAllBranches merges branch counts from multiple LCOV files, taking
+// the maximum as ParseAll does.
+func (p *Parser) ParseAllBranches(paths []string) (map[string]domain.CoverageStat, error) {
+	merged := make(map[string]domain.CoverageStat)
+	for _, path := range paths {
+		stats, err := p.ParseBranches(path)
+		if err != nil {
+			return nil, err
+		}
+		for file, stat := range stats {
+			existing := merged[file]
+			existing.Total = max(existing.Total, stat.Total)
+			existing.Covered = max(existing.Covered, stat.Covered)
+			merged[file] = existing
+		}
+	}
+	return merged, nil
+}
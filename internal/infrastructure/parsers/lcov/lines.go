@@ -0,0 +1,76 @@
+package lcov
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// ParseLines reads an LCOV file and returns per-line hit counts from DA records.
+func (p *Parser) ParseLines(path string) (map[string]domain.LineCoverage, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	file, err := os.Open(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("open lcov file: %w", err)
+	}
+	defer file.Close()
+
+	result := make(map[string]domain.LineCoverage)
+	scanner := bufio.NewScanner(file)
+
+	var current domain.LineCoverage
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			name := strings.TrimPrefix(line, "SF:")
+			current = result[name]
+			if current == nil {
+				current = make(domain.LineCoverage)
+				result[name] = current
+			}
+		case strings.HasPrefix(line, "DA:") && current != nil:
+			parts := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(parts) < 2 {
+				continue
+			}
+			n, err := strconv.Atoi(parts[0])
+			if err != nil {
+				continue
+			}
+			count, _ := strconv.Atoi(parts[1])
+			if prev, ok := current[n]; !ok || count > prev {
+				current[n] = count
+			}
+		case line == "end_of_record":
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan lcov file: %w", err)
+	}
+	return result, nil
+}
+
+// ParseAllLines merges per-line hit counts from multiple LCOV files.
+func (p *Parser) ParseAllLines(paths []string) (map[string]domain.LineCoverage, error) {
+	merged := make(map[string]domain.LineCoverage)
+	for _, path := range paths {
+		lines, err := p.ParseLines(path)
+		if err != nil {
+			return nil, err
+		}
+		domain.MergeLineCoverage(merged, lines)
+	}
+	return merged, nil
+}
@@ -53,7 +53,7 @@ func (p *Parser) Parse(path string) (map[string]domain.CoverageStat, error) {
 	var covered, total int
 
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
 		if line == "" {
 			continue
 		}
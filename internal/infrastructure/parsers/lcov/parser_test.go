@@ -351,3 +351,71 @@ func createTempFile(t *testing.T, content string) string {
 	require.NoError(t, err)
 	return tmpfile
 }
+
+func TestParser_ParseLines(t *testing.T) {
+	content := `SF:src/main.py
+DA:1,3
+DA:4,0
+end_of_record`
+
+	tmpfile := createTempFile(t, content)
+
+	lines, err := New().ParseLines(tmpfile)
+
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	assert.Equal(t, 3, lines["src/main.py"][1])
+	hits, ok := lines["src/main.py"][4]
+	assert.True(t, ok)
+	assert.Equal(t, 0, hits)
+}
+
+func TestParser_Parse_WindowsCRLF(t *testing.T) {
+	// Written on Windows: byte order mark, CRLF endings, backslash paths.
+	content := "\ufeffSF:C:\\work\\app\\src\\core\\a.js\r\n" +
+		"DA:1,1\r\n" +
+		"DA:2,0\r\n" +
+		"end_of_record\r\n" +
+		"SF:src\\api\\b.js\r\n" +
+		"DA:1,1\r\n" +
+		"end_of_record\r\n"
+
+	tmpfile := createTempFile(t, content)
+
+	stats, err := New().Parse(tmpfile)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, 1, stats[`C:\work\app\src\core\a.js`].Covered)
+	assert.Equal(t, 2, stats[`C:\work\app\src\core\a.js`].Total)
+	assert.Equal(t, 1, stats[`src\api\b.js`].Total)
+
+	lines, err := New().ParseLines(tmpfile)
+	require.NoError(t, err)
+	assert.Equal(t, 0, lines[`C:\work\app\src\core\a.js`][2])
+}
+
+func TestParser_ParseAllBranches(t *testing.T) {
+	unit := createTempFile(t, `SF:src/main.py
+BRDA:5,0,0,1
+BRDA:5,0,1,0
+BRDA:9,0,0,-
+BRDA:9,0,1,3
+DA:5,1
+end_of_record
+SF:src/util.py
+BRF:4
+BRH:1
+end_of_record`)
+	e2e := createTempFile(t, `SF:src/util.py
+BRF:4
+BRH:3
+end_of_record`)
+
+	stats, err := New().ParseAllBranches([]string{unit, e2e})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats["src/main.py"].Covered) // taken "-" never ran
+	assert.Equal(t, 4, stats["src/main.py"].Total)
+	assert.Equal(t, 3, stats["src/util.py"].Covered)
+	assert.Equal(t, 4, stats["src/util.py"].Total)
+}
@@ -0,0 +1,40 @@
+package lcov
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// WriteLines writes per-line hit counts as an LCOV tracefile, one record
+// per file in name order.
+func WriteLines(w io.Writer, lines map[string]domain.LineCoverage) error {
+	files := make([]string, 0, len(lines))
+	for file := range lines {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	bw := bufio.NewWriter(w)
+	for _, file := range files {
+		hits := lines[file]
+		numbers := make([]int, 0, len(hits))
+		for n := range hits {
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+		fmt.Fprintf(bw, "SF:%s\n", file)
+		covered := 0
+		for _, n := range numbers {
+			if hits[n] > 0 {
+				covered++
+			}
+			fmt.Fprintf(bw, "DA:%d,%d\n", n, hits[n])
+		}
+		fmt.Fprintf(bw, "LF:%d\nLH:%d\nend_of_record\n", len(numbers), covered)
+	}
+	return bw.Flush()
+}
@@ -4,11 +4,15 @@
 package parsers
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"path/filepath"
+	"slices"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/covdata"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/coverprofile"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers/cobertura"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers/detector"
@@ -20,12 +24,45 @@ import (
 type Registry struct {
 	detector *detector.Detector
 	parsers  map[application.Format]application.ProfileParser
+	// detecting are extra parsers asked, in order, about profiles that no
+	// built-in format recognises.
+	detecting []detectingParser
+	// covdata converts a GOCOVERDIR directory to a text profile path and
+	// returns any other path unchanged.
+	covdata func(path string) (string, error)
+}
+
+// detectingParser is a parser that recognises its own profiles.
+type detectingParser interface {
+	application.ProfileParser
+	Detect(path string) bool
+}
+
+// Option configures the parser registry.
+type Option func(*Registry)
+
+// WithParser registers an additional parser, e.g. a plugin, under its
+// format. It cannot replace a built-in format. A parser that also has a
+// Detect(path) bool method is offered profiles no built-in format
+// recognises.
+func WithParser(p application.ProfileParser) Option {
+	return func(r *Registry) {
+		if _, ok := r.parsers[p.Format()]; ok {
+			return
+		}
+		r.parsers[p.Format()] = p
+		if d, ok := p.(detectingParser); ok {
+			r.detecting = append(r.detecting, d)
+		}
+	}
 }
 
 // NewRegistry creates a new parser registry with all supported parsers.
-func NewRegistry() *Registry {
-	return &Registry{
+func NewRegistry(opts ...Option) *Registry {
+	converter := &covdata.Converter{}
+	r := &Registry{
 		detector: detector.New(),
+		covdata:  converter.TextProfile,
 		parsers: map[application.Format]application.ProfileParser{
 			application.FormatGo:        coverprofile.Parser{},
 			application.FormatLCOV:      lcov.New(),
@@ -33,6 +70,20 @@ func NewRegistry() *Registry {
 			application.FormatJaCoCo:    jacoco.New(),
 		},
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// textProfile resolves a covdata directory to the text profile converted
+// from it, so every entry point accepts GOCOVERDIR output directly.
+func (r *Registry) textProfile(path string) (string, error) {
+	path, err := r.covdata(path)
+	if err != nil {
+		return "", parseError(err)
+	}
+	return path, nil
 }
 
 // Format returns the auto format, since the registry handles all formats.
@@ -40,31 +91,199 @@ func (r *Registry) Format() application.Format {
 	return application.FormatAuto
 }
 
-// Parse parses a coverage profile, auto-detecting the format.
+// Parse parses a coverage profile, auto-detecting the format. A glob
+// pattern or directory parses every profile it names, as ParseAll does.
 func (r *Registry) Parse(path string) (map[string]domain.CoverageStat, error) {
+	if isPattern(path) || isProfileDir(path) {
+		return r.ParseAll([]string{path})
+	}
+	return r.parse(path)
+}
+
+// parse parses a single profile file or covdata directory.
+func (r *Registry) parse(path string) (map[string]domain.CoverageStat, error) {
+	path, err := r.textProfile(path)
+	if err != nil {
+		return nil, err
+	}
 	format, err := r.detector.DetectFormat(path)
 	if err != nil {
-		return nil, fmt.Errorf("detect format: %w", err)
+		return nil, parseError(fmt.Errorf("detect format: %w", err))
 	}
 
 	parser, err := r.getParser(format, path)
 	if err != nil {
-		return nil, err
+		return nil, parseError(err)
 	}
 
-	return parser.Parse(path)
+	stats, err := parser.Parse(path)
+	return stats, parseError(err)
+}
+
+// parseError marks a profile that exists but cannot be parsed with
+// ErrCodeParseFormat. A missing or unreadable file is left unclassified.
+func parseError(err error) error {
+	if err == nil || errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+	return application.WithErrorCode(application.ErrCodeParseFormat, err)
 }
 
 // ParseAll parses multiple profiles, potentially with different formats.
+// Glob patterns and directories among paths are expanded first.
 func (r *Registry) ParseAll(paths []string) (map[string]domain.CoverageStat, error) {
 	merged := make(map[string]domain.CoverageStat)
+	paths, _ = r.expandProfiles(paths)
+
+	for _, path := range paths {
+		stats, err := r.parse(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for file, stat := range stats {
+			existing := merged[file]
+			existing.Total += stat.Total
+			existing.Covered += stat.Covered
+			merged[file] = existing
+		}
+	}
+
+	return merged, nil
+}
+
+// ParseLines parses per-line hit counts from a profile, auto-detecting the
+// format. A glob pattern or directory merges every profile it names.
+func (r *Registry) ParseLines(path string) (map[string]domain.LineCoverage, error) {
+	if isPattern(path) || isProfileDir(path) {
+		return r.ParseAllLines([]string{path})
+	}
+	return r.parseLines(path)
+}
+
+// parseLines parses per-line hit counts from a single profile.
+func (r *Registry) parseLines(path string) (map[string]domain.LineCoverage, error) {
+	path, err := r.textProfile(path)
+	if err != nil {
+		return nil, err
+	}
+	format, err := r.detector.DetectFormat(path)
+	if err != nil {
+		return nil, parseError(fmt.Errorf("detect format: %w", err))
+	}
+
+	parser, err := r.getParser(format, path)
+	if err != nil {
+		return nil, parseError(err)
+	}
+
+	lineParser, ok := parser.(lineParser)
+	if !ok {
+		return nil, fmt.Errorf("format %s does not provide line coverage", parser.Format())
+	}
+	lines, err := lineParser.ParseLines(path)
+	return lines, parseError(err)
+}
+
+// ParseAllLines merges per-line hit counts from multiple profiles. Go
+// profiles are merged together first so their coverage modes are
+// reconciled; the highest count wins between formats.
+func (r *Registry) ParseAllLines(paths []string) (map[string]domain.LineCoverage, error) {
+	merged := make(map[string]domain.LineCoverage)
+	paths, _ = r.expandProfiles(paths)
+	goPaths, err := r.goProfiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		if slices.Contains(goPaths, path) {
+			continue
+		}
+		lines, err := r.parseLines(path)
+		if err != nil {
+			return nil, err
+		}
+		domain.MergeLineCoverage(merged, lines)
+	}
+	if len(goPaths) > 0 {
+		lines, err := coverprofile.Parser{}.ParseAllLines(r.textProfiles(goPaths))
+		if err != nil {
+			return nil, parseError(err)
+		}
+		domain.MergeLineCoverage(merged, lines)
+	}
+	return merged, nil
+}
+
+// ModeWarnings reports Go profiles among paths that mix coverage modes.
+func (r *Registry) ModeWarnings(paths []string) ([]string, error) {
+	paths, _ = r.expandProfiles(paths)
+	goPaths, err := r.goProfiles(paths)
+	if err != nil || len(goPaths) < 2 {
+		return nil, err
+	}
+	return coverprofile.Parser{}.ModeWarnings(r.textProfiles(goPaths))
+}
 
+var _ application.ModeReconciler = (*Registry)(nil)
+
+// goProfiles returns the paths, as given, that hold Go profiles.
+func (r *Registry) goProfiles(paths []string) ([]string, error) {
+	var goPaths []string
 	for _, path := range paths {
-		stats, err := r.Parse(path)
+		text, err := r.textProfile(path)
 		if err != nil {
 			return nil, err
 		}
+		if format, err := r.detector.DetectFormat(text); err == nil && format == application.FormatGo {
+			goPaths = append(goPaths, path)
+		}
+	}
+	return goPaths, nil
+}
+
+// textProfiles resolves paths already known to convert cleanly.
+func (r *Registry) textProfiles(paths []string) []string {
+	texts := make([]string, len(paths))
+	for i, path := range paths {
+		texts[i], _ = r.textProfile(path)
+	}
+	return texts
+}
+
+// lineParser is implemented by every registered parser.
+type lineParser interface {
+	ParseLines(path string) (map[string]domain.LineCoverage, error)
+}
+
+var _ application.LineProfileParser = (*Registry)(nil)
 
+// ParseAllBranches sums branch counts from multiple profiles, as ParseAll
+// sums statements. Every profile's format must record branches.
+func (r *Registry) ParseAllBranches(paths []string) (map[string]domain.CoverageStat, error) {
+	merged := make(map[string]domain.CoverageStat)
+	paths, _ = r.expandProfiles(paths)
+	for _, path := range paths {
+		path, err := r.textProfile(path)
+		if err != nil {
+			return nil, err
+		}
+		format, err := r.detector.DetectFormat(path)
+		if err != nil {
+			return nil, parseError(fmt.Errorf("detect format: %w", err))
+		}
+		parser, err := r.getParser(format, path)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		branchParser, ok := parser.(application.BranchProfileParser)
+		if !ok {
+			return nil, fmt.Errorf("format %s does not provide branch coverage", parser.Format())
+		}
+		stats, err := branchParser.ParseAllBranches([]string{path})
+		if err != nil {
+			return nil, parseError(err)
+		}
 		for file, stat := range stats {
 			existing := merged[file]
 			existing.Total += stat.Total
@@ -72,10 +291,84 @@ func (r *Registry) ParseAll(paths []string) (map[string]domain.CoverageStat, err
 			merged[file] = existing
 		}
 	}
+	return merged, nil
+}
+
+var _ application.BranchProfileParser = (*Registry)(nil)
 
+// ParseAllBlocks returns statement blocks from the profiles whose format
+// reports them; profiles in other formats contribute no blocks. Blocks are
+// concatenated per profile, mirroring how ParseAll sums statements.
+func (r *Registry) ParseAllBlocks(paths []string) (map[string][]domain.CoverageBlock, error) {
+	merged := make(map[string][]domain.CoverageBlock)
+	paths, _ = r.expandProfiles(paths)
+	for _, path := range paths {
+		path, err := r.textProfile(path)
+		if err != nil {
+			return nil, err
+		}
+		format, err := r.detector.DetectFormat(path)
+		if err != nil {
+			return nil, parseError(fmt.Errorf("detect format: %w", err))
+		}
+		parser, err := r.getParser(format, path)
+		if err != nil {
+			return nil, parseError(err)
+		}
+		blockParser, ok := parser.(application.BlockProfileParser)
+		if !ok {
+			continue
+		}
+		blocks, err := blockParser.ParseAllBlocks([]string{path})
+		if err != nil {
+			return nil, parseError(err)
+		}
+		for file, b := range blocks {
+			merged[file] = append(merged[file], b...)
+		}
+	}
 	return merged, nil
 }
 
+var _ application.BlockProfileParser = (*Registry)(nil)
+
+// InspectProfile checks a profile of any detected format. Parsers that can
+// inspect their own format (Go) check it block by block; other formats
+// report whether they parse, with their file and statement counts.
+func (r *Registry) InspectProfile(path string) (application.ProfileInspection, error) {
+	path, err := r.textProfile(path)
+	if err != nil {
+		return application.ProfileInspection{}, err
+	}
+	format, err := r.detector.DetectFormat(path)
+	if err != nil {
+		return application.ProfileInspection{}, err
+	}
+	parser, err := r.getParser(format, path)
+	if err != nil {
+		return application.ProfileInspection{Format: format, Errors: []string{err.Error()}}, nil
+	}
+	if inspector, ok := parser.(application.ProfileInspector); ok {
+		return inspector.InspectProfile(path)
+	}
+	inspection := application.ProfileInspection{Format: parser.Format()}
+	stats, err := parser.Parse(path)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+		return inspection, err
+	}
+	if err != nil {
+		inspection.Errors = []string{err.Error()}
+		return inspection, nil
+	}
+	inspection.Files = len(stats)
+	for _, stat := range stats {
+		inspection.Statements += stat.Total
+	}
+	return inspection, nil
+}
+
+var _ application.ProfileInspector = (*Registry)(nil)
+
 // ParseWithFormat parses a profile using a specific format (no auto-detection).
 func (r *Registry) ParseWithFormat(path string, format application.Format) (map[string]domain.CoverageStat, error) {
 	parser, ok := r.parsers[format]
@@ -87,7 +380,9 @@ func (r *Registry) ParseWithFormat(path string, format application.Format) (map[
 
 // getParser returns the appropriate parser for a detected format.
 // When format is unknown, it uses language detection to select the
-// appropriate parser before falling back to Go format.
+// appropriate parser, then asks the plugin parsers, before falling back to
+// Go format. Built-in detection goes first so plugins are neither started
+// for profiles coverctl reads itself nor able to claim them.
 func (r *Registry) getParser(format application.Format, path string) (application.ProfileParser, error) {
 	if format == application.FormatAuto {
 		// Try language-aware format selection based on project directory
@@ -98,6 +393,13 @@ func (r *Registry) getParser(format application.Format, path string) (applicatio
 			}
 		}
 	}
+	if format == application.FormatAuto {
+		for _, p := range r.detecting {
+			if p.Detect(path) {
+				return p, nil
+			}
+		}
+	}
 
 	// Final fallback to Go format
 	if format == application.FormatAuto {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -98,6 +99,34 @@ end_of_record`
 	assert.Equal(t, 1, stats["src/app.py"].Covered)
 }
 
+func TestRegistry_ParseAllBlocks_SkipsFormatsWithoutBlocks(t *testing.T) {
+	goFile := createTempFile(t, "coverage.out", "mode: set\ngithub.com/example/pkg/main.go:3.1,5.2 2 1")
+	lcovFile := createTempFile(t, "coverage.info", "SF:src/app.py\nDA:1,1\nLF:1\nLH:1\nend_of_record")
+
+	registry := NewRegistry()
+	blocks, err := registry.ParseAllBlocks([]string{goFile, lcovFile})
+
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Len(t, blocks["github.com/example/pkg/main.go"], 1)
+	assert.Equal(t, 3, blocks["github.com/example/pkg/main.go"][0].Lines.Start)
+	assert.Equal(t, 2, blocks["github.com/example/pkg/main.go"][0].Stat.Total)
+}
+
+func TestRegistry_ParseAllBranches(t *testing.T) {
+	lcovFile := createTempFile(t, "coverage.info", "SF:src/app.py\nBRDA:1,0,0,1\nBRDA:1,0,1,0\nend_of_record")
+	goFile := createTempFile(t, "coverage.out", "mode: set\ngithub.com/example/pkg/main.go:3.1,5.2 2 1")
+
+	registry := NewRegistry()
+	stats, err := registry.ParseAllBranches([]string{lcovFile})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats["src/app.py"].Covered)
+	assert.Equal(t, 2, stats["src/app.py"].Total)
+
+	_, err = registry.ParseAllBranches([]string{lcovFile, goFile})
+	assert.ErrorContains(t, err, "does not provide branch coverage")
+}
+
 func TestRegistry_ParseAll_Empty(t *testing.T) {
 	registry := NewRegistry()
 	stats, err := registry.ParseAll([]string{})
@@ -131,6 +160,82 @@ func TestRegistry_ParseWithFormat_Unsupported(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported format")
 }
 
+// customParser stands in for a plugin parser.
+type customParser struct {
+	format application.Format
+	detect bool
+}
+
+func (p customParser) Format() application.Format { return p.format }
+func (p customParser) Detect(string) bool         { return p.detect }
+func (p customParser) Parse(string) (map[string]domain.CoverageStat, error) {
+	return map[string]domain.CoverageStat{"src/a.x": {Covered: 1, Total: 2}}, nil
+}
+func (p customParser) ParseAll(paths []string) (map[string]domain.CoverageStat, error) {
+	return p.Parse("")
+}
+
+func TestRegistry_WithParser(t *testing.T) {
+	tmpfile := createTempFile(t, "coverage.bzl", "opaque")
+
+	t.Run("detects unknown profiles", func(t *testing.T) {
+		registry := NewRegistry(WithParser(customParser{format: "bazel", detect: true}))
+		stats, err := registry.Parse(tmpfile)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats["src/a.x"].Total)
+		assert.Contains(t, registry.SupportedFormats(), application.Format("bazel"))
+	})
+
+	t.Run("selectable by format", func(t *testing.T) {
+		registry := NewRegistry(WithParser(customParser{format: "bazel"}))
+		stats, err := registry.ParseWithFormat(tmpfile, "bazel")
+		require.NoError(t, err)
+		assert.Len(t, stats, 1)
+	})
+
+	t.Run("cannot replace built-in formats", func(t *testing.T) {
+		goProfile := createTempFile(t, "coverage.out", "mode: set\nexample.com/m/a.go:1.1,2.2 1 1\n")
+		registry := NewRegistry(WithParser(customParser{format: application.FormatGo, detect: true}))
+		stats, err := registry.Parse(goProfile)
+		require.NoError(t, err)
+		assert.Contains(t, stats, "example.com/m/a.go")
+	})
+}
+
+// askedParser counts the Detect calls a plugin parser receives.
+type askedParser struct {
+	customParser
+	asked *int
+}
+
+func (p askedParser) Detect(path string) bool {
+	*p.asked++
+	return p.customParser.Detect(path)
+}
+
+func TestRegistry_PluginsAskedAfterBuiltinDetection(t *testing.T) {
+	asked := 0
+	registry := NewRegistry(WithParser(askedParser{customParser: customParser{format: "bazel", detect: true}, asked: &asked}))
+
+	goProfile := createTempFile(t, "coverage.out", "mode: set\nexample.com/m/a.go:1.1,2.2 1 1\n")
+	stats, err := registry.Parse(goProfile)
+	require.NoError(t, err)
+	assert.Contains(t, stats, "example.com/m/a.go")
+	assert.Zero(t, asked, "a recognised format never reaches the plugins")
+
+	project := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(project, "go.mod"), []byte("module example.com/m\n"), 0o644))
+	opaque := filepath.Join(project, "coverage.bzl")
+	require.NoError(t, os.WriteFile(opaque, []byte("opaque"), 0o644))
+	_, _ = registry.Parse(opaque)
+	assert.Zero(t, asked, "language detection picks the built-in parser first")
+
+	stats, err = registry.Parse(createTempFile(t, "coverage.bzl", "opaque"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, asked, "plugins are asked once built-in detection finds nothing")
+	assert.Contains(t, stats, "src/a.x")
+}
+
 func TestRegistry_Format(t *testing.T) {
 	registry := NewRegistry()
 	assert.Equal(t, application.FormatAuto, registry.Format())
@@ -229,6 +334,22 @@ end_of_record`
 }
 
 // createTempFile creates a temporary file with the given content.
+func TestRegistry_ParseErrorCodes(t *testing.T) {
+	registry := NewRegistry()
+
+	_, err := registry.Parse(createTempFile(t, "coverage.out", "mode: set\nnot a coverage line\n"))
+	require.Error(t, err)
+	assert.Equal(t, application.ErrCodeParseFormat, application.ErrorCodeOf(err))
+
+	_, err = registry.ParseLines(createTempFile(t, "coverage.out", "mode: set\nnot a coverage line\n"))
+	require.Error(t, err)
+	assert.Equal(t, application.ErrCodeParseFormat, application.ErrorCodeOf(err))
+
+	_, err = registry.Parse(filepath.Join(t.TempDir(), "missing.out"))
+	require.Error(t, err)
+	assert.Empty(t, application.ErrorCodeOf(err), "a missing file is not a format error")
+}
+
 func createTempFile(t *testing.T, name, content string) string {
 	t.Helper()
 	tmpdir := t.TempDir()
@@ -237,3 +358,68 @@ func createTempFile(t *testing.T, name, content string) string {
 	require.NoError(t, err)
 	return tmpfile
 }
+
+func TestRegistry_InspectProfile(t *testing.T) {
+	registry := NewRegistry()
+
+	lcov := createTempFile(t, "coverage.info", "SF:src/main.py\nDA:1,1\nDA:2,0\nend_of_record\n")
+	inspection, err := registry.InspectProfile(lcov)
+	require.NoError(t, err)
+	assert.Equal(t, application.FormatLCOV, inspection.Format)
+	assert.Equal(t, 1, inspection.Files)
+	assert.Equal(t, 2, inspection.Statements)
+	assert.Empty(t, inspection.Errors)
+
+	goProfile := createTempFile(t, "coverage.out", "not a profile\n")
+	inspection, err = registry.InspectProfile(goProfile)
+	require.NoError(t, err)
+	assert.Equal(t, application.FormatGo, inspection.Format)
+	require.NotEmpty(t, inspection.Errors)
+	assert.Contains(t, inspection.Errors[0], "invalid coverage mode line")
+}
+
+func TestRegistry_ParseCovdataDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "covmeta.abc"), nil, 0o644))
+	converted := createTempFile(t, "covdata.out", "mode: set\nexample.com/pkg/a.go:1.1,2.2 2 1\n")
+
+	registry := NewRegistry()
+	var calls []string
+	registry.covdata = func(path string) (string, error) {
+		calls = append(calls, path)
+		if path == dir {
+			return converted, nil
+		}
+		return path, nil
+	}
+
+	stats, err := registry.ParseAll([]string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CoverageStat{Covered: 2, Total: 2}, stats["example.com/pkg/a.go"])
+
+	lines, err := registry.ParseLines(dir)
+	require.NoError(t, err)
+	assert.Contains(t, lines, "example.com/pkg/a.go")
+	assert.Equal(t, []string{dir, dir}, calls)
+}
+
+func TestRegistry_ParseAllLines_ReconcilesGoModes(t *testing.T) {
+	registry := NewRegistry()
+	unit := createTempFile(t, "unit.out", "mode: count\nexample.com/pkg/a.go:1.1,1.9 1 5\n")
+	e2e := createTempFile(t, "e2e.out", "mode: set\nexample.com/pkg/a.go:1.1,1.9 1 1\n")
+	lcov := createTempFile(t, "coverage.info", "SF:src/main.py\nDA:1,3\nend_of_record\n")
+
+	lines, err := registry.ParseAllLines([]string{unit, lcov, e2e})
+	require.NoError(t, err)
+	assert.Equal(t, 1, lines["example.com/pkg/a.go"][1], "mixed set and count profiles merge as set")
+	assert.Equal(t, 3, lines["src/main.py"][1])
+
+	warnings, err := registry.ModeWarnings([]string{unit, lcov, e2e})
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "mix coverage modes")
+
+	warnings, err = registry.ModeWarnings([]string{unit, lcov})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
@@ -25,8 +25,9 @@ func NewGoModuleNormalizer(moduleRoot, modulePath string) *GoModuleNormalizer {
 
 // NormalizePath converts a coverage file path to an absolute path.
 func (n *GoModuleNormalizer) NormalizePath(file string) string {
+	file = domain.CanonicalPath(file)
 	clean := filepath.Clean(file)
-	if filepath.IsAbs(clean) {
+	if filepath.IsAbs(clean) || domain.IsWindowsAbs(file) {
 		return clean
 	}
 	if n.ModulePath != "" {
@@ -0,0 +1,91 @@
+package plugin
+
+import (
+	"context"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Parser is a profile parser backed by a coverctl-parser-<format> plugin.
+type Parser struct {
+	format string
+	path   string
+}
+
+// NewParser returns a parser for the plugin executable at path.
+func NewParser(format, path string) *Parser {
+	return &Parser{format: format, path: path}
+}
+
+// Format returns the plugin's format name.
+func (p *Parser) Format() application.Format { return application.Format(p.format) }
+
+// Path returns the plugin executable.
+func (p *Parser) Path() string { return p.path }
+
+// Detect asks the plugin whether it understands the profile at path.
+func (p *Parser) Detect(path string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), detectTimeout)
+	defer cancel()
+	resp, err := call(ctx, p.path, "", request{Action: "detect", Profile: path}, nil)
+	return err == nil && resp.Detected
+}
+
+// Parse returns the plugin's per-file statement counts for the profile.
+func (p *Parser) Parse(path string) (map[string]domain.CoverageStat, error) {
+	files, err := p.parse(path)
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]domain.CoverageStat, len(files))
+	for file, f := range files {
+		stats[file] = domain.CoverageStat{Covered: f.Covered, Total: f.Total}
+	}
+	return stats, nil
+}
+
+// ParseAll parses and sums several profiles.
+func (p *Parser) ParseAll(paths []string) (map[string]domain.CoverageStat, error) {
+	merged := make(map[string]domain.CoverageStat)
+	for _, path := range paths {
+		stats, err := p.Parse(path)
+		if err != nil {
+			return nil, err
+		}
+		for file, stat := range stats {
+			existing := merged[file]
+			existing.Covered += stat.Covered
+			existing.Total += stat.Total
+			merged[file] = existing
+		}
+	}
+	return merged, nil
+}
+
+// ParseLines returns per-line hit counts for the files whose response
+// included "lines".
+func (p *Parser) ParseLines(path string) (map[string]domain.LineCoverage, error) {
+	files, err := p.parse(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := make(map[string]domain.LineCoverage)
+	for file, f := range files {
+		if len(f.Lines) > 0 {
+			lines[file] = domain.LineCoverage(f.Lines)
+		}
+	}
+	return lines, nil
+}
+
+func (p *Parser) parse(path string) (map[string]fileStat, error) {
+	resp, err := call(context.Background(), p.path, "", request{Action: "parse", Profile: path}, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Files, nil
+}
+
+var _ application.ProfileParser = (*Parser)(nil)
@@ -0,0 +1,193 @@
+// Package plugin adds coverage runners and profile parsers implemented by
+// external executables, so a proprietary build system can be supported
+// without forking coverctl.
+//
+// Plugins are discovered on PATH by name: coverctl-runner-<name> adds a
+// runner selectable with `runner: <name>`, coverctl-parser-<format> adds a
+// profile format. coverctl starts the plugin once per request, writes one
+// JSON request to its stdin, and reads one JSON response from its stdout.
+// Anything the plugin prints to stderr, such as test output, is passed
+// through. A non-zero exit or a response with "error" set fails the
+// request.
+//
+// Requests carry "protocol" (currently 1) and "action":
+//
+//	runner  detect  {"project_dir"}                      -> {"detected": bool}
+//	runner  run     {"project_dir", "profile", "packages",
+//	                 "domains", "build_flags"}            -> {"profile": path}
+//	parser  detect  {"profile"}                           -> {"detected": bool}
+//	parser  parse   {"profile"}                           -> {"files": {path: {"covered", "total", "lines"}}}
+//
+// "lines" is optional and maps line numbers to hit counts.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/cmdrun"
+)
+
+// ProtocolVersion is sent with every request so plugins can reject
+// requests they do not understand.
+const ProtocolVersion = 1
+
+// Executable name prefixes that mark a plugin.
+const (
+	RunnerPrefix = "coverctl-runner-"
+	ParserPrefix = "coverctl-parser-"
+)
+
+// Plugins are the plugin executables found by Discover.
+type Plugins struct {
+	Runners []*Runner
+	Parsers []*Parser
+}
+
+// Discover finds plugins in the directories of pathEnv, a PATH-style list.
+// When two directories hold a plugin with the same name the first wins, as
+// with command lookup. Plugins are returned sorted by name.
+func Discover(pathEnv string) Plugins {
+	runners := map[string]string{}
+	parsers := map[string]string{}
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := executableName(dir, e)
+			if !ok {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if n, ok := strings.CutPrefix(name, RunnerPrefix); ok && n != "" && runners[n] == "" {
+				runners[n] = path
+			}
+			if n, ok := strings.CutPrefix(name, ParserPrefix); ok && n != "" && parsers[n] == "" {
+				parsers[n] = path
+			}
+		}
+	}
+
+	var found Plugins
+	for _, name := range sortedKeys(runners) {
+		found.Runners = append(found.Runners, NewRunner(name, runners[name]))
+	}
+	for _, name := range sortedKeys(parsers) {
+		found.Parsers = append(found.Parsers, NewParser(name, parsers[name]))
+	}
+	return found
+}
+
+// executableName returns the command name of an executable directory
+// entry, without the .exe suffix on Windows.
+func executableName(dir string, e os.DirEntry) (string, bool) {
+	if e.IsDir() || !strings.HasPrefix(e.Name(), "coverctl-") {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.CutSuffix(e.Name(), ".exe")
+	}
+	info, err := os.Stat(filepath.Join(dir, e.Name()))
+	if err != nil || info.IsDir() || info.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+	return e.Name(), true
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// request is the JSON written to a plugin's stdin.
+type request struct {
+	Protocol   int         `json:"protocol"`
+	Action     string      `json:"action"`
+	ProjectDir string      `json:"project_dir,omitempty"`
+	Profile    string      `json:"profile,omitempty"`
+	Packages   []string    `json:"packages,omitempty"`
+	Domains    []domainRef `json:"domains,omitempty"`
+	BuildFlags *buildFlags `json:"build_flags,omitempty"`
+}
+
+type domainRef struct {
+	Name  string   `json:"name"`
+	Match []string `json:"match"`
+}
+
+type buildFlags struct {
+	Tags     string   `json:"tags,omitempty"`
+	Race     bool     `json:"race,omitempty"`
+	Short    bool     `json:"short,omitempty"`
+	Verbose  bool     `json:"verbose,omitempty"`
+	Run      string   `json:"run,omitempty"`
+	Timeout  string   `json:"timeout,omitempty"`
+	TestArgs []string `json:"test_args,omitempty"`
+}
+
+// response is the JSON a plugin writes to stdout.
+type response struct {
+	Error    string              `json:"error"`
+	Detected bool                `json:"detected"`
+	Profile  string              `json:"profile"`
+	Files    map[string]fileStat `json:"files"`
+}
+
+type fileStat struct {
+	Covered int         `json:"covered"`
+	Total   int         `json:"total"`
+	Lines   map[int]int `json:"lines"`
+}
+
+// call runs the plugin at path with req on stdin and decodes its stdout.
+// stderr receives the plugin's diagnostics; nil discards them.
+func call(ctx context.Context, path, dir string, req request, stderr io.Writer) (response, error) {
+	req.Protocol = ProtocolVersion
+	in, err := json.Marshal(req)
+	if err != nil {
+		return response{}, err
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	var out bytes.Buffer
+	name := filepath.Base(path)
+	runErr := cmdrun.Runner{Stdin: bytes.NewReader(in), Stdout: &out, Stderr: stderr}.Exec(ctx, dir, path, nil)
+
+	var resp response
+	if decodeErr := json.Unmarshal(bytes.TrimSpace(out.Bytes()), &resp); decodeErr != nil {
+		if runErr != nil {
+			return response{}, fmt.Errorf("plugin %s %s: %w", name, req.Action, runErr)
+		}
+		return response{}, fmt.Errorf("plugin %s %s: invalid response: %w", name, req.Action, decodeErr)
+	}
+	if resp.Error != "" {
+		return response{}, fmt.Errorf("plugin %s %s: %s", name, req.Action, resp.Error)
+	}
+	if runErr != nil {
+		return response{}, fmt.Errorf("plugin %s %s: %w", name, req.Action, runErr)
+	}
+	return resp, nil
+}
+
+// errNoIntegration is returned by plugin runners for integration coverage,
+// which the protocol does not cover.
+var errNoIntegration = errors.New("plugin runners do not support integration coverage")
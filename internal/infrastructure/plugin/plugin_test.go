@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// writePlugin writes an executable shell script named name into dir.
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	writePlugin(t, first, "coverctl-runner-bazel", "")
+	writePlugin(t, second, "coverctl-runner-bazel", "")
+	writePlugin(t, second, "coverctl-parser-gcovr", "")
+	writePlugin(t, second, "coverctl-runner-", "")
+	if err := os.WriteFile(filepath.Join(second, "coverctl-runner-plain"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	found := Discover(first + string(os.PathListSeparator) + second + string(os.PathListSeparator) + filepath.Join(first, "missing"))
+	if len(found.Runners) != 1 || found.Runners[0].Name() != "bazel" || filepath.Dir(found.Runners[0].Path()) != first {
+		t.Fatalf("expected bazel runner from the first directory, got %+v", found.Runners)
+	}
+	if len(found.Parsers) != 1 || found.Parsers[0].Format() != "gcovr" {
+		t.Fatalf("expected gcovr parser, got %+v", found.Parsers)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	dir := t.TempDir()
+	path := writePlugin(t, dir, "coverctl-runner-bazel", `
+req=$(cat)
+case "$req" in
+  *'"action":"detect"'*) echo '{"detected": true}' ;;
+  *'"action":"run"'*'"tags":"integration"'*) echo "running tests" >&2; echo '{"profile": "bazel-out/coverage.dat"}' ;;
+  *) echo '{"error": "unexpected request"}' ;;
+esac
+`)
+	r := NewRunner("bazel", path)
+	if r.Language() != application.Language("bazel") || !r.Detect(dir) {
+		t.Fatal("expected the plugin to detect the project")
+	}
+
+	var stderr bytes.Buffer
+	r.Stderr = &stderr
+	profile, err := r.Run(context.Background(), application.RunOptions{
+		ProfilePath: ".cover/coverage.out",
+		Domains:     []domain.Domain{{Name: "core", Match: []string{"//core/..."}}},
+		BuildFlags:  application.BuildFlags{Tags: "integration"},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if profile != "bazel-out/coverage.dat" || !strings.Contains(stderr.String(), "running tests") {
+		t.Fatalf("unexpected profile %q or stderr %q", profile, stderr.String())
+	}
+
+	_, err = r.Run(context.Background(), application.RunOptions{})
+	if err == nil || !strings.Contains(err.Error(), "unexpected request") {
+		t.Fatalf("expected plugin error to surface, got %v", err)
+	}
+	if _, err := r.RunIntegration(context.Background(), application.IntegrationOptions{}); err == nil {
+		t.Fatal("expected integration coverage to be unsupported")
+	}
+}
+
+func TestRunnerFailures(t *testing.T) {
+	dir := t.TempDir()
+	crash := NewRunner("crash", writePlugin(t, dir, "coverctl-runner-crash", "exit 3\n"))
+	if crash.Detect(dir) {
+		t.Fatal("a failing plugin must not detect")
+	}
+	if _, err := crash.Run(context.Background(), application.RunOptions{}); err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Fatalf("expected exit status in error, got %v", err)
+	}
+
+	garbage := NewRunner("garbage", writePlugin(t, dir, "coverctl-runner-garbage", "echo not json\n"))
+	if _, err := garbage.Run(context.Background(), application.RunOptions{}); err == nil || !strings.Contains(err.Error(), "invalid response") {
+		t.Fatalf("expected invalid response error, got %v", err)
+	}
+}
+
+func TestParser(t *testing.T) {
+	dir := t.TempDir()
+	path := writePlugin(t, dir, "coverctl-parser-gcovr", `
+req=$(cat)
+case "$req" in
+  *'"action":"detect"'*'.gcovr'*) echo '{"detected": true}' ;;
+  *'"action":"detect"'*) echo '{"detected": false}' ;;
+  *'"action":"parse"'*) echo '{"files": {"src/a.c": {"covered": 3, "total": 4, "lines": {"10": 1, "11": 0}}}}' ;;
+esac
+`)
+	p := NewParser("gcovr", path)
+	if !p.Detect("report.gcovr") || p.Detect("coverage.out") {
+		t.Fatal("unexpected detection")
+	}
+
+	stats, err := p.ParseAll([]string{"a.gcovr", "b.gcovr"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := stats["src/a.c"]; got.Covered != 6 || got.Total != 8 {
+		t.Fatalf("expected merged stats, got %+v", got)
+	}
+
+	lines, err := p.ParseLines("a.gcovr")
+	if err != nil {
+		t.Fatalf("parse lines: %v", err)
+	}
+	if lines["src/a.c"][10] != 1 || lines["src/a.c"][11] != 0 || len(lines["src/a.c"]) != 2 {
+		t.Fatalf("unexpected lines: %+v", lines)
+	}
+}
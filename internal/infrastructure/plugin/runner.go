@@ -0,0 +1,92 @@
+package plugin
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// detectTimeout bounds a detect request, which runs during every runner
+// lookup and must not stall it.
+const detectTimeout = 10 * time.Second
+
+// Runner is a coverage runner backed by a coverctl-runner-<name> plugin.
+// Its name doubles as its language.
+type Runner struct {
+	name string
+	path string
+	// Stderr receives the plugin's stderr during run; nil means os.Stderr.
+	Stderr io.Writer
+}
+
+// NewRunner returns a runner for the plugin executable at path.
+func NewRunner(name, path string) *Runner {
+	return &Runner{name: name, path: path}
+}
+
+// Name returns the plugin name, e.g. "bazel" for coverctl-runner-bazel.
+func (r *Runner) Name() string { return r.name }
+
+// Path returns the plugin executable.
+func (r *Runner) Path() string { return r.path }
+
+// Language returns the plugin name as the runner's language.
+func (r *Runner) Language() application.Language { return application.Language(r.name) }
+
+// Detect asks the plugin whether it handles the project. A plugin that
+// fails or times out does not.
+func (r *Runner) Detect(projectDir string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), detectTimeout)
+	defer cancel()
+	resp, err := call(ctx, r.path, projectDir, request{Action: "detect", ProjectDir: projectDir}, nil)
+	return err == nil && resp.Detected
+}
+
+// Run asks the plugin to run the tests with coverage and returns the
+// profile it wrote, opts.ProfilePath unless it reports another.
+func (r *Runner) Run(ctx context.Context, opts application.RunOptions) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	req := request{
+		Action:     "run",
+		ProjectDir: dir,
+		Profile:    opts.ProfilePath,
+		Packages:   opts.Packages,
+		BuildFlags: &buildFlags{
+			Tags:     opts.BuildFlags.Tags,
+			Race:     opts.BuildFlags.Race,
+			Short:    opts.BuildFlags.Short,
+			Verbose:  opts.BuildFlags.Verbose,
+			Run:      opts.BuildFlags.Run,
+			Timeout:  opts.BuildFlags.Timeout,
+			TestArgs: opts.BuildFlags.TestArgs,
+		},
+	}
+	for _, d := range opts.Domains {
+		req.Domains = append(req.Domains, domainRef{Name: d.Name, Match: d.Match})
+	}
+	stderr := r.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	resp, err := call(ctx, r.path, dir, req, stderr)
+	if err != nil {
+		return "", err
+	}
+	if resp.Profile != "" {
+		return resp.Profile, nil
+	}
+	return opts.ProfilePath, nil
+}
+
+// RunIntegration is not part of the plugin protocol.
+func (r *Runner) RunIntegration(ctx context.Context, opts application.IntegrationOptions) (string, error) {
+	return "", errNoIntegration
+}
+
+var _ application.CoverageRunner = (*Runner)(nil)
@@ -0,0 +1,137 @@
+// Package preset loads init presets: built-in ones by name, and
+// organization presets from a URL or file.
+package preset
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultHTTPTimeout bounds fetching a remote preset.
+const DefaultHTTPTimeout = 10 * time.Second
+
+// maxPresetBytes caps a preset file; presets are a few lines of YAML.
+const maxPresetBytes = 1 << 20
+
+// filePreset is the YAML layout of a preset.
+type filePreset struct {
+	DefaultMin *float64        `yaml:"default_min,omitempty"`
+	WarnMargin *float64        `yaml:"warn_margin,omitempty"`
+	Exclude    []string        `yaml:"exclude,omitempty"`
+	Diff       *filePresetDiff `yaml:"diff,omitempty"`
+}
+
+type filePresetDiff struct {
+	Enabled bool     `yaml:"enabled"`
+	Base    string   `yaml:"base,omitempty"`
+	Min     *float64 `yaml:"min,omitempty"`
+}
+
+// Loader resolves preset references.
+type Loader struct {
+	httpClient *http.Client
+}
+
+// NewLoader creates a preset loader with the default timeout.
+func NewLoader() *Loader {
+	return &Loader{httpClient: &http.Client{Timeout: DefaultHTTPTimeout}}
+}
+
+// NewLoaderWithHTTP creates a preset loader with a custom HTTP client.
+func NewLoaderWithHTTP(httpClient *http.Client) *Loader {
+	return &Loader{httpClient: httpClient}
+}
+
+// Load resolves ref: a built-in preset name, an http(s) URL, or a file
+// path. Presets from a URL or file are YAML and reject unknown keys.
+func (l *Loader) Load(ctx context.Context, ref string) (application.Preset, error) {
+	if p, ok := application.BuiltinPreset(ref); ok {
+		return p, nil
+	}
+	var data []byte
+	var err error
+	switch {
+	case strings.HasPrefix(ref, "https://"), strings.HasPrefix(ref, "http://"):
+		data, err = l.fetch(ctx, ref)
+	default:
+		data, err = readFile(ref)
+		if errors.Is(err, os.ErrNotExist) && !strings.ContainsAny(ref, `/\.`) {
+			return application.Preset{}, fmt.Errorf("unknown preset %q (built-in: %s)", ref, strings.Join(application.BuiltinPresetNames(), ", "))
+		}
+	}
+	if err != nil {
+		return application.Preset{}, err
+	}
+	return parse(ref, data)
+}
+
+func (l *Loader) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("preset %s: %w", url, err)
+	}
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch preset %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch preset %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPresetBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetch preset %s: %w", url, err)
+	}
+	if len(data) > maxPresetBytes {
+		return nil, fmt.Errorf("fetch preset %s: larger than %d bytes", url, maxPresetBytes)
+	}
+	return data, nil
+}
+
+func readFile(path string) ([]byte, error) {
+	clean, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preset path: %w", err)
+	}
+	return os.ReadFile(clean) // #nosec G304 - path is validated above
+}
+
+// parse decodes and validates a preset file.
+func parse(ref string, data []byte) (application.Preset, error) {
+	var fp filePreset
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&fp); err != nil && !errors.Is(err, io.EOF) {
+		return application.Preset{}, fmt.Errorf("parse preset %s: %w", ref, err)
+	}
+	if fp.DefaultMin != nil {
+		if _, err := domain.NewThreshold(*fp.DefaultMin); err != nil {
+			return application.Preset{}, fmt.Errorf("preset %s: default_min: %w", ref, err)
+		}
+	}
+	if fp.WarnMargin != nil && *fp.WarnMargin < 0 {
+		return application.Preset{}, fmt.Errorf("preset %s: warn_margin must not be negative: %g", ref, *fp.WarnMargin)
+	}
+	p := application.Preset{Name: ref, DefaultMin: fp.DefaultMin, WarnMargin: fp.WarnMargin, Exclude: fp.Exclude}
+	if fp.Diff != nil {
+		if fp.Diff.Min != nil {
+			if _, err := domain.NewThreshold(*fp.Diff.Min); err != nil {
+				return application.Preset{}, fmt.Errorf("preset %s: diff.min: %w", ref, err)
+			}
+		}
+		p.Diff = &application.PresetDiff{Enabled: fp.Diff.Enabled, Base: fp.Diff.Base, Min: fp.Diff.Min}
+	}
+	return p, nil
+}
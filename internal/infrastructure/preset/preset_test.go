@@ -0,0 +1,79 @@
+package preset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const orgPreset = "default_min: 70\nwarn_margin: 3\nexclude: [\"gen/*\"]\ndiff:\n  enabled: true\n  min: 85\n"
+
+func TestLoadBuiltin(t *testing.T) {
+	p, err := NewLoader().Load(context.Background(), "legacy")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if p.Name != "legacy" || p.DefaultMin == nil || *p.DefaultMin != 50 {
+		t.Fatalf("unexpected preset %+v", p)
+	}
+}
+
+func TestLoadURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/preset.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(orgPreset))
+	}))
+	defer srv.Close()
+
+	loader := NewLoaderWithHTTP(srv.Client())
+	p, err := loader.Load(context.Background(), srv.URL+"/preset.yaml")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if *p.DefaultMin != 70 || *p.WarnMargin != 3 || p.Exclude[0] != "gen/*" || p.Diff == nil || !p.Diff.Enabled || *p.Diff.Min != 85 {
+		t.Fatalf("unexpected preset %+v", p)
+	}
+
+	if _, err := loader.Load(context.Background(), srv.URL+"/missing.yaml"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected a 404 error, got %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "preset.yaml")
+	if err := os.WriteFile(path, []byte(orgPreset), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := NewLoader().Load(context.Background(), path); err != nil || *p.DefaultMin != 70 {
+		t.Fatalf("load: %+v, %v", p, err)
+	}
+
+	for content, want := range map[string]string{
+		"default_minimum: 70\n": "field default_minimum not found",
+		"default_min: 120\n":    "default_min",
+		"warn_margin: -1\n":     "warn_margin must not be negative",
+		"diff:\n  min: 101\n":   "diff.min",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewLoader().Load(context.Background(), path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected error containing %q, got %v", content, want, err)
+		}
+	}
+}
+
+func TestLoadUnknownName(t *testing.T) {
+	_, err := NewLoader().Load(context.Background(), "lenient")
+	if err == nil || !strings.Contains(err.Error(), "built-in: balanced, legacy, strict") {
+		t.Fatalf("expected an unknown preset error, got %v", err)
+	}
+}
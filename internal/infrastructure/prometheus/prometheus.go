@@ -0,0 +1,159 @@
+// Package prometheus exports coverage snapshots in the Prometheus text
+// exposition format, either as a node-exporter textfile or via a
+// Pushgateway.
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// ContentType is the Prometheus text exposition format media type.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultHTTPTimeout bounds a single Pushgateway request.
+const DefaultHTTPTimeout = 30 * time.Second
+
+type family struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+type sample struct {
+	domain string // Empty for unlabelled samples
+	value  float64
+}
+
+// WriteText renders m as gauges in the text exposition format.
+func WriteText(w io.Writer, m application.MetricsResult) error {
+	passed := 0.0
+	if m.Result.Passed {
+		passed = 1
+	}
+	families := []family{
+		{"coverctl_coverage_percent", "Overall statement coverage percentage.", []sample{{value: m.Result.OverallPercent()}}},
+		{"coverctl_policy_passed", "Whether the coverage policy passed (1) or failed (0).", []sample{{value: passed}}},
+		{"coverctl_domain_coverage_percent", "Statement coverage percentage per domain.", nil},
+		{"coverctl_domain_required_percent", "Minimum coverage percentage required per domain.", nil},
+		{"coverctl_domain_statements_covered", "Covered statements per domain.", nil},
+		{"coverctl_domain_statements_total", "Total statements per domain.", nil},
+		{"coverctl_debt_percent", "Sum of coverage shortfalls below policy minimums.", []sample{{value: m.Debt.TotalDebt}}},
+		{"coverctl_debt_lines", "Estimated statements needing tests to close coverage debt.", []sample{{value: float64(m.Debt.TotalLines)}}},
+		{"coverctl_health_score", "Coverage health score from 0 to 100.", []sample{{value: m.Debt.HealthScore}}},
+	}
+	for _, d := range m.Result.Domains {
+		families[2].samples = append(families[2].samples, sample{d.Domain, d.Percent})
+		families[3].samples = append(families[3].samples, sample{d.Domain, d.Required})
+		families[4].samples = append(families[4].samples, sample{d.Domain, float64(d.Covered)})
+		families[5].samples = append(families[5].samples, sample{d.Domain, float64(d.Total)})
+	}
+
+	var b strings.Builder
+	for _, f := range families {
+		if len(f.samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", f.name, f.help, f.name)
+		for _, s := range f.samples {
+			b.WriteString(f.name)
+			if s.domain != "" {
+				fmt.Fprintf(&b, `{domain="%s"}`, escapeLabel(s.domain))
+			}
+			b.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteTextfile writes m to path for the node-exporter textfile collector.
+// The file is written to a temporary sibling and renamed into place so the
+// collector never reads a partial file.
+func WriteTextfile(path string, m application.MetricsResult) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create metrics dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("write metrics: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := WriteText(tmp, m); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write metrics: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write metrics: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil { // #nosec G302 - textfile must be readable by node-exporter
+		return fmt.Errorf("write metrics: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Pushgateway pushes metrics to a Prometheus Pushgateway.
+type Pushgateway struct {
+	URL        string // Gateway base URL, e.g. http://push:9091
+	Job        string // Grouping job label
+	httpClient *http.Client
+}
+
+// NewPushgateway creates a Pushgateway client with the default timeout.
+func NewPushgateway(gatewayURL, job string) *Pushgateway {
+	return &Pushgateway{
+		URL:        gatewayURL,
+		Job:        job,
+		httpClient: &http.Client{Timeout: DefaultHTTPTimeout},
+	}
+}
+
+// Push replaces the metrics of the job's group with m.
+func (p *Pushgateway) Push(ctx context.Context, m application.MetricsResult) error {
+	base, err := url.Parse(p.URL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return fmt.Errorf("invalid pushgateway URL: %q", p.URL)
+	}
+	if p.Job == "" {
+		return fmt.Errorf("pushgateway job name is required")
+	}
+	endpoint := strings.TrimRight(base.String(), "/") + "/metrics/job/" + url.PathEscape(p.Job)
+
+	var body bytes.Buffer
+	if err := WriteText(&body, m); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ContentType)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pushgateway returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
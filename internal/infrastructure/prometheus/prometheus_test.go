@@ -0,0 +1,104 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func sampleMetrics() application.MetricsResult {
+	return application.MetricsResult{
+		Result: domain.Result{
+			Passed: true,
+			Domains: []domain.DomainResult{
+				{Domain: "core", Covered: 8, Total: 10, Percent: 80, Required: 75, Status: domain.StatusPass},
+				{Domain: `we"ird`, Covered: 1, Total: 2, Percent: 50, Required: 40, Status: domain.StatusPass},
+			},
+		},
+		Debt: application.DebtResult{TotalDebt: 2.5, TotalLines: 12, HealthScore: 91.5},
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteText(&buf, sampleMetrics()); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE coverctl_coverage_percent gauge\n",
+		"coverctl_policy_passed 1\n",
+		`coverctl_domain_coverage_percent{domain="core"} 80` + "\n",
+		`coverctl_domain_statements_total{domain="we\"ird"} 2` + "\n",
+		"coverctl_debt_lines 12\n",
+		"coverctl_health_score 91.5\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteTextfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collector", "coverctl.prom")
+	if err := WriteTextfile(path, sampleMetrics()); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.Contains(string(data), "coverctl_coverage_percent") {
+		t.Fatalf("unexpected textfile:\n%s", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("expected temporary file to be renamed away, got %d entries", len(entries))
+	}
+}
+
+func TestPushgatewayPush(t *testing.T) {
+	var method, path, contentType, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	if err := NewPushgateway(server.URL+"/", "coverctl").Push(context.Background(), sampleMetrics()); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if method != http.MethodPut || path != "/metrics/job/coverctl" || contentType != ContentType {
+		t.Fatalf("unexpected request %s %s (%s)", method, path, contentType)
+	}
+	if !strings.Contains(body, "coverctl_health_score 91.5") {
+		t.Fatalf("unexpected body:\n%s", body)
+	}
+}
+
+func TestPushgatewayErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad metrics", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := NewPushgateway(server.URL, "coverctl").Push(context.Background(), sampleMetrics()); err == nil || !strings.Contains(err.Error(), "bad metrics") {
+		t.Fatalf("expected gateway error, got %v", err)
+	}
+	if err := NewPushgateway("push:9091", "coverctl").Push(context.Background(), sampleMetrics()); err == nil {
+		t.Fatal("expected invalid URL error")
+	}
+	if err := NewPushgateway(server.URL, "").Push(context.Background(), sampleMetrics()); err == nil {
+		t.Fatal("expected missing job error")
+	}
+}
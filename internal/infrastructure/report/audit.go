@@ -0,0 +1,49 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// WriteAuditLog renders policy audit entries, oldest first, as text or
+// json.
+func WriteAuditLog(w io.Writer, entries []application.AuditEntry, format application.OutputFormat) error {
+	switch format {
+	case application.OutputJSON:
+		if entries == nil {
+			entries = []application.AuditEntry{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case application.OutputText, "":
+		return writeAuditText(w, entries)
+	default:
+		return fmt.Errorf("unsupported output format for audit: %s (valid: text, json)", format)
+	}
+}
+
+func writeAuditText(w io.Writer, entries []application.AuditEntry) error {
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(w, "No threshold changes recorded.")
+		return err
+	}
+	for i, e := range entries {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s  %s  %s  by %s\n", e.Timestamp.UTC().Format("2006-01-02 15:04 UTC"), e.Config, e.Source, orDash(e.Actor))
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, c := range e.Changes {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", c.Kind, c.Subject, orDash(c.Before), orDash(c.After))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// coberturaNow is the report timestamp source; tests may override it.
+var coberturaNow = time.Now
+
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        string             `xml:"line-rate,attr"`
+	BranchRate      string             `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      string             `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   string           `xml:"line-rate,attr"`
+	BranchRate string           `xml:"branch-rate,attr"`
+	Complexity string           `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name       string          `xml:"name,attr"`
+	Filename   string          `xml:"filename,attr"`
+	LineRate   string          `xml:"line-rate,attr"`
+	BranchRate string          `xml:"branch-rate,attr"`
+	Complexity string          `xml:"complexity,attr"`
+	Methods    struct{}        `xml:"methods"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number int `xml:"number,attr"`
+	Hits   int `xml:"hits,attr"`
+}
+
+// writeCobertura renders result.Lines as Cobertura XML for CI systems that
+// visualize coverage natively.
+//
+// GitLab maps class filenames onto the repository by stripping the job's
+// checkout directory from <source>, so gitlab output keeps filenames
+// relative to the module root and names that root as the source. It also
+// embeds the overall percentage in a comment so a job `coverage:` regex of
+// /Coverage: \d+\.\d+%/ matches when the XML is echoed to the log.
+// Azure DevOps publishes from the same agent that produced the report, so
+// azure output uses absolute filenames that resolve without source mapping.
+func writeCobertura(w io.Writer, result domain.Result, format application.OutputFormat) error {
+	if result.Lines == nil {
+		return fmt.Errorf("%s output requires line-level coverage data", format)
+	}
+
+	files := make([]string, 0, len(result.Lines))
+	for file := range result.Lines {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	byDir := make(map[string][]coberturaClass)
+	dirCounts := make(map[string][2]int)
+	var covered, valid int
+	for _, file := range files {
+		class, c, v := coberturaClassFor(file, result.Lines[file], result.SourceRoot, format)
+		dir := path.Dir(file)
+		byDir[dir] = append(byDir[dir], class)
+		counts := dirCounts[dir]
+		dirCounts[dir] = [2]int{counts[0] + c, counts[1] + v}
+		covered += c
+		valid += v
+	}
+
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	doc := coberturaCoverage{
+		LineRate:     lineRate(covered, valid),
+		BranchRate:   "0",
+		LinesCovered: covered,
+		LinesValid:   valid,
+		Complexity:   "0",
+		Version:      "coverctl",
+		Timestamp:    coberturaNow().UnixMilli(),
+	}
+	if result.SourceRoot != "" {
+		doc.Sources = []string{result.SourceRoot}
+	}
+	for _, dir := range dirs {
+		counts := dirCounts[dir]
+		doc.Packages = append(doc.Packages, coberturaPackage{
+			Name:       dir,
+			LineRate:   lineRate(counts[0], counts[1]),
+			BranchRate: "0",
+			Complexity: "0",
+			Classes:    byDir[dir],
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if format == application.OutputGitLab {
+		if _, err := fmt.Fprintf(w, "<!-- Coverage: %.1f%% -->\n", domain.Round1(percentOf(covered, valid))); err != nil {
+			return err
+		}
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func coberturaClassFor(file string, lines domain.LineCoverage, root string, format application.OutputFormat) (coberturaClass, int, int) {
+	numbers := make([]int, 0, len(lines))
+	for n := range lines {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	class := coberturaClass{
+		Name:       path.Base(file),
+		Filename:   file,
+		BranchRate: "0",
+		Complexity: "0",
+	}
+	if format == application.OutputAzure && root != "" {
+		class.Filename = filepath.ToSlash(filepath.Join(root, filepath.FromSlash(file)))
+	}
+	covered := 0
+	for _, n := range numbers {
+		hits := lines[n]
+		if hits > 0 {
+			covered++
+		}
+		class.Lines = append(class.Lines, coberturaLine{Number: n, Hits: hits})
+	}
+	class.LineRate = lineRate(covered, len(numbers))
+	return class, covered, len(numbers)
+}
+
+func percentOf(covered, valid int) float64 {
+	if valid == 0 {
+		return 0
+	}
+	return float64(covered) / float64(valid) * 100
+}
+
+func lineRate(covered, valid int) string {
+	if valid == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%.4f", float64(covered)/float64(valid))
+}
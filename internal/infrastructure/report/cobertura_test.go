@@ -0,0 +1,72 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWriteCobertura(t *testing.T) {
+	coberturaNow = func() time.Time { return time.UnixMilli(1700000000000) }
+	defer func() { coberturaNow = time.Now }()
+
+	result := domain.Result{
+		SourceRoot: "/builds/group/project",
+		Lines: map[string]domain.LineCoverage{
+			"internal/core/a.go": {3: 1, 4: 0},
+			"internal/api/b.go":  {10: 2},
+		},
+	}
+
+	t.Run("gitlab keeps relative filenames and regex comment", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := (Writer{}).Write(&buf, result, application.OutputGitLab); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		out := buf.String()
+		if !strings.Contains(out, "<!-- Coverage: 66.7% -->") {
+			t.Fatalf("expected coverage regex comment:\n%s", out)
+		}
+		var doc coberturaCoverage
+		if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("output is not valid XML: %v", err)
+		}
+		if doc.LinesCovered != 2 || doc.LinesValid != 3 || doc.LineRate != "0.6667" {
+			t.Fatalf("unexpected totals: %+v", doc)
+		}
+		if len(doc.Sources) != 1 || doc.Sources[0] != "/builds/group/project" {
+			t.Fatalf("unexpected sources: %v", doc.Sources)
+		}
+		if len(doc.Packages) != 2 || doc.Packages[0].Name != "internal/api" {
+			t.Fatalf("unexpected packages: %+v", doc.Packages)
+		}
+		if got := doc.Packages[1].Classes[0].Filename; got != "internal/core/a.go" {
+			t.Fatalf("expected relative filename, got %q", got)
+		}
+	})
+
+	t.Run("azure uses absolute filenames", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := (Writer{}).Write(&buf, result, application.OutputAzure); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if strings.Contains(buf.String(), "<!--") {
+			t.Fatal("azure output should not carry the gitlab regex comment")
+		}
+		if !strings.Contains(buf.String(), `filename="/builds/group/project/internal/core/a.go"`) {
+			t.Fatalf("expected absolute filename:\n%s", buf.String())
+		}
+	})
+
+	t.Run("requires line data", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := (Writer{}).Write(&buf, domain.Result{}, application.OutputGitLab); err == nil {
+			t.Fatal("expected error without line data")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// WriteConfigDiff renders the policy changes between two configs as text,
+// json, or markdown for PR comments.
+func WriteConfigDiff(w io.Writer, diff application.ConfigDiff, format application.OutputFormat) error {
+	switch format {
+	case application.OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	case application.OutputMarkdown:
+		_, err := io.WriteString(w, configDiffMarkdown(diff))
+		return err
+	case application.OutputText, "":
+		return writeConfigDiffText(w, diff)
+	default:
+		return fmt.Errorf("unsupported output format for diff-config: %s (valid: text, json, markdown)", format)
+	}
+}
+
+func writeConfigDiffText(w io.Writer, diff application.ConfigDiff) error {
+	if len(diff.Changes) == 0 {
+		_, err := fmt.Fprintf(w, "No policy changes between %s and %s.\n", diff.Base, diff.Head)
+		return err
+	}
+	fmt.Fprintf(w, "Policy changes from %s to %s:\n\n", diff.Base, diff.Head)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Change\tSetting\tBefore\tAfter")
+	for _, c := range diff.Changes {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Kind, c.Subject, orDash(c.Before), orDash(c.After))
+	}
+	return tw.Flush()
+}
+
+func configDiffMarkdown(diff application.ConfigDiff) string {
+	var b strings.Builder
+	b.WriteString("## Coverage policy changes\n\n")
+	fmt.Fprintf(&b, "`%s` → `%s`\n\n", diff.Base, diff.Head)
+	if len(diff.Changes) == 0 {
+		b.WriteString("No policy changes.\n")
+		return b.String()
+	}
+	b.WriteString("| Change | Setting | Before | After |\n")
+	b.WriteString("|--------|---------|--------|-------|\n")
+	for _, c := range diff.Changes {
+		fmt.Fprintf(&b, "| %s %s | %s | %s | %s |\n", configChangeIcon(c.Kind), c.Kind, markdownCell(c.Subject), markdownCell(c.Before), markdownCell(c.After))
+	}
+	return b.String()
+}
+
+// configChangeIcon marks each kind of change so lowered thresholds stand
+// out in review.
+func configChangeIcon(kind application.ConfigChangeKind) string {
+	switch kind {
+	case application.ConfigRaised:
+		return "⬆️"
+	case application.ConfigLowered:
+		return "⬇️"
+	case application.ConfigAdded:
+		return "➕"
+	case application.ConfigRemoved:
+		return "➖"
+	default:
+		return "✏️"
+	}
+}
+
+func markdownCell(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
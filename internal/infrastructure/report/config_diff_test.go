@@ -0,0 +1,40 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func TestWriteConfigDiff(t *testing.T) {
+	diff := application.ConfigDiff{Base: "main:.coverctl.yaml", Head: ".coverctl.yaml", Changes: []application.ConfigChange{
+		{Kind: application.ConfigLowered, Subject: "domain api min", Before: "80.0%", After: "75.0%"},
+		{Kind: application.ConfigAdded, Subject: "exclude", After: "internal/gen/*"},
+	}}
+
+	var text bytes.Buffer
+	if err := WriteConfigDiff(&text, diff, application.OutputText); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), "lowered  domain api min  80.0%   75.0%") {
+		t.Fatalf("unexpected text:\n%s", text.String())
+	}
+
+	var md bytes.Buffer
+	if err := WriteConfigDiff(&md, diff, application.OutputMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(md.String(), "| ⬇️ lowered | domain api min | 80.0% | 75.0% |") || !strings.Contains(md.String(), "| ➕ added | exclude | - | internal/gen/* |") {
+		t.Fatalf("unexpected markdown:\n%s", md.String())
+	}
+
+	var none bytes.Buffer
+	if err := WriteConfigDiff(&none, application.ConfigDiff{Base: "a", Head: "b"}, application.OutputText); err != nil || !strings.Contains(none.String(), "No policy changes") {
+		t.Fatalf("unexpected empty diff output %q (%v)", none.String(), err)
+	}
+	if err := WriteConfigDiff(&none, diff, application.OutputHTML); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// newDelimitedWriter returns a CSV writer, tab-separated for OutputTSV.
+func newDelimitedWriter(w io.Writer, format application.OutputFormat) *csv.Writer {
+	cw := csv.NewWriter(w)
+	if format == application.OutputTSV {
+		cw.Comma = '\t'
+	}
+	return cw
+}
+
+// formatPercent renders a percentage for spreadsheets: two decimals, no sign.
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// writeCSV writes one row per domain.
+func writeCSV(w io.Writer, result domain.Result, format application.OutputFormat) error {
+	cw := newDelimitedWriter(w, format)
+	_ = cw.Write([]string{"domain", "covered", "total", "percent", "required", "status"})
+	for _, d := range result.Domains {
+		_ = cw.Write([]string{
+			d.Domain,
+			strconv.Itoa(d.Covered),
+			strconv.Itoa(d.Total),
+			formatPercent(d.Percent),
+			formatPercent(d.Required),
+			string(d.Status),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteDebtCSV writes one row per debt item.
+func WriteDebtCSV(w io.Writer, result application.DebtResult, format application.OutputFormat) error {
+	cw := newDelimitedWriter(w, format)
+	_ = cw.Write([]string{"type", "name", "current", "required", "shortfall", "lines"})
+	for _, item := range result.Items {
+		_ = cw.Write([]string{
+			item.Type,
+			item.Name,
+			formatPercent(item.Current),
+			formatPercent(item.Required),
+			formatPercent(item.Shortfall),
+			strconv.Itoa(item.Lines),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTrendCSV writes one row per history entry: its timestamp, overall
+// coverage, and a column per domain seen in any entry. A domain missing from
+// an entry leaves its cell empty.
+func WriteTrendCSV(w io.Writer, entries []domain.HistoryEntry, format application.OutputFormat) error {
+	seen := make(map[string]bool)
+	var names []string
+	for _, e := range entries {
+		for name := range e.Domains {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	cw := newDelimitedWriter(w, format)
+	_ = cw.Write(append([]string{"timestamp", "overall"}, names...))
+	for _, e := range entries {
+		row := []string{e.Timestamp.UTC().Format("2006-01-02T15:04:05Z"), formatPercent(e.Overall)}
+		for _, name := range names {
+			cell := ""
+			if d, ok := e.Domains[name]; ok {
+				cell = formatPercent(d.Percent)
+			}
+			row = append(row, cell)
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
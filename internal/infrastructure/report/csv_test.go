@@ -0,0 +1,71 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWriteCSV(t *testing.T) {
+	res := domain.Result{
+		Domains: []domain.DomainResult{
+			{Domain: "core", Covered: 80, Total: 100, Percent: 80, Required: 75, Status: domain.StatusPass},
+			{Domain: "api, v2", Covered: 1, Total: 3, Percent: 33.333, Required: 50, Status: domain.StatusFail},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputCSV); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := "domain,covered,total,percent,required,status\n" +
+		"core,80,100,80.00,75.00,PASS\n" +
+		"\"api, v2\",1,3,33.33,50.00,FAIL\n"
+	if buf.String() != want {
+		t.Fatalf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, res, application.OutputTSV); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if got := buf.String(); got[:len("domain\tcovered")] != "domain\tcovered" {
+		t.Fatalf("expected tab-separated output, got %q", got)
+	}
+}
+
+func TestWriteDebtCSV(t *testing.T) {
+	result := application.DebtResult{Items: []application.DebtItem{
+		{Type: "domain", Name: "core", Current: 60, Required: 80, Shortfall: 20, Lines: 42},
+	}}
+	buf := new(bytes.Buffer)
+	if err := WriteDebtCSV(buf, result, application.OutputCSV); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := "type,name,current,required,shortfall,lines\ndomain,core,60.00,80.00,20.00,42\n"
+	if buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestWriteTrendCSV(t *testing.T) {
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []domain.HistoryEntry{
+		{Timestamp: day, Overall: 70, Domains: map[string]domain.DomainEntry{"core": {Percent: 70}}},
+		{Timestamp: day.Add(24 * time.Hour), Overall: 72.5, Domains: map[string]domain.DomainEntry{
+			"core": {Percent: 71}, "api": {Percent: 74},
+		}},
+	}
+	buf := new(bytes.Buffer)
+	if err := WriteTrendCSV(buf, entries, application.OutputCSV); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := "timestamp,overall,api,core\n" +
+		"2026-03-01T12:00:00Z,70.00,,70.00\n" +
+		"2026-03-02T12:00:00Z,72.50,74.00,71.00\n"
+	if buf.String() != want {
+		t.Fatalf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
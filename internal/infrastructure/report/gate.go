@@ -0,0 +1,85 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// WriteGateJSON writes the machine-readable gate summary.
+func WriteGateJSON(w io.Writer, gate domain.Gate) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(gate)
+}
+
+// WriteGateMarkdown writes a human-readable gate summary suitable for CI job
+// summaries (e.g. $GITHUB_STEP_SUMMARY) or PR comments.
+func WriteGateMarkdown(w io.Writer, gate domain.Gate) error {
+	var b strings.Builder
+
+	verdict := domain.StatusPass
+	if !gate.Passed {
+		verdict = domain.StatusFail
+	}
+	fmt.Fprintf(&b, "## Coverage gate: %s %s\n\n", gateIcon(verdict), verdict)
+	fmt.Fprintf(&b, "Overall coverage: **%.1f%%**\n\n", gate.Overall)
+
+	b.WriteString("| Check | Status | Detail |\n")
+	b.WriteString("|-------|--------|--------|\n")
+	for _, check := range gate.Checks {
+		fmt.Fprintf(&b, "| %s | %s %s | %s |\n", check.Name, gateIcon(check.Status), check.Status, check.Detail)
+	}
+
+	var failing []domain.DomainResult
+	for _, d := range gate.Result.Domains {
+		if d.Status == domain.StatusFail {
+			failing = append(failing, d)
+		}
+	}
+	if len(failing) > 0 {
+		b.WriteString("\n### Failing domains\n\n")
+		b.WriteString("| Domain | Coverage | Required |\n")
+		b.WriteString("|--------|----------|----------|\n")
+		for _, d := range failing {
+			fmt.Fprintf(&b, "| %s | %.1f%% | %.1f%% |\n", d.Domain, d.Percent, d.Required)
+		}
+	}
+
+	if patch := gate.Result.Patch; patch != nil && len(patch.Uncovered) > 0 {
+		b.WriteString("\n### Uncovered changed lines\n\n")
+		for i, u := range patch.Uncovered {
+			if i == maxPatchLinesShown {
+				fmt.Fprintf(&b, "- ... and %d more\n", len(patch.Uncovered)-maxPatchLinesShown)
+				break
+			}
+			fmt.Fprintf(&b, "- `%s`\n", u)
+		}
+	}
+
+	if len(gate.Result.Warnings) > 0 {
+		b.WriteString("\n### Warnings\n\n")
+		for _, warning := range gate.Result.Warnings {
+			fmt.Fprintf(&b, "- %s\n", warning)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func gateIcon(status domain.Status) string {
+	switch status {
+	case domain.StatusPass:
+		return ":white_check_mark:"
+	case domain.StatusFail:
+		return ":x:"
+	case domain.StatusWarn:
+		return ":warning:"
+	default:
+		return ":heavy_minus_sign:"
+	}
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWriteGateMarkdown(t *testing.T) {
+	gate := domain.Gate{
+		Passed:  false,
+		Overall: 71.2,
+		Checks: []domain.GateCheck{
+			{Name: domain.GateCheckPolicy, Status: domain.StatusFail, Detail: "1/2 domains meet their minimum"},
+			{Name: domain.GateCheckRatchet, Status: domain.StatusSkip, Detail: "no recorded history"},
+		},
+		Result: domain.Result{
+			Domains: []domain.DomainResult{{Domain: "api", Percent: 55, Required: 70, Status: domain.StatusFail}},
+			Patch:   &domain.PatchResult{Uncovered: []domain.UncoveredLine{{File: "api/h.go", Line: 12}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGateMarkdown(&buf, gate); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Coverage gate: :x: FAIL",
+		"| policy | :x: FAIL | 1/2 domains meet their minimum |",
+		"| ratchet | :heavy_minus_sign: SKIP |",
+		"| api | 55.0% | 70.0% |",
+		"- `api/h.go:12`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in markdown:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+const heatmapTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        :root {
+            --bg: #0f172a;
+            --card: #1e293b;
+            --text: #f8fafc;
+            --muted: #94a3b8;
+            --border: #334155;
+        }
+        * { box-sizing: border-box; margin: 0; padding: 0; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, sans-serif;
+            background: var(--bg);
+            color: var(--text);
+            line-height: 1.6;
+            padding: 2rem;
+        }
+        h1 { font-size: 2rem; margin-bottom: 0.5rem; font-weight: 600; }
+        .timestamp { color: var(--muted); font-size: 0.875rem; margin-bottom: 1rem; }
+        .summary { color: var(--muted); margin-bottom: 1rem; }
+        .summary strong { color: var(--text); }
+        .toolbar {
+            display: flex;
+            justify-content: space-between;
+            align-items: center;
+            gap: 1rem;
+            margin-bottom: 0.75rem;
+        }
+        .crumbs button {
+            background: none;
+            border: none;
+            color: var(--muted);
+            cursor: pointer;
+            font: inherit;
+        }
+        .crumbs button:last-child { color: var(--text); cursor: default; }
+        .crumbs span { color: var(--muted); margin: 0 0.25rem; }
+        .legend { display: flex; align-items: center; gap: 0.5rem; color: var(--muted); font-size: 0.75rem; }
+        .legend .scale {
+            width: 10rem;
+            height: 0.5rem;
+            border-radius: 0.25rem;
+            background: linear-gradient(to right, hsl(0, 70%, 42%), hsl(60, 70%, 42%), hsl(120, 70%, 42%));
+        }
+        #map {
+            position: relative;
+            width: 100%;
+            height: calc(100vh - 14rem);
+            min-height: 24rem;
+            background: var(--card);
+            border: 1px solid var(--border);
+            border-radius: 0.5rem;
+            overflow: hidden;
+        }
+        .cell {
+            position: absolute;
+            overflow: hidden;
+            border: 1px solid var(--bg);
+            padding: 0.125rem 0.25rem;
+            font-size: 0.75rem;
+            line-height: 1.2;
+            color: #fff;
+            white-space: nowrap;
+            text-overflow: ellipsis;
+        }
+        .cell.dir { cursor: zoom-in; font-weight: 600; }
+        .cell:hover { outline: 2px solid var(--text); z-index: 1; }
+    </style>
+</head>
+<body>
+    <h1>{{.Title}}</h1>
+    <p class="timestamp">Generated {{.Timestamp}}</p>
+    <p class="summary">
+        <strong>{{printf "%.1f" .Root.Percent}}%</strong> of {{.Root.Total}} statements covered
+        across {{.Files}} files. Size is statements, colour is coverage; click a directory to zoom in.
+    </p>
+    <div class="toolbar">
+        <nav class="crumbs" id="crumbs"></nav>
+        <div class="legend"><span>0%</span><div class="scale"></div><span>100%</span></div>
+    </div>
+    <div id="map"></div>
+    <script>
+        (function () {
+            var data = {{.Root}};
+            var view = document.getElementById("map");
+            var crumbs = document.getElementById("crumbs");
+            var stack = [data];
+
+            function color(percent) {
+                return "hsl(" + Math.round(percent * 1.2) + ", 70%, 42%)";
+            }
+
+            // squarify lays nodes out in rect, keeping cells close to square.
+            function squarify(nodes, rect) {
+                var out = [];
+                var total = 0;
+                nodes.forEach(function (n) { total += n.total; });
+                if (total === 0 || rect.w <= 0 || rect.h <= 0) { return out; }
+                var scale = rect.w * rect.h / total;
+                var items = nodes.map(function (n) { return { node: n, area: n.total * scale }; });
+                var r = { x: rect.x, y: rect.y, w: rect.w, h: rect.h };
+
+                function worst(row, side) {
+                    var sum = 0, max = 0, min = Infinity;
+                    row.forEach(function (it) {
+                        sum += it.area;
+                        max = Math.max(max, it.area);
+                        min = Math.min(min, it.area);
+                    });
+                    return Math.max(side * side * max / (sum * sum), (sum * sum) / (side * side * min));
+                }
+                function place(row) {
+                    var sum = 0;
+                    row.forEach(function (it) { sum += it.area; });
+                    var vertical = r.w >= r.h;
+                    var thick = sum / (vertical ? r.h : r.w);
+                    var offset = 0;
+                    row.forEach(function (it) {
+                        var len = it.area / thick;
+                        if (vertical) {
+                            out.push({ node: it.node, x: r.x, y: r.y + offset, w: thick, h: len });
+                        } else {
+                            out.push({ node: it.node, x: r.x + offset, y: r.y, w: len, h: thick });
+                        }
+                        offset += len;
+                    });
+                    if (vertical) { r.x += thick; r.w -= thick; } else { r.y += thick; r.h -= thick; }
+                }
+
+                var row = [];
+                for (var i = 0; i < items.length;) {
+                    var side = Math.min(r.w, r.h);
+                    if (row.length === 0 || worst(row.concat([items[i]]), side) <= worst(row, side)) {
+                        row.push(items[i]);
+                        i++;
+                    } else {
+                        place(row);
+                        row = [];
+                    }
+                }
+                if (row.length) { place(row); }
+                return out;
+            }
+
+            function zoom(trail) {
+                stack = stack.concat(trail);
+                render();
+            }
+
+            function draw(nodes, rect, depth, trail) {
+                squarify(nodes, rect).forEach(function (cell) {
+                    var n = cell.node;
+                    var el = document.createElement("div");
+                    el.className = n.children ? "cell dir" : "cell";
+                    el.style.left = cell.x + "px";
+                    el.style.top = cell.y + "px";
+                    el.style.width = cell.w + "px";
+                    el.style.height = cell.h + "px";
+                    el.style.background = color(n.percent);
+                    el.title = n.path + "\n" + n.percent.toFixed(1) + "% covered (" + n.covered + "/" + n.total +
+                        " statements, " + n.uncovered + " uncovered)";
+                    if (cell.w > 40 && cell.h > 16) {
+                        el.textContent = n.name + " " + n.percent.toFixed(1) + "%";
+                    }
+                    view.appendChild(el);
+                    if (!n.children) { return; }
+                    el.addEventListener("click", function () { zoom(trail.concat([n])); });
+                    if (depth < 1 && cell.w > 60 && cell.h > 40) {
+                        draw(n.children, { x: cell.x + 2, y: cell.y + 18, w: cell.w - 4, h: cell.h - 20 }, depth + 1, trail.concat([n]));
+                    }
+                });
+            }
+
+            function render() {
+                var current = stack[stack.length - 1];
+                view.innerHTML = "";
+                draw(current.children || [current], { x: 0, y: 0, w: view.clientWidth, h: view.clientHeight }, 0, []);
+                crumbs.innerHTML = "";
+                stack.forEach(function (n, i) {
+                    if (i > 0) {
+                        var sep = document.createElement("span");
+                        sep.textContent = "/";
+                        crumbs.appendChild(sep);
+                    }
+                    var b = document.createElement("button");
+                    b.type = "button";
+                    b.textContent = n.name + " (" + n.percent.toFixed(1) + "%)";
+                    b.addEventListener("click", function () {
+                        stack = stack.slice(0, i + 1);
+                        render();
+                    });
+                    crumbs.appendChild(b);
+                });
+            }
+
+            window.addEventListener("resize", render);
+            render();
+        })();
+    </script>
+</body>
+</html>`
+
+// defaultHeatmapTitle is the page title when --title is not given.
+const defaultHeatmapTitle = "Coverage Heatmap"
+
+type heatmapData struct {
+	application.HeatmapResult
+	Title     string
+	Timestamp string
+}
+
+// WriteHeatmap renders a self-contained HTML treemap of the coverage tree:
+// each rectangle's area is its statement count and its colour its coverage,
+// from red at 0% to green at 100%. Directories zoom in when clicked.
+func WriteHeatmap(w io.Writer, result application.HeatmapResult, title string) error {
+	tmpl, err := template.New("heatmap").Parse(heatmapTemplate)
+	if err != nil {
+		return err
+	}
+	data := heatmapData{
+		HeatmapResult: result,
+		Title:         title,
+		Timestamp:     time.Now().Format("2006-01-02 15:04:05"),
+	}
+	if data.Title == "" {
+		data.Title = defaultHeatmapTitle
+	}
+	return tmpl.Execute(w, data)
+}
@@ -0,0 +1,35 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWriteHeatmap(t *testing.T) {
+	result := application.HeatmapResult{
+		Root:  domain.BuildHeatmap(map[string]domain.CoverageStat{"internal/core/a.go": {Covered: 3, Total: 4}}),
+		Files: 1,
+	}
+	buf := new(bytes.Buffer)
+	if err := WriteHeatmap(buf, result, ""); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"<title>Coverage Heatmap</title>", "75.0%", `"path":"internal/core/a.go"`, "across 1 files"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output", want)
+		}
+	}
+
+	buf.Reset()
+	if err := WriteHeatmap(buf, result, "api <heatmap>"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<title>api &lt;heatmap&gt;</title>") {
+		t.Fatal("expected escaped custom title")
+	}
+}
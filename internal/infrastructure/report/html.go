@@ -5,6 +5,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/domain"
 )
 
@@ -13,7 +14,7 @@ const htmlTemplate = `<!DOCTYPE html>
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Coverage Report</title>
+    <title>{{.Title}}</title>
     <style>
         :root {
             --pass: #16A34A;
@@ -24,6 +25,27 @@ const htmlTemplate = `<!DOCTYPE html>
             --text: #f8fafc;
             --muted: #94a3b8;
             --border: #334155;
+            --hover: rgba(255,255,255,0.02);
+            --miss-bg: rgba(220, 38, 38, 0.15);
+            --hit-bg: rgba(22, 163, 74, 0.12);
+        }
+        :root[data-theme="light"] {
+            --bg: #f8fafc;
+            --card: #ffffff;
+            --text: #0f172a;
+            --muted: #64748b;
+            --border: #e2e8f0;
+            --hover: rgba(15,23,42,0.03);
+        }
+        @media (prefers-color-scheme: light) {
+            :root:not([data-theme="dark"]) {
+                --bg: #f8fafc;
+                --card: #ffffff;
+                --text: #0f172a;
+                --muted: #64748b;
+                --border: #e2e8f0;
+                --hover: rgba(15,23,42,0.03);
+            }
         }
         * { box-sizing: border-box; margin: 0; padding: 0; }
         body {
@@ -91,7 +113,7 @@ const htmlTemplate = `<!DOCTYPE html>
             color: var(--muted);
         }
         tr:last-child td { border-bottom: none; }
-        tr:hover { background: rgba(255,255,255,0.02); }
+        tr:hover { background: var(--hover); }
         .status {
             display: inline-block;
             padding: 0.25rem 0.5rem;
@@ -147,16 +169,66 @@ const htmlTemplate = `<!DOCTYPE html>
             list-style: none;
             color: var(--muted);
         }
+        .delta.up { color: var(--pass); }
+        .delta.down { color: var(--fail); }
+        .delta.stable { color: var(--muted); }
         .warnings li::before {
             content: "⚠ ";
             color: var(--warn);
         }
+        .header {
+            display: flex;
+            justify-content: space-between;
+            align-items: flex-start;
+        }
+        .theme-toggle {
+            background: var(--card);
+            color: var(--text);
+            border: 1px solid var(--border);
+            border-radius: 0.375rem;
+            padding: 0.375rem 0.75rem;
+            cursor: pointer;
+            font: inherit;
+            font-size: 0.875rem;
+        }
+        details.source {
+            background: var(--card);
+            border: 1px solid var(--border);
+            border-radius: 0.5rem;
+            margin-bottom: 0.75rem;
+        }
+        details.source summary {
+            padding: 0.75rem 1rem;
+            cursor: pointer;
+        }
+        details.source summary span { color: var(--muted); font-size: 0.875rem; }
+        details.source pre {
+            overflow-x: auto;
+            border-top: 1px solid var(--border);
+            font-size: 0.8125rem;
+            line-height: 1.5;
+        }
+        .hunk + .hunk { border-top: 1px dashed var(--border); }
+        .src { display: block; padding: 0 1rem; white-space: pre; }
+        .src.miss { background: var(--miss-bg); }
+        .src.hit { background: var(--hit-bg); }
+        .src .ln {
+            display: inline-block;
+            min-width: 3.5rem;
+            color: var(--muted);
+            user-select: none;
+        }
     </style>
 </head>
 <body>
     <div class="container">
-        <h1>Coverage Report</h1>
-        <p class="timestamp">Generated {{.Timestamp}}</p>
+        <div class="header">
+            <div>
+                <h1>{{.Title}}</h1>
+                <p class="timestamp">Generated {{.Timestamp}}</p>
+            </div>
+            <button class="theme-toggle" type="button">Toggle theme</button>
+        </div>
 
         <div class="summary">
             <div class="summary-card {{if .Passed}}pass{{else}}fail{{end}}">
@@ -195,7 +267,9 @@ const htmlTemplate = `<!DOCTYPE html>
                 <tr>
                     <th>Domain</th>
                     <th>Coverage</th>
-                    <th>Required</th>
+                    {{if .DeltaFor}}<th>Change</th>
+                    <th>Previous</th>
+                    {{end}}<th>Required</th>
                     <th>Status</th>
                 </tr>
             </thead>
@@ -212,7 +286,11 @@ const htmlTemplate = `<!DOCTYPE html>
                             </div>
                         </div>
                     </td>
-                    <td>{{printf "%.1f" .Required}}%</td>
+                    {{if $.DeltaFor}}{{with index $.DeltaFor .Domain}}<td class="delta {{.Trend}}">{{if eq .Trend "up"}}▲{{else if eq .Trend "down"}}▼{{else}}→{{end}} {{printf "%+.1f" .Delta}}%</td>
+                    <td>{{printf "%.1f" .Previous}}%</td>
+                    {{else}}<td class="delta stable">new</td>
+                    <td>—</td>
+                    {{end}}{{end}}<td>{{printf "%.1f" .Required}}%</td>
                     <td><span class="status {{if eq .Status "PASS"}}pass{{else}}fail{{end}}">{{.Status}}</span></td>
                 </tr>
                 {{end}}
@@ -220,6 +298,30 @@ const htmlTemplate = `<!DOCTYPE html>
         </table>
         {{end}}
 
+        {{if .HasSources}}
+        <h2 class="section-title">Coverage by Source</h2>
+        <table>
+            <thead>
+                <tr>
+                    <th>Domain</th>
+                    <th>Source</th>
+                    <th>Alone</th>
+                    <th>Added</th>
+                </tr>
+            </thead>
+            <tbody>
+                {{range .Domains}}{{$domain := .Domain}}{{range .Sources}}
+                <tr>
+                    <td>{{$domain}}</td>
+                    <td>{{.Source}}</td>
+                    <td>{{printf "%.1f" .Percent}}%</td>
+                    <td>{{printf "%+.1f" .Added}}%</td>
+                </tr>
+                {{end}}{{end}}
+            </tbody>
+        </table>
+        {{end}}
+
         {{if .Files}}
         <h2 class="section-title">File Rules</h2>
         <table>
@@ -251,23 +353,86 @@ const htmlTemplate = `<!DOCTYPE html>
             </tbody>
         </table>
         {{end}}
+
+        {{if .Snippets}}
+        <h2 class="section-title">Uncovered Source</h2>
+        {{range .Snippets}}
+        <details class="source">
+            <summary><code>{{.File}}</code> <span>{{.Uncovered}} uncovered lines</span></summary>
+            <pre>{{range .Hunks}}<div class="hunk">{{range .}}<span class="src {{.Class}}"><span class="ln">{{.Number}}</span>{{.Text}}</span>{{end}}</div>{{end}}</pre>
+        </details>
+        {{end}}
+        {{end}}
     </div>
+    <script>
+        (function () {
+            var root = document.documentElement;
+            try {
+                var saved = localStorage.getItem("coverctl-theme");
+                if (saved) { root.setAttribute("data-theme", saved); }
+            } catch (e) {}
+            document.querySelector(".theme-toggle").addEventListener("click", function () {
+                var current = root.getAttribute("data-theme");
+                var dark = current ? current === "dark" : !window.matchMedia("(prefers-color-scheme: light)").matches;
+                var next = dark ? "light" : "dark";
+                root.setAttribute("data-theme", next);
+                try { localStorage.setItem("coverctl-theme", next); } catch (e) {}
+            });
+        })();
+    </script>
 </body>
 </html>`
 
+// defaultHTMLTitle is the page title when --title is not given.
+const defaultHTMLTitle = "Coverage Report"
+
 type htmlData struct {
 	domain.Result
-	Timestamp string
+	Title      string
+	Timestamp  string
+	HasSources bool
+	Snippets   []htmlSnippet
+	// DeltaFor holds each domain's change since the latest history entry
+	// (--show-delta); domains missing from it are new since that entry.
+	DeltaFor map[string]*domain.DomainDelta
+}
+
+// WriteHTML renders the HTML report with a custom title and, when
+// self-contained, the uncovered source embedded so the file can be shared
+// without access to the repository.
+func (Writer) WriteHTML(w io.Writer, result domain.Result, opts application.HTMLOptions) error {
+	return writeHTML(w, result, opts)
 }
 
-func writeHTML(w io.Writer, result domain.Result) error {
+var _ application.HTMLReporter = Writer{}
+
+func writeHTML(w io.Writer, result domain.Result, opts application.HTMLOptions) error {
 	tmpl, err := template.New("report").Parse(htmlTemplate)
 	if err != nil {
 		return err
 	}
 	data := htmlData{
 		Result:    result,
+		Title:     opts.Title,
 		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
 	}
+	if data.Title == "" {
+		data.Title = defaultHTMLTitle
+	}
+	if opts.SelfContained && result.Lines != nil {
+		data.Snippets = sourceSnippets(result.Lines, result.SourceRoot)
+	}
+	for _, d := range result.Domains {
+		if len(d.Sources) > 0 {
+			data.HasSources = true
+			break
+		}
+	}
+	if len(result.Deltas) > 0 {
+		data.DeltaFor = make(map[string]*domain.DomainDelta, len(result.Deltas))
+		for i := range result.Deltas {
+			data.DeltaFor[result.Deltas[i].Domain] = &result.Deltas[i]
+		}
+	}
 	return tmpl.Execute(w, data)
 }
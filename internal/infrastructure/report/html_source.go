@@ -0,0 +1,101 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// Limits that keep a self-contained report a reasonable size.
+const (
+	maxSnippetFiles = 50 // Files with the most uncovered lines are embedded first
+	snippetContext  = 2  // Lines shown around each uncovered range
+)
+
+// htmlSnippet is the uncovered source of one file, split into hunks of
+// consecutive lines.
+type htmlSnippet struct {
+	File      string
+	Uncovered int
+	Hunks     [][]htmlSourceLine
+}
+
+type htmlSourceLine struct {
+	Number int
+	Text   string
+	Class  string // "hit", "miss", or empty for lines that are not executable
+}
+
+// sourceSnippets reads the files under root that have uncovered lines and
+// cuts out each uncovered range with a little context. Files that cannot be
+// read (generated elsewhere, deleted since the run) are left out.
+func sourceSnippets(lines map[string]domain.LineCoverage, root string) []htmlSnippet {
+	files := make([]domain.UncoveredFile, 0, len(lines))
+	for file, cov := range lines {
+		missed := 0
+		for _, hits := range cov {
+			if hits == 0 {
+				missed++
+			}
+		}
+		if missed > 0 {
+			files = append(files, domain.UncoveredFile{File: file, Uncovered: missed})
+		}
+	}
+	domain.SortUncoveredFiles(files)
+	if len(files) > maxSnippetFiles {
+		files = files[:maxSnippetFiles]
+	}
+
+	snippets := make([]htmlSnippet, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(f.File)))
+		if err != nil {
+			continue
+		}
+		src := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+		cov := lines[f.File]
+		snippet := htmlSnippet{File: f.File, Uncovered: f.Uncovered}
+		for _, r := range snippetRanges(cov.UncoveredRanges(), len(src)) {
+			hunk := make([]htmlSourceLine, 0, r.End-r.Start+1)
+			for n := r.Start; n <= r.End; n++ {
+				line := htmlSourceLine{Number: n, Text: src[n-1]}
+				if hits, ok := cov[n]; ok {
+					line.Class = "hit"
+					if hits == 0 {
+						line.Class = "miss"
+					}
+				}
+				hunk = append(hunk, line)
+			}
+			snippet.Hunks = append(snippet.Hunks, hunk)
+		}
+		if len(snippet.Hunks) > 0 {
+			snippets = append(snippets, snippet)
+		}
+	}
+	return snippets
+}
+
+// snippetRanges widens each range by snippetContext lines, clamps it to the
+// file, and joins ranges that touch.
+func snippetRanges(ranges []domain.LineRange, lineCount int) []domain.LineRange {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start < ranges[j].Start })
+	var out []domain.LineRange
+	for _, r := range ranges {
+		start := max(1, r.Start-snippetContext)
+		end := min(lineCount, r.End+snippetContext)
+		if start > end {
+			continue
+		}
+		if n := len(out); n > 0 && start <= out[n-1].End+1 {
+			out[n-1].End = max(out[n-1].End, end)
+			continue
+		}
+		out = append(out, domain.LineRange{Start: start, End: end})
+	}
+	return out
+}
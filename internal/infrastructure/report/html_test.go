@@ -2,6 +2,8 @@ package report
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -141,3 +143,137 @@ func TestWriteHTMLMultipleDomains(t *testing.T) {
 		t.Fatal("expected cli domain")
 	}
 }
+
+func TestWriteHTMLDeltas(t *testing.T) {
+	buf := new(bytes.Buffer)
+	res := domain.Result{
+		Passed: true,
+		Domains: []domain.DomainResult{
+			{Domain: "core", Percent: 72, Required: 70, Status: domain.StatusPass},
+			{Domain: "api", Percent: 90, Required: 70, Status: domain.StatusPass},
+		},
+		Deltas: []domain.DomainDelta{{Domain: "core", Previous: 75, Current: 72, Delta: -3, Trend: domain.TrendDown}},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputHTML); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "<th>Change</th>") || !strings.Contains(output, "<th>Previous</th>") {
+		t.Fatal("expected change and previous columns in the domain table")
+	}
+	if !strings.Contains(output, `class="delta down">▼ -3.0%</td>`) || !strings.Contains(output, "<td>75.0%</td>") {
+		t.Fatalf("expected arrow, signed delta, and previous value, got:\n%s", output)
+	}
+	if !strings.Contains(output, `class="delta stable">new</td>`) {
+		t.Fatalf("expected a domain without history to be marked new, got:\n%s", output)
+	}
+
+	buf.Reset()
+	res.Deltas = nil
+	if err := (Writer{}).Write(buf, res, application.OutputHTML); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if strings.Contains(buf.String(), "<th>Change</th>") {
+		t.Fatal("expected no change column without --show-delta")
+	}
+}
+
+func TestWriteHTMLSources(t *testing.T) {
+	buf := new(bytes.Buffer)
+	res := domain.Result{
+		Passed: true,
+		Domains: []domain.DomainResult{{Domain: "core", Percent: 81, Required: 80, Status: domain.StatusPass, Sources: []domain.SourceCoverage{
+			{Source: "unit", Percent: 72, Added: 72},
+			{Source: "integration", Percent: 40, Added: 9},
+		}}},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputHTML); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "Coverage by Source") {
+		t.Fatal("expected source section")
+	}
+	if !strings.Contains(output, "<td>integration</td>") || !strings.Contains(output, "<td>40.0%</td>") {
+		t.Fatalf("expected integration row, got:\n%s", output)
+	}
+}
+
+func TestWriteHTMLTitleAndTheme(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).WriteHTML(buf, domain.Result{Passed: true}, application.HTMLOptions{Title: "api <coverage>"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "<title>api &lt;coverage&gt;</title>") {
+		t.Fatal("expected escaped custom title")
+	}
+	if !strings.Contains(output, `class="theme-toggle"`) || !strings.Contains(output, `data-theme="light"`) {
+		t.Fatal("expected theme toggle and light theme")
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, domain.Result{Passed: true}, application.OutputHTML); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<title>Coverage Report</title>") {
+		t.Fatal("expected default title")
+	}
+}
+
+func TestWriteHTMLSelfContained(t *testing.T) {
+	root := t.TempDir()
+	src := "package core\n\nfunc A() int {\n\treturn 1\n}\n\nfunc B() int {\n\treturn 2\n}\n\nfunc C() int {\n\treturn 3\n}\n"
+	if err := os.MkdirAll(filepath.Join(root, "core"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "core", "a.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	res := domain.Result{
+		Passed:     true,
+		SourceRoot: root,
+		Lines: map[string]domain.LineCoverage{
+			"core/a.go":    {4: 1, 8: 0, 12: 1},
+			"core/gone.go": {1: 0},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).WriteHTML(buf, res, application.HTMLOptions{}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if strings.Contains(buf.String(), "Uncovered Source") {
+		t.Fatal("expected no source without --self-contained")
+	}
+
+	buf.Reset()
+	if err := (Writer{}).WriteHTML(buf, res, application.HTMLOptions{SelfContained: true}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "Uncovered Source") || !strings.Contains(output, "core/a.go") {
+		t.Fatal("expected uncovered source section")
+	}
+	if !strings.Contains(output, `<span class="src miss"><span class="ln">8</span>	return 2</span>`) {
+		t.Fatalf("expected uncovered line 8 marked as miss:\n%s", output)
+	}
+	if strings.Contains(output, "return 1") || strings.Contains(output, "return 3") {
+		t.Fatal("expected only the uncovered range with context")
+	}
+	if strings.Contains(output, "core/gone.go") {
+		t.Fatal("expected unreadable files to be skipped")
+	}
+}
+
+func TestSnippetRanges(t *testing.T) {
+	got := snippetRanges([]domain.LineRange{{Start: 9, End: 9}, {Start: 1, End: 2}, {Start: 5, End: 5}}, 10)
+	want := []domain.LineRange{{Start: 1, End: 10}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	got = snippetRanges([]domain.LineRange{{Start: 2, End: 2}, {Start: 20, End: 21}}, 22)
+	if len(got) != 2 || got[0] != (domain.LineRange{Start: 1, End: 4}) || got[1] != (domain.LineRange{Start: 18, End: 22}) {
+		t.Fatalf("unexpected ranges %v", got)
+	}
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// TestWriteJSONGolden pins the --output json layout. Changing a golden file
+// means downstream parsers see a different document: only add optional
+// fields, or bump application.JSONSchema.
+func TestWriteJSONGolden(t *testing.T) {
+	min := 75.0
+	delta := 1.5
+	tests := map[string]domain.Result{
+		"empty": {Passed: true},
+		"full": {
+			Passed: false,
+			// Config order, not name order: the writer sorts.
+			Domains: []domain.DomainResult{
+				{Domain: "core", Covered: 40, Total: 100, Percent: 40, Required: 80, Status: domain.StatusFail, Delta: &delta},
+				{Domain: "api", Covered: 90, Total: 100, Percent: 90, Required: 80, Status: domain.StatusPass},
+			},
+			Groups: []domain.GroupResult{
+				{Group: "backend", Domains: []string{"api", "core"}, Covered: 130, Total: 200, Percent: 65, Required: &min, Status: domain.StatusFail},
+			},
+			Files: []domain.FileResult{
+				{File: "internal/core/z.go", Percent: 50, Required: 60, Status: domain.StatusFail},
+				{File: "internal/api/a.go", Percent: 90, Required: 60, Status: domain.StatusPass},
+			},
+			Warnings: []string{"directory internal/shared belongs to api, core domains"},
+			Deltas: []domain.DomainDelta{
+				{Domain: "core", Previous: 38.5, Current: 40, Delta: 1.5, Trend: "up"},
+				{Domain: "api", Previous: 90, Current: 90, Delta: 0, Trend: "stable"},
+			},
+			TopFiles: []domain.UncoveredFile{
+				{File: "internal/core/z.go", Domains: []string{"core"}, Covered: 10, Total: 70, Uncovered: 60, Percent: 14.3},
+			},
+			Timing: []domain.DomainTiming{
+				{Domain: "core", Seconds: 12.5, Percent: 50, SecondsPerPercent: 0.25},
+			},
+			Exceptions: []domain.ExceptionResult{
+				{Domain: "api", Reason: "rewrite in progress", Approver: "alice", Expires: "2026-03-31", Applied: true},
+			},
+			Health: &domain.Health{Score: 50, PassingDomains: 1, FailingDomains: 1, Shortfall: 50, Events: 2},
+		},
+	}
+	for name, result := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (Writer{}).Write(&buf, result, application.OutputJSON); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			path := filepath.Join("testdata", name+".json.golden")
+			if *updateGolden {
+				if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+					t.Fatalf("update golden: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden (run with -update to create): %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("JSON output differs from %s:\n%s", path, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// writeMarkdown renders a result for CI job summary pages such as GitHub
+// Actions' $GITHUB_STEP_SUMMARY: a domain table with deltas, failing file
+// rules, patch coverage, and warnings.
+func writeMarkdown(w io.Writer, result domain.Result) error {
+	var b strings.Builder
+
+	verdict := domain.StatusPass
+	if !result.Passed {
+		verdict = domain.StatusFail
+	}
+	fmt.Fprintf(&b, "## Coverage: %s %s\n\n", gateIcon(verdict), verdict)
+
+	if len(result.Domains) > 0 {
+		fmt.Fprintf(&b, "Overall coverage: **%.1f%%**\n\n", result.OverallPercent())
+
+		hasDeltas := false
+		for _, d := range result.Domains {
+			if d.Delta != nil {
+				hasDeltas = true
+				break
+			}
+		}
+		if hasDeltas {
+			b.WriteString("| Domain | Coverage | Required | Delta | Status |\n")
+			b.WriteString("|--------|----------|----------|-------|--------|\n")
+		} else {
+			b.WriteString("| Domain | Coverage | Required | Status |\n")
+			b.WriteString("|--------|----------|----------|--------|\n")
+		}
+		for _, d := range result.Domains {
+			fmt.Fprintf(&b, "| %s | %.1f%% | %.1f%% |", d.Domain, d.Percent, d.Required)
+			if hasDeltas {
+				fmt.Fprintf(&b, " %s |", markdownDelta(d.Delta))
+			}
+			fmt.Fprintf(&b, " %s %s |\n", gateIcon(d.Status), d.Status)
+		}
+	}
+
+	if len(result.Groups) > 0 {
+		b.WriteString("\n### Groups\n\n")
+		b.WriteString("| Group | Coverage | Required | Domains | Status |\n")
+		b.WriteString("|-------|----------|----------|---------|--------|\n")
+		for _, g := range result.Groups {
+			required := "-"
+			if g.Required != nil {
+				required = fmt.Sprintf("%.1f%%", *g.Required)
+			}
+			fmt.Fprintf(&b, "| %s | %.1f%% | %s | %s | %s %s |\n", g.Group, g.Percent, required, strings.Join(g.Domains, ", "), gateIcon(g.Status), g.Status)
+		}
+	}
+
+	var failingFiles []domain.FileResult
+	for _, f := range result.Files {
+		if f.Status == domain.StatusFail {
+			failingFiles = append(failingFiles, f)
+		}
+	}
+	if len(failingFiles) > 0 {
+		b.WriteString("\n### Failing file rules\n\n")
+		b.WriteString("| File | Coverage | Required |\n")
+		b.WriteString("|------|----------|----------|\n")
+		for _, f := range failingFiles {
+			fmt.Fprintf(&b, "| `%s` | %.1f%% | %.1f%% |\n", f.File, f.Percent, f.Required)
+		}
+	}
+
+	if len(result.TopFiles) > 0 {
+		b.WriteString("\n### Top files by uncovered statements\n\n")
+		b.WriteString("| File | Uncovered | Coverage | Domains |\n")
+		b.WriteString("|------|-----------|----------|---------|\n")
+		for _, f := range result.TopFiles {
+			fmt.Fprintf(&b, "| `%s` | %d/%d | %.1f%% | %s |\n", f.File, f.Uncovered, f.Total, f.Percent, strings.Join(f.Domains, ", "))
+		}
+	}
+
+	if len(result.Timing) > 0 {
+		b.WriteString("\n### Test time by domain\n\n")
+		b.WriteString("| Domain | Seconds | Coverage | Sec/pt |\n")
+		b.WriteString("|--------|---------|----------|--------|\n")
+		for _, t := range result.Timing {
+			fmt.Fprintf(&b, "| %s | %.1f | %.1f%% | %s |\n", t.Domain, t.Seconds, t.Percent, secondsPerPoint(t))
+		}
+	}
+
+	if len(result.Exceptions) > 0 {
+		b.WriteString("\n### Policy exceptions\n\n")
+		b.WriteString("| Target | Expires | Approver | State | Reason |\n")
+		b.WriteString("|--------|---------|----------|-------|--------|\n")
+		for _, e := range result.Exceptions {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", exceptionTarget(e), e.Expires, e.Approver, exceptionState(e), e.Reason)
+		}
+	}
+
+	if patch := result.Patch; patch != nil {
+		fmt.Fprintf(&b, "\n### Patch coverage\n\n%s %.1f%% of %d changed lines (%s)\n",
+			gateIcon(patch.Status), patch.Percent, patch.Total, patch.Requirement())
+		if len(patch.Uncovered) > 0 {
+			b.WriteString("\n")
+		}
+		for i, u := range patch.Uncovered {
+			if i == maxPatchLinesShown {
+				fmt.Fprintf(&b, "- ... and %d more\n", len(patch.Uncovered)-maxPatchLinesShown)
+				break
+			}
+			fmt.Fprintf(&b, "- `%s`\n", u)
+		}
+	}
+
+	if nc := result.NewCode; nc != nil {
+		fmt.Fprintf(&b, "\n### New code coverage\n\n%s %.1f%% of %d lines changed in the last %s (required %.1f%%)\n",
+			gateIcon(nc.Status), nc.Percent, nc.Total, nc.Since, nc.Required)
+		if len(nc.Uncovered) > 0 {
+			b.WriteString("\n")
+		}
+		for i, u := range nc.Uncovered {
+			if i == maxPatchLinesShown {
+				fmt.Fprintf(&b, "- ... and %d more\n", len(nc.Uncovered)-maxPatchLinesShown)
+				break
+			}
+			fmt.Fprintf(&b, "- `%s`\n", u)
+		}
+	}
+
+	if fc := result.Functions; fc != nil {
+		fmt.Fprintf(&b, "\n### Function coverage\n\n%s %.1f%% of %d functions (required %.1f%%)\n",
+			gateIcon(fc.Status), fc.Percent, fc.Total, fc.Required)
+		if len(fc.Uncovered) > 0 {
+			b.WriteString("\n")
+		}
+		for i, fn := range fc.Uncovered {
+			if i == maxPatchLinesShown {
+				fmt.Fprintf(&b, "- ... and %d more\n", len(fc.Uncovered)-maxPatchLinesShown)
+				break
+			}
+			fmt.Fprintf(&b, "- `%s`\n", fn)
+		}
+	}
+
+	if len(result.Warnings) > 0 {
+		b.WriteString("\n### Warnings\n\n")
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(&b, "- %s\n", warning)
+		}
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownDelta(delta *float64) string {
+	switch {
+	case delta == nil:
+		return "-"
+	case *delta > 0:
+		return fmt.Sprintf(":arrow_up: +%.1f%%", *delta)
+	case *delta < 0:
+		return fmt.Sprintf(":arrow_down: %.1f%%", *delta)
+	default:
+		return "0.0%"
+	}
+}
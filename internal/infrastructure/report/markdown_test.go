@@ -0,0 +1,62 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	delta := -1.5
+	result := domain.Result{
+		Passed: false,
+		Domains: []domain.DomainResult{
+			{Domain: "core", Covered: 80, Total: 100, Percent: 80, Required: 70, Status: domain.StatusPass, Delta: &delta},
+		},
+		Files: []domain.FileResult{
+			{File: "core/a.go", Percent: 40, Required: 60, Status: domain.StatusFail},
+			{File: "core/b.go", Percent: 90, Required: 60, Status: domain.StatusPass},
+		},
+		Warnings: []string{"overlapping domains"},
+	}
+
+	var buf bytes.Buffer
+	if err := (Writer{}).Write(&buf, result, application.OutputMarkdown); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Coverage: :x: FAIL",
+		"| Domain | Coverage | Required | Delta | Status |",
+		"| core | 80.0% | 70.0% | :arrow_down: -1.5% | :white_check_mark: PASS |",
+		"| `core/a.go` | 40.0% | 60.0% |",
+		"- overlapping domains",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in markdown:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "core/b.go") {
+		t.Errorf("passing file rules should be omitted:\n%s", out)
+	}
+}
+
+func TestWriteMarkdownGroups(t *testing.T) {
+	min := 80.0
+	result := domain.Result{
+		Domains: []domain.DomainResult{{Domain: "api", Percent: 70, Required: 50, Status: domain.StatusPass}},
+		Groups: []domain.GroupResult{
+			{Group: "backend", Domains: []string{"api", "db"}, Percent: 70, Required: &min, Status: domain.StatusFail},
+		},
+	}
+	var buf bytes.Buffer
+	if err := (Writer{}).Write(&buf, result, application.OutputMarkdown); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "| backend | 70.0% | 80.0% | api, db | :x: FAIL |") {
+		t.Fatalf("expected group row in markdown:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,274 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+const orgTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        :root {
+            --pass: #16A34A;
+            --fail: #DC2626;
+            --bg: #0f172a;
+            --card: #1e293b;
+            --text: #f8fafc;
+            --muted: #94a3b8;
+            --border: #334155;
+        }
+        * { box-sizing: border-box; margin: 0; padding: 0; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, sans-serif;
+            background: var(--bg);
+            color: var(--text);
+            line-height: 1.6;
+            padding: 2rem;
+        }
+        .container { max-width: 1200px; margin: 0 auto; }
+        h1 { font-size: 2rem; margin-bottom: 0.5rem; font-weight: 600; }
+        h2 { font-size: 1.25rem; margin-bottom: 1rem; font-weight: 600; }
+        .timestamp { color: var(--muted); font-size: 0.875rem; margin-bottom: 2rem; }
+        .summary { display: flex; gap: 1rem; margin-bottom: 2rem; }
+        .summary-card {
+            background: var(--card);
+            border-radius: 0.5rem;
+            padding: 1rem 1.5rem;
+            border: 1px solid var(--border);
+        }
+        .summary-card.pass { border-left: 4px solid var(--pass); }
+        .summary-card.fail { border-left: 4px solid var(--fail); }
+        .summary-label { font-size: 0.75rem; text-transform: uppercase; color: var(--muted); letter-spacing: 0.05em; }
+        .summary-value { font-size: 1.5rem; font-weight: 600; }
+        table {
+            width: 100%;
+            border-collapse: collapse;
+            background: var(--card);
+            border-radius: 0.5rem;
+            overflow: hidden;
+            margin-bottom: 2rem;
+        }
+        th, td { padding: 0.75rem 1rem; text-align: left; border-bottom: 1px solid var(--border); }
+        th {
+            background: rgba(0,0,0,0.2);
+            font-weight: 600;
+            font-size: 0.75rem;
+            text-transform: uppercase;
+            letter-spacing: 0.05em;
+            color: var(--muted);
+        }
+        tr:last-child td { border-bottom: none; }
+        .status { display: inline-block; padding: 0.25rem 0.5rem; border-radius: 0.25rem; font-size: 0.75rem; font-weight: 600; }
+        .status.pass { background: rgba(22, 163, 74, 0.2); color: var(--pass); }
+        .status.fail { background: rgba(220, 38, 38, 0.2); color: var(--fail); }
+        .muted { color: var(--muted); }
+        .trend { background: var(--card); border: 1px solid var(--border); border-radius: 0.5rem; padding: 1rem; margin-bottom: 2rem; }
+        .trend polyline { fill: none; stroke: var(--pass); stroke-width: 2; }
+    </style>
+</head>
+<body>
+<div class="container">
+    <h1>{{.Title}}</h1>
+    <p class="timestamp">Generated {{.Timestamp}}</p>
+    <div class="summary">
+        <div class="summary-card {{if .Report.Passed}}pass{{else}}fail{{end}}">
+            <div class="summary-label">Overall</div>
+            <div class="summary-value">{{printf "%.1f" .Report.Percent}}%</div>
+        </div>
+        <div class="summary-card">
+            <div class="summary-label">Services</div>
+            <div class="summary-value">{{len .Report.Services}}</div>
+        </div>
+        <div class="summary-card {{if .Failing}}fail{{else}}pass{{end}}">
+            <div class="summary-label">Failing</div>
+            <div class="summary-value">{{.Failing}}</div>
+        </div>
+    </div>
+    <h2>Services</h2>
+    <table>
+        <thead><tr><th>Service</th><th>Coverage</th><th>Change</th><th>Status</th><th>Failing domains</th><th>Source</th></tr></thead>
+        <tbody>
+        {{range .Report.Services}}
+            <tr>
+                <td>{{.Service}}</td>
+                <td>{{printf "%.1f" .Percent}}%</td>
+                <td class="muted">{{if .Delta}}{{printf "%+.1f" (deref .Delta)}}%{{else}}-{{end}}</td>
+                <td><span class="status {{if .Passed}}pass{{else}}fail{{end}}">{{if .Passed}}PASS{{else}}FAIL{{end}}</span></td>
+                <td>{{join .Failing}}</td>
+                <td class="muted">{{.Source}}</td>
+            </tr>
+        {{end}}
+        </tbody>
+    </table>
+    {{if .Report.Trend}}
+    <h2>Trend</h2>
+    <div class="trend">
+        <svg viewBox="0 0 {{.TrendWidth}} 100" preserveAspectRatio="none" width="100%" height="120">
+            <polyline points="{{.TrendPoints}}"/>
+        </svg>
+    </div>
+    <table>
+        <thead><tr><th>Date</th><th>Coverage</th><th>Services</th></tr></thead>
+        <tbody>
+        {{range .Report.Trend}}
+            <tr><td>{{.Date}}</td><td>{{printf "%.1f" .Percent}}%</td><td>{{.Services}}</td></tr>
+        {{end}}
+        </tbody>
+    </table>
+    {{end}}
+</div>
+</body>
+</html>`
+
+// defaultOrgTitle is the HTML page title when --title is not given.
+const defaultOrgTitle = "Organization Coverage"
+
+type orgData struct {
+	Report      domain.OrgReport
+	Title       string
+	Timestamp   string
+	Failing     int
+	TrendWidth  int
+	TrendPoints string
+}
+
+// WriteOrgReport renders a combined multi-repository report as text,
+// json, markdown, or html.
+func WriteOrgReport(w io.Writer, report domain.OrgReport, format application.OutputFormat, title string) error {
+	switch format {
+	case application.OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case application.OutputMarkdown:
+		_, err := io.WriteString(w, orgMarkdown(report))
+		return err
+	case application.OutputHTML:
+		return writeOrgHTML(w, report, title)
+	case application.OutputText, "":
+		return writeOrgText(w, report)
+	default:
+		return fmt.Errorf("unsupported output format for aggregate: %s (valid: text, json, markdown, html)", format)
+	}
+}
+
+func writeOrgText(w io.Writer, report domain.OrgReport) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Service\tCoverage\tChange\tStatus\tFailing domains")
+	for _, s := range report.Services {
+		failing := strings.Join(s.Failing, ", ")
+		if failing == "" {
+			failing = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%.1f%%\t%s\t%s\t%s\n", s.Service, s.Percent, serviceDelta(s), serviceStatus(s), failing)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\nOverall: %.1f%% across %d services (%d failing)\n", report.Percent, len(report.Services), failingServices(report))
+	if len(report.Trend) > 0 {
+		fmt.Fprintln(w, "\nTrend:")
+		ttw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(ttw, "Date\tCoverage\tServices")
+		for _, p := range report.Trend {
+			_, _ = fmt.Fprintf(ttw, "%s\t%.1f%%\t%d\n", p.Date, p.Percent, p.Services)
+		}
+		return ttw.Flush()
+	}
+	return nil
+}
+
+func orgMarkdown(report domain.OrgReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Organization coverage: %.1f%%\n\n", report.Percent)
+	fmt.Fprintf(&b, "%d services, %d failing\n\n", len(report.Services), failingServices(report))
+	b.WriteString("| Service | Coverage | Change | Status | Failing domains |\n")
+	b.WriteString("|---------|----------|--------|--------|-----------------|\n")
+	for _, s := range report.Services {
+		status := domain.StatusPass
+		if !s.Passed {
+			status = domain.StatusFail
+		}
+		fmt.Fprintf(&b, "| %s | %.1f%% | %s | %s | %s |\n", s.Service, s.Percent, serviceDelta(s), gateIcon(status), strings.Join(s.Failing, ", "))
+	}
+	if len(report.Trend) > 0 {
+		b.WriteString("\n### Trend\n\n")
+		b.WriteString("| Date | Coverage | Services |\n")
+		b.WriteString("|------|----------|----------|\n")
+		for _, p := range report.Trend {
+			fmt.Fprintf(&b, "| %s | %.1f%% | %d |\n", p.Date, p.Percent, p.Services)
+		}
+	}
+	return b.String()
+}
+
+func writeOrgHTML(w io.Writer, report domain.OrgReport, title string) error {
+	tmpl, err := template.New("org").Funcs(template.FuncMap{
+		"deref": func(v *float64) float64 { return *v },
+		"join":  func(s []string) string { return strings.Join(s, ", ") },
+	}).Parse(orgTemplate)
+	if err != nil {
+		return err
+	}
+	data := orgData{
+		Report:    report,
+		Title:     title,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Failing:   failingServices(report),
+	}
+	if data.Title == "" {
+		data.Title = defaultOrgTitle
+	}
+	data.TrendWidth, data.TrendPoints = trendPolyline(report.Trend)
+	return tmpl.Execute(w, data)
+}
+
+// trendPolyline lays trend points out 10 units apart on a 0-100 scale,
+// with y inverted so higher coverage is drawn higher.
+func trendPolyline(trend []domain.OrgTrendPoint) (int, string) {
+	points := make([]string, 0, len(trend))
+	for i, p := range trend {
+		points = append(points, fmt.Sprintf("%d,%.1f", i*10, 100-p.Percent))
+	}
+	width := (len(trend) - 1) * 10
+	if width < 10 {
+		width = 10
+	}
+	return width, strings.Join(points, " ")
+}
+
+func serviceStatus(s domain.ServiceCoverage) string {
+	if s.Passed {
+		return string(domain.StatusPass)
+	}
+	return string(domain.StatusFail)
+}
+
+func serviceDelta(s domain.ServiceCoverage) string {
+	if s.Delta == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", *s.Delta)
+}
+
+func failingServices(report domain.OrgReport) int {
+	n := 0
+	for _, s := range report.Services {
+		if !s.Passed {
+			n++
+		}
+	}
+	return n
+}
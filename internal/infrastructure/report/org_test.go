@@ -0,0 +1,49 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func orgFixture() domain.OrgReport {
+	delta := -1.5
+	return domain.OrgReport{
+		Percent: 70,
+		Passed:  false,
+		Services: []domain.ServiceCoverage{
+			{Service: "ledger", Source: "ledger/history.json", Percent: 60, Passed: false, Failing: []string{"api"}, Delta: &delta},
+			{Service: "payments", Source: "payments.json", Percent: 80, Passed: true},
+		},
+		Trend: []domain.OrgTrendPoint{{Date: "2026-03-01", Percent: 71, Services: 2}, {Date: "2026-03-02", Percent: 70, Services: 2}},
+	}
+}
+
+func TestWriteOrgReport(t *testing.T) {
+	tests := map[application.OutputFormat][]string{
+		application.OutputText:     {"ledger    60.0%     -1.5%   FAIL    api", "Overall: 70.0% across 2 services (1 failing)", "2026-03-02  70.0%     2"},
+		application.OutputJSON:     {`"service": "ledger"`, `"delta": -1.5`, `"trend": [`},
+		application.OutputMarkdown: {"## Organization coverage: 70.0%", "| ledger | 60.0% | -1.5% | :x: | api |", "### Trend"},
+		application.OutputHTML:     {"<title>Org</title>", "<td>payments</td>", `points="0,29.0 10,30.0"`},
+	}
+	for format, wants := range tests {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteOrgReport(&buf, orgFixture(), format, "Org"); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			for _, want := range wants {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected %q in output:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+
+	if err := WriteOrgReport(&bytes.Buffer{}, orgFixture(), application.OutputCSV, ""); err == nil {
+		t.Fatal("expected csv to be rejected")
+	}
+}
@@ -0,0 +1,208 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+const patchTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        :root {
+            --pass: #16A34A;
+            --fail: #DC2626;
+            --bg: #0f172a;
+            --card: #1e293b;
+            --text: #f8fafc;
+            --muted: #94a3b8;
+            --border: #334155;
+            --miss-bg: rgba(220, 38, 38, 0.22);
+            --hit-bg: rgba(22, 163, 74, 0.18);
+            --changed-bg: rgba(148, 163, 184, 0.08);
+        }
+        @media (prefers-color-scheme: light) {
+            :root {
+                --bg: #f8fafc;
+                --card: #ffffff;
+                --text: #0f172a;
+                --muted: #64748b;
+                --border: #e2e8f0;
+            }
+        }
+        * { box-sizing: border-box; margin: 0; padding: 0; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, sans-serif;
+            background: var(--bg);
+            color: var(--text);
+            line-height: 1.6;
+            padding: 2rem;
+        }
+        .container { max-width: 1200px; margin: 0 auto; }
+        h1 { font-size: 2rem; font-weight: 600; margin-bottom: 0.25rem; }
+        .timestamp { color: var(--muted); font-size: 0.875rem; margin-bottom: 1.5rem; }
+        .summary { display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 1rem; margin-bottom: 2rem; }
+        .summary-card {
+            background: var(--card);
+            border: 1px solid var(--border);
+            border-radius: 0.5rem;
+            padding: 1rem 1.25rem;
+        }
+        .summary-card.pass { border-left: 4px solid var(--pass); }
+        .summary-card.fail { border-left: 4px solid var(--fail); }
+        .summary-label { color: var(--muted); font-size: 0.75rem; text-transform: uppercase; letter-spacing: 0.05em; }
+        .summary-value { font-size: 1.5rem; font-weight: 600; }
+        .summary-value.pass { color: var(--pass); }
+        .summary-value.fail { color: var(--fail); }
+        .requirement { color: var(--muted); font-size: 0.875rem; }
+        .empty { color: var(--muted); }
+        details.source {
+            background: var(--card);
+            border: 1px solid var(--border);
+            border-radius: 0.5rem;
+            margin-bottom: 0.75rem;
+        }
+        details.source summary { padding: 0.75rem 1rem; cursor: pointer; }
+        details.source summary span { color: var(--muted); font-size: 0.875rem; }
+        details.source pre {
+            overflow-x: auto;
+            border-top: 1px solid var(--border);
+            font-size: 0.8125rem;
+            line-height: 1.5;
+        }
+        .hunk + .hunk { border-top: 1px dashed var(--border); }
+        .src { display: block; padding: 0 1rem; white-space: pre; }
+        .src.changed { background: var(--changed-bg); }
+        .src.miss { background: var(--miss-bg); }
+        .src.hit { background: var(--hit-bg); }
+        .src .ln {
+            display: inline-block;
+            min-width: 3.5rem;
+            color: var(--muted);
+            user-select: none;
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1>{{.Title}}</h1>
+        <div class="timestamp">Changes since <code>{{.Base}}</code> · generated {{.Timestamp}}</div>
+
+        <div class="summary">
+            <div class="summary-card {{if eq .Patch.Status "FAIL"}}fail{{else}}pass{{end}}">
+                <div class="summary-label">Patch Coverage</div>
+                <div class="summary-value {{if eq .Patch.Status "FAIL"}}fail{{else}}pass{{end}}">{{printf "%.1f" .Patch.Percent}}%</div>
+                {{if or .Patch.Required .Patch.MaxUncovered}}<div class="requirement">{{.Patch.Status}}: {{.Patch.Requirement}}</div>{{end}}
+            </div>
+            <div class="summary-card">
+                <div class="summary-label">Changed Lines Covered</div>
+                <div class="summary-value">{{.Patch.Covered}} / {{.Patch.Total}}</div>
+            </div>
+            <div class="summary-card">
+                <div class="summary-label">Uncovered Changed Lines</div>
+                <div class="summary-value">{{len .Patch.Uncovered}}</div>
+            </div>
+            <div class="summary-card">
+                <div class="summary-label">Files</div>
+                <div class="summary-value">{{len .Files}}</div>
+            </div>
+        </div>
+
+        {{range .Files}}
+        <details class="source" open>
+            <summary><code>{{.File}}</code> <span>{{printf "%.1f" .Percent}}% · {{.Covered}}/{{.Total}} changed lines covered</span></summary>
+            {{if .Hunks}}<pre>{{range .Hunks}}<div class="hunk">{{range .}}<span class="src {{.Class}}"><span class="ln">{{.Number}}</span>{{.Text}}</span>{{end}}</div>{{end}}</pre>{{end}}
+        </details>
+        {{else}}
+        <p class="empty">No changed lines are executable code covered by the profile.</p>
+        {{end}}
+    </div>
+</body>
+</html>`
+
+// defaultPatchTitle is the page title when --title is not given.
+const defaultPatchTitle = "Patch Coverage"
+
+type patchData struct {
+	Title     string
+	Timestamp string
+	Base      string
+	Patch     domain.PatchResult
+	Files     []patchFileView
+}
+
+type patchFileView struct {
+	domain.PatchFile
+	Hunks [][]htmlSourceLine // Nil when the source cannot be read
+}
+
+// WritePatchReport renders coverage of changed lines as a standalone HTML
+// page: a summary header, then each changed file's hunks with covered
+// changed lines green, uncovered ones red, and a few lines of context.
+func WritePatchReport(w io.Writer, result application.PatchReportResult, title string) error {
+	tmpl, err := template.New("patch").Parse(patchTemplate)
+	if err != nil {
+		return err
+	}
+	data := patchData{
+		Title:     title,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Base:      result.Base,
+		Patch:     result.Patch,
+	}
+	if data.Title == "" {
+		data.Title = defaultPatchTitle
+	}
+	for _, f := range result.Files {
+		data.Files = append(data.Files, patchFileView{PatchFile: f, Hunks: patchHunks(f, result.SourceRoot)})
+	}
+	return tmpl.Execute(w, data)
+}
+
+// patchHunks cuts the changed ranges of a file, with context, out of its
+// source under root.
+func patchHunks(f domain.PatchFile, root string) [][]htmlSourceLine {
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(f.File)))
+	if err != nil {
+		return nil
+	}
+	src := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var hunks [][]htmlSourceLine
+	for _, r := range snippetRanges(append([]domain.LineRange(nil), f.Changed...), len(src)) {
+		hunk := make([]htmlSourceLine, 0, r.End-r.Start+1)
+		for n := r.Start; n <= r.End; n++ {
+			line := htmlSourceLine{Number: n, Text: src[n-1]}
+			if changedLine(n, f.Changed) {
+				line.Class = "changed"
+				if hits, ok := f.Lines[n]; ok {
+					line.Class = "hit"
+					if hits == 0 {
+						line.Class = "miss"
+					}
+				}
+			}
+			hunk = append(hunk, line)
+		}
+		hunks = append(hunks, hunk)
+	}
+	return hunks
+}
+
+func changedLine(n int, ranges []domain.LineRange) bool {
+	for _, r := range ranges {
+		if r.Contains(n) {
+			return true
+		}
+	}
+	return false
+}
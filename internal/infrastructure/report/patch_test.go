@@ -0,0 +1,68 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestWritePatchReport(t *testing.T) {
+	root := t.TempDir()
+	var src strings.Builder
+	for i := 1; i <= 20; i++ {
+		src.WriteString("line" + strings.Repeat("x", i) + "\n")
+	}
+	if err := os.MkdirAll(filepath.Join(root, "core"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "core", "a.go"), []byte(src.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	minimum := 80.0
+	changed := map[string][]domain.LineRange{"core/a.go": {{Start: 10, End: 12}}, "gone.go": {{Start: 1, End: 1}}}
+	lines := map[string]domain.LineCoverage{"core/a.go": {10: 1, 11: 0, 15: 0}, "gone.go": {1: 1}}
+	result := application.PatchReportResult{
+		Base:       "origin/main",
+		Patch:      domain.EvaluatePatch(changed, lines, minimum, domain.RoundingRound),
+		Files:      domain.PatchFiles(changed, lines),
+		SourceRoot: root,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := WritePatchReport(buf, result, ""); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<title>Patch Coverage</title>",
+		"<code>origin/main</code>",
+		"66.7%",
+		"FAIL: required 80.0%",
+		`<span class="src hit"><span class="ln">10</span>`,
+		`<span class="src miss"><span class="ln">11</span>`,
+		`<span class="src changed"><span class="ln">12</span>`,
+		`<span class="src "><span class="ln">8</span>`,
+		"<code>gone.go</code>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output", want)
+		}
+	}
+	if strings.Contains(out, `<span class="ln">15</span>`) || strings.Contains(out, `<span class="ln">7</span>`) {
+		t.Error("expected only changed lines and their context")
+	}
+
+	buf.Reset()
+	if err := WritePatchReport(buf, application.PatchReportResult{Base: "HEAD~1", Patch: domain.EvaluatePatch(nil, nil, 0, domain.RoundingRound)}, "PR <42>"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<title>PR &lt;42&gt;</title>") || !strings.Contains(buf.String(), "No changed lines") {
+		t.Fatalf("unexpected empty report:\n%s", buf.String())
+	}
+}
@@ -4,14 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
-	"github.com/charmbracelet/lipgloss"
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/domain"
-	"github.com/mattn/go-isatty"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/theme"
 )
 
 type Writer struct{}
@@ -19,26 +18,19 @@ type Writer struct{}
 func (Writer) Write(w io.Writer, result domain.Result, format application.OutputFormat) error {
 	switch format {
 	case application.OutputJSON:
-		payload := struct {
-			Domains []domain.DomainResult `json:"domains"`
-			Files   []domain.FileResult   `json:"files,omitempty"`
-			Summary struct {
-				Pass bool `json:"pass"`
-			} `json:"summary"`
-			Warnings []string `json:"warnings,omitempty"`
-		}{
-			Domains: result.Domains,
-			Files:   result.Files,
-		}
-		payload.Summary.Pass = result.Passed
-		payload.Warnings = result.Warnings
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
-		return enc.Encode(payload)
+		return enc.Encode(newJSONPayload(result))
 	case application.OutputHTML:
-		return writeHTML(w, result)
+		return writeHTML(w, result, application.HTMLOptions{})
 	case application.OutputBrief:
 		return writeBrief(w, result)
+	case application.OutputMarkdown:
+		return writeMarkdown(w, result)
+	case application.OutputGitLab, application.OutputAzure:
+		return writeCobertura(w, result, format)
+	case application.OutputCSV, application.OutputTSV:
+		return writeCSV(w, result, format)
 	case application.OutputText, "":
 		return writeText(w, result)
 	default:
@@ -46,6 +38,59 @@ func (Writer) Write(w io.Writer, result domain.Result, format application.Output
 	}
 }
 
+// jsonPayload is the --output json document. Field order is fixed by the
+// struct; domains, files, and warnings are always arrays, and the optional
+// sections appear only when their feature produced data.
+type jsonPayload struct {
+	Schema    string                  `json:"schema"`
+	Domains   []domain.DomainResult   `json:"domains"`
+	Groups    []domain.GroupResult    `json:"groups,omitempty"`
+	Files     []domain.FileResult     `json:"files"`
+	Patch     *domain.PatchResult     `json:"patch,omitempty"`
+	NewCode   *domain.NewCodeResult   `json:"new_code,omitempty"`
+	Functions *domain.FunctionsResult `json:"functions,omitempty"`
+	Summary   struct {
+		Pass   bool           `json:"pass"`
+		Health *domain.Health `json:"health,omitempty"`
+	} `json:"summary"`
+	Warnings     []string                 `json:"warnings"`
+	Deltas       []domain.DomainDelta     `json:"deltas,omitempty"`
+	EmptyDomains []string                 `json:"empty_domains,omitempty"`
+	NewDomains   []string                 `json:"new_domains,omitempty"`
+	TopFiles     []domain.UncoveredFile   `json:"top_files,omitempty"`
+	Timing       []domain.DomainTiming    `json:"timing,omitempty"`
+	Exceptions   []domain.ExceptionResult `json:"exceptions,omitempty"`
+}
+
+// newJSONPayload copies result into the JSON layout, sorting domains,
+// groups, and deltas by name and files by path so output is stable
+// regardless of config order.
+func newJSONPayload(result domain.Result) jsonPayload {
+	payload := jsonPayload{
+		Schema:       application.JSONSchema,
+		Domains:      append([]domain.DomainResult{}, result.Domains...),
+		Groups:       append([]domain.GroupResult(nil), result.Groups...),
+		Files:        append([]domain.FileResult{}, result.Files...),
+		Patch:        result.Patch,
+		NewCode:      result.NewCode,
+		Functions:    result.Functions,
+		Warnings:     append([]string{}, result.Warnings...),
+		Deltas:       append([]domain.DomainDelta(nil), result.Deltas...),
+		EmptyDomains: result.EmptyDomains,
+		NewDomains:   result.NewDomains,
+		TopFiles:     result.TopFiles,
+		Timing:       result.Timing,
+		Exceptions:   result.Exceptions,
+	}
+	payload.Summary.Pass = result.Passed
+	payload.Summary.Health = result.Health
+	sort.SliceStable(payload.Domains, func(i, j int) bool { return payload.Domains[i].Domain < payload.Domains[j].Domain })
+	sort.SliceStable(payload.Groups, func(i, j int) bool { return payload.Groups[i].Group < payload.Groups[j].Group })
+	sort.SliceStable(payload.Files, func(i, j int) bool { return payload.Files[i].File < payload.Files[j].File })
+	sort.SliceStable(payload.Deltas, func(i, j int) bool { return payload.Deltas[i].Domain < payload.Deltas[j].Domain })
+	return payload
+}
+
 func writeText(w io.Writer, result domain.Result) error {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 
@@ -64,26 +109,11 @@ func writeText(w io.Writer, result domain.Result) error {
 		_, _ = fmt.Fprintln(tw, "Domain\tCoverage\tRequired\tStatus")
 	}
 
-	colorize := colorEnabled(w)
-	passStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#16A34A")).Bold(true)
-	failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#DC2626")).Bold(true)
-	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#CA8A04")).Bold(true)
-	deltaUpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#16A34A"))
-	deltaDownStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#DC2626"))
+	th := theme.For(w)
 
 	var failedDomains []domain.DomainResult
 	for _, d := range result.Domains {
-		statusText := string(d.Status)
-		if colorize {
-			switch d.Status {
-			case domain.StatusPass:
-				statusText = passStyle.Render(statusText)
-			case domain.StatusFail:
-				statusText = failStyle.Render(statusText)
-			case domain.StatusWarn:
-				statusText = warnStyle.Render(statusText)
-			}
-		}
+		statusText := th.Status(d.Status)
 		if d.Status == domain.StatusFail {
 			failedDomains = append(failedDomains, d)
 			statusText = fmt.Sprintf("%s (%+.1f%%)", statusText, d.Percent-d.Required)
@@ -92,14 +122,7 @@ func writeText(w io.Writer, result domain.Result) error {
 		if hasDeltas {
 			deltaStr := "-"
 			if d.Delta != nil {
-				deltaStr = fmt.Sprintf("%+.1f%%", *d.Delta)
-				if colorize {
-					if *d.Delta > 0 {
-						deltaStr = deltaUpStyle.Render(deltaStr)
-					} else if *d.Delta < 0 {
-						deltaStr = deltaDownStyle.Render(deltaStr)
-					}
-				}
+				deltaStr = th.Delta(*d.Delta)
 			}
 			_, _ = fmt.Fprintf(tw, "%s\t%.1f%%\t%s\t%.1f%%\t%s\n", d.Domain, d.Percent, deltaStr, d.Required, statusText)
 		} else {
@@ -109,29 +132,44 @@ func writeText(w io.Writer, result domain.Result) error {
 	if err := tw.Flush(); err != nil {
 		return err
 	}
+	if err := writeGroupsText(w, result.Groups, th); err != nil {
+		return err
+	}
+	if err := writeSourcesText(w, result.Domains); err != nil {
+		return err
+	}
 	if len(result.Files) > 0 {
 		fmt.Fprintln(w, "\nFile rules:")
 		ftw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 		_, _ = fmt.Fprintln(ftw, "File\tCoverage\tRequired\tStatus")
-		colorize := colorEnabled(w)
-		passStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#16A34A")).Bold(true)
-		failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#DC2626")).Bold(true)
 		for _, f := range result.Files {
-			status := string(f.Status)
-			if colorize {
-				switch f.Status {
-				case domain.StatusPass:
-					status = passStyle.Render(status)
-				case domain.StatusFail:
-					status = failStyle.Render(status)
-				}
-			}
-			_, _ = fmt.Fprintf(ftw, "%s\t%.1f%%\t%.1f%%\t%s\n", f.File, f.Percent, f.Required, status)
+			_, _ = fmt.Fprintf(ftw, "%s\t%.1f%%\t%.1f%%\t%s\n", f.File, f.Percent, f.Required, th.Status(f.Status))
 		}
 		if err := ftw.Flush(); err != nil {
 			return err
 		}
 	}
+	if err := writeTopFilesText(w, result.TopFiles); err != nil {
+		return err
+	}
+	if err := writeTimingText(w, result.Timing); err != nil {
+		return err
+	}
+	if err := writeExceptionsText(w, result.Exceptions); err != nil {
+		return err
+	}
+	if result.Patch != nil {
+		writePatchText(w, *result.Patch)
+	}
+	if result.NewCode != nil {
+		writeNewCodeText(w, *result.NewCode)
+	}
+	if result.Functions != nil {
+		writeFunctionsText(w, *result.Functions)
+	}
+	if result.Health != nil {
+		writeHealthText(w, *result.Health)
+	}
 	if len(result.Warnings) > 0 {
 		fmt.Fprintln(w, "\nWarnings:")
 		for _, warn := range result.Warnings {
@@ -139,62 +177,221 @@ func writeText(w io.Writer, result domain.Result) error {
 		}
 	}
 
-	writeNextActionFooter(w, result, failedDomains, colorize)
+	writeNextActionFooter(w, result, failedDomains, th)
 	return nil
 }
 
+// writeGroupsText prints per-group subtotals below the domain table. A
+// group without a minimum of its own shows "-" as required.
+func writeGroupsText(w io.Writer, groups []domain.GroupResult, th theme.Theme) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "\nGroups:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Group\tCoverage\tRequired\tStatus\tDomains")
+	for _, g := range groups {
+		required := "-"
+		if g.Required != nil {
+			required = fmt.Sprintf("%.1f%%", *g.Required)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%.1f%%\t%s\t%s\t%d\n", g.Group, g.Percent, required, th.Status(g.Status), len(g.Domains))
+	}
+	return tw.Flush()
+}
+
+// writeTopFilesText lists the files with the most uncovered statements in
+// failing domains, worst first, so the next test to write is obvious.
+func writeTopFilesText(w io.Writer, files []domain.UncoveredFile) error {
+	if len(files) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "\nTop files by uncovered statements (failing domains):")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "File\tUncovered\tCoverage\tDomains")
+	for _, f := range files {
+		_, _ = fmt.Fprintf(tw, "%s\t%d/%d\t%.1f%%\t%s\n", f.File, f.Uncovered, f.Total, f.Percent, strings.Join(f.Domains, ", "))
+	}
+	return tw.Flush()
+}
+
+// writeTimingText lists test seconds per domain and what each point of
+// coverage costs, most expensive domain first.
+func writeTimingText(w io.Writer, timing []domain.DomainTiming) error {
+	if len(timing) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "\nTest time by domain:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Domain\tSeconds\tCoverage\tSec/pt")
+	for _, t := range timing {
+		_, _ = fmt.Fprintf(tw, "%s\t%.1f\t%.1f%%\t%s\n", t.Domain, t.Seconds, t.Percent, secondsPerPoint(t))
+	}
+	return tw.Flush()
+}
+
+// writeExceptionsText lists every configured policy exception so an
+// exemption stays visible in each run until it is removed.
+func writeExceptionsText(w io.Writer, exceptions []domain.ExceptionResult) error {
+	if len(exceptions) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "\nPolicy exceptions:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Target\tExpires\tApprover\tState\tReason")
+	for _, e := range exceptions {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", exceptionTarget(e), e.Expires, e.Approver, exceptionState(e), e.Reason)
+	}
+	return tw.Flush()
+}
+
+func exceptionTarget(e domain.ExceptionResult) string {
+	if e.File != "" {
+		return e.File
+	}
+	return e.Domain
+}
+
+// exceptionState is "expired", "applied" when the exception turned a
+// failure into a warning, or "unused" when nothing needed it this run.
+func exceptionState(e domain.ExceptionResult) string {
+	switch {
+	case e.Expired:
+		return "expired"
+	case e.Applied:
+		return "applied"
+	default:
+		return "unused"
+	}
+}
+
+// secondsPerPoint formats a domain's seconds per coverage point, or "-"
+// when it has no coverage to divide by.
+func secondsPerPoint(t domain.DomainTiming) string {
+	if t.Percent == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", t.SecondsPerPercent)
+}
+
+// writeSourcesText prints each domain's coverage split by profile source,
+// e.g. "unit 72.0%  integration +9.0%  combined 81.0%".
+func writeSourcesText(w io.Writer, domains []domain.DomainResult) error {
+	var rows []domain.DomainResult
+	for _, d := range domains {
+		if len(d.Sources) > 0 {
+			rows = append(rows, d)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "\nCoverage by source:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, d := range rows {
+		_, _ = fmt.Fprintf(tw, "  %s", d.Domain)
+		for i, src := range d.Sources {
+			if i == 0 {
+				_, _ = fmt.Fprintf(tw, "\t%s %.1f%%", src.Source, src.Percent)
+			} else {
+				_, _ = fmt.Fprintf(tw, "\t%s %+.1f%%", src.Source, src.Added)
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "\tcombined %.1f%%\n", d.Percent)
+	}
+	return tw.Flush()
+}
+
+// maxPatchLinesShown caps the uncovered changed lines listed in text output.
+const maxPatchLinesShown = 20
+
+// writePatchText prints coverage of changed lines and lists uncovered ones
+// as file:line so editors and CI logs can link to them.
+func writePatchText(w io.Writer, patch domain.PatchResult) {
+	fmt.Fprintf(w, "\nPatch coverage: %.1f%% of %d changed lines (%s) %s\n",
+		patch.Percent, patch.Total, patch.Requirement(), patch.Status)
+	writeUncoveredLines(w, patch.Uncovered)
+}
+
+// writeNewCodeText prints coverage of lines changed within the new code
+// window and lists the uncovered ones.
+func writeNewCodeText(w io.Writer, newCode domain.NewCodeResult) {
+	fmt.Fprintf(w, "\nNew code coverage: %.1f%% of %d lines changed in the last %s (required %.1f%%) %s\n",
+		newCode.Percent, newCode.Total, newCode.Since, newCode.Required, newCode.Status)
+	writeUncoveredLines(w, newCode.Uncovered)
+}
+
+// writeFunctionsText prints the share of functions tests reached and lists
+// the functions they missed.
+func writeFunctionsText(w io.Writer, functions domain.FunctionsResult) {
+	fmt.Fprintf(w, "\nFunction coverage: %.1f%% of %d functions (required %.1f%%) %s\n",
+		functions.Percent, functions.Total, functions.Required, functions.Status)
+	for i, fn := range functions.Uncovered {
+		if i == maxPatchLinesShown {
+			fmt.Fprintf(w, "  ... and %d more uncovered functions\n", len(functions.Uncovered)-maxPatchLinesShown)
+			break
+		}
+		fmt.Fprintf(w, "  %s\n", fn)
+	}
+}
+
+// writeHealthText prints the health KPI on one line.
+func writeHealthText(w io.Writer, h domain.Health) {
+	fmt.Fprintf(w, "\nHealth: %.1f%% (domains: %d passing, %d failing; shortfall: %.1f points; events: %d)\n",
+		h.Score, h.PassingDomains, h.FailingDomains, h.Shortfall, h.Events)
+}
+
+func writeUncoveredLines(w io.Writer, lines []domain.UncoveredLine) {
+	for i, u := range lines {
+		if i == maxPatchLinesShown {
+			fmt.Fprintf(w, "  ... and %d more uncovered lines\n", len(lines)-maxPatchLinesShown)
+			break
+		}
+		fmt.Fprintf(w, "  %s\n", u)
+	}
+}
+
 // writeNextActionFooter prints a Peak-End summary line and a short
 // next-action hint after the domain table. The hint depends on whether
 // any domain failed; the goal is to leave the user with one obvious next
 // command rather than a flat table.
-func writeNextActionFooter(w io.Writer, result domain.Result, failedDomains []domain.DomainResult, colorize bool) {
+func writeNextActionFooter(w io.Writer, result domain.Result, failedDomains []domain.DomainResult, th theme.Theme) {
 	if len(result.Domains) == 0 {
 		return
 	}
 	total := len(result.Domains)
-	passStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#16A34A")).Bold(true)
-	failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#DC2626")).Bold(true)
 	if !result.Passed && len(failedDomains) > 0 {
-		mark := "x"
-		if colorize {
-			mark = failStyle.Render("✗")
-		}
+		mark := th.Fail("✗", "x")
 		fmt.Fprintf(w, "\n%s %d of %d domains below threshold.\n", mark, len(failedDomains), total)
 		first := failedDomains[0].Domain
 		fmt.Fprintf(w, "  → coverctl suggest %s    show uncovered files in failing domain\n", first)
 		fmt.Fprintln(w, "  → coverctl debt           list smallest tests to add")
 		return
 	}
-	mark := "v"
-	if colorize {
-		mark = passStyle.Render("✓")
+	var failedGroups []string
+	for _, g := range result.Groups {
+		if g.Status == domain.StatusFail {
+			failedGroups = append(failedGroups, g.Group)
+		}
+	}
+	if !result.Passed && len(failedGroups) > 0 {
+		mark := th.Fail("✗", "x")
+		fmt.Fprintf(w, "\n%s %d of %d groups below threshold: %s.\n", mark, len(failedGroups), len(result.Groups), strings.Join(failedGroups, ", "))
+		fmt.Fprintln(w, "  → coverctl debt           list smallest tests to add")
+		return
 	}
+	mark := th.Pass("✓", "v")
 	fmt.Fprintf(w, "\n%s All %d domain(s) pass.\n", mark, total)
 	fmt.Fprintln(w, "  → coverctl record         save coverage baseline for next run")
 }
 
-func colorEnabled(w io.Writer) bool {
-	if os.Getenv("NO_COLOR") != "" {
-		return false
-	}
-	file, ok := w.(*os.File)
-	if !ok {
-		return false
-	}
-	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
-}
-
 // writeBrief outputs a single-line summary optimized for LLM/agent consumption.
 // Format: STATUS | XX.X% overall | N/M domains passing [| failing: domain1 (XX.X%), domain2 (XX.X%)]
 func writeBrief(w io.Writer, result domain.Result) error {
-	// Calculate overall coverage
-	var totalCovered, totalStatements int
 	var passing, failing int
 	var failedDomains []domain.DomainResult
 
 	for _, d := range result.Domains {
-		totalCovered += d.Covered
-		totalStatements += d.Total
 		if d.Status == domain.StatusFail {
 			failing++
 			failedDomains = append(failedDomains, d)
@@ -203,10 +400,7 @@ func writeBrief(w io.Writer, result domain.Result) error {
 		}
 	}
 
-	overall := 0.0
-	if totalStatements > 0 {
-		overall = float64(totalCovered) / float64(totalStatements) * 100
-	}
+	overall := result.OverallPercent()
 
 	status := "PASS"
 	if !result.Passed {
@@ -2,6 +2,7 @@ package report
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -73,6 +74,136 @@ func TestWriteWarningsJSON(t *testing.T) {
 	}
 }
 
+func TestWriteEmptyDomainsFieldJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	res := domain.Result{
+		Passed:       true,
+		EmptyDomains: []string{"api"},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\"empty_domains\": [\n    \"api\"\n  ]") {
+		t.Fatalf("expected empty_domains field, got %s", buf.String())
+	}
+}
+
+func TestWriteNewDomainsFieldJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	res := domain.Result{
+		Passed:     true,
+		NewDomains: []string{"billing"},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\"new_domains\": [\n    \"billing\"\n  ]") {
+		t.Fatalf("expected new_domains field, got %s", buf.String())
+	}
+}
+
+func TestWriteGroupsText(t *testing.T) {
+	min := 80.0
+	res := domain.Result{
+		Passed:  false,
+		Domains: []domain.DomainResult{{Domain: "api", Percent: 70, Required: 50, Status: domain.StatusPass}},
+		Groups: []domain.GroupResult{
+			{Group: "backend", Domains: []string{"api"}, Percent: 70, Required: &min, Status: domain.StatusFail},
+			{Group: "frontend", Domains: []string{"web"}, Percent: 90, Status: domain.StatusPass},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Groups:", "backend   70.0%     80.0%     FAIL", "frontend  90.0%     -", "1 of 2 groups below threshold: backend."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteTopFiles(t *testing.T) {
+	res := domain.Result{
+		Domains: []domain.DomainResult{{Domain: "core", Percent: 40, Required: 80, Status: domain.StatusFail}},
+		TopFiles: []domain.UncoveredFile{
+			{File: "internal/core/a.go", Domains: []string{"core"}, Covered: 2, Total: 10, Uncovered: 8, Percent: 20},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, want := range []string{"Top files by uncovered statements (failing domains):", "internal/core/a.go  8/10       20.0%     core"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\"top_files\"") {
+		t.Fatalf("expected top_files field, got %s", buf.String())
+	}
+}
+
+func TestWriteTiming(t *testing.T) {
+	res := domain.Result{
+		Domains: []domain.DomainResult{{Domain: "core", Percent: 50, Status: domain.StatusPass}},
+		Timing: []domain.DomainTiming{
+			{Domain: "core", Seconds: 12, Percent: 50, SecondsPerPercent: 0.24},
+			{Domain: "gen", Seconds: 3, Percent: 0},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, want := range []string{"Test time by domain:", "core    12.0     50.0%     0.24", "gen     3.0      0.0%      -"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"seconds_per_percent": 0.24`) {
+		t.Fatalf("expected timing field, got %s", buf.String())
+	}
+}
+
+func TestWriteExceptions(t *testing.T) {
+	res := domain.Result{
+		Domains: []domain.DomainResult{{Domain: "billing", Percent: 40, Required: 80, Status: domain.StatusWarn}},
+		Exceptions: []domain.ExceptionResult{
+			{Domain: "billing", Reason: "rewrite", Approver: "alice", Expires: "2026-03-31", Applied: true},
+			{File: "internal/legacy/*.go", Reason: "removal", Approver: "bob", Expires: "2025-12-31", Expired: true},
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, want := range []string{"Policy exceptions:", "billing               2026-03-31  alice     applied  rewrite", "internal/legacy/*.go  2025-12-31  bob       expired  removal"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, res, application.OutputMarkdown); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "| `billing` | 2026-03-31 | alice | applied | rewrite |") {
+		t.Fatalf("expected exceptions table, got %s", buf.String())
+	}
+}
+
 func TestWriteFileRulesText(t *testing.T) {
 	buf := new(bytes.Buffer)
 	res := domain.Result{
@@ -111,6 +242,75 @@ func TestWriteFileRulesJSON(t *testing.T) {
 	}
 }
 
+func TestWritePatchText(t *testing.T) {
+	buf := new(bytes.Buffer)
+	uncovered := make([]domain.UncoveredLine, 0, maxPatchLinesShown+2)
+	for i := 0; i < maxPatchLinesShown+2; i++ {
+		uncovered = append(uncovered, domain.UncoveredLine{File: "internal/core/a.go", Line: 10 + i})
+	}
+	res := domain.Result{
+		Patch: &domain.PatchResult{Covered: 3, Total: 25, Percent: 12, Required: 80, Status: domain.StatusFail, Uncovered: uncovered},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Patch coverage: 12.0% of 25 changed lines") {
+		t.Fatalf("expected patch summary, got: %s", out)
+	}
+	if !strings.Contains(out, "internal/core/a.go:10") || !strings.Contains(out, "and 2 more uncovered lines") {
+		t.Fatalf("expected capped file:line list, got: %s", out)
+	}
+}
+
+func TestWritePatchJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	res := domain.Result{
+		Patch: &domain.PatchResult{Percent: 50, Required: 80, Status: domain.StatusFail, Uncovered: []domain.UncoveredLine{{File: "a.go", Line: 3}}},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\"patch\"") || !strings.Contains(buf.String(), "\"line\": 3") {
+		t.Fatalf("expected patch field, got: %s", buf.String())
+	}
+}
+
+func TestWriteNewCodeTextAndJSON(t *testing.T) {
+	res := domain.Result{
+		NewCode: &domain.NewCodeResult{
+			PatchResult: domain.PatchResult{Covered: 8, Total: 10, Percent: 80, Required: 90, Status: domain.StatusFail, Uncovered: []domain.UncoveredLine{{File: "a.go", Line: 7}}},
+			Since:       "90d",
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "New code coverage: 80.0% of 10 lines changed in the last 90d (required 90.0%) FAIL") || !strings.Contains(buf.String(), "a.go:7") {
+		t.Fatalf("expected new code summary, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"new_code"`) || !strings.Contains(buf.String(), `"since": "90d"`) {
+		t.Fatalf("expected new_code field, got: %s", buf.String())
+	}
+}
+
+func TestWriteHealthText(t *testing.T) {
+	res := domain.Result{Health: &domain.Health{Score: 75, PassingDomains: 3, FailingDomains: 1, Shortfall: 12.5, Events: 2}}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Health: 75.0% (domains: 3 passing, 1 failing; shortfall: 12.5 points; events: 2)") {
+		t.Fatalf("expected health line, got: %s", buf.String())
+	}
+}
+
 func TestWriteUnsupportedFormat(t *testing.T) {
 	buf := new(bytes.Buffer)
 	res := domain.Result{Passed: true}
@@ -455,3 +655,74 @@ func TestWriteBriefEmpty(t *testing.T) {
 		t.Fatalf("expected 0/0 domains, got: %q", output)
 	}
 }
+
+func TestWriteDeltasJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	res := domain.Result{
+		Passed:  true,
+		Domains: []domain.DomainResult{{Domain: "core", Percent: 80, Status: domain.StatusPass}},
+		Deltas:  []domain.DomainDelta{{Domain: "core", Previous: 75, Current: 80, Delta: 5, Trend: domain.TrendUp}},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var payload struct {
+		Deltas []domain.DomainDelta `json:"deltas"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(payload.Deltas) != 1 || payload.Deltas[0].Trend != domain.TrendUp || payload.Deltas[0].Previous != 75 {
+		t.Fatalf("unexpected deltas: %+v", payload.Deltas)
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, domain.Result{Passed: true}, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if strings.Contains(buf.String(), "deltas") {
+		t.Fatal("expected no deltas block without history")
+	}
+}
+
+func TestWriteSourcesText(t *testing.T) {
+	buf := new(bytes.Buffer)
+	res := domain.Result{
+		Passed: true,
+		Domains: []domain.DomainResult{{Domain: "core", Percent: 81, Required: 80, Status: domain.StatusPass, Sources: []domain.SourceCoverage{
+			{Source: "unit", Percent: 72, Added: 72},
+			{Source: "integration", Percent: 40, Added: 9},
+		}}},
+	}
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "Coverage by source:") {
+		t.Fatalf("expected source section, got:\n%s", output)
+	}
+	for _, want := range []string{"unit 72.0%", "integration +9.0%", "combined 81.0%"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteFunctionsTextAndJSON(t *testing.T) {
+	res := domain.Result{Functions: &domain.FunctionsResult{Covered: 3, Total: 4, Percent: 75, Required: 80, Status: domain.StatusFail, Uncovered: []string{"pkg/a.go:Run()"}}}
+	buf := new(bytes.Buffer)
+	if err := (Writer{}).Write(buf, res, application.OutputText); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Function coverage: 75.0% of 4 functions (required 80.0%) FAIL") || !strings.Contains(buf.String(), "pkg/a.go:Run()") {
+		t.Fatalf("expected function coverage summary, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := (Writer{}).Write(buf, res, application.OutputJSON); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"functions"`) || !strings.Contains(buf.String(), `"uncovered": [`) {
+		t.Fatalf("expected functions field, got: %s", buf.String())
+	}
+}
@@ -59,6 +59,10 @@ func (f *fakeRegistry) DetectRunner(dir string) (application.CoverageRunner, err
 	return f.runner, f.err
 }
 
+func (f *fakeRegistry) GetRunnerByName(name string) (application.CoverageRunner, error) {
+	return f.runner, f.err
+}
+
 func (f *fakeRegistry) SupportedLanguages() []application.Language {
 	return []application.Language{application.LanguageGo, application.LanguagePython}
 }
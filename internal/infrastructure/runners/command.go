@@ -0,0 +1,59 @@
+package runners
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/cmdrun"
+)
+
+// profilePlaceholder in a domain's test_command is replaced with the path
+// the command must write its coverage profile to.
+const profilePlaceholder = "{profile}"
+
+// RunCommand runs a domain's test_command in the project directory. The
+// command is executed directly, not through a shell. The profile path is
+// substituted for {profile} in its arguments and exported as
+// COVERCTL_PROFILE; the command must leave a profile there.
+func (r *Registry) RunCommand(ctx context.Context, command []string, profilePath string) (string, error) {
+	if len(command) == 0 {
+		return "", fmt.Errorf("test_command is empty")
+	}
+	dir := r.dir()
+	profile := profilePath
+	if !filepath.IsAbs(profile) {
+		profile = filepath.Join(dir, profile)
+	}
+	if err := os.MkdirAll(filepath.Dir(profile), 0o750); err != nil {
+		return "", err
+	}
+	// A stale profile from an earlier run must not pass for this one's.
+	if err := os.Remove(profile); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	args := make([]string, 0, len(command)-1)
+	for _, arg := range command[1:] {
+		args = append(args, strings.ReplaceAll(arg, profilePlaceholder, profile))
+	}
+	env := append(os.Environ(), "COVERCTL_PROFILE="+profile)
+	execFn := r.execCommand
+	if execFn == nil {
+		execFn = func(ctx context.Context, dir string, env []string, name string, args []string) error {
+			return cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr, Env: env}.Exec(ctx, dir, name, args)
+		}
+	}
+	if err := execFn(ctx, dir, env, command[0], args); err != nil {
+		return "", fmt.Errorf("%s failed: %w", command[0], err)
+	}
+	if _, err := os.Stat(profile); err != nil {
+		return "", fmt.Errorf("%s did not write a coverage profile to %s (use %s or $COVERCTL_PROFILE)", command[0], profile, profilePlaceholder)
+	}
+	return profile, nil
+}
+
+var _ application.CommandRunner = (*Registry)(nil)
@@ -13,12 +13,27 @@ import (
 )
 
 // NodeRunner implements CoverageRunner for Node.js/TypeScript projects.
-// Supports nyc, c8, and Jest.
+// Supports Vitest, Jest, c8, and nyc, run through the project's package
+// manager (npm, pnpm, or yarn).
 type NodeRunner struct {
-	// Exec overrides command execution (for testing).
+	// Exec overrides command execution (for testing). It receives the
+	// package manager binary and its full argument list.
 	Exec func(ctx context.Context, dir string, cmd string, args []string) error
 }
 
+// Node package managers, detected from lockfiles.
+const (
+	nodePMNpm  = "npm"
+	nodePMPnpm = "pnpm"
+	nodePMYarn = "yarn"
+)
+
+// vitestConfigs are the config file names Vitest looks for.
+var vitestConfigs = []string{
+	"vitest.config.ts", "vitest.config.mts", "vitest.config.cts",
+	"vitest.config.js", "vitest.config.mjs", "vitest.config.cjs",
+}
+
 // NewNodeRunner creates a new Node.js coverage runner.
 func NewNodeRunner() *NodeRunner {
 	return &NodeRunner{}
@@ -72,9 +87,10 @@ func (r *NodeRunner) Run(ctx context.Context, opts application.RunOptions) (stri
 		return "", err
 	}
 
-	// Detect which tool to use
+	// Detect which tool and package manager to use
 	tool := r.detectCoverageTool(cwd)
-	args := r.buildArgs(tool, opts, profile)
+	pm := detectPackageManager(cwd)
+	binary, args := nodeCommand(pm, tool, r.buildArgs(tool, pm, opts, profile))
 
 	execFn := r.Exec
 	if execFn == nil {
@@ -82,8 +98,8 @@ func (r *NodeRunner) Run(ctx context.Context, opts application.RunOptions) (stri
 	}
 
 	// Run coverage command
-	if err := execFn(ctx, cwd, tool, args); err != nil {
-		return "", fmt.Errorf("node coverage failed: %w", err)
+	if err := execFn(ctx, cwd, binary, args); err != nil {
+		return "", fmt.Errorf("node coverage failed (%s %s): %w", pm, tool, err)
 	}
 
 	return profile, nil
@@ -98,7 +114,15 @@ func (r *NodeRunner) RunIntegration(ctx context.Context, opts application.Integr
 }
 
 // detectCoverageTool determines which Node.js coverage tool is available.
+// A Vitest config file wins outright, since such projects rarely declare
+// another runner.
 func (r *NodeRunner) detectCoverageTool(projectDir string) string {
+	for _, name := range vitestConfigs {
+		if _, err := os.Stat(filepath.Join(projectDir, name)); err == nil {
+			return "vitest"
+		}
+	}
+
 	// Check package.json for hints
 	pkgPath := filepath.Join(projectDir, "package.json")
 	// #nosec G304 -- Path is constructed from trusted project directory
@@ -109,6 +133,10 @@ func (r *NodeRunner) detectCoverageTool(projectDir string) string {
 			DevDeps      map[string]string `json:"devDependencies"`
 		}
 		if json.Unmarshal(data, &pkg) == nil {
+			// Check for Vitest
+			if _, ok := pkg.DevDeps["vitest"]; ok {
+				return "vitest"
+			}
 			// Check for Jest
 			if _, ok := pkg.DevDeps["jest"]; ok {
 				return "jest"
@@ -139,20 +167,65 @@ func (r *NodeRunner) detectCoverageTool(projectDir string) string {
 	return "npm"
 }
 
+// detectPackageManager picks the package manager from the lockfile in
+// projectDir, defaulting to npm.
+func detectPackageManager(projectDir string) string {
+	lockfiles := []struct{ name, pm string }{
+		{"pnpm-lock.yaml", nodePMPnpm},
+		{"yarn.lock", nodePMYarn},
+		{"package-lock.json", nodePMNpm},
+	}
+	for _, lf := range lockfiles {
+		if _, err := os.Stat(filepath.Join(projectDir, lf.name)); err == nil {
+			return lf.pm
+		}
+	}
+	return nodePMNpm
+}
+
 // buildArgs builds command line arguments for the detected tool.
-func (r *NodeRunner) buildArgs(tool string, opts application.RunOptions, profile string) []string {
+func (r *NodeRunner) buildArgs(tool, pm string, opts application.RunOptions, profile string) []string {
 	switch tool {
+	case "vitest":
+		return r.buildVitestArgs(opts, profile)
 	case "jest":
 		return r.buildJestArgs(opts, profile)
 	case "c8":
-		return r.buildC8Args(opts, profile)
+		return r.buildC8Args(pm, opts, profile)
 	case "nyc":
-		return r.buildNycArgs(opts, profile)
+		return r.buildNycArgs(pm, opts, profile)
 	default:
 		return r.buildNpmArgs(opts, profile)
 	}
 }
 
+// buildVitestArgs builds command line arguments for Vitest. `vitest run`
+// disables watch mode, which Vitest otherwise enables in a terminal.
+func (r *NodeRunner) buildVitestArgs(opts application.RunOptions, profile string) []string {
+	args := []string{
+		"run",
+		"--coverage.enabled",
+		"--coverage.reporter=lcov",
+		"--coverage.reportsDirectory=" + filepath.Dir(profile),
+	}
+
+	if opts.BuildFlags.Verbose {
+		args = append(args, "--reporter=verbose")
+	}
+
+	if opts.BuildFlags.Run != "" {
+		args = append(args, "-t", opts.BuildFlags.Run)
+	}
+
+	if len(opts.Packages) > 0 {
+		args = append(args, opts.Packages...)
+	}
+
+	args = append(args, opts.BuildFlags.TestArgs...)
+
+	return args
+}
+
 // buildJestArgs builds command line arguments for Jest.
 func (r *NodeRunner) buildJestArgs(opts application.RunOptions, profile string) []string {
 	coverageDir := filepath.Dir(profile)
@@ -180,13 +253,13 @@ func (r *NodeRunner) buildJestArgs(opts application.RunOptions, profile string)
 }
 
 // buildC8Args builds command line arguments for c8.
-func (r *NodeRunner) buildC8Args(opts application.RunOptions, profile string) []string {
+func (r *NodeRunner) buildC8Args(pm string, opts application.RunOptions, profile string) []string {
 	coverageDir := filepath.Dir(profile)
 	args := []string{
 		"--reporter=lcov",
 		"--reporter=text",
 		"--report-dir=" + coverageDir,
-		"npm", "test",
+		pm, "test",
 	}
 
 	args = append(args, opts.BuildFlags.TestArgs...)
@@ -195,13 +268,13 @@ func (r *NodeRunner) buildC8Args(opts application.RunOptions, profile string) []
 }
 
 // buildNycArgs builds command line arguments for nyc.
-func (r *NodeRunner) buildNycArgs(opts application.RunOptions, profile string) []string {
+func (r *NodeRunner) buildNycArgs(pm string, opts application.RunOptions, profile string) []string {
 	coverageDir := filepath.Dir(profile)
 	args := []string{
 		"--reporter=lcov",
 		"--reporter=text",
 		"--report-dir=" + coverageDir,
-		"npm", "test",
+		pm, "test",
 	}
 
 	args = append(args, opts.BuildFlags.TestArgs...)
@@ -209,12 +282,11 @@ func (r *NodeRunner) buildNycArgs(opts application.RunOptions, profile string) [
 	return args
 }
 
-// buildNpmArgs builds command line arguments for npm test with coverage.
+// buildNpmArgs builds command line arguments for the test script with
+// coverage. The args are appended to `<pm> test`; only npm needs "--" to
+// forward them to the script.
 func (r *NodeRunner) buildNpmArgs(opts application.RunOptions, profile string) []string {
-	args := []string{
-		"test", "--",
-		"--coverage",
-	}
+	args := []string{"--coverage"}
 
 	if opts.BuildFlags.Verbose {
 		args = append(args, "--verbose")
@@ -225,21 +297,27 @@ func (r *NodeRunner) buildNpmArgs(opts application.RunOptions, profile string) [
 	return args
 }
 
-// runNodeCommand executes a Node.js command via cmdrun for forensic logging.
-// jest / c8 / nyc all run as `npx <tool> <args>`; npm runs directly as
-// `npm <args>`.
-func runNodeCommand(ctx context.Context, dir string, tool string, args []string) error {
-	var binary string
-	var fullArgs []string
-	switch tool {
-	case "jest", "c8", "nyc":
-		binary = "npx"
-		fullArgs = append([]string{tool}, args...)
-	case "npm":
-		binary = "npm"
-		fullArgs = args
+// nodeCommand returns the binary and arguments that run tool through pm:
+// tools run as `npx <tool>`, `pnpm exec <tool>`, or `yarn <tool>`, and the
+// fallback runs the package's test script.
+func nodeCommand(pm, tool string, args []string) (string, []string) {
+	if tool == "npm" {
+		if pm == nodePMNpm {
+			return pm, append([]string{"test", "--"}, args...)
+		}
+		return pm, append([]string{"test"}, args...)
+	}
+	switch pm {
+	case nodePMPnpm:
+		return pm, append([]string{"exec", tool}, args...)
+	case nodePMYarn:
+		return pm, append([]string{tool}, args...)
 	default:
-		return fmt.Errorf("unsupported tool: %s", tool)
+		return "npx", append([]string{tool}, args...)
 	}
-	return cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr}.Exec(ctx, dir, binary, fullArgs)
+}
+
+// runNodeCommand executes a Node.js command via cmdrun for forensic logging.
+func runNodeCommand(ctx context.Context, dir string, binary string, args []string) error {
+	return cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr}.Exec(ctx, dir, binary, args)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -127,6 +128,16 @@ func TestNodeRunnerDetectCoverageTool(t *testing.T) {
 			}`,
 			wantTool: "nyc",
 		},
+		{
+			name: "vitest in devDependencies",
+			packageJSON: `{
+				"devDependencies": {
+					"vitest": "^2.0.0",
+					"c8": "^8.0.0"
+				}
+			}`,
+			wantTool: "vitest",
+		},
 		{
 			name:        "no coverage tool",
 			packageJSON: `{"name": "test"}`,
@@ -213,7 +224,7 @@ func TestNodeRunnerBuildJestArgs(t *testing.T) {
 
 func TestNodeRunnerBuildC8Args(t *testing.T) {
 	runner := NewNodeRunner()
-	args := runner.buildC8Args(application.RunOptions{}, "/tmp/coverage/lcov.info")
+	args := runner.buildC8Args("npm", application.RunOptions{}, "/tmp/coverage/lcov.info")
 
 	expected := []string{"--reporter=lcov", "--reporter=text", "npm", "test"}
 	for _, want := range expected {
@@ -274,3 +285,91 @@ func TestNodeRunnerRun(t *testing.T) {
 		t.Error("expected non-empty profile path")
 	}
 }
+
+func TestNodeRunnerDetectVitestConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"devDependencies": {"jest": "^29.0.0"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "vitest.config.mts"), []byte("export default {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if tool := NewNodeRunner().detectCoverageTool(dir); tool != "vitest" {
+		t.Errorf("detectCoverageTool() = %s, want vitest", tool)
+	}
+}
+
+func TestDetectPackageManager(t *testing.T) {
+	tests := []struct {
+		lockfile string
+		want     string
+	}{
+		{"pnpm-lock.yaml", "pnpm"},
+		{"yarn.lock", "yarn"},
+		{"package-lock.json", "npm"},
+		{"", "npm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want+"/"+tt.lockfile, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.lockfile != "" {
+				if err := os.WriteFile(filepath.Join(dir, tt.lockfile), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := detectPackageManager(dir); got != tt.want {
+				t.Errorf("detectPackageManager() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeCommand(t *testing.T) {
+	tests := []struct {
+		pm, tool   string
+		wantBinary string
+		wantArgs   string
+	}{
+		{"npm", "vitest", "npx", "vitest run"},
+		{"pnpm", "vitest", "pnpm", "exec vitest run"},
+		{"yarn", "jest", "yarn", "jest run"},
+		{"npm", "npm", "npm", "test -- run"},
+		{"pnpm", "npm", "pnpm", "test run"},
+	}
+	for _, tt := range tests {
+		binary, args := nodeCommand(tt.pm, tt.tool, []string{"run"})
+		if binary != tt.wantBinary || strings.Join(args, " ") != tt.wantArgs {
+			t.Errorf("nodeCommand(%s, %s) = %s %v, want %s %s", tt.pm, tt.tool, binary, args, tt.wantBinary, tt.wantArgs)
+		}
+	}
+}
+
+func TestNodeRunnerRunVitestWithPnpm(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"package.json":     `{"devDependencies": {"vitest": "^2.0.0"}}`,
+		"pnpm-lock.yaml":   "lockfileVersion: '9.0'\n",
+		"vitest.config.ts": "export default {}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	var gotCmd string
+	var gotArgs []string
+	runner := &NodeRunner{
+		Exec: func(ctx context.Context, dir string, cmd string, args []string) error {
+			gotCmd, gotArgs = cmd, args
+			return nil
+		},
+	}
+	if _, err := runner.Run(context.Background(), application.RunOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := "exec vitest run --coverage.enabled --coverage.reporter=lcov --coverage.reportsDirectory=" + filepath.Join(dir, "coverage")
+	if gotCmd != "pnpm" || strings.Join(gotArgs, " ") != want {
+		t.Errorf("got %s %v, want pnpm %s", gotCmd, gotArgs, want)
+	}
+}
@@ -6,20 +6,43 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/cmdrun"
 )
 
 // PythonRunner implements CoverageRunner for Python projects.
-// Supports pytest-cov and coverage.py.
+// Supports pytest-cov and coverage.py, run directly or inside a uv, tox, or
+// nox managed environment.
 type PythonRunner struct {
-	// Exec overrides command execution (for testing).
+	// Exec overrides command execution (for testing). It receives the
+	// binary (python, uv, tox, or nox) and its full argument list.
 	Exec func(ctx context.Context, dir string, cmd string, args []string) error
 	// ExecOutput overrides command output (for testing).
 	ExecOutput func(ctx context.Context, dir string, cmd string, args []string) ([]byte, error)
 }
 
+// Python environment managers, detected from project files in order of
+// preference. pythonEnvNone runs the tools from the current interpreter.
+const (
+	pythonEnvNone = ""
+	pythonEnvUv   = "uv"
+	pythonEnvTox  = "tox"
+	pythonEnvNox  = "nox"
+)
+
+// pythonCoverageConfig holds the coverage.py settings found in the
+// [tool.coverage.*] tables of pyproject.toml.
+type pythonCoverageConfig struct {
+	// Present is true when pyproject.toml has any [tool.coverage] table,
+	// so coverage.py is pointed at it for omit and other settings.
+	Present bool
+	// Source replaces the default "." measurement root when set.
+	Source []string
+}
+
 // NewPythonRunner creates a new Python coverage runner.
 func NewPythonRunner() *PythonRunner {
 	return &PythonRunner{}
@@ -43,6 +66,9 @@ func (r *PythonRunner) Detect(projectDir string) bool {
 		"requirements.txt",
 		"Pipfile",
 		"poetry.lock",
+		"uv.lock",
+		"tox.ini",
+		"noxfile.py",
 	}
 	for _, marker := range markers {
 		if _, err := os.Stat(filepath.Join(projectDir, marker)); err == nil {
@@ -54,16 +80,17 @@ func (r *PythonRunner) Detect(projectDir string) bool {
 
 // Run executes pytest with coverage and returns the profile path.
 func (r *PythonRunner) Run(ctx context.Context, opts application.RunOptions) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
 	// Determine profile path
 	profile := opts.ProfilePath
 	if profile == "" {
 		profile = "coverage.xml"
 	}
 	if !filepath.IsAbs(profile) {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return "", err
-		}
 		profile = filepath.Join(cwd, profile)
 	}
 
@@ -72,15 +99,23 @@ func (r *PythonRunner) Run(ctx context.Context, opts application.RunOptions) (st
 		return "", err
 	}
 
-	// Detect which tool to use
-	tool := r.detectCoverageTool()
+	env := detectPythonEnv(cwd)
+	cov := readPythonCoverageConfig(cwd)
+
+	// A managed environment installs its own test dependencies, so
+	// pytest-cov is assumed there; otherwise probe the current interpreter.
+	tool := "pytest-cov"
+	if env == pythonEnvNone {
+		tool = r.detectCoverageTool()
+	}
 
+	var binary string
 	var args []string
 	switch tool {
 	case "pytest-cov":
-		args = r.buildPytestArgs(opts, profile)
+		binary, args = pythonCommand(env, cwd, r.buildPytestArgs(opts, profile, cov))
 	case "coverage":
-		args = r.buildCoverageArgs(opts, profile)
+		binary, args = "python", r.buildCoverageArgs(opts, profile, cov)
 	default:
 		return "", fmt.Errorf("no supported Python coverage tool found (pytest-cov or coverage.py required)")
 	}
@@ -91,7 +126,10 @@ func (r *PythonRunner) Run(ctx context.Context, opts application.RunOptions) (st
 	}
 
 	// Run coverage command
-	if err := execFn(ctx, "", tool, args); err != nil {
+	if err := execFn(ctx, cwd, binary, args); err != nil {
+		if env != pythonEnvNone {
+			return "", fmt.Errorf("python coverage failed (%s): %w", env, err)
+		}
 		return "", fmt.Errorf("python coverage failed: %w", err)
 	}
 
@@ -133,13 +171,20 @@ func (r *PythonRunner) detectCoverageTool() string {
 	return ""
 }
 
-// buildPytestArgs builds command line arguments for pytest-cov.
-func (r *PythonRunner) buildPytestArgs(opts application.RunOptions, profile string) []string {
-	args := []string{
-		"-m", "pytest",
-		"--cov=.",
-		"--cov-report=xml:" + profile,
+// buildPytestArgs builds pytest arguments for pytest-cov. They exclude the
+// pytest invocation itself, which depends on the environment manager.
+func (r *PythonRunner) buildPytestArgs(opts application.RunOptions, profile string, cov pythonCoverageConfig) []string {
+	var args []string
+	if len(cov.Source) == 0 {
+		args = append(args, "--cov=.")
+	}
+	for _, src := range cov.Source {
+		args = append(args, "--cov="+src)
 	}
+	if cov.Present {
+		args = append(args, "--cov-config=pyproject.toml")
+	}
+	args = append(args, "--cov-report=xml:"+profile)
 
 	// Add verbose flag
 	if opts.BuildFlags.Verbose {
@@ -168,13 +213,18 @@ func (r *PythonRunner) buildPytestArgs(opts application.RunOptions, profile stri
 }
 
 // buildCoverageArgs builds command line arguments for coverage.py.
-func (r *PythonRunner) buildCoverageArgs(opts application.RunOptions, profile string) []string {
+func (r *PythonRunner) buildCoverageArgs(opts application.RunOptions, profile string, cov pythonCoverageConfig) []string {
 	// Using coverage.py with pytest
-	args := []string{
-		"-m", "coverage", "run",
-		"--source=.",
-		"-m", "pytest",
+	args := []string{"-m", "coverage", "run"}
+	if len(cov.Source) == 0 {
+		args = append(args, "--source=.")
+	} else {
+		args = append(args, "--source="+strings.Join(cov.Source, ","))
+	}
+	if cov.Present {
+		args = append(args, "--rcfile=pyproject.toml")
 	}
+	args = append(args, "-m", "pytest")
 
 	// Add verbose flag
 	if opts.BuildFlags.Verbose {
@@ -197,18 +247,142 @@ func (r *PythonRunner) buildCoverageArgs(opts application.RunOptions, profile st
 	return args
 }
 
+// detectPythonEnv picks the environment manager from the files in
+// projectDir: uv.lock, then tox.ini, then noxfile.py.
+func detectPythonEnv(projectDir string) string {
+	markers := []struct{ name, env string }{
+		{"uv.lock", pythonEnvUv},
+		{"tox.ini", pythonEnvTox},
+		{"noxfile.py", pythonEnvNox},
+	}
+	for _, m := range markers {
+		if _, err := os.Stat(filepath.Join(projectDir, m.name)); err == nil {
+			return m.env
+		}
+	}
+	return pythonEnvNone
+}
+
+// pythonCommand returns the binary and arguments that run pytest with
+// pytestArgs inside env. tox runs pytest in the first envlist entry via
+// `tox exec`; nox passes the arguments as posargs to the test session,
+// which is expected to forward session.posargs to pytest.
+func pythonCommand(env, projectDir string, pytestArgs []string) (string, []string) {
+	switch env {
+	case pythonEnvUv:
+		return "uv", append([]string{"run", "pytest"}, pytestArgs...)
+	case pythonEnvTox:
+		return "tox", append([]string{"exec", "-e", toxEnv(projectDir), "--", "python", "-m", "pytest"}, pytestArgs...)
+	case pythonEnvNox:
+		return "nox", append([]string{"-s", noxSession(projectDir), "--"}, pytestArgs...)
+	default:
+		return "python", append([]string{"-m", "pytest"}, pytestArgs...)
+	}
+}
+
+// toxEnv returns the first environment of the envlist in tox.ini, or "py"
+// when none is declared.
+func toxEnv(projectDir string) string {
+	data, err := os.ReadFile(filepath.Join(projectDir, "tox.ini")) // #nosec G304 -- fixed file name in the project directory
+	if err != nil {
+		return "py"
+	}
+	section := ""
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if section != "tox" || !ok || strings.TrimSpace(key) != "envlist" {
+			continue
+		}
+		// The envlist may continue on indented lines after "envlist =".
+		value = strings.TrimSpace(value)
+		for j := i + 1; value == "" && j < len(lines); j++ {
+			value = strings.TrimSpace(lines[j])
+		}
+		first, _, _ := strings.Cut(value, ",")
+		if first = strings.TrimSpace(first); first != "" && !strings.ContainsAny(first, "{}") {
+			return first
+		}
+		break
+	}
+	return "py"
+}
+
+// noxSessionPattern matches session function definitions in a noxfile.
+var noxSessionPattern = regexp.MustCompile(`(?m)^def\s+(\w+)\s*\(\s*session\b`)
+
+// noxSession returns the first noxfile session whose name contains "test",
+// or "tests" when none does.
+func noxSession(projectDir string) string {
+	data, err := os.ReadFile(filepath.Join(projectDir, "noxfile.py")) // #nosec G304 -- fixed file name in the project directory
+	if err != nil {
+		return "tests"
+	}
+	for _, m := range noxSessionPattern.FindAllStringSubmatch(string(data), -1) {
+		if strings.Contains(m[1], "test") {
+			return m[1]
+		}
+	}
+	return "tests"
+}
+
+// readPythonCoverageConfig reads the source setting and the presence of
+// [tool.coverage] tables from pyproject.toml. It understands the subset of
+// TOML those tables use in practice: string values and string arrays,
+// which may span several lines.
+func readPythonCoverageConfig(projectDir string) pythonCoverageConfig {
+	var cfg pythonCoverageConfig
+	data, err := os.ReadFile(filepath.Join(projectDir, "pyproject.toml")) // #nosec G304 -- fixed file name in the project directory
+	if err != nil {
+		return cfg
+	}
+	section := ""
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			if section == "tool.coverage" || strings.HasPrefix(section, "tool.coverage.") {
+				cfg.Present = true
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if section != "tool.coverage.run" || !ok || strings.TrimSpace(key) != "source" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		for strings.HasPrefix(value, "[") && !strings.Contains(value, "]") && i+1 < len(lines) {
+			i++
+			value += " " + strings.TrimSpace(lines[i])
+		}
+		cfg.Source = parseTOMLStrings(value)
+	}
+	return cfg
+}
+
+// parseTOMLStrings returns the quoted strings in a TOML string or string
+// array value, ignoring a trailing comment.
+func parseTOMLStrings(value string) []string {
+	var out []string
+	for _, m := range tomlStringPattern.FindAllStringSubmatch(value, -1) {
+		out = append(out, m[1]+m[2])
+	}
+	return out
+}
+
+// tomlStringPattern matches basic ("...") and literal ('...') TOML strings.
+var tomlStringPattern = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+
 // runPythonCommand executes a Python command. Delegates to cmdrun.Runner so
 // every invocation produces a structured-log event with resolved binary path
 // (security review T7), args fingerprint, and exit code (T8). Operators can
 // surface these via `coverctl --debug` or `--ci`.
-func runPythonCommand(ctx context.Context, dir string, tool string, args []string) error {
-	switch tool {
-	case "pytest-cov", "coverage":
-		// Both run as `python <args>`; the args slice already encodes
-		// `-m pytest` or `-m coverage run` from the buildPytest/buildCoverage
-		// helpers above.
-	default:
-		return fmt.Errorf("unsupported tool: %s", tool)
-	}
-	return cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr}.Exec(ctx, dir, "python", args)
+func runPythonCommand(ctx context.Context, dir string, binary string, args []string) error {
+	return cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr}.Exec(ctx, dir, binary, args)
 }
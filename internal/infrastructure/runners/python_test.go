@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -105,7 +106,6 @@ func TestPythonRunnerBuildPytestArgs(t *testing.T) {
 			opts:    application.RunOptions{},
 			profile: "/tmp/coverage.xml",
 			contains: []string{
-				"-m", "pytest",
 				"--cov=.",
 				"--cov-report=xml:/tmp/coverage.xml",
 			},
@@ -138,7 +138,7 @@ func TestPythonRunnerBuildPytestArgs(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			args := runner.buildPytestArgs(tt.opts, tt.profile)
+			args := runner.buildPytestArgs(tt.opts, tt.profile, pythonCoverageConfig{})
 
 			for _, want := range tt.contains {
 				found := false
@@ -211,9 +211,9 @@ func TestPythonRunnerRun(t *testing.T) {
 		t.Error("expected non-empty profile path")
 	}
 
-	// Verify the tool used is pytest-cov or coverage
-	if capturedTool != "pytest-cov" && capturedTool != "coverage" {
-		t.Errorf("expected pytest-cov or coverage, got %s", capturedTool)
+	// Both pytest-cov and coverage.py run through the interpreter
+	if capturedTool != "python" {
+		t.Errorf("expected python, got %s", capturedTool)
 	}
 
 	// Verify args contain pytest markers
@@ -228,3 +228,126 @@ func TestPythonRunnerRun(t *testing.T) {
 		t.Errorf("expected pytest in args, got %v", capturedArgs)
 	}
 }
+
+func TestDetectPythonEnv(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{[]string{"uv.lock", "tox.ini"}, "uv"},
+		{[]string{"tox.ini", "noxfile.py"}, "tox"},
+		{[]string{"noxfile.py"}, "nox"},
+		{[]string{"requirements.txt"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.files, ","), func(t *testing.T) {
+			dir := t.TempDir()
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := detectPythonEnv(dir); got != tt.want {
+				t.Errorf("detectPythonEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPythonCommand(t *testing.T) {
+	dir := t.TempDir()
+	tox := "[tox]\nenvlist =\n    py312,\n    lint\n\n[testenv]\ncommands = pytest {posargs}\n"
+	nox := "import nox\n\n@nox.session\ndef lint(session):\n    pass\n\n@nox.session(python=[\"3.12\"])\ndef unit_tests(session):\n    session.run(\"pytest\", *session.posargs)\n"
+	for name, content := range map[string]string{"tox.ini": tox, "noxfile.py": nox} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		env        string
+		wantBinary string
+		wantArgs   string
+	}{
+		{"", "python", "-m pytest --cov=."},
+		{"uv", "uv", "run pytest --cov=."},
+		{"tox", "tox", "exec -e py312 -- python -m pytest --cov=."},
+		{"nox", "nox", "-s unit_tests -- --cov=."},
+	}
+	for _, tt := range tests {
+		binary, args := pythonCommand(tt.env, dir, []string{"--cov=."})
+		if binary != tt.wantBinary || strings.Join(args, " ") != tt.wantArgs {
+			t.Errorf("pythonCommand(%q) = %s %v, want %s %s", tt.env, binary, args, tt.wantBinary, tt.wantArgs)
+		}
+	}
+
+	// Without config files the defaults apply.
+	empty := t.TempDir()
+	if got := toxEnv(empty); got != "py" {
+		t.Errorf("toxEnv() = %s, want py", got)
+	}
+	if got := noxSession(empty); got != "tests" {
+		t.Errorf("noxSession() = %s, want tests", got)
+	}
+}
+
+func TestReadPythonCoverageConfig(t *testing.T) {
+	dir := t.TempDir()
+	pyproject := `[project]
+name = "demo"
+source = ["ignored"]
+
+[tool.coverage.run]
+source = [
+    "src/demo",  # package code
+    'scripts',
+]
+omit = ["*/migrations/*"]
+`
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte(pyproject), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := readPythonCoverageConfig(dir)
+	if !cfg.Present {
+		t.Error("expected [tool.coverage] to be detected")
+	}
+	if strings.Join(cfg.Source, ",") != "src/demo,scripts" {
+		t.Errorf("Source = %v, want [src/demo scripts]", cfg.Source)
+	}
+
+	args := NewPythonRunner().buildPytestArgs(application.RunOptions{}, "/tmp/coverage.xml", cfg)
+	want := "--cov=src/demo --cov=scripts --cov-config=pyproject.toml --cov-report=xml:/tmp/coverage.xml"
+	if got := strings.Join(args, " "); got != want {
+		t.Errorf("buildPytestArgs() = %s, want %s", got, want)
+	}
+
+	if cfg := readPythonCoverageConfig(t.TempDir()); cfg.Present || cfg.Source != nil {
+		t.Errorf("expected empty config without pyproject.toml, got %+v", cfg)
+	}
+}
+
+func TestPythonRunnerRunWithUv(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"pyproject.toml", "uv.lock"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	var gotCmd string
+	var gotArgs []string
+	runner := &PythonRunner{
+		Exec: func(ctx context.Context, dir string, cmd string, args []string) error {
+			gotCmd, gotArgs = cmd, args
+			return nil
+		},
+	}
+	if _, err := runner.Run(context.Background(), application.RunOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := "run pytest --cov=. --cov-report=xml:" + filepath.Join(dir, "coverage.xml")
+	if gotCmd != "uv" || strings.Join(gotArgs, " ") != want {
+		t.Errorf("got %s %v, want uv %s", gotCmd, gotArgs, want)
+	}
+}
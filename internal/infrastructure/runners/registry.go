@@ -7,6 +7,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
@@ -24,10 +28,37 @@ var languageAliases = map[application.Language]application.Language{
 	application.LanguageTypeScript: application.LanguageJavaScript,
 }
 
+// runnerAliases maps the names users type for --runner (or the runner
+// config key) to registered runner names.
+var runnerAliases = map[string]string{
+	"node":       "nodejs",
+	"javascript": "nodejs",
+	"typescript": "nodejs",
+}
+
+// runnerTools lists the executables each runner invokes. A runner is
+// installed when any of them is on PATH; entries starting with "./" are
+// project-local wrappers looked up in the project directory. Runners not
+// listed are always considered installed.
+var runnerTools = map[string][]string{
+	"go":     {"go"},
+	"python": {"python", "uv", "tox", "nox"},
+	"nodejs": {"npm", "npx", "pnpm", "yarn"},
+	"rust":   {"cargo"},
+	"java":   {"mvn", "gradle", "./mvnw", "./gradlew"},
+	"csharp": {"dotnet"},
+	"php":    {"php"},
+	"ruby":   {"bundle"},
+	"scala":  {"sbt", "mill", "./sbt", "./mill"},
+}
+
 // Registry manages multiple coverage runners and auto-detects which to use.
 type Registry struct {
 	runners    []application.CoverageRunner
 	projectDir string
+	lookPath   func(file string) (string, error) // exec.LookPath unless overridden in tests
+	// execCommand runs a test_command; cmdrun unless overridden in tests.
+	execCommand func(ctx context.Context, dir string, env []string, name string, args []string) error
 }
 
 // RegistryOption configures the runner registry.
@@ -125,14 +156,71 @@ func (r *Registry) GetRunner(lang application.Language) (application.CoverageRun
 	return nil, fmt.Errorf("no coverage runner for language: %s", lang)
 }
 
-// GetRunnerByName returns a runner by its name.
+// GetRunnerByName returns a runner by its name (or an alias such as
+// "node"), bypassing detection. It fails with the list of installed runners
+// when the name is unknown or the runner's toolchain is not on PATH.
 func (r *Registry) GetRunnerByName(name string) (application.CoverageRunner, error) {
+	if canonical, ok := runnerAliases[name]; ok {
+		name = canonical
+	}
 	for _, runner := range r.runners {
-		if runner.Name() == name {
-			return runner, nil
+		if runner.Name() != name {
+			continue
 		}
+		if !r.installed(name) {
+			return nil, fmt.Errorf("runner %q is not installed (none of %s found); installed runners: %s",
+				name, strings.Join(runnerTools[name], ", "), r.installedNames())
+		}
+		return runner, nil
+	}
+	return nil, fmt.Errorf("unknown runner %q; installed runners: %s", name, r.installedNames())
+}
+
+// installed reports whether any of the runner's executables is available.
+func (r *Registry) installed(name string) bool {
+	tools, ok := runnerTools[name]
+	if !ok {
+		return true
+	}
+	lookPath := r.lookPath
+	if lookPath == nil {
+		lookPath = exec.LookPath
+	}
+	for _, tool := range tools {
+		if local, ok := strings.CutPrefix(tool, "./"); ok {
+			if _, err := os.Stat(filepath.Join(r.dir(), local)); err == nil {
+				return true
+			}
+			continue
+		}
+		if _, err := lookPath(tool); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Registry) installedNames() string {
+	var names []string
+	for _, runner := range r.runners {
+		if r.installed(runner.Name()) {
+			names = append(names, runner.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// dir returns the project directory, defaulting to the working directory.
+func (r *Registry) dir() string {
+	if r.projectDir != "" {
+		return r.projectDir
 	}
-	return nil, fmt.Errorf("no coverage runner with name: %s", name)
+	wd, _ := os.Getwd()
+	return wd
 }
 
 // SupportedLanguages returns all languages supported by the registry,
@@ -3,7 +3,10 @@ package runners
 import (
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -124,6 +127,7 @@ func TestRegistryGetRunner(t *testing.T) {
 func TestRegistryGetRunnerByName(t *testing.T) {
 	module := mockModuleInfo{root: "/test", path: "example.com/test"}
 	registry := NewRegistry(module)
+	registry.lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
 
 	tests := []struct {
 		name    string
@@ -166,6 +170,48 @@ func TestRegistryGetRunnerByName(t *testing.T) {
 	}
 }
 
+func TestRegistryGetRunnerByNameInstallCheck(t *testing.T) {
+	module := mockModuleInfo{root: "/test", path: "example.com/test"}
+	projectDir := t.TempDir()
+	registry := NewRegistry(module, WithProjectDir(projectDir))
+	registry.lookPath = func(file string) (string, error) {
+		if file == "go" || file == "npm" {
+			return "/usr/bin/" + file, nil
+		}
+		return "", exec.ErrNotFound
+	}
+
+	runner, err := registry.GetRunnerByName("node")
+	if err != nil || runner.Name() != "nodejs" {
+		t.Fatalf("expected node alias to resolve to nodejs, got %v, %v", runner, err)
+	}
+
+	_, err = registry.GetRunnerByName("rust")
+	if err == nil {
+		t.Fatal("expected error for runner without its toolchain")
+	}
+	for _, want := range []string{`"rust" is not installed`, "cargo", "installed runners: ", "go", "nodejs"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "python") {
+		t.Errorf("expected python not listed as installed, got %v", err)
+	}
+
+	if _, err := registry.GetRunnerByName("cobol"); err == nil || !strings.Contains(err.Error(), `unknown runner "cobol"`) {
+		t.Errorf("expected unknown runner error, got %v", err)
+	}
+
+	// A project-local wrapper counts as an installed toolchain.
+	if err := os.WriteFile(filepath.Join(projectDir, "mvnw"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.GetRunnerByName("java"); err != nil {
+		t.Errorf("expected java runner via mvnw wrapper, got %v", err)
+	}
+}
+
 func TestRegistryDetectRunner(t *testing.T) {
 	// Create temp directories with language markers
 	tmpDir := t.TempDir()
@@ -337,3 +383,41 @@ func TestRegistryGetRunner_UnknownLanguageErrors(t *testing.T) {
 		t.Error("expected error for unknown language")
 	}
 }
+
+func TestRegistryRunCommand(t *testing.T) {
+	dir := t.TempDir()
+	var gotArgs, gotEnv []string
+	r := &Registry{projectDir: dir, execCommand: func(_ context.Context, _ string, env []string, name string, args []string) error {
+		gotArgs = append([]string{name}, args...)
+		gotEnv = env
+		profile := filepath.Join(dir, ".cover", "domains", "web.out")
+		return os.WriteFile(profile, []byte("mode: set\n"), 0o600)
+	}}
+
+	profile, err := r.RunCommand(context.Background(), []string{"make", "cover", "OUT={profile}"}, filepath.Join(".cover", "domains", "web.out"))
+	if err != nil {
+		t.Fatalf("RunCommand: %v", err)
+	}
+	want := filepath.Join(dir, ".cover", "domains", "web.out")
+	if profile != want {
+		t.Fatalf("profile = %q, want %q", profile, want)
+	}
+	if len(gotArgs) != 3 || gotArgs[2] != "OUT="+want {
+		t.Fatalf("args = %v", gotArgs)
+	}
+	if !slices.Contains(gotEnv, "COVERCTL_PROFILE="+want) {
+		t.Fatal("COVERCTL_PROFILE not exported")
+	}
+}
+
+func TestRegistryRunCommandMissingProfile(t *testing.T) {
+	r := &Registry{projectDir: t.TempDir(), execCommand: func(context.Context, string, []string, string, []string) error {
+		return nil
+	}}
+	if _, err := r.RunCommand(context.Background(), []string{"true"}, "web.out"); err == nil {
+		t.Fatal("expected an error when no profile is written")
+	}
+	if _, err := r.RunCommand(context.Background(), nil, "web.out"); err == nil {
+		t.Fatal("expected an error for an empty command")
+	}
+}
@@ -2,20 +2,40 @@ package runners
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/cmdrun"
 )
 
+// Rust coverage tools, in order of preference.
+const (
+	rustToolLlvmCov   = "llvm-cov"  // cargo-llvm-cov
+	rustToolTarpaulin = "tarpaulin" // cargo-tarpaulin
+	rustToolGrcov     = "grcov"     // cargo test with -Cinstrument-coverage, then grcov
+)
+
+// rustToolInstallCommands lists how to install each supported tool.
+const rustToolInstallCommands = `  cargo install cargo-llvm-cov    (recommended; needs: rustup component add llvm-tools-preview)
+  cargo install cargo-tarpaulin   (Linux x86_64 only)
+  cargo install grcov             (needs: rustup component add llvm-tools-preview)`
+
+// rustToolInstallHint is returned when no Rust coverage tool is installed.
+const rustToolInstallHint = "no Rust coverage tool found; install one of:\n" + rustToolInstallCommands
+
 // RustRunner implements CoverageRunner for Rust projects.
-// Supports cargo-tarpaulin and cargo-llvm-cov.
+// Uses cargo-llvm-cov when installed, then cargo-tarpaulin, then plain
+// cargo test with source-based coverage collected by grcov.
 type RustRunner struct {
 	// Exec overrides command execution (for testing).
 	Exec func(ctx context.Context, dir string, cmd string, args []string) error
+	// Installed overrides coverage tool detection (for testing).
+	Installed func(tool string) bool
 }
 
 // NewRustRunner creates a new Rust coverage runner.
@@ -70,16 +90,19 @@ func (r *RustRunner) Run(ctx context.Context, opts application.RunOptions) (stri
 
 	// Detect which tool to use
 	tool := r.detectCoverageTool()
-	args := r.buildArgs(tool, opts, profile)
-
-	execFn := r.Exec
-	if execFn == nil {
-		execFn = runRustCommand
+	switch tool {
+	case "":
+		return "", errors.New(rustToolInstallHint)
+	case rustToolGrcov:
+		if err := r.runGrcov(ctx, cwd, opts, profile); err != nil {
+			return "", fmt.Errorf("rust coverage failed (cargo test + grcov): %w", err)
+		}
+		return profile, nil
 	}
 
 	// Run coverage command
-	if err := execFn(ctx, cwd, tool, args); err != nil {
-		return "", fmt.Errorf("rust coverage failed: %w", err)
+	if err := r.exec(ctx, cwd, "cargo", r.buildArgs(tool, opts, profile)); err != nil {
+		return "", fmt.Errorf("rust coverage failed (cargo %s): %w", tool, err)
 	}
 
 	return profile, nil
@@ -93,28 +116,85 @@ func (r *RustRunner) RunIntegration(ctx context.Context, opts application.Integr
 	})
 }
 
-// detectCoverageTool determines which Rust coverage tool is available.
+// detectCoverageTool returns the first installed Rust coverage tool, or ""
+// when none is.
 func (r *RustRunner) detectCoverageTool() string {
-	// Check for cargo-llvm-cov first (recommended for accuracy)
-	cmd := exec.Command("cargo", "llvm-cov", "--version")
-	if cmd.Run() == nil {
-		return "llvm-cov"
+	installed := r.Installed
+	if installed == nil {
+		installed = rustToolInstalled
+	}
+	for _, tool := range []string{rustToolLlvmCov, rustToolTarpaulin, rustToolGrcov} {
+		if installed(tool) {
+			return tool
+		}
 	}
+	return ""
+}
 
-	// Check for cargo-tarpaulin
-	cmd = exec.Command("cargo", "tarpaulin", "--version")
-	if cmd.Run() == nil {
-		return "tarpaulin"
+// rustToolInstalled reports whether a Rust coverage tool can be run.
+func rustToolInstalled(tool string) bool {
+	if tool == rustToolGrcov {
+		_, cargoErr := exec.LookPath("cargo")
+		_, grcovErr := exec.LookPath("grcov")
+		return cargoErr == nil && grcovErr == nil
+	}
+	return exec.Command("cargo", tool, "--version").Run() == nil // #nosec G204 - tool is one of the constants above
+}
+
+// runGrcov runs cargo test with LLVM source-based coverage and converts the
+// raw profiles to LCOV with grcov.
+func (r *RustRunner) runGrcov(ctx context.Context, cwd string, opts application.RunOptions, profile string) error {
+	rawDir := filepath.Join(filepath.Dir(profile), "profraw")
+	if err := os.RemoveAll(rawDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(rawDir, 0o750); err != nil {
+		return err
 	}
 
-	// Default to tarpaulin (will error if not installed)
-	return "tarpaulin"
+	rustflags := strings.TrimSpace(os.Getenv("RUSTFLAGS") + " -Cinstrument-coverage")
+	env := []string{
+		"RUSTFLAGS=" + rustflags,
+		"LLVM_PROFILE_FILE=" + filepath.Join(rawDir, "cargo-test-%p-%m.profraw"),
+	}
+	if err := r.exec(ctx, cwd, "cargo", r.buildCargoTestArgs(opts), env...); err != nil {
+		return err
+	}
+
+	return r.exec(ctx, cwd, "grcov", []string{
+		rawDir,
+		"--binary-path", filepath.Join("target", "debug", "deps"),
+		"--source-dir", ".",
+		"--output-type", "lcov",
+		"--branch",
+		"--ignore-not-existing",
+		"--ignore", "/*",
+		"--output-path", profile,
+	})
+}
+
+// buildCargoTestArgs builds plain cargo test arguments for the grcov path.
+func (r *RustRunner) buildCargoTestArgs(opts application.RunOptions) []string {
+	args := []string{"test"}
+	if opts.BuildFlags.Tags == "" {
+		args = append(args, "--all-features")
+	} else {
+		args = append(args, "--features", opts.BuildFlags.Tags)
+	}
+	if opts.BuildFlags.Verbose {
+		args = append(args, "--verbose")
+	}
+	args = append(args, opts.BuildFlags.TestArgs...)
+	if opts.BuildFlags.Run != "" {
+		args = append(args, opts.BuildFlags.Run)
+	}
+	return args
 }
 
 // buildArgs builds command line arguments for the detected tool.
 func (r *RustRunner) buildArgs(tool string, opts application.RunOptions, profile string) []string {
 	switch tool {
-	case "llvm-cov":
+	case rustToolLlvmCov:
 		return r.buildLlvmCovArgs(opts, profile)
 	default:
 		return r.buildTarpaulinArgs(opts, profile)
@@ -189,8 +269,15 @@ func (r *RustRunner) buildTarpaulinArgs(opts application.RunOptions, profile str
 	return args
 }
 
-// runRustCommand executes a Rust/Cargo command via cmdrun for forensic
-// logging.
-func runRustCommand(ctx context.Context, dir string, _ string, args []string) error {
-	return cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr}.Exec(ctx, dir, "cargo", args)
+// exec runs binary via cmdrun for forensic logging, adding env to the
+// inherited environment.
+func (r *RustRunner) exec(ctx context.Context, dir, binary string, args []string, env ...string) error {
+	if r.Exec != nil {
+		return r.Exec(ctx, dir, binary, args)
+	}
+	runner := cmdrun.Runner{Stdout: os.Stdout, Stderr: os.Stderr}
+	if len(env) > 0 {
+		runner.Env = append(os.Environ(), env...)
+	}
+	return runner.Exec(ctx, dir, binary, args)
 }
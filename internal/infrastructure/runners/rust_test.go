@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -225,6 +226,7 @@ func TestRustRunnerRun(t *testing.T) {
 	var capturedTool string
 
 	runner := &RustRunner{
+		Installed: func(tool string) bool { return tool == rustToolTarpaulin },
 		Exec: func(ctx context.Context, dir string, cmd string, args []string) error {
 			execCalled = true
 			if len(args) > 0 {
@@ -264,3 +266,76 @@ func TestRustRunnerRun(t *testing.T) {
 		t.Errorf("expected tarpaulin or llvm-cov, got %s", capturedTool)
 	}
 }
+
+func TestRustRunnerFallbackChain(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed []string
+		want      string
+	}{
+		{"prefers llvm-cov", []string{rustToolLlvmCov, rustToolTarpaulin, rustToolGrcov}, rustToolLlvmCov},
+		{"falls back to tarpaulin", []string{rustToolTarpaulin, rustToolGrcov}, rustToolTarpaulin},
+		{"falls back to grcov", []string{rustToolGrcov}, rustToolGrcov},
+		{"nothing installed", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &RustRunner{Installed: func(tool string) bool {
+				for _, have := range tt.installed {
+					if have == tool {
+						return true
+					}
+				}
+				return false
+			}}
+			if got := runner.detectCoverageTool(); got != tt.want {
+				t.Errorf("detectCoverageTool() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRustRunnerRunGrcov(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var calls [][]string
+	runner := &RustRunner{
+		Installed: func(tool string) bool { return tool == rustToolGrcov },
+		Exec: func(ctx context.Context, dir string, cmd string, args []string) error {
+			calls = append(calls, append([]string{cmd}, args...))
+			return nil
+		},
+	}
+
+	profile, err := runner.Run(context.Background(), application.RunOptions{
+		BuildFlags: application.BuildFlags{Run: "parser"},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected cargo test then grcov, got %v", calls)
+	}
+	if got := strings.Join(calls[0], " "); got != "cargo test --all-features parser" {
+		t.Errorf("unexpected cargo test call: %s", got)
+	}
+	grcov := strings.Join(calls[1], " ")
+	if calls[1][0] != "grcov" || !strings.Contains(grcov, "--output-type lcov") || !strings.Contains(grcov, "--output-path "+profile) {
+		t.Errorf("unexpected grcov call: %s", grcov)
+	}
+}
+
+func TestRustRunnerRunWithoutTools(t *testing.T) {
+	t.Chdir(t.TempDir())
+	runner := &RustRunner{
+		Installed: func(string) bool { return false },
+		Exec: func(ctx context.Context, dir string, cmd string, args []string) error {
+			t.Fatalf("unexpected exec of %s", cmd)
+			return nil
+		},
+	}
+
+	_, err := runner.Run(context.Background(), application.RunOptions{})
+	if err == nil || !strings.Contains(err.Error(), "cargo install cargo-llvm-cov") {
+		t.Fatalf("expected install guidance, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package runners
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Toolchain check outcomes.
+const (
+	ToolchainPass = "pass"
+	ToolchainWarn = "warn" // usable, but coverage may be degraded
+	ToolchainFail = "fail"
+)
+
+// ToolchainCheck is the result of checking one detected runner's coverage
+// toolchain.
+type ToolchainCheck struct {
+	Runner      string
+	Status      string
+	Detail      string
+	Remediation string
+}
+
+// DiagnoseToolchains checks the coverage toolchain of every runner that
+// detects projectDir. Unlike DetectRunner it does not stop at the first
+// match, so a polyglot repository gets one check per language.
+func (r *Registry) DiagnoseToolchains(ctx context.Context, projectDir string) []ToolchainCheck {
+	var checks []ToolchainCheck
+	for _, runner := range r.runners {
+		if !runner.Detect(projectDir) {
+			continue
+		}
+		check := ToolchainCheck{Runner: runner.Name()}
+		if !r.installed(runner.Name()) {
+			check.Status = ToolchainFail
+			check.Detail = fmt.Sprintf("none of %s found on PATH", strings.Join(runnerTools[runner.Name()], ", "))
+			check.Remediation = fmt.Sprintf("Install the %s toolchain and make sure it is on PATH.", runner.Name())
+			checks = append(checks, check)
+			continue
+		}
+		switch runner := runner.(type) {
+		case *PythonRunner:
+			check = diagnosePython(runner, projectDir)
+		case *NodeRunner:
+			check = diagnoseNode(runner, projectDir)
+		case *RustRunner:
+			check = diagnoseRust(runner)
+		case *JavaRunner:
+			check = diagnoseJava(runner, projectDir)
+		default:
+			if runner.Name() == "go" {
+				check = diagnoseGo(ctx)
+			} else {
+				check.Status = ToolchainPass
+				check.Detail = "toolchain found on PATH"
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func diagnoseGo(ctx context.Context) ToolchainCheck {
+	out, err := exec.CommandContext(ctx, "go", "version").Output()
+	if err != nil {
+		return ToolchainCheck{
+			Runner:      "go",
+			Status:      ToolchainFail,
+			Detail:      fmt.Sprintf("go version failed: %v", err),
+			Remediation: "Reinstall Go from https://go.dev/dl/.",
+		}
+	}
+	return ToolchainCheck{Runner: "go", Status: ToolchainPass, Detail: strings.TrimSpace(string(out))}
+}
+
+func diagnosePython(r *PythonRunner, projectDir string) ToolchainCheck {
+	if env := detectPythonEnv(projectDir); env != pythonEnvNone {
+		if _, err := exec.LookPath(env); err != nil {
+			return ToolchainCheck{
+				Runner:      r.Name(),
+				Status:      ToolchainFail,
+				Detail:      fmt.Sprintf("project uses %s but it is not on PATH", env),
+				Remediation: fmt.Sprintf("pip install %s (or pipx install %s).", env, env),
+			}
+		}
+		return ToolchainCheck{Runner: r.Name(), Status: ToolchainPass, Detail: "using pytest-cov via " + env}
+	}
+	tool := r.detectCoverageTool()
+	if tool == "" {
+		return ToolchainCheck{
+			Runner:      r.Name(),
+			Status:      ToolchainFail,
+			Detail:      "neither pytest-cov nor coverage.py is importable",
+			Remediation: "pip install pytest-cov (or pip install coverage).",
+		}
+	}
+	return ToolchainCheck{Runner: r.Name(), Status: ToolchainPass, Detail: "using " + tool}
+}
+
+func diagnoseNode(r *NodeRunner, projectDir string) ToolchainCheck {
+	tool := r.detectCoverageTool(projectDir)
+	if tool == "npm" {
+		return ToolchainCheck{
+			Runner:      r.Name(),
+			Status:      ToolchainWarn,
+			Detail:      "no vitest, jest, c8, or nyc found; falling back to the test script with --coverage",
+			Remediation: "npm install --save-dev c8 (or declare vitest, jest, or nyc in package.json).",
+		}
+	}
+	return ToolchainCheck{Runner: r.Name(), Status: ToolchainPass, Detail: "using " + tool}
+}
+
+func diagnoseRust(r *RustRunner) ToolchainCheck {
+	tool := r.detectCoverageTool()
+	if tool == "" {
+		return ToolchainCheck{
+			Runner:      r.Name(),
+			Status:      ToolchainFail,
+			Detail:      "no Rust coverage tool found",
+			Remediation: "Install one of:\n" + rustToolInstallCommands,
+		}
+	}
+	check := ToolchainCheck{Runner: r.Name(), Status: ToolchainPass, Detail: "using " + tool}
+	if tool != rustToolLlvmCov {
+		check.Remediation = "cargo install cargo-llvm-cov for the most accurate line coverage."
+	}
+	return check
+}
+
+// diagnoseJava looks for the JaCoCo plugin in the build file; without it
+// the build produces no report for coverctl to read.
+func diagnoseJava(r *JavaRunner, projectDir string) ToolchainCheck {
+	tool := r.detectBuildTool(projectDir)
+	files := []string{"pom.xml"}
+	remediation := "Add the org.jacoco:jacoco-maven-plugin with the report goal to pom.xml."
+	if tool == "gradle" {
+		files = []string{"build.gradle", "build.gradle.kts"}
+		remediation = "Apply the jacoco plugin in build.gradle and enable xml reports on jacocoTestReport."
+	}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(projectDir, name)) // #nosec G304 -- fixed file names in the project directory
+		if err == nil && strings.Contains(strings.ToLower(string(data)), "jacoco") {
+			return ToolchainCheck{Runner: r.Name(), Status: ToolchainPass, Detail: fmt.Sprintf("%s with JaCoCo configured in %s", tool, name)}
+		}
+	}
+	return ToolchainCheck{
+		Runner:      r.Name(),
+		Status:      ToolchainFail,
+		Detail:      fmt.Sprintf("JaCoCo is not configured in %s", strings.Join(files, " or ")),
+		Remediation: remediation,
+	}
+}
@@ -0,0 +1,69 @@
+package runners
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func TestDiagnoseToolchains(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"Cargo.toml": "[package]\nname = \"demo\"\n",
+		"pom.xml":    "<project><artifactId>demo</artifactId></project>",
+		"Gemfile":    "source 'https://rubygems.org'\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	registry := &Registry{
+		runners: []application.CoverageRunner{
+			&RustRunner{Installed: func(string) bool { return false }},
+			NewJavaRunner(),
+			NewRubyRunner(),
+			NewPythonRunner(), // not detected: no Python markers
+		},
+		projectDir: dir,
+		lookPath: func(file string) (string, error) {
+			if file == "bundle" {
+				return "", os.ErrNotExist
+			}
+			return "/usr/bin/" + file, nil
+		},
+	}
+
+	checks := registry.DiagnoseToolchains(context.Background(), dir)
+	if len(checks) != 3 {
+		t.Fatalf("expected 3 checks, got %+v", checks)
+	}
+	byRunner := map[string]ToolchainCheck{}
+	for _, c := range checks {
+		byRunner[c.Runner] = c
+	}
+
+	rust := byRunner["rust"]
+	if rust.Status != ToolchainFail || !strings.Contains(rust.Remediation, "cargo install cargo-llvm-cov") {
+		t.Errorf("expected rust failure with install hint, got %+v", rust)
+	}
+	java := byRunner["java"]
+	if java.Status != ToolchainFail || !strings.Contains(java.Remediation, "jacoco-maven-plugin") {
+		t.Errorf("expected java failure for missing JaCoCo, got %+v", java)
+	}
+	ruby := byRunner["ruby"]
+	if ruby.Status != ToolchainFail || !strings.Contains(ruby.Detail, "bundle") {
+		t.Errorf("expected ruby failure for missing bundle, got %+v", ruby)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"),
+		[]byte("<project><build><plugins><plugin><artifactId>jacoco-maven-plugin</artifactId></plugin></plugins></build></project>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if java := diagnoseJava(NewJavaRunner(), dir); java.Status != ToolchainPass {
+		t.Errorf("expected java pass with JaCoCo configured, got %+v", java)
+	}
+}
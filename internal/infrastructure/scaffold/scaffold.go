@@ -0,0 +1,379 @@
+// Package scaffold generates table-driven Go test skeletons for functions
+// that no test executes, for `coverctl scaffold`.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/annotations"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// TestFileSuffix replaces ".go" in the source file name to name the
+// skeleton, so generated tests never collide with hand-written files.
+const TestFileSuffix = "_scaffold_test.go"
+
+// reservedNames are identifiers the skeleton itself uses; parameters with
+// these names get an "Arg" suffix.
+var reservedNames = map[string]bool{
+	"name": true, "tt": true, "t": true, "tests": true, "r": true,
+	"got": true, "want": true, "err": true, "wantErr": true,
+}
+
+// versionElem matches major-version path elements such as "v2".
+var versionElem = regexp.MustCompile(`^v[0-9]+$`)
+
+// Generator implements application.TestScaffolder for Go source.
+type Generator struct{}
+
+// Scaffold parses target.File under moduleRoot and writes one test per
+// listed function, in the same package so unexported types stay usable.
+// Generic functions and functions whose test name is already declared in
+// the package are skipped with a reason.
+func (Generator) Scaffold(moduleRoot string, target application.ScaffoldTarget) (application.ScaffoldFile, error) {
+	out := application.ScaffoldFile{
+		Source:   target.File,
+		TestFile: strings.TrimSuffix(target.File, ".go") + TestFileSuffix,
+	}
+	cleanPath, err := pathutil.ValidatePath(filepath.Join(moduleRoot, filepath.FromSlash(target.File)))
+	if err != nil {
+		return out, fmt.Errorf("invalid path: %w", err)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, cleanPath, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return out, err
+	}
+	existing, err := existingTests(filepath.Dir(cleanPath), filepath.Base(out.TestFile))
+	if err != nil {
+		return out, err
+	}
+
+	wanted := make(map[string]bool, len(target.Functions))
+	for _, name := range target.Functions {
+		wanted[name] = true
+	}
+	g := &generation{fset: fset, imports: importNames(file), used: map[string]bool{strconv.Quote("testing"): true}}
+	var body bytes.Buffer
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || !wanted[annotations.FunctionName(fn)] {
+			continue
+		}
+		name := annotations.FunctionName(fn)
+		testName := testFuncName(fn)
+		switch {
+		case isGeneric(fn):
+			out.Skipped = append(out.Skipped, name+": generic functions need explicit type arguments")
+			continue
+		case existing[testName]:
+			out.Skipped = append(out.Skipped, name+": "+testName+" already exists")
+			continue
+		}
+		body.WriteString("\n")
+		g.writeTest(&body, fn, testName)
+		out.Functions = append(out.Functions, name)
+	}
+	if len(out.Functions) == 0 {
+		return out, nil
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "package %s\n\n", file.Name.Name)
+	src.WriteString("// Test skeletons generated by coverctl scaffold. Fill in the TODOs.\n\n")
+	src.WriteString("import (\n")
+	std, others := g.importSpecs()
+	for _, spec := range std {
+		fmt.Fprintf(&src, "\t%s\n", spec)
+	}
+	if len(others) > 0 {
+		src.WriteString("\n")
+	}
+	for _, spec := range others {
+		fmt.Fprintf(&src, "\t%s\n", spec)
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return out, fmt.Errorf("format skeleton for %s: %w", target.File, err)
+	}
+	out.Content = formatted
+	return out, nil
+}
+
+// generation accumulates the imports the skeletons of one file need.
+type generation struct {
+	fset    *token.FileSet
+	imports map[string]string // package name -> import spec in the source
+	used    map[string]bool   // import specs the skeletons need
+}
+
+// param is one field of the test table.
+type param struct {
+	field    string
+	typ      string
+	variadic bool
+}
+
+func (g *generation) writeTest(w *bytes.Buffer, fn *ast.FuncDecl, testName string) {
+	var fields []param
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		fields = append(fields, param{field: "r", typ: g.typeString(fn.Recv.List[0].Type)})
+	}
+	var args []param
+	i := 0
+	for _, p := range fn.Type.Params.List {
+		typ := p.Type
+		variadic := false
+		if ell, ok := typ.(*ast.Ellipsis); ok {
+			typ = ell.Elt
+			variadic = true
+		}
+		typeStr := g.typeString(typ)
+		if variadic {
+			typeStr = "[]" + typeStr
+		}
+		names := p.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		for _, n := range names {
+			field := "arg" + strconv.Itoa(i)
+			if n != nil && n.Name != "_" {
+				field = n.Name
+			}
+			if reservedNames[field] {
+				field += "Arg"
+			}
+			args = append(args, param{field: field, typ: typeStr, variadic: variadic})
+			i++
+		}
+	}
+	fields = append(fields, args...)
+
+	var results []string
+	hasErr := false
+	if fn.Type.Results != nil {
+		for _, r := range fn.Type.Results.List {
+			n := len(r.Names)
+			if n == 0 {
+				n = 1
+			}
+			for range n {
+				results = append(results, g.typeString(r.Type))
+			}
+		}
+	}
+	if len(results) > 0 && results[len(results)-1] == "error" {
+		hasErr = true
+		results = results[:len(results)-1]
+	}
+	gots := make([]string, len(results))
+	for i := range results {
+		gots[i] = numbered("got", i)
+		fields = append(fields, param{field: numbered("want", i), typ: results[i]})
+	}
+	if hasErr {
+		fields = append(fields, param{field: "wantErr", typ: "bool"})
+	}
+	if len(results) > 0 {
+		g.used[strconv.Quote("reflect")] = true
+	}
+
+	callArgs := make([]string, len(args))
+	for i, a := range args {
+		callArgs[i] = "tt." + a.field
+		if a.variadic {
+			callArgs[i] += "..."
+		}
+	}
+	call := fn.Name.Name + "(" + strings.Join(callArgs, ", ") + ")"
+	label := fn.Name.Name
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		call = "tt.r." + call
+		label = annotations.ReceiverType(fn.Recv.List[0].Type) + "." + label
+	}
+	lhs := append([]string(nil), gots...)
+	if hasErr {
+		lhs = append(lhs, "err")
+	}
+
+	fmt.Fprintf(w, "func %s(t *testing.T) {\n", testName)
+	w.WriteString("\ttests := []struct {\n\t\tname string\n")
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		w.WriteString("\t\t// TODO: construct the receiver.\n")
+	}
+	for _, f := range fields {
+		fmt.Fprintf(w, "\t\t%s %s\n", f.field, f.typ)
+	}
+	w.WriteString("\t}{\n\t\t// TODO: add test cases.\n\t}\n")
+	w.WriteString("\tfor _, tt := range tests {\n\t\tt.Run(tt.name, func(t *testing.T) {\n")
+	if len(lhs) > 0 {
+		fmt.Fprintf(w, "\t\t\t%s := %s\n", strings.Join(lhs, ", "), call)
+	} else {
+		fmt.Fprintf(w, "\t\t\t%s\n\t\t\t// TODO: check the effects of the call.\n", call)
+	}
+	if hasErr {
+		fmt.Fprintf(w, "\t\t\tif (err != nil) != tt.wantErr {\n\t\t\t\tt.Fatalf(\"%s() error = %%v, wantErr %%v\", err, tt.wantErr)\n\t\t\t}\n", label)
+	}
+	for i, got := range gots {
+		want := numbered("want", i)
+		fmt.Fprintf(w, "\t\t\tif !reflect.DeepEqual(%s, tt.%s) {\n\t\t\t\tt.Errorf(\"%s() %s = %%v, want %%v\", %s, tt.%s)\n\t\t\t}\n", got, want, label, got, got, want)
+	}
+	w.WriteString("\t\t})\n\t}\n}\n")
+}
+
+// typeString prints expr as source and records the imports it refers to.
+func (g *generation) typeString(expr ast.Expr) string {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			if spec, ok := g.imports[pkg.Name]; ok {
+				g.used[spec] = true
+			}
+		}
+		return false
+	})
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, g.fset, expr)
+	return buf.String()
+}
+
+// importSpecs returns the import specs the skeletons need, standard
+// library first, each group sorted by path as goimports would.
+func (g *generation) importSpecs() (std, others []string) {
+	for spec := range g.used {
+		if first, _, _ := strings.Cut(specPath(spec), "/"); strings.Contains(first, ".") {
+			others = append(others, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	byPath := func(specs []string) {
+		sort.Slice(specs, func(i, j int) bool { return specPath(specs[i]) < specPath(specs[j]) })
+	}
+	byPath(std)
+	byPath(others)
+	return std, others
+}
+
+// specPath returns the unquoted path of an import spec.
+func specPath(spec string) string {
+	p, _ := strconv.Unquote(spec[strings.IndexByte(spec, '"'):])
+	return p
+}
+
+// importNames maps the name each import is referred to by to its spec,
+// e.g. "yaml" -> `"gopkg.in/yaml.v3"` or "cfg" -> `cfg "example.com/config"`.
+func importNames(file *ast.File) map[string]string {
+	names := make(map[string]string, len(file.Imports))
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name != "_" && imp.Name.Name != "." {
+				names[imp.Name.Name] = imp.Name.Name + " " + imp.Path.Value
+			}
+			continue
+		}
+		names[packageName(importPath)] = imp.Path.Value
+	}
+	return names
+}
+
+// packageName guesses the package name of an import path from its last
+// element, skipping major-version elements and ".vN" suffixes.
+func packageName(importPath string) string {
+	elem := path.Base(importPath)
+	if versionElem.MatchString(elem) {
+		elem = path.Base(path.Dir(importPath))
+	}
+	if i := strings.Index(elem, ".v"); i > 0 {
+		elem = elem[:i]
+	}
+	return strings.ReplaceAll(elem, "-", "_")
+}
+
+// existingTests returns the Test function names declared in dir's test
+// files, other than skip.
+func existingTests(dir, skip string) (map[string]bool, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, m := range matches {
+		if filepath.Base(m) == skip {
+			continue
+		}
+		src, err := os.ReadFile(m) // #nosec G304 - globbed from the source directory
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), m, src, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && strings.HasPrefix(fn.Name.Name, "Test") {
+				names[fn.Name.Name] = true
+			}
+		}
+	}
+	return names, nil
+}
+
+// testFuncName is TestFunc for functions and TestType_Method for methods.
+func testFuncName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return "Test" + fn.Name.Name
+	}
+	return "Test" + annotations.ReceiverType(fn.Recv.List[0].Type) + "_" + fn.Name.Name
+}
+
+// isGeneric reports whether fn or its receiver declares type parameters.
+func isGeneric(fn *ast.FuncDecl) bool {
+	if fn.Type.TypeParams != nil && len(fn.Type.TypeParams.List) > 0 {
+		return true
+	}
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return false
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch expr.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return true
+	}
+	return false
+}
+
+// numbered returns base for i == 0 and base followed by i otherwise.
+func numbered(base string, i int) string {
+	if i == 0 {
+		return base
+	}
+	return base + strconv.Itoa(i)
+}
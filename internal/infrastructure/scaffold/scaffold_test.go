@@ -0,0 +1,112 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+const source = `package core
+
+import (
+	"io"
+	yaml "gopkg.in/yaml.v3"
+)
+
+type Box struct{}
+
+func Parse(r io.Reader, name string) (*Box, error) { return nil, nil }
+
+func (b *Box) Encode(n *yaml.Node, opts ...string) {}
+
+func Split(s string) (string, string) { return s, s }
+
+func Map[T any](v T) T { return v }
+
+func Existing() {}
+`
+
+func TestGeneratorScaffold(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "internal", "core")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "core.go"), []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "core_test.go"), []byte("package core\n\nimport \"testing\"\n\nfunc TestExisting(t *testing.T) {}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Generator{}.Scaffold(root, application.ScaffoldTarget{
+		File:      "internal/core/core.go",
+		Functions: []string{"Parse()", "Box.Encode()", "Split()", "Map()", "Existing()"},
+	})
+	if err != nil {
+		t.Fatalf("scaffold: %v", err)
+	}
+	if got.TestFile != "internal/core/core_scaffold_test.go" {
+		t.Fatalf("unexpected test file %q", got.TestFile)
+	}
+	if want := []string{"Parse()", "Box.Encode()", "Split()"}; !reflect.DeepEqual(got.Functions, want) {
+		t.Fatalf("unexpected functions %v", got.Functions)
+	}
+	if len(got.Skipped) != 2 || !strings.HasPrefix(got.Skipped[0], "Map()") || !strings.Contains(got.Skipped[1], "TestExisting already exists") {
+		t.Fatalf("unexpected skipped %v", got.Skipped)
+	}
+
+	content := string(got.Content)
+	for _, want := range []string{
+		"package core",
+		`"io"`,
+		`"reflect"`,
+		`"gopkg.in/yaml.v3"`,
+		"func TestParse(t *testing.T)",
+		"rArg    io.Reader",
+		"nameArg string",
+		"wantErr bool",
+		"got, err := Parse(tt.rArg, tt.nameArg)",
+		"func TestBox_Encode(t *testing.T)",
+		"tt.r.Encode(tt.n, tt.opts...)",
+		"opts []string",
+		"got, got1 := Split(tt.s)",
+		"// TODO: add test cases.",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("skeleton missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestGeneratorScaffoldNothingToGenerate(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.go"), []byte("package a\n\nfunc F[T any]() {}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Generator{}.Scaffold(root, application.ScaffoldTarget{File: "a.go", Functions: []string{"F()"}})
+	if err != nil {
+		t.Fatalf("scaffold: %v", err)
+	}
+	if got.Content != nil || len(got.Functions) != 0 || len(got.Skipped) != 1 {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	for path, want := range map[string]string{
+		"io":                      "io",
+		"gopkg.in/yaml.v3":        "yaml",
+		"github.com/x/mod/v2":     "mod",
+		"github.com/x/go-cmp/cmp": "cmp",
+		"example.com/go-errors":   "go_errors",
+	} {
+		if got := packageName(path); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
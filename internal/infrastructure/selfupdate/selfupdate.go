@@ -0,0 +1,313 @@
+// Package selfupdate replaces the running coverctl binary with a GitHub
+// release, verifying the download against the release's checksums.txt.
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAPIURL is the GitHub API endpoint releases are read from.
+	DefaultAPIURL = "https://api.github.com"
+	// Repository is the GitHub repository that publishes coverctl releases.
+	Repository = "felixgeelhaar/coverctl"
+	// DefaultHTTPTimeout bounds each request, including the archive download.
+	DefaultHTTPTimeout = 5 * time.Minute
+	// checksumsAsset is the goreleaser checksum file name.
+	checksumsAsset = "checksums.txt"
+	// maxDownloadBytes caps an archive download.
+	maxDownloadBytes = 256 << 20
+)
+
+// Release is a published coverctl release.
+type Release struct {
+	Tag    string
+	Assets map[string]string // asset name -> download URL
+}
+
+// Client fetches releases and applies them.
+type Client struct {
+	httpClient *http.Client
+	apiURL     string
+	goos       string
+	goarch     string
+}
+
+// NewClient creates a client for the official release repository on
+// api.github.com, for the running platform.
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: DefaultHTTPTimeout},
+		apiURL:     DefaultAPIURL,
+		goos:       runtime.GOOS,
+		goarch:     runtime.GOARCH,
+	}
+}
+
+// NewClientWithHTTP creates a client with a custom HTTP client and API URL.
+//
+// SECURITY: FOR TESTS ONLY. The API URL decides where the replacement binary
+// comes from; production callers must use NewClient, which pins it to
+// api.github.com. The architecture fitness test enforces this.
+func NewClientWithHTTP(httpClient *http.Client, apiURL, goos, goarch string) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &Client{httpClient: httpClient, apiURL: apiURL, goos: goos, goarch: goarch}
+}
+
+// Latest returns the newest published release.
+func (c *Client) Latest(ctx context.Context) (Release, error) {
+	return c.release(ctx, fmt.Sprintf("%s/repos/%s/releases/latest", c.apiURL, Repository))
+}
+
+// Tag returns the release with the given tag, e.g. "v1.14.0".
+func (c *Client) Tag(ctx context.Context, tag string) (Release, error) {
+	if !strings.HasPrefix(tag, "v") {
+		tag = "v" + tag
+	}
+	return c.release(ctx, fmt.Sprintf("%s/repos/%s/releases/tags/%s", c.apiURL, Repository, tag))
+}
+
+func (c *Client) release(ctx context.Context, url string) (Release, error) {
+	body, err := c.get(ctx, url, "application/vnd.github+json", 1<<20)
+	if err != nil {
+		return Release{}, err
+	}
+	var payload struct {
+		TagName string `json:"tag_name"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return Release{}, fmt.Errorf("parse release: %w", err)
+	}
+	rel := Release{Tag: payload.TagName, Assets: make(map[string]string, len(payload.Assets))}
+	for _, asset := range payload.Assets {
+		rel.Assets[asset.Name] = asset.URL
+	}
+	return rel, nil
+}
+
+// ArchiveName is the release asset holding the binary for the client's
+// platform, following the goreleaser archive template.
+func (c *Client) ArchiveName() string {
+	ext := ".tar.gz"
+	if c.goos == "windows" {
+		ext = ".zip"
+	}
+	return c.binaryName() + ext
+}
+
+func (c *Client) binaryName() string {
+	return fmt.Sprintf("coverctl-%s-%s", c.goos, c.goarch)
+}
+
+// Apply downloads rel's archive for the client's platform, checks its
+// SHA-256 against checksums.txt, and atomically replaces the file at target.
+// Nothing is written unless the checksum matches.
+func (c *Client) Apply(ctx context.Context, rel Release, target string) error {
+	archive := c.ArchiveName()
+	archiveURL, ok := rel.Assets[archive]
+	if !ok {
+		return fmt.Errorf("release %s has no %s asset", rel.Tag, archive)
+	}
+	checksumsURL, ok := rel.Assets[checksumsAsset]
+	if !ok {
+		return fmt.Errorf("release %s has no %s; refusing to install an unverified binary", rel.Tag, checksumsAsset)
+	}
+	sums, err := c.get(ctx, checksumsURL, "", 1<<20)
+	if err != nil {
+		return err
+	}
+	want, err := checksumFor(sums, archive)
+	if err != nil {
+		return err
+	}
+	data, err := c.get(ctx, archiveURL, "", maxDownloadBytes)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", archive, got, want)
+	}
+	binary, err := c.extract(data)
+	if err != nil {
+		return err
+	}
+	return replaceFile(target, binary)
+}
+
+func (c *Client) get(ctx context.Context, url, accept string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", url, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("download %s: larger than %d bytes", url, limit)
+	}
+	return data, nil
+}
+
+// checksumFor finds name in a sha256sum-style checksums file.
+func checksumFor(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s is not listed in %s", name, checksumsAsset)
+}
+
+// extract returns the binary from a release archive.
+func (c *Client) extract(data []byte) ([]byte, error) {
+	want := c.binaryName()
+	if c.goos == "windows" {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("open archive: %w", err)
+		}
+		for _, f := range zr.File {
+			if isBinary(f.Name, want) {
+				rc, err := f.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer func() { _ = rc.Close() }()
+				return io.ReadAll(io.LimitReader(rc, maxDownloadBytes))
+			}
+		}
+		return nil, fmt.Errorf("archive does not contain %s", want)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("archive does not contain %s", want)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && isBinary(hdr.Name, want) {
+			return io.ReadAll(io.LimitReader(tr, maxDownloadBytes))
+		}
+	}
+}
+
+func isBinary(name, want string) bool {
+	base := path.Base(name)
+	return base == want || base == want+".exe"
+}
+
+// replaceFile swaps target for data via a temporary file in the same
+// directory, so a failed update leaves the old binary in place. Windows
+// cannot overwrite a running executable, so the old file is moved aside.
+func replaceFile(target string, data []byte) error {
+	dir := filepath.Dir(target)
+	tmp, err := os.CreateTemp(dir, ".coverctl-update-*")
+	if err != nil {
+		return fmt.Errorf("create temp file in %s: %w", dir, err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, 0o755); err != nil { // #nosec G302 -- executables need the exec bit
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		old := target + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(target, old); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmpName, target)
+}
+
+// Newer reports whether release tag latest is newer than current. A
+// development build is older than every release.
+func Newer(current, latest string) bool {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	next, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := range cur {
+		if next[i] != cur[i] {
+			return next[i] > cur[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
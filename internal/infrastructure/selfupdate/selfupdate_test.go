@@ -0,0 +1,144 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// releaseServer serves a release whose checksums.txt lists sum for the
+// linux/amd64 archive.
+func releaseServer(t *testing.T, archive []byte, sum string) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/"+Repository+"/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"tag_name":"v9.9.9","assets":[
+			{"name":"coverctl-linux-amd64.tar.gz","browser_download_url":"%[1]s/dl/archive"},
+			{"name":"checksums.txt","browser_download_url":"%[1]s/dl/checksums"}]}`, srv.URL)
+	})
+	mux.HandleFunc("/dl/archive", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/dl/checksums", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  coverctl-darwin-arm64.tar.gz\n%s  coverctl-linux-amd64.tar.gz\n", strings.Repeat("0", 64), sum)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestApplyReplacesBinary(t *testing.T) {
+	archive := tarGz(t, "coverctl-linux-amd64", []byte("new binary"))
+	digest := sha256.Sum256(archive)
+	srv := releaseServer(t, archive, hex.EncodeToString(digest[:]))
+	client := NewClientWithHTTP(srv.Client(), srv.URL, "linux", "amd64")
+
+	rel, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("latest: %v", err)
+	}
+	if rel.Tag != "v9.9.9" {
+		t.Fatalf("expected tag v9.9.9, got %s", rel.Tag)
+	}
+	target := filepath.Join(t.TempDir(), "coverctl")
+	if err := os.WriteFile(target, []byte("old binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Apply(context.Background(), rel, target); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new binary" {
+		t.Fatalf("binary not replaced: %q", data)
+	}
+}
+
+func TestApplyRejectsChecksumMismatch(t *testing.T) {
+	archive := tarGz(t, "coverctl-linux-amd64", []byte("tampered"))
+	srv := releaseServer(t, archive, strings.Repeat("a", 64))
+	client := NewClientWithHTTP(srv.Client(), srv.URL, "linux", "amd64")
+	rel, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(t.TempDir(), "coverctl")
+	if err := os.WriteFile(target, []byte("old binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	err = client.Apply(context.Background(), rel, target)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "old binary" {
+		t.Fatalf("binary must be untouched, got %q", data)
+	}
+}
+
+func TestApplyMissingPlatformAsset(t *testing.T) {
+	client := NewClientWithHTTP(http.DefaultClient, "", "plan9", "386")
+	err := client.Apply(context.Background(), Release{Tag: "v1.0.0", Assets: map[string]string{}}, "unused")
+	if err == nil || !strings.Contains(err.Error(), "coverctl-plan9-386.tar.gz") {
+		t.Fatalf("expected missing asset error, got %v", err)
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	if got := NewClientWithHTTP(nil, "", "windows", "arm64").ArchiveName(); got != "coverctl-windows-arm64.zip" {
+		t.Fatalf("unexpected windows archive: %s", got)
+	}
+	if got := NewClientWithHTTP(nil, "", "darwin", "arm64").ArchiveName(); got != "coverctl-darwin-arm64.tar.gz" {
+		t.Fatalf("unexpected darwin archive: %s", got)
+	}
+}
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"1.13.0", "v1.14.0", true},
+		{"v1.13.0", "v1.13.0", false},
+		{"1.13.1", "v1.13.0", false},
+		{"1.9.0", "v1.10.0", true},
+		{"dev", "v1.0.0", true},
+		{"1.0.0", "nightly", false},
+		{"v1.13.0-rc.1", "v1.13.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Package shards waits for the coverage profiles of sharded CI jobs and
+// merges them into one profile, for `coverctl merge`.
+package shards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/coverprofile"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers/detector"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers/lcov"
+)
+
+// DefaultInterval is how often Wait looks for new shard profiles.
+const DefaultInterval = 5 * time.Second
+
+// Options configure Wait.
+type Options struct {
+	Dir      string
+	Pattern  string // Glob matched against file names; empty accepts any recognised profile
+	Exclude  string // A path never counted as a shard, such as the merged output under Dir
+	Expect   int
+	Timeout  time.Duration
+	Interval time.Duration // Zero uses DefaultInterval
+}
+
+// MissingError reports shards that had not arrived when Wait gave up.
+type MissingError struct {
+	Dir     string
+	Expect  int
+	Found   []string
+	Missing []int    // Shard numbers, when every profile name carries one
+	Writing []string // Profiles in Found still changing at the last poll
+	Waited  time.Duration
+}
+
+func (e *MissingError) Error() string {
+	msg := fmt.Sprintf("found %d of %d shard profiles in %s after %s", len(e.Found), e.Expect, e.Dir, e.Waited.Round(time.Second))
+	if len(e.Writing) > 0 {
+		msg = fmt.Sprintf("%s (%d still being written)", msg, len(e.Writing))
+		if len(e.Found) >= e.Expect {
+			return msg
+		}
+	}
+	if len(e.Missing) == 0 {
+		return fmt.Sprintf("%s; %d shards missing", msg, e.Expect-len(e.Found))
+	}
+	numbers := make([]string, len(e.Missing))
+	for i, n := range e.Missing {
+		numbers[i] = strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%s; missing shards %s", msg, strings.Join(numbers, ", "))
+}
+
+// Wait polls opts.Dir until it holds opts.Expect complete profiles and
+// returns them, or returns a *MissingError once opts.Timeout passes. A
+// profile counts once its size and modification time are the same on two
+// polls in a row, so a shard still being downloaded is not merged half
+// written. A directory that does not exist yet counts as empty.
+func Wait(ctx context.Context, opts Options) ([]string, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	exclude := ""
+	if opts.Exclude != "" {
+		exclude, _ = filepath.Abs(opts.Exclude)
+	}
+	start := time.Now()
+	var last map[string]fileStamp
+	for {
+		found, err := Find(opts.Dir, opts.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		stamps := make(map[string]fileStamp, len(found))
+		var settled, writing []string
+		kept := found[:0]
+		for _, path := range found {
+			if abs, _ := filepath.Abs(path); exclude != "" && abs == exclude {
+				continue
+			}
+			kept = append(kept, path)
+			stamp := stampFile(path)
+			stamps[path] = stamp
+			switch prev, ok := last[path]; {
+			case ok && prev == stamp:
+				settled = append(settled, path)
+			case ok:
+				writing = append(writing, path)
+			}
+		}
+		found, last = kept, stamps
+		if len(settled) >= opts.Expect {
+			return settled, nil
+		}
+		waited := time.Since(start)
+		if waited >= opts.Timeout {
+			return found, &MissingError{
+				Dir:     opts.Dir,
+				Expect:  opts.Expect,
+				Found:   found,
+				Missing: missingShards(opts.Dir, found, opts.Expect),
+				Writing: writing,
+				Waited:  waited,
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(interval, opts.Timeout-waited)):
+		}
+	}
+}
+
+// fileStamp is what Wait compares between polls to tell whether a profile
+// is still being written.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{size: -1}
+	}
+	return fileStamp{size: info.Size(), modTime: info.ModTime()}
+}
+
+// Find returns the profiles anywhere under dir, sorted. Artifact downloads
+// often put each shard in a directory of its own, so the whole tree is
+// searched. Without a pattern, files in a recognised profile format count.
+func Find(dir, pattern string) ([]string, error) {
+	formats := detector.New()
+	var found []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == dir {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		if pattern != "" {
+			if ok, err := filepath.Match(pattern, d.Name()); err != nil || !ok {
+				return err
+			}
+		} else if format, err := formats.DetectFormat(path); err != nil || format == application.FormatAuto {
+			return nil
+		}
+		found = append(found, path)
+		return nil
+	})
+	sort.Strings(found)
+	return found, err
+}
+
+// shardNumber matches the last number in a profile's path below the
+// artifact directory, e.g. 3 in coverage-3.out or shard-3/coverage.out.
+var shardNumber = regexp.MustCompile(`(\d+)\D*$`)
+
+// missingShards returns the shard numbers absent from found, counting from
+// 0 when a shard 0 arrived and from 1 otherwise. It returns nil when a
+// profile name carries no number or two share one.
+func missingShards(dir string, found []string, expect int) []int {
+	seen := make(map[int]bool, len(found))
+	for _, path := range found {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+		m := shardNumber.FindStringSubmatch(strings.TrimSuffix(rel, filepath.Ext(rel)))
+		if m == nil {
+			return nil
+		}
+		n, _ := strconv.Atoi(m[1])
+		if seen[n] {
+			return nil
+		}
+		seen[n] = true
+	}
+	first := 1
+	if seen[0] {
+		first = 0
+	}
+	var missing []int
+	for n := first; n < first+expect; n++ {
+		if !seen[n] {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+// Merge writes the profiles at paths as one profile and returns its
+// format. Go profiles merge block by block into a Go profile; any other
+// mix merges line hit counts into an LCOV tracefile.
+func Merge(w io.Writer, paths []string) (application.Format, error) {
+	formats := detector.New()
+	allGo := true
+	for _, path := range paths {
+		format, err := formats.DetectFormat(path)
+		if err != nil {
+			return "", err
+		}
+		allGo = allGo && format == application.FormatGo
+	}
+	if allGo {
+		return application.FormatGo, coverprofile.Merge(w, paths)
+	}
+	lines, err := parsers.NewRegistry().ParseAllLines(paths)
+	if err != nil {
+		return "", err
+	}
+	return application.FormatLCOV, lcov.WriteLines(w, lines)
+}
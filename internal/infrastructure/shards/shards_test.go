@@ -0,0 +1,128 @@
+package shards
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func writeShard(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitAndMerge(t *testing.T) {
+	dir := t.TempDir()
+	writeShard(t, filepath.Join(dir, "shard-1", "coverage.out"), "mode: count\nexample.com/a/a.go:1.1,2.2 1 2\nexample.com/a/a.go:3.1,4.2 1 0\n")
+	writeShard(t, filepath.Join(dir, "shard-2", "coverage.out"), "mode: atomic\nexample.com/a/a.go:1.1,2.2 1 3\nexample.com/a/b.go:1.1,2.2 2 1\n")
+	writeShard(t, filepath.Join(dir, "shard-2", "notes.txt"), "not a profile\n")
+
+	// The third shard arrives while Wait polls.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = os.MkdirAll(filepath.Join(dir, "shard-3"), 0o755)
+		_ = os.WriteFile(filepath.Join(dir, "shard-3", "coverage.out"), []byte("mode: count\nexample.com/a/a.go:3.1,4.2 1 1\n"), 0o644)
+	}()
+	found, err := Wait(context.Background(), Options{Dir: dir, Expect: 3, Timeout: 5 * time.Second, Interval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if len(found) != 3 {
+		t.Fatalf("expected 3 shards, got %v", found)
+	}
+
+	var buf bytes.Buffer
+	format, err := Merge(&buf, found)
+	if err != nil || format != application.FormatGo {
+		t.Fatalf("merge: %s, %v", format, err)
+	}
+	want := "mode: count\nexample.com/a/a.go:1.1,2.2 1 5\nexample.com/a/a.go:3.1,4.2 1 1\nexample.com/a/b.go:1.1,2.2 2 1\n"
+	if buf.String() != want {
+		t.Fatalf("merged profile:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWaitMissingShards(t *testing.T) {
+	dir := t.TempDir()
+	writeShard(t, filepath.Join(dir, "coverage-1.out"), "mode: set\nexample.com/a/a.go:1.1,2.2 1 1\n")
+	writeShard(t, filepath.Join(dir, "coverage-3.out"), "mode: set\nexample.com/a/a.go:1.1,2.2 1 0\n")
+
+	_, err := Wait(context.Background(), Options{Dir: dir, Expect: 4, Interval: time.Millisecond})
+	var missing *MissingError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected a MissingError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "found 2 of 4 shard profiles") || !strings.Contains(err.Error(), "missing shards 2, 4") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+
+	_, err = Wait(context.Background(), Options{Dir: filepath.Join(dir, "absent"), Expect: 2})
+	if err == nil || !strings.Contains(err.Error(), "missing shards 1, 2") {
+		t.Fatalf("expected every shard missing from an absent directory, got %v", err)
+	}
+}
+
+func TestWaitSkipsOutputAndUnfinishedProfiles(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "merged.out")
+	writeShard(t, filepath.Join(dir, "coverage-1.out"), "mode: set\nexample.com/a/a.go:1.1,2.2 1 1\n")
+	writeShard(t, output, "mode: set\nexample.com/a/a.go:1.1,2.2 1 1\n")
+
+	_, err := Wait(context.Background(), Options{Dir: dir, Expect: 2, Exclude: output, Timeout: 50 * time.Millisecond, Interval: 5 * time.Millisecond})
+	var missing *MissingError
+	if !errors.As(err, &missing) || len(missing.Found) != 1 {
+		t.Fatalf("expected the merged output not to count as a shard, got %v", err)
+	}
+
+	// A profile that grows on every poll is still downloading.
+	growing := filepath.Join(dir, "coverage-2.out")
+	writeShard(t, growing, "mode: set\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		f, err := os.OpenFile(growing, os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return
+		}
+		defer f.Close()
+		for ctx.Err() == nil {
+			_, _ = f.WriteString("example.com/a/a.go:1.1,2.2 1 1\n")
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	_, err = Wait(ctx, Options{Dir: dir, Expect: 2, Exclude: output, Timeout: 50 * time.Millisecond, Interval: 5 * time.Millisecond})
+	if !errors.As(err, &missing) || len(missing.Writing) != 1 || !strings.Contains(err.Error(), "still being written") {
+		t.Fatalf("expected the growing profile to be reported as still being written, got %v", err)
+	}
+}
+
+func TestMergeMixedFormatsWritesLCOV(t *testing.T) {
+	dir := t.TempDir()
+	goShard := filepath.Join(dir, "go.out")
+	lcovShard := filepath.Join(dir, "web.info")
+	writeShard(t, goShard, "mode: set\nexample.com/a/a.go:1.1,2.2 1 1\n")
+	writeShard(t, lcovShard, "SF:web/app.js\nDA:1,2\nDA:2,0\nend_of_record\n")
+
+	var buf bytes.Buffer
+	format, err := Merge(&buf, []string{goShard, lcovShard})
+	if err != nil || format != application.FormatLCOV {
+		t.Fatalf("merge: %s, %v", format, err)
+	}
+	for _, want := range []string{"SF:web/app.js\nDA:1,2\nDA:2,0\nLF:2\nLH:1\nend_of_record", "SF:example.com/a/a.go\nDA:1,1\nDA:2,1\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected %q in:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Package signing signs and verifies history entries with Ed25519 keys
+// stored as PEM files, the format `openssl genpkey -algorithm ed25519`
+// writes.
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// Algorithm names the signatures this package writes.
+const Algorithm = "ed25519"
+
+// Signer signs with an Ed25519 private key.
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// Verifier checks signatures against an Ed25519 public key.
+type Verifier struct {
+	key   ed25519.PublicKey
+	keyID string
+}
+
+var (
+	_ application.EntrySigner   = (*Signer)(nil)
+	_ application.EntryVerifier = (*Verifier)(nil)
+)
+
+// LoadSigner reads a PKCS #8 "PRIVATE KEY" PEM file.
+func LoadSigner(path string) (*Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("signing key %s: expected a PRIVATE KEY PEM block, got %s", path, block.Type)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s: not an Ed25519 key", path)
+	}
+	keyID, err := keyID(key.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key, keyID: keyID}, nil
+}
+
+// LoadVerifier reads a PKIX "PUBLIC KEY" PEM file. A private key file is
+// accepted too, verifying with its public half.
+func LoadVerifier(path string) (*Verifier, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "PRIVATE KEY" {
+		signer, err := LoadSigner(path)
+		if err != nil {
+			return nil, err
+		}
+		return &Verifier{key: signer.key.Public().(ed25519.PublicKey), keyID: signer.keyID}, nil
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("verification key %s: expected a PUBLIC KEY PEM block, got %s", path, block.Type)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("verification key %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verification key %s: not an Ed25519 key", path)
+	}
+	keyID, err := keyID(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{key: key, keyID: keyID}, nil
+}
+
+// Sign signs payload.
+func (s *Signer) Sign(payload []byte) (domain.Signature, error) {
+	return domain.Signature{
+		Algorithm: Algorithm,
+		KeyID:     s.keyID,
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, payload)),
+	}, nil
+}
+
+// Verify reports why sig is not a signature over payload by this key.
+func (v *Verifier) Verify(payload []byte, sig domain.Signature) error {
+	if sig.Algorithm != Algorithm {
+		return fmt.Errorf("unsupported signature algorithm %q", sig.Algorithm)
+	}
+	if sig.KeyID != v.keyID {
+		return fmt.Errorf("signed with key %s, not %s", sig.KeyID, v.keyID)
+	}
+	value, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	if !ed25519.Verify(v.key, payload, value) {
+		return errors.New("signature does not match the entry")
+	}
+	return nil
+}
+
+// keyID is the first 16 hex digits of the SHA-256 of the public key's
+// PKIX encoding, so the same key always has the same ID.
+func keyID(key ed25519.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	data, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM block found", path)
+	}
+	return block, nil
+}
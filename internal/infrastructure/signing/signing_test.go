@@ -0,0 +1,76 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeKeyPair writes an Ed25519 key pair as openssl would and returns the
+// private and public key paths.
+func writeKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	privPath, pubPath := filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return privPath, pubPath
+}
+
+func TestSignAndVerify(t *testing.T) {
+	privPath, pubPath := writeKeyPair(t)
+	signer, err := LoadSigner(privPath)
+	if err != nil {
+		t.Fatalf("load signer: %v", err)
+	}
+	verifier, err := LoadVerifier(pubPath)
+	if err != nil {
+		t.Fatalf("load verifier: %v", err)
+	}
+	sig, err := signer.Sign([]byte("payload"))
+	if err != nil || sig.Algorithm != Algorithm || len(sig.KeyID) != 16 {
+		t.Fatalf("unexpected signature %+v, %v", sig, err)
+	}
+	if err := verifier.Verify([]byte("payload"), sig); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := verifier.Verify([]byte("tampered"), sig); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected a mismatch, got %v", err)
+	}
+
+	// The private key verifies too; another key pair does not.
+	if fromPrivate, err := LoadVerifier(privPath); err != nil || fromPrivate.Verify([]byte("payload"), sig) != nil {
+		t.Fatalf("expected the private key file to verify: %v", err)
+	}
+	_, otherPub := writeKeyPair(t)
+	other, err := LoadVerifier(otherPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Verify([]byte("payload"), sig); err == nil || !strings.Contains(err.Error(), "signed with key") {
+		t.Fatalf("expected a key mismatch, got %v", err)
+	}
+	if _, err := LoadSigner(pubPath); err == nil {
+		t.Fatal("expected a public key to be rejected for signing")
+	}
+}
@@ -0,0 +1,100 @@
+// Package snapshot reads the per-service inputs of `coverctl aggregate`:
+// JSON reports written by --output json or --report-file, and history
+// files written by `coverctl record`.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// Loader implements application.SnapshotSource over the filesystem.
+type Loader struct{}
+
+// probe holds the top-level keys that tell the input kinds apart.
+type probe struct {
+	Entries *[]domain.HistoryEntry `json:"entries"`
+	Domains *[]domain.DomainResult `json:"domains"`
+	Summary *struct {
+		Pass bool `json:"pass"`
+	} `json:"summary"`
+	Result *domain.Result `json:"result"`
+}
+
+// Snapshots expands each pattern as a glob, or takes it as a path when it
+// has no glob characters, and reads every file once in sorted order. A
+// pattern that matches nothing is an error, so a typo does not silently
+// drop a service.
+func (Loader) Snapshots(patterns []string) ([]application.ServiceSnapshot, error) {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("input %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("input %q matches no files", pattern)
+		}
+		sort.Strings(matches)
+		for _, m := range matches {
+			if !seen[m] {
+				seen[m] = true
+				paths = append(paths, m)
+			}
+		}
+	}
+
+	out := make([]application.ServiceSnapshot, 0, len(paths))
+	for _, path := range paths {
+		snap, err := read(path)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, snap)
+	}
+	return out, nil
+}
+
+func read(path string) (application.ServiceSnapshot, error) {
+	cleanPath, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return application.ServiceSnapshot{}, fmt.Errorf("invalid path: %w", err)
+	}
+	data, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return application.ServiceSnapshot{}, err
+	}
+	var p probe
+	if err := json.Unmarshal(data, &p); err != nil {
+		return application.ServiceSnapshot{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	snap := application.ServiceSnapshot{Path: path}
+	switch {
+	case p.Entries != nil:
+		snap.History = &domain.History{Entries: *p.Entries}
+	case p.Result != nil:
+		// --report-file wraps the result of check, report, or gate.
+		snap.Result = p.Result
+	case p.Domains != nil:
+		var result domain.Result
+		if err := json.Unmarshal(data, &result); err != nil {
+			return application.ServiceSnapshot{}, fmt.Errorf("%s: %w", path, err)
+		}
+		if p.Summary != nil {
+			result.Passed = p.Summary.Pass
+		}
+		snap.Result = &result
+	default:
+		return application.ServiceSnapshot{}, fmt.Errorf("%s: not a coverctl JSON report or history file", path)
+	}
+	return snap, nil
+}
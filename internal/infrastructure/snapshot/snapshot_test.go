@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func TestLoaderSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "api.json"), `{"schema":"x","domains":[{"domain":"core","covered":8,"total":10,"percent":80,"required":70,"status":"PASS"}],"files":[],"summary":{"pass":true},"warnings":[]}`)
+	writeFile(t, filepath.Join(dir, "gate.json"), `{"schema":"x","command":"gate","passed":false,"result":{"domains":[],"passed":false}}`)
+	writeFile(t, filepath.Join(dir, "ledger", "history.json"), `{"entries":[{"timestamp":"2026-03-01T00:00:00Z","overall":61.5,"domains":{}}]}`)
+
+	snaps, err := Loader{}.Snapshots([]string{filepath.Join(dir, "*.json"), filepath.Join(dir, "*", "history.json"), filepath.Join(dir, "api.json")})
+	if err != nil {
+		t.Fatalf("snapshots: %v", err)
+	}
+	if len(snaps) != 3 {
+		t.Fatalf("expected 3 deduplicated inputs, got %d", len(snaps))
+	}
+	if snaps[0].Result == nil || !snaps[0].Result.Passed || snaps[0].Result.Domains[0].Covered != 8 {
+		t.Fatalf("unexpected JSON report snapshot: %+v", snaps[0])
+	}
+	if snaps[1].Result == nil || snaps[1].Result.Passed {
+		t.Fatalf("unexpected report-file snapshot: %+v", snaps[1])
+	}
+	if snaps[2].History == nil || snaps[2].History.Entries[0].Overall != 61.5 {
+		t.Fatalf("unexpected history snapshot: %+v", snaps[2])
+	}
+}
+
+func TestLoaderSnapshotsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "other.json"), `{"name":"not coverage"}`)
+	tests := map[string]struct {
+		pattern string
+		want    string
+	}{
+		"no match":      {pattern: filepath.Join(dir, "missing-*.json"), want: "matches no files"},
+		"unknown shape": {pattern: filepath.Join(dir, "other.json"), want: "not a coverctl JSON report"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Loader{}.Snapshots([]string{tt.pattern})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q error, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Package telemetry exports coverctl spans and metrics with OpenTelemetry.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+const instrumentationName = "github.com/felixgeelhaar/coverctl"
+
+// endpointEnvVars enable export when any of them is set. The OTLP exporters
+// read these and the remaining OTEL_EXPORTER_OTLP_* settings themselves.
+var endpointEnvVars = []string{
+	"OTEL_EXPORTER_OTLP_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+}
+
+// Enabled reports whether the environment asks for OTLP export.
+func Enabled(getenv func(string) string) bool {
+	if strings.EqualFold(getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	for _, key := range endpointEnvVars {
+		if getenv(key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// OTel records coverctl phases as spans and duration histograms, and
+// coverage results as gauges.
+type OTel struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	overall  metric.Float64Gauge
+	domains  metric.Float64Gauge
+	shutdown []func(context.Context) error
+}
+
+// NewFromEnv builds OTLP/HTTP trace and metric exporters configured by the
+// standard OTEL_* environment variables.
+func NewFromEnv(ctx context.Context, version string) (*OTel, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "coverctl"),
+			attribute.String("service.version", version),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)), sdkmetric.WithResource(res))
+	o, err := New(tp, mp)
+	if err != nil {
+		return nil, err
+	}
+	o.shutdown = []func(context.Context) error{tp.Shutdown, mp.Shutdown}
+	return o, nil
+}
+
+// New creates instruments from the given providers.
+func New(tp trace.TracerProvider, mp metric.MeterProvider) (*OTel, error) {
+	meter := mp.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram("coverctl.phase.duration",
+		metric.WithDescription("Duration of coverctl phases (test run, parse, aggregate)"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	overall, err := meter.Float64Gauge("coverctl.coverage.overall",
+		metric.WithDescription("Overall statement coverage percentage"),
+		metric.WithUnit("%"))
+	if err != nil {
+		return nil, err
+	}
+	domains, err := meter.Float64Gauge("coverctl.coverage.domain",
+		metric.WithDescription("Statement coverage percentage per domain"),
+		metric.WithUnit("%"))
+	if err != nil {
+		return nil, err
+	}
+	return &OTel{
+		tracer:   tp.Tracer(instrumentationName),
+		duration: duration,
+		overall:  overall,
+		domains:  domains,
+	}, nil
+}
+
+// StartPhase starts a span named coverctl.<phase>; the returned function
+// ends it and records its duration.
+func (o *OTel) StartPhase(ctx context.Context, phase string, attrs map[string]string) (context.Context, func(error)) {
+	kv := make([]attribute.KeyValue, 0, len(attrs)+1)
+	kv = append(kv, attribute.String("phase", phase))
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		kv = append(kv, attribute.String(k, attrs[k]))
+	}
+
+	start := time.Now()
+	ctx, span := o.tracer.Start(ctx, "coverctl."+phase, trace.WithAttributes(kv...))
+	return ctx, func(err error) {
+		outcome := "ok"
+		if err != nil {
+			outcome = "error"
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		o.duration.Record(ctx, time.Since(start).Seconds(),
+			metric.WithAttributes(append(kv, attribute.String("outcome", outcome))...))
+		span.End()
+	}
+}
+
+// RecordResult records overall and per-domain coverage, and annotates the
+// active span with the overall value and verdict.
+func (o *OTel) RecordResult(ctx context.Context, command string, result domain.Result) {
+	overall := result.OverallPercent()
+	cmd := attribute.String("command", command)
+	o.overall.Record(ctx, overall, metric.WithAttributes(cmd))
+	for _, d := range result.Domains {
+		o.domains.Record(ctx, d.Percent, metric.WithAttributes(
+			cmd,
+			attribute.String("domain", d.Domain),
+			attribute.String("status", string(d.Status)),
+		))
+	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Float64("coverage.overall", overall),
+		attribute.Bool("coverage.passed", result.Passed),
+	)
+}
+
+// Shutdown flushes pending spans and metrics.
+func (o *OTel) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, fn := range o.shutdown {
+		errs = append(errs, fn(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+var _ application.Telemetry = (*OTel)(nil)
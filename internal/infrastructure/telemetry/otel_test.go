@@ -0,0 +1,92 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestEnabled(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	if Enabled(env(nil)) {
+		t.Fatal("expected disabled without endpoint")
+	}
+	if !Enabled(env(map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"})) {
+		t.Fatal("expected enabled with endpoint")
+	}
+	if !Enabled(env(map[string]string{"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "http://collector:4318/v1/metrics"})) {
+		t.Fatal("expected enabled with signal endpoint")
+	}
+	if Enabled(env(map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318", "OTEL_SDK_DISABLED": "true"})) {
+		t.Fatal("expected OTEL_SDK_DISABLED to win")
+	}
+}
+
+func TestOTelPhasesAndResults(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	o, err := New(tp, mp)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	ctx, endCheck := o.StartPhase(context.Background(), "check", nil)
+	_, endRun := o.StartPhase(ctx, "run", map[string]string{"runner": "go"})
+	endRun(errors.New("tests failed"))
+	o.RecordResult(ctx, "check", domain.Result{
+		Passed:  true,
+		Domains: []domain.DomainResult{{Domain: "core", Covered: 8, Total: 10, Percent: 80, Status: domain.StatusPass}},
+	})
+	endCheck(nil)
+
+	ended := spans.Ended()
+	if len(ended) != 2 || ended[0].Name() != "coverctl.run" || ended[1].Name() != "coverctl.check" {
+		t.Fatalf("unexpected spans: %v", ended)
+	}
+	if ended[0].Parent().SpanID() != ended[1].SpanContext().SpanID() {
+		t.Fatal("expected run span to be a child of check")
+	}
+	if ended[0].Status().Code != codes.Error {
+		t.Fatalf("expected run span error status, got %v", ended[0].Status())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			if m.Name != "coverctl.coverage.domain" {
+				continue
+			}
+			gauge := m.Data.(metricdata.Gauge[float64])
+			if len(gauge.DataPoints) != 1 || gauge.DataPoints[0].Value != 80 {
+				t.Fatalf("unexpected domain gauge: %+v", gauge.DataPoints)
+			}
+			if v, ok := gauge.DataPoints[0].Attributes.Value(attribute.Key("domain")); !ok || v.AsString() != "core" {
+				t.Fatalf("expected domain attribute, got %v", gauge.DataPoints[0].Attributes)
+			}
+		}
+	}
+	for _, name := range []string{"coverctl.phase.duration", "coverctl.coverage.overall", "coverctl.coverage.domain"} {
+		if !found[name] {
+			t.Fatalf("expected metric %s, got %v", name, found)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Package testprofiles lists the per-test coverage profiles that
+// `coverctl select` uses to map changed files to the tests covering them.
+//
+// The layout mirrors the module: the profile of TestParse in ./internal/core
+// is <dir>/internal/core/TestParse.out.
+package testprofiles
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// Dir implements application.TestProfileSource over the filesystem.
+type Dir struct{}
+
+// TestProfiles walks dir for Test*.out files. A missing directory is an
+// error, so a CI cache miss does not silently select by package.
+func (Dir) TestProfiles(dir string) ([]application.TestProfile, error) {
+	cleanDir, err := pathutil.ValidatePath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	var out []application.TestProfile
+	err = filepath.WalkDir(cleanDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() || filepath.Ext(name) != ".out" || !strings.HasPrefix(name, "Test") {
+			return nil
+		}
+		rel, err := filepath.Rel(cleanDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		out = append(out, application.TestProfile{
+			Dir:  filepath.ToSlash(rel),
+			Test: strings.TrimSuffix(name, ".out"),
+			Path: path,
+		})
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("per-test profile directory %s does not exist", dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
@@ -0,0 +1,39 @@
+package testprofiles
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+func TestDirTestProfiles(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"TestMain.out", "internal/core/TestParse.out", "internal/core/notes.txt", "internal/core/helper.out"} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("mode: set\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := Dir{}.TestProfiles(root)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	want := []application.TestProfile{
+		{Dir: ".", Test: "TestMain", Path: filepath.Join(root, "TestMain.out")},
+		{Dir: "internal/core", Test: "TestParse", Path: filepath.Join(root, "internal", "core", "TestParse.out")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected profiles:\n got %+v\nwant %+v", got, want)
+	}
+
+	if _, err := (Dir{}).TestProfiles(filepath.Join(root, "missing")); err == nil {
+		t.Fatal("expected error for a missing directory")
+	}
+}
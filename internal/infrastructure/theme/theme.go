@@ -0,0 +1,151 @@
+// Package theme is coverctl's terminal palette. It decides when output may
+// carry colour — only on a terminal, never with NO_COLOR set, and never
+// after --no-color or --ci — and renders status words and deltas the same
+// way in reports, watch mode, and the init wizard.
+package theme
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+)
+
+// Palette shared by every coloured surface.
+var (
+	Green  = lipgloss.Color("#16A34A")
+	Red    = lipgloss.Color("#DC2626")
+	Yellow = lipgloss.Color("#CA8A04")
+	Orange = lipgloss.Color("#F97316")
+	Sky    = lipgloss.Color("#0EA5E9")
+	Lime   = lipgloss.Color("#84CC16")
+	Slate  = lipgloss.Color("#64748B")
+	Ink    = lipgloss.Color("#0F172A")
+)
+
+var (
+	passStyle  = lipgloss.NewStyle().Foreground(Green).Bold(true)
+	failStyle  = lipgloss.NewStyle().Foreground(Red).Bold(true)
+	warnStyle  = lipgloss.NewStyle().Foreground(Yellow).Bold(true)
+	upStyle    = lipgloss.NewStyle().Foreground(Green)
+	downStyle  = lipgloss.NewStyle().Foreground(Red)
+	mutedStyle = lipgloss.NewStyle().Foreground(Slate)
+)
+
+var (
+	mu             sync.Mutex
+	disabled       bool
+	defaultProfile *termenv.Profile
+)
+
+// SetEnabled allows or forbids colour for the rest of the process. When
+// colour is off, by this call or NO_COLOR, lipgloss rendering that does not
+// go through a Theme, such as the init wizard, is stripped of colour too.
+func SetEnabled(on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if defaultProfile == nil {
+		profile := lipgloss.ColorProfile()
+		defaultProfile = &profile
+	}
+	disabled = !on
+	if on && os.Getenv("NO_COLOR") == "" {
+		lipgloss.SetColorProfile(*defaultProfile)
+	} else {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// Enabled reports whether text written to w may carry colour.
+func Enabled(w io.Writer) bool {
+	mu.Lock()
+	off := disabled
+	mu.Unlock()
+	if off || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return IsTerminal(w)
+}
+
+// IsTerminal reports whether w is a terminal, regardless of colour settings.
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+}
+
+// Theme renders text for one writer, in colour only when it allows it.
+type Theme struct {
+	color bool
+}
+
+// For returns the theme for w.
+func For(w io.Writer) Theme {
+	return Theme{color: Enabled(w)}
+}
+
+// Color reports whether the theme emits colour.
+func (t Theme) Color() bool {
+	return t.color
+}
+
+// Status renders a PASS/WARN/FAIL word in its colour.
+func (t Theme) Status(s domain.Status) string {
+	text := string(s)
+	switch s {
+	case domain.StatusPass:
+		return t.render(passStyle, text)
+	case domain.StatusFail:
+		return t.render(failStyle, text)
+	case domain.StatusWarn:
+		return t.render(warnStyle, text)
+	}
+	return text
+}
+
+// Delta renders a percentage-point change such as "+1.2%". In colour it
+// gains a trend arrow: green ↑ for gains, red ↓ for losses, grey → when
+// unchanged.
+func (t Theme) Delta(delta float64) string {
+	text := fmt.Sprintf("%+.1f%%", delta)
+	if !t.color {
+		return text
+	}
+	switch {
+	case delta > 0:
+		return upStyle.Render("↑ " + text)
+	case delta < 0:
+		return downStyle.Render("↓ " + text)
+	}
+	return mutedStyle.Render("→ " + text)
+}
+
+// Pass renders a success mark or message; plain is used without colour.
+func (t Theme) Pass(colored, plain string) string {
+	if !t.color {
+		return plain
+	}
+	return passStyle.Render(colored)
+}
+
+// Fail renders a failure mark or message; plain is used without colour.
+func (t Theme) Fail(colored, plain string) string {
+	if !t.color {
+		return plain
+	}
+	return failStyle.Render(colored)
+}
+
+func (t Theme) render(style lipgloss.Style, text string) string {
+	if !t.color {
+		return text
+	}
+	return style.Render(text)
+}
@@ -0,0 +1,60 @@
+package theme
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func TestEnabled(t *testing.T) {
+	if Enabled(&bytes.Buffer{}) {
+		t.Error("expected no colour for a non-file writer")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if Enabled(os.Stdout) {
+		t.Error("expected NO_COLOR to disable colour")
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	t.Cleanup(func() { SetEnabled(true) })
+	SetEnabled(false)
+	if Enabled(os.Stdout) {
+		t.Error("expected SetEnabled(false) to disable colour")
+	}
+}
+
+func TestThemePlain(t *testing.T) {
+	th := For(&bytes.Buffer{})
+	if th.Color() {
+		t.Fatal("expected plain theme")
+	}
+	if got := th.Status(domain.StatusFail); got != "FAIL" {
+		t.Errorf("Status = %q", got)
+	}
+	if got := th.Delta(-1.5); got != "-1.5%" {
+		t.Errorf("Delta = %q", got)
+	}
+	if got := th.Pass("✓", "v"); got != "v" {
+		t.Errorf("Pass = %q", got)
+	}
+	if got := th.Fail("✗", "x"); got != "x" {
+		t.Errorf("Fail = %q", got)
+	}
+}
+
+func TestThemeColorDeltaArrows(t *testing.T) {
+	th := Theme{color: true}
+	for delta, arrow := range map[float64]string{2: "↑ +2.0%", -0.5: "↓ -0.5%", 0: "→ +0.0%"} {
+		if got := th.Delta(delta); !strings.Contains(got, arrow) {
+			t.Errorf("Delta(%v) = %q, want it to contain %q", delta, got, arrow)
+		}
+	}
+	if got := th.Status(domain.StatusWarn); !strings.Contains(got, "WARN") {
+		t.Errorf("Status = %q", got)
+	}
+}
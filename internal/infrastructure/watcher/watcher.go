@@ -15,6 +15,7 @@ type Watcher struct {
 	watcher    *fsnotify.Watcher
 	debounce   time.Duration
 	extensions []string
+	files      map[string]bool // Absolute paths added with WatchFile
 }
 
 // Option configures the watcher.
@@ -45,6 +46,7 @@ func New(opts ...Option) (*Watcher, error) {
 		watcher:    fsw,
 		debounce:   500 * time.Millisecond,
 		extensions: []string{".go"},
+		files:      make(map[string]bool),
 	}
 
 	for _, opt := range opts {
@@ -72,6 +74,22 @@ func (w *Watcher) WatchDir(root string) error {
 	})
 }
 
+// WatchFile watches a single file regardless of its extension. The
+// parent directory is watched instead of the file itself so the watch
+// survives tools that replace the file by renaming a new one into place.
+// The parent directory must exist; the file need not.
+func (w *Watcher) WatchFile(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if err := w.watcher.Add(filepath.Dir(abs)); err != nil {
+		return err
+	}
+	w.files[abs] = true
+	return nil
+}
+
 // Events returns a channel that emits when relevant files change.
 // The channel is debounced to avoid rapid successive triggers.
 func (w *Watcher) Events(ctx context.Context) <-chan struct{} {
@@ -100,7 +118,7 @@ func (w *Watcher) Events(ctx context.Context) <-chan struct{} {
 				if !isWriteEvent(event.Op) {
 					continue
 				}
-				if !w.hasRelevantExtension(event.Name) {
+				if !w.files[event.Name] && !w.hasRelevantExtension(event.Name) {
 					continue
 				}
 
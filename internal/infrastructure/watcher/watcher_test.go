@@ -210,3 +210,45 @@ func TestHasRelevantExtension(t *testing.T) {
 		}
 	}
 }
+
+func TestWatcherWatchFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := filepath.Join(tmpDir, ".cover")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	w, err := New(WithDebounce(50*time.Millisecond), WithExtensions())
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer w.Close()
+
+	profile := filepath.Join(dir, "coverage.out")
+	if err := w.WatchFile(profile); err != nil {
+		t.Fatalf("watch file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	events := w.Events(ctx)
+
+	// A sibling file must not trigger; the watched file must.
+	if err := os.WriteFile(filepath.Join(dir, "other.out"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("write sibling: %v", err)
+	}
+	select {
+	case <-events:
+		t.Fatal("should not receive event for unwatched sibling")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := os.WriteFile(profile, []byte("mode: set\n"), 0o644); err != nil {
+		t.Fatalf("write profile: %v", err)
+	}
+	select {
+	case <-events:
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for watched file event")
+	}
+}
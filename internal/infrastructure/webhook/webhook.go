@@ -0,0 +1,55 @@
+// Package webhook posts JSON payloads to user-configured webhook URLs, for
+// the notify and events adapters.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultHTTPTimeout bounds a single webhook delivery.
+const DefaultHTTPTimeout = 10 * time.Second
+
+// NewClient returns an HTTP client with the default delivery timeout.
+func NewClient() *http.Client {
+	return &http.Client{Timeout: DefaultHTTPTimeout}
+}
+
+// PostJSON marshals payload and posts it to rawURL. ${VAR} references in
+// the URL are expanded from the environment so the secret URL can stay out
+// of the config file. name ("webhook", "events webhook") labels errors,
+// which name the host only: the URL usually embeds a secret token.
+func PostJSON(ctx context.Context, client *http.Client, name, rawURL string, payload any) error {
+	target := os.ExpandEnv(rawURL)
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("invalid %s URL", name)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to %s failed", parsed.Host)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s", name, parsed.Host, resp.Status)
+	}
+	return nil
+}
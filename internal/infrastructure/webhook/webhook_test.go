@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostJSON(t *testing.T) {
+	var got map[string]string
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("HOOK_PATH", "/secret-token")
+
+	if err := PostJSON(context.Background(), srv.Client(), "webhook", srv.URL+"${HOOK_PATH}", map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if got["a"] != "b" || contentType != "application/json" {
+		t.Fatalf("unexpected request %v %q", got, contentType)
+	}
+
+	err := PostJSON(context.Background(), srv.Client(), "events webhook", srv.URL+"/fail", nil)
+	if err == nil || !strings.Contains(err.Error(), "events webhook") || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected a status error naming the webhook, got %v", err)
+	}
+	if err := PostJSON(context.Background(), srv.Client(), "webhook", "ftp://example.com", nil); err == nil || err.Error() != "invalid webhook URL" {
+		t.Fatalf("expected an invalid URL error, got %v", err)
+	}
+}
+
+func TestNewClientTimeout(t *testing.T) {
+	if got := NewClient().Timeout; got != DefaultHTTPTimeout {
+		t.Fatalf("expected %v timeout, got %v", DefaultHTTPTimeout, got)
+	}
+}
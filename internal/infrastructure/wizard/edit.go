@@ -0,0 +1,288 @@
+package wizard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/theme"
+)
+
+// editMode is the sub-mode of the review step: browsing thresholds, typing
+// into a prompt, or editing the exclude list.
+type editMode int
+
+const (
+	modeBrowse editMode = iota
+	modeAddName
+	modeAddMatch
+	modeRename
+	modeExcludes
+	modeAddExclude
+)
+
+// goListTimeout bounds match pattern validation.
+const goListTimeout = 30 * time.Second
+
+// validateMatchPattern checks new match patterns; tests replace it.
+var validateMatchPattern = validateGoPattern
+
+var errorStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Red)
+
+// validateGoPattern reports an error when pattern matches no package of the
+// Go module in the working directory. Outside a Go module every pattern is
+// accepted, since other languages match directories by glob at check time.
+func validateGoPattern(pattern string) error {
+	if _, err := os.Stat("go.mod"); err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), goListTimeout)
+	defer cancel()
+	resolver := gotool.DomainResolver{Module: gotool.ModuleResolver{}}
+	dirs, err := resolver.Resolve(ctx, []domain.Domain{{Name: "candidate", Match: []string{pattern}}})
+	if err != nil {
+		return fmt.Errorf("go list %s failed: %w", pattern, err)
+	}
+	if len(dirs["candidate"]) == 0 {
+		return fmt.Errorf("%s matches no packages", pattern)
+	}
+	return nil
+}
+
+// updateEditing handles keys while a prompt or the exclude editor is open.
+// Letters are text here, so "q" does not quit.
+func (m *initWizardModel) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		m.aborted = true
+		return m, tea.Quit
+	}
+	if m.mode == modeExcludes {
+		m.updateExcludes(msg)
+		return m, nil
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closePrompt()
+	case tea.KeyEnter:
+		m.submitPrompt()
+	case tea.KeyBackspace:
+		if runes := []rune(m.input); len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.input += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// updateBrowse handles the domain editing keys of the review step. It
+// reports whether the key was one of them.
+func (m *initWizardModel) updateBrowse(key string) bool {
+	switch key {
+	case "a":
+		m.openPrompt(modeAddName, "")
+	case "r":
+		if m.cursor == 0 {
+			m.message = "select a domain to rename"
+			return true
+		}
+		m.openPrompt(modeRename, m.domains[m.cursor-1].domain.Name)
+	case "d", "delete":
+		m.deleteSelected()
+	case "e":
+		m.mode = modeExcludes
+		m.message = ""
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *initWizardModel) updateExcludes(msg tea.KeyMsg) {
+	m.message = ""
+	switch msg.String() {
+	case "up":
+		if m.excludeCursor > 0 {
+			m.excludeCursor--
+		}
+	case "down":
+		if m.excludeCursor < len(m.exclude)-1 {
+			m.excludeCursor++
+		}
+	case "a":
+		m.openPrompt(modeAddExclude, "")
+	case "d", "delete":
+		if len(m.exclude) == 0 {
+			return
+		}
+		m.exclude = append(m.exclude[:m.excludeCursor], m.exclude[m.excludeCursor+1:]...)
+		if m.excludeCursor >= len(m.exclude) && m.excludeCursor > 0 {
+			m.excludeCursor--
+		}
+	case "esc", "enter":
+		m.mode = modeBrowse
+	}
+}
+
+func (m *initWizardModel) openPrompt(mode editMode, initial string) {
+	m.mode = mode
+	m.input = initial
+	m.message = ""
+}
+
+// closePrompt cancels the open prompt, returning to where it was opened.
+func (m *initWizardModel) closePrompt() {
+	if m.mode == modeAddExclude {
+		m.mode = modeExcludes
+	} else {
+		m.mode = modeBrowse
+	}
+	m.input = ""
+	m.pendingName = ""
+	m.message = ""
+}
+
+// submitPrompt applies the prompt input, keeping the prompt open with a
+// message when it does not validate.
+func (m *initWizardModel) submitPrompt() {
+	value := strings.TrimSpace(m.input)
+	switch m.mode {
+	case modeAddName:
+		if err := m.checkDomainName(value, -1); err != nil {
+			m.message = err.Error()
+			return
+		}
+		m.pendingName = value
+		m.openPrompt(modeAddMatch, "./internal/"+value+"/...")
+		return
+	case modeAddMatch:
+		if err := m.checkMatchPattern(value); err != nil {
+			m.message = err.Error()
+			return
+		}
+		m.addDomain(m.pendingName, value)
+		m.cursor = len(m.domains)
+	case modeRename:
+		index := m.cursor - 1
+		if err := m.checkDomainName(value, index); err != nil {
+			m.message = err.Error()
+			return
+		}
+		m.domains[index].domain.Name = value
+	case modeAddExclude:
+		if err := m.checkExclude(value); err != nil {
+			m.message = err.Error()
+			return
+		}
+		m.exclude = append(m.exclude, value)
+		m.excludeCursor = len(m.exclude) - 1
+	}
+	m.closePrompt()
+}
+
+// checkDomainName rejects empty, blank-containing, and duplicate names;
+// index is the domain being renamed, or -1 for a new one.
+func (m *initWizardModel) checkDomainName(name string, index int) error {
+	if name == "" {
+		return errors.New("domain name is required")
+	}
+	if strings.ContainsAny(name, " \t") {
+		return errors.New("domain name must not contain spaces")
+	}
+	for i, dom := range m.domains {
+		if i != index && dom.domain.Name == name {
+			return fmt.Errorf("domain %s already exists", name)
+		}
+	}
+	return nil
+}
+
+func (m *initWizardModel) checkMatchPattern(pattern string) error {
+	if pattern == "" {
+		return errors.New("match pattern is required")
+	}
+	if m.validateMatch == nil {
+		return nil
+	}
+	return m.validateMatch(pattern)
+}
+
+func (m *initWizardModel) checkExclude(pattern string) error {
+	if pattern == "" {
+		return errors.New("exclude pattern is required")
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid exclude pattern %s: %w", pattern, err)
+	}
+	for _, existing := range m.exclude {
+		if existing == pattern {
+			return fmt.Errorf("%s is already excluded", pattern)
+		}
+	}
+	return nil
+}
+
+// deleteSelected removes the domain under the cursor, keeping at least one.
+func (m *initWizardModel) deleteSelected() {
+	if m.cursor == 0 {
+		m.message = "select a domain to delete"
+		return
+	}
+	if len(m.domains) == 1 {
+		m.message = "keep at least one domain"
+		return
+	}
+	index := m.cursor - 1
+	m.domains = append(m.domains[:index], m.domains[index+1:]...)
+	if m.cursor > len(m.domains) {
+		m.cursor = len(m.domains)
+	}
+	m.message = ""
+}
+
+// viewPrompt renders the open prompt and any validation message.
+func (m *initWizardModel) viewPrompt() string {
+	var b strings.Builder
+	label := map[editMode]string{
+		modeAddName:    "New domain name",
+		modeAddMatch:   fmt.Sprintf("Match pattern for %s", m.pendingName),
+		modeRename:     "Rename domain to",
+		modeAddExclude: "Exclude pattern",
+	}[m.mode]
+	if label != "" {
+		fmt.Fprintf(&b, "\n%s: %s\n", label, valueStyle.Render(m.input+"_"))
+		fmt.Fprintf(&b, "%s\n", subtleStyle.Render("Enter to accept, Esc to cancel."))
+	}
+	if m.message != "" {
+		fmt.Fprintf(&b, "%s\n", errorStyle.Render(m.message))
+	}
+	return b.String()
+}
+
+func (m *initWizardModel) viewExcludes() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%s %s\n", titleStyle.Render("Edit exclusions"), badgeStyle.Render("step 2/3"))
+	fmt.Fprintf(&b, "%s\n", m.stepper())
+	fmt.Fprintf(&b, "%s\n\n", subtleStyle.Render("Use ↑/↓ to move, a to add, d to delete."))
+	if len(m.exclude) == 0 {
+		fmt.Fprintf(&b, "  %s\n", subtleStyle.Render("No exclusions configured."))
+	}
+	for idx, pattern := range m.exclude {
+		if m.mode == modeExcludes && idx == m.excludeCursor {
+			fmt.Fprintf(&b, "%s\n", selectedRow.Render(pattern))
+		} else {
+			fmt.Fprintf(&b, "  %s\n", pattern)
+		}
+	}
+	b.WriteString(m.viewPrompt())
+	fmt.Fprintf(&b, "\nPress %s to return to domains.\n", keyStyle.Render("Esc"))
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package wizard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeText(m *initWizardModel, text string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+}
+
+func editModel(t *testing.T) *initWizardModel {
+	t.Helper()
+	model := newInitWizardModel(minimalConfig())
+	model.validateMatch = func(pattern string) error {
+		if strings.Contains(pattern, "missing") {
+			return errors.New(pattern + " matches no packages")
+		}
+		return nil
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return model
+}
+
+func TestInitWizardAddDomain(t *testing.T) {
+	model := editModel(t)
+	typeText(model, "a")
+	if model.mode != modeAddName {
+		t.Fatalf("expected name prompt, got mode %d", model.mode)
+	}
+	typeText(model, "api")
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.mode != modeAddMatch || model.input != "./internal/api/..." {
+		t.Fatalf("expected prefilled match prompt, got mode %d input %q", model.mode, model.input)
+	}
+
+	model.input = "./missing/..."
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.mode != modeAddMatch || !strings.Contains(model.message, "matches no packages") {
+		t.Fatalf("expected validation error, got mode %d message %q", model.mode, model.message)
+	}
+
+	model.input = "./internal/api/..."
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.mode != modeBrowse {
+		t.Fatalf("expected browse mode, got %d", model.mode)
+	}
+	cfg := model.toConfig()
+	if len(cfg.Policy.Domains) != 2 || cfg.Policy.Domains[1].Name != "api" || cfg.Policy.Domains[1].Match[0] != "./internal/api/..." {
+		t.Fatalf("unexpected domains: %+v", cfg.Policy.Domains)
+	}
+	if model.cursor != 2 {
+		t.Fatalf("expected cursor on new domain, got %d", model.cursor)
+	}
+}
+
+func TestInitWizardAddDomainRejectsDuplicateName(t *testing.T) {
+	model := editModel(t)
+	typeText(model, "a")
+	typeText(model, "module")
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.mode != modeAddName || !strings.Contains(model.message, "already exists") {
+		t.Fatalf("expected duplicate error, got mode %d message %q", model.mode, model.message)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.mode != modeBrowse || len(model.domains) != 1 {
+		t.Fatalf("expected cancel back to browse without changes")
+	}
+}
+
+func TestInitWizardRenameAndDeleteDomain(t *testing.T) {
+	model := editModel(t)
+	model.cursor = 1
+	typeText(model, "r")
+	if model.input != "module" {
+		t.Fatalf("expected rename prompt prefilled, got %q", model.input)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	model.input = ""
+	typeText(model, "core")
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.domains[0].domain.Name != "core" {
+		t.Fatalf("expected rename to core, got %s", model.domains[0].domain.Name)
+	}
+
+	typeText(model, "d")
+	if len(model.domains) != 1 || model.message != "keep at least one domain" {
+		t.Fatalf("expected last domain to be kept, got %d domains, message %q", len(model.domains), model.message)
+	}
+
+	model.domains = append(model.domains, wizardDomain{domain: model.domains[0].domain})
+	model.domains[1].domain.Name = "api"
+	model.cursor = 2
+	typeText(model, "d")
+	if len(model.domains) != 1 || model.cursor != 1 {
+		t.Fatalf("expected api deleted with cursor clamped, got %d domains cursor %d", len(model.domains), model.cursor)
+	}
+}
+
+func TestInitWizardEditExcludes(t *testing.T) {
+	model := editModel(t)
+	typeText(model, "e")
+	if model.mode != modeExcludes || !strings.Contains(model.View(), "Edit exclusions") {
+		t.Fatalf("expected exclude editor")
+	}
+	typeText(model, "a")
+	typeText(model, "internal/gen/[")
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(model.message, "invalid exclude pattern") {
+		t.Fatalf("expected bad pattern error, got %q", model.message)
+	}
+	model.input = ""
+	typeText(model, "internal/gen/*")
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	typeText(model, "a")
+	typeText(model, "quux/*") // "q" is text, not quit, inside a prompt
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.aborted || len(model.exclude) != 2 {
+		t.Fatalf("expected two excludes, got %v (aborted=%v)", model.exclude, model.aborted)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	typeText(model, "d")
+	if len(model.exclude) != 1 || model.exclude[0] != "quux/*" {
+		t.Fatalf("expected internal/gen/* removed, got %v", model.exclude)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.mode != modeBrowse {
+		t.Fatalf("expected browse mode after esc, got %d", model.mode)
+	}
+	if cfg := model.toConfig(); len(cfg.Exclude) != 1 {
+		t.Fatalf("expected excludes in config, got %v", cfg.Exclude)
+	}
+}
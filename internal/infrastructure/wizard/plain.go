@@ -0,0 +1,319 @@
+package wizard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+	"github.com/mattn/go-isatty"
+	"gopkg.in/yaml.v3"
+)
+
+// isTerminal reports whether w is a terminal; bubbletea needs one.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// plainPrompter asks one question per line. An exhausted input accepts the
+// default of every remaining question, so `coverctl init </dev/null` writes
+// the detected config.
+type plainPrompter struct {
+	out     io.Writer
+	scanner *bufio.Scanner
+}
+
+// ask prints question with def in brackets and returns the trimmed answer,
+// or def when the answer is blank or input has ended.
+func (p *plainPrompter) ask(question, def string) string {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	if !p.scanner.Scan() {
+		fmt.Fprintln(p.out)
+		return def
+	}
+	if answer := strings.TrimSpace(p.scanner.Text()); answer != "" {
+		return answer
+	}
+	return def
+}
+
+// askValid repeats question until check accepts the answer.
+func (p *plainPrompter) askValid(question, def string, check func(string) error) string {
+	for {
+		answer := p.ask(question, def)
+		err := check(answer)
+		if err == nil {
+			return answer
+		}
+		fmt.Fprintf(p.out, "  %v\n", err)
+		if answer == def {
+			// Input ended or the default itself is invalid; re-asking loops forever.
+			return ""
+		}
+	}
+}
+
+// runPlainWizard walks the same decisions as the terminal UI with line-based
+// prompts, for containers and piped output where no TTY is available.
+func runPlainWizard(cfg application.Config, stdout io.Writer, stdin io.Reader) (application.Config, bool, error) {
+	m := newInitWizardModel(cfg)
+	p := &plainPrompter{out: stdout, scanner: bufio.NewScanner(stdin)}
+
+	fmt.Fprintf(stdout, "coverctl init: detected %d domains.\n", len(m.domains))
+	defaultMin := p.askValid("Default minimum coverage", formatPercent(m.defaultMin), parsePercentErr)
+	m.setDefaultMin(mustParsePercent(defaultMin, m.defaultMin))
+
+	for i := 0; i < len(m.domains); {
+		dom := m.domains[i]
+		question := fmt.Sprintf("Minimum for %s (%s), or - to remove", dom.domain.Name, strings.Join(dom.domain.Match, ", "))
+		answer := p.askValid(question, formatPercent(dom.min), func(s string) error {
+			if s == "-" {
+				if len(m.domains) == 1 {
+					return errors.New("keep at least one domain")
+				}
+				return nil
+			}
+			return parsePercentErr(s)
+		})
+		switch {
+		case answer == "-":
+			m.domains = append(m.domains[:i], m.domains[i+1:]...)
+			continue
+		case answer != formatPercent(dom.min):
+			m.setDomainMin(i, mustParsePercent(answer, dom.min))
+		}
+		i++
+	}
+
+	for {
+		name := p.askValid("Add a domain: name (blank to finish)", "", func(s string) error {
+			if s == "" {
+				return nil
+			}
+			return m.checkDomainName(s, -1)
+		})
+		if name == "" {
+			break
+		}
+		match := p.askValid("  Match pattern", "./internal/"+name+"/...", m.checkMatchPattern)
+		if match == "" {
+			continue
+		}
+		m.addDomain(name, match)
+	}
+
+	current := strings.Join(m.exclude, ", ")
+	excludes := p.ask("Exclude patterns, comma-separated (- for none)", current)
+	if excludes != current {
+		m.exclude = nil
+		if excludes != "-" {
+			for _, pattern := range splitList(excludes) {
+				if err := m.checkExclude(pattern); err != nil {
+					fmt.Fprintf(stdout, "  skipping: %v\n", err)
+					continue
+				}
+				m.exclude = append(m.exclude, pattern)
+			}
+		}
+	}
+
+	write := strings.ToLower(p.ask("Write config? (y/n)", "y"))
+	if write != "y" && write != "yes" {
+		return cfg, false, nil
+	}
+	return m.toConfig(), true, nil
+}
+
+// Answers scripts the wizard's decisions for provisioning. Operations apply
+// in field order to the detected config.
+type Answers struct {
+	DefaultMin    *float64          `yaml:"default_min,omitempty"`    // Default minimum coverage
+	Rename        map[string]string `yaml:"rename,omitempty"`         // Detected domain name -> new name
+	Remove        []string          `yaml:"remove,omitempty"`         // Domains to delete
+	Domains       []AnswerDomain    `yaml:"domains,omitempty"`        // Threshold overrides and new domains
+	Exclude       []string          `yaml:"exclude,omitempty"`        // Exclude patterns to add
+	RemoveExclude []string          `yaml:"remove_exclude,omitempty"` // Exclude patterns to drop
+}
+
+// AnswerDomain sets the minimum of an existing domain, or adds the domain
+// when it does not exist yet; new domains need Match.
+type AnswerDomain struct {
+	Name  string   `yaml:"name"`
+	Match []string `yaml:"match,omitempty"`
+	Min   *float64 `yaml:"min,omitempty"`
+}
+
+// LoadAnswers reads an answers file, rejecting unknown keys so typos do not
+// silently fall back to defaults.
+func LoadAnswers(path string) (Answers, error) {
+	clean, err := pathutil.ValidatePath(path)
+	if err != nil {
+		return Answers{}, fmt.Errorf("invalid answers path: %w", err)
+	}
+	f, err := os.Open(clean) // #nosec G304 - path is validated above
+	if err != nil {
+		return Answers{}, err
+	}
+	defer func() { _ = f.Close() }()
+	var answers Answers
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&answers); err != nil && !errors.Is(err, io.EOF) {
+		return Answers{}, fmt.Errorf("parse answers %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// ApplyAnswers applies scripted answers to a detected config with the same
+// validation as the interactive wizard, including match patterns.
+func ApplyAnswers(cfg application.Config, answers Answers) (application.Config, error) {
+	m := newInitWizardModel(cfg)
+	if answers.DefaultMin != nil {
+		if err := checkPercent(*answers.DefaultMin); err != nil {
+			return cfg, fmt.Errorf("default_min: %w", err)
+		}
+		m.setDefaultMin(*answers.DefaultMin)
+	}
+	renames := make([]string, 0, len(answers.Rename))
+	for from := range answers.Rename {
+		renames = append(renames, from)
+	}
+	sort.Strings(renames)
+	for _, from := range renames {
+		to := answers.Rename[from]
+		index := m.domainIndex(from)
+		if index < 0 {
+			return cfg, fmt.Errorf("rename: unknown domain %s", from)
+		}
+		if err := m.checkDomainName(to, index); err != nil {
+			return cfg, fmt.Errorf("rename %s: %w", from, err)
+		}
+		m.domains[index].domain.Name = to
+	}
+	for _, name := range answers.Remove {
+		index := m.domainIndex(name)
+		if index < 0 {
+			return cfg, fmt.Errorf("remove: unknown domain %s", name)
+		}
+		m.domains = append(m.domains[:index], m.domains[index+1:]...)
+	}
+	for _, ans := range answers.Domains {
+		index := m.domainIndex(ans.Name)
+		if index < 0 {
+			if len(ans.Match) == 0 {
+				return cfg, fmt.Errorf("domains: %s is not detected; give match patterns to add it", ans.Name)
+			}
+			if err := m.checkDomainName(ans.Name, -1); err != nil {
+				return cfg, fmt.Errorf("domains: %w", err)
+			}
+			for _, pattern := range ans.Match {
+				if err := m.checkMatchPattern(pattern); err != nil {
+					return cfg, fmt.Errorf("domains %s: %w", ans.Name, err)
+				}
+			}
+			m.addDomain(ans.Name, ans.Match...)
+			index = len(m.domains) - 1
+		} else if len(ans.Match) > 0 {
+			m.domains[index].domain.Match = append([]string(nil), ans.Match...)
+		}
+		if ans.Min != nil {
+			if err := checkPercent(*ans.Min); err != nil {
+				return cfg, fmt.Errorf("domains %s: %w", ans.Name, err)
+			}
+			m.setDomainMin(index, *ans.Min)
+		}
+	}
+	if len(m.domains) == 0 {
+		return cfg, errors.New("answers remove every domain; keep at least one")
+	}
+	for _, pattern := range answers.RemoveExclude {
+		for i, existing := range m.exclude {
+			if existing == pattern {
+				m.exclude = append(m.exclude[:i], m.exclude[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, pattern := range answers.Exclude {
+		if err := m.checkExclude(pattern); err != nil {
+			return cfg, fmt.Errorf("exclude: %w", err)
+		}
+		m.exclude = append(m.exclude, pattern)
+	}
+	return m.toConfig(), nil
+}
+
+func (m *initWizardModel) domainIndex(name string) int {
+	for i, dom := range m.domains {
+		if dom.domain.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *initWizardModel) addDomain(name string, match ...string) {
+	m.domains = append(m.domains, wizardDomain{
+		domain: domain.Domain{Name: name, Match: append([]string(nil), match...)},
+		min:    m.defaultMin,
+	})
+}
+
+func (m *initWizardModel) setDefaultMin(value float64) {
+	m.adjustDefault(value - m.defaultMin)
+}
+
+func (m *initWizardModel) setDomainMin(index int, value float64) {
+	m.adjustDomain(index, value-m.domains[index].min)
+}
+
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func checkPercent(v float64) error {
+	if v < 0 || v > 100 {
+		return fmt.Errorf("%s is not between 0 and 100", formatPercent(v))
+	}
+	return nil
+}
+
+func parsePercentErr(s string) error {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return fmt.Errorf("%s is not a number", s)
+	}
+	return checkPercent(v)
+}
+
+// mustParsePercent parses a value already accepted by parsePercentErr,
+// returning fallback for the empty answer of an exhausted input.
+func mustParsePercent(s string, fallback float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,166 @@
+package wizard
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func stubMatchValidation(t *testing.T) {
+	t.Helper()
+	old := validateMatchPattern
+	validateMatchPattern = func(pattern string) error {
+		if strings.Contains(pattern, "missing") {
+			return errors.New(pattern + " matches no packages")
+		}
+		return nil
+	}
+	t.Cleanup(func() { validateMatchPattern = old })
+}
+
+func TestRunPlainWizardScript(t *testing.T) {
+	stubMatchValidation(t)
+	script := strings.Join([]string{
+		"85",              // default min
+		"90",              // module min
+		"api",             // new domain
+		"./missing/...",   // rejected pattern
+		"./internal/api/", // accepted pattern
+		"",                // finish adding
+		"vendor/*, gen/*", // excludes
+		"y",
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	cfg, confirmed, err := runPlainWizard(minimalConfig(), &out, strings.NewReader(script))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !confirmed {
+		t.Fatalf("expected confirmation, output:\n%s", out.String())
+	}
+	if cfg.Policy.DefaultMin != 85 {
+		t.Fatalf("expected default 85, got %v", cfg.Policy.DefaultMin)
+	}
+	if len(cfg.Policy.Domains) != 2 || cfg.Policy.Domains[1].Name != "api" || cfg.Policy.Domains[1].Match[0] != "./internal/api/" {
+		t.Fatalf("unexpected domains: %+v", cfg.Policy.Domains)
+	}
+	if min := cfg.Policy.Domains[0].Min; min == nil || *min != 90 {
+		t.Fatalf("expected module min 90, got %v", min)
+	}
+	if strings.Join(cfg.Exclude, ",") != "vendor/*,gen/*" {
+		t.Fatalf("unexpected excludes: %v", cfg.Exclude)
+	}
+	if !strings.Contains(out.String(), "matches no packages") {
+		t.Fatalf("expected validation message, output:\n%s", out.String())
+	}
+}
+
+func TestRunPlainWizardEOFAcceptsDefaults(t *testing.T) {
+	stubMatchValidation(t)
+	var out bytes.Buffer
+	cfg, confirmed, err := runPlainWizard(minimalConfig(), &out, strings.NewReader(""))
+	if err != nil || !confirmed {
+		t.Fatalf("expected defaults to be confirmed, got %v %v", confirmed, err)
+	}
+	if cfg.Policy.DefaultMin != 80 || len(cfg.Policy.Domains) != 1 {
+		t.Fatalf("expected detected config, got %+v", cfg.Policy)
+	}
+}
+
+func TestRunPlainWizardDecline(t *testing.T) {
+	stubMatchValidation(t)
+	var out bytes.Buffer
+	_, confirmed, err := runPlainWizard(minimalConfig(), &out, strings.NewReader("\n\n\n\nn\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if confirmed {
+		t.Fatal("expected the wizard to be declined")
+	}
+}
+
+func TestApplyAnswers(t *testing.T) {
+	stubMatchValidation(t)
+	cfg := minimalConfig()
+	cfg.Exclude = []string{"vendor/*"}
+	defaultMin, apiMin := 70.0, 85.0
+	got, err := ApplyAnswers(cfg, Answers{
+		DefaultMin:    &defaultMin,
+		Rename:        map[string]string{"module": "core"},
+		Domains:       []AnswerDomain{{Name: "api", Match: []string{"./internal/api/..."}, Min: &apiMin}},
+		Exclude:       []string{"gen/*"},
+		RemoveExclude: []string{"vendor/*"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Policy.DefaultMin != 70 {
+		t.Fatalf("expected default 70, got %v", got.Policy.DefaultMin)
+	}
+	if len(got.Policy.Domains) != 2 || got.Policy.Domains[0].Name != "core" || got.Policy.Domains[1].Name != "api" {
+		t.Fatalf("unexpected domains: %+v", got.Policy.Domains)
+	}
+	if min := got.Policy.Domains[1].Min; min == nil || *min != 85 {
+		t.Fatalf("expected api min 85, got %v", min)
+	}
+	if strings.Join(got.Exclude, ",") != "gen/*" {
+		t.Fatalf("unexpected excludes: %v", got.Exclude)
+	}
+}
+
+func TestApplyAnswersErrors(t *testing.T) {
+	stubMatchValidation(t)
+	tooHigh := 120.0
+	tests := []struct {
+		name    string
+		answers Answers
+		want    string
+	}{
+		{"unknown rename", Answers{Rename: map[string]string{"nope": "x"}}, "unknown domain nope"},
+		{"unknown remove", Answers{Remove: []string{"nope"}}, "unknown domain nope"},
+		{"remove all", Answers{Remove: []string{"module"}}, "keep at least one"},
+		{"new without match", Answers{Domains: []AnswerDomain{{Name: "api"}}}, "give match patterns"},
+		{"bad match", Answers{Domains: []AnswerDomain{{Name: "api", Match: []string{"./missing/..."}}}}, "matches no packages"},
+		{"bad min", Answers{DefaultMin: &tooHigh}, "between 0 and 100"},
+		{"bad exclude", Answers{Exclude: []string{"["}}, "invalid exclude pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyAnswers(minimalConfig(), tt.answers)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadAnswersRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	if err := os.WriteFile(path, []byte("default_min: 75\ndomians: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAnswers(path); err == nil || !strings.Contains(err.Error(), "domians") {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+}
+
+func TestLoadAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	content := "default_min: 75\nrename:\n  module: core\ndomains:\n  - name: api\n    match: [./internal/api/...]\n    min: 90\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	answers, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if answers.DefaultMin == nil || *answers.DefaultMin != 75 || answers.Rename["module"] != "core" {
+		t.Fatalf("unexpected answers: %+v", answers)
+	}
+	if len(answers.Domains) != 1 || answers.Domains[0].Min == nil || *answers.Domains[0].Min != 90 {
+		t.Fatalf("unexpected domains: %+v", answers.Domains)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/theme"
 )
 
 type (
@@ -22,6 +23,13 @@ type (
 		confirmed  bool
 		aborted    bool
 		exclude    []string
+
+		mode          editMode
+		input         string // text typed into the open prompt
+		pendingName   string // name of the domain being added, while its pattern is prompted
+		message       string // validation feedback shown under the prompt
+		excludeCursor int
+		validateMatch func(pattern string) error
 	}
 
 	wizardDomain struct {
@@ -38,11 +46,11 @@ const (
 )
 
 var (
-	colorOrange = lipgloss.Color("#F97316")
-	colorSky    = lipgloss.Color("#0EA5E9")
-	colorLime   = lipgloss.Color("#84CC16")
-	colorSlate  = lipgloss.Color("#64748B")
-	colorInk    = lipgloss.Color("#0F172A")
+	colorOrange = theme.Orange
+	colorSky    = theme.Sky
+	colorLime   = theme.Lime
+	colorSlate  = theme.Slate
+	colorInk    = theme.Ink
 
 	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(colorOrange)
 	badgeStyle   = lipgloss.NewStyle().Bold(true).Foreground(colorInk).Background(colorSky).Padding(0, 1)
@@ -55,7 +63,13 @@ var (
 	valueStyle   = lipgloss.NewStyle().Bold(true).Foreground(colorOrange)
 )
 
+// Run walks the user through the detected config. It uses the terminal UI
+// when stdout is a terminal and line-based prompts otherwise, so init also
+// works in containers and when piped.
 func Run(cfg application.Config, stdout io.Writer, stdin io.Reader) (application.Config, bool, error) {
+	if !isTerminal(stdout) {
+		return runPlainWizard(cfg, stdout, stdin)
+	}
 	return runInitWizard(cfg, stdout, stdin)
 }
 
@@ -104,6 +118,8 @@ func newInitWizardModel(cfg application.Config) *initWizardModel {
 		domains:    domains,
 		cursor:     0,
 		exclude:    append([]string(nil), cfg.Exclude...),
+
+		validateMatch: validateMatchPattern,
 	}
 }
 
@@ -114,6 +130,12 @@ func (m *initWizardModel) Init() tea.Cmd {
 func (m *initWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.state == stateEdit && m.mode != modeBrowse {
+			return m.updateEditing(msg)
+		}
+		if m.state == stateEdit && m.updateBrowse(msg.String()) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			m.aborted = true
@@ -158,6 +180,9 @@ func (m *initWizardModel) View() string {
 	case stateIntro:
 		return m.viewIntro()
 	case stateEdit:
+		if m.mode == modeExcludes || m.mode == modeAddExclude {
+			return m.viewExcludes()
+		}
 		return m.viewEdit()
 	case stateConfirm:
 		return m.viewConfirm()
@@ -210,7 +235,7 @@ func (m *initWizardModel) viewIntro() string {
 	fmt.Fprintf(&b, "\n%s %s\n", titleStyle.Render("coverctl init"), badgeStyle.Render("wizard"))
 	fmt.Fprintf(&b, "%s\n\n", subtleStyle.Render("Domain-aware coverage policy setup"))
 	fmt.Fprintf(&b, "%s\n\n", m.stepper())
-	fmt.Fprintf(&b, "coverctl detected %d domains. The wizard helps you review coverage thresholds.\n\n", len(m.domains))
+	fmt.Fprintf(&b, "coverctl detected %d domains. The wizard helps you review domains, thresholds, and exclusions.\n\n", len(m.domains))
 	fmt.Fprintf(&b, "Default coverage is %s. Press %s to continue or %s to cancel.\n",
 		valueStyle.Render(fmt.Sprintf("%.0f%%", m.defaultMin)),
 		keyStyle.Render("Enter"),
@@ -221,9 +246,10 @@ func (m *initWizardModel) viewIntro() string {
 
 func (m *initWizardModel) viewEdit() string {
 	var b strings.Builder
-	fmt.Fprintf(&b, "\n%s %s\n", titleStyle.Render("Review thresholds"), badgeStyle.Render("step 2/3"))
+	fmt.Fprintf(&b, "\n%s %s\n", titleStyle.Render("Review domains"), badgeStyle.Render("step 2/3"))
 	fmt.Fprintf(&b, "%s\n", m.stepper())
-	fmt.Fprintf(&b, "%s\n\n", subtleStyle.Render("Use ↑/↓ to move, ←/→ or +/- to adjust."))
+	fmt.Fprintf(&b, "%s\n", subtleStyle.Render("Use ↑/↓ to move, ←/→ or +/- to adjust."))
+	fmt.Fprintf(&b, "%s\n\n", subtleStyle.Render("a add · r rename · d delete domain · e edit exclusions"))
 	fmt.Fprintf(&b, "%s\n", subtleStyle.Render("Default min (applies to non-customized domains):"))
 	defaultLine := fmt.Sprintf("Default min: %s", valueStyle.Render(fmt.Sprintf("%.0f%%", m.defaultMin)))
 	if m.cursor == 0 {
@@ -244,6 +270,7 @@ func (m *initWizardModel) viewEdit() string {
 			fmt.Fprintf(&b, "  %s\n", row)
 		}
 	}
+	b.WriteString(m.viewPrompt())
 	fmt.Fprintf(&b, "\nPress %s to continue, %s to cancel.\n", keyStyle.Render("Enter"), keyStyle.Render("q"))
 	return b.String()
 }
@@ -61,6 +61,18 @@ func (s *Server) Dispatch(ctx context.Context, tool string, input map[string]any
 			return nil, fmt.Errorf("unmarshal debt input: %w", err)
 		}
 		return s.handleDebt(ctx, in)
+	case "uncovered":
+		var in UncoveredInput
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, fmt.Errorf("unmarshal uncovered input: %w", err)
+		}
+		return s.handleUncovered(ctx, in)
+	case "configure":
+		var in ConfigureInput
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, fmt.Errorf("unmarshal configure input: %w", err)
+		}
+		return s.handleConfigure(ctx, in)
 	case "compare":
 		var in CompareInput
 		if err := json.Unmarshal(raw, &in); err != nil {
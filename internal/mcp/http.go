@@ -0,0 +1,282 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felixgeelhaar/mcp-go/protocol"
+	"github.com/felixgeelhaar/mcp-go/transport"
+)
+
+// HTTP transport
+//
+// RunHTTP serves the MCP streamable HTTP transport on a single endpoint,
+// /mcp, for remote and containerized setups where the client cannot spawn
+// coverctl over stdio:
+//
+//   - POST /mcp carries one JSON-RPC message; requests get a JSON response,
+//     notifications get 202 Accepted.
+//   - GET /mcp opens a server-sent event stream for server-to-client
+//     notifications (resource updates).
+//
+// When a token is configured, every request must carry it as
+// "Authorization: Bearer <token>"; without one the server only binds to
+// loopback addresses. The Host header, and the Origin header when a
+// browser sends one, must name an allowed host (loopback names plus
+// HTTPOptions.AllowedHosts), so a DNS-rebound page cannot reach the
+// server under its own hostname.
+
+// maxHTTPRequestBytes bounds one POSTed JSON-RPC message.
+const maxHTTPRequestBytes = 1 << 20
+
+// httpShutdownTimeout bounds the graceful shutdown after ctx is canceled.
+const httpShutdownTimeout = 5 * time.Second
+
+// DefaultHTTPAddr is the loopback address the HTTP transport listens on.
+const DefaultHTTPAddr = "127.0.0.1:8765"
+
+// loopbackHosts are always allowed in the Host and Origin headers.
+var loopbackHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// HTTPOptions configures the streamable HTTP transport.
+type HTTPOptions struct {
+	Addr         string   // Listen address; empty means DefaultHTTPAddr
+	Token        string   // Bearer token clients must present; empty disables auth
+	AllowedHosts []string // Host names accepted besides loopback ones
+}
+
+// Validate rejects serving without a token on an address reachable from
+// other machines.
+func (o HTTPOptions) Validate() error {
+	if o.Token != "" || isLoopbackAddr(o.addr()) {
+		return nil
+	}
+	return fmt.Errorf("refusing to listen on non-loopback address %q without a token; set a token or bind to %s", o.addr(), DefaultHTTPAddr)
+}
+
+func (o HTTPOptions) addr() string {
+	if o.Addr == "" {
+		return DefaultHTTPAddr
+	}
+	return o.Addr
+}
+
+// isLoopbackAddr reports whether addr's host is a loopback name or IP. An
+// empty host listens on every interface.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// RunHTTP serves MCP over streamable HTTP and blocks until ctx is canceled
+// or the listener fails.
+func (s *Server) RunHTTP(ctx context.Context, opts HTTPOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", opts.addr())
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:           s.HTTPHandler(opts),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Event streams live as long as the client stays connected; tie
+		// them to ctx so shutdown ends them.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	s.watchResources(ctx)
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(listener) }()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+// HTTPHandler returns the /mcp handler. An empty opts.Token disables auth.
+func (s *Server) HTTPHandler(opts HTTPOptions) http.Handler {
+	events := newEventStreams()
+	rpc := s.subscriptionMiddleware()(s.handleRPC)
+	allowed := make(map[string]bool, len(loopbackHosts)+len(opts.AllowedHosts))
+	for _, host := range append(append([]string{}, loopbackHosts...), opts.AllowedHosts...) {
+		allowed[strings.ToLower(strings.Trim(host, "[]"))] = true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		if !allowedHost(r, allowed) {
+			http.Error(w, "host not allowed", http.StatusForbidden)
+			return
+		}
+		if !authorized(r, opts.Token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="coverctl"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			servePost(w, r, rpc, events)
+		case http.MethodGet:
+			events.serve(w, r)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	return mux
+}
+
+func servePost(w http.ResponseWriter, r *http.Request, rpc func(context.Context, *protocol.Request) (*protocol.Response, error), events *eventStreams) {
+	var req protocol.Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHTTPRequestBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, protocol.NewErrorResponse(nil, protocol.NewParseError("invalid JSON-RPC message")))
+		return
+	}
+
+	ctx := transport.ContextWithNotificationSender(r.Context(), events)
+	resp, err := rpc(ctx, &req)
+	if req.IsNotification() {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if err != nil {
+		resp = protocol.NewErrorResponse(req.ID, asProtocolError(err, protocol.NewInternalError))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func authorized(r *http.Request, token string) bool {
+	if token == "" {
+		return true
+	}
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || header[:len(prefix)] != prefix {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(header[len(prefix):]), []byte(token)) == 1
+}
+
+// allowedHost accepts requests whose Host names an allowed host and whose
+// Origin, when a browser sends one, does too. Comparing Origin with Host
+// alone would pass a DNS-rebound page, where both carry the attacker's
+// hostname.
+func allowedHost(r *http.Request, allowed map[string]bool) bool {
+	if !allowed[hostname(r.Host)] {
+		return false
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && allowed[strings.ToLower(u.Hostname())]
+}
+
+// hostname strips the port and IPv6 brackets from a Host header.
+func hostname(hostport string) string {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	return strings.ToLower(strings.Trim(host, "[]"))
+}
+
+// eventStreams fans server notifications out to every open GET /mcp
+// stream. It is the notification sender for requests arriving over HTTP.
+type eventStreams struct {
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+}
+
+func newEventStreams() *eventStreams {
+	return &eventStreams{clients: make(map[chan []byte]struct{})}
+}
+
+// SendNotification implements transport.NotificationSender. Streams that
+// are not keeping up drop the notification rather than block the sender.
+func (e *eventStreams) SendNotification(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(transport.Notification{JSONRPC: "2.0", Method: method, Params: raw})
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.clients {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+	return nil
+}
+
+func (e *eventStreams) serve(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan []byte, 16)
+	e.mu.Lock()
+	e.clients[ch] = struct{}{}
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		delete(e.clients, ch)
+		e.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg := <-ch:
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg)
+			flusher.Flush()
+		}
+	}
+}
+
+var _ transport.NotificationSender = (*eventStreams)(nil)
@@ -0,0 +1,195 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+func postRPC(t *testing.T, url, token, body string) (*http.Response, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+	var decoded map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp, decoded
+}
+
+func TestHTTPHandler(t *testing.T) {
+	svc := &mockService{uncovered: application.UncoveredResult{
+		Files: []domain.UncoveredFile{{File: "internal/core/a.go", Uncovered: 3, Total: 4}},
+		Total: 1,
+	}}
+	server := New(svc, DefaultConfig(), "test")
+	ts := httptest.NewServer(server.HTTPHandler(HTTPOptions{Token: "s3cret", AllowedHosts: []string{"mcp.internal"}}))
+	defer ts.Close()
+	endpoint := ts.URL + "/mcp"
+
+	t.Run("rejects missing or wrong token", func(t *testing.T) {
+		for _, token := range []string{"", "wrong"} {
+			resp, _ := postRPC(t, endpoint, token, `{"jsonrpc":"2.0","id":1,"method":"ping"}`)
+			if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+				t.Fatalf("token %q: expected 401 with challenge, got %d", token, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("rejects cross-origin browser requests", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{}`))
+		req.Header.Set("Origin", "https://evil.example")
+		req.Header.Set("Authorization", "Bearer s3cret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("post: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("rejects DNS-rebound requests", func(t *testing.T) {
+		for _, host := range []string{"evil.example", "mcp.internal"} {
+			req, _ := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+			req.Host = host
+			req.Header.Set("Origin", "http://evil.example")
+			req.Header.Set("Authorization", "Bearer s3cret")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("post: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusForbidden {
+				t.Fatalf("host %s: expected 403, got %d", host, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("accepts allowed host names", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+		req.Host = "MCP.internal:8765"
+		req.Header.Set("Origin", "http://mcp.internal:8765")
+		req.Header.Set("Authorization", "Bearer s3cret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("post: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("initialize advertises subscriptions", func(t *testing.T) {
+		resp, body := postRPC(t, endpoint, "s3cret", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+		resources := body["result"].(map[string]any)["capabilities"].(map[string]any)["resources"].(map[string]any)
+		if resources["subscribe"] != true {
+			t.Fatalf("expected subscribe capability, got %v", resources)
+		}
+	})
+
+	t.Run("notifications are accepted without a body", func(t *testing.T) {
+		resp, _ := postRPC(t, endpoint, "s3cret", `{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("lists and calls tools", func(t *testing.T) {
+		_, body := postRPC(t, endpoint, "s3cret", `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+		tools := body["result"].(map[string]any)["tools"].([]any)
+		if len(tools) != 5 {
+			t.Fatalf("expected the 5 agent-mode tools, got %d", len(tools))
+		}
+
+		_, body = postRPC(t, endpoint, "s3cret", `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"uncovered","arguments":{}}}`)
+		content := body["result"].(map[string]any)["content"].([]any)[0].(map[string]any)
+		if !strings.Contains(content["text"].(string), "internal/core/a.go") {
+			t.Fatalf("unexpected tool result: %v", content)
+		}
+	})
+
+	t.Run("unknown methods return a JSON-RPC error", func(t *testing.T) {
+		_, body := postRPC(t, endpoint, "s3cret", `{"jsonrpc":"2.0","id":4,"method":"nope"}`)
+		if body["error"] == nil {
+			t.Fatalf("expected error response, got %v", body)
+		}
+	})
+
+	t.Run("streams resource updates to GET listeners", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		stream, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("open stream: %v", err)
+		}
+		defer stream.Body.Close()
+		if ct := stream.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("unexpected content type %q", ct)
+		}
+
+		postRPC(t, endpoint, "s3cret", `{"jsonrpc":"2.0","id":5,"method":"resources/subscribe","params":{"uri":"coverctl://debt"}}`)
+		if err := server.subs.notify(uriDebt); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+
+		scanner := bufio.NewScanner(stream.Body)
+		for scanner.Scan() {
+			if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+				if !strings.Contains(line, `"notifications/resources/updated"`) || !strings.Contains(line, uriDebt) {
+					t.Fatalf("unexpected event %q", line)
+				}
+				return
+			}
+		}
+		t.Fatalf("stream ended without an event: %v", scanner.Err())
+	})
+}
+
+func TestHTTPOptionsValidate(t *testing.T) {
+	tests := []struct {
+		opts    HTTPOptions
+		wantErr bool
+	}{
+		{HTTPOptions{}, false},
+		{HTTPOptions{Addr: "localhost:9000"}, false},
+		{HTTPOptions{Addr: "[::1]:9000"}, false},
+		{HTTPOptions{Addr: ":8765"}, true},
+		{HTTPOptions{Addr: "0.0.0.0:8765"}, true},
+		{HTTPOptions{Addr: "10.0.0.5:8765"}, true},
+		{HTTPOptions{Addr: ":8765", Token: "s3cret"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%+v: Validate() = %v, want error %v", tt.opts, err, tt.wantErr)
+		}
+	}
+	server := New(&mockService{}, DefaultConfig(), "test")
+	if err := server.RunHTTP(context.Background(), HTTPOptions{Addr: ":0"}); err == nil {
+		t.Fatal("expected RunHTTP to refuse an unauthenticated wildcard bind")
+	}
+}
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/mcp-go"
+)
+
+// Prompts turn coverctl data into ready-to-send instructions for the
+// agent. Each handler gathers read-only data (no test runs, no writes),
+// sanitizes it like any other tool output, and embeds it as JSON in a
+// single user message so the client model sees both the task and the
+// evidence.
+
+// defaultPromptTargets is how many debt items improve-coverage proposes
+// when the client does not pass a limit.
+const defaultPromptTargets = 5
+
+// registerPrompts adds the workflow prompt templates to the server.
+func (s *Server) registerPrompts() {
+	s.server.Prompt("improve-coverage").
+		Description("Rank the largest coverage gaps from the debt report and ask for concrete test targets that close them.").
+		Argument("limit", "Maximum number of targets to propose (default 5)", false).
+		Handler(s.handleImproveCoveragePrompt)
+
+	s.server.Prompt("explain-policy-failure").
+		Description("Explain why the coverage policy fails for an existing profile and what would make it pass.").
+		Argument("profile", "Coverage profile to analyze (defaults to the server profile)", false).
+		Handler(s.handleExplainPolicyFailurePrompt)
+
+	s.server.Prompt("configure-coverctl").
+		Description("Propose a .coverctl.yaml for this project from auto-detected domains and current coverage.").
+		Argument("goal", "What the policy should achieve, e.g. 'lock in current coverage' or 'reach 80% in core'", false).
+		Handler(s.handleConfigureCoverctlPrompt)
+}
+
+func (s *Server) handleImproveCoveragePrompt(ctx context.Context, args map[string]string) (*mcp.PromptResult, error) {
+	limit := defaultPromptTargets
+	if raw := args["limit"]; raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("limit must be a positive integer")
+		}
+		limit = n
+	}
+
+	result, err := s.debt(ctx, application.DebtOptions{
+		ConfigPath:  s.config.ConfigPath,
+		ProfilePath: s.config.ProfilePath,
+		Output:      application.OutputJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate debt: %w", err)
+	}
+
+	items := append([]application.DebtItem(nil), result.Items...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Lines > items[j].Lines })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	evidence := map[string]any{
+		"healthScore": result.HealthScore,
+		"totalDebt":   result.TotalDebt,
+		"totalLines":  result.TotalLines,
+		"targets":     sanitizeDebtItems(items),
+	}
+	task := "Coverage debt is listed below, largest gaps first. For each target, " +
+		"propose specific tests to write: the file or package to test, the behaviours " +
+		"or branches that are likely untested, and the test cases that would cover them. " +
+		"Prefer tests that exercise real behaviour over tests that only raise the number. " +
+		"After writing tests, call the `check` tool to confirm the domains pass."
+	if len(items) == 0 {
+		task = "The debt report shows no coverage gaps. Confirm this " +
+			"with the `check` tool and suggest whether thresholds could be raised with the `suggest` tool."
+	}
+	return promptResult("Test targets that close the largest coverage gaps", task, evidence)
+}
+
+func (s *Server) handleExplainPolicyFailurePrompt(ctx context.Context, args map[string]string) (*mcp.PromptResult, error) {
+	if err := validateScopedInputs(namedPath{"profile", args["profile"]}); err != nil {
+		return nil, err
+	}
+
+	result, err := s.reportResult(ctx, application.ReportOptions{
+		ConfigPath: s.config.ConfigPath,
+		Profile:    coalesce(args["profile"], s.config.ProfilePath),
+		Output:     application.OutputJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze profile: %w", err)
+	}
+
+	var failingFiles []domain.FileResult
+	for _, f := range result.Files {
+		if f.Status == domain.StatusFail {
+			failingFiles = append(failingFiles, f)
+		}
+	}
+	evidence := map[string]any{
+		"passed":         result.Passed,
+		"overall":        result.OverallPercent(),
+		"failingDomains": sanitizeDomainResults(failingDomains(result.Domains)),
+		"failingFiles":   sanitizeFileResults(failingFiles),
+		"warnings":       sanitizeWarnings(result.Warnings),
+	}
+	task := "The coverage policy result is below. Explain in plain language which domains " +
+		"and file rules fail, by how much, and why the policy treats them as failures. Then " +
+		"list the smallest set of changes that would make the policy pass, distinguishing " +
+		"missing tests from thresholds or domain boundaries that look misconfigured."
+	if result.Passed {
+		task = "The coverage policy currently passes for this profile. Summarize the margin " +
+			"per domain and point out any warnings that could turn into failures."
+	}
+	return promptResult("Why the coverage policy fails and how to fix it", task, evidence)
+}
+
+func (s *Server) handleConfigureCoverctlPrompt(ctx context.Context, args map[string]string) (*mcp.PromptResult, error) {
+	detected, err := s.svc.Detect(ctx, application.DetectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect config: %w", err)
+	}
+
+	evidence := map[string]any{
+		"configPath":     canonicalizePath(s.config.ConfigPath),
+		"detectedConfig": detected,
+	}
+	// Suggestions need an existing profile; without one the prompt still
+	// works from the detected layout alone.
+	if suggest, err := s.suggest(ctx, application.SuggestOptions{
+		ConfigPath:  s.config.ConfigPath,
+		ProfilePath: s.config.ProfilePath,
+		Strategy:    application.SuggestCurrent,
+	}); err == nil {
+		evidence["suggestions"] = suggest.Suggestions
+	}
+
+	goal := sanitizeOutputString(args["goal"])
+	if goal == "" {
+		goal = "lock in current coverage without failing the build"
+	}
+	task := "Propose a .coverctl.yaml for this project. Goal: " + goal + ". Start from the " +
+		"auto-detected domains below, merge or split them where the boundaries look wrong, " +
+		"and set per-domain minimums informed by the threshold suggestions when present. " +
+		"Explain each domain and threshold in one line, then show the complete YAML. Do not " +
+		"write the file; the user applies it with `coverctl init` or by saving it."
+	return promptResult("A coverctl configuration for this project", task, evidence)
+}
+
+// promptResult renders task followed by the JSON evidence as one user
+// message.
+func promptResult(description, task string, evidence map[string]any) (*mcp.PromptResult, error) {
+	data, err := json.MarshalIndent(evidence, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prompt data: %w", err)
+	}
+	var b strings.Builder
+	b.WriteString(task)
+	b.WriteString("\n\ncoverctl data:\n```json\n")
+	b.Write(data)
+	b.WriteString("\n```\n")
+	return &mcp.PromptResult{
+		Description: description,
+		Messages: []mcp.PromptMessage{{
+			Role:    "user",
+			Content: mcp.TextContent{Type: "text", Text: b.String()},
+		}},
+	}, nil
+}
@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/felixgeelhaar/mcp-go/protocol"
+)
+
+// handleRPC routes one JSON-RPC request to the tools, resources, and
+// prompts registered on s.server, producing the same result shapes the MCP
+// library's own router produces. That router is unexported, so both
+// transports coverctl serves (stdio and HTTP) route through this instead.
+// Resource subscriptions are handled by subscriptionMiddleware in front of
+// it.
+func (s *Server) handleRPC(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	switch req.Method {
+	case protocol.MethodInitialize:
+		return s.rpcInitialize(req), nil
+	case protocol.MethodPing:
+		return protocol.NewResponse(req.ID, map[string]any{}), nil
+	case protocol.MethodToolsList:
+		return s.rpcToolsList(req), nil
+	case protocol.MethodToolsCall:
+		return s.rpcToolsCall(ctx, req)
+	case protocol.MethodResourcesList:
+		return s.rpcResourcesList(req), nil
+	case protocol.MethodResourcesRead:
+		return s.rpcResourcesRead(ctx, req)
+	case protocol.MethodPromptsList:
+		return s.rpcPromptsList(req), nil
+	case protocol.MethodPromptsGet:
+		return s.rpcPromptsGet(ctx, req)
+	default:
+		return nil, protocol.NewMethodNotFound(req.Method)
+	}
+}
+
+func (s *Server) rpcInitialize(req *protocol.Request) *protocol.Response {
+	manifest := s.server.Manifest()
+	result := map[string]any{
+		"protocolVersion": manifest.ProtocolVersion,
+		"serverInfo": map[string]any{
+			"name":    manifest.Name,
+			"version": manifest.Version,
+		},
+		"capabilities": map[string]any{
+			"tools":     map[string]any{"listChanged": true},
+			"resources": map[string]any{"listChanged": true},
+			"prompts":   map[string]any{"listChanged": true},
+		},
+	}
+	if instructions := s.server.Instructions(); instructions != "" {
+		result["instructions"] = instructions
+	}
+	return protocol.NewResponse(req.ID, result)
+}
+
+func (s *Server) rpcToolsList(req *protocol.Request) *protocol.Response {
+	tools := s.server.Tools()
+	list := make([]map[string]any, 0, len(tools))
+	for _, t := range tools {
+		item := map[string]any{
+			"name":        t.Name,
+			"description": t.Description,
+			"inputSchema": t.InputSchema,
+		}
+		if t.OutputSchema != nil {
+			item["outputSchema"] = t.OutputSchema
+		}
+		if t.Annotations != nil {
+			item["annotations"] = t.Annotations
+		}
+		list = append(list, item)
+	}
+	return protocol.NewResponse(req.ID, map[string]any{"tools": list})
+}
+
+func (s *Server) rpcToolsCall(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, protocol.NewInvalidParams(err.Error())
+	}
+	tool, ok := s.server.GetTool(params.Name)
+	if !ok {
+		return nil, protocol.NewNotFound("tool not found: " + params.Name)
+	}
+
+	result, err := tool.Execute(ctx, params.Arguments)
+	if err != nil {
+		return nil, asProtocolError(err, protocol.NewInternalError)
+	}
+	// Every coverctl tool returns a JSON object, which MCP carries as a
+	// single text content block.
+	text, err := json.Marshal(result)
+	if err != nil {
+		return nil, protocol.NewInternalError("failed to serialize tool result: " + err.Error())
+	}
+	return protocol.NewResponse(req.ID, map[string]any{
+		"content": []map[string]any{{"type": "text", "text": string(text)}},
+	}), nil
+}
+
+func (s *Server) rpcResourcesList(req *protocol.Request) *protocol.Response {
+	resources := s.server.Resources()
+	list := make([]map[string]any, 0, len(resources))
+	for _, r := range resources {
+		item := map[string]any{"uri": r.URITemplate, "name": r.Name}
+		if r.Description != "" {
+			item["description"] = r.Description
+		}
+		if r.MimeType != "" {
+			item["mimeType"] = r.MimeType
+		}
+		list = append(list, item)
+	}
+	return protocol.NewResponse(req.ID, map[string]any{"resources": list})
+}
+
+func (s *Server) rpcResourcesRead(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	var params struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, protocol.NewInvalidParams(err.Error())
+	}
+	resource, ok := s.server.FindResourceForURI(params.URI)
+	if !ok {
+		return nil, protocol.NewNotFound("resource not found: " + params.URI)
+	}
+	content, err := resource.Read(ctx, params.URI)
+	if err != nil {
+		return nil, asProtocolError(err, protocol.NewInternalError)
+	}
+	return protocol.NewResponse(req.ID, map[string]any{
+		"contents": []map[string]any{{
+			"uri":      content.URI,
+			"mimeType": content.MimeType,
+			"text":     content.Text,
+		}},
+	}), nil
+}
+
+func (s *Server) rpcPromptsList(req *protocol.Request) *protocol.Response {
+	prompts := s.server.Prompts()
+	list := make([]map[string]any, 0, len(prompts))
+	for _, p := range prompts {
+		item := map[string]any{"name": p.Name}
+		if p.Description != "" {
+			item["description"] = p.Description
+		}
+		if len(p.Arguments) > 0 {
+			args := make([]map[string]any, 0, len(p.Arguments))
+			for _, arg := range p.Arguments {
+				args = append(args, map[string]any{
+					"name":        arg.Name,
+					"description": arg.Description,
+					"required":    arg.Required,
+				})
+			}
+			item["arguments"] = args
+		}
+		list = append(list, item)
+	}
+	return protocol.NewResponse(req.ID, map[string]any{"prompts": list})
+}
+
+func (s *Server) rpcPromptsGet(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	var params struct {
+		Name      string            `json:"name"`
+		Arguments map[string]string `json:"arguments"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, protocol.NewInvalidParams(err.Error())
+	}
+	prompt, ok := s.server.GetPrompt(params.Name)
+	if !ok {
+		return nil, protocol.NewNotFound("prompt not found: " + params.Name)
+	}
+	result, err := prompt.Get(ctx, params.Arguments)
+	if err != nil {
+		return nil, asProtocolError(err, protocol.NewInvalidParams)
+	}
+	response := map[string]any{"messages": result.Messages}
+	if result.Description != "" {
+		response["description"] = result.Description
+	}
+	return protocol.NewResponse(req.ID, response), nil
+}
+
+// asProtocolError passes MCP errors through and wraps anything else with
+// fallback.
+func asProtocolError(err error, fallback func(string) *protocol.Error) *protocol.Error {
+	var mcpErr *protocol.Error
+	if errors.As(err, &mcpErr) {
+		return mcpErr
+	}
+	return fallback(err.Error())
+}
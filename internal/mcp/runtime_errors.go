@@ -3,6 +3,7 @@ package mcp
 import (
 	"errors"
 
+	"github.com/felixgeelhaar/coverctl/internal/application"
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
 )
 
@@ -32,5 +33,26 @@ func classifyRuntimeError(err error) (map[string]any, bool) {
 			ModuleRootRemediation,
 		), true
 	}
+	if code := application.ErrorCodeOf(err); code != "" {
+		resp := errorResponse(
+			RejectionCode(code),
+			runtimeErrorSummary[code],
+			err,
+			application.Remediation(code),
+		)
+		if failures := application.TestFailuresOf(err); len(failures) > 0 {
+			resp["test_failures"] = failures
+		}
+		return resp, true
+	}
 	return nil, false
 }
+
+// runtimeErrorSummary is the one-line summary for each application error
+// code; the code itself is passed through as error_code unchanged.
+var runtimeErrorSummary = map[application.ErrorCode]string{
+	application.ErrCodeParseFormat:   "Coverage profile could not be parsed",
+	application.ErrCodeNoDomains:     "No coverage domains to evaluate",
+	application.ErrCodeRunnerFailed:  "Test runner failed",
+	application.ErrCodeConfigInvalid: "Config file is invalid",
+}
@@ -2,9 +2,12 @@ package mcp
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/felixgeelhaar/coverctl/internal/application"
+
 	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
 )
 
@@ -61,6 +64,37 @@ func TestClassifyRuntimeError_ModuleRootEmitsSchema(t *testing.T) {
 	}
 }
 
+func TestClassifyRuntimeError_ApplicationErrorCode(t *testing.T) {
+	err := fmt.Errorf("check: %w", application.WithErrorCode(application.ErrCodeParseFormat, errors.New("invalid coverage mode line")))
+	resp, ok := classifyRuntimeError(err)
+	if !ok {
+		t.Fatal("coded error should classify")
+	}
+	if got, _ := resp["error_code"].(string); got != "ERR_PARSE_FORMAT" {
+		t.Errorf("error_code = %q, want ERR_PARSE_FORMAT", got)
+	}
+	if got, _ := resp["remediation"].(string); got != application.Remediation(application.ErrCodeParseFormat) {
+		t.Errorf("unexpected remediation %q", got)
+	}
+	if got, _ := resp["summary"].(string); got == "" {
+		t.Error("expected a summary")
+	}
+}
+
+func TestClassifyRuntimeError_TestFailures(t *testing.T) {
+	failures := &application.TestFailuresError{
+		Failures: []application.TestFailure{{Package: "example.com/a", Kind: application.TestFailureBuild}},
+		Err:      errors.New("exit status 1"),
+	}
+	resp, ok := classifyRuntimeError(application.WithErrorCode(application.ErrCodeRunnerFailed, failures))
+	if !ok {
+		t.Fatal("coded error should classify")
+	}
+	if got, _ := resp["test_failures"].([]application.TestFailure); len(got) != 1 || got[0].Kind != application.TestFailureBuild {
+		t.Errorf("test_failures = %v", resp["test_failures"])
+	}
+}
+
 func TestClassifyRuntimeError_WrappedModuleRootStillClassifies(t *testing.T) {
 	inner := &gotool.ModuleRootError{CWD: "/x", Searched: []string{"/x"}}
 	wrapped := errors.New("wrap: " + inner.Error())
@@ -180,6 +180,8 @@ const (
 	OpCodeMissingArg        RejectionCode = "OP_MISSING_ARG"
 	OpCodeInternalError     RejectionCode = "OP_INTERNAL_ERROR"
 	OpCodeModuleRootMissing RejectionCode = "OP_MODULE_ROOT_MISSING"
+	OpCodeConfigMissing     RejectionCode = "OP_CONFIG_MISSING"
+	OpCodeInvalidChange     RejectionCode = "OP_INVALID_CHANGE"
 )
 
 // ModuleRootRemediation is the agent-actionable hint returned when
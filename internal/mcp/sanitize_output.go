@@ -129,6 +129,26 @@ func sanitizeFileResults(rs []domain.FileResult) []domain.FileResult {
 	return out
 }
 
+// sanitizeUncoveredFiles returns a copy of the slice with each File path
+// and domain name canonicalized, on the same grounds as
+// sanitizeFileResults.
+func sanitizeUncoveredFiles(fs []domain.UncoveredFile) []domain.UncoveredFile {
+	if len(fs) == 0 {
+		return fs
+	}
+	out := make([]domain.UncoveredFile, len(fs))
+	for i, f := range fs {
+		f.File = canonicalizePath(f.File)
+		domains := make([]string, len(f.Domains))
+		for j, d := range f.Domains {
+			domains[j] = canonicalizePath(d)
+		}
+		f.Domains = domains
+		out[i] = f
+	}
+	return out
+}
+
 // sanitizeWarnings returns a copy of the slice with each entry passed
 // through sanitizeOutputString. Warnings are free-form and may interpolate
 // user-controlled content; treat as untrusted text.
@@ -184,3 +204,12 @@ func sanitizeDomainDeltas(m map[string]float64) map[string]float64 {
 	}
 	return out
 }
+
+// nonNil returns s, or an empty slice when s is nil, so JSON output always
+// carries an array rather than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -22,6 +22,9 @@ type Server struct {
 	server         *mcp.Server
 	prCommentLimit *rateLimiter
 	telemetry      Telemetry // nil = NoopTelemetry (opt-in via config)
+	subs           *subscriptions
+	session        *sessionCache
+	newWatcher     func() (FileWatcher, error)
 }
 
 // New creates a new MCP server wrapping the given service.
@@ -59,6 +62,9 @@ func New(svc Service, cfg Config, version string) *Server {
 		config:         cfg,
 		prCommentLimit: newRateLimiter(),
 		telemetry:      NoopTelemetry{},
+		subs:           newSubscriptions(),
+		session:        newSessionCache(),
+		newWatcher:     newFileWatcher,
 	}
 
 	// Create MCP server with capabilities
@@ -68,26 +74,29 @@ func New(svc Service, cfg Config, version string) *Server {
 		Capabilities: mcp.Capabilities{
 			Tools:     true,
 			Resources: true,
+			Prompts:   true,
 		},
 	})
 
-	// Register tools and resources
+	// Register tools, resources, and prompts
 	s.registerTools()
 	s.registerResources()
+	s.registerPrompts()
 
 	return s
 }
 
 // Run starts the MCP server and blocks until the context is canceled.
 func (s *Server) Run(ctx context.Context) error {
-	return mcp.ServeStdio(ctx, s.server)
+	s.watchResources(ctx)
+	return s.serveStdio(ctx, os.Stdin, os.Stdout)
 }
 
 // registerTools adds tool handlers to the server, gated by s.config.Mode.
 //
-// Agent mode (default) advertises only the three agent-loop tools (check,
-// suggest, debt) so coding agents have a small, reliable selection
-// surface. CI mode adds setup, dashboarding, and CI/automation tools
+// Agent mode (default) advertises only the five agent-loop tools (check,
+// suggest, debt, uncovered, configure) so coding agents have a small,
+// reliable selection surface. CI mode adds setup, dashboarding, and CI/automation tools
 // (init, report, record, badge, compare, pr-comment) for non-agent
 // callers.
 //
@@ -109,6 +118,14 @@ func (s *Server) registerTools() {
 		Description("Compute coverage debt: the gap between current coverage and required thresholds, ranked per domain and per file. Returns a health score (0-100) and the items contributing the most debt. Use this to direct test-writing effort to the highest-impact gaps.").
 		Handler(s.handleDebt)
 
+	s.server.Tool("uncovered").
+		Description("List files with uncovered statements, most uncovered first, optionally filtered by domain or a glob over module-relative paths. Each file includes its owning domains, uncovered statement count, and, when the profile carries line data, the uncovered line ranges. Reads an existing coverage profile; does not run tests. Use this to pick concrete files and lines to write tests for.").
+		Handler(s.handleUncovered)
+
+	s.server.Tool("configure").
+		Description("Edit .coverctl.yaml with structured changes instead of rewriting it: set_default_min, add_domain, remove_domain, add_exclude (global or per domain). Comments, key order, and unrelated settings are preserved. Returns a unified diff; dryRun=true previews without writing, otherwise the previous file is backed up first.").
+		Handler(s.handleConfigure)
+
 	if agent {
 		return
 	}
@@ -141,14 +158,14 @@ func (s *Server) registerTools() {
 // registerResources adds all resource handlers to the server.
 func (s *Server) registerResources() {
 	// Debt resource
-	s.server.Resource("coverctl://debt").
+	s.server.Resource(uriDebt).
 		Name("Coverage Debt").
 		Description("Shows coverage debt - gap between current and required coverage thresholds").
 		MimeType("application/json").
 		Handler(s.handleDebtResource)
 
 	// Trend resource
-	s.server.Resource("coverctl://trend").
+	s.server.Resource(uriTrend).
 		Name("Coverage Trend").
 		Description("Shows coverage trends over time from recorded history").
 		MimeType("application/json").
@@ -211,12 +228,16 @@ func (s *Server) handleCheck(ctx context.Context, input CheckInput) (map[string]
 		},
 	}
 
-	// Add history store if ratchet is enabled
+	// The history file decides which domains are new (policy.new_domain);
+	// it also backs the ratchet when enabled.
+	store := &history.FileStore{Path: s.config.HistoryPath}
+	opts.BaselineStore = store
 	if input.Ratchet {
-		opts.HistoryStore = &history.FileStore{Path: s.config.HistoryPath}
+		opts.HistoryStore = store
 	}
 
 	result, err := s.svc.CheckResult(ctx, opts)
+	s.session.invalidate()
 	s.telemetry.RecordToolCall("check", time.Since(start), err, false)
 
 	if classified, ok := classifyRuntimeError(err); ok {
@@ -227,11 +248,15 @@ func (s *Server) handleCheck(ctx context.Context, input CheckInput) (map[string]
 	domains, domainCursor := applyDomainBudget(result.Domains, v)
 	files, fileCursor := applyFileBudget(result.Files, v)
 	output := map[string]any{
+		"schema":   application.JSONSchema,
 		"passed":   result.Passed,
 		"summary":  sanitizeOutputString(generateSummary(result)),
-		"domains":  sanitizeDomainResults(domains),
-		"files":    sanitizeFileResults(files),
-		"warnings": sanitizeWarnings(result.Warnings),
+		"domains":  nonNil(sanitizeDomainResults(domains)),
+		"files":    nonNil(sanitizeFileResults(files)),
+		"warnings": nonNil(sanitizeWarnings(result.Warnings)),
+	}
+	if len(result.EmptyDomains) > 0 {
+		output["emptyDomains"] = sanitizeWarnings(result.EmptyDomains)
 	}
 	if domainCursor != "" {
 		output["domainsNextCursor"] = domainCursor
@@ -252,55 +277,6 @@ func (s *Server) handleCheck(ctx context.Context, input CheckInput) (map[string]
 	return output, nil
 }
 
-func (s *Server) handleReport(ctx context.Context, input ReportInput) (map[string]any, error) {
-	defer traceTool("report")()
-	if err := validateScopedInputs(
-		namedPath{"configPath", input.ConfigPath},
-		namedPath{"profile", input.Profile},
-	); err != nil {
-		return rejectionResponse(err), nil
-	}
-
-	opts := application.ReportOptions{
-		ConfigPath:    s.resolveConfigPath(input.ConfigPath),
-		Profile:       coalesce(input.Profile, s.config.ProfilePath),
-		Output:        application.OutputJSON,
-		Domains:       input.Domains,
-		ShowUncovered: input.ShowUncovered,
-		DiffRef:       input.DiffRef,
-	}
-
-	result, err := s.svc.ReportResult(ctx, opts)
-
-	if classified, ok := classifyRuntimeError(err); ok {
-		return classified, nil
-	}
-
-	v := resolveVerbosity(input.Verbosity)
-	domains, domainCursor := applyDomainBudget(result.Domains, v)
-	files, fileCursor := applyFileBudget(result.Files, v)
-	output := map[string]any{
-		"passed":   result.Passed,
-		"summary":  sanitizeOutputString(generateSummary(result)),
-		"domains":  sanitizeDomainResults(domains),
-		"files":    sanitizeFileResults(files),
-		"warnings": sanitizeWarnings(result.Warnings),
-	}
-	if domainCursor != "" {
-		output["domainsNextCursor"] = domainCursor
-	}
-	if fileCursor != "" {
-		output["filesNextCursor"] = fileCursor
-	}
-
-	if err != nil {
-		output["passed"] = false
-		output["error"] = sanitizeOutputString(err.Error())
-	}
-
-	return output, nil
-}
-
 type recordWarner interface {
 	RecordWithWarnings(ctx context.Context, opts application.RecordOptions, store application.HistoryStore) (application.RecordResult, error)
 }
@@ -436,6 +412,7 @@ func (s *Server) handleInit(ctx context.Context, input InitInput) (map[string]an
 		domainNames = append(domainNames, d.Name)
 	}
 
+	s.session.invalidate()
 	s.telemetry.RecordActivationStep("init_completed", repoFingerprint())
 
 	return map[string]any{
@@ -451,7 +428,7 @@ func (s *Server) handleInit(ctx context.Context, input InitInput) (map[string]an
 // Resource handlers
 
 func (s *Server) handleDebtResource(ctx context.Context, uri string, params map[string]string) (*mcp.ResourceContent, error) {
-	result, err := s.svc.Debt(ctx, application.DebtOptions{
+	result, err := s.debt(ctx, application.DebtOptions{
 		ConfigPath:  s.config.ConfigPath,
 		ProfilePath: s.config.ProfilePath,
 		Output:      application.OutputJSON,
@@ -498,7 +475,7 @@ func (s *Server) handleTrendResource(ctx context.Context, uri string, params map
 }
 
 func (s *Server) handleSuggestResource(ctx context.Context, uri string, params map[string]string) (*mcp.ResourceContent, error) {
-	result, err := s.svc.Suggest(ctx, application.SuggestOptions{
+	result, err := s.suggest(ctx, application.SuggestOptions{
 		ConfigPath:  s.config.ConfigPath,
 		ProfilePath: s.config.ProfilePath,
 		Strategy:    application.SuggestCurrent,
@@ -552,6 +529,8 @@ func (s *Server) handleSuggest(ctx context.Context, input SuggestInput) (map[str
 		strategy = application.SuggestAggressive
 	case "conservative":
 		strategy = application.SuggestConservative
+	case "history":
+		strategy = application.SuggestHistory
 	case "current", "":
 		strategy = application.SuggestCurrent
 	}
@@ -563,8 +542,19 @@ func (s *Server) handleSuggest(ctx context.Context, input SuggestInput) (map[str
 		ProfilePath: coalesce(input.Profile, s.config.ProfilePath),
 		Strategy:    strategy,
 	}
+	if strategy == application.SuggestHistory {
+		opts.HistoryStore = &history.FileStore{Path: s.config.HistoryPath}
+	}
 
-	result, err := s.svc.Suggest(ctx, opts)
+	var result application.SuggestResult
+	var err error
+	if input.WriteConfig {
+		// The suggested config is edited and written below, so it must not
+		// be shared with later calls.
+		result, err = s.svc.Suggest(ctx, opts)
+	} else {
+		result, err = s.suggest(ctx, opts)
+	}
 
 	if classified, ok := classifyRuntimeError(err); ok {
 		return classified, nil
@@ -601,6 +591,7 @@ func (s *Server) handleSuggest(ctx context.Context, input SuggestInput) (map[str
 			output["summary"] = "Failed to write config"
 			return output, nil
 		}
+		s.session.invalidate()
 
 		output["configPath"] = configPath
 		if backupPath != "" {
@@ -629,7 +620,7 @@ func (s *Server) handleDebt(ctx context.Context, input DebtInput) (map[string]an
 		Output:      application.OutputJSON,
 	}
 
-	result, err := s.svc.Debt(ctx, opts)
+	result, err := s.debt(ctx, opts)
 
 	if classified, ok := classifyRuntimeError(err); ok {
 		return classified, nil
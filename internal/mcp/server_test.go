@@ -2,8 +2,10 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/coverctl/internal/application"
@@ -28,6 +30,10 @@ type mockService struct {
 	recordOpts    application.RecordOptions
 	debtResult    application.DebtResult
 	debtErr       error
+	debtBlock     chan struct{} // When set, Debt waits for it to close (simulates a slow tool)
+	uncovered     application.UncoveredResult
+	uncoveredErr  error
+	uncoveredOpts application.UncoveredOptions
 	trendResult   application.TrendResult
 	trendErr      error
 	suggestResult application.SuggestResult
@@ -60,9 +66,17 @@ func (m *mockService) RecordWithWarnings(ctx context.Context, opts application.R
 }
 
 func (m *mockService) Debt(ctx context.Context, opts application.DebtOptions) (application.DebtResult, error) {
+	if m.debtBlock != nil {
+		<-m.debtBlock
+	}
 	return m.debtResult, m.debtErr
 }
 
+func (m *mockService) Uncovered(ctx context.Context, opts application.UncoveredOptions) (application.UncoveredResult, error) {
+	m.uncoveredOpts = opts
+	return m.uncovered, m.uncoveredErr
+}
+
 func (m *mockService) Trend(ctx context.Context, opts application.TrendOptions, store application.HistoryStore) (application.TrendResult, error) {
 	return m.trendResult, m.trendErr
 }
@@ -158,7 +172,7 @@ func TestDispatch_AgentModeDispatchesAgentTools(t *testing.T) {
 	svc := &mockService{}
 	server := New(svc, Config{Mode: ModeAgent}, "test")
 
-	for _, tool := range []string{"check", "suggest", "debt"} {
+	for _, tool := range []string{"check", "suggest", "debt", "uncovered", "configure"} {
 		t.Run(tool, func(t *testing.T) {
 			out, err := server.Dispatch(t.Context(), tool, map[string]any{})
 			if err != nil {
@@ -435,6 +449,24 @@ func TestHandleReport(t *testing.T) {
 	}
 }
 
+func TestHandleReportStableShape(t *testing.T) {
+	server := New(&mockService{reportResult: domain.Result{Passed: true}}, DefaultConfig(), "test")
+
+	output, err := server.handleReport(context.Background(), ReportInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{`"schema":"coverctl/v1"`, `"domains":[]`, `"files":[]`, `"warnings":[]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in %s", want, data)
+		}
+	}
+}
+
 func TestHandleRecord(t *testing.T) {
 	svc := &mockService{
 		recordResult: application.RecordResult{
@@ -459,6 +491,94 @@ func TestHandleRecord(t *testing.T) {
 	}
 }
 
+func TestHandleUncovered(t *testing.T) {
+	svc := &mockService{
+		uncovered: application.UncoveredResult{
+			Files: []domain.UncoveredFile{
+				{File: "internal/core/a`b.go", Domains: []string{"core"}, Uncovered: 8, Total: 10},
+			},
+			Total: 3,
+		},
+	}
+	server := New(svc, DefaultConfig(), "test")
+
+	output, err := server.handleUncovered(context.Background(), UncoveredInput{Domains: []string{"core"}})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if svc.uncoveredOpts.Limit != normalRowCap || svc.uncoveredOpts.ProfilePath != ".cover/coverage.out" {
+		t.Errorf("unexpected options: %+v", svc.uncoveredOpts)
+	}
+	files, ok := output["files"].([]domain.UncoveredFile)
+	if !ok || len(files) != 1 || files[0].File != "internal/core/a?b.go" {
+		t.Fatalf("expected sanitized files, got %v", output["files"])
+	}
+	if output["nextCursor"] != "next/1/of/3" {
+		t.Errorf("expected cursor for omitted files, got %v", output["nextCursor"])
+	}
+
+	svc.uncoveredErr = errors.New("no matching domains found for: [missing]")
+	output, _ = server.handleUncovered(context.Background(), UncoveredInput{Domains: []string{"missing"}})
+	if passed, _ := output["passed"].(bool); passed {
+		t.Errorf("expected failure output, got %v", output)
+	}
+}
+
+func TestHandleConfigure(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	original := "version: 1\npolicy:\n  default:\n    min: 70 # team floor\n  domains:\n    - name: core\n      match: [\"./internal/core/...\"]\n"
+	if err := os.WriteFile(".coverctl.yaml", []byte(original), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	server := New(&mockService{}, DefaultConfig(), "test")
+	minVal := 80.0
+	changes := []ConfigChange{{Op: "set_default_min", Min: &minVal}}
+
+	output, err := server.handleConfigure(context.Background(), ConfigureInput{Changes: changes, DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff, _ := output["diff"].(string); !strings.Contains(diff, "+    min: 80 # team floor") {
+		t.Fatalf("expected diff in dry run output, got %v", output)
+	}
+	if data, _ := os.ReadFile(".coverctl.yaml"); string(data) != original {
+		t.Fatalf("dry run modified the config:\n%s", data)
+	}
+
+	output, _ = server.handleConfigure(context.Background(), ConfigureInput{Changes: changes})
+	if output["backupPath"] != ".coverctl.yaml.backup" {
+		t.Fatalf("expected backup path, got %v", output)
+	}
+	if data, _ := os.ReadFile(".coverctl.yaml"); !strings.Contains(string(data), "min: 80 # team floor") {
+		t.Fatalf("expected edited config, got:\n%s", data)
+	}
+
+	domainMin := 85.0
+	output, _ = server.handleConfigure(context.Background(), ConfigureInput{Changes: []ConfigChange{{Op: "set_domain_min", Domain: "core", Min: &domainMin}}})
+	if passed, _ := output["passed"].(bool); !passed {
+		t.Fatalf("expected set_domain_min to succeed, got %v", output)
+	}
+	if data, _ := os.ReadFile(".coverctl.yaml"); !strings.Contains(string(data), "min: 85") {
+		t.Fatalf("expected core minimum in config, got:\n%s", data)
+	}
+	output, _ = server.handleConfigure(context.Background(), ConfigureInput{Changes: []ConfigChange{{Op: "set_domain_min", Domain: "missing", Min: &domainMin}}})
+	if output["error_code"] != string(OpCodeInvalidChange) {
+		t.Errorf("expected set_domain_min on a missing domain to be rejected, got %v", output)
+	}
+
+	output, _ = server.handleConfigure(context.Background(), ConfigureInput{Changes: []ConfigChange{{Op: "remove_domain", Domain: "missing"}}})
+	if output["error_code"] != string(OpCodeInvalidChange) {
+		t.Errorf("expected invalid change code, got %v", output)
+	}
+
+	output, _ = server.handleConfigure(context.Background(), ConfigureInput{Changes: []ConfigChange{{Op: "add_domain", Domain: "x", Match: []string{"../outside/..."}}}})
+	if output["error_code"] != string(CodePathScope) {
+		t.Errorf("expected out-of-tree match to be rejected, got %v", output)
+	}
+}
+
 func TestHandleDebtResource(t *testing.T) {
 	svc := &mockService{
 		debtResult: application.DebtResult{
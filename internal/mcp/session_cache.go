@@ -0,0 +1,125 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// sessionCache memoizes read-only analysis results (debt, uncovered,
+// report, suggest) for the life of the server, so an agent that calls
+// several tools in a row against the same profile pays for loading the
+// config and aggregating coverage once. It only serves hits once
+// watchResources is watching both the config and the profile; any change
+// to either, or a tool that rewrites them, clears it.
+type sessionCache struct {
+	mu      sync.Mutex
+	armed   bool
+	entries map[string]any
+}
+
+func newSessionCache() *sessionCache {
+	return &sessionCache{entries: make(map[string]any)}
+}
+
+// arm starts serving cached results. Until then every lookup misses.
+func (c *sessionCache) arm() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.armed = true
+}
+
+// invalidate drops every cached result.
+func (c *sessionCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+func (c *sessionCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.armed {
+		return nil, false
+	}
+	v, ok := c.entries[key]
+	return v, ok
+}
+
+func (c *sessionCache) put(key string, v any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.armed {
+		c.entries[key] = v
+	}
+}
+
+// cachedCall returns the cached result for tool and opts, or calls fn and
+// caches a successful result. Errors are never cached so a fixed config
+// or profile is picked up on the next call.
+func cachedCall[O, R any](c *sessionCache, tool string, opts O, fn func() (R, error)) (R, error) {
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return fn()
+	}
+	key := tool + ":" + string(data)
+	if v, ok := c.get(key); ok {
+		if r, ok := v.(R); ok {
+			return r, nil
+		}
+	}
+	r, err := fn()
+	if err == nil {
+		c.put(key, r)
+	}
+	return r, err
+}
+
+// cacheable reports whether results for configPath and profilePath can be
+// reused: only the files the server watches are known to be unchanged.
+func (s *Server) cacheable(configPath, profilePath string) bool {
+	return configPath == s.config.ConfigPath && profilePath == s.config.ProfilePath
+}
+
+func (s *Server) debt(ctx context.Context, opts application.DebtOptions) (application.DebtResult, error) {
+	if !s.cacheable(opts.ConfigPath, opts.ProfilePath) {
+		return s.svc.Debt(ctx, opts)
+	}
+	return cachedCall(s.session, "debt", opts, func() (application.DebtResult, error) {
+		return s.svc.Debt(ctx, opts)
+	})
+}
+
+func (s *Server) uncovered(ctx context.Context, opts application.UncoveredOptions) (application.UncoveredResult, error) {
+	if !s.cacheable(opts.ConfigPath, opts.ProfilePath) {
+		return s.svc.Uncovered(ctx, opts)
+	}
+	return cachedCall(s.session, "uncovered", opts, func() (application.UncoveredResult, error) {
+		return s.svc.Uncovered(ctx, opts)
+	})
+}
+
+// reportResult skips the cache for diff-scoped reports, which also depend
+// on the git working tree.
+func (s *Server) reportResult(ctx context.Context, opts application.ReportOptions) (domain.Result, error) {
+	if !s.cacheable(opts.ConfigPath, opts.Profile) || opts.DiffRef != "" || opts.HistoryStore != nil {
+		return s.svc.ReportResult(ctx, opts)
+	}
+	return cachedCall(s.session, "report", opts, func() (domain.Result, error) {
+		return s.svc.ReportResult(ctx, opts)
+	})
+}
+
+// suggest skips the cache for the history strategy, which reads the
+// history file rather than the profile.
+func (s *Server) suggest(ctx context.Context, opts application.SuggestOptions) (application.SuggestResult, error) {
+	if !s.cacheable(opts.ConfigPath, opts.ProfilePath) || opts.HistoryStore != nil {
+		return s.svc.Suggest(ctx, opts)
+	}
+	return cachedCall(s.session, "suggest", opts, func() (application.SuggestResult, error) {
+		return s.svc.Suggest(ctx, opts)
+	})
+}
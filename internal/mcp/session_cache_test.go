@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// countingService counts Debt calls so tests can tell cache hits apart.
+type countingService struct {
+	*mockService
+	debtCalls atomic.Int32
+}
+
+func (c *countingService) Debt(ctx context.Context, opts application.DebtOptions) (application.DebtResult, error) {
+	c.debtCalls.Add(1)
+	return c.mockService.Debt(ctx, opts)
+}
+
+func watchedServer(t *testing.T, svc Service) (*Server, []*fakeFileWatcher) {
+	t.Helper()
+	server := New(svc, DefaultConfig(), "test")
+	var watchers []*fakeFileWatcher
+	server.newWatcher = func() (FileWatcher, error) {
+		w := &fakeFileWatcher{events: make(chan struct{})}
+		watchers = append(watchers, w)
+		return w, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	server.watchResources(ctx)
+	return server, watchers
+}
+
+func TestSessionCacheReusesDebtUntilConfigChanges(t *testing.T) {
+	svc := &countingService{mockService: &mockService{debtResult: application.DebtResult{HealthScore: 90}}}
+	server, watchers := watchedServer(t, svc)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := server.handleDebt(ctx, DebtInput{}); err != nil {
+			t.Fatalf("debt: %v", err)
+		}
+	}
+	if got := svc.debtCalls.Load(); got != 1 {
+		t.Fatalf("expected one service call for repeated debt, got %d", got)
+	}
+
+	// The config watcher is the third one; a change clears the cache.
+	watchers[2].events <- struct{}{}
+	deadline := time.Now().Add(2 * time.Second)
+	for svc.debtCalls.Load() < 2 && time.Now().Before(deadline) {
+		if _, err := server.handleDebt(ctx, DebtInput{}); err != nil {
+			t.Fatalf("debt: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := svc.debtCalls.Load(); got != 2 {
+		t.Fatalf("expected config change to force a recompute, got %d calls", got)
+	}
+}
+
+func TestSessionCacheBypass(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not watching", func(t *testing.T) {
+		svc := &countingService{mockService: &mockService{}}
+		server := New(svc, DefaultConfig(), "test")
+		_, _ = server.handleDebt(ctx, DebtInput{})
+		_, _ = server.handleDebt(ctx, DebtInput{})
+		if got := svc.debtCalls.Load(); got != 2 {
+			t.Fatalf("unwatched server must not cache, got %d calls", got)
+		}
+	})
+
+	t.Run("other profile", func(t *testing.T) {
+		svc := &countingService{mockService: &mockService{}}
+		server, _ := watchedServer(t, svc)
+		_, _ = server.handleDebt(ctx, DebtInput{Profile: "other.out"})
+		_, _ = server.handleDebt(ctx, DebtInput{Profile: "other.out"})
+		if got := svc.debtCalls.Load(); got != 2 {
+			t.Fatalf("unwatched profile must not cache, got %d calls", got)
+		}
+	})
+
+	t.Run("check clears", func(t *testing.T) {
+		svc := &countingService{mockService: &mockService{}}
+		server, _ := watchedServer(t, svc)
+		_, _ = server.handleDebt(ctx, DebtInput{})
+		_, _ = server.handleCheck(ctx, CheckInput{})
+		_, _ = server.handleDebt(ctx, DebtInput{})
+		if got := svc.debtCalls.Load(); got != 2 {
+			t.Fatalf("check must clear the session cache, got %d calls", got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		svc := &countingService{mockService: &mockService{debtErr: context.DeadlineExceeded}}
+		server, _ := watchedServer(t, svc)
+		_, _ = server.handleDebt(ctx, DebtInput{})
+		_, _ = server.handleDebt(ctx, DebtInput{})
+		if got := svc.debtCalls.Load(); got != 2 {
+			t.Fatalf("failed results must not be cached, got %d calls", got)
+		}
+	})
+}
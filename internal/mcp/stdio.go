@@ -0,0 +1,216 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/felixgeelhaar/mcp-go/protocol"
+	"github.com/felixgeelhaar/mcp-go/transport"
+)
+
+// stdio transport
+//
+// The loop reads newline-delimited JSON-RPC messages from the client and
+// is built to outlive the failure modes that used to end a session with
+// "server is closing: EOF":
+//
+//   - Messages are read with a growing buffer, so a message split across
+//     several reads, or larger than any fixed line limit, is reassembled.
+//   - A malformed message gets a JSON-RPC parse error and is logged; the
+//     loop keeps reading.
+//   - Requests run one at a time, in order, on a worker. Pings are answered
+//     directly by the reader, so the client's keepalive succeeds while a
+//     long check is running.
+//   - On EOF the loop stops reading but still finishes queued requests and
+//     writes their responses before returning.
+//   - When ctx is canceled (SIGTERM) the loop stops reading and gives
+//     running and queued requests stdioShutdownGrace to finish before their
+//     context is canceled.
+
+// stdioShutdownGrace bounds how long requests may keep running after ctx is
+// canceled.
+const stdioShutdownGrace = 10 * time.Second
+
+// stdioQueueSize is how many requests may wait behind a running one before
+// the reader stops reading.
+const stdioQueueSize = 32
+
+// serveStdio runs the MCP session over in/out until in is exhausted or ctx
+// is canceled. It returns nil on a clean EOF or shutdown.
+func (s *Server) serveStdio(ctx context.Context, in io.Reader, out io.Writer) error {
+	w := &lineWriter{w: out}
+	rpc := s.subscriptionMiddleware()(s.handleRPC)
+
+	// Requests outlive ctx by up to stdioShutdownGrace.
+	reqCtx, cancelRequests := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRequests()
+	reqCtx = transport.ContextWithNotificationSender(reqCtx, w)
+
+	queue := make(chan *protocol.Request, stdioQueueSize)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for req := range queue {
+			s.serveStdioRequest(reqCtx, rpc, w, req)
+		}
+	}()
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	stopReading := make(chan struct{})
+	defer close(stopReading)
+	go readLines(in, lines, readErr, stopReading)
+
+	slog.Info("mcp stdio session started")
+	var err error
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("mcp stdio shutdown requested; finishing in-flight requests")
+			break loop
+		case line, ok := <-lines:
+			if !ok {
+				err = <-readErr
+				slog.Info("mcp stdio input closed", "error", err)
+				break loop
+			}
+			req, perr := parseMessage(line)
+			if perr != nil {
+				slog.Warn("mcp protocol error", "error", perr, "bytes", len(line))
+				w.write(protocol.NewErrorResponse(nil, protocol.NewParseError(perr.Error())))
+				continue
+			}
+			if req.Method == "" {
+				// coverctl sends no requests, so there are no responses to
+				// match this against.
+				slog.Debug("mcp ignoring message without method")
+				continue
+			}
+			if req.Method == protocol.MethodPing && !req.IsNotification() {
+				w.write(protocol.NewResponse(req.ID, map[string]any{}))
+				continue
+			}
+			select {
+			case queue <- req:
+			case <-ctx.Done():
+				break loop
+			}
+		}
+	}
+	close(queue)
+
+	if ctx.Err() == nil {
+		// Input ended: finish everything that was asked for.
+		select {
+		case <-done:
+			return err
+		case <-ctx.Done():
+		}
+	}
+	select {
+	case <-done:
+	case <-time.After(stdioShutdownGrace):
+		slog.Warn("mcp requests still running after shutdown grace period; canceling")
+		cancelRequests()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			slog.Error("mcp requests ignored cancellation; exiting without their responses")
+		}
+	}
+	return err
+}
+
+func (s *Server) serveStdioRequest(ctx context.Context, rpc func(context.Context, *protocol.Request) (*protocol.Response, error), w *lineWriter, req *protocol.Request) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("mcp request panicked", "method", req.Method, "panic", r)
+			if !req.IsNotification() {
+				w.write(protocol.NewErrorResponse(req.ID, protocol.NewInternalError(fmt.Sprint(r))))
+			}
+		}
+	}()
+
+	resp, err := rpc(ctx, req)
+	if req.IsNotification() {
+		return
+	}
+	if err != nil {
+		resp = protocol.NewErrorResponse(req.ID, asProtocolError(err, protocol.NewInternalError))
+	}
+	w.write(resp)
+}
+
+// readLines sends each newline-terminated message from in, then closes
+// lines and reports the terminating error (nil for EOF). It gives up when
+// stop is closed.
+func readLines(in io.Reader, lines chan<- []byte, readErr chan<- error, stop <-chan struct{}) {
+	r := bufio.NewReader(in)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			select {
+			case lines <- line:
+			case <-stop:
+				return
+			}
+		}
+		if err != nil {
+			close(lines)
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			readErr <- err
+			return
+		}
+	}
+}
+
+func parseMessage(line []byte) (*protocol.Request, error) {
+	var req protocol.Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return nil, fmt.Errorf("invalid JSON-RPC message: %w", err)
+	}
+	return &req, nil
+}
+
+// lineWriter serializes responses and notifications onto the output, one
+// JSON message per line.
+type lineWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lineWriter) write(v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("mcp response not serializable", "error", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(append(data, '\n')); err != nil {
+		slog.Warn("mcp write failed", "error", err)
+	}
+}
+
+// SendNotification implements transport.NotificationSender.
+func (l *lineWriter) SendNotification(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	l.write(transport.Notification{JSONRPC: "2.0", Method: method, Params: raw})
+	return nil
+}
+
+var _ transport.NotificationSender = (*lineWriter)(nil)
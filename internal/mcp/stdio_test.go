@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+)
+
+// stdioSession drives serveStdio over pipes, like an MCP client would.
+type stdioSession struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	out    *bufio.Reader
+	result chan error
+}
+
+func startStdioSession(t *testing.T, ctx context.Context, svc Service) *stdioSession {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	server := New(svc, DefaultConfig(), "test")
+	result := make(chan error, 1)
+	go func() {
+		result <- server.serveStdio(ctx, inR, outW)
+		outW.Close()
+	}()
+	return &stdioSession{t: t, in: inW, out: bufio.NewReader(outR), result: result}
+}
+
+func (s *stdioSession) send(line string) {
+	s.t.Helper()
+	if _, err := io.WriteString(s.in, line+"\n"); err != nil {
+		s.t.Fatalf("write: %v", err)
+	}
+}
+
+// recv reads the next message, failing the test if none arrives in time.
+func (s *stdioSession) recv() map[string]any {
+	s.t.Helper()
+	type msg struct {
+		line []byte
+		err  error
+	}
+	ch := make(chan msg, 1)
+	go func() {
+		line, err := s.out.ReadBytes('\n')
+		ch <- msg{line, err}
+	}()
+	select {
+	case m := <-ch:
+		if m.err != nil {
+			s.t.Fatalf("read: %v", m.err)
+		}
+		var decoded map[string]any
+		if err := json.Unmarshal(m.line, &decoded); err != nil {
+			s.t.Fatalf("invalid response %q: %v", m.line, err)
+		}
+		return decoded
+	case <-time.After(5 * time.Second):
+		s.t.Fatal("timed out waiting for response")
+		return nil
+	}
+}
+
+func (s *stdioSession) wait() error {
+	s.t.Helper()
+	select {
+	case err := <-s.result:
+		return err
+	case <-time.After(5 * time.Second):
+		s.t.Fatal("serveStdio did not return")
+		return nil
+	}
+}
+
+func toolText(t *testing.T, resp map[string]any) map[string]any {
+	t.Helper()
+	result, ok := resp["result"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected result, got %v", resp)
+	}
+	content := result["content"].([]any)[0].(map[string]any)
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(content["text"].(string)), &decoded); err != nil {
+		t.Fatalf("tool text is not JSON: %v", err)
+	}
+	return decoded
+}
+
+func TestServeStdioSession(t *testing.T) {
+	svc := &mockService{checkResult: domain.Result{
+		Passed:  true,
+		Domains: []domain.DomainResult{{Domain: "core", Percent: 90, Required: 80, Status: domain.StatusPass}},
+	}}
+	session := startStdioSession(t, context.Background(), svc)
+
+	session.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	init := session.recv()
+	if init["id"] != float64(1) || init["result"].(map[string]any)["serverInfo"] == nil {
+		t.Fatalf("unexpected initialize response: %v", init)
+	}
+	session.send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+
+	// A malformed line gets a parse error and the session continues.
+	session.send(`{"jsonrpc":`)
+	if parseErr := session.recv(); parseErr["error"] == nil {
+		t.Fatalf("expected parse error, got %v", parseErr)
+	}
+
+	session.send(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	tools := session.recv()["result"].(map[string]any)["tools"].([]any)
+	if len(tools) != 5 {
+		t.Fatalf("expected 5 agent tools, got %d", len(tools))
+	}
+
+	// Well past bufio.Scanner's 64KB default line limit.
+	padding := strings.Repeat("x", 100_000)
+	session.send(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"check","arguments":{},"_meta":{"padding":"` + padding + `"}}}`)
+	call := session.recv()
+	if call["id"] != float64(3) {
+		t.Fatalf("unexpected tools/call response: %v", call)
+	}
+	if passed := toolText(t, call)["passed"]; passed != true {
+		t.Fatalf("expected passed check, got %v", passed)
+	}
+
+	session.in.Close()
+	if err := session.wait(); err != nil {
+		t.Fatalf("expected clean EOF, got %v", err)
+	}
+}
+
+func TestServeStdioPingDuringSlowTool(t *testing.T) {
+	svc := &mockService{debtBlock: make(chan struct{})}
+	session := startStdioSession(t, context.Background(), svc)
+
+	session.send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"debt","arguments":{}}}`)
+	session.send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	if pong := session.recv(); pong["id"] != float64(2) {
+		t.Fatalf("expected ping answered first, got %v", pong)
+	}
+
+	close(svc.debtBlock)
+	if call := session.recv(); call["id"] != float64(1) {
+		t.Fatalf("expected debt response, got %v", call)
+	}
+	session.in.Close()
+	_ = session.wait()
+}
+
+func TestServeStdioFlushesInFlightResponses(t *testing.T) {
+	t.Run("on EOF", func(t *testing.T) {
+		svc := &mockService{debtBlock: make(chan struct{})}
+		session := startStdioSession(t, context.Background(), svc)
+
+		session.send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"debt","arguments":{}}}`)
+		session.in.Close()
+		close(svc.debtBlock)
+		if call := session.recv(); call["id"] != float64(1) {
+			t.Fatalf("expected in-flight response after EOF, got %v", call)
+		}
+		if err := session.wait(); err != nil {
+			t.Fatalf("expected clean EOF, got %v", err)
+		}
+	})
+
+	t.Run("on shutdown", func(t *testing.T) {
+		svc := &mockService{
+			debtBlock:  make(chan struct{}),
+			debtResult: application.DebtResult{HealthScore: 75},
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		session := startStdioSession(t, ctx, svc)
+
+		session.send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"debt","arguments":{}}}`)
+		// Give the worker a moment to pick up the call before shutdown.
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		close(svc.debtBlock)
+
+		call := session.recv()
+		if call["id"] != float64(1) || toolText(t, call)["healthScore"] != float64(75) {
+			t.Fatalf("expected in-flight debt response after shutdown, got %v", call)
+		}
+		if err := session.wait(); err != nil {
+			t.Fatalf("expected clean shutdown, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/watcher"
+	"github.com/felixgeelhaar/mcp-go"
+	"github.com/felixgeelhaar/mcp-go/protocol"
+	"github.com/felixgeelhaar/mcp-go/transport"
+)
+
+// Resource subscriptions let a client keep a live coverage panel without
+// polling: after resources/subscribe, the server sends
+// notifications/resources/updated whenever the file behind the resource
+// changes. The MCP library routes neither subscribe request, so they are
+// answered by subscriptionMiddleware before the library sees them.
+
+const (
+	uriDebt  = "coverctl://debt"
+	uriTrend = "coverctl://trend"
+)
+
+// subscriptionDebounce coalesces the burst of writes a test run makes to
+// the profile into one notification.
+const subscriptionDebounce = 500 * time.Millisecond
+
+// FileWatcher reports debounced changes to individually watched files.
+type FileWatcher interface {
+	WatchFile(path string) error
+	Events(ctx context.Context) <-chan struct{}
+	Close() error
+}
+
+func newFileWatcher() (FileWatcher, error) {
+	return watcher.New(watcher.WithDebounce(subscriptionDebounce), watcher.WithExtensions())
+}
+
+// subscriptions tracks subscribed resource URIs and where to send their
+// update notifications.
+type subscriptions struct {
+	mu     sync.Mutex
+	uris   map[string]bool
+	sender transport.NotificationSender
+}
+
+func newSubscriptions() *subscriptions {
+	return &subscriptions{uris: make(map[string]bool)}
+}
+
+func (s *subscriptions) subscribe(uri string, sender transport.NotificationSender) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uris[uri] = true
+	if sender != nil {
+		s.sender = sender
+	}
+}
+
+func (s *subscriptions) unsubscribe(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uris, uri)
+}
+
+// notify sends a resource-updated notification if uri is subscribed.
+func (s *subscriptions) notify(uri string) error {
+	s.mu.Lock()
+	sender := s.sender
+	subscribed := s.uris[uri]
+	s.mu.Unlock()
+	if !subscribed || sender == nil {
+		return nil
+	}
+	return sender.SendNotification(protocol.MethodResourceUpdated, mcp.ResourceUpdatedNotification{URI: uri})
+}
+
+// subscriptionMiddleware answers resources/subscribe and
+// resources/unsubscribe, and advertises the subscribe capability in the
+// initialize response.
+func (s *Server) subscriptionMiddleware() mcp.Middleware {
+	return func(next mcp.MiddlewareHandlerFunc) mcp.MiddlewareHandlerFunc {
+		return func(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+			switch req.Method {
+			case protocol.MethodResourcesSubscribe, protocol.MethodResourcesUnsubscribe:
+				var params mcp.SubscribeRequest
+				if err := json.Unmarshal(req.Params, &params); err != nil {
+					return nil, protocol.NewInvalidParams("invalid subscribe params")
+				}
+				if params.URI != uriDebt && params.URI != uriTrend {
+					return nil, protocol.NewInvalidParams(fmt.Sprintf("resource %q does not support subscriptions", params.URI))
+				}
+				if req.Method == protocol.MethodResourcesSubscribe {
+					s.subs.subscribe(params.URI, transport.NotificationSenderFromContext(ctx))
+				} else {
+					s.subs.unsubscribe(params.URI)
+				}
+				return protocol.NewResponse(req.ID, map[string]any{}), nil
+			case protocol.MethodInitialize:
+				resp, err := next(ctx, req)
+				if err == nil && resp != nil {
+					advertiseSubscribe(resp)
+				}
+				return resp, err
+			default:
+				return next(ctx, req)
+			}
+		}
+	}
+}
+
+func advertiseSubscribe(resp *protocol.Response) {
+	result, ok := resp.Result.(map[string]any)
+	if !ok {
+		return
+	}
+	caps, ok := result["capabilities"].(map[string]any)
+	if !ok {
+		return
+	}
+	resources, ok := caps["resources"].(map[string]any)
+	if !ok {
+		resources = map[string]any{}
+		caps["resources"] = resources
+	}
+	resources["subscribe"] = true
+}
+
+// watchResources notifies subscribers when the coverage profile (debt) or
+// the history file (trend) changes, until ctx is done, and clears the
+// session cache when the profile or the config changes. A file whose
+// directory does not exist yet is skipped with a warning; the resource
+// can still be read, it just is not pushed, and the session cache stays
+// off because a change to it would go unnoticed.
+func (s *Server) watchResources(ctx context.Context) {
+	watched := []struct {
+		path, uri  string
+		invalidate bool
+	}{
+		{s.config.ProfilePath, uriDebt, true},
+		{s.config.HistoryPath, uriTrend, false},
+		{s.config.ConfigPath, "", true},
+	}
+	invalidators := 0
+	for _, w := range watched {
+		fw, err := s.newWatcher()
+		if err != nil {
+			slog.Warn("mcp resource watcher unavailable", "error", err)
+			return
+		}
+		if err := fw.WatchFile(w.path); err != nil {
+			slog.Warn("mcp file not watched", "uri", w.uri, "path", w.path, "error", err)
+			_ = fw.Close()
+			continue
+		}
+		if w.invalidate {
+			invalidators++
+		}
+		go func(fw FileWatcher, uri string, invalidate bool) {
+			defer fw.Close()
+			for range fw.Events(ctx) {
+				if invalidate {
+					s.session.invalidate()
+				}
+				if uri == "" {
+					continue
+				}
+				if err := s.subs.notify(uri); err != nil {
+					slog.Warn("mcp resource update notification failed", "uri", uri, "error", err)
+				}
+			}
+		}(fw, w.uri, w.invalidate)
+	}
+	if invalidators == 2 {
+		s.session.arm()
+	}
+}
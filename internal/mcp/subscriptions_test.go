@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/mcp-go/protocol"
+	"github.com/felixgeelhaar/mcp-go/transport"
+)
+
+type fakeFileWatcher struct {
+	path   string
+	events chan struct{}
+}
+
+func (f *fakeFileWatcher) WatchFile(path string) error { f.path = path; return nil }
+func (f *fakeFileWatcher) Events(ctx context.Context) <-chan struct{} {
+	out := make(chan struct{})
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-f.events:
+				out <- struct{}{}
+			}
+		}
+	}()
+	return out
+}
+func (f *fakeFileWatcher) Close() error { return nil }
+
+type recordingSender struct {
+	mu   sync.Mutex
+	uris chan string
+}
+
+func (r *recordingSender) SendNotification(method string, params any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, _ := json.Marshal(params)
+	var n struct{ URI string }
+	_ = json.Unmarshal(data, &n)
+	r.uris <- method + " " + n.URI
+	return nil
+}
+
+func subscribeRequest(method, uri string) *protocol.Request {
+	params, _ := json.Marshal(map[string]string{"uri": uri})
+	return &protocol.Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: params}
+}
+
+func TestSubscriptionMiddleware(t *testing.T) {
+	server := New(&mockService{}, DefaultConfig(), "test")
+	handler := server.subscriptionMiddleware()(func(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+		return protocol.NewResponse(req.ID, map[string]any{
+			"capabilities": map[string]any{"resources": map[string]any{"listChanged": true}},
+		}), nil
+	})
+	sender := &recordingSender{uris: make(chan string, 1)}
+	ctx := transport.ContextWithNotificationSender(context.Background(), sender)
+
+	resp, err := handler(ctx, &protocol.Request{Method: protocol.MethodInitialize})
+	if err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	resources := resp.Result.(map[string]any)["capabilities"].(map[string]any)["resources"].(map[string]any)
+	if resources["subscribe"] != true || resources["listChanged"] != true {
+		t.Fatalf("expected subscribe capability, got %v", resources)
+	}
+
+	if _, err := handler(ctx, subscribeRequest(protocol.MethodResourcesSubscribe, "coverctl://config")); err == nil {
+		t.Fatal("expected error subscribing to an unwatched resource")
+	}
+	if _, err := handler(ctx, subscribeRequest(protocol.MethodResourcesSubscribe, uriDebt)); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := server.subs.notify(uriTrend); err != nil || len(sender.uris) != 0 {
+		t.Fatalf("unsubscribed resource must not notify (err=%v)", err)
+	}
+	if err := server.subs.notify(uriDebt); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if got := <-sender.uris; got != protocol.MethodResourceUpdated+" "+uriDebt {
+		t.Fatalf("unexpected notification %q", got)
+	}
+
+	if _, err := handler(ctx, subscribeRequest(protocol.MethodResourcesUnsubscribe, uriDebt)); err != nil {
+		t.Fatalf("unsubscribe: %v", err)
+	}
+	if err := server.subs.notify(uriDebt); err != nil || len(sender.uris) != 0 {
+		t.Fatalf("unsubscribed resource must not notify (err=%v)", err)
+	}
+}
+
+func TestWatchResourcesNotifiesOnFileChange(t *testing.T) {
+	server := New(&mockService{}, DefaultConfig(), "test")
+	watchers := map[int]*fakeFileWatcher{}
+	server.newWatcher = func() (FileWatcher, error) {
+		w := &fakeFileWatcher{events: make(chan struct{})}
+		watchers[len(watchers)] = w
+		return w, nil
+	}
+	sender := &recordingSender{uris: make(chan string, 1)}
+	server.subs.subscribe(uriTrend, sender)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server.watchResources(ctx)
+
+	if len(watchers) != 3 || watchers[0].path != ".cover/coverage.out" || watchers[1].path != ".cover/history.json" || watchers[2].path != server.config.ConfigPath {
+		t.Fatalf("unexpected watched files: %+v", watchers)
+	}
+	watchers[1].events <- struct{}{}
+	select {
+	case got := <-sender.uris:
+		if got != protocol.MethodResourceUpdated+" "+uriTrend {
+			t.Fatalf("unexpected notification %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for trend notification")
+	}
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/config"
+	"github.com/felixgeelhaar/coverctl/internal/pathutil"
+)
+
+// handleConfigure handles the `configure` tool: apply structured edits to
+// the config file and return the resulting diff, so agents never have to
+// regenerate the whole file to change one threshold.
+func (s *Server) handleConfigure(ctx context.Context, input ConfigureInput) (map[string]any, error) {
+	defer traceTool("configure")()
+	paths := []namedPath{{"configPath", input.ConfigPath}}
+	for i, c := range input.Changes {
+		for j, m := range c.Match {
+			paths = append(paths, namedPath{fmt.Sprintf("changes[%d].match[%d]", i, j), m})
+		}
+		paths = append(paths, namedPath{fmt.Sprintf("changes[%d].pattern", i), c.Pattern})
+	}
+	if err := validateScopedInputs(paths...); err != nil {
+		return rejectionResponse(err), nil
+	}
+	if len(input.Changes) == 0 {
+		return errorResponse(
+			OpCodeMissingArg,
+			"No changes given",
+			errors.New("changes is required"),
+			"Pass at least one change, e.g. {\"op\": \"set_default_min\", \"min\": 80}.",
+		), nil
+	}
+
+	configPath := s.resolveConfigPath(input.ConfigPath)
+	cleanPath, err := pathutil.ValidatePath(configPath)
+	if err != nil {
+		return errorResponse(
+			OpCodeInvalidPath,
+			"Invalid config path",
+			fmt.Errorf("invalid config path: %v", err),
+			"Use a path inside the current working directory. Out-of-tree paths are rejected.",
+		), nil
+	}
+	info, err := os.Stat(cleanPath)
+	if err != nil {
+		return errorResponse(
+			OpCodeConfigMissing,
+			"Config file not found",
+			err,
+			"Create the config first: call init (CI mode) or run `coverctl init`, then retry configure.",
+		), nil
+	}
+	before, err := os.ReadFile(cleanPath) // #nosec G304 - path is validated above
+	if err != nil {
+		return errorResponse(OpCodeInternalError, "Failed to read config file", err, "Check read permissions on the config file."), nil
+	}
+
+	changes := make([]config.Change, 0, len(input.Changes))
+	for _, c := range input.Changes {
+		changes = append(changes, config.Change{
+			Op:      c.Op,
+			Domain:  c.Domain,
+			Match:   c.Match,
+			Min:     c.Min,
+			Pattern: c.Pattern,
+		})
+	}
+	after, err := config.Edit(before, changes)
+	if err != nil {
+		return errorResponse(
+			OpCodeInvalidChange,
+			"Config change rejected; nothing was written",
+			err,
+			"Fix the change named in the error. Ops: set_default_min (min), set_domain_min (domain, min), add_domain (domain, match, optional min), remove_domain (domain), add_exclude (pattern, optional domain). Thresholds must be between 0 and 100.",
+		), nil
+	}
+
+	diff := config.Diff(configPath, before, after)
+	output := map[string]any{
+		"passed":     true,
+		"configPath": configPath,
+		"diff":       diff,
+		"dryRun":     input.DryRun,
+	}
+	switch {
+	case diff == "":
+		output["summary"] = "Config already matches the requested changes"
+		return output, nil
+	case input.DryRun:
+		output["summary"] = fmt.Sprintf("Dry run: %d changes would modify %s", len(changes), configPath)
+		return output, nil
+	}
+
+	backupPath, err := backupConfig(cleanPath)
+	if err != nil {
+		return errorResponse(OpCodeFileWrite, "Failed to backup existing config", err, "Check write permissions next to the config file."), nil
+	}
+	if err := os.WriteFile(cleanPath, after, info.Mode().Perm()); err != nil {
+		return errorResponse(OpCodeFileWrite, "Failed to write config file", err, "Check disk space and write permissions on the config file."), nil
+	}
+	s.session.invalidate()
+	output["backupPath"] = backupPath
+	output["summary"] = fmt.Sprintf("Applied %d changes to %s", len(changes), configPath)
+	return output, nil
+}
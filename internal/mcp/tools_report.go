@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// handleReport handles the `report` tool: evaluate policy against an
+// existing coverage profile without running tests.
+func (s *Server) handleReport(ctx context.Context, input ReportInput) (map[string]any, error) {
+	defer traceTool("report")()
+	if err := validateScopedInputs(
+		namedPath{"configPath", input.ConfigPath},
+		namedPath{"profile", input.Profile},
+	); err != nil {
+		return rejectionResponse(err), nil
+	}
+
+	opts := application.ReportOptions{
+		ConfigPath:    s.resolveConfigPath(input.ConfigPath),
+		Profile:       coalesce(input.Profile, s.config.ProfilePath),
+		Output:        application.OutputJSON,
+		Domains:       input.Domains,
+		ShowUncovered: input.ShowUncovered,
+		DiffRef:       input.DiffRef,
+	}
+
+	result, err := s.reportResult(ctx, opts)
+
+	if classified, ok := classifyRuntimeError(err); ok {
+		return classified, nil
+	}
+
+	v := resolveVerbosity(input.Verbosity)
+	domains, domainCursor := applyDomainBudget(result.Domains, v)
+	files, fileCursor := applyFileBudget(result.Files, v)
+	output := map[string]any{
+		"schema":   application.JSONSchema,
+		"passed":   result.Passed,
+		"summary":  sanitizeOutputString(generateSummary(result)),
+		"domains":  nonNil(sanitizeDomainResults(domains)),
+		"files":    nonNil(sanitizeFileResults(files)),
+		"warnings": nonNil(sanitizeWarnings(result.Warnings)),
+	}
+	if len(result.EmptyDomains) > 0 {
+		output["emptyDomains"] = sanitizeWarnings(result.EmptyDomains)
+	}
+	if domainCursor != "" {
+		output["domainsNextCursor"] = domainCursor
+	}
+	if fileCursor != "" {
+		output["filesNextCursor"] = fileCursor
+	}
+
+	if err != nil {
+		output["passed"] = false
+		output["error"] = sanitizeOutputString(err.Error())
+	}
+
+	return output, nil
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+)
+
+// handleUncovered handles the `uncovered` tool: list the files an agent
+// should write tests for, most uncovered statements first.
+func (s *Server) handleUncovered(ctx context.Context, input UncoveredInput) (map[string]any, error) {
+	defer traceTool("uncovered")()
+	if err := validateScopedInputs(
+		namedPath{"configPath", input.ConfigPath},
+		namedPath{"profile", input.Profile},
+	); err != nil {
+		return rejectionResponse(err), nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = normalRowCap
+	}
+	result, err := s.uncovered(ctx, application.UncoveredOptions{
+		ConfigPath:  s.resolveConfigPath(input.ConfigPath),
+		ProfilePath: coalesce(input.Profile, s.config.ProfilePath),
+		Domains:     input.Domains,
+		Pattern:     input.Pattern,
+		Limit:       limit,
+	})
+
+	if classified, ok := classifyRuntimeError(err); ok {
+		return classified, nil
+	}
+	if err != nil {
+		return map[string]any{
+			"passed":  false,
+			"error":   sanitizeOutputString(err.Error()),
+			"summary": "Failed to list uncovered files",
+		}, nil
+	}
+
+	output := map[string]any{
+		"passed":     true,
+		"files":      sanitizeUncoveredFiles(result.Files),
+		"total":      result.Total,
+		"lineRanges": result.LineRanges,
+	}
+	if cursor := cursorFor(len(result.Files), result.Total); cursor != "" {
+		output["nextCursor"] = cursor
+	}
+	if result.Total == 0 {
+		output["summary"] = "No uncovered statements in the selected files"
+	} else {
+		output["summary"] = fmt.Sprintf("%d files with uncovered statements; showing the top %d", result.Total, len(result.Files))
+	}
+	return output, nil
+}
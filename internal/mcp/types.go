@@ -29,6 +29,7 @@ type Service interface {
 
 	// Query tools (read-only but exposed as tools for better discoverability)
 	Debt(ctx context.Context, opts application.DebtOptions) (application.DebtResult, error)
+	Uncovered(ctx context.Context, opts application.UncoveredOptions) (application.UncoveredResult, error)
 	Trend(ctx context.Context, opts application.TrendOptions, store application.HistoryStore) (application.TrendResult, error)
 	Suggest(ctx context.Context, opts application.SuggestOptions) (application.SuggestResult, error)
 	Badge(ctx context.Context, opts application.BadgeOptions) (application.BadgeResult, error)
@@ -41,13 +42,13 @@ type Service interface {
 // # Why mode-aware exposure
 //
 // AI coding agents reliably select among a small (≤5–7) tool surface but
-// degrade as the surface grows. coverctl exposes nine tools by default;
-// only three are useful inside the agent edit loop (check, suggest, debt).
-// The other six (init, report, record, badge, compare, pr-comment) belong
-// to setup or CI/automation contexts where agents do not benefit from
-// seeing them.
+// degrade as the surface grows. coverctl exposes eleven tools by default;
+// only five are useful inside the agent edit loop (check, suggest, debt,
+// uncovered, configure). The other six (init, report, record, badge, compare,
+// pr-comment) belong to setup or CI/automation contexts where agents do
+// not benefit from seeing them.
 //
-// ModeAgent advertises only the three agent-loop tools. ModeCI advertises
+// ModeAgent advertises only the five agent-loop tools. ModeCI advertises
 // the full set. The default is ModeAgent so agent-side adoption is the
 // happy path; CI/automation jobs opt into the wider surface explicitly.
 type Mode string
@@ -57,13 +58,14 @@ const (
 	ModeCI    Mode = "ci"
 )
 
-// The canonical agent-mode tool set is check, suggest, debt — wired
-// directly in registerTools rather than indexed via a separate map. Why
-// each tool earns its place:
+// The canonical agent-mode tool set is check, suggest, debt, uncovered —
+// wired directly in registerTools rather than indexed via a separate map.
+// Why each tool earns its place:
 //
 //   - check: the wedge metric — coverage feedback in the edit loop.
 //   - suggest: actionable threshold guidance derived from current coverage.
 //   - debt: ranked list of smallest tests to add, agent-actionable.
+//   - uncovered: the concrete files (and line ranges) to write tests for.
 //
 // init/report/record/badge/compare/pr-comment are setup, dashboarding, or
 // CI-side concerns; they are intentionally absent from agent mode and
@@ -167,7 +169,7 @@ func coalesce(value, fallback string) string {
 type SuggestInput struct {
 	ConfigPath  string `json:"configPath,omitempty" jsonschema:"description=Path to .coverctl.yaml config file"`
 	Profile     string `json:"profile,omitempty" jsonschema:"description=Path to coverage profile"`
-	Strategy    string `json:"strategy,omitempty" jsonschema:"description=Suggestion strategy: current (default)|aggressive|conservative"`
+	Strategy    string `json:"strategy,omitempty" jsonschema:"description=Suggestion strategy: current (default)|aggressive|conservative|history"`
 	WriteConfig bool   `json:"writeConfig,omitempty" jsonschema:"description=Write suggested thresholds to config file (creates backup if file exists)"`
 }
 
@@ -178,6 +180,31 @@ type DebtInput struct {
 	Verbosity  string `json:"verbosity,omitempty" jsonschema:"description=Output detail: 'brief' | 'normal' (default) | 'verbose'"`
 }
 
+// UncoveredInput defines the input parameters for the uncovered tool.
+type UncoveredInput struct {
+	ConfigPath string   `json:"configPath,omitempty" jsonschema:"description=Path to .coverctl.yaml config file"`
+	Profile    string   `json:"profile,omitempty" jsonschema:"description=Path to coverage profile"`
+	Domains    []string `json:"domains,omitempty" jsonschema:"description=Only list files in these domains"`
+	Pattern    string   `json:"pattern,omitempty" jsonschema:"description=Glob over module-relative file paths, e.g. internal/core/*.go"`
+	Limit      int      `json:"limit,omitempty" jsonschema:"description=Maximum files to return (default 20)"`
+}
+
+// ConfigureInput defines the input parameters for the configure tool.
+type ConfigureInput struct {
+	ConfigPath string         `json:"configPath,omitempty" jsonschema:"description=Path to .coverctl.yaml config file"`
+	Changes    []ConfigChange `json:"changes" jsonschema:"description=Edits to apply in order; all succeed or none are written"`
+	DryRun     bool           `json:"dryRun,omitempty" jsonschema:"description=Return the diff without writing the file"`
+}
+
+// ConfigChange is one structured edit applied by the configure tool.
+type ConfigChange struct {
+	Op      string   `json:"op" jsonschema:"description=set_default_min | set_domain_min | add_domain | remove_domain | add_exclude"`
+	Domain  string   `json:"domain,omitempty" jsonschema:"description=Domain name (set_domain_min, add_domain, remove_domain; add_exclude scopes the pattern to this domain)"`
+	Match   []string `json:"match,omitempty" jsonschema:"description=Package or path patterns for add_domain, e.g. ./internal/core/..."`
+	Min     *float64 `json:"min,omitempty" jsonschema:"description=Threshold 0-100 for set_default_min and set_domain_min, optional for add_domain"`
+	Pattern string   `json:"pattern,omitempty" jsonschema:"description=Exclude glob for add_exclude, e.g. internal/generated/*"`
+}
+
 // BadgeInput defines the input parameters for the badge tool.
 type BadgeInput struct {
 	ConfigPath string `json:"configPath,omitempty" jsonschema:"description=Path to .coverctl.yaml config file"`
@@ -214,21 +241,14 @@ func generateSummary(result domain.Result) string {
 		return "No domains found"
 	}
 
-	var totalCovered, totalStatements int
 	var passing int
-
 	for _, d := range result.Domains {
-		totalCovered += d.Covered
-		totalStatements += d.Total
 		if d.Status == domain.StatusPass {
 			passing++
 		}
 	}
 
-	overallPercent := 0.0
-	if totalStatements > 0 {
-		overallPercent = float64(totalCovered) / float64(totalStatements) * 100
-	}
+	overallPercent := result.OverallPercent()
 
 	total := len(result.Domains)
 	if result.Passed {
@@ -0,0 +1,15 @@
+package pathutil
+
+import "strings"
+
+// SafeFileName keeps the characters of name that are safe in a file name
+// (ASCII letters, digits, '-', and '_') and replaces the rest with '-', so
+// a domain or build variant name can name the profile written for it.
+func SafeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '-'
+	}, name)
+}
@@ -0,0 +1,18 @@
+package pathutil
+
+import "testing"
+
+func TestSafeFileName(t *testing.T) {
+	tests := map[string]string{
+		"core":         "core",
+		"windows/cgo":  "windows-cgo",
+		"api v2.1":     "api-v2-1",
+		"../../etc":    "------etc",
+		"Mixed_Case-1": "Mixed_Case-1",
+	}
+	for name, want := range tests {
+		if got := SafeFileName(name); got != want {
+			t.Errorf("SafeFileName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package perf_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers"
+	"github.com/felixgeelhaar/coverctl/internal/perf"
+)
+
+var (
+	benchFiles  = flag.Int("perf.files", perf.DefaultFiles, "files in the benchmark fixture")
+	benchBlocks = flag.Int("perf.blocks", perf.DefaultBlocks, "blocks in the benchmark fixture")
+)
+
+func TestGenerate(t *testing.T) {
+	opts := perf.Options{Files: 10, Blocks: 25, Domains: 3, Seed: 7}
+	var first, second bytes.Buffer
+	if err := perf.Generate(&first, opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := perf.Generate(&second, opts); err != nil {
+		t.Fatal(err)
+	}
+	if first.String() != second.String() {
+		t.Fatal("expected the same seed to generate the same profile")
+	}
+	lines := strings.Split(strings.TrimSpace(first.String()), "\n")
+	if lines[0] != "mode: atomic" || len(lines) != 26 {
+		t.Fatalf("expected a header and 25 blocks, got %d lines starting %q", len(lines), lines[0])
+	}
+
+	path := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(path, first.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := parsers.NewRegistry().ParseAll([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := perf.Stats(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 10 || len(stats) != 10 {
+		t.Fatalf("expected 10 files, parsed %d and computed %d", len(parsed), len(stats))
+	}
+	for file, stat := range stats {
+		if parsed[file] != stat {
+			t.Fatalf("%s: parsed %+v, computed %+v", file, parsed[file], stat)
+		}
+	}
+
+	if err := perf.Generate(&first, perf.Options{Files: 10, Blocks: 5}); err == nil {
+		t.Fatal("expected fewer blocks than files to fail")
+	}
+}
+
+func benchOptions() perf.Options {
+	return perf.Options{Files: *benchFiles, Blocks: *benchBlocks}
+}
+
+var fixture struct {
+	once sync.Once
+	dir  string
+	path string
+	err  error
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if fixture.dir != "" {
+		_ = os.RemoveAll(fixture.dir)
+	}
+	os.Exit(code)
+}
+
+// fixtureProfile writes the benchmark profile once per test binary.
+func fixtureProfile(b *testing.B) string {
+	b.Helper()
+	fixture.once.Do(func() {
+		fixture.dir, fixture.err = os.MkdirTemp("", "coverctl-perf-")
+		if fixture.err != nil {
+			return
+		}
+		fixture.path = filepath.Join(fixture.dir, "coverage.out")
+		f, err := os.Create(fixture.path)
+		if err != nil {
+			fixture.err = err
+			return
+		}
+		defer f.Close()
+		fixture.err = perf.Generate(f, benchOptions())
+	})
+	if fixture.err != nil {
+		b.Fatal(fixture.err)
+	}
+	return fixture.path
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	path := fixtureProfile(b)
+	registry := parsers.NewRegistry()
+	for b.Loop() {
+		if _, err := registry.ParseAll([]string{path}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAggregateByDomain(b *testing.B) {
+	opts := benchOptions()
+	stats, err := perf.Stats(opts)
+	if err != nil {
+		b.Fatal(err)
+	}
+	const moduleRoot = "/src/fixture"
+	dirs := opts.DomainDirs(moduleRoot)
+	for b.Loop() {
+		application.AggregateByDomain(stats, dirs, nil, moduleRoot, perf.DefaultModulePath, nil)
+	}
+}
@@ -0,0 +1,158 @@
+// Package coverctl is the public Go API to coverctl's coverage policy
+// engine. It loads .coverctl.yaml files, parses coverage profiles in any
+// supported format, aggregates them into domains, and evaluates the
+// policy, producing the same results as `coverctl report`.
+//
+//	result, err := coverctl.Analyze(ctx, coverctl.Options{Profile: "coverage.out"})
+//	if err != nil {
+//		return err
+//	}
+//	for _, d := range result.Domains {
+//		fmt.Println(d.Domain, d.Percent, d.Status)
+//	}
+//
+// Everything exported here follows semantic versioning: it is not removed
+// or changed incompatibly within a major version. The types are aliases of
+// coverctl's internal ones; only the fields documented on them are part of
+// that promise.
+//
+// Relative paths and domain match patterns resolve from the current
+// working directory, as they do for the CLI.
+package coverctl
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/felixgeelhaar/coverctl/internal/application"
+	"github.com/felixgeelhaar/coverctl/internal/domain"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/annotations"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/autodetect"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/config"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/diff"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/gotool"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/parsers"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/resolver"
+	"github.com/felixgeelhaar/coverctl/internal/infrastructure/runners"
+)
+
+// Config is a loaded .coverctl.yaml.
+type Config = application.Config
+
+// Policy is the set of domains and thresholds a result is evaluated against.
+type Policy = domain.Policy
+
+// Domain is a named group of packages or directories with its own threshold.
+type Domain = domain.Domain
+
+// CoverageStat counts covered and total statements.
+type CoverageStat = domain.CoverageStat
+
+// Result is the outcome of evaluating a policy.
+type Result = domain.Result
+
+// DomainResult is one domain's coverage and status within a Result.
+type DomainResult = domain.DomainResult
+
+// FileResult is one file rule's coverage and status within a Result.
+type FileResult = domain.FileResult
+
+// Status is PASS, FAIL, or WARN.
+type Status = domain.Status
+
+const (
+	StatusPass = domain.StatusPass
+	StatusFail = domain.StatusFail
+	StatusWarn = domain.StatusWarn
+)
+
+// DefaultProfile is the profile path used when Options.Profile is empty.
+const DefaultProfile = ".cover/coverage.out"
+
+// Options selects what Analyze and Aggregate read.
+type Options struct {
+	// ConfigPath is the config file. When empty or missing, domains are
+	// auto-detected as `coverctl report` does without a config.
+	ConfigPath string
+	// Profile is the coverage profile (Go, LCOV, Cobertura, or JaCoCo).
+	// Defaults to DefaultProfile.
+	Profile string
+	// MergeProfiles are further profiles merged with Profile, in addition
+	// to any listed under merge.profiles in the config.
+	MergeProfiles []string
+	// Domains restricts the analysis to these domains. Empty means all.
+	Domains []string
+}
+
+func (o Options) profile() string {
+	if o.Profile == "" {
+		return DefaultProfile
+	}
+	return o.Profile
+}
+
+// FindConfig walks up from dir (the working directory when empty) to the
+// nearest .coverctl.yaml and returns its path.
+func FindConfig(dir string) (string, error) {
+	return config.FindConfigFrom(dir)
+}
+
+// LoadConfig reads and validates the config at path, following extends.
+func LoadConfig(path string) (Config, error) {
+	return config.Loader{}.Load(path)
+}
+
+// ParseProfiles parses and merges coverage profiles, detecting each one's
+// format. Files are keyed as they appear in the profile.
+func ParseProfiles(paths ...string) (map[string]CoverageStat, error) {
+	return parsers.NewRegistry().ParseAll(paths)
+}
+
+// Evaluate checks per-domain coverage against policy. It is pure: coverage
+// must already be aggregated by domain name, e.g. by Aggregate.
+func Evaluate(policy Policy, coverage map[string]CoverageStat) Result {
+	return domain.Evaluate(policy, coverage)
+}
+
+// Aggregate parses the profiles and sums their coverage into the config's
+// domains, honouring excludes, path mappings, and ignore annotations.
+func Aggregate(ctx context.Context, opts Options) (map[string]CoverageStat, error) {
+	return newService().Aggregate(ctx, application.AggregateOptions{
+		ConfigPath:    opts.ConfigPath,
+		ProfilePath:   opts.profile(),
+		MergeProfiles: opts.MergeProfiles,
+		Domains:       opts.Domains,
+	})
+}
+
+// Analyze runs the whole pipeline, load, parse, aggregate, and evaluate,
+// and returns the result `coverctl report` would print. A policy failure
+// is reported through Result.Passed, not as an error.
+func Analyze(ctx context.Context, opts Options) (Result, error) {
+	return newService().ReportResult(ctx, application.ReportOptions{
+		ConfigPath:    opts.ConfigPath,
+		Profile:       opts.profile(),
+		Output:        application.OutputJSON,
+		Domains:       opts.Domains,
+		MergeProfiles: opts.MergeProfiles,
+	})
+}
+
+// newService wires the analysis adapters the CLI uses, without runners'
+// test execution, caching, telemetry, or output.
+func newService() *application.Service {
+	module := gotool.NewCachedModuleResolver()
+	registry := runners.NewRegistry(module)
+	projectDir, _ := os.Getwd()
+	return &application.Service{
+		ConfigLoader:      config.Loader{},
+		Autodetector:      autodetect.Detector{Module: module, Registry: registry},
+		DomainResolver:    resolver.NewMultiResolver(gotool.DomainResolver{Module: module}, projectDir, registry),
+		RunnerRegistry:    registry,
+		ProfileParser:     parsers.NewRegistry(),
+		DiffProvider:      diff.GitDiff{Module: module},
+		AnnotationScanner: annotations.Scanner{},
+		Out:               io.Discard,
+	}
+}
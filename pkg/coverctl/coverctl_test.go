@@ -0,0 +1,113 @@
+package coverctl_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/coverctl/pkg/coverctl"
+)
+
+// writeModule lays out a small Go module with two domains and a profile
+// covering core fully and api not at all, then makes it the working
+// directory.
+func writeModule(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":    "module example.com/lib\n\ngo 1.21\n",
+		"core/a.go": "package core\n\nfunc A() int {\n\treturn 1\n}\n",
+		"api/b.go":  "package api\n\nfunc B() int {\n\treturn 2\n}\n",
+		".coverctl.yaml": `version: 1
+policy:
+  default:
+    min: 50
+  domains:
+    - name: core
+      match: ["./core/..."]
+    - name: api
+      match: ["./api/..."]
+`,
+		"coverage.out": "mode: set\n" +
+			"example.com/lib/core/a.go:3.14,5.2 4 1\n" +
+			"example.com/lib/api/b.go:3.14,5.2 2 0\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+}
+
+func TestLoadConfigAndParseProfiles(t *testing.T) {
+	writeModule(t)
+
+	path, err := coverctl.FindConfig("")
+	if err != nil {
+		t.Fatalf("find config: %v", err)
+	}
+	cfg, err := coverctl.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if len(cfg.Policy.Domains) != 2 || cfg.Policy.DefaultMin != 50 {
+		t.Fatalf("unexpected policy: %+v", cfg.Policy)
+	}
+
+	stats, err := coverctl.ParseProfiles("coverage.out")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := stats["example.com/lib/core/a.go"]; got.Covered != 4 || got.Total != 4 {
+		t.Fatalf("unexpected core stats: %+v", stats)
+	}
+
+	result := coverctl.Evaluate(cfg.Policy, map[string]coverctl.CoverageStat{
+		"core": {Covered: 4, Total: 4},
+		"api":  {Covered: 0, Total: 2},
+	})
+	if result.Passed {
+		t.Fatal("expected api to fail the policy")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	writeModule(t)
+	ctx := context.Background()
+	opts := coverctl.Options{ConfigPath: ".coverctl.yaml", Profile: "coverage.out"}
+
+	byDomain, err := coverctl.Aggregate(ctx, opts)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if byDomain["core"].Total != 4 || byDomain["api"].Total != 2 {
+		t.Fatalf("unexpected aggregation: %+v", byDomain)
+	}
+
+	result, err := coverctl.Analyze(ctx, opts)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+	if result.Passed {
+		t.Fatal("expected policy failure")
+	}
+	status := map[string]coverctl.Status{}
+	for _, d := range result.Domains {
+		status[d.Domain] = d.Status
+	}
+	if status["core"] != coverctl.StatusPass || status["api"] != coverctl.StatusFail {
+		t.Fatalf("unexpected domain statuses: %+v", result.Domains)
+	}
+
+	opts.Domains = []string{"core"}
+	result, err = coverctl.Analyze(ctx, opts)
+	if err != nil || !result.Passed || len(result.Domains) != 1 {
+		t.Fatalf("expected core-only analysis to pass: %+v, %v", result, err)
+	}
+}